@@ -2,15 +2,24 @@ package v1alpha2
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // JenkinsSpec defines the desired state of Jenkins
 // +k8s:openapi-gen=true
 type JenkinsSpec struct {
+	// From is the reference to the cluster-scoped JenkinsTemplate, the template spec is merged with this spec
+	// before the reconciliation and values set here override the template
+	// +optional
+	From *JenkinsTemplateRef `json:"from,omitempty"`
+
 	// Master represents Jenkins master pod properties and Jenkins plugins.
 	// Every single change here requires a pod restart.
+	// It's optional when it's set by the template referenced by spec.from.
+	// +optional
 	Master JenkinsMaster `json:"master"`
 
 	// SeedJobs defines list of Jenkins Seed Job configurations
@@ -68,7 +77,892 @@ type JenkinsSpec struct {
 	ServiceAccount ServiceAccount `json:"serviceAccount,omitempty"`
 
 	// JenkinsAPISettings defines configuration used by the operator to gain admin access to the Jenkins API
+	// Defaults to createUser authorization strategy.
+	// +optional
 	JenkinsAPISettings JenkinsAPISettings `json:"jenkinsAPISettings"`
+
+	// Proxy defines HTTP(S) proxy used by Jenkins master, plugin downloads, backup containers and agents
+	// +optional
+	Proxy *Proxy `json:"proxy,omitempty"`
+
+	// TrustedCABundle defines ConfigMap with PEM encoded CA certificates trusted by Jenkins master,
+	// plugin downloads and backup containers in addition to the default ones
+	// +optional
+	TrustedCABundle *TrustedCABundle `json:"trustedCABundle,omitempty"`
+
+	// BuildLogArchival defines uploading of finished build logs to external storage by the sidecar container
+	// +optional
+	BuildLogArchival *BuildLogArchival `json:"buildLogArchival,omitempty"`
+
+	// PluginUpdates defines periodic checks of plugin updates in the update center and automatic plugin upgrades
+	// +optional
+	PluginUpdates *PluginUpdates `json:"pluginUpdates,omitempty"`
+
+	// Plugins defines restrictions of plugins installed in Jenkins
+	// +optional
+	Plugins *Plugins `json:"plugins,omitempty"`
+
+	// UpdateCenterMirror defines the update center generated by the operator with only approved plugins,
+	// it replaces the update sites of Jenkins so the plugin manager can install only these plugins
+	// +optional
+	UpdateCenterMirror *UpdateCenterMirror `json:"updateCenterMirror,omitempty"`
+
+	// Tools defines global tool installations (JDK, Maven, NodeJS) configured by the operator with Configuration as Code,
+	// the plugins required by the tool installers are added to spec.master.plugins
+	// +optional
+	Tools *Tools `json:"tools,omitempty"`
+
+	// SharedLibraries defines global pipeline libraries configured by the operator with Configuration as Code,
+	// the global libraries not listed here are removed from Jenkins
+	// +optional
+	SharedLibraries []SharedLibrary `json:"sharedLibraries,omitempty"`
+
+	// Folders defines initial folder structure created by the operator, existing folders and their items are kept
+	// +optional
+	Folders []Folder `json:"folders,omitempty"`
+
+	// Views defines list views created by the operator
+	// +optional
+	Views []View `json:"views,omitempty"`
+
+	// Persistence defines PersistentVolumeClaim mounted as Jenkins home, the emptyDir volume is used when it's not set
+	// +optional
+	Persistence *Persistence `json:"persistence,omitempty"`
+
+	// DiskUsage defines monitoring of Jenkins home disk usage and cleanup policies run when the usage is high
+	// +optional
+	DiskUsage *DiskUsage `json:"diskUsage,omitempty"`
+
+	// ResourceUsage defines reporting of CPU and memory requests and usage of Jenkins master and agent pods,
+	// e.g. for chargeback of CI workloads to teams
+	// +optional
+	ResourceUsage *ResourceUsage `json:"resourceUsage,omitempty"`
+
+	// Agents defines pod templates of Jenkins agents added to the kubernetes cloud
+	// +optional
+	Agents *Agents `json:"agents,omitempty"`
+
+	// Monitoring defines metrics of Jenkins exported by the operator
+	// +optional
+	Monitoring *Monitoring `json:"monitoring,omitempty"`
+
+	// Canary defines verification of Configuration as Code and groovy script changes in a temporary Jenkins pod
+	// before they're applied to Jenkins
+	// +optional
+	Canary *Canary `json:"canary,omitempty"`
+
+	// OpenShift defines the configuration specific to OpenShift, e.g. SecurityContextConstraints granted to Jenkins
+	// and the Route exposing Jenkins
+	// +optional
+	OpenShift *OpenShift `json:"openShift,omitempty"`
+
+	// SecurityProfile is the Pod Security Standard which security contexts of Jenkins master, its sidecars, e.g. backup
+	// containers, and agent pods rendered by the operator comply with
+	// restricted - non-root user, RuntimeDefault seccomp profile, read-only root filesystem and all capabilities dropped
+	// baseline - RuntimeDefault seccomp profile, privileged containers and host paths aren't allowed
+	// custom - security contexts are used as they're set in Jenkins CR
+	// Defaults to custom.
+	// +kubebuilder:validation:Enum=restricted;baseline;custom
+	// +optional
+	SecurityProfile SecurityProfile `json:"securityProfile,omitempty"`
+
+	// InboundAgents defines how inbound agents connect to Jenkins master, over the TCP agent listener exposed
+	// by spec.slaveService or over WebSocket through the Jenkins HTTP endpoint
+	// +optional
+	InboundAgents *InboundAgents `json:"inboundAgents,omitempty"`
+
+	// ScriptApproval makes the operator approve pending script approvals of script-security plugin, e.g. Job DSL scripts
+	// of seed jobs, which match the listed signatures or script hashes, the other pending approvals are reported
+	// in status.pendingScriptApprovals. It enables script security of Job DSL scripts, which is disabled without it
+	// +optional
+	ScriptApproval *ScriptApproval `json:"scriptApproval,omitempty"`
+
+	// MaintenanceWindows are the recurring windows in which the operator runs disruptive actions: the recreation
+	// of Jenkins master pod after spec changes, the plugin changes and the migration of Jenkins home storage.
+	// Outside of the windows these actions are deferred and reported in status.deferredActions, the rest of
+	// the reconciliation continues. Disruptive actions run at any time if it's empty
+	// +optional
+	MaintenanceWindows []MaintenanceWindow `json:"maintenanceWindows,omitempty"`
+
+	// Audit enables the append-only audit trail of the operator actions in Jenkins: spec changes, plugin changes,
+	// applied groovy scripts, restarts, backups and restores
+	// +optional
+	Audit *Audit `json:"audit,omitempty"`
+}
+
+// ScriptApproval defines scripts and method signatures approved by the operator.
+type ScriptApproval struct {
+	// Signatures are method signatures approved for sandboxed scripts as they're shown in In-process Script Approval,
+	// e.g. "method java.lang.String trim" or "staticMethod java.lang.Math max int int"
+	// +optional
+	Signatures []string `json:"signatures,omitempty"`
+
+	// ScriptHashes are hashes of approved whole scripts computed by script-security plugin,
+	// e.g. "SHA512:1a2b..." or the SHA-1 hex of scripts pending with older versions of the plugin
+	// +optional
+	ScriptHashes []string `json:"scriptHashes,omitempty"`
+}
+
+// ScriptApprovalType is the type of pending script approval.
+type ScriptApprovalType string
+
+const (
+	// ScriptApprovalTypeScript is the approval of whole script
+	ScriptApprovalTypeScript ScriptApprovalType = "script"
+	// ScriptApprovalTypeSignature is the approval of method signature used by sandboxed script
+	ScriptApprovalTypeSignature ScriptApprovalType = "signature"
+)
+
+// PendingScriptApproval defines the script approval pending in Jenkins which isn't listed in spec.scriptApproval.
+type PendingScriptApproval struct {
+	// Type is the type of approval, script or signature
+	Type ScriptApprovalType `json:"type"`
+
+	// Value is the script hash or the signature which can be added to spec.scriptApproval
+	Value string `json:"value"`
+
+	// Language is the language of pending script, e.g. groovy
+	// +optional
+	Language string `json:"language,omitempty"`
+
+	// Dangerous is true when the signature is known to be dangerous by script-security plugin
+	// +optional
+	Dangerous bool `json:"dangerous,omitempty"`
+}
+
+// ScriptApprovalCheck defines the last check of pending script approvals in Jenkins.
+type ScriptApprovalCheck struct {
+	// Hash is the hash of spec.scriptApproval and seed jobs the check has been done for
+	Hash string `json:"hash"`
+
+	// LastCheckTime is the time of the last check
+	LastCheckTime metav1.Time `json:"lastCheckTime"`
+}
+
+// Audit defines where the audit records of the operator actions are kept.
+type Audit struct {
+	// MaxRecords is the number of the last records kept in the jenkins-operator-audit-<cr-name> ConfigMap,
+	// the oldest records are removed first
+	// Defaults to 500.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxRecords int32 `json:"maxRecords,omitempty"`
+
+	// Webhook sends every record to the HTTP endpoint, e.g. the log collector which keeps them in S3
+	// +optional
+	Webhook *AuditWebhook `json:"webhook,omitempty"`
+}
+
+// AuditWebhook defines the HTTP endpoint the audit records are sent to as JSON.
+type AuditWebhook struct {
+	// URLSecretKeySelector selects the URL of the endpoint from the secret, the records are POSTed to it
+	URLSecretKeySelector SecretKeySelector `json:"urlSecretKeySelector"`
+}
+
+// MaintenanceWindow defines the recurring time window in which disruptive actions are allowed.
+type MaintenanceWindow struct {
+	// Schedule is the standard cron expression of the window start, e.g. "0 2 * * 6" for every Saturday at 2 AM
+	Schedule string `json:"schedule"`
+
+	// Duration is the length of the window, e.g. 2h
+	Duration metav1.Duration `json:"duration"`
+
+	// TimeZone is the IANA time zone of the schedule, e.g. Europe/Warsaw
+	// Defaults to UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// DisruptiveAction is the type of action which runs only in the maintenance windows.
+type DisruptiveAction string
+
+const (
+	// DisruptiveActionPodRestart is the recreation of Jenkins master pod after the changes of its spec
+	DisruptiveActionPodRestart DisruptiveAction = "PodRestart"
+	// DisruptiveActionPluginChanges is the installation of plugin changes which restarts Jenkins
+	DisruptiveActionPluginChanges DisruptiveAction = "PluginChanges"
+	// DisruptiveActionStorageMigration is the migration of Jenkins home to the new storage class which stops Jenkins
+	DisruptiveActionStorageMigration DisruptiveAction = "StorageMigration"
+	// DisruptiveActionSeedJobRemoval is the recreation of Jenkins master pod which removes the deleted seed jobs
+	DisruptiveActionSeedJobRemoval DisruptiveAction = "SeedJobRemoval"
+)
+
+// DeferredAction defines the disruptive action waiting for the next maintenance window.
+type DeferredAction struct {
+	// Action is the type of deferred action
+	Action DisruptiveAction `json:"action"`
+
+	// Message describes why the action is needed
+	Message string `json:"message"`
+
+	// DeferredSince is the time when the action has been deferred for the first time
+	DeferredSince metav1.Time `json:"deferredSince"`
+
+	// NextWindow is the start of the next maintenance window
+	// +optional
+	NextWindow *metav1.Time `json:"nextWindow,omitempty"`
+}
+
+// InboundAgents defines the inbound agent listener of Jenkins master.
+type InboundAgents struct {
+	// TCPPort is the port of the TCP agent listener in Jenkins master container
+	// Defaults to 50000.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	TCPPort *int32 `json:"tcpPort,omitempty"`
+
+	// DisableTCP disables the TCP agent listener, the Jenkins slave service isn't created then and agents
+	// must connect over WebSocket
+	// +optional
+	DisableTCP bool `json:"disableTCP,omitempty"`
+
+	// WebSocket makes agents created by the kubernetes plugin and seed job agents connect over WebSocket through
+	// the Jenkins HTTP endpoint, e.g. an ingress. Other agents can still use the TCP agent listener.
+	// +optional
+	WebSocket bool `json:"webSocket,omitempty"`
+
+	// NetworkPolicy makes the operator create the NetworkPolicy allowing connections to Jenkins HTTP endpoint
+	// and the TCP agent listener only, the NetworkPolicy is deleted when it isn't set
+	// +optional
+	NetworkPolicy *InboundAgentsNetworkPolicy `json:"networkPolicy,omitempty"`
+}
+
+// InboundAgentsNetworkPolicy defines the NetworkPolicy of Jenkins master pod.
+type InboundAgentsNetworkPolicy struct {
+	// From are the peers allowed to connect to the TCP agent listener, Jenkins HTTP endpoint is allowed
+	// from everywhere, e.g. the ingress controller and agents connected over WebSocket.
+	// Defaults to the pods in the namespace of Jenkins.
+	// +optional
+	From []networkingv1.NetworkPolicyPeer `json:"from,omitempty"`
+}
+
+// SecurityProfile defines the Pod Security Standard of pods rendered by the operator.
+type SecurityProfile string
+
+const (
+	// RestrictedSecurityProfile complies with the restricted Pod Security Standard
+	RestrictedSecurityProfile SecurityProfile = "restricted"
+	// BaselineSecurityProfile complies with the baseline Pod Security Standard
+	BaselineSecurityProfile SecurityProfile = "baseline"
+	// CustomSecurityProfile uses security contexts from Jenkins CR
+	CustomSecurityProfile SecurityProfile = "custom"
+)
+
+// OpenShift defines the configuration of Jenkins running in OpenShift.
+type OpenShift struct {
+	// SecurityContextConstraints are names of SCCs granted to the Jenkins service account, Jenkins master pod
+	// and agent pods created by Jenkins can use them, e.g. nonroot or anyuid to run with spec.master.securityContext.runAsUser
+	// Jenkins pods run with an arbitrary UID assigned by the restricted SCC if not set.
+	// +optional
+	SecurityContextConstraints []string `json:"securityContextConstraints,omitempty"`
+
+	// Route defines the Route exposing Jenkins HTTP service
+	// +optional
+	Route Route `json:"route,omitempty"`
+}
+
+// Route defines the OpenShift Route exposing Jenkins.
+type Route struct {
+	// Disabled tells the operator not to create the Route, it's created by default when the Route API is available
+	// +optional
+	Disabled bool `json:"disabled,omitempty"`
+
+	// Host is the host name of the Route, OpenShift generates it if not set
+	// +optional
+	Host string `json:"host,omitempty"`
+
+	// Annotations are added to the Route, e.g. haproxy.router.openshift.io/timeout
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// Canary defines the temporary Jenkins pod which verifies configuration changes.
+type Canary struct {
+	// Enabled turns on the verification, Configuration as Code and groovy script changes are applied to Jenkins
+	// only when they have been applied to the canary Jenkins pod without errors
+	Enabled bool `json:"enabled"`
+
+	// StartupTimeout is the time in seconds for the canary Jenkins to become ready, defaults to 900
+	// +kubebuilder:validation:Minimum=60
+	// +optional
+	StartupTimeout uint64 `json:"startupTimeout,omitempty"`
+}
+
+// Monitoring defines the observability of Jenkins instance.
+type Monitoring struct {
+	// Enabled tells the operator to create PrometheusRule with alerts of Jenkins and ConfigMap with Grafana dashboard
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// RuleLabels are added to the PrometheusRule, e.g. to match ruleSelector of Prometheus
+	// +optional
+	RuleLabels map[string]string `json:"ruleLabels,omitempty"`
+
+	// DashboardLabels are added to the Grafana dashboard ConfigMap, e.g. to match the label of Grafana dashboards sidecar
+	// Defaults to grafana_dashboard: "1".
+	// +optional
+	DashboardLabels map[string]string `json:"dashboardLabels,omitempty"`
+
+	// Exporter scrapes queue, executor, node and build metrics from Jenkins API, the metrics are exposed
+	// by the operator metrics endpoint labelled with the Jenkins CR
+	// +optional
+	Exporter *MetricsExporter `json:"exporter,omitempty"`
+}
+
+// MetricsExporter defines how the operator scrapes metrics from Jenkins.
+type MetricsExporter struct {
+	// ScrapeInterval tells how often metrics are scraped from Jenkins in seconds
+	// Defaults to 60.
+	// +kubebuilder:validation:Minimum=10
+	// +optional
+	ScrapeInterval uint64 `json:"scrapeInterval,omitempty"`
+}
+
+// AgentOS defines the operating system of agent nodes.
+type AgentOS string
+
+const (
+	// LinuxAgentOS schedules agent pods on Linux nodes
+	LinuxAgentOS AgentOS = "linux"
+	// WindowsAgentOS schedules agent pods on Windows nodes
+	WindowsAgentOS AgentOS = "windows"
+)
+
+// Agents defines Jenkins agents run by the Kubernetes plugin.
+type Agents struct {
+	// PodTemplates are the pod templates of the kubernetes cloud, the templates which aren't in the list
+	// and were created by the operator are removed
+	// +optional
+	PodTemplates []AgentPodTemplate `json:"podTemplates,omitempty"`
+
+	// MaxConcurrent is the maximum number of agent pods running concurrently, builds above the limit wait
+	// in Jenkins queue. It's set as the container cap of the kubernetes cloud, so it limits all agent pods of the cloud.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxConcurrent *int32 `json:"maxConcurrent,omitempty"`
+}
+
+// AgentPodTemplate defines Kubernetes plugin pod template.
+type AgentPodTemplate struct {
+	// Name is the name of the pod template
+	Name string `json:"name"`
+
+	// Labels are Jenkins labels used by jobs to select the pod template, defaults to the name
+	// +optional
+	Labels []string `json:"labels,omitempty"`
+
+	// OS is the operating system of nodes where agent pods are scheduled
+	// Defaults to linux.
+	// +kubebuilder:validation:Enum=linux;windows
+	// +optional
+	OS AgentOS `json:"os,omitempty"`
+
+	// Image is the image of the jnlp container which connects the agent to Jenkins master
+	// Defaults to jenkins/inbound-agent:4.9-1 on Linux and jenkins/inbound-agent:4.9-1-jdk11-windowsservercore-ltsc2019 on Windows.
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// Resources are the compute resources of the jnlp container
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Containers are the build containers of the agent pod, they share the workspace with the jnlp container
+	// +optional
+	Containers []AgentContainer `json:"containers,omitempty"`
+
+	// RunAsUser is the UID of agent containers on Linux
+	// +optional
+	RunAsUser *int64 `json:"runAsUser,omitempty"`
+
+	// RunAsGroup is the GID of agent containers on Linux
+	// +optional
+	RunAsGroup *int64 `json:"runAsGroup,omitempty"`
+
+	// RunAsUserName is the user of agent containers on Windows, e.g. ContainerUser or ContainerAdministrator
+	// +optional
+	RunAsUserName string `json:"runAsUserName,omitempty"`
+
+	// NodeSelector is added to the OS node selector of agent pods
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations of agent pods, e.g. the taint of Windows nodes
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// IdleMinutes is the time the agent pod is kept after the build
+	// +optional
+	IdleMinutes int32 `json:"idleMinutes,omitempty"`
+}
+
+// AgentContainer defines the build container of the agent pod.
+type AgentContainer struct {
+	// Name of the container
+	Name string `json:"name"`
+
+	// Image of the container
+	Image string `json:"image"`
+
+	// Command of the container, it defaults to the command which keeps the container running,
+	// sleep on Linux and powershell Start-Sleep on Windows
+	// +optional
+	Command []string `json:"command,omitempty"`
+
+	// Args of the container
+	// +optional
+	Args []string `json:"args,omitempty"`
+
+	// Env are the environment variables of the container
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// Resources are the compute resources of the container
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// Persistence defines Jenkins home PersistentVolumeClaim.
+type Persistence struct {
+	// Size is the requested storage size, the claim is expanded when it grows and it can't be decreased
+	Size resource.Quantity `json:"size"`
+
+	// StorageClassName is the storage class of the claim, the default storage class is used when it's not set.
+	// Jenkins home is migrated to a new claim when it changes
+	// +optional
+	StorageClassName *string `json:"storageClassName,omitempty"`
+
+	// VolumeMode is the volume mode of the claim, only Filesystem is supported by Jenkins home
+	// Defaults to Filesystem.
+	// +optional
+	VolumeMode *corev1.PersistentVolumeMode `json:"volumeMode,omitempty"`
+
+	// AccessModes are the access modes of the claim
+	// Defaults to ReadWriteOnce.
+	// +optional
+	AccessModes []corev1.PersistentVolumeAccessMode `json:"accessModes,omitempty"`
+
+	// Migration defines migration of Jenkins home to the claim with the new storage class
+	// +optional
+	Migration PersistenceMigrationSettings `json:"migration,omitempty"`
+
+	// ReclaimPolicy tells if Jenkins home claims are retained or deleted when Jenkins CR is deleted
+	// Defaults to Retain.
+	// +kubebuilder:validation:Enum=Retain;Delete
+	// +optional
+	ReclaimPolicy PersistenceReclaimPolicy `json:"reclaimPolicy,omitempty"`
+}
+
+// PersistenceReclaimPolicy defines what happens with Jenkins home claims when Jenkins CR is deleted.
+type PersistenceReclaimPolicy string
+
+const (
+	// PersistenceReclaimRetain - the claims are kept, so Jenkins home can be mounted by the new Jenkins CR with the same name
+	PersistenceReclaimRetain PersistenceReclaimPolicy = "Retain"
+	// PersistenceReclaimDelete - the claims are deleted after the final backup
+	PersistenceReclaimDelete PersistenceReclaimPolicy = "Delete"
+)
+
+// PersistenceMigrationSettings defines migration of Jenkins home between storage classes.
+type PersistenceMigrationSettings struct {
+	// VolumeSnapshotClassName enables VolumeSnapshot of the old claim taken before Jenkins home is copied,
+	// it requires the CSI snapshot controller in the cluster
+	// +optional
+	VolumeSnapshotClassName string `json:"volumeSnapshotClassName,omitempty"`
+}
+
+// DiskUsage defines monitoring of Jenkins home disk usage.
+type DiskUsage struct {
+	// CheckInterval tells how often the disk usage is checked in seconds
+	// Defaults to 300.
+	// +optional
+	CheckInterval uint64 `json:"checkInterval,omitempty"`
+
+	// WarningThreshold is the percentage of used disk space when the warning event is emitted
+	// Defaults to 80.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	WarningThreshold int32 `json:"warningThreshold,omitempty"`
+
+	// CleanupPolicies are run in the order when the percentage of used disk space reaches their threshold
+	// +optional
+	CleanupPolicies []DiskCleanupPolicy `json:"cleanupPolicies,omitempty"`
+}
+
+// ResourceUsage defines reporting of resource requests and usage of Jenkins pods.
+type ResourceUsage struct {
+	// CheckInterval tells how often the resource usage is collected in seconds
+	// Defaults to 300.
+	// +optional
+	CheckInterval uint64 `json:"checkInterval,omitempty"`
+
+	// Team is the value of the team label of resource usage metrics
+	// Defaults to the value of jenkins.io/team label of Jenkins CR.
+	// +optional
+	Team string `json:"team,omitempty"`
+}
+
+// DiskCleanupPolicy defines actions freeing Jenkins home disk space.
+type DiskCleanupPolicy struct {
+	// Name is the name of the policy
+	Name string `json:"name"`
+
+	// Threshold is the percentage of used disk space when the policy is run
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	Threshold int32 `json:"threshold"`
+
+	// DiscardOldBuilds deletes old builds of all jobs, the running builds and the builds marked as keep forever are kept
+	// +optional
+	DiscardOldBuilds *DiscardOldBuilds `json:"discardOldBuilds,omitempty"`
+
+	// WipeWorkspaces deletes workspaces on the built-in node of the jobs which aren't running
+	// +optional
+	WipeWorkspaces bool `json:"wipeWorkspaces,omitempty"`
+
+	// CachePaths are directories relative to Jenkins home which contents are deleted, e.g. caches
+	// +optional
+	CachePaths []string `json:"cachePaths,omitempty"`
+}
+
+// DiscardOldBuilds defines which builds are kept when the old builds are discarded.
+type DiscardOldBuilds struct {
+	// KeepBuilds is the number of the latest builds of each job which are kept
+	// +optional
+	KeepBuilds int32 `json:"keepBuilds,omitempty"`
+
+	// KeepDays is the number of days the builds are kept
+	// +optional
+	KeepDays int32 `json:"keepDays,omitempty"`
+}
+
+// Folder defines Jenkins folder.
+type Folder struct {
+	// Name is the full name of the folder, nested folders are separated by '/', e.g. team-a/services,
+	// the parent folders are created when they don't exist
+	Name string `json:"name"`
+
+	// DisplayName is the folder name shown in Jenkins UI
+	// +optional
+	DisplayName string `json:"displayName,omitempty"`
+
+	// Description is the folder description
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Roles defines item roles of the folder and its items, it requires role-strategy plugin
+	// and the role-based authorization strategy
+	// +optional
+	Roles []FolderRole `json:"roles,omitempty"`
+}
+
+// FolderRole defines role-strategy item role which matches the folder and its items.
+type FolderRole struct {
+	// Name is the role name, it must be unique in Jenkins
+	Name string `json:"name"`
+
+	// Permissions is the list of permission IDs, e.g. hudson.model.Item.Read or hudson.model.Item.Build
+	Permissions []string `json:"permissions"`
+
+	// Sids is the list of users and groups assigned to the role
+	Sids []string `json:"sids"`
+}
+
+// View defines Jenkins list view.
+type View struct {
+	// Name is the view name
+	Name string `json:"name"`
+
+	// Folder is the full name of the folder from spec.folders where the view is created,
+	// the view is created in Jenkins root when it's not set
+	// +optional
+	Folder string `json:"folder,omitempty"`
+
+	// Description is the view description
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// IncludeRegex selects items shown in the view by name regex
+	// +optional
+	IncludeRegex string `json:"includeRegex,omitempty"`
+
+	// Recurse shows items from the nested folders
+	// +optional
+	Recurse bool `json:"recurse,omitempty"`
+}
+
+// SharedLibrary defines global pipeline library loaded from Git repository.
+type SharedLibrary struct {
+	// Name is the library name used in pipelines, e.g. @Library('my-library')
+	Name string `json:"name"`
+
+	// RepositoryURL is the Git repository URL of the library
+	RepositoryURL string `json:"repositoryUrl"`
+
+	// DefaultVersion is the branch, tag or commit loaded when the pipeline doesn't select the version
+	// +optional
+	DefaultVersion string `json:"defaultVersion,omitempty"`
+
+	// CredentialID is the Jenkins credential ID used to fetch the repository,
+	// e.g. the credential created by kubernetes-credentials-provider plugin from Kubernetes secret
+	// +optional
+	CredentialID string `json:"credentialID,omitempty"`
+
+	// Implicit loads the library automatically in all pipelines
+	// +optional
+	Implicit bool `json:"implicit,omitempty"`
+
+	// Cache enables caching of the library versions on Jenkins master
+	// +optional
+	Cache *SharedLibraryCache `json:"cache,omitempty"`
+}
+
+// SharedLibraryCache defines caching of the library versions.
+type SharedLibraryCache struct {
+	// RefreshTimeMinutes is the time after the cached version is fetched again, 0 means the cache is never refreshed
+	// automatically
+	// +optional
+	RefreshTimeMinutes uint64 `json:"refreshTimeMinutes,omitempty"`
+
+	// WebhookSecretKeySelector selects the secret token of the Git push webhook which clears the library cache,
+	// the webhook is disabled when it's not set
+	// +optional
+	WebhookSecretKeySelector *SecretKeySelector `json:"webhookSecretKeySelector,omitempty"`
+}
+
+// Tools defines global tool installations.
+type Tools struct {
+	// JDK defines JDK installations, they're installed from AdoptOpenJDK releases by the adoptopenjdk plugin
+	// +optional
+	JDK []JDKTool `json:"jdk,omitempty"`
+
+	// Maven defines Maven installations, they're installed from Apache Maven releases
+	// +optional
+	Maven []MavenTool `json:"maven,omitempty"`
+
+	// NodeJS defines NodeJS installations, they're installed from nodejs.org releases by the nodejs plugin
+	// +optional
+	NodeJS []NodeJSTool `json:"nodejs,omitempty"`
+}
+
+// ToolInstallation defines common attributes of the tool installation.
+type ToolInstallation struct {
+	// Name is the name of the tool used in pipelines, e.g. tools { jdk 'jdk11' }
+	Name string `json:"name"`
+
+	// Version is the version of the tool installed automatically on agents, mutually exclusive with Home
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// Home is the directory of the tool already installed on agents, mutually exclusive with Version
+	// +optional
+	Home string `json:"home,omitempty"`
+}
+
+// JDKTool defines JDK installation, the version is the AdoptOpenJDK release name, e.g. jdk-11.0.13+8 or jdk8u312-b07.
+type JDKTool struct {
+	ToolInstallation `json:",inline"`
+}
+
+// MavenTool defines Maven installation, the version is the Maven release, e.g. 3.8.4.
+type MavenTool struct {
+	ToolInstallation `json:",inline"`
+}
+
+// NodeJSTool defines NodeJS installation, the version is the NodeJS release, e.g. 16.13.1.
+type NodeJSTool struct {
+	ToolInstallation `json:",inline"`
+
+	// GlobalPackages is the list of npm packages installed globally with the NodeJS installation, e.g. yarn@1.22.17
+	// +optional
+	GlobalPackages []string `json:"globalPackages,omitempty"`
+}
+
+// PluginUpgradePolicy defines which plugins are upgraded automatically.
+type PluginUpgradePolicy string
+
+const (
+	// PatchOnlyPluginUpgradePolicy upgrades plugins if the major and minor versions don't change
+	PatchOnlyPluginUpgradePolicy PluginUpgradePolicy = "patch-only"
+	// SecurityOnlyPluginUpgradePolicy upgrades plugins if the installed version is affected by a security warning
+	SecurityOnlyPluginUpgradePolicy PluginUpgradePolicy = "security-only"
+)
+
+// PluginUpdates defines configuration of plugin update checks and automatic upgrades.
+type PluginUpdates struct {
+	// UpdateCenterURL is the URL of the update center JSON
+	// Defaults to https://updates.jenkins.io/update-center.actual.json.
+	// +optional
+	UpdateCenterURL string `json:"updateCenterURL,omitempty"`
+
+	// CheckInterval tells how often the plugin updates are checked in seconds
+	// Defaults to 86400.
+	// +optional
+	CheckInterval uint64 `json:"checkInterval,omitempty"`
+
+	// AutoUpgradePolicy enables automatic upgrades of plugins from spec.master.basePlugins and spec.master.plugins,
+	// plugins are upgraded only when they are reported by the update check. Plugins aren't upgraded when it's not set.
+	// +kubebuilder:validation:Enum=patch-only;security-only
+	// +optional
+	AutoUpgradePolicy PluginUpgradePolicy `json:"autoUpgradePolicy,omitempty"`
+
+	// RollbackTimeout is the time in seconds for Jenkins to become ready after the automatic upgrade,
+	// the upgraded plugins are rolled back to the previous versions when it's exceeded
+	// Defaults to 900.
+	// +optional
+	RollbackTimeout uint64 `json:"rollbackTimeout,omitempty"`
+}
+
+// Plugins defines restrictions of plugins installed in Jenkins.
+type Plugins struct {
+	// Policy restricts plugins which can be set in spec.master.basePlugins and spec.master.plugins,
+	// it's enforced by the operator and by the validating webhook
+	// +optional
+	Policy *PluginPolicy `json:"policy,omitempty"`
+}
+
+// PluginPolicy defines allowed and banned plugins, plugin names are matched by glob patterns, e.g. 'workflow-*'.
+type PluginPolicy struct {
+	// Allow contains patterns of plugins which can be installed, all plugins are allowed when it's empty.
+	// Plugins required by the operator are always allowed.
+	// +optional
+	Allow []string `json:"allow,omitempty"`
+
+	// Deny contains patterns of banned plugins, it takes precedence over allow
+	// +optional
+	Deny []string `json:"deny,omitempty"`
+
+	// MinimumVersions contains the lowest versions of plugins which can be installed
+	// +optional
+	MinimumVersions []PluginMinimumVersion `json:"minimumVersions,omitempty"`
+}
+
+// PluginMinimumVersion defines the lowest version of plugins.
+type PluginMinimumVersion struct {
+	// Name is the pattern of plugin names
+	Name string `json:"name"`
+
+	// Version is the lowest version which can be installed
+	Version string `json:"version"`
+}
+
+// UpdateCenterMirror defines the update center JSON generated from the upstream update center,
+// the generated JSON contains only approved plugins and it's signed with the key managed by the operator.
+type UpdateCenterMirror struct {
+	// UpstreamURL is the URL of the update center JSON which the mirror is generated from
+	// Defaults to https://updates.jenkins.io/update-center.actual.json.
+	// +optional
+	UpstreamURL string `json:"upstreamURL,omitempty"`
+
+	// Plugins contains glob patterns of plugins approved in addition to spec.master.basePlugins and spec.master.plugins,
+	// dependencies of approved plugins are added and plugins not allowed by spec.plugins.policy are skipped
+	// +optional
+	Plugins []string `json:"plugins,omitempty"`
+
+	// RefreshInterval tells how often the mirror is generated in seconds
+	// Defaults to 86400.
+	// +optional
+	RefreshInterval uint64 `json:"refreshInterval,omitempty"`
+}
+
+// BuildLogArchivalProvider defines type of external storage where build logs are uploaded.
+type BuildLogArchivalProvider string
+
+const (
+	// S3BuildLogArchivalProvider uploads build logs to Amazon S3 or S3 compatible storage
+	S3BuildLogArchivalProvider BuildLogArchivalProvider = "S3"
+	// GCSBuildLogArchivalProvider uploads build logs to Google Cloud Storage
+	GCSBuildLogArchivalProvider BuildLogArchivalProvider = "GCS"
+)
+
+// BuildLogArchival defines configuration of uploading finished build logs to external storage.
+type BuildLogArchival struct {
+	// Provider is the type of external storage
+	// +kubebuilder:validation:Enum=S3;GCS
+	Provider BuildLogArchivalProvider `json:"provider"`
+
+	// Bucket is the name of bucket where build logs are uploaded
+	Bucket string `json:"bucket"`
+
+	// Prefix is prepended to the keys of uploaded build logs, the key is <prefix><job>/<build number>/log
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+
+	// Region is the region of S3 bucket
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// Endpoint is the URL of S3 compatible storage
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// CredentialsSecret is the Secret with storage credentials, AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY keys for S3
+	// or service-account.json key for GCS. Without it the credentials are taken from the pod environment,
+	// e.g. workload identity
+	// +optional
+	CredentialsSecret *SecretRef `json:"credentialsSecret,omitempty"`
+
+	// Interval tells how often finished builds are looked for in seconds
+	// Defaults to 60.
+	// +optional
+	Interval uint64 `json:"interval,omitempty"`
+
+	// RetentionDays is added to uploaded build logs as lifecycle metadata, bucket lifecycle rules can use it to expire
+	// the logs
+	// +optional
+	RetentionDays int32 `json:"retentionDays,omitempty"`
+
+	// DeleteAfterUpload removes uploaded build logs from Jenkins home to keep persistent volumes small
+	// +optional
+	DeleteAfterUpload bool `json:"deleteAfterUpload,omitempty"`
+
+	// Image is the sidecar container image, the image has to provide aws or gsutil command depending on the provider
+	// Defaults to amazon/aws-cli for S3 and google/cloud-sdk for GCS.
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// Resources are the compute resources required by the sidecar container
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// Proxy defines HTTP(S) proxy configuration.
+type Proxy struct {
+	// HTTPProxy is the URL of the proxy used for HTTP requests, e.g. http://proxy.example.com:3128
+	// +optional
+	HTTPProxy string `json:"httpProxy,omitempty"`
+
+	// HTTPSProxy is the URL of the proxy used for HTTPS requests, e.g. http://proxy.example.com:3128
+	// +optional
+	HTTPSProxy string `json:"httpsProxy,omitempty"`
+
+	// NoProxy is the list of hosts and domains which are accessed directly, e.g. .example.com
+	// localhost, 127.0.0.1, .svc and .cluster.local are always accessed directly
+	// +optional
+	NoProxy []string `json:"noProxy,omitempty"`
+
+	// Credentials are the username and password of the proxy read from Secrets, the credentials can't be
+	// set inline in the proxy URLs
+	// +optional
+	Credentials *ProxyCredentials `json:"credentials,omitempty"`
+}
+
+// ProxyCredentials selects the username and password of the proxy.
+type ProxyCredentials struct {
+	UsernameSecretKeySelector SecretKeySelector `json:"usernameSecretKeySelector"`
+	PasswordSecretKeySelector SecretKeySelector `json:"passwordSecretKeySelector"`
+}
+
+// TrustedCABundle defines ConfigMap with PEM encoded CA certificates.
+type TrustedCABundle struct {
+	// ConfigMapRef is the reference to the ConfigMap with CA certificates
+	ConfigMapRef `json:",inline"`
+
+	// Key is the key in the ConfigMap which contains PEM encoded CA certificates
+	// Defaults to : ca-bundle.crt
+	// +optional
+	Key string `json:"key,omitempty"`
 }
 
 // AuthorizationStrategy defines authorization strategy of the operator for the Jenkins API
@@ -81,9 +975,26 @@ const (
 	ServiceAccountAuthorizationStrategy AuthorizationStrategy = "serviceAccount"
 )
 
+// JenkinsTemplateRef is the reference to the cluster-scoped JenkinsTemplate.
+type JenkinsTemplateRef struct {
+	Name string `json:"name"`
+}
+
 // JenkinsAPISettings defines configuration used by the operator to gain admin access to the Jenkins API
 type JenkinsAPISettings struct {
 	AuthorizationStrategy AuthorizationStrategy `json:"authorizationStrategy"`
+
+	// CredentialsRotation enables the periodic rotation of the operator user password and API token,
+	// it's supported only by the createUser authorization strategy
+	// +optional
+	CredentialsRotation *CredentialsRotation `json:"credentialsRotation,omitempty"`
+}
+
+// CredentialsRotation defines how often the operator rotates credentials of its Jenkins user
+type CredentialsRotation struct {
+	// Period is the time between rotations in seconds
+	// +kubebuilder:validation:Minimum=3600
+	Period uint64 `json:"period"`
 }
 
 // ServiceAccount defines Kubernetes service account attributes
@@ -116,6 +1027,23 @@ type Notification struct {
 	Teams        *MicrosoftTeams   `json:"teams,omitempty"`
 	Mailgun      *Mailgun          `json:"mailgun,omitempty"`
 	SMTP         *SMTP             `json:"smtp,omitempty"`
+	// Custom sends the notifications with the sink registered in the operator build
+	// +optional
+	Custom *CustomNotification `json:"custom,omitempty"`
+}
+
+// CustomNotification is handler for notification sinks registered in the operator build, e.g. PagerDuty.
+type CustomNotification struct {
+	// Sink is the name of the registered notification sink
+	Sink string `json:"sink"`
+
+	// Parameters are passed to the sink, e.g. the routing key of PagerDuty service
+	// +optional
+	Parameters map[string]string `json:"parameters,omitempty"`
+
+	// SecretKeySelector selects the secret with the credentials of the sink
+	// +optional
+	SecretKeySelector *SecretKeySelector `json:"secretKeySelector,omitempty"`
 }
 
 // Slack is handler for Slack notification channel.
@@ -256,8 +1184,65 @@ type Plugin struct {
 	Version string `json:"version"`
 	// DownloadURL is the custom url from where plugin has to be downloaded.
 	DownloadURL string `json:"downloadURL,omitempty"`
+	// UpdateSite is the name of the update site the plugin is downloaded from, "experimental" or one of
+	// spec.master.updateSites, the plugin is downloaded from the default update center if it isn't set
+	// +optional
+	UpdateSite string `json:"updateSite,omitempty"`
+}
+
+// UpdateSite defines the update center plugins can be downloaded from.
+type UpdateSite struct {
+	// Name is the name of the update site used by spec.master.plugins[].updateSite
+	Name string `json:"name"`
+	// URL is the root URL of the update center, the latest versions of plugins are downloaded from <url>/latest/
+	URL string `json:"url"`
+	// DownloadURL is the root URL of plugin versions downloaded from <downloadURL>/plugins/<name>/<version>/
+	// Defaults to <url>/download.
+	// +optional
+	DownloadURL string `json:"downloadURL,omitempty"`
+}
+
+// ContainerType defines the kind of the container of Jenkins master pod
+type ContainerType string
+
+const (
+	// InitContainerType is the container of spec.master.initContainers
+	InitContainerType ContainerType = "Init"
+	// SidecarContainerType is the container of spec.master.containers other than Jenkins master container
+	SidecarContainerType ContainerType = "Sidecar"
+)
+
+// ContainerStatus defines the state of the init container or the sidecar of Jenkins master pod
+type ContainerStatus struct {
+	// Name is the name of the container
+	Name string `json:"name"`
+
+	// Type is Init or Sidecar
+	Type ContainerType `json:"type"`
+
+	// Ready tells if the sidecar is ready or the init container has completed
+	Ready bool `json:"ready"`
+
+	// RestartCount is the number of times the container has been restarted
+	RestartCount int32 `json:"restartCount"`
+
+	// Failure describes why the container isn't running, e.g. it's crash looping or it has exited with the error
+	// +optional
+	Failure string `json:"failure,omitempty"`
 }
 
+// WorkloadType defines the Kubernetes workload running Jenkins master
+type WorkloadType string
+
+const (
+	// WorkloadTypePod the operator creates Jenkins master pod and recreates it when it's needed
+	WorkloadTypePod WorkloadType = "Pod"
+	// WorkloadTypeStatefulSet Jenkins master pod is created by the StatefulSet with a single replica
+	WorkloadTypeStatefulSet WorkloadType = "StatefulSet"
+	// WorkloadTypeDeployment Jenkins master pod is created by the Deployment, it's the same as jenkins.io/use-deployment annotation
+	WorkloadTypeDeployment WorkloadType = "Deployment"
+)
+
 // JenkinsMaster defines the Jenkins master pod attributes and plugins,
 // every single change requires a Jenkins master pod restart.
 type JenkinsMaster struct {
@@ -290,6 +1275,11 @@ type JenkinsMaster struct {
 	// fsGroup: 1000
 	SecurityContext *corev1.PodSecurityContext `json:"securityContext,omitempty"`
 
+	// InitContainers are run in the defined order before Jenkins master and sidecar containers are started,
+	// e.g. to pre-seed Jenkins home or fix permissions of volumes. Jenkins master pod is restarted when they change.
+	// +optional
+	InitContainers []Container `json:"initContainers,omitempty"`
+
 	// List of containers belonging to the pod.
 	// Containers cannot currently be added or removed.
 	// There must be at least one container in a Pod.
@@ -366,6 +1356,11 @@ type JenkinsMaster struct {
 	// +optional
 	Plugins []Plugin `json:"plugins,omitempty"`
 
+	// UpdateSites defines update sites which plugins can be downloaded from besides the default update center
+	// and the "experimental" one, e.g. an internal update center
+	// +optional
+	UpdateSites []UpdateSite `json:"updateSites,omitempty"`
+
 	// DisableCSRFProtection allows you to toggle CSRF Protection on Jenkins
 	DisableCSRFProtection bool `json:"disableCSRFProtection"`
 
@@ -376,6 +1371,57 @@ type JenkinsMaster struct {
 	// HostAliases for Jenkins master pod and SeedJob agent
 	// +optional
 	HostAliases []corev1.HostAlias `json:"hostAliases,omitempty"`
+
+	// JVM defines Java virtual machine options of Jenkins master, they are appended to JAVA_OPTS
+	// +optional
+	JVM *JVM `json:"jvm,omitempty"`
+
+	// WorkloadType defines the Kubernetes workload running Jenkins master, Pod is managed by the operator directly,
+	// StatefulSet gives Jenkins master the stable network identity and makes sure that at most one pod uses Jenkins home
+	// Defaults to Pod or to Deployment when jenkins.io/use-deployment annotation is set.
+	// +kubebuilder:validation:Enum=Pod;StatefulSet;Deployment
+	// +optional
+	WorkloadType WorkloadType `json:"workloadType,omitempty"`
+}
+
+// GarbageCollector defines Java garbage collector algorithm
+// +kubebuilder:validation:Enum=G1;Parallel;Serial;Z;Shenandoah
+type GarbageCollector string
+
+const (
+	// G1GarbageCollector enables garbage-first garbage collector
+	G1GarbageCollector GarbageCollector = "G1"
+	// ParallelGarbageCollector enables parallel garbage collector
+	ParallelGarbageCollector GarbageCollector = "Parallel"
+	// SerialGarbageCollector enables serial garbage collector
+	SerialGarbageCollector GarbageCollector = "Serial"
+	// ZGarbageCollector enables Z garbage collector, requires Java 15 or newer
+	ZGarbageCollector GarbageCollector = "Z"
+	// ShenandoahGarbageCollector enables Shenandoah garbage collector
+	ShenandoahGarbageCollector GarbageCollector = "Shenandoah"
+)
+
+// JVM defines Java virtual machine options.
+type JVM struct {
+	// InitialHeap is the initial size of the heap (-Xms)
+	// +optional
+	InitialHeap *resource.Quantity `json:"initialHeap,omitempty"`
+
+	// MaxHeap is the maximum size of the heap (-Xmx), it can't be greater than 80% of Jenkins master container memory limit
+	// +optional
+	MaxHeap *resource.Quantity `json:"maxHeap,omitempty"`
+
+	// MaxMetaspace is the maximum size of the metaspace (-XX:MaxMetaspaceSize)
+	// +optional
+	MaxMetaspace *resource.Quantity `json:"maxMetaspace,omitempty"`
+
+	// GarbageCollector is the garbage collector algorithm
+	// +optional
+	GarbageCollector GarbageCollector `json:"garbageCollector,omitempty"`
+
+	// ExtraFlags is the list of additional JVM flags, e.g. -XX:+HeapDumpOnOutOfMemoryError
+	// +optional
+	ExtraFlags []string `json:"extraFlags,omitempty"`
 }
 
 // Service defines Kubernetes service attributes
@@ -439,6 +1485,18 @@ type Service struct {
 	// This field will be ignored if the cloud-provider does not support the feature.
 	// +optional
 	LoadBalancerIP string `json:"loadBalancerIP,omitempty"`
+
+	// IPFamilyPolicy represents the dual-stack-ness requested or required by this Service.
+	// Valid values are SingleStack, PreferDualStack and RequireDualStack. Defaults to the cluster default.
+	// More info: https://kubernetes.io/docs/concepts/services-networking/dual-stack/
+	// +optional
+	IPFamilyPolicy *corev1.IPFamilyPolicyType `json:"ipFamilyPolicy,omitempty"`
+
+	// IPFamilies is a list of IP families (IPv4, IPv6) assigned to this service. The first family is the
+	// primary one and can't be changed after the service is created.
+	// More info: https://kubernetes.io/docs/concepts/services-networking/dual-stack/
+	// +optional
+	IPFamilies []corev1.IPFamily `json:"ipFamilies,omitempty"`
 }
 
 // JenkinsStatus defines the observed state of Jenkins
@@ -468,6 +1526,10 @@ type JenkinsStatus struct {
 	// +optional
 	LastBackup uint64 `json:"lastBackup,omitempty"`
 
+	// LastBackupTime is a time when the latest backup has been completed
+	// +optional
+	LastBackupTime *metav1.Time `json:"lastBackupTime,omitempty"`
+
 	// PendingBackup is the pending backup number
 	// +optional
 	PendingBackup uint64 `json:"pendingBackup,omitempty"`
@@ -480,6 +1542,15 @@ type JenkinsStatus struct {
 	// +optional
 	UserAndPasswordHash string `json:"userAndPasswordHash,omitempty"`
 
+	// Containers reports the state of init containers and sidecars of Jenkins master pod defined in spec.master,
+	// e.g. why they have failed
+	// +optional
+	Containers []ContainerStatus `json:"containers,omitempty"`
+
+	// LastCredentialsRotationTime is the time when the operator user password and API token were rotated last time
+	// +optional
+	LastCredentialsRotationTime *metav1.Time `json:"lastCredentialsRotationTime,omitempty"`
+
 	// CreatedSeedJobs contains list of seed job id already created in Jenkins
 	// +optional
 	CreatedSeedJobs []string `json:"createdSeedJobs,omitempty"`
@@ -487,10 +1558,473 @@ type JenkinsStatus struct {
 	// AppliedGroovyScripts is a list with all applied groovy scripts in Jenkins by the operator
 	// +optional
 	AppliedGroovyScripts []AppliedGroovyScript `json:"appliedGroovyScripts,omitempty"`
+
+	// Conditions represent the latest available observations of Jenkins state
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// PluginUpdates contains the result of the last plugin update check and automatic upgrades
+	// +optional
+	PluginUpdates *PluginUpdatesStatus `json:"pluginUpdates,omitempty"`
+
+	// Plan is the list of actions which the operator would take to reconcile the Jenkins CR,
+	// it's computed instead of the reconciliation when the jenkins.io/plan annotation is set to "true"
+	// +optional
+	Plan *JenkinsPlan `json:"plan,omitempty"`
+
+	// Persistence contains the state of Jenkins home PersistentVolumeClaim
+	// +optional
+	Persistence *PersistenceStatus `json:"persistence,omitempty"`
+
+	// DiskUsage contains the result of the last Jenkins home disk usage check
+	// +optional
+	DiskUsage *DiskUsageStatus `json:"diskUsage,omitempty"`
+
+	// Exporter contains the state of the last scrape of Jenkins metrics
+	// +optional
+	Exporter *ExporterStatus `json:"exporter,omitempty"`
+
+	// ResourceUsage contains the resource requests and usage of Jenkins pods collected last time
+	// +optional
+	ResourceUsage *ResourceUsageStatus `json:"resourceUsage,omitempty"`
+
+	// UpdateCenterMirror contains the result of the last update center mirror generation
+	// +optional
+	UpdateCenterMirror *UpdateCenterMirrorStatus `json:"updateCenterMirror,omitempty"`
+
+	// Canary contains the result of the last verification of configuration changes in the canary Jenkins pod
+	// +optional
+	Canary *CanaryStatus `json:"canary,omitempty"`
+
+	// FailedCanaryConfigurationHash is the hash of configuration which has failed in the canary Jenkins pod, it's kept
+	// after Jenkins master pod restart so the configuration isn't applied until it changes
+	// +optional
+	FailedCanaryConfigurationHash string `json:"failedCanaryConfigurationHash,omitempty"`
+
+	// PluginBatch contains the last batch of plugin changes installed in running Jenkins with one safe restart
+	// +optional
+	PluginBatch *PluginBatchStatus `json:"pluginBatch,omitempty"`
+
+	// ApplyConflicts contains the last conflict of server-side apply with other field managers per object managed
+	// by the operator, the object isn't updated until the conflict is resolved and the entry is removed
+	// +optional
+	ApplyConflicts []ApplyConflict `json:"applyConflicts,omitempty"`
+
+	// PendingScriptApprovals contains script approvals pending in Jenkins which don't match spec.scriptApproval,
+	// e.g. seed jobs wait for them
+	// +optional
+	PendingScriptApprovals []PendingScriptApproval `json:"pendingScriptApprovals,omitempty"`
+
+	// ScriptApprovalCheck contains the last check of pending script approvals, Jenkins is checked again when
+	// spec.scriptApproval or the seed jobs change and every 5 minutes
+	// +optional
+	ScriptApprovalCheck *ScriptApprovalCheck `json:"scriptApprovalCheck,omitempty"`
+
+	// DeferredActions contains disruptive actions waiting for the next window of spec.maintenanceWindows
+	// +optional
+	DeferredActions []DeferredAction `json:"deferredActions,omitempty"`
+
+	// AuditedGeneration is the generation of Jenkins CR whose spec change has been recorded in the audit trail
+	// +optional
+	AuditedGeneration int64 `json:"auditedGeneration,omitempty"`
+}
+
+// ApplyConflict defines the conflict of server-side apply of the object managed by the operator.
+type ApplyConflict struct {
+	// Kind is the kind of the object
+	Kind string `json:"kind"`
+
+	// Name is the name of the object
+	Name string `json:"name"`
+
+	// Message lists the conflicting fields and their field managers
+	Message string `json:"message"`
+
+	// Time is the time of the last conflict
+	Time metav1.Time `json:"time"`
+}
+
+// PluginBatchStatus defines the batch of plugin changes installed in running Jenkins.
+type PluginBatchStatus struct {
+	// StartTime is a time when the plugins have been downloaded to Jenkins master
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// RestartTime is a time when the safe restart of Jenkins loading the plugins has been scheduled
+	// +optional
+	RestartTime *metav1.Time `json:"restartTime,omitempty"`
+
+	// CompletionTime is a time when Jenkins has been restarted and the plugins have been verified
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Plugins contains the status of every plugin change in the batch
+	// +optional
+	Plugins []PluginChangeStatus `json:"plugins,omitempty"`
+}
+
+// PluginChangePhase defines the phase of the plugin change in the batch.
+type PluginChangePhase string
+
+const (
+	// PluginChangePhasePending - the plugin has been downloaded, Jenkins hasn't been restarted yet
+	PluginChangePhasePending PluginChangePhase = "Pending"
+	// PluginChangePhaseInstalled - Jenkins has loaded the required version of the plugin
+	PluginChangePhaseInstalled PluginChangePhase = "Installed"
+	// PluginChangePhaseFailed - the plugin couldn't be downloaded or Jenkins hasn't loaded it
+	PluginChangePhaseFailed PluginChangePhase = "Failed"
+)
+
+// PluginChangeStatus defines the status of the plugin change in the batch.
+type PluginChangeStatus struct {
+	PlannedPluginChange `json:",inline"`
+
+	// Phase is the phase of the plugin change: Pending, Installed or Failed
+	Phase PluginChangePhase `json:"phase"`
+
+	// Message is a human readable reason why the plugin change has failed
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// CanaryPhase defines the phase of the verification in the canary Jenkins pod.
+type CanaryPhase string
+
+const (
+	// CanaryPhaseRunning - the canary Jenkins pod is starting or the configuration is being applied to it
+	CanaryPhaseRunning CanaryPhase = "Running"
+	// CanaryPhasePassed - the configuration has been applied to the canary Jenkins pod without errors
+	CanaryPhasePassed CanaryPhase = "Passed"
+	// CanaryPhaseFailed - the canary Jenkins pod hasn't started or the configuration has failed, it isn't applied to Jenkins
+	CanaryPhaseFailed CanaryPhase = "Failed"
+)
+
+// CanaryStatus defines the observed state of the verification in the canary Jenkins pod.
+type CanaryStatus struct {
+	// ConfigurationHash is the hash of the verified Configuration as Code and groovy scripts
+	ConfigurationHash string `json:"configurationHash"`
+
+	// Phase is the phase of the verification
+	Phase CanaryPhase `json:"phase"`
+
+	// StartTime is a time when the canary Jenkins pod has been created
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is a time when the verification has passed or failed
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Message describes the failure of the verification
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// UpdateCenterMirrorStatus defines the observed state of the update center mirror.
+type UpdateCenterMirrorStatus struct {
+	// LastGenerationTime is the time of the last successful generation
+	// +optional
+	LastGenerationTime *metav1.Time `json:"lastGenerationTime,omitempty"`
+
+	// ObservedGeneration is the generation of Jenkins CR which the update center has been generated for
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Plugins is the number of plugins in the generated update center
+	// +optional
+	Plugins int `json:"plugins,omitempty"`
+}
+
+// DiskUsageStatus defines the observed Jenkins home disk usage.
+type DiskUsageStatus struct {
+	// LastCheckTime is a time when the disk usage has been checked
+	// +optional
+	LastCheckTime *metav1.Time `json:"lastCheckTime,omitempty"`
+
+	// UsedBytes is the used disk space of Jenkins home volume
+	UsedBytes int64 `json:"usedBytes"`
+
+	// CapacityBytes is the size of Jenkins home volume
+	CapacityBytes int64 `json:"capacityBytes"`
+
+	// UsedPercent is the percentage of used disk space
+	UsedPercent int32 `json:"usedPercent"`
+
+	// LastCleanup is the last run of cleanup policies
+	// +optional
+	LastCleanup *DiskCleanup `json:"lastCleanup,omitempty"`
+}
+
+// ExporterStatus defines the state of the last scrape of Jenkins metrics.
+type ExporterStatus struct {
+	// LastScrapeTime is a time when Jenkins metrics have been scraped
+	// +optional
+	LastScrapeTime *metav1.Time `json:"lastScrapeTime,omitempty"`
+
+	// LastScrapeJenkinsTimeMillis is the time of the last scrape by Jenkins clock, builds completed after it are
+	// reported by the next scrape
+	// +optional
+	LastScrapeJenkinsTimeMillis int64 `json:"lastScrapeJenkinsTimeMillis,omitempty"`
+}
+
+// ResourceUsageStatus defines the resource requests and usage of Jenkins master and agent pods.
+type ResourceUsageStatus struct {
+	// LastCheckTime is a time when the resource usage has been collected
+	// +optional
+	LastCheckTime *metav1.Time `json:"lastCheckTime,omitempty"`
+
+	// Team is the team charged for the resource usage
+	// +optional
+	Team string `json:"team,omitempty"`
+
+	// Master is the resource usage of Jenkins master pod
+	Master PodsResourceUsage `json:"master"`
+
+	// Agents is the resource usage of agent pods created from spec.agents.podTemplates
+	Agents PodsResourceUsage `json:"agents"`
+
+	// Message tells why the actual usage isn't reported, e.g. metrics-server isn't available
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// PodsResourceUsage defines the sum of resource requests and usage of pods.
+type PodsResourceUsage struct {
+	// Pods is the number of running and pending pods
+	Pods int32 `json:"pods"`
+
+	// CPURequests is the sum of CPU requests of pod containers
+	CPURequests resource.Quantity `json:"cpuRequests"`
+
+	// MemoryRequests is the sum of memory requests of pod containers
+	MemoryRequests resource.Quantity `json:"memoryRequests"`
+
+	// CPUUsage is the sum of CPU usage of pod containers reported by metrics-server
+	// +optional
+	CPUUsage *resource.Quantity `json:"cpuUsage,omitempty"`
+
+	// MemoryUsage is the sum of memory working set of pod containers reported by metrics-server
+	// +optional
+	MemoryUsage *resource.Quantity `json:"memoryUsage,omitempty"`
+}
+
+// DiskCleanup defines the run of cleanup policies.
+type DiskCleanup struct {
+	// Time is a time when the cleanup policies have been run
+	Time metav1.Time `json:"time"`
+
+	// Policies are the names of policies which have been run
+	Policies []string `json:"policies"`
+
+	// FreedBytes is the disk space freed by the cleanup
+	// +optional
+	FreedBytes int64 `json:"freedBytes,omitempty"`
+
+	// Message describes the cleanup failure
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// PersistenceMigrationPhase defines the phase of Jenkins home migration.
+type PersistenceMigrationPhase string
+
+const (
+	// PersistenceMigrationCopying - Jenkins is stopped and Jenkins home is being copied to the new claim
+	PersistenceMigrationCopying PersistenceMigrationPhase = "Copying"
+	// PersistenceMigrationFailed - the copy failed, Jenkins runs with the old claim
+	PersistenceMigrationFailed PersistenceMigrationPhase = "Failed"
+)
+
+// PersistenceStatus defines the state of Jenkins home PersistentVolumeClaim.
+type PersistenceStatus struct {
+	// ClaimName is the name of PersistentVolumeClaim mounted as Jenkins home
+	ClaimName string `json:"claimName"`
+
+	// StorageClassName is the storage class of the claim
+	// +optional
+	StorageClassName string `json:"storageClassName,omitempty"`
+
+	// Capacity is the actual capacity of the claim
+	// +optional
+	Capacity *resource.Quantity `json:"capacity,omitempty"`
+
+	// Message is the error of the last expansion request
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Migration is the migration of Jenkins home to the claim with the new storage class
+	// +optional
+	Migration *PersistenceMigration `json:"migration,omitempty"`
+}
+
+// PersistenceMigration defines the state of Jenkins home migration.
+type PersistenceMigration struct {
+	// TargetClaimName is the name of the new claim
+	TargetClaimName string `json:"targetClaimName"`
+
+	// StorageClassName is the storage class of the new claim
+	StorageClassName string `json:"storageClassName"`
+
+	// Phase is the migration phase
+	Phase PersistenceMigrationPhase `json:"phase"`
+
+	// StartTime is the time when the migration has been started
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// SnapshotName is the name of VolumeSnapshot of the old claim
+	// +optional
+	SnapshotName string `json:"snapshotName,omitempty"`
+
+	// Message describes the migration failure
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+const (
+	// JenkinsReadyConditionType tells if Jenkins API is reachable by the operator, all required plugins are active and
+	// Configuration as Code has been applied
+	JenkinsReadyConditionType = "Ready"
+
+	// JenkinsReconciledConditionType tells if the last reconcile loop of Jenkins CR has succeeded, the reason of failed
+	// loop is the category of its error, e.g. Transient or ConfigurationInvalid
+	JenkinsReconciledConditionType = "Reconciled"
+
+	// PlanAnnotation enables the plan mode when set to "true", the operator computes the actions which it would take
+	// and publishes them in status.plan without executing them
+	PlanAnnotation = "jenkins.io/plan"
+
+	// BackupNowAnnotation requests the backup of Jenkins outside of spec.backup.interval, the operator removes
+	// the annotation when the backup has been scheduled
+	BackupNowAnnotation = "jenkins.io/backup-now"
+
+	// RerunSeedJobsAnnotation requests builds of all seed jobs, the operator removes the annotation when the builds
+	// have been queued
+	RerunSeedJobsAnnotation = "jenkins.io/rerun-seed-jobs"
+)
+
+// PluginUpdatesStatus defines the observed state of plugin updates.
+type PluginUpdatesStatus struct {
+	// LastCheckTime is a time when the plugin updates have been checked
+	// +optional
+	LastCheckTime *metav1.Time `json:"lastCheckTime,omitempty"`
+
+	// AvailableUpgrades contains installed plugins which have newer version in the update center
+	// +optional
+	AvailableUpgrades []PluginUpgrade `json:"availableUpgrades,omitempty"`
+
+	// PendingUpgrade is the automatic upgrade which waits for Jenkins to become ready
+	// +optional
+	PendingUpgrade *PluginAutoUpgrade `json:"pendingUpgrade,omitempty"`
+
+	// LastUpgrade is the last completed or rolled back automatic upgrade
+	// +optional
+	LastUpgrade *PluginAutoUpgrade `json:"lastUpgrade,omitempty"`
+}
+
+// PluginUpgrade defines the plugin which can be upgraded.
+type PluginUpgrade struct {
+	// Name is the name of Jenkins plugin
+	Name string `json:"name"`
+	// CurrentVersion is the version of the plugin installed in Jenkins
+	CurrentVersion string `json:"currentVersion"`
+	// AvailableVersion is the latest version of the plugin in the update center
+	AvailableVersion string `json:"availableVersion"`
+	// Security tells if the current version is affected by a security warning which is fixed in the available version
+	// +optional
+	Security bool `json:"security,omitempty"`
+}
+
+// PluginAutoUpgrade defines the automatic upgrade of plugins.
+type PluginAutoUpgrade struct {
+	// StartTime is a time when the plugins have been upgraded in the Jenkins CR
+	StartTime *metav1.Time `json:"startTime"`
+	// Generation is the Jenkins CR generation with the upgraded plugins
+	Generation int64 `json:"generation"`
+	// Plugins contains the upgraded plugins
+	Plugins []PluginUpgrade `json:"plugins"`
+	// RolledBack tells if the plugins have been rolled back to the previous versions
+	// +optional
+	RolledBack bool `json:"rolledBack,omitempty"`
+	// Message is a human readable result of the upgrade
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// JenkinsPlan defines the actions which the operator would take to reconcile the Jenkins CR.
+type JenkinsPlan struct {
+	// ObservedGeneration is the Jenkins CR generation which the plan has been computed for
+	ObservedGeneration int64 `json:"observedGeneration"`
+
+	// GeneratedTime is a time when the plan has been computed
+	// +optional
+	GeneratedTime *metav1.Time `json:"generatedTime,omitempty"`
+
+	// PodRestartRequired tells if Jenkins master pod would be created or restarted
+	PodRestartRequired bool `json:"podRestartRequired"`
+
+	// PodRestartReasons contains the reasons why Jenkins master pod would be created or restarted
+	// +optional
+	PodRestartReasons []string `json:"podRestartReasons,omitempty"`
+
+	// JenkinsRestartRequired tells if Jenkins would be restarted safely to load the plugin changes in one batch,
+	// Jenkins master pod is kept then
+	// +optional
+	JenkinsRestartRequired bool `json:"jenkinsRestartRequired,omitempty"`
+
+	// Plugins contains plugins which would be installed, upgraded or downgraded
+	// +optional
+	Plugins []PlannedPluginChange `json:"plugins,omitempty"`
+
+	// Configurations contains Configuration as Code and groovy scripts which would be applied
+	// +optional
+	Configurations []PlannedConfigurationChange `json:"configurations,omitempty"`
+
+	// Messages contains validation errors of Jenkins CR and the reasons why a part of the plan can't be computed
+	// +optional
+	Messages []string `json:"messages,omitempty"`
+}
+
+// PluginAction defines the action which would be taken on the plugin.
+type PluginAction string
+
+const (
+	// PluginActionInstall - the plugin isn't installed in Jenkins
+	PluginActionInstall PluginAction = "Install"
+	// PluginActionUpgrade - the plugin is installed in older version
+	PluginActionUpgrade PluginAction = "Upgrade"
+	// PluginActionDowngrade - the plugin is installed in newer version
+	PluginActionDowngrade PluginAction = "Downgrade"
+)
+
+// PlannedPluginChange defines the plugin which would be changed.
+type PlannedPluginChange struct {
+	// Name is the name of Jenkins plugin
+	Name string `json:"name"`
+	// Action is the action which would be taken on the plugin: Install, Upgrade or Downgrade
+	Action PluginAction `json:"action"`
+	// CurrentVersion is the version of the plugin installed in Jenkins
+	// +optional
+	CurrentVersion string `json:"currentVersion,omitempty"`
+	// Version is the required version of the plugin
+	Version string `json:"version"`
+}
+
+// PlannedConfigurationChange defines the Configuration as Code or groovy script which would be applied.
+type PlannedConfigurationChange struct {
+	// ConfigurationType is the name of the configuration type(user-groovy, user-casc)
+	ConfigurationType string `json:"configurationType"`
+	// Source is the name of ConfigMap where is located the script
+	Source string `json:"source"`
+	// Name is the ConfigMap key of the script
+	Name string `json:"name"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status"
+// +kubebuilder:printcolumn:name="Reason",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].reason"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
@@ -596,6 +2130,35 @@ type SeedJob struct {
 	// UnstableOnDeprecation is setting for Job DSL API plugin that sets build status as unstable if build using deprecated features
 	// +optional
 	UnstableOnDeprecation bool `json:"unstableOnDeprecation"`
+
+	// Agent defines the dedicated agent the seed job runs on, the seed job runs on the agent shared by all seed jobs
+	// if it isn't set
+	// +optional
+	Agent *SeedJobAgent `json:"agent,omitempty"`
+
+	// Timeout is the time after which the seed job build is aborted, it requires build-timeout plugin
+	// and it's rounded up to minutes, the minimum is 3 minutes
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// SeedJobAgent defines the dedicated agent of the seed job created and deleted by the operator.
+type SeedJobAgent struct {
+	// Image is the image of the agent container
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// Resources are the compute resources of the agent container
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// NodeSelector of the agent pod, spec.master.nodeSelector is used if it isn't set
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations of the agent pod, spec.master.tolerations are used if they aren't set
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
 }
 
 // Handler defines a specific action that should be taken.
@@ -606,11 +2169,23 @@ type Handler struct {
 
 // Backup defines configuration of Jenkins backup.
 type Backup struct {
-	// ContainerName is the container name responsible for backup operation
-	ContainerName string `json:"containerName"`
+	// Provider is the name of the backup provider which performs backups and restores, providers other than exec
+	// can be registered in the operator build
+	// Defaults to exec.
+	// +optional
+	Provider string `json:"provider,omitempty"`
 
-	// Action defines action which performs backup in backup container sidecar
-	Action Handler `json:"action"`
+	// Parameters are passed to the backup provider other than exec, e.g. the bucket of the object store
+	// +optional
+	Parameters map[string]string `json:"parameters,omitempty"`
+
+	// ContainerName is the container name responsible for backup operation, required by exec provider
+	// +optional
+	ContainerName string `json:"containerName,omitempty"`
+
+	// Action defines action which performs backup in backup container sidecar, required by exec provider
+	// +optional
+	Action Handler `json:"action,omitempty"`
 
 	// Interval tells how often make backup in seconds
 	// Defaults to 30.
@@ -20,18 +20,24 @@ import (
 	"compress/gzip"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 	"os"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/jenkinsci/kubernetes-operator/pkg/log"
 	"github.com/jenkinsci/kubernetes-operator/pkg/plugins"
 
 	"golang.org/x/mod/semver"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 )
@@ -41,6 +47,9 @@ var (
 	SecValidator                                              = *NewSecurityValidator()
 	_                                       webhook.Validator = &Jenkins{}
 	initialSecurityWarningsDownloadSucceded                   = false
+
+	basePluginsReader    client.Reader
+	basePluginsConfigMap string
 )
 
 const (
@@ -57,11 +66,24 @@ func (in *Jenkins) SetupWebhookWithManager(mgr ctrl.Manager) error {
 		Complete()
 }
 
+// SetBasePluginsConfigMap sets ConfigMap with base plugin manifests per Jenkins version read by the webhook,
+// it's the same ConfigMap as the one of the reconciler so both select the same base plugins
+func SetBasePluginsConfigMap(reader client.Reader, name string) {
+	basePluginsReader = reader
+	basePluginsConfigMap = name
+}
+
 // TODO(user): change verbs to "verbs=create;update;delete" if you want to enable deletion validation.
 // +kubebuilder:webhook:path=/validate-jenkins-io-jenkins-io-v1alpha2-jenkins,mutating=false,failurePolicy=fail,sideEffects=None,groups=jenkins.io.jenkins.io,resources=jenkins,verbs=create;update,versions=v1alpha2,name=vjenkins.kb.io,admissionReviewVersions={v1,v1beta1}
 
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type
 func (in *Jenkins) ValidateCreate() error {
+	if err := validatePluginPolicy(*in); err != nil {
+		return err
+	}
+	if err := validateInlineSecrets(*in); err != nil {
+		return err
+	}
 	if in.Spec.ValidateSecurityWarnings {
 		jenkinslog.Info("validate create", "name", in.Name)
 		return Validate(*in)
@@ -72,6 +94,14 @@ func (in *Jenkins) ValidateCreate() error {
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
 func (in *Jenkins) ValidateUpdate(old runtime.Object) error {
+	if err := validatePluginPolicy(*in); err != nil {
+		return err
+	}
+	// the inline secrets which already exist don't block updates of the Jenkins CR, e.g. by the operator
+	oldJenkins, _ := old.(*Jenkins)
+	if err := validateNewInlineSecrets(*in, oldJenkins); err != nil {
+		return err
+	}
 	if in.Spec.ValidateSecurityWarnings {
 		jenkinslog.Info("validate update", "name", in.Name)
 		return Validate(*in)
@@ -128,6 +158,13 @@ func Validate(r Jenkins) error {
 	var faultyBasePlugins string
 	var faultyUserPlugins string
 	basePlugins := plugins.BasePlugins()
+	if len(r.Spec.Master.Containers) > 0 {
+		manifests, err := plugins.LoadBasePluginManifests(basePluginsReader, basePluginsConfigMap, r.Namespace)
+		if err != nil {
+			return err
+		}
+		basePlugins = manifests.ForImage(r.Spec.Master.Containers[0].Image).Plugins
+	}
 
 	for _, plugin := range basePlugins {
 		// Only Update the map if the plugin is not present or a lower version is being used
@@ -186,6 +223,134 @@ func Validate(r Jenkins) error {
 	return nil
 }
 
+// VerifyPluginPolicy returns messages about invalid spec.plugins.policy and about plugins
+// from spec.master.basePlugins and spec.master.plugins which violate it
+func (in *Jenkins) VerifyPluginPolicy() []string {
+	if in.Spec.Plugins == nil || in.Spec.Plugins.Policy == nil {
+		return nil
+	}
+
+	policy := in.Spec.Plugins.Policy.ToPolicy()
+	if messages := policy.Validate(); len(messages) > 0 {
+		return messages
+	}
+
+	return policy.Verify(in.RequiredPlugins())
+}
+
+// RequiredPlugins returns the plugins from spec.master.basePlugins and spec.master.plugins
+func (in *Jenkins) RequiredPlugins() []plugins.Plugin {
+	var requiredPlugins []plugins.Plugin
+	for _, plugin := range append(append([]Plugin{}, in.Spec.Master.BasePlugins...), in.Spec.Master.Plugins...) {
+		requiredPlugins = append(requiredPlugins, plugins.Plugin{Name: plugin.Name, Version: plugin.Version, DownloadURL: plugin.DownloadURL})
+	}
+	return requiredPlugins
+}
+
+// ToPolicy converts spec.plugins.policy to the policy verified by the plugins package
+func (in *PluginPolicy) ToPolicy() plugins.Policy {
+	policy := plugins.Policy{Allow: in.Allow, Deny: in.Deny}
+	for _, minimumVersion := range in.MinimumVersions {
+		policy.MinimumVersions = append(policy.MinimumVersions, plugins.MinimumVersion{Name: minimumVersion.Name, Version: minimumVersion.Version})
+	}
+	return policy
+}
+
+// validatePluginPolicy rejects Jenkins CR with plugins which violate spec.plugins.policy
+func validatePluginPolicy(r Jenkins) error {
+	if messages := r.VerifyPluginPolicy(); len(messages) > 0 {
+		return errors.New("plugin policy violations: " + strings.Join(messages, "; "))
+	}
+	return nil
+}
+
+// secretEnvNamePattern matches names of environment variables which carry secrets, the secret word has to end the name,
+// so e.g. TOKEN_TTL or SECRETS_DIR aren't matched
+var secretEnvNamePattern = regexp.MustCompile(`(?i)(^|_)(PASSWORD|PASSWD|SECRET|TOKEN|API_?KEY|PRIVATE_?KEY|ACCESS_?KEY|CREDENTIALS?)$`)
+
+// inlineSecret is the secret set inline in the Jenkins CR, the key identifies it regardless of its position in lists
+type inlineSecret struct {
+	key     string
+	message string
+}
+
+// VerifyInlineSecrets returns messages about secrets set inline in the Jenkins CR instead of being referenced
+// from Secrets, so the Jenkins CR can be safely stored in Git
+func (in *Jenkins) VerifyInlineSecrets() []string {
+	var messages []string
+	for _, secret := range in.inlineSecrets() {
+		messages = append(messages, secret.message)
+	}
+	return messages
+}
+
+func (in *Jenkins) inlineSecrets() []inlineSecret {
+	var secrets []inlineSecret
+	if proxy := in.Spec.Proxy; proxy != nil {
+		if hasURLCredentials(proxy.HTTPProxy) {
+			secrets = append(secrets, inlineSecret{key: "proxy/" + proxy.HTTPProxy, message: "spec.proxy.httpProxy contains inline credentials, use spec.proxy.credentials"})
+		}
+		if hasURLCredentials(proxy.HTTPSProxy) {
+			secrets = append(secrets, inlineSecret{key: "proxy/" + proxy.HTTPSProxy, message: "spec.proxy.httpsProxy contains inline credentials, use spec.proxy.credentials"})
+		}
+	}
+	for i, container := range in.Spec.Master.Containers {
+		secrets = append(secrets, verifyInlineSecretEnvs(fmt.Sprintf("spec.master.containers[%d]", i), "master/"+container.Name, container.Env)...)
+	}
+	if in.Spec.Agents != nil {
+		for i, template := range in.Spec.Agents.PodTemplates {
+			for j, container := range template.Containers {
+				secrets = append(secrets, verifyInlineSecretEnvs(fmt.Sprintf("spec.agents.podTemplates[%d].containers[%d]", i, j),
+					"agents/"+template.Name+"/"+container.Name, container.Env)...)
+			}
+		}
+	}
+	return secrets
+}
+
+func hasURLCredentials(rawURL string) bool {
+	parsed, err := url.Parse(rawURL)
+	return err == nil && parsed.User != nil
+}
+
+func verifyInlineSecretEnvs(field, key string, envs []corev1.EnvVar) []inlineSecret {
+	var secrets []inlineSecret
+	for i, env := range envs {
+		if len(env.Value) > 0 && secretEnvNamePattern.MatchString(env.Name) {
+			secrets = append(secrets, inlineSecret{
+				key:     key + "/" + env.Name + "=" + env.Value,
+				message: fmt.Sprintf("%s.env[%d] '%s' has inline value, use valueFrom.secretKeyRef", field, i, env.Name),
+			})
+		}
+	}
+	return secrets
+}
+
+// validateInlineSecrets rejects Jenkins CR with inline secrets
+func validateInlineSecrets(r Jenkins) error {
+	return validateNewInlineSecrets(r, nil)
+}
+
+// validateNewInlineSecrets rejects Jenkins CR with inline secrets which aren't set in the old Jenkins CR
+func validateNewInlineSecrets(r Jenkins, old *Jenkins) error {
+	existing := map[string]bool{}
+	if old != nil {
+		for _, secret := range old.inlineSecrets() {
+			existing[secret.key] = true
+		}
+	}
+	var messages []string
+	for _, secret := range r.inlineSecrets() {
+		if !existing[secret.key] {
+			messages = append(messages, secret.message)
+		}
+	}
+	if len(messages) > 0 {
+		return errors.New("inline secrets: " + strings.Join(messages, "; "))
+	}
+	return nil
+}
+
 // NewMonitor creates a new worker and instantiates all the data structures required
 func NewSecurityValidator() *SecurityValidator {
 	return &SecurityValidator{
@@ -0,0 +1,130 @@
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// JenkinsOperationSpec defines the one-shot action performed in Jenkins
+// +k8s:openapi-gen=true
+type JenkinsOperationSpec struct {
+	// JenkinsRef is the reference to Jenkins CR in the same namespace where the action is performed
+	JenkinsRef JenkinsRef `json:"jenkinsRef"`
+
+	// Action is the action performed once by the operator
+	// Backup - performs the backup with spec.backup.action outside of spec.backup.interval
+	// SafeRestart - restarts Jenkins when the running builds are completed
+	// ReloadCasc - applies Configuration as Code from spec.configurationAsCode again
+	// RerunSeedJobs - queues builds of all seed jobs from spec.seedJobs
+	// Groovy - runs spec.groovy in Jenkins script console
+	// +kubebuilder:validation:Enum=Backup;SafeRestart;ReloadCasc;RerunSeedJobs;Groovy
+	Action OperationAction `json:"action"`
+
+	// Groovy is the script run in Jenkins script console by Groovy action
+	// +optional
+	Groovy string `json:"groovy,omitempty"`
+
+	// Timeout tells how long the operator waits in seconds until Jenkins is ready and the action is completed
+	// Defaults to 900.
+	// +optional
+	Timeout uint64 `json:"timeout,omitempty"`
+
+	// TTLSecondsAfterFinished tells how long JenkinsOperation CR is kept after the action has succeeded or failed,
+	// JenkinsOperation CR isn't deleted if not set
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	TTLSecondsAfterFinished *int32 `json:"ttlSecondsAfterFinished,omitempty"`
+}
+
+// OperationAction defines the action performed by the operator.
+type OperationAction string
+
+const (
+	// BackupOperationAction performs the backup of Jenkins
+	BackupOperationAction OperationAction = "Backup"
+	// SafeRestartOperationAction restarts Jenkins when the running builds are completed
+	SafeRestartOperationAction OperationAction = "SafeRestart"
+	// ReloadCascOperationAction applies Configuration as Code again
+	ReloadCascOperationAction OperationAction = "ReloadCasc"
+	// RerunSeedJobsOperationAction queues builds of all seed jobs
+	RerunSeedJobsOperationAction OperationAction = "RerunSeedJobs"
+	// GroovyOperationAction runs the groovy script in Jenkins script console
+	GroovyOperationAction OperationAction = "Groovy"
+)
+
+// OperationPhase defines the phase of the action.
+type OperationPhase string
+
+const (
+	// OperationPhasePending means that the action waits for Jenkins to become ready
+	OperationPhasePending OperationPhase = "Pending"
+	// OperationPhaseRunning means that the action has been started and the operator waits for its result, it's recorded
+	// before the action is performed
+	OperationPhaseRunning OperationPhase = "Running"
+	// OperationPhaseSucceeded means that the action has been completed successfully
+	OperationPhaseSucceeded OperationPhase = "Succeeded"
+	// OperationPhaseFailed means that the action has failed or hasn't been completed before spec.timeout
+	OperationPhaseFailed OperationPhase = "Failed"
+)
+
+// JenkinsOperationStatus defines the observed state of the JenkinsOperation
+type JenkinsOperationStatus struct {
+	// Phase is the phase of the action
+	// +optional
+	Phase OperationPhase `json:"phase,omitempty"`
+
+	// StartTime is a time when the operator has started processing the action
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is a time when the action has succeeded or failed
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// BackupNumber is the number of backup performed by Backup action
+	// +optional
+	BackupNumber uint64 `json:"backupNumber,omitempty"`
+
+	// Output is the output of groovy script run by Groovy action, it's truncated to 4096 characters
+	// +optional
+	Output string `json:"output,omitempty"`
+
+	// Message describes the progress or the result of the action
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Jenkins",type="string",JSONPath=".spec.jenkinsRef.name"
+// +kubebuilder:printcolumn:name="Action",type="string",JSONPath=".spec.action"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.message",priority=1
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// JenkinsOperation is the Schema for the jenkinsoperations API
+// +k8s:openapi-gen=true
+type JenkinsOperation struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec defines the action performed in Jenkins
+	Spec JenkinsOperationSpec `json:"spec,omitempty"`
+
+	// Status defines the observed state of the action
+	Status JenkinsOperationStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// JenkinsOperationList contains a list of JenkinsOperation
+type JenkinsOperationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []JenkinsOperation `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&JenkinsOperation{}, &JenkinsOperationList{})
+}
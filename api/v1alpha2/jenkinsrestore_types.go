@@ -0,0 +1,212 @@
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// JenkinsRestoreSpec defines the desired state of the JenkinsRestore
+// +k8s:openapi-gen=true
+type JenkinsRestoreSpec struct {
+	// JenkinsRef is the reference to Jenkins CR in the same namespace which backups are restored
+	JenkinsRef JenkinsRef `json:"jenkinsRef"`
+
+	// Mode defines the restore operation performed by the operator
+	// Verify - the latest backup is periodically restored into a temporary Jenkins instance which is checked and deleted
+	// Restore - the backup is restored once into existing or new Jenkins CR
+	// +kubebuilder:validation:Enum=Verify;Restore
+	Mode RestoreMode `json:"mode"`
+
+	// Verification defines how the backup verification is performed
+	// +optional
+	Verification *RestoreVerification `json:"verification,omitempty"`
+
+	// Restore defines which backup is restored and where in Restore mode
+	// +optional
+	Restore *RestoreBackup `json:"restore,omitempty"`
+}
+
+// JenkinsRef is reference to Jenkins CR.
+type JenkinsRef struct {
+	Name string `json:"name"`
+}
+
+// RestoreMode defines the restore operation performed by the operator.
+type RestoreMode string
+
+const (
+	// VerifyRestoreMode restores the latest backup into a temporary Jenkins instance to check if it's usable
+	VerifyRestoreMode RestoreMode = "Verify"
+	// RestoreRestoreMode restores the backup into existing or new Jenkins CR
+	RestoreRestoreMode RestoreMode = "Restore"
+)
+
+// RestoreVerification defines configuration of the backup verification.
+type RestoreVerification struct {
+	// Interval tells how often the latest backup is verified in seconds, 0 means that backup is verified only once
+	// Defaults to 86400.
+	// +optional
+	Interval *uint64 `json:"interval,omitempty"`
+
+	// Timeout tells how long the operator waits in seconds until the temporary Jenkins instance is ready
+	// Defaults to 900.
+	// +optional
+	Timeout uint64 `json:"timeout,omitempty"`
+
+	// MinimumJobs is the minimum number of jobs which have to be present in the restored Jenkins
+	// +optional
+	MinimumJobs int32 `json:"minimumJobs,omitempty"`
+}
+
+// RestoreBackup defines configuration of the point-in-time restore.
+type RestoreBackup struct {
+	// BackupNumber is the number of backup to restore, the latest backup of spec.jenkinsRef is restored if not set
+	// +optional
+	BackupNumber uint64 `json:"backupNumber,omitempty"`
+
+	// Target defines Jenkins CR where the backup is restored, defaults to spec.jenkinsRef
+	// +optional
+	Target RestoreTarget `json:"target,omitempty"`
+
+	// Timeout tells how long the operator waits in seconds until the backup is restored and Jenkins is ready
+	// Defaults to 900.
+	// +optional
+	Timeout uint64 `json:"timeout,omitempty"`
+}
+
+// RestoreTarget defines Jenkins CR where the backup is restored.
+type RestoreTarget struct {
+	// Name is the name of Jenkins CR in the same namespace where the backup is restored
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Create tells the operator to create Jenkins CR from the spec of spec.jenkinsRef if it doesn't exist,
+	// backups of the created Jenkins are disabled until the jenkins.io/backups-disabled label is removed
+	// +optional
+	Create bool `json:"create,omitempty"`
+}
+
+// RestorePhase defines the phase of the restore operation.
+type RestorePhase string
+
+const (
+	// RestorePhasePending means that the restore operation waits for its turn
+	RestorePhasePending RestorePhase = "Pending"
+	// RestorePhaseRunning means that the restore operation is in progress
+	RestorePhaseRunning RestorePhase = "Running"
+	// RestorePhaseSucceeded means that the last restore operation has been completed successfully
+	RestorePhaseSucceeded RestorePhase = "Succeeded"
+	// RestorePhaseFailed means that the last restore operation has failed
+	RestorePhaseFailed RestorePhase = "Failed"
+)
+
+// RestoreVerificationResult is the result of single backup verification.
+type RestoreVerificationResult struct {
+	// BackupNumber is the number of verified backup
+	// +optional
+	BackupNumber uint64 `json:"backupNumber,omitempty"`
+
+	// StartTime is a time when the verification has been started
+	StartTime metav1.Time `json:"startTime"`
+
+	// CompletionTime is a time when the verification has been completed
+	CompletionTime metav1.Time `json:"completionTime"`
+
+	// Succeeded tells if the backup has been restored and the restored Jenkins passed the checks
+	Succeeded bool `json:"succeeded"`
+
+	// Jobs is the number of jobs found in the restored Jenkins
+	// +optional
+	Jobs int32 `json:"jobs,omitempty"`
+
+	// Message describes the result of the verification
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// JenkinsRestoreStatus defines the observed state of the JenkinsRestore
+// +k8s:openapi-gen=true
+type JenkinsRestoreStatus struct {
+	// Phase is the phase of the restore operation
+	// +optional
+	Phase RestorePhase `json:"phase,omitempty"`
+
+	// StartTime is a time when the current restore operation has been started
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// LastVerification is the result of the latest backup verification
+	// +optional
+	LastVerification *RestoreVerificationResult `json:"lastVerification,omitempty"`
+
+	// Verifications is the history of backup verifications, the latest verification is the last one
+	// +optional
+	Verifications []RestoreVerificationResult `json:"verifications,omitempty"`
+
+	// CompletionTime is a time when the restore has been completed in Restore mode
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// BackupNumber is the number of backup restored in Restore mode
+	// +optional
+	BackupNumber uint64 `json:"backupNumber,omitempty"`
+
+	// TargetName is the name of Jenkins CR where the backup is restored in Restore mode
+	// +optional
+	TargetName string `json:"targetName,omitempty"`
+
+	// TargetGeneration is the generation of Jenkins CR when the restore was requested in Restore mode
+	// +optional
+	TargetGeneration int64 `json:"targetGeneration,omitempty"`
+
+	// Message describes the progress or the result of the restore in Restore mode
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Conditions represent the latest available observations of the restore operation
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+const (
+	// BackupVerifiedConditionType tells if the latest backup has been restored and the restored Jenkins passed the checks
+	BackupVerifiedConditionType = "BackupVerified"
+	// BackupRestoredConditionType tells if the backup has been restored and Jenkins is ready
+	BackupRestoredConditionType = "BackupRestored"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Jenkins",type="string",JSONPath=".spec.jenkinsRef.name"
+// +kubebuilder:printcolumn:name="Mode",type="string",JSONPath=".spec.mode"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Backup",type="integer",JSONPath=".status.lastVerification.backupNumber",priority=1
+// +kubebuilder:printcolumn:name="Target",type="string",JSONPath=".status.targetName",priority=1
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// JenkinsRestore is the Schema for the jenkinsrestores API
+// +k8s:openapi-gen=true
+type JenkinsRestore struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec defines the desired state of the JenkinsRestore
+	Spec JenkinsRestoreSpec `json:"spec,omitempty"`
+
+	// Status defines the observed state of the JenkinsRestore
+	Status JenkinsRestoreStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// JenkinsRestoreList contains a list of JenkinsRestore
+type JenkinsRestoreList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []JenkinsRestore `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&JenkinsRestore{}, &JenkinsRestoreList{})
+}
@@ -0,0 +1,42 @@
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// JenkinsTemplateSpec defines the golden configuration of Jenkins instances created from the template
+// +k8s:openapi-gen=true
+type JenkinsTemplateSpec struct {
+	// Template is the spec of Jenkins CR merged with spec of Jenkins CRs which reference the template by spec.from,
+	// spec.from of the template is ignored
+	Template JenkinsSpec `json:"template"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// JenkinsTemplate is the Schema for the jenkinstemplates API
+// +k8s:openapi-gen=true
+type JenkinsTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec defines the golden configuration of Jenkins instances
+	Spec JenkinsTemplateSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// JenkinsTemplateList contains a list of JenkinsTemplate
+type JenkinsTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []JenkinsTemplate `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&JenkinsTemplate{}, &JenkinsTemplateList{})
+}
@@ -22,10 +22,124 @@ package v1alpha2
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentContainer) DeepCopyInto(out *AgentContainer) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentContainer.
+func (in *AgentContainer) DeepCopy() *AgentContainer {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentContainer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentPodTemplate) DeepCopyInto(out *AgentPodTemplate) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.Containers != nil {
+		in, out := &in.Containers, &out.Containers
+		*out = make([]AgentContainer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RunAsUser != nil {
+		in, out := &in.RunAsUser, &out.RunAsUser
+		*out = new(int64)
+		**out = **in
+	}
+	if in.RunAsGroup != nil {
+		in, out := &in.RunAsGroup, &out.RunAsGroup
+		*out = new(int64)
+		**out = **in
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentPodTemplate.
+func (in *AgentPodTemplate) DeepCopy() *AgentPodTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentPodTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Agents) DeepCopyInto(out *Agents) {
+	*out = *in
+	if in.PodTemplates != nil {
+		in, out := &in.PodTemplates, &out.PodTemplates
+		*out = make([]AgentPodTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MaxConcurrent != nil {
+		in, out := &in.MaxConcurrent, &out.MaxConcurrent
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Agents.
+func (in *Agents) DeepCopy() *Agents {
+	if in == nil {
+		return nil
+	}
+	out := new(Agents)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AppliedGroovyScript) DeepCopyInto(out *AppliedGroovyScript) {
 	*out = *in
@@ -41,9 +155,68 @@ func (in *AppliedGroovyScript) DeepCopy() *AppliedGroovyScript {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplyConflict) DeepCopyInto(out *ApplyConflict) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplyConflict.
+func (in *ApplyConflict) DeepCopy() *ApplyConflict {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplyConflict)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Audit) DeepCopyInto(out *Audit) {
+	*out = *in
+	if in.Webhook != nil {
+		in, out := &in.Webhook, &out.Webhook
+		*out = new(AuditWebhook)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Audit.
+func (in *Audit) DeepCopy() *Audit {
+	if in == nil {
+		return nil
+	}
+	out := new(Audit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditWebhook) DeepCopyInto(out *AuditWebhook) {
+	*out = *in
+	out.URLSecretKeySelector = in.URLSecretKeySelector
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditWebhook.
+func (in *AuditWebhook) DeepCopy() *AuditWebhook {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditWebhook)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Backup) DeepCopyInto(out *Backup) {
 	*out = *in
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	in.Action.DeepCopyInto(&out.Action)
 }
 
@@ -57,6 +230,65 @@ func (in *Backup) DeepCopy() *Backup {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuildLogArchival) DeepCopyInto(out *BuildLogArchival) {
+	*out = *in
+	if in.CredentialsSecret != nil {
+		in, out := &in.CredentialsSecret, &out.CredentialsSecret
+		*out = new(SecretRef)
+		**out = **in
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildLogArchival.
+func (in *BuildLogArchival) DeepCopy() *BuildLogArchival {
+	if in == nil {
+		return nil
+	}
+	out := new(BuildLogArchival)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Canary) DeepCopyInto(out *Canary) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Canary.
+func (in *Canary) DeepCopy() *Canary {
+	if in == nil {
+		return nil
+	}
+	out := new(Canary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CanaryStatus) DeepCopyInto(out *CanaryStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanaryStatus.
+func (in *CanaryStatus) DeepCopy() *CanaryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CanaryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConfigMapRef) DeepCopyInto(out *ConfigMapRef) {
 	*out = *in
@@ -161,469 +393,2118 @@ func (in *Container) DeepCopy() *Container {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Customization) DeepCopyInto(out *Customization) {
+func (in *ContainerStatus) DeepCopyInto(out *ContainerStatus) {
 	*out = *in
-	out.Secret = in.Secret
-	if in.Configurations != nil {
-		in, out := &in.Configurations, &out.Configurations
-		*out = make([]ConfigMapRef, len(*in))
-		copy(*out, *in)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Customization.
-func (in *Customization) DeepCopy() *Customization {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerStatus.
+func (in *ContainerStatus) DeepCopy() *ContainerStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(Customization)
+	out := new(ContainerStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GroovyScripts) DeepCopyInto(out *GroovyScripts) {
+func (in *CredentialsRotation) DeepCopyInto(out *CredentialsRotation) {
 	*out = *in
-	in.Customization.DeepCopyInto(&out.Customization)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroovyScripts.
-func (in *GroovyScripts) DeepCopy() *GroovyScripts {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialsRotation.
+func (in *CredentialsRotation) DeepCopy() *CredentialsRotation {
 	if in == nil {
 		return nil
 	}
-	out := new(GroovyScripts)
+	out := new(CredentialsRotation)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Handler) DeepCopyInto(out *Handler) {
+func (in *CustomNotification) DeepCopyInto(out *CustomNotification) {
 	*out = *in
-	if in.Exec != nil {
-		in, out := &in.Exec, &out.Exec
-		*out = new(corev1.ExecAction)
-		(*in).DeepCopyInto(*out)
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.SecretKeySelector != nil {
+		in, out := &in.SecretKeySelector, &out.SecretKeySelector
+		*out = new(SecretKeySelector)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Handler.
-func (in *Handler) DeepCopy() *Handler {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomNotification.
+func (in *CustomNotification) DeepCopy() *CustomNotification {
 	if in == nil {
 		return nil
 	}
-	out := new(Handler)
+	out := new(CustomNotification)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Jenkins) DeepCopyInto(out *Jenkins) {
+func (in *Customization) DeepCopyInto(out *Customization) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	out.Secret = in.Secret
+	if in.Configurations != nil {
+		in, out := &in.Configurations, &out.Configurations
+		*out = make([]ConfigMapRef, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Jenkins.
-func (in *Jenkins) DeepCopy() *Jenkins {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Customization.
+func (in *Customization) DeepCopy() *Customization {
 	if in == nil {
 		return nil
 	}
-	out := new(Jenkins)
+	out := new(Customization)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Jenkins) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeferredAction) DeepCopyInto(out *DeferredAction) {
+	*out = *in
+	in.DeferredSince.DeepCopyInto(&out.DeferredSince)
+	if in.NextWindow != nil {
+		in, out := &in.NextWindow, &out.NextWindow
+		*out = (*in).DeepCopy()
 	}
-	return nil
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeferredAction.
+func (in *DeferredAction) DeepCopy() *DeferredAction {
+	if in == nil {
+		return nil
+	}
+	out := new(DeferredAction)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *JenkinsAPISettings) DeepCopyInto(out *JenkinsAPISettings) {
+func (in *DiscardOldBuilds) DeepCopyInto(out *DiscardOldBuilds) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsAPISettings.
-func (in *JenkinsAPISettings) DeepCopy() *JenkinsAPISettings {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiscardOldBuilds.
+func (in *DiscardOldBuilds) DeepCopy() *DiscardOldBuilds {
 	if in == nil {
 		return nil
 	}
-	out := new(JenkinsAPISettings)
+	out := new(DiscardOldBuilds)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *JenkinsList) DeepCopyInto(out *JenkinsList) {
+func (in *DiskCleanup) DeepCopyInto(out *DiskCleanup) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]Jenkins, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	in.Time.DeepCopyInto(&out.Time)
+	if in.Policies != nil {
+		in, out := &in.Policies, &out.Policies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsList.
-func (in *JenkinsList) DeepCopy() *JenkinsList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiskCleanup.
+func (in *DiskCleanup) DeepCopy() *DiskCleanup {
 	if in == nil {
 		return nil
 	}
-	out := new(JenkinsList)
+	out := new(DiskCleanup)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *JenkinsList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *JenkinsMaster) DeepCopyInto(out *JenkinsMaster) {
+func (in *DiskCleanupPolicy) DeepCopyInto(out *DiskCleanupPolicy) {
 	*out = *in
-	if in.Annotations != nil {
-		in, out := &in.Annotations, &out.Annotations
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.Labels != nil {
-		in, out := &in.Labels, &out.Labels
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.DiscardOldBuilds != nil {
+		in, out := &in.DiscardOldBuilds, &out.DiscardOldBuilds
+		*out = new(DiscardOldBuilds)
+		**out = **in
 	}
-	if in.NodeSelector != nil {
-		in, out := &in.NodeSelector, &out.NodeSelector
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.CachePaths != nil {
+		in, out := &in.CachePaths, &out.CachePaths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.SecurityContext != nil {
-		in, out := &in.SecurityContext, &out.SecurityContext
-		*out = new(corev1.PodSecurityContext)
-		(*in).DeepCopyInto(*out)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiskCleanupPolicy.
+func (in *DiskCleanupPolicy) DeepCopy() *DiskCleanupPolicy {
+	if in == nil {
+		return nil
 	}
-	if in.Containers != nil {
-		in, out := &in.Containers, &out.Containers
-		*out = make([]Container, len(*in))
+	out := new(DiskCleanupPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiskUsage) DeepCopyInto(out *DiskUsage) {
+	*out = *in
+	if in.CleanupPolicies != nil {
+		in, out := &in.CleanupPolicies, &out.CleanupPolicies
+		*out = make([]DiskCleanupPolicy, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.ImagePullSecrets != nil {
-		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
-		*out = make([]corev1.LocalObjectReference, len(*in))
-		copy(*out, *in)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiskUsage.
+func (in *DiskUsage) DeepCopy() *DiskUsage {
+	if in == nil {
+		return nil
 	}
-	if in.Volumes != nil {
-		in, out := &in.Volumes, &out.Volumes
-		*out = make([]corev1.Volume, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	out := new(DiskUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiskUsageStatus) DeepCopyInto(out *DiskUsageStatus) {
+	*out = *in
+	if in.LastCheckTime != nil {
+		in, out := &in.LastCheckTime, &out.LastCheckTime
+		*out = (*in).DeepCopy()
 	}
-	if in.Tolerations != nil {
-		in, out := &in.Tolerations, &out.Tolerations
-		*out = make([]corev1.Toleration, len(*in))
+	if in.LastCleanup != nil {
+		in, out := &in.LastCleanup, &out.LastCleanup
+		*out = new(DiskCleanup)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiskUsageStatus.
+func (in *DiskUsageStatus) DeepCopy() *DiskUsageStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DiskUsageStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExporterStatus) DeepCopyInto(out *ExporterStatus) {
+	*out = *in
+	if in.LastScrapeTime != nil {
+		in, out := &in.LastScrapeTime, &out.LastScrapeTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExporterStatus.
+func (in *ExporterStatus) DeepCopy() *ExporterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ExporterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Folder) DeepCopyInto(out *Folder) {
+	*out = *in
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]FolderRole, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.BasePlugins != nil {
-		in, out := &in.BasePlugins, &out.BasePlugins
-		*out = make([]Plugin, len(*in))
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Folder.
+func (in *Folder) DeepCopy() *Folder {
+	if in == nil {
+		return nil
+	}
+	out := new(Folder)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FolderRole) DeepCopyInto(out *FolderRole) {
+	*out = *in
+	if in.Permissions != nil {
+		in, out := &in.Permissions, &out.Permissions
+		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.Plugins != nil {
-		in, out := &in.Plugins, &out.Plugins
-		*out = make([]Plugin, len(*in))
+	if in.Sids != nil {
+		in, out := &in.Sids, &out.Sids
+		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.HostAliases != nil {
-		in, out := &in.HostAliases, &out.HostAliases
-		*out = make([]corev1.HostAlias, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FolderRole.
+func (in *FolderRole) DeepCopy() *FolderRole {
+	if in == nil {
+		return nil
 	}
+	out := new(FolderRole)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsMaster.
-func (in *JenkinsMaster) DeepCopy() *JenkinsMaster {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GroovyScripts) DeepCopyInto(out *GroovyScripts) {
+	*out = *in
+	in.Customization.DeepCopyInto(&out.Customization)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroovyScripts.
+func (in *GroovyScripts) DeepCopy() *GroovyScripts {
 	if in == nil {
 		return nil
 	}
-	out := new(JenkinsMaster)
+	out := new(GroovyScripts)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *JenkinsSpec) DeepCopyInto(out *JenkinsSpec) {
+func (in *Handler) DeepCopyInto(out *Handler) {
 	*out = *in
-	in.Master.DeepCopyInto(&out.Master)
-	if in.SeedJobs != nil {
-		in, out := &in.SeedJobs, &out.SeedJobs
-		*out = make([]SeedJob, len(*in))
-		copy(*out, *in)
+	if in.Exec != nil {
+		in, out := &in.Exec, &out.Exec
+		*out = new(corev1.ExecAction)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.Notifications != nil {
-		in, out := &in.Notifications, &out.Notifications
-		*out = make([]Notification, len(*in))
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Handler.
+func (in *Handler) DeepCopy() *Handler {
+	if in == nil {
+		return nil
+	}
+	out := new(Handler)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InboundAgents) DeepCopyInto(out *InboundAgents) {
+	*out = *in
+	if in.TCPPort != nil {
+		in, out := &in.TCPPort, &out.TCPPort
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NetworkPolicy != nil {
+		in, out := &in.NetworkPolicy, &out.NetworkPolicy
+		*out = new(InboundAgentsNetworkPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InboundAgents.
+func (in *InboundAgents) DeepCopy() *InboundAgents {
+	if in == nil {
+		return nil
+	}
+	out := new(InboundAgents)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InboundAgentsNetworkPolicy) DeepCopyInto(out *InboundAgentsNetworkPolicy) {
+	*out = *in
+	if in.From != nil {
+		in, out := &in.From, &out.From
+		*out = make([]networkingv1.NetworkPolicyPeer, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	in.Service.DeepCopyInto(&out.Service)
-	in.SlaveService.DeepCopyInto(&out.SlaveService)
-	in.Backup.DeepCopyInto(&out.Backup)
-	in.Restore.DeepCopyInto(&out.Restore)
-	in.GroovyScripts.DeepCopyInto(&out.GroovyScripts)
-	in.ConfigurationAsCode.DeepCopyInto(&out.ConfigurationAsCode)
-	if in.Roles != nil {
-		in, out := &in.Roles, &out.Roles
-		*out = make([]rbacv1.RoleRef, len(*in))
-		copy(*out, *in)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InboundAgentsNetworkPolicy.
+func (in *InboundAgentsNetworkPolicy) DeepCopy() *InboundAgentsNetworkPolicy {
+	if in == nil {
+		return nil
 	}
-	in.ServiceAccount.DeepCopyInto(&out.ServiceAccount)
-	out.JenkinsAPISettings = in.JenkinsAPISettings
+	out := new(InboundAgentsNetworkPolicy)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsSpec.
-func (in *JenkinsSpec) DeepCopy() *JenkinsSpec {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JDKTool) DeepCopyInto(out *JDKTool) {
+	*out = *in
+	out.ToolInstallation = in.ToolInstallation
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JDKTool.
+func (in *JDKTool) DeepCopy() *JDKTool {
 	if in == nil {
 		return nil
 	}
-	out := new(JenkinsSpec)
+	out := new(JDKTool)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *JenkinsStatus) DeepCopyInto(out *JenkinsStatus) {
+func (in *JVM) DeepCopyInto(out *JVM) {
 	*out = *in
-	if in.ProvisionStartTime != nil {
-		in, out := &in.ProvisionStartTime, &out.ProvisionStartTime
-		*out = (*in).DeepCopy()
+	if in.InitialHeap != nil {
+		in, out := &in.InitialHeap, &out.InitialHeap
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.MaxHeap != nil {
+		in, out := &in.MaxHeap, &out.MaxHeap
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.MaxMetaspace != nil {
+		in, out := &in.MaxMetaspace, &out.MaxMetaspace
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.ExtraFlags != nil {
+		in, out := &in.ExtraFlags, &out.ExtraFlags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.BaseConfigurationCompletedTime != nil {
-		in, out := &in.BaseConfigurationCompletedTime, &out.BaseConfigurationCompletedTime
-		*out = (*in).DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JVM.
+func (in *JVM) DeepCopy() *JVM {
+	if in == nil {
+		return nil
 	}
-	if in.UserConfigurationCompletedTime != nil {
-		in, out := &in.UserConfigurationCompletedTime, &out.UserConfigurationCompletedTime
-		*out = (*in).DeepCopy()
+	out := new(JVM)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Jenkins) DeepCopyInto(out *Jenkins) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Jenkins.
+func (in *Jenkins) DeepCopy() *Jenkins {
+	if in == nil {
+		return nil
 	}
-	if in.CreatedSeedJobs != nil {
-		in, out := &in.CreatedSeedJobs, &out.CreatedSeedJobs
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	out := new(Jenkins)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Jenkins) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
 	}
-	if in.AppliedGroovyScripts != nil {
-		in, out := &in.AppliedGroovyScripts, &out.AppliedGroovyScripts
-		*out = make([]AppliedGroovyScript, len(*in))
-		copy(*out, *in)
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JenkinsAPISettings) DeepCopyInto(out *JenkinsAPISettings) {
+	*out = *in
+	if in.CredentialsRotation != nil {
+		in, out := &in.CredentialsRotation, &out.CredentialsRotation
+		*out = new(CredentialsRotation)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsAPISettings.
+func (in *JenkinsAPISettings) DeepCopy() *JenkinsAPISettings {
+	if in == nil {
+		return nil
+	}
+	out := new(JenkinsAPISettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JenkinsList) DeepCopyInto(out *JenkinsList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Jenkins, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsList.
+func (in *JenkinsList) DeepCopy() *JenkinsList {
+	if in == nil {
+		return nil
+	}
+	out := new(JenkinsList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *JenkinsList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JenkinsMaster) DeepCopyInto(out *JenkinsMaster) {
+	*out = *in
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(corev1.PodSecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InitContainers != nil {
+		in, out := &in.InitContainers, &out.InitContainers
+		*out = make([]Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Containers != nil {
+		in, out := &in.Containers, &out.Containers
+		*out = make([]Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]corev1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]corev1.Volume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.BasePlugins != nil {
+		in, out := &in.BasePlugins, &out.BasePlugins
+		*out = make([]Plugin, len(*in))
+		copy(*out, *in)
+	}
+	if in.Plugins != nil {
+		in, out := &in.Plugins, &out.Plugins
+		*out = make([]Plugin, len(*in))
+		copy(*out, *in)
+	}
+	if in.UpdateSites != nil {
+		in, out := &in.UpdateSites, &out.UpdateSites
+		*out = make([]UpdateSite, len(*in))
+		copy(*out, *in)
+	}
+	if in.HostAliases != nil {
+		in, out := &in.HostAliases, &out.HostAliases
+		*out = make([]corev1.HostAlias, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.JVM != nil {
+		in, out := &in.JVM, &out.JVM
+		*out = new(JVM)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsMaster.
+func (in *JenkinsMaster) DeepCopy() *JenkinsMaster {
+	if in == nil {
+		return nil
+	}
+	out := new(JenkinsMaster)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JenkinsOperation) DeepCopyInto(out *JenkinsOperation) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsOperation.
+func (in *JenkinsOperation) DeepCopy() *JenkinsOperation {
+	if in == nil {
+		return nil
+	}
+	out := new(JenkinsOperation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *JenkinsOperation) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JenkinsOperationList) DeepCopyInto(out *JenkinsOperationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]JenkinsOperation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsOperationList.
+func (in *JenkinsOperationList) DeepCopy() *JenkinsOperationList {
+	if in == nil {
+		return nil
+	}
+	out := new(JenkinsOperationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *JenkinsOperationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JenkinsOperationSpec) DeepCopyInto(out *JenkinsOperationSpec) {
+	*out = *in
+	out.JenkinsRef = in.JenkinsRef
+	if in.TTLSecondsAfterFinished != nil {
+		in, out := &in.TTLSecondsAfterFinished, &out.TTLSecondsAfterFinished
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsOperationSpec.
+func (in *JenkinsOperationSpec) DeepCopy() *JenkinsOperationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(JenkinsOperationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JenkinsOperationStatus) DeepCopyInto(out *JenkinsOperationStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsOperationStatus.
+func (in *JenkinsOperationStatus) DeepCopy() *JenkinsOperationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(JenkinsOperationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JenkinsPlan) DeepCopyInto(out *JenkinsPlan) {
+	*out = *in
+	if in.GeneratedTime != nil {
+		in, out := &in.GeneratedTime, &out.GeneratedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.PodRestartReasons != nil {
+		in, out := &in.PodRestartReasons, &out.PodRestartReasons
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Plugins != nil {
+		in, out := &in.Plugins, &out.Plugins
+		*out = make([]PlannedPluginChange, len(*in))
+		copy(*out, *in)
+	}
+	if in.Configurations != nil {
+		in, out := &in.Configurations, &out.Configurations
+		*out = make([]PlannedConfigurationChange, len(*in))
+		copy(*out, *in)
+	}
+	if in.Messages != nil {
+		in, out := &in.Messages, &out.Messages
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsPlan.
+func (in *JenkinsPlan) DeepCopy() *JenkinsPlan {
+	if in == nil {
+		return nil
+	}
+	out := new(JenkinsPlan)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JenkinsRef) DeepCopyInto(out *JenkinsRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsRef.
+func (in *JenkinsRef) DeepCopy() *JenkinsRef {
+	if in == nil {
+		return nil
+	}
+	out := new(JenkinsRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JenkinsRestore) DeepCopyInto(out *JenkinsRestore) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsRestore.
+func (in *JenkinsRestore) DeepCopy() *JenkinsRestore {
+	if in == nil {
+		return nil
+	}
+	out := new(JenkinsRestore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *JenkinsRestore) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JenkinsRestoreList) DeepCopyInto(out *JenkinsRestoreList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]JenkinsRestore, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsRestoreList.
+func (in *JenkinsRestoreList) DeepCopy() *JenkinsRestoreList {
+	if in == nil {
+		return nil
+	}
+	out := new(JenkinsRestoreList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *JenkinsRestoreList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JenkinsRestoreSpec) DeepCopyInto(out *JenkinsRestoreSpec) {
+	*out = *in
+	out.JenkinsRef = in.JenkinsRef
+	if in.Verification != nil {
+		in, out := &in.Verification, &out.Verification
+		*out = new(RestoreVerification)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Restore != nil {
+		in, out := &in.Restore, &out.Restore
+		*out = new(RestoreBackup)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsRestoreSpec.
+func (in *JenkinsRestoreSpec) DeepCopy() *JenkinsRestoreSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(JenkinsRestoreSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JenkinsRestoreStatus) DeepCopyInto(out *JenkinsRestoreStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastVerification != nil {
+		in, out := &in.LastVerification, &out.LastVerification
+		*out = new(RestoreVerificationResult)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Verifications != nil {
+		in, out := &in.Verifications, &out.Verifications
+		*out = make([]RestoreVerificationResult, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsRestoreStatus.
+func (in *JenkinsRestoreStatus) DeepCopy() *JenkinsRestoreStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(JenkinsRestoreStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JenkinsSpec) DeepCopyInto(out *JenkinsSpec) {
+	*out = *in
+	if in.From != nil {
+		in, out := &in.From, &out.From
+		*out = new(JenkinsTemplateRef)
+		**out = **in
+	}
+	in.Master.DeepCopyInto(&out.Master)
+	if in.SeedJobs != nil {
+		in, out := &in.SeedJobs, &out.SeedJobs
+		*out = make([]SeedJob, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Notifications != nil {
+		in, out := &in.Notifications, &out.Notifications
+		*out = make([]Notification, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.Service.DeepCopyInto(&out.Service)
+	in.SlaveService.DeepCopyInto(&out.SlaveService)
+	in.Backup.DeepCopyInto(&out.Backup)
+	in.Restore.DeepCopyInto(&out.Restore)
+	in.GroovyScripts.DeepCopyInto(&out.GroovyScripts)
+	in.ConfigurationAsCode.DeepCopyInto(&out.ConfigurationAsCode)
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]rbacv1.RoleRef, len(*in))
+		copy(*out, *in)
+	}
+	in.ServiceAccount.DeepCopyInto(&out.ServiceAccount)
+	in.JenkinsAPISettings.DeepCopyInto(&out.JenkinsAPISettings)
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(Proxy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TrustedCABundle != nil {
+		in, out := &in.TrustedCABundle, &out.TrustedCABundle
+		*out = new(TrustedCABundle)
+		**out = **in
+	}
+	if in.BuildLogArchival != nil {
+		in, out := &in.BuildLogArchival, &out.BuildLogArchival
+		*out = new(BuildLogArchival)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PluginUpdates != nil {
+		in, out := &in.PluginUpdates, &out.PluginUpdates
+		*out = new(PluginUpdates)
+		**out = **in
+	}
+	if in.Plugins != nil {
+		in, out := &in.Plugins, &out.Plugins
+		*out = new(Plugins)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UpdateCenterMirror != nil {
+		in, out := &in.UpdateCenterMirror, &out.UpdateCenterMirror
+		*out = new(UpdateCenterMirror)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tools != nil {
+		in, out := &in.Tools, &out.Tools
+		*out = new(Tools)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SharedLibraries != nil {
+		in, out := &in.SharedLibraries, &out.SharedLibraries
+		*out = make([]SharedLibrary, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Folders != nil {
+		in, out := &in.Folders, &out.Folders
+		*out = make([]Folder, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Views != nil {
+		in, out := &in.Views, &out.Views
+		*out = make([]View, len(*in))
+		copy(*out, *in)
+	}
+	if in.Persistence != nil {
+		in, out := &in.Persistence, &out.Persistence
+		*out = new(Persistence)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DiskUsage != nil {
+		in, out := &in.DiskUsage, &out.DiskUsage
+		*out = new(DiskUsage)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResourceUsage != nil {
+		in, out := &in.ResourceUsage, &out.ResourceUsage
+		*out = new(ResourceUsage)
+		**out = **in
+	}
+	if in.Agents != nil {
+		in, out := &in.Agents, &out.Agents
+		*out = new(Agents)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Monitoring != nil {
+		in, out := &in.Monitoring, &out.Monitoring
+		*out = new(Monitoring)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(Canary)
+		**out = **in
+	}
+	if in.OpenShift != nil {
+		in, out := &in.OpenShift, &out.OpenShift
+		*out = new(OpenShift)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InboundAgents != nil {
+		in, out := &in.InboundAgents, &out.InboundAgents
+		*out = new(InboundAgents)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ScriptApproval != nil {
+		in, out := &in.ScriptApproval, &out.ScriptApproval
+		*out = new(ScriptApproval)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaintenanceWindows != nil {
+		in, out := &in.MaintenanceWindows, &out.MaintenanceWindows
+		*out = make([]MaintenanceWindow, len(*in))
+		copy(*out, *in)
+	}
+	if in.Audit != nil {
+		in, out := &in.Audit, &out.Audit
+		*out = new(Audit)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsSpec.
+func (in *JenkinsSpec) DeepCopy() *JenkinsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(JenkinsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JenkinsStatus) DeepCopyInto(out *JenkinsStatus) {
+	*out = *in
+	if in.ProvisionStartTime != nil {
+		in, out := &in.ProvisionStartTime, &out.ProvisionStartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.BaseConfigurationCompletedTime != nil {
+		in, out := &in.BaseConfigurationCompletedTime, &out.BaseConfigurationCompletedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.UserConfigurationCompletedTime != nil {
+		in, out := &in.UserConfigurationCompletedTime, &out.UserConfigurationCompletedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastBackupTime != nil {
+		in, out := &in.LastBackupTime, &out.LastBackupTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Containers != nil {
+		in, out := &in.Containers, &out.Containers
+		*out = make([]ContainerStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastCredentialsRotationTime != nil {
+		in, out := &in.LastCredentialsRotationTime, &out.LastCredentialsRotationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CreatedSeedJobs != nil {
+		in, out := &in.CreatedSeedJobs, &out.CreatedSeedJobs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AppliedGroovyScripts != nil {
+		in, out := &in.AppliedGroovyScripts, &out.AppliedGroovyScripts
+		*out = make([]AppliedGroovyScript, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PluginUpdates != nil {
+		in, out := &in.PluginUpdates, &out.PluginUpdates
+		*out = new(PluginUpdatesStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Plan != nil {
+		in, out := &in.Plan, &out.Plan
+		*out = new(JenkinsPlan)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Persistence != nil {
+		in, out := &in.Persistence, &out.Persistence
+		*out = new(PersistenceStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DiskUsage != nil {
+		in, out := &in.DiskUsage, &out.DiskUsage
+		*out = new(DiskUsageStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Exporter != nil {
+		in, out := &in.Exporter, &out.Exporter
+		*out = new(ExporterStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResourceUsage != nil {
+		in, out := &in.ResourceUsage, &out.ResourceUsage
+		*out = new(ResourceUsageStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UpdateCenterMirror != nil {
+		in, out := &in.UpdateCenterMirror, &out.UpdateCenterMirror
+		*out = new(UpdateCenterMirrorStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(CanaryStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PluginBatch != nil {
+		in, out := &in.PluginBatch, &out.PluginBatch
+		*out = new(PluginBatchStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ApplyConflicts != nil {
+		in, out := &in.ApplyConflicts, &out.ApplyConflicts
+		*out = make([]ApplyConflict, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PendingScriptApprovals != nil {
+		in, out := &in.PendingScriptApprovals, &out.PendingScriptApprovals
+		*out = make([]PendingScriptApproval, len(*in))
+		copy(*out, *in)
+	}
+	if in.ScriptApprovalCheck != nil {
+		in, out := &in.ScriptApprovalCheck, &out.ScriptApprovalCheck
+		*out = new(ScriptApprovalCheck)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DeferredActions != nil {
+		in, out := &in.DeferredActions, &out.DeferredActions
+		*out = make([]DeferredAction, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsStatus.
+func (in *JenkinsStatus) DeepCopy() *JenkinsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(JenkinsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JenkinsTemplate) DeepCopyInto(out *JenkinsTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsTemplate.
+func (in *JenkinsTemplate) DeepCopy() *JenkinsTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(JenkinsTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *JenkinsTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JenkinsTemplateList) DeepCopyInto(out *JenkinsTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]JenkinsTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsTemplateList.
+func (in *JenkinsTemplateList) DeepCopy() *JenkinsTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(JenkinsTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *JenkinsTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JenkinsTemplateRef) DeepCopyInto(out *JenkinsTemplateRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsTemplateRef.
+func (in *JenkinsTemplateRef) DeepCopy() *JenkinsTemplateRef {
+	if in == nil {
+		return nil
+	}
+	out := new(JenkinsTemplateRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JenkinsTemplateSpec) DeepCopyInto(out *JenkinsTemplateSpec) {
+	*out = *in
+	in.Template.DeepCopyInto(&out.Template)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsTemplateSpec.
+func (in *JenkinsTemplateSpec) DeepCopy() *JenkinsTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(JenkinsTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Mailgun) DeepCopyInto(out *Mailgun) {
+	*out = *in
+	out.APIKeySecretKeySelector = in.APIKeySecretKeySelector
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Mailgun.
+func (in *Mailgun) DeepCopy() *Mailgun {
+	if in == nil {
+		return nil
+	}
+	out := new(Mailgun)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MavenTool) DeepCopyInto(out *MavenTool) {
+	*out = *in
+	out.ToolInstallation = in.ToolInstallation
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MavenTool.
+func (in *MavenTool) DeepCopy() *MavenTool {
+	if in == nil {
+		return nil
+	}
+	out := new(MavenTool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricsExporter) DeepCopyInto(out *MetricsExporter) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricsExporter.
+func (in *MetricsExporter) DeepCopy() *MetricsExporter {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricsExporter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MicrosoftTeams) DeepCopyInto(out *MicrosoftTeams) {
+	*out = *in
+	out.WebHookURLSecretKeySelector = in.WebHookURLSecretKeySelector
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MicrosoftTeams.
+func (in *MicrosoftTeams) DeepCopy() *MicrosoftTeams {
+	if in == nil {
+		return nil
+	}
+	out := new(MicrosoftTeams)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Monitoring) DeepCopyInto(out *Monitoring) {
+	*out = *in
+	if in.RuleLabels != nil {
+		in, out := &in.RuleLabels, &out.RuleLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.DashboardLabels != nil {
+		in, out := &in.DashboardLabels, &out.DashboardLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Exporter != nil {
+		in, out := &in.Exporter, &out.Exporter
+		*out = new(MetricsExporter)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Monitoring.
+func (in *Monitoring) DeepCopy() *Monitoring {
+	if in == nil {
+		return nil
+	}
+	out := new(Monitoring)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeJSTool) DeepCopyInto(out *NodeJSTool) {
+	*out = *in
+	out.ToolInstallation = in.ToolInstallation
+	if in.GlobalPackages != nil {
+		in, out := &in.GlobalPackages, &out.GlobalPackages
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeJSTool.
+func (in *NodeJSTool) DeepCopy() *NodeJSTool {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeJSTool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Notification) DeepCopyInto(out *Notification) {
+	*out = *in
+	if in.Slack != nil {
+		in, out := &in.Slack, &out.Slack
+		*out = new(Slack)
+		**out = **in
+	}
+	if in.Teams != nil {
+		in, out := &in.Teams, &out.Teams
+		*out = new(MicrosoftTeams)
+		**out = **in
+	}
+	if in.Mailgun != nil {
+		in, out := &in.Mailgun, &out.Mailgun
+		*out = new(Mailgun)
+		**out = **in
+	}
+	if in.SMTP != nil {
+		in, out := &in.SMTP, &out.SMTP
+		*out = new(SMTP)
+		**out = **in
+	}
+	if in.Custom != nil {
+		in, out := &in.Custom, &out.Custom
+		*out = new(CustomNotification)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Notification.
+func (in *Notification) DeepCopy() *Notification {
+	if in == nil {
+		return nil
+	}
+	out := new(Notification)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenShift) DeepCopyInto(out *OpenShift) {
+	*out = *in
+	if in.SecurityContextConstraints != nil {
+		in, out := &in.SecurityContextConstraints, &out.SecurityContextConstraints
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.Route.DeepCopyInto(&out.Route)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenShift.
+func (in *OpenShift) DeepCopy() *OpenShift {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenShift)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PendingScriptApproval) DeepCopyInto(out *PendingScriptApproval) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PendingScriptApproval.
+func (in *PendingScriptApproval) DeepCopy() *PendingScriptApproval {
+	if in == nil {
+		return nil
+	}
+	out := new(PendingScriptApproval)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Persistence) DeepCopyInto(out *Persistence) {
+	*out = *in
+	out.Size = in.Size.DeepCopy()
+	if in.StorageClassName != nil {
+		in, out := &in.StorageClassName, &out.StorageClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.VolumeMode != nil {
+		in, out := &in.VolumeMode, &out.VolumeMode
+		*out = new(corev1.PersistentVolumeMode)
+		**out = **in
+	}
+	if in.AccessModes != nil {
+		in, out := &in.AccessModes, &out.AccessModes
+		*out = make([]corev1.PersistentVolumeAccessMode, len(*in))
+		copy(*out, *in)
+	}
+	out.Migration = in.Migration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Persistence.
+func (in *Persistence) DeepCopy() *Persistence {
+	if in == nil {
+		return nil
+	}
+	out := new(Persistence)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PersistenceMigration) DeepCopyInto(out *PersistenceMigration) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PersistenceMigration.
+func (in *PersistenceMigration) DeepCopy() *PersistenceMigration {
+	if in == nil {
+		return nil
+	}
+	out := new(PersistenceMigration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PersistenceMigrationSettings) DeepCopyInto(out *PersistenceMigrationSettings) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PersistenceMigrationSettings.
+func (in *PersistenceMigrationSettings) DeepCopy() *PersistenceMigrationSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(PersistenceMigrationSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PersistenceStatus) DeepCopyInto(out *PersistenceStatus) {
+	*out = *in
+	if in.Capacity != nil {
+		in, out := &in.Capacity, &out.Capacity
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Migration != nil {
+		in, out := &in.Migration, &out.Migration
+		*out = new(PersistenceMigration)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PersistenceStatus.
+func (in *PersistenceStatus) DeepCopy() *PersistenceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PersistenceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlannedConfigurationChange) DeepCopyInto(out *PlannedConfigurationChange) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlannedConfigurationChange.
+func (in *PlannedConfigurationChange) DeepCopy() *PlannedConfigurationChange {
+	if in == nil {
+		return nil
+	}
+	out := new(PlannedConfigurationChange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlannedPluginChange) DeepCopyInto(out *PlannedPluginChange) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlannedPluginChange.
+func (in *PlannedPluginChange) DeepCopy() *PlannedPluginChange {
+	if in == nil {
+		return nil
+	}
+	out := new(PlannedPluginChange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Plugin) DeepCopyInto(out *Plugin) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Plugin.
+func (in *Plugin) DeepCopy() *Plugin {
+	if in == nil {
+		return nil
+	}
+	out := new(Plugin)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PluginAutoUpgrade) DeepCopyInto(out *PluginAutoUpgrade) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Plugins != nil {
+		in, out := &in.Plugins, &out.Plugins
+		*out = make([]PluginUpgrade, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginAutoUpgrade.
+func (in *PluginAutoUpgrade) DeepCopy() *PluginAutoUpgrade {
+	if in == nil {
+		return nil
+	}
+	out := new(PluginAutoUpgrade)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PluginBatchStatus) DeepCopyInto(out *PluginBatchStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.RestartTime != nil {
+		in, out := &in.RestartTime, &out.RestartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Plugins != nil {
+		in, out := &in.Plugins, &out.Plugins
+		*out = make([]PluginChangeStatus, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginBatchStatus.
+func (in *PluginBatchStatus) DeepCopy() *PluginBatchStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PluginBatchStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PluginChangeStatus) DeepCopyInto(out *PluginChangeStatus) {
+	*out = *in
+	out.PlannedPluginChange = in.PlannedPluginChange
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginChangeStatus.
+func (in *PluginChangeStatus) DeepCopy() *PluginChangeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PluginChangeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PluginData) DeepCopyInto(out *PluginData) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginData.
+func (in *PluginData) DeepCopy() *PluginData {
+	if in == nil {
+		return nil
+	}
+	out := new(PluginData)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PluginInfo) DeepCopyInto(out *PluginInfo) {
+	*out = *in
+	if in.SecurityWarnings != nil {
+		in, out := &in.SecurityWarnings, &out.SecurityWarnings
+		*out = make([]Warning, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginInfo.
+func (in *PluginInfo) DeepCopy() *PluginInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(PluginInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PluginMinimumVersion) DeepCopyInto(out *PluginMinimumVersion) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginMinimumVersion.
+func (in *PluginMinimumVersion) DeepCopy() *PluginMinimumVersion {
+	if in == nil {
+		return nil
+	}
+	out := new(PluginMinimumVersion)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PluginPolicy) DeepCopyInto(out *PluginPolicy) {
+	*out = *in
+	if in.Allow != nil {
+		in, out := &in.Allow, &out.Allow
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Deny != nil {
+		in, out := &in.Deny, &out.Deny
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MinimumVersions != nil {
+		in, out := &in.MinimumVersions, &out.MinimumVersions
+		*out = make([]PluginMinimumVersion, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginPolicy.
+func (in *PluginPolicy) DeepCopy() *PluginPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(PluginPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PluginUpdates) DeepCopyInto(out *PluginUpdates) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginUpdates.
+func (in *PluginUpdates) DeepCopy() *PluginUpdates {
+	if in == nil {
+		return nil
+	}
+	out := new(PluginUpdates)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PluginUpdatesStatus) DeepCopyInto(out *PluginUpdatesStatus) {
+	*out = *in
+	if in.LastCheckTime != nil {
+		in, out := &in.LastCheckTime, &out.LastCheckTime
+		*out = (*in).DeepCopy()
+	}
+	if in.AvailableUpgrades != nil {
+		in, out := &in.AvailableUpgrades, &out.AvailableUpgrades
+		*out = make([]PluginUpgrade, len(*in))
+		copy(*out, *in)
+	}
+	if in.PendingUpgrade != nil {
+		in, out := &in.PendingUpgrade, &out.PendingUpgrade
+		*out = new(PluginAutoUpgrade)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastUpgrade != nil {
+		in, out := &in.LastUpgrade, &out.LastUpgrade
+		*out = new(PluginAutoUpgrade)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginUpdatesStatus.
+func (in *PluginUpdatesStatus) DeepCopy() *PluginUpdatesStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PluginUpdatesStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PluginUpgrade) DeepCopyInto(out *PluginUpgrade) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginUpgrade.
+func (in *PluginUpgrade) DeepCopy() *PluginUpgrade {
+	if in == nil {
+		return nil
+	}
+	out := new(PluginUpgrade)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Plugins) DeepCopyInto(out *Plugins) {
+	*out = *in
+	if in.Policy != nil {
+		in, out := &in.Policy, &out.Policy
+		*out = new(PluginPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Plugins.
+func (in *Plugins) DeepCopy() *Plugins {
+	if in == nil {
+		return nil
+	}
+	out := new(Plugins)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PluginsInfo) DeepCopyInto(out *PluginsInfo) {
+	*out = *in
+	if in.Plugins != nil {
+		in, out := &in.Plugins, &out.Plugins
+		*out = make([]PluginInfo, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginsInfo.
+func (in *PluginsInfo) DeepCopy() *PluginsInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(PluginsInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodsResourceUsage) DeepCopyInto(out *PodsResourceUsage) {
+	*out = *in
+	out.CPURequests = in.CPURequests.DeepCopy()
+	out.MemoryRequests = in.MemoryRequests.DeepCopy()
+	if in.CPUUsage != nil {
+		in, out := &in.CPUUsage, &out.CPUUsage
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.MemoryUsage != nil {
+		in, out := &in.MemoryUsage, &out.MemoryUsage
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodsResourceUsage.
+func (in *PodsResourceUsage) DeepCopy() *PodsResourceUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(PodsResourceUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Proxy) DeepCopyInto(out *Proxy) {
+	*out = *in
+	if in.NoProxy != nil {
+		in, out := &in.NoProxy, &out.NoProxy
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Credentials != nil {
+		in, out := &in.Credentials, &out.Credentials
+		*out = new(ProxyCredentials)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Proxy.
+func (in *Proxy) DeepCopy() *Proxy {
+	if in == nil {
+		return nil
+	}
+	out := new(Proxy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyCredentials) DeepCopyInto(out *ProxyCredentials) {
+	*out = *in
+	out.UsernameSecretKeySelector = in.UsernameSecretKeySelector
+	out.PasswordSecretKeySelector = in.PasswordSecretKeySelector
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyCredentials.
+func (in *ProxyCredentials) DeepCopy() *ProxyCredentials {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyCredentials)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceUsage) DeepCopyInto(out *ResourceUsage) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceUsage.
+func (in *ResourceUsage) DeepCopy() *ResourceUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceUsageStatus) DeepCopyInto(out *ResourceUsageStatus) {
+	*out = *in
+	if in.LastCheckTime != nil {
+		in, out := &in.LastCheckTime, &out.LastCheckTime
+		*out = (*in).DeepCopy()
 	}
+	in.Master.DeepCopyInto(&out.Master)
+	in.Agents.DeepCopyInto(&out.Agents)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JenkinsStatus.
-func (in *JenkinsStatus) DeepCopy() *JenkinsStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceUsageStatus.
+func (in *ResourceUsageStatus) DeepCopy() *ResourceUsageStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(JenkinsStatus)
+	out := new(ResourceUsageStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Mailgun) DeepCopyInto(out *Mailgun) {
+func (in *Restore) DeepCopyInto(out *Restore) {
 	*out = *in
-	out.APIKeySecretKeySelector = in.APIKeySecretKeySelector
+	in.Action.DeepCopyInto(&out.Action)
+	in.GetLatestAction.DeepCopyInto(&out.GetLatestAction)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Mailgun.
-func (in *Mailgun) DeepCopy() *Mailgun {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Restore.
+func (in *Restore) DeepCopy() *Restore {
 	if in == nil {
 		return nil
 	}
-	out := new(Mailgun)
+	out := new(Restore)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MicrosoftTeams) DeepCopyInto(out *MicrosoftTeams) {
+func (in *RestoreBackup) DeepCopyInto(out *RestoreBackup) {
 	*out = *in
-	out.WebHookURLSecretKeySelector = in.WebHookURLSecretKeySelector
+	out.Target = in.Target
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MicrosoftTeams.
-func (in *MicrosoftTeams) DeepCopy() *MicrosoftTeams {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreBackup.
+func (in *RestoreBackup) DeepCopy() *RestoreBackup {
 	if in == nil {
 		return nil
 	}
-	out := new(MicrosoftTeams)
+	out := new(RestoreBackup)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Notification) DeepCopyInto(out *Notification) {
+func (in *RestoreTarget) DeepCopyInto(out *RestoreTarget) {
 	*out = *in
-	if in.Slack != nil {
-		in, out := &in.Slack, &out.Slack
-		*out = new(Slack)
-		**out = **in
-	}
-	if in.Teams != nil {
-		in, out := &in.Teams, &out.Teams
-		*out = new(MicrosoftTeams)
-		**out = **in
-	}
-	if in.Mailgun != nil {
-		in, out := &in.Mailgun, &out.Mailgun
-		*out = new(Mailgun)
-		**out = **in
-	}
-	if in.SMTP != nil {
-		in, out := &in.SMTP, &out.SMTP
-		*out = new(SMTP)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Notification.
-func (in *Notification) DeepCopy() *Notification {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreTarget.
+func (in *RestoreTarget) DeepCopy() *RestoreTarget {
 	if in == nil {
 		return nil
 	}
-	out := new(Notification)
+	out := new(RestoreTarget)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Plugin) DeepCopyInto(out *Plugin) {
+func (in *RestoreVerification) DeepCopyInto(out *RestoreVerification) {
 	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(uint64)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Plugin.
-func (in *Plugin) DeepCopy() *Plugin {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreVerification.
+func (in *RestoreVerification) DeepCopy() *RestoreVerification {
 	if in == nil {
 		return nil
 	}
-	out := new(Plugin)
+	out := new(RestoreVerification)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PluginData) DeepCopyInto(out *PluginData) {
+func (in *RestoreVerificationResult) DeepCopyInto(out *RestoreVerificationResult) {
 	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	in.CompletionTime.DeepCopyInto(&out.CompletionTime)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginData.
-func (in *PluginData) DeepCopy() *PluginData {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreVerificationResult.
+func (in *RestoreVerificationResult) DeepCopy() *RestoreVerificationResult {
 	if in == nil {
 		return nil
 	}
-	out := new(PluginData)
+	out := new(RestoreVerificationResult)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PluginInfo) DeepCopyInto(out *PluginInfo) {
+func (in *Route) DeepCopyInto(out *Route) {
 	*out = *in
-	if in.SecurityWarnings != nil {
-		in, out := &in.SecurityWarnings, &out.SecurityWarnings
-		*out = make([]Warning, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginInfo.
-func (in *PluginInfo) DeepCopy() *PluginInfo {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Route.
+func (in *Route) DeepCopy() *Route {
 	if in == nil {
 		return nil
 	}
-	out := new(PluginInfo)
+	out := new(Route)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PluginsInfo) DeepCopyInto(out *PluginsInfo) {
+func (in *SMTP) DeepCopyInto(out *SMTP) {
 	*out = *in
-	if in.Plugins != nil {
-		in, out := &in.Plugins, &out.Plugins
-		*out = make([]PluginInfo, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
+	out.UsernameSecretKeySelector = in.UsernameSecretKeySelector
+	out.PasswordSecretKeySelector = in.PasswordSecretKeySelector
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginsInfo.
-func (in *PluginsInfo) DeepCopy() *PluginsInfo {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SMTP.
+func (in *SMTP) DeepCopy() *SMTP {
 	if in == nil {
 		return nil
 	}
-	out := new(PluginsInfo)
+	out := new(SMTP)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Restore) DeepCopyInto(out *Restore) {
+func (in *ScriptApproval) DeepCopyInto(out *ScriptApproval) {
 	*out = *in
-	in.Action.DeepCopyInto(&out.Action)
-	in.GetLatestAction.DeepCopyInto(&out.GetLatestAction)
+	if in.Signatures != nil {
+		in, out := &in.Signatures, &out.Signatures
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ScriptHashes != nil {
+		in, out := &in.ScriptHashes, &out.ScriptHashes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Restore.
-func (in *Restore) DeepCopy() *Restore {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScriptApproval.
+func (in *ScriptApproval) DeepCopy() *ScriptApproval {
 	if in == nil {
 		return nil
 	}
-	out := new(Restore)
+	out := new(ScriptApproval)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SMTP) DeepCopyInto(out *SMTP) {
+func (in *ScriptApprovalCheck) DeepCopyInto(out *ScriptApprovalCheck) {
 	*out = *in
-	out.UsernameSecretKeySelector = in.UsernameSecretKeySelector
-	out.PasswordSecretKeySelector = in.PasswordSecretKeySelector
+	in.LastCheckTime.DeepCopyInto(&out.LastCheckTime)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SMTP.
-func (in *SMTP) DeepCopy() *SMTP {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScriptApprovalCheck.
+func (in *ScriptApprovalCheck) DeepCopy() *ScriptApprovalCheck {
 	if in == nil {
 		return nil
 	}
-	out := new(SMTP)
+	out := new(ScriptApprovalCheck)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -678,6 +2559,16 @@ func (in *SecurityValidator) DeepCopy() *SecurityValidator {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SeedJob) DeepCopyInto(out *SeedJob) {
 	*out = *in
+	if in.Agent != nil {
+		in, out := &in.Agent, &out.Agent
+		*out = new(SeedJobAgent)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SeedJob.
@@ -690,6 +2581,36 @@ func (in *SeedJob) DeepCopy() *SeedJob {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SeedJobAgent) DeepCopyInto(out *SeedJobAgent) {
+	*out = *in
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SeedJobAgent.
+func (in *SeedJobAgent) DeepCopy() *SeedJobAgent {
+	if in == nil {
+		return nil
+	}
+	out := new(SeedJobAgent)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Service) DeepCopyInto(out *Service) {
 	*out = *in
@@ -712,6 +2633,16 @@ func (in *Service) DeepCopyInto(out *Service) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.IPFamilyPolicy != nil {
+		in, out := &in.IPFamilyPolicy, &out.IPFamilyPolicy
+		*out = new(corev1.IPFamilyPolicyType)
+		**out = **in
+	}
+	if in.IPFamilies != nil {
+		in, out := &in.IPFamilies, &out.IPFamilies
+		*out = make([]corev1.IPFamily, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Service.
@@ -746,6 +2677,46 @@ func (in *ServiceAccount) DeepCopy() *ServiceAccount {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SharedLibrary) DeepCopyInto(out *SharedLibrary) {
+	*out = *in
+	if in.Cache != nil {
+		in, out := &in.Cache, &out.Cache
+		*out = new(SharedLibraryCache)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SharedLibrary.
+func (in *SharedLibrary) DeepCopy() *SharedLibrary {
+	if in == nil {
+		return nil
+	}
+	out := new(SharedLibrary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SharedLibraryCache) DeepCopyInto(out *SharedLibraryCache) {
+	*out = *in
+	if in.WebhookSecretKeySelector != nil {
+		in, out := &in.WebhookSecretKeySelector, &out.WebhookSecretKeySelector
+		*out = new(SecretKeySelector)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SharedLibraryCache.
+func (in *SharedLibraryCache) DeepCopy() *SharedLibraryCache {
+	if in == nil {
+		return nil
+	}
+	out := new(SharedLibraryCache)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Slack) DeepCopyInto(out *Slack) {
 	*out = *in
@@ -762,6 +2733,123 @@ func (in *Slack) DeepCopy() *Slack {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ToolInstallation) DeepCopyInto(out *ToolInstallation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ToolInstallation.
+func (in *ToolInstallation) DeepCopy() *ToolInstallation {
+	if in == nil {
+		return nil
+	}
+	out := new(ToolInstallation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Tools) DeepCopyInto(out *Tools) {
+	*out = *in
+	if in.JDK != nil {
+		in, out := &in.JDK, &out.JDK
+		*out = make([]JDKTool, len(*in))
+		copy(*out, *in)
+	}
+	if in.Maven != nil {
+		in, out := &in.Maven, &out.Maven
+		*out = make([]MavenTool, len(*in))
+		copy(*out, *in)
+	}
+	if in.NodeJS != nil {
+		in, out := &in.NodeJS, &out.NodeJS
+		*out = make([]NodeJSTool, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Tools.
+func (in *Tools) DeepCopy() *Tools {
+	if in == nil {
+		return nil
+	}
+	out := new(Tools)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrustedCABundle) DeepCopyInto(out *TrustedCABundle) {
+	*out = *in
+	out.ConfigMapRef = in.ConfigMapRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrustedCABundle.
+func (in *TrustedCABundle) DeepCopy() *TrustedCABundle {
+	if in == nil {
+		return nil
+	}
+	out := new(TrustedCABundle)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpdateCenterMirror) DeepCopyInto(out *UpdateCenterMirror) {
+	*out = *in
+	if in.Plugins != nil {
+		in, out := &in.Plugins, &out.Plugins
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpdateCenterMirror.
+func (in *UpdateCenterMirror) DeepCopy() *UpdateCenterMirror {
+	if in == nil {
+		return nil
+	}
+	out := new(UpdateCenterMirror)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpdateCenterMirrorStatus) DeepCopyInto(out *UpdateCenterMirrorStatus) {
+	*out = *in
+	if in.LastGenerationTime != nil {
+		in, out := &in.LastGenerationTime, &out.LastGenerationTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpdateCenterMirrorStatus.
+func (in *UpdateCenterMirrorStatus) DeepCopy() *UpdateCenterMirrorStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(UpdateCenterMirrorStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpdateSite) DeepCopyInto(out *UpdateSite) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpdateSite.
+func (in *UpdateSite) DeepCopy() *UpdateSite {
+	if in == nil {
+		return nil
+	}
+	out := new(UpdateSite)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Version) DeepCopyInto(out *Version) {
 	*out = *in
@@ -777,6 +2865,21 @@ func (in *Version) DeepCopy() *Version {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *View) DeepCopyInto(out *View) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new View.
+func (in *View) DeepCopy() *View {
+	if in == nil {
+		return nil
+	}
+	out := new(View)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Warning) DeepCopyInto(out *Warning) {
 	*out = *in
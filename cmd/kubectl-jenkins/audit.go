@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/audit"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func newAuditCommand(o *options) *cobra.Command {
+	var output, action string
+	var since time.Duration
+	cmd := &cobra.Command{
+		Use:   "audit NAME",
+		Short: "Show audit trail of Jenkins",
+		Long: `Show the audit trail of the operator actions in Jenkins recorded with spec.audit.
+
+The records are printed from the oldest one, only the last spec.audit.maxRecords records
+are kept by the operator.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if output != "table" && output != "json" {
+				return errors.Errorf("unsupported output format '%s', use table or json", output)
+			}
+			namespace, err := o.namespace()
+			if err != nil {
+				return err
+			}
+			k8sClient, err := o.client()
+			if err != nil {
+				return err
+			}
+			configMap := &corev1.ConfigMap{}
+			err = k8sClient.Get(context.Background(), types.NamespacedName{Namespace: namespace, Name: audit.ConfigMapName(args[0])}, configMap)
+			if apierrors.IsNotFound(err) {
+				return errors.Errorf("Jenkins CR '%s' has no audit records, set spec.audit to record them", args[0])
+			}
+			if err != nil {
+				return err
+			}
+			records, err := audit.ReadRecords(configMap)
+			if err != nil {
+				return err
+			}
+
+			var filtered []audit.Record
+			for _, record := range records {
+				if len(action) > 0 && record.Action != action {
+					continue
+				}
+				if since > 0 && time.Since(record.Time.Time) > since {
+					continue
+				}
+				filtered = append(filtered, record)
+			}
+			if output == "json" {
+				for _, record := range filtered {
+					line, err := json.Marshal(record)
+					if err != nil {
+						return err
+					}
+					fmt.Fprintln(o.Out, string(line))
+				}
+				return nil
+			}
+
+			writer := tabwriter.NewWriter(o.Out, 0, 8, 2, ' ', 0)
+			fmt.Fprintln(writer, "TIME\tACTION\tGENERATION\tSPEC HASH\tCHANGED BY\tMESSAGE")
+			for _, record := range filtered {
+				fmt.Fprintf(writer, "%s\t%s\t%d\t%s\t%s\t%s\n", record.Time.UTC().Format(time.RFC3339), record.Action,
+					record.Generation, record.SpecHash, record.ChangedBy, record.Message)
+			}
+			return writer.Flush()
+		},
+	}
+	cmd.Flags().StringVarP(&output, "output", "o", "table", "Output format, table or json (one record per line)")
+	cmd.Flags().StringVar(&action, "action", "", "Print only records of the action, e.g. SpecChanged or PodRestart")
+	cmd.Flags().DurationVar(&since, "since", 0, "Print only records newer than the duration, e.g. 24h")
+	return cmd
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/backup"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// pollInterval is the interval of checks of Jenkins CR status when the command waits for the operator
+var pollInterval = 5 * time.Second
+
+func newBackupCommand(o *options) *cobra.Command {
+	var waitForBackup bool
+	var timeout time.Duration
+	cmd := &cobra.Command{
+		Use:   "backup NAME",
+		Short: "Trigger the backup of Jenkins",
+		Long: fmt.Sprintf(`Trigger the backup of Jenkins outside of spec.backup.interval.
+
+The command sets the %s annotation on Jenkins CR, the operator performs the backup
+with spec.backup.action and removes the annotation.`, v1alpha2.BackupNowAnnotation),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			namespace, err := o.namespace()
+			if err != nil {
+				return err
+			}
+			k8sClient, err := o.client()
+			if err != nil {
+				return err
+			}
+			ctx := context.Background()
+			jenkins, err := getJenkins(ctx, k8sClient, namespace, args[0])
+			if err != nil {
+				return err
+			}
+			if !backup.IsBackupConfigured(jenkins) {
+				return errors.Errorf("backup of Jenkins CR '%s' isn't configured, set spec.backup", jenkins.Name)
+			}
+
+			lastBackup := jenkins.Status.LastBackup
+			if err = requestByAnnotation(ctx, k8sClient, jenkins, v1alpha2.BackupNowAnnotation); err != nil {
+				return err
+			}
+			fmt.Fprintf(o.Out, "jenkins.jenkins.io/%s backup requested\n", jenkins.Name)
+			if !waitForBackup {
+				return nil
+			}
+
+			err = wait.PollImmediate(pollInterval, timeout, func() (bool, error) {
+				jenkins, err = getJenkins(ctx, k8sClient, namespace, args[0])
+				if err != nil {
+					return false, err
+				}
+				return jenkins.Status.LastBackup > lastBackup, nil
+			})
+			if err != nil {
+				return errors.Wrap(err, "backup hasn't been completed, check events of Jenkins CR")
+			}
+			fmt.Fprintf(o.Out, "jenkins.jenkins.io/%s backup '%d' completed\n", jenkins.Name, jenkins.Status.LastBackup)
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&waitForBackup, "wait", false, "Wait until the backup is completed")
+	cmd.Flags().DurationVar(&timeout, "timeout", 15*time.Minute, "How long to wait for the backup with --wait")
+	return cmd
+}
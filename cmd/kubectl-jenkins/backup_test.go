@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestBackupCommand(t *testing.T) {
+	pollInterval = 10 * time.Millisecond
+
+	t.Run("sets annotation", func(t *testing.T) {
+		k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(newJenkins()).Build()
+
+		out, err := runCommand(k8sClient, "backup", "jenkins")
+
+		require.NoError(t, err)
+		assert.Equal(t, "jenkins.jenkins.io/jenkins backup requested\n", out)
+		requestTime, found := getJenkinsCR(t, k8sClient).Annotations[v1alpha2.BackupNowAnnotation]
+		require.True(t, found)
+		_, err = time.Parse(time.RFC3339, requestTime)
+		assert.NoError(t, err)
+	})
+	t.Run("backup not configured", func(t *testing.T) {
+		jenkins := newJenkins()
+		jenkins.Spec.Backup = v1alpha2.Backup{}
+		k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(jenkins).Build()
+
+		_, err := runCommand(k8sClient, "backup", "jenkins")
+
+		assert.EqualError(t, err, "backup of Jenkins CR 'jenkins' isn't configured, set spec.backup")
+		assert.NotContains(t, getJenkinsCR(t, k8sClient).Annotations, v1alpha2.BackupNowAnnotation)
+	})
+	t.Run("waits for the backup", func(t *testing.T) {
+		k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(newJenkins()).Build()
+		// the operator performs the backup requested by the annotation
+		done := make(chan error)
+		go func() {
+			done <- wait.PollImmediate(pollInterval, 5*time.Second, func() (bool, error) {
+				jenkins := &v1alpha2.Jenkins{}
+				if err := k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: "jenkins"}, jenkins); err != nil {
+					return false, err
+				}
+				if _, found := jenkins.Annotations[v1alpha2.BackupNowAnnotation]; !found {
+					return false, nil
+				}
+				delete(jenkins.Annotations, v1alpha2.BackupNowAnnotation)
+				jenkins.Status.LastBackup = 2
+				jenkins.Status.PendingBackup = 2
+				return true, k8sClient.Update(context.TODO(), jenkins)
+			})
+		}()
+
+		out, err := runCommand(k8sClient, "backup", "jenkins", "--wait", "--timeout", "5s")
+
+		require.NoError(t, err)
+		require.NoError(t, <-done)
+		assert.Equal(t, "jenkins.jenkins.io/jenkins backup requested\njenkins.jenkins.io/jenkins backup '2' completed\n", out)
+	})
+	t.Run("backup isn't completed in time", func(t *testing.T) {
+		k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(newJenkins()).Build()
+
+		_, err := runCommand(k8sClient, "backup", "jenkins", "--wait", "--timeout", "50ms")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "backup hasn't been completed")
+	})
+}
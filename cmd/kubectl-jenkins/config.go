@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+func newConfigCommand(o *options) *cobra.Command {
+	var output string
+	var withConfigurations bool
+	cmd := &cobra.Command{
+		Use:   "config NAME",
+		Short: "Show effective configuration of Jenkins",
+		Long: `Show effective configuration of Jenkins.
+
+The command prints Jenkins CR with the spec rendered from Jenkins templates in the same way
+as the operator does, the ConfigMaps with Configuration as Code and groovy scripts
+are printed with --configurations.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if output != "yaml" && output != "json" {
+				return errors.Errorf("unsupported output format '%s', use yaml or json", output)
+			}
+			namespace, err := o.namespace()
+			if err != nil {
+				return err
+			}
+			k8sClient, err := o.client()
+			if err != nil {
+				return err
+			}
+			ctx := context.Background()
+			jenkins, err := getJenkins(ctx, k8sClient, namespace, args[0])
+			if err != nil {
+				return err
+			}
+			jenkins.TypeMeta = v1alpha2.JenkinsTypeMeta()
+			jenkins.ManagedFields = nil
+			jenkins.Status = v1alpha2.JenkinsStatus{}
+
+			objects := []interface{}{jenkins}
+			if withConfigurations {
+				configMaps, err := getConfigurations(ctx, k8sClient, jenkins)
+				if err != nil {
+					return err
+				}
+				objects = append(objects, configMaps...)
+			}
+			return printObjects(o, output, objects)
+		},
+	}
+	cmd.Flags().StringVarP(&output, "output", "o", "yaml", "Output format, yaml or json")
+	cmd.Flags().BoolVar(&withConfigurations, "configurations", false, "Print ConfigMaps of spec.configurationAsCode and spec.groovyScripts")
+	return cmd
+}
+
+// getConfigurations returns ConfigMaps with Configuration as Code and groovy scripts of Jenkins CR
+func getConfigurations(ctx context.Context, k8sClient client.Client, jenkins *v1alpha2.Jenkins) ([]interface{}, error) {
+	var refs []v1alpha2.ConfigMapRef
+	refs = append(refs, jenkins.Spec.ConfigurationAsCode.Configurations...)
+	refs = append(refs, jenkins.Spec.GroovyScripts.Configurations...)
+
+	var configMaps []interface{}
+	for _, ref := range refs {
+		configMap := &corev1.ConfigMap{}
+		if err := k8sClient.Get(ctx, types.NamespacedName{Namespace: jenkins.Namespace, Name: ref.Name}, configMap); err != nil {
+			return nil, errors.Wrapf(err, "couldn't get ConfigMap '%s'", ref.Name)
+		}
+		configMap.APIVersion = "v1"
+		configMap.Kind = "ConfigMap"
+		configMap.ManagedFields = nil
+		configMaps = append(configMaps, configMap)
+	}
+	return configMaps, nil
+}
+
+// printObjects prints the objects as YAML documents or as JSON list
+func printObjects(o *options, output string, objects []interface{}) error {
+	if output == "json" {
+		var data []byte
+		var err error
+		if len(objects) == 1 {
+			data, err = json.MarshalIndent(objects[0], "", "    ")
+		} else {
+			data, err = json.MarshalIndent(map[string]interface{}{"apiVersion": "v1", "kind": "List", "items": objects}, "", "    ")
+		}
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(o.Out, string(data))
+		return err
+	}
+
+	for i, object := range objects {
+		data, err := yaml.Marshal(object)
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			fmt.Fprintln(o.Out, "---")
+		}
+		if _, err = o.Out.Write(data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
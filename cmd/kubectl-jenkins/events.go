@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+func newEventsCommand(o *options) *cobra.Command {
+	var follow bool
+	cmd := &cobra.Command{
+		Use:   "events NAME",
+		Short: "Show events of Jenkins",
+		Long: `Show events recorded by the operator while reconciling Jenkins CR.
+
+The events are printed from the oldest one, new events are printed until interrupted with --follow.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			namespace, err := o.namespace()
+			if err != nil {
+				return err
+			}
+			clientSet, err := o.clientSet()
+			if err != nil {
+				return err
+			}
+			ctx := context.Background()
+			selector := fields.Set{
+				"involvedObject.kind": v1alpha2.Kind,
+				"involvedObject.name": args[0],
+			}.AsSelector().String()
+			events, err := clientSet.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{FieldSelector: selector})
+			if err != nil {
+				return err
+			}
+
+			writer := tabwriter.NewWriter(o.Out, 0, 8, 2, ' ', 0)
+			fmt.Fprintln(writer, "LAST SEEN\tTYPE\tREASON\tMESSAGE")
+			sort.SliceStable(events.Items, func(i, j int) bool {
+				return eventTime(events.Items[i]).Before(eventTime(events.Items[j]))
+			})
+			for _, event := range events.Items {
+				printEvent(writer, event)
+			}
+			if err = writer.Flush(); err != nil || !follow {
+				return err
+			}
+
+			watcher, err := clientSet.CoreV1().Events(namespace).Watch(ctx, metav1.ListOptions{
+				FieldSelector:   selector,
+				ResourceVersion: events.ResourceVersion,
+			})
+			if err != nil {
+				return err
+			}
+			defer watcher.Stop()
+			for result := range watcher.ResultChan() {
+				if result.Type != watch.Added && result.Type != watch.Modified {
+					continue
+				}
+				if event, ok := result.Object.(*corev1.Event); ok {
+					printEvent(writer, *event)
+					if err = writer.Flush(); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		},
+	}
+	cmd.Flags().BoolVarP(&follow, "follow", "f", false, "Print new events until interrupted")
+	return cmd
+}
+
+func printEvent(writer *tabwriter.Writer, event corev1.Event) {
+	lastSeen := duration.HumanDuration(time.Since(eventTime(event)))
+	fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n", lastSeen, event.Type, event.Reason, event.Message)
+}
+
+// eventTime returns the time when the event has been recorded for the last time
+func eventTime(event corev1.Event) time.Time {
+	if !event.LastTimestamp.IsZero() {
+		return event.LastTimestamp.Time
+	}
+	if !event.EventTime.IsZero() {
+		return event.EventTime.Time
+	}
+	return event.CreationTimestamp.Time
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// kubectl-jenkins is the kubectl plugin which talks to Jenkins Operator through annotations and custom resources,
+// install it in PATH and run `kubectl jenkins --help`.
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/template"
+	"github.com/jenkinsci/kubernetes-operator/version"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var scheme = runtime.NewScheme()
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(v1alpha2.AddToScheme(scheme))
+}
+
+// options are shared by all commands
+type options struct {
+	configFlags *genericclioptions.ConfigFlags
+	genericclioptions.IOStreams
+	// k8sClient is used instead of the client created from configFlags when it's set, e.g. by tests
+	k8sClient client.Client
+}
+
+func main() {
+	o := &options{
+		configFlags: genericclioptions.NewConfigFlags(true),
+		IOStreams:   genericclioptions.IOStreams{In: os.Stdin, Out: os.Stdout, ErrOut: os.Stderr},
+	}
+	if err := newRootCommand(o).Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+
+func newRootCommand(o *options) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "kubectl-jenkins",
+		Short:        "Manage Jenkins instances of Jenkins Operator",
+		Version:      fmt.Sprintf("%s (%s)", version.Version, version.GitCommit),
+		SilenceUsage: true,
+	}
+	o.configFlags.AddFlags(cmd.PersistentFlags())
+	cmd.AddCommand(
+		newBackupCommand(o),
+		newRestoreCommand(o),
+		newRerunSeedJobsCommand(o),
+		newConfigCommand(o),
+		newEventsCommand(o),
+		newAuditCommand(o),
+	)
+	return cmd
+}
+
+// namespace returns the namespace from the flags or the current context of kubeconfig
+func (o *options) namespace() (string, error) {
+	namespace, _, err := o.configFlags.ToRawKubeConfigLoader().Namespace()
+	return namespace, err
+}
+
+// client returns the client of Kubernetes API which knows Jenkins Operator types
+func (o *options) client() (client.Client, error) {
+	if o.k8sClient != nil {
+		return o.k8sClient, nil
+	}
+	config, err := o.configFlags.ToRESTConfig()
+	if err != nil {
+		return nil, err
+	}
+	return client.New(config, client.Options{Scheme: scheme})
+}
+
+// clientSet returns the client of Kubernetes API used to watch events
+func (o *options) clientSet() (*kubernetes.Clientset, error) {
+	config, err := o.configFlags.ToRESTConfig()
+	if err != nil {
+		return nil, err
+	}
+	return kubernetes.NewForConfig(config)
+}
+
+// getJenkins returns the Jenkins CR with the spec rendered from Jenkins templates
+func getJenkins(ctx context.Context, k8sClient client.Client, namespace, name string) (*v1alpha2.Jenkins, error) {
+	jenkins := &v1alpha2.Jenkins{}
+	if err := k8sClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, jenkins); err != nil {
+		return nil, err
+	}
+	if err := template.Apply(ctx, k8sClient, jenkins); err != nil {
+		return nil, err
+	}
+	return jenkins, nil
+}
+
+// requestByAnnotation sets the annotation handled by the operator on Jenkins CR, the value is the time of the request
+func requestByAnnotation(ctx context.Context, k8sClient client.Client, jenkins *v1alpha2.Jenkins, annotation string) error {
+	current := &v1alpha2.Jenkins{}
+	if err := k8sClient.Get(ctx, types.NamespacedName{Namespace: jenkins.Namespace, Name: jenkins.Name}, current); err != nil {
+		return err
+	}
+	patch := client.MergeFrom(current.DeepCopy())
+	if current.Annotations == nil {
+		current.Annotations = map[string]string{}
+	}
+	current.Annotations[annotation] = time.Now().UTC().Format(time.RFC3339)
+	return k8sClient.Patch(ctx, current, patch)
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const namespace = "default"
+
+func newJenkins() *v1alpha2.Jenkins {
+	return &v1alpha2.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: namespace},
+		Spec: v1alpha2.JenkinsSpec{
+			Backup: v1alpha2.Backup{
+				ContainerName: "backup",
+				Action:        v1alpha2.Handler{Exec: &corev1.ExecAction{Command: []string{"backup.sh"}}},
+			},
+		},
+		Status: v1alpha2.JenkinsStatus{LastBackup: 1, PendingBackup: 1},
+	}
+}
+
+// runCommand runs kubectl-jenkins with the fake client in the default namespace
+func runCommand(k8sClient client.Client, args ...string) (string, error) {
+	out := &bytes.Buffer{}
+	configFlags := genericclioptions.NewConfigFlags(false)
+	ns := namespace
+	configFlags.Namespace = &ns
+	o := &options{
+		configFlags: configFlags,
+		IOStreams:   genericclioptions.IOStreams{In: &bytes.Buffer{}, Out: out, ErrOut: &bytes.Buffer{}},
+		k8sClient:   k8sClient,
+	}
+	cmd := newRootCommand(o)
+	cmd.SetArgs(args)
+	cmd.SetOut(out)
+	cmd.SetErr(out)
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+func getJenkinsCR(t *testing.T, k8sClient client.Client) *v1alpha2.Jenkins {
+	jenkins := &v1alpha2.Jenkins{}
+	require.NoError(t, k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: "jenkins"}, jenkins))
+	return jenkins
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/backup"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+func newRestoreCommand(o *options) *cobra.Command {
+	var waitForRestore bool
+	var timeout time.Duration
+	restoreBackup := v1alpha2.RestoreBackup{}
+	cmd := &cobra.Command{
+		Use:   "restore NAME",
+		Short: "Request the restore of Jenkins backup",
+		Long: `Request the restore of Jenkins backup.
+
+The command creates JenkinsRestore CR in Restore mode, the operator restores the backup
+and records the progress in the status of JenkinsRestore CR.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			namespace, err := o.namespace()
+			if err != nil {
+				return err
+			}
+			k8sClient, err := o.client()
+			if err != nil {
+				return err
+			}
+			ctx := context.Background()
+			jenkins, err := getJenkins(ctx, k8sClient, namespace, args[0])
+			if err != nil {
+				return err
+			}
+			if !backup.IsRestoreConfigured(jenkins) {
+				return errors.Errorf("restore of Jenkins CR '%s' isn't configured, set spec.restore", jenkins.Name)
+			}
+
+			restore := &v1alpha2.JenkinsRestore{
+				ObjectMeta: metav1.ObjectMeta{
+					GenerateName: fmt.Sprintf("%s-restore-", jenkins.Name),
+					Namespace:    namespace,
+				},
+				Spec: v1alpha2.JenkinsRestoreSpec{
+					JenkinsRef: v1alpha2.JenkinsRef{Name: jenkins.Name},
+					Mode:       v1alpha2.RestoreRestoreMode,
+					Restore:    &restoreBackup,
+				},
+			}
+			if err = k8sClient.Create(ctx, restore); err != nil {
+				return err
+			}
+			fmt.Fprintf(o.Out, "jenkinsrestore.jenkins.io/%s created\n", restore.Name)
+			if !waitForRestore {
+				return nil
+			}
+
+			err = wait.PollImmediate(pollInterval, timeout, func() (bool, error) {
+				if err := k8sClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: restore.Name}, restore); err != nil {
+					return false, err
+				}
+				return restore.Status.Phase == v1alpha2.RestorePhaseSucceeded || restore.Status.Phase == v1alpha2.RestorePhaseFailed, nil
+			})
+			if err != nil {
+				return errors.Wrapf(err, "backup hasn't been restored, check status of JenkinsRestore CR '%s'", restore.Name)
+			}
+			if restore.Status.Phase == v1alpha2.RestorePhaseFailed {
+				return errors.Errorf("restore of backup '%d' has failed: %s", restore.Status.BackupNumber, restore.Status.Message)
+			}
+			fmt.Fprintf(o.Out, "jenkinsrestore.jenkins.io/%s backup '%d' restored in Jenkins CR '%s'\n",
+				restore.Name, restore.Status.BackupNumber, restore.Status.TargetName)
+			return nil
+		},
+	}
+	cmd.Flags().Uint64Var(&restoreBackup.BackupNumber, "backup", 0, "Number of backup to restore, the latest backup is restored if not set")
+	cmd.Flags().StringVar(&restoreBackup.Target.Name, "target", "", "Name of Jenkins CR where the backup is restored, defaults to NAME")
+	cmd.Flags().BoolVar(&restoreBackup.Target.Create, "create", false, "Create the target Jenkins CR from the spec of NAME if it doesn't exist")
+	cmd.Flags().BoolVar(&waitForRestore, "wait", false, "Wait until the backup is restored")
+	cmd.Flags().DurationVar(&timeout, "timeout", 15*time.Minute, "How long to wait for the restore with --wait")
+	return cmd
+}
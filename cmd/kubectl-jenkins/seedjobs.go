@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func newRerunSeedJobsCommand(o *options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "rerun-seed-jobs NAME",
+		Short: "Re-run seed jobs of Jenkins",
+		Long: fmt.Sprintf(`Re-run seed jobs of Jenkins to recreate jobs from Job DSL scripts.
+
+The command sets the %s annotation on Jenkins CR, the operator queues builds
+of all seed jobs from spec.seedJobs and removes the annotation.`, v1alpha2.RerunSeedJobsAnnotation),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			namespace, err := o.namespace()
+			if err != nil {
+				return err
+			}
+			k8sClient, err := o.client()
+			if err != nil {
+				return err
+			}
+			ctx := context.Background()
+			jenkins, err := getJenkins(ctx, k8sClient, namespace, args[0])
+			if err != nil {
+				return err
+			}
+			if len(jenkins.Spec.SeedJobs) == 0 {
+				return errors.Errorf("Jenkins CR '%s' has no seed jobs", jenkins.Name)
+			}
+
+			if err = requestByAnnotation(ctx, k8sClient, jenkins, v1alpha2.RerunSeedJobsAnnotation); err != nil {
+				return err
+			}
+			fmt.Fprintf(o.Out, "jenkins.jenkins.io/%s rerun of %d seed jobs requested\n", jenkins.Name, len(jenkins.Spec.SeedJobs))
+			return nil
+		},
+	}
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestRerunSeedJobsCommand(t *testing.T) {
+	t.Run("sets annotation", func(t *testing.T) {
+		jenkins := newJenkins()
+		jenkins.Spec.SeedJobs = []v1alpha2.SeedJob{{ID: "jenkins-operator"}}
+		k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(jenkins).Build()
+
+		out, err := runCommand(k8sClient, "rerun-seed-jobs", "jenkins")
+
+		require.NoError(t, err)
+		assert.Equal(t, "jenkins.jenkins.io/jenkins rerun of 1 seed jobs requested\n", out)
+		assert.Contains(t, getJenkinsCR(t, k8sClient).Annotations, v1alpha2.RerunSeedJobsAnnotation)
+	})
+	t.Run("no seed jobs", func(t *testing.T) {
+		k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(newJenkins()).Build()
+
+		_, err := runCommand(k8sClient, "rerun-seed-jobs", "jenkins")
+
+		assert.EqualError(t, err, "Jenkins CR 'jenkins' has no seed jobs")
+	})
+}
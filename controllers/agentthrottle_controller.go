@@ -0,0 +1,94 @@
+package controllers
+
+import (
+	"context"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/template"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/user/agents"
+	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
+	"github.com/jenkinsci/kubernetes-operator/pkg/metrics"
+	"github.com/jenkinsci/kubernetes-operator/pkg/probes"
+	"github.com/jenkinsci/kubernetes-operator/pkg/tracing"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// AgentThrottleReconciler reports the number of agent pods of Jenkins and the limit of spec.agents.maxConcurrent,
+// the limit is enforced by Jenkins with the container cap of the kubernetes cloud set by the agents groovy script
+type AgentThrottleReconciler struct {
+	Client client.Client
+	Scheme *runtime.Scheme
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *AgentThrottleReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	probes.Reconciles.Register("jenkins-agent-throttle")
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("jenkins-agent-throttle").
+		For(&v1alpha2.Jenkins{}).
+		Watches(&source.Kind{Type: &corev1.Pod{}}, handler.EnqueueRequestsFromMapFunc(requestsForAgentPod)).
+		Complete(r)
+}
+
+// requestsForAgentPod enqueues Jenkins CR of the agent pod created from spec.agents.podTemplates
+func requestsForAgentPod(object client.Object) []reconcile.Request {
+	name, ok := object.GetLabels()[constants.LabelAgentOfKey]
+	if !ok {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: object.GetNamespace(), Name: name}}}
+}
+
+// Reconcile reports agent pods of Jenkins defined by Jenkins CR.
+func (r *AgentThrottleReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	done := probes.Reconciles.Track("jenkins-agent-throttle")
+	ctx, span := tracing.Start(ctx, "ReconcileAgentThrottle", tracing.JenkinsAttributes(request.Namespace, request.Name)...)
+	err := r.reconcile(ctx, request)
+	tracing.End(span, err)
+	done(err)
+	return reconcile.Result{}, err
+}
+
+func (r *AgentThrottleReconciler) reconcile(ctx context.Context, request ctrl.Request) error {
+	jenkins := &v1alpha2.Jenkins{}
+	err := r.Client.Get(context.TODO(), request.NamespacedName, jenkins)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			metrics.DeleteAgentPods(&v1alpha2.Jenkins{ObjectMeta: metav1.ObjectMeta{Namespace: request.Namespace, Name: request.Name}})
+			return nil
+		}
+		return errors.WithStack(err)
+	}
+	if err = template.Apply(context.TODO(), r.Client, jenkins); err != nil {
+		return err
+	}
+	if jenkins.Spec.Agents == nil || jenkins.Spec.Agents.MaxConcurrent == nil || jenkins.DeletionTimestamp != nil {
+		metrics.DeleteAgentPods(jenkins)
+		return nil
+	}
+	if isPlanMode(jenkins) {
+		return nil
+	}
+	maxConcurrent := *jenkins.Spec.Agents.MaxConcurrent
+
+	pods := &corev1.PodList{}
+	err = r.Client.List(context.TODO(), pods, client.InNamespace(jenkins.Namespace), client.MatchingLabels(agents.BuildPodLabels(jenkins)))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	usage := agents.GetUsage(pods.Items)
+	metrics.SetAgentPods(jenkins, usage.Running, usage.Pending, maxConcurrent)
+	return nil
+}
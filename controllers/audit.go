@@ -0,0 +1,44 @@
+package controllers
+
+import (
+	"context"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/audit"
+	k8sevent "github.com/jenkinsci/kubernetes-operator/pkg/event"
+
+	"github.com/pkg/errors"
+)
+
+// auditSpecChange records the change of Jenkins CR spec in the audit trail once per generation
+func (r *JenkinsReconciler) auditSpecChange(jenkins *v1alpha2.Jenkins) error {
+	if jenkins.Spec.Audit == nil || r.Events == nil || jenkins.Status.AuditedGeneration == jenkins.Generation {
+		return nil
+	}
+	changedBy := audit.ChangedBy(jenkins)
+	if len(changedBy) == 0 {
+		changedBy = "unknown field manager"
+	}
+	r.Events.Emitf(jenkins, k8sevent.TypeNormal, k8sevent.ReasonSpecChanged, "Spec has changed to generation %d with hash %s by %s",
+		jenkins.Generation, audit.SpecHash(jenkins), changedBy)
+	jenkins.Status.AuditedGeneration = jenkins.Generation
+	return errors.WithStack(r.Client.Status().Update(context.TODO(), jenkins))
+}
+
+// auditGroovyScripts records groovy scripts and Configuration as Code applied during the reconcile loop in the audit trail
+func (r *JenkinsReconciler) auditGroovyScripts(jenkins *v1alpha2.Jenkins, appliedBefore []v1alpha2.AppliedGroovyScript) {
+	if jenkins.Spec.Audit == nil || r.Events == nil {
+		return
+	}
+	applied := map[v1alpha2.AppliedGroovyScript]bool{}
+	for _, script := range appliedBefore {
+		applied[script] = true
+	}
+	for _, script := range jenkins.Status.AppliedGroovyScripts {
+		if applied[script] {
+			continue
+		}
+		r.Events.Emitf(jenkins, k8sevent.TypeNormal, k8sevent.ReasonGroovyScriptApplied, "%s script '%s' from '%s' with hash %s has been applied",
+			script.ConfigurationType, script.Name, script.Source, script.Hash)
+	}
+}
@@ -0,0 +1,54 @@
+package controllers
+
+import (
+	"context"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/canary"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/user"
+	"github.com/jenkinsci/kubernetes-operator/pkg/tracing"
+
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// verifyInCanary verifies pending Configuration as Code and groovy script changes in the canary Jenkins pod
+// when spec.canary.enabled is set, it returns true when the changes can be applied to Jenkins.
+// The first configuration of Jenkins master pod isn't verified, but the configuration which has failed before isn't applied.
+func (r *JenkinsReconciler) verifyInCanary(ctx context.Context, config configuration.Configuration, userConfiguration user.ReconcileUserConfiguration) (bool, reconcile.Result, error) {
+	jenkins := config.Jenkins
+	if !canary.IsEnabled(jenkins) {
+		if jenkins.Status.Canary == nil && len(jenkins.Status.FailedCanaryConfigurationHash) == 0 {
+			return true, reconcile.Result{}, nil
+		}
+		return true, reconcile.Result{}, canary.New(config).Stop()
+	}
+	if jenkins.Status.UserConfigurationCompletedTime == nil {
+		failed, err := canary.New(config).IsFailedConfiguration()
+		return !failed, reconcile.Result{}, err
+	}
+
+	changes, err := userConfiguration.Plan()
+	if err != nil {
+		return false, reconcile.Result{}, err
+	}
+	pending := false
+	for _, change := range changes {
+		if user.IsVerifiedByCanary(change) {
+			pending = true
+			break
+		}
+	}
+	if !pending {
+		// the changes being verified have been reverted
+		if jenkins.Status.Canary != nil && jenkins.Status.Canary.Phase == v1alpha2.CanaryPhaseRunning {
+			return true, reconcile.Result{}, canary.New(config).Stop()
+		}
+		return true, reconcile.Result{}, nil
+	}
+
+	canaryCtx, span := tracing.Start(ctx, "VerifyInCanary")
+	verified, result, err := canary.New(config).Verify(canaryCtx, userConfiguration.Verify)
+	tracing.End(span, err)
+	return verified, result, err
+}
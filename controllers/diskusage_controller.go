@@ -0,0 +1,206 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	jenkinsclient "github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/diskusage"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/template"
+	k8sevent "github.com/jenkinsci/kubernetes-operator/pkg/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+	"github.com/jenkinsci/kubernetes-operator/pkg/metrics"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/probes"
+	"github.com/jenkinsci/kubernetes-operator/pkg/tracing"
+
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// DiskUsageReconciler monitors Jenkins home disk usage and runs cleanup policies according to spec.diskUsage
+type DiskUsageReconciler struct {
+	Client                       client.Client
+	Scheme                       *runtime.Scheme
+	JenkinsAPIConnectionSettings jenkinsclient.JenkinsAPIConnectionSettings
+	ClientSet                    kubernetes.Clientset
+	Config                       rest.Config
+	NotificationEvents           *chan event.Event
+	Events                       k8sevent.Recorder
+	KubernetesClusterDomain      string
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *DiskUsageReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	probes.Reconciles.Register("jenkins-disk-usage")
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("jenkins-disk-usage").
+		For(&v1alpha2.Jenkins{}).
+		Complete(r)
+}
+
+func (r *DiskUsageReconciler) newJenkinsConfiguration(jenkins *v1alpha2.Jenkins) configuration.Configuration {
+	return configuration.Configuration{
+		Client:                       r.Client,
+		ClientSet:                    r.ClientSet,
+		Notifications:                r.NotificationEvents,
+		Events:                       r.Events,
+		Jenkins:                      jenkins,
+		Scheme:                       r.Scheme,
+		Config:                       &r.Config,
+		JenkinsAPIConnectionSettings: r.JenkinsAPIConnectionSettings,
+		KubernetesClusterDomain:      r.KubernetesClusterDomain,
+	}
+}
+
+// Reconcile checks Jenkins home disk usage of Jenkins defined by Jenkins CR.
+func (r *DiskUsageReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	done := probes.Reconciles.Track("jenkins-disk-usage")
+	ctx, span := tracing.Start(ctx, "ReconcileDiskUsage", tracing.JenkinsAttributes(request.Namespace, request.Name)...)
+	result, err := r.reconcile(ctx, request)
+	tracing.End(span, err)
+	done(err)
+	if err != nil && apierrors.IsConflict(err) {
+		return reconcile.Result{Requeue: true}, nil
+	}
+	return result, err
+}
+
+func (r *DiskUsageReconciler) reconcile(ctx context.Context, request ctrl.Request) (reconcile.Result, error) {
+	jenkins := &v1alpha2.Jenkins{}
+	err := r.Client.Get(context.TODO(), request.NamespacedName, jenkins)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, errors.WithStack(err)
+	}
+	if err = template.Apply(context.TODO(), r.Client, jenkins); err != nil {
+		return reconcile.Result{}, err
+	}
+	logger := log.ForJenkins(jenkins)
+
+	settings := jenkins.Spec.DiskUsage
+	if settings == nil {
+		metrics.DeleteDiskUsage(jenkins)
+		if jenkins.Status.DiskUsage == nil {
+			return reconcile.Result{}, nil
+		}
+		jenkins.Status.DiskUsage = nil
+		return reconcile.Result{}, errors.WithStack(r.Client.Status().Update(context.TODO(), jenkins))
+	}
+	if isPlanMode(jenkins) {
+		return reconcile.Result{}, nil
+	}
+
+	interval := diskusage.GetCheckInterval(*settings)
+	if jenkins.Status.DiskUsage != nil && jenkins.Status.DiskUsage.LastCheckTime != nil {
+		if next := jenkins.Status.DiskUsage.LastCheckTime.Add(interval); time.Now().Before(next) {
+			return reconcile.Result{RequeueAfter: time.Until(next)}, nil
+		}
+	}
+	if !isJenkinsReady(jenkins) {
+		return reconcile.Result{RequeueAfter: notReadyRequeueInterval}, nil
+	}
+
+	config := r.newJenkinsConfiguration(jenkins)
+	status, err := r.checkDiskUsage(config)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if jenkins.Status.DiskUsage != nil {
+		status.LastCleanup = jenkins.Status.DiskUsage.LastCleanup
+	}
+	logger.V(log.VDebug).Info(fmt.Sprintf("Jenkins home disk usage is %d%%", status.UsedPercent))
+
+	if threshold := diskusage.GetWarningThreshold(*settings); status.UsedPercent >= threshold {
+		message := fmt.Sprintf("Jenkins home disk usage %d%% has reached the warning threshold %d%%", status.UsedPercent, threshold)
+		logger.V(log.VWarn).Info(message)
+		config.Emit(k8sevent.TypeWarning, k8sevent.ReasonDiskUsageHigh, message)
+	}
+
+	if policies := diskusage.SelectPolicies(*settings, status.UsedPercent); len(policies) > 0 {
+		status, err = r.runCleanupPolicies(ctx, config, policies, status)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	jenkins.Status.DiskUsage = status
+	if err = r.Client.Status().Update(context.TODO(), jenkins); err != nil {
+		return reconcile.Result{}, errors.WithStack(err)
+	}
+	return reconcile.Result{RequeueAfter: interval}, nil
+}
+
+func (r *DiskUsageReconciler) checkDiskUsage(config configuration.Configuration) (*v1alpha2.DiskUsageStatus, error) {
+	stdout, _, err := config.Exec(resources.GetJenkinsMasterPodName(config.Jenkins), resources.JenkinsMasterContainerName, diskusage.Command(config.Jenkins))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to check Jenkins home disk usage")
+	}
+	status, err := diskusage.ParseDiskUsage(stdout.String())
+	if err != nil {
+		return nil, err
+	}
+
+	now := metav1.Now()
+	status.LastCheckTime = &now
+	metrics.SetDiskUsage(config.Jenkins, status)
+	return &status, nil
+}
+
+// runCleanupPolicies runs cleanup policies in the order and it returns the disk usage after the cleanup,
+// the next policies are skipped when the policy fails
+func (r *DiskUsageReconciler) runCleanupPolicies(ctx context.Context, config configuration.Configuration, policies []v1alpha2.DiskCleanupPolicy, before *v1alpha2.DiskUsageStatus) (*v1alpha2.DiskUsageStatus, error) {
+	logger := log.ForJenkins(config.Jenkins)
+	jenkinsClient, err := config.GetJenkinsClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cleanup := &v1alpha2.DiskCleanup{Time: metav1.Now()}
+	for _, policy := range policies {
+		logger.Info(fmt.Sprintf("Running Jenkins home cleanup policy '%s'", policy.Name))
+		script, err := diskusage.RenderCleanupScript(policy)
+		if err != nil {
+			return nil, err
+		}
+		metrics.IncDiskCleanups(config.Jenkins, policy.Name)
+		if logs, err := jenkinsClient.ExecuteScript(script); err != nil {
+			cleanup.Message = fmt.Sprintf("Cleanup policy '%s' has failed: %s", policy.Name, err)
+			logger.V(log.VWarn).Info(fmt.Sprintf("%s, logs '%s'", cleanup.Message, logs))
+			config.Emit(k8sevent.TypeWarning, k8sevent.ReasonDiskCleanupFailed, cleanup.Message)
+			break
+		}
+		cleanup.Policies = append(cleanup.Policies, policy.Name)
+	}
+
+	after, err := r.checkDiskUsage(config)
+	if err != nil {
+		return nil, err
+	}
+	if freed := before.UsedBytes - after.UsedBytes; freed > 0 {
+		cleanup.FreedBytes = freed
+	}
+	after.LastCleanup = cleanup
+
+	if len(cleanup.Policies) > 0 {
+		message := fmt.Sprintf("Cleanup policies %s have freed %d bytes, Jenkins home disk usage is %d%%",
+			strings.Join(cleanup.Policies, ", "), cleanup.FreedBytes, after.UsedPercent)
+		logger.Info(message)
+		config.Emit(k8sevent.TypeNormal, k8sevent.ReasonDiskCleanupCompleted, message)
+	}
+	return after, nil
+}
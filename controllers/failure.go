@@ -0,0 +1,59 @@
+package controllers
+
+import (
+	"context"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/failure"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// reconcileSucceededReason is the reason of the Reconciled condition when the reconcile loop has succeeded
+const reconcileSucceededReason = "ReconcileSucceeded"
+
+// requeueAfterFailure returns when the reconcile loop failed with the error of the category is retried,
+// invalid configuration isn't retried until Jenkins CR changes
+func (r *JenkinsReconciler) requeueAfterFailure(jenkins *v1alpha2.Jenkins, category failure.Category, failures uint64) reconcile.Result {
+	settings := r.requeueSettings(jenkins)
+	switch category {
+	case failure.CategoryConfigurationInvalid:
+		return reconcile.Result{}
+	case failure.CategoryAuthFailure:
+		return reconcile.Result{RequeueAfter: settings.MaxErrorBackoff}
+	default:
+		return reconcile.Result{RequeueAfter: settings.ErrorBackoff(failures)}
+	}
+}
+
+// canGiveUp tells if the reconcile loop failing with the same error of the category is stopped after the fail limit,
+// transient and authentication failures are retried until they are gone
+func canGiveUp(category failure.Category) bool {
+	return category == failure.CategoryUnknown || category == failure.CategoryPluginFailure
+}
+
+// setReconciledCondition updates the Reconciled condition if it has changed
+func (r *JenkinsReconciler) setReconciledCondition(jenkins *v1alpha2.Jenkins, status metav1.ConditionStatus, reason, message string) error {
+	current := meta.FindStatusCondition(jenkins.Status.Conditions, v1alpha2.JenkinsReconciledConditionType)
+	if current != nil && current.Status == status && current.Reason == reason &&
+		current.Message == message && current.ObservedGeneration == jenkins.Generation {
+		return nil
+	}
+
+	meta.SetStatusCondition(&jenkins.Status.Conditions, metav1.Condition{
+		Type:               v1alpha2.JenkinsReconciledConditionType,
+		Status:             status,
+		ObservedGeneration: jenkins.Generation,
+		Reason:             reason,
+		Message:            message,
+	})
+	return errors.WithStack(r.Client.Status().Update(context.TODO(), jenkins))
+}
+
+// setReconcileFailure sets the Reconciled condition to False with the category of the error
+func (r *JenkinsReconciler) setReconcileFailure(jenkins *v1alpha2.Jenkins, category failure.Category, message string) error {
+	return r.setReconciledCondition(jenkins, metav1.ConditionFalse, string(category), message)
+}
@@ -38,7 +38,7 @@ func (e *enqueueRequestForJenkins) Update(evt event.UpdateEvent, q workqueue.Rat
 			jenkinsName = req2.Name
 		}
 
-		log.Log.WithValues("cr", jenkinsName).Info(
+		log.Log.WithValues(log.NamespaceKey, evt.ObjectNew.GetNamespace(), log.JenkinsKey, jenkinsName).Info(
 			fmt.Sprintf("%T/%s has been updated", evt.ObjectNew, evt.ObjectNew.GetName()))
 	}
 
@@ -80,20 +80,20 @@ type jenkinsDecorator struct {
 }
 
 func (e *jenkinsDecorator) Create(evt event.CreateEvent, q workqueue.RateLimitingInterface) {
-	log.Log.WithValues("cr", evt.Object.GetName()).Info(fmt.Sprintf("%T/%s was created", evt.Object, evt.Object.GetName()))
+	log.ForJenkins(evt.Object).Info(fmt.Sprintf("%T/%s was created", evt.Object, evt.Object.GetName()))
 	e.handler.Create(evt, q)
 }
 
 func (e *jenkinsDecorator) Update(evt event.UpdateEvent, q workqueue.RateLimitingInterface) {
 	if !reflect.DeepEqual(evt.ObjectOld.(*v1alpha2.Jenkins).Spec, evt.ObjectNew.(*v1alpha2.Jenkins).Spec) {
-		log.Log.WithValues("cr", evt.ObjectNew.GetName()).Info(
+		log.ForJenkins(evt.ObjectNew).Info(
 			fmt.Sprintf("%T/%s has been updated", evt.ObjectNew, evt.ObjectNew.GetName()))
 	}
 	e.handler.Update(evt, q)
 }
 
 func (e *jenkinsDecorator) Delete(evt event.DeleteEvent, q workqueue.RateLimitingInterface) {
-	log.Log.WithValues("cr", evt.Object.GetName()).Info(fmt.Sprintf("%T/%s was deleted", evt.Object, evt.Object.GetName()))
+	log.ForJenkins(evt.Object).Info(fmt.Sprintf("%T/%s was deleted", evt.Object, evt.Object.GetName()))
 	e.handler.Delete(evt, q)
 }
 
@@ -21,29 +21,43 @@ import (
 	"fmt"
 	"math/rand"
 	"reflect"
+	"strings"
 	"time"
 
 	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/backup"
 	jenkinsclient "github.com/jenkinsci/kubernetes-operator/pkg/client"
 	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
 	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base"
 	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/health"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/requeue"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/template"
 	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/user"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/user/tools"
 	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
+	k8sevent "github.com/jenkinsci/kubernetes-operator/pkg/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/failure"
 	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+	"github.com/jenkinsci/kubernetes-operator/pkg/metrics"
 	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
 	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/reason"
 	"github.com/jenkinsci/kubernetes-operator/pkg/plugins"
+	"github.com/jenkinsci/kubernetes-operator/pkg/probes"
+	"github.com/jenkinsci/kubernetes-operator/pkg/tracing"
 
 	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
@@ -63,7 +77,6 @@ const (
 )
 
 var reconcileErrors = map[string]reconcileError{}
-var logx = log.Log
 
 // JenkinsReconciler reconciles a Jenkins object
 type JenkinsReconciler struct {
@@ -73,11 +86,28 @@ type JenkinsReconciler struct {
 	ClientSet                    kubernetes.Clientset
 	Config                       rest.Config
 	NotificationEvents           *chan event.Event
+	Events                       k8sevent.Recorder
 	KubernetesClusterDomain      string
+	// Requeue is the timing of reconcile loops, it can be overridden by Jenkins CR annotations
+	Requeue requeue.Settings
+	// BasePluginsConfigMap is the name of ConfigMap in the namespace of Jenkins CR with base plugin manifests
+	// which override the manifests embedded in operator
+	BasePluginsConfigMap string
+	// ServerSideApply enables server-side apply of the objects managed by the operator
+	ServerSideApply bool
+	// APIReader reads base plugin manifests directly from the API server, the cache may not keep ConfigMap data
+	APIReader client.Reader
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *JenkinsReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	probes.Reconciles.Register("jenkins")
+	if r.APIReader == nil {
+		r.APIReader = mgr.GetAPIReader()
+	}
+	if err := mgr.GetFieldIndexer().IndexField(context.TODO(), &v1alpha2.Jenkins{}, template.IndexField, template.IndexJenkinsTemplate); err != nil {
+		return errors.WithStack(err)
+	}
 	jenkinsHandler := &enqueueRequestForJenkins{}
 	configMapResource := &source.Kind{Type: &corev1.ConfigMap{TypeMeta: metav1.TypeMeta{APIVersion: APIVersion, Kind: ConfigMapKind}}}
 	secretResource := &source.Kind{Type: &corev1.Secret{TypeMeta: metav1.TypeMeta{APIVersion: APIVersion, Kind: SecretKind}}}
@@ -85,24 +115,42 @@ func (r *JenkinsReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&v1alpha2.Jenkins{}).
 		Owns(&corev1.Pod{}).
+		Owns(&appsv1.StatefulSet{}).
 		Owns(&corev1.Secret{}).
 		Owns(&corev1.ConfigMap{}).
 		Watches(secretResource, jenkinsHandler).
 		Watches(configMapResource, jenkinsHandler).
 		Watches(&source.Kind{Type: &v1alpha2.Jenkins{}}, &decorator).
+		Watches(&source.Kind{Type: &v1alpha2.JenkinsTemplate{}}, handler.EnqueueRequestsFromMapFunc(r.requestsForJenkinsTemplate)).
 		Complete(r)
 }
 
+// requestsForJenkinsTemplate enqueues Jenkins CRs created from the changed JenkinsTemplate
+func (r *JenkinsReconciler) requestsForJenkinsTemplate(object client.Object) []reconcile.Request {
+	jenkinsList := &v1alpha2.JenkinsList{}
+	if err := r.Client.List(context.TODO(), jenkinsList, client.MatchingFields{template.IndexField: object.GetName()}); err != nil {
+		log.Log.Error(err, fmt.Sprintf("couldn't list Jenkins CRs created from JenkinsTemplate '%s'", object.GetName()))
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, jenkins := range jenkinsList.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: jenkins.Namespace, Name: jenkins.Name}})
+	}
+	return requests
+}
+
 func (r *JenkinsReconciler) newJenkinsReconcilier(jenkins *v1alpha2.Jenkins) configuration.Configuration {
 	config := configuration.Configuration{
 		Client:                       r.Client,
 		ClientSet:                    r.ClientSet,
 		Notifications:                r.NotificationEvents,
+		Events:                       r.Events,
 		Jenkins:                      jenkins,
 		Scheme:                       r.Scheme,
 		Config:                       &r.Config,
 		JenkinsAPIConnectionSettings: r.JenkinsAPIConnectionSettings,
 		KubernetesClusterDomain:      r.KubernetesClusterDomain,
+		ServerSideApply:              r.ServerSideApply,
 	}
 	return config
 }
@@ -110,33 +158,49 @@ func (r *JenkinsReconciler) newJenkinsReconcilier(jenkins *v1alpha2.Jenkins) con
 // +kubebuilder:rbac:groups=jenkins.io,resources=jenkins,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=jenkins.io,resources=jenkins/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=jenkins.io,resources=jenkins/finalizers,verbs=update
+// +kubebuilder:rbac:groups=jenkins.io,resources=jenkinstemplates,verbs=get;list;watch
 // +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups=core,resources=services;configmaps;secrets,verbs=get;list;watch;create;update
+// +kubebuilder:rbac:groups=core,resources=services;configmaps;secrets,verbs=get;list;watch;create;update;patch
 // +kubebuilder:rbac:groups=apps,resources=deployments;daemonsets;replicasets;statefulsets,verbs=*
-// +kubebuilder:rbac:groups=core,resources=serviceaccounts,verbs=get;list;watch;create;update
-// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles;rolebindings,verbs=get;list;watch;create;update
+// +kubebuilder:rbac:groups=core,resources=serviceaccounts,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles;rolebindings,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=pods/portforward,verbs=create
 // +kubebuilder:rbac:groups=core,resources=pods/log,verbs=get;list;watch
 // +kubebuilder:rbac:groups=core,resources=pods;pods/exec,verbs=*
 // +kubebuilder:rbac:groups=core,resources=events,verbs=get;watch;list;create;patch
 // +kubebuilder:rbac:groups=apps;jenkins-operator,resources=deployments/finalizers,verbs=update
 // +kubebuilder:rbac:groups=jenkins.io,resources=*,verbs=*
-// +kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=get;list;watch
-// +kubebuilder:rbac:groups=route.openshift.io,resources=routes,verbs=get;list;watch;create;update
+// +kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=snapshot.storage.k8s.io,resources=volumesnapshots,verbs=get;create
+// +kubebuilder:rbac:groups=core,resources=configmaps;secrets;services,verbs=delete
+// +kubebuilder:rbac:groups=monitoring.coreos.com,resources=prometheusrules,verbs=get;create;update;delete
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=get;create;update;patch;delete
+// +kubebuilder:rbac:groups=route.openshift.io,resources=routes,verbs=get;list;watch;create;update;delete
+// +kubebuilder:rbac:groups=security.openshift.io,resources=securitycontextconstraints,resourceNames=nonroot,verbs=use
 // +kubebuilder:rbac:groups=image.openshift.io,resources=imagestreams,verbs=get;list;watch
 // +kubebuilder:rbac:groups=build.openshift.io,resources=builds;buildconfigs,verbs=get;list;watch
+// +kubebuilder:rbac:groups=metrics.k8s.io,resources=pods,verbs=get;list
 
 // For more details, check Reconcile and its Result here:
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.7.0/pkg/reconcile
-func (r *JenkinsReconciler) Reconcile(_ context.Context, request ctrl.Request) (ctrl.Result, error) {
+func (r *JenkinsReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
 	reconcileFailLimit := uint64(10)
-	logger := logx.WithValues("cr", request.Name)
+	logger := log.Log.WithValues(log.NamespaceKey, request.Namespace, log.JenkinsKey, request.Name)
 	logger.V(log.VDebug).Info("Reconciling Jenkins")
 
-	result, jenkins, err := r.reconcile(request)
+	done := probes.Reconciles.Track("jenkins")
+	ctx, span := tracing.Start(ctx, "Reconcile", tracing.JenkinsAttributes(request.Namespace, request.Name)...)
+	result, jenkins, err := r.reconcile(ctx, request)
+	tracing.End(span, err)
+	done(err)
+	if jenkins != nil {
+		logger = log.ForJenkins(jenkins)
+	}
 	if err != nil && apierrors.IsConflict(err) {
 		return reconcile.Result{Requeue: true}, nil
 	} else if err != nil {
+		metrics.IncReconcileErrors(request.Namespace, request.Name)
+		category := failure.CategoryOf(err)
 		lastErrors, found := reconcileErrors[request.Name]
 		if found {
 			if err.Error() == lastErrors.err.Error() {
@@ -152,7 +216,12 @@ func (r *JenkinsReconciler) Reconcile(_ context.Context, request ctrl.Request) (
 			}
 		}
 		reconcileErrors[request.Name] = lastErrors
-		if lastErrors.counter >= reconcileFailLimit {
+		if jenkins != nil {
+			if err := r.setReconcileFailure(jenkins, category, failure.Message(err)); err != nil {
+				logger.V(log.VDebug).Info(fmt.Sprintf("Failed to set Reconciled condition: %s", err))
+			}
+		}
+		if canGiveUp(category) && lastErrors.counter >= reconcileFailLimit {
 			if log.Debug {
 				logger.V(log.VWarn).Info(fmt.Sprintf("Reconcile loop failed %d times with the same errors, giving up: %+v", reconcileFailLimit, err))
 			} else {
@@ -172,12 +241,13 @@ func (r *JenkinsReconciler) Reconcile(_ context.Context, request ctrl.Request) (
 		}
 
 		if log.Debug {
-			logger.V(log.VWarn).Info(fmt.Sprintf("Reconcile loop failed: %+v", err))
+			logger.V(log.VWarn).Info(fmt.Sprintf("Reconcile loop failed with %s error: %+v", category, err))
 		} else if err.Error() != fmt.Sprintf("Operation cannot be fulfilled on jenkins.jenkins.io \"%s\": the object has been modified; please apply your changes to the latest version and try again", request.Name) {
-			logger.V(log.VWarn).Info(fmt.Sprintf("Reconcile loop failed: %s", err))
+			logger.V(log.VWarn).Info(fmt.Sprintf("Reconcile loop failed with %s error: %s", category, err))
 		}
 
-		if groovyErr, ok := err.(*jenkinsclient.GroovyScriptExecutionFailed); ok {
+		groovyErr := &jenkinsclient.GroovyScriptExecutionFailed{}
+		if errors.As(err, &groovyErr) {
 			*r.NotificationEvents <- event.Event{
 				Jenkins: *jenkins,
 				Phase:   event.PhaseBase,
@@ -188,18 +258,37 @@ func (r *JenkinsReconciler) Reconcile(_ context.Context, request ctrl.Request) (
 					[]string{fmt.Sprintf("%s Source '%s' Name '%s' groovy script execution failed, logs: %+v", groovyErr.ConfigurationType, groovyErr.Source, groovyErr.Name, groovyErr.Logs)}...,
 				),
 			}
+			notReady := health.Assessment{
+				Reason:   health.ReasonGroovyScriptExecutionFailed,
+				Messages: []string{fmt.Sprintf("%s Source '%s' Name '%s' groovy script execution failed", groovyErr.ConfigurationType, groovyErr.Source, groovyErr.Name)},
+			}
+			if err := r.setReadyCondition(jenkins, notReady); err != nil {
+				logger.V(log.VWarn).Info(fmt.Sprintf("Failed to set Ready condition: %s", err))
+			}
 			return reconcile.Result{Requeue: false}, nil
 		}
-		return reconcile.Result{Requeue: true}, nil
+		return r.requeueAfterFailure(jenkins, category, lastErrors.counter), nil
 	}
+	delete(reconcileErrors, request.Name)
 	if result.Requeue && result.RequeueAfter == 0 {
 		result.RequeueAfter = time.Duration(rand.Intn(10)) * time.Millisecond
 	}
 	return result, nil
 }
 
-func (r *JenkinsReconciler) reconcile(request reconcile.Request) (reconcile.Result, *v1alpha2.Jenkins, error) {
-	logger := logx.WithValues("cr", request.Name)
+// requeueSettings returns the timing of reconcile loops of Jenkins, Jenkins is nil when it couldn't be fetched
+func (r *JenkinsReconciler) requeueSettings(jenkins *v1alpha2.Jenkins) requeue.Settings {
+	settings := r.Requeue
+	if settings == (requeue.Settings{}) {
+		settings = requeue.Default()
+	}
+	if jenkins == nil {
+		return settings
+	}
+	return settings.ForObject(jenkins)
+}
+
+func (r *JenkinsReconciler) reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, *v1alpha2.Jenkins, error) {
 	// Fetch the Jenkins instance
 	jenkins := &v1alpha2.Jenkins{}
 	var err error
@@ -209,26 +298,63 @@ func (r *JenkinsReconciler) reconcile(request reconcile.Request) (reconcile.Resu
 			// Request object not found, could have been deleted after reconcile request.
 			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
 			// Return and don't requeue
+			metrics.DeleteJenkins(request.Namespace, request.Name)
 			return reconcile.Result{}, nil, nil
 		}
 		// Error reading the object - requeue the request.
 		return reconcile.Result{}, nil, errors.WithStack(err)
 	}
+	// the metric survives the operator restart since it's exported from the status
+	metrics.SetLastBackupTime(jenkins)
+	logger := log.ForJenkins(jenkins)
+	if err = template.Apply(context.TODO(), r.Client, jenkins); err != nil {
+		return reconcile.Result{}, jenkins, err
+	}
+	if jenkins.DeletionTimestamp != nil {
+		return reconcile.Result{}, jenkins, r.teardown(jenkins)
+	}
+	if !controllerutil.ContainsFinalizer(jenkins, base.TeardownFinalizer) {
+		patch := client.MergeFrom(jenkins.DeepCopy())
+		controllerutil.AddFinalizer(jenkins, base.TeardownFinalizer)
+		return reconcile.Result{Requeue: true}, jenkins, errors.WithStack(r.Client.Patch(context.TODO(), jenkins, patch))
+	}
+
+	basePluginManifests, err := r.getBasePluginManifests(jenkins.Namespace)
+	if err != nil {
+		return reconcile.Result{}, jenkins, err
+	}
 	var requeue bool
-	requeue, err = r.setDefaults(jenkins)
+	requeue, err = r.setDefaults(jenkins, basePluginManifests)
 	if err != nil {
 		return reconcile.Result{}, jenkins, err
 	}
 	if requeue {
 		return reconcile.Result{Requeue: true}, jenkins, nil
 	}
+	if err = r.auditSpecChange(jenkins); err != nil {
+		return reconcile.Result{}, jenkins, err
+	}
+	defer r.auditGroovyScripts(jenkins, jenkins.Status.AppliedGroovyScripts)
 
 	config := r.newJenkinsReconcilier(jenkins)
+	config.BasePluginManifests = basePluginManifests
+	if isPlanMode(jenkins) {
+		planCtx, span := tracing.Start(ctx, "ReconcilePlan")
+		result, err := r.reconcilePlan(planCtx, config)
+		tracing.End(span, err)
+		return result, jenkins, err
+	}
+	if err = r.clearPlan(jenkins); err != nil {
+		return reconcile.Result{}, jenkins, err
+	}
+
 	// Reconcile base configuration
 	baseConfiguration := base.New(config, r.JenkinsAPIConnectionSettings)
 
 	var baseMessages []string
+	_, span := tracing.Start(ctx, "ValidateBaseConfiguration")
 	baseMessages, err = baseConfiguration.Validate(jenkins)
+	tracing.End(span, err)
 	if err != nil {
 		return reconcile.Result{}, jenkins, err
 	}
@@ -244,12 +370,14 @@ func (r *JenkinsReconciler) reconcile(request reconcile.Request) (reconcile.Resu
 		for _, msg := range baseMessages {
 			logger.V(log.VWarn).Info(msg)
 		}
-		return reconcile.Result{}, jenkins, nil // don't requeue
+		return reconcile.Result{}, jenkins, r.setReconcileFailure(jenkins, failure.CategoryConfigurationInvalid, strings.Join(baseMessages, "; ")) // don't requeue
 	}
 
 	var result reconcile.Result
 	var jenkinsClient jenkinsclient.Jenkins
-	result, jenkinsClient, err = baseConfiguration.Reconcile()
+	baseCtx, span := tracing.Start(ctx, "ReconcileBaseConfiguration")
+	result, jenkinsClient, err = baseConfiguration.Reconcile(baseCtx)
+	tracing.End(span, err)
 	if err != nil {
 		return reconcile.Result{}, jenkins, err
 	}
@@ -277,13 +405,16 @@ func (r *JenkinsReconciler) reconcile(request reconcile.Request) (reconcile.Resu
 			Reason:  reason.NewBaseConfigurationComplete(reason.OperatorSource, []string{message}),
 		}
 		logger.Info(message)
+		config.Emit(k8sevent.TypeNormal, k8sevent.ReasonUserConfigurationStarted, "User configuration phase started")
 	}
 
 	// Reconcile casc, seedjobs and backups
 	userConfiguration := user.New(config, jenkinsClient)
 
 	var messages []string
+	_, span = tracing.Start(ctx, "ValidateUserConfiguration")
 	messages, err = userConfiguration.Validate(jenkins)
+	tracing.End(span, err)
 	if err != nil {
 		return reconcile.Result{}, jenkins, err
 	}
@@ -300,20 +431,31 @@ func (r *JenkinsReconciler) reconcile(request reconcile.Request) (reconcile.Resu
 		for _, msg := range messages {
 			logger.V(log.VWarn).Info(msg)
 		}
-		return reconcile.Result{}, jenkins, nil // don't requeue
+		return reconcile.Result{}, jenkins, r.setReconcileFailure(jenkins, failure.CategoryConfigurationInvalid, strings.Join(messages, "; ")) // don't requeue
 	}
 
-	// Reconcile casc
-	result, err = userConfiguration.ReconcileCasc()
+	canaryVerified, canaryResult, err := r.verifyInCanary(ctx, config, userConfiguration)
 	if err != nil {
 		return reconcile.Result{}, jenkins, err
 	}
-	if result.Requeue {
-		return result, jenkins, nil
+
+	// Reconcile casc
+	if canaryVerified {
+		_, span = tracing.Start(ctx, "ReconcileCasc")
+		result, err = userConfiguration.ReconcileCasc()
+		tracing.End(span, err)
+		if err != nil {
+			return reconcile.Result{}, jenkins, err
+		}
+		if result.Requeue {
+			return result, jenkins, nil
+		}
 	}
 
 	// Reconcile seedjobs, backups
+	_, span = tracing.Start(ctx, "ReconcileOthers")
 	result, err = userConfiguration.ReconcileOthers()
+	tracing.End(span, err)
 	if err != nil {
 		return reconcile.Result{}, jenkins, err
 	}
@@ -321,6 +463,14 @@ func (r *JenkinsReconciler) reconcile(request reconcile.Request) (reconcile.Resu
 		return result, jenkins, nil
 	}
 
+	// seed jobs and backups run while configuration changes wait for the canary Jenkins
+	if !canaryVerified {
+		if jenkins.Status.Canary != nil && jenkins.Status.Canary.Phase == v1alpha2.CanaryPhaseFailed {
+			return reconcile.Result{}, jenkins, r.setReconcileFailure(jenkins, failure.CategoryConfigurationInvalid, jenkins.Status.Canary.Message) // don't requeue
+		}
+		return canaryResult, jenkins, nil
+	}
+
 	if jenkins.Status.UserConfigurationCompletedTime == nil {
 		now := metav1.Now()
 		jenkins.Status.UserConfigurationCompletedTime = &now
@@ -338,12 +488,33 @@ func (r *JenkinsReconciler) reconcile(request reconcile.Request) (reconcile.Resu
 		}
 		logger.Info(message)
 	}
-	return reconcile.Result{}, jenkins, nil
+
+	if err = r.setReconciledCondition(jenkins, metav1.ConditionTrue, reconcileSucceededReason, "Jenkins CR has been reconciled"); err != nil {
+		return reconcile.Result{}, jenkins, err
+	}
+
+	_, span = tracing.Start(ctx, "EnsureReadiness")
+	result, err = r.ensureReadiness(config, jenkinsClient)
+	tracing.End(span, err)
+	return result, jenkins, err
+}
+
+// teardown tears down Jenkins CR being deleted and removes the finalizer, so the CR and owned resources can be deleted
+func (r *JenkinsReconciler) teardown(jenkins *v1alpha2.Jenkins) error {
+	if !controllerutil.ContainsFinalizer(jenkins, base.TeardownFinalizer) {
+		return nil
+	}
+	if err := base.New(r.newJenkinsReconcilier(jenkins), r.JenkinsAPIConnectionSettings).Teardown(); err != nil {
+		return err
+	}
+	patch := client.MergeFrom(jenkins.DeepCopy())
+	controllerutil.RemoveFinalizer(jenkins, base.TeardownFinalizer)
+	return errors.WithStack(r.Client.Patch(context.TODO(), jenkins, patch))
 }
 
-func (r *JenkinsReconciler) setDefaults(jenkins *v1alpha2.Jenkins) (requeue bool, err error) {
+func (r *JenkinsReconciler) setDefaults(jenkins *v1alpha2.Jenkins, basePluginManifests plugins.BasePluginManifests) (requeue bool, err error) {
 	changed := false
-	logger := logx.WithValues("cr", jenkins.Name)
+	logger := log.ForJenkins(jenkins)
 
 	var jenkinsContainer v1alpha2.Container
 	if len(jenkins.Spec.Master.Containers) == 0 {
@@ -351,7 +522,7 @@ func (r *JenkinsReconciler) setDefaults(jenkins *v1alpha2.Jenkins) (requeue bool
 		jenkinsContainer = v1alpha2.Container{Name: resources.JenkinsMasterContainerName}
 	} else {
 		if jenkins.Spec.Master.Containers[0].Name != resources.JenkinsMasterContainerName {
-			return false, errors.Errorf("first container in spec.master.containers must be Jenkins container with name '%s', please correct CR", resources.JenkinsMasterContainerName)
+			return false, failure.ConfigurationInvalid(errors.Errorf("first container in spec.master.containers must be Jenkins container with name '%s', please correct CR", resources.JenkinsMasterContainerName))
 		}
 		jenkinsContainer = jenkins.Spec.Master.Containers[0]
 	}
@@ -391,10 +562,15 @@ func (r *JenkinsReconciler) setDefaults(jenkins *v1alpha2.Jenkins) (requeue bool
 			Value: "-XX:MinRAMPercentage=50.0 -XX:MaxRAMPercentage=80.0 -Djenkins.install.runSetupWizard=false -Djava.awt.headless=true",
 		})
 	}
-	if len(jenkins.Spec.Master.BasePlugins) == 0 {
-		logger.Info("Setting default operator plugins")
+	if r.setDefaultBasePlugins(jenkins, basePluginManifests.ForImage(jenkinsContainer.Image), basePluginManifests) {
 		changed = true
-		jenkins.Spec.Master.BasePlugins = basePlugins()
+	}
+	for _, plugin := range tools.RequiredPlugins(jenkins.Spec.Tools) {
+		if !plugins.IsRequired(plugin.Name, jenkins.RequiredPlugins()) {
+			logger.Info(fmt.Sprintf("Adding plugin '%s' required by spec.tools", plugin))
+			changed = true
+			jenkins.Spec.Master.Plugins = append(jenkins.Spec.Master.Plugins, v1alpha2.Plugin{Name: plugin.Name, Version: plugin.Version})
+		}
 	}
 	if isResourceRequirementsNotSet(jenkinsContainer.Resources) {
 		logger.Info("Setting default Jenkins master container resource requirements")
@@ -422,13 +598,18 @@ func (r *JenkinsReconciler) setDefaults(jenkins *v1alpha2.Jenkins) (requeue bool
 		}
 	}
 	if len(jenkins.Spec.Master.Containers) > 1 {
-		for i, container := range jenkins.Spec.Master.Containers[1:] {
-			if r.setDefaultsForContainer(jenkins, container.Name, i+1) {
+		for i := range jenkins.Spec.Master.Containers[1:] {
+			if r.setDefaultsForContainer(jenkins, &jenkins.Spec.Master.Containers[i+1]) {
 				changed = true
 			}
 		}
 	}
-	if len(jenkins.Spec.Backup.ContainerName) > 0 && jenkins.Spec.Backup.Interval == 0 {
+	for i := range jenkins.Spec.Master.InitContainers {
+		if r.setDefaultsForContainer(jenkins, &jenkins.Spec.Master.InitContainers[i]) {
+			changed = true
+		}
+	}
+	if (len(jenkins.Spec.Backup.ContainerName) > 0 || backup.IsBackupConfigured(jenkins)) && jenkins.Spec.Backup.Interval == 0 {
 		logger.Info("Setting default backup interval")
 		changed = true
 		jenkins.Spec.Backup.Interval = 30
@@ -455,10 +636,17 @@ func (r *JenkinsReconciler) setDefaults(jenkins *v1alpha2.Jenkins) (requeue bool
 		jenkins.Spec.JenkinsAPISettings.AuthorizationStrategy = v1alpha2.CreateUserAuthorizationStrategy
 	}
 
-	if changed {
+	if jenkins.Spec.TrustedCABundle != nil && len(jenkins.Spec.TrustedCABundle.Key) == 0 {
+		logger.Info("Setting default trusted CA bundle key")
+		changed = true
+		jenkins.Spec.TrustedCABundle.Key = constants.DefaultTrustedCABundleKey
+	}
+
+	// the defaults of Jenkins CR created from JenkinsTemplate are kept in memory, the merged spec mustn't be written back
+	if changed && !template.IsTemplated(jenkins) {
 		return changed, errors.WithStack(r.Client.Update(context.TODO(), jenkins))
 	}
-	return changed, nil
+	return false, nil
 }
 
 func isJavaOpsVariableNotSet(container v1alpha2.Container) bool {
@@ -470,19 +658,19 @@ func isJavaOpsVariableNotSet(container v1alpha2.Container) bool {
 	return true
 }
 
-func (r *JenkinsReconciler) setDefaultsForContainer(jenkins *v1alpha2.Jenkins, containerName string, containerIndex int) bool {
+func (r *JenkinsReconciler) setDefaultsForContainer(jenkins *v1alpha2.Jenkins, container *v1alpha2.Container) bool {
 	changed := false
-	logger := logx.WithValues("cr", jenkins.Name, "container", containerName)
+	logger := log.ForJenkins(jenkins).WithValues("container", container.Name)
 
-	if len(jenkins.Spec.Master.Containers[containerIndex].ImagePullPolicy) == 0 {
+	if len(container.ImagePullPolicy) == 0 {
 		logger.Info(fmt.Sprintf("Setting default container image pull policy: %s", corev1.PullAlways))
 		changed = true
-		jenkins.Spec.Master.Containers[containerIndex].ImagePullPolicy = corev1.PullAlways
+		container.ImagePullPolicy = corev1.PullAlways
 	}
-	if isResourceRequirementsNotSet(jenkins.Spec.Master.Containers[containerIndex].Resources) {
+	if isResourceRequirementsNotSet(container.Resources) {
 		logger.Info("Setting default container resource requirements")
 		changed = true
-		jenkins.Spec.Master.Containers[containerIndex].Resources = resources.NewResourceRequirements("50m", "50Mi", "100m", "100Mi")
+		container.Resources = resources.NewResourceRequirements("50m", "50Mi", "100m", "100Mi")
 	}
 	return changed
 }
@@ -491,8 +679,40 @@ func isResourceRequirementsNotSet(requirements corev1.ResourceRequirements) bool
 	return reflect.DeepEqual(requirements, corev1.ResourceRequirements{})
 }
 
-func basePlugins() (result []v1alpha2.Plugin) {
-	for _, value := range plugins.BasePlugins() {
+// setDefaultBasePlugins sets the base plugins of the manifest selected for Jenkins image, the base plugins set
+// from another manifest are replaced when the image moves to another LTS line unless user has changed them
+func (r *JenkinsReconciler) setDefaultBasePlugins(jenkins *v1alpha2.Jenkins, manifest plugins.BasePluginManifest, basePluginManifests plugins.BasePluginManifests) bool {
+	logger := log.ForJenkins(jenkins)
+	if len(jenkins.Spec.Master.BasePlugins) > 0 {
+		current, found := basePluginManifests.Get(jenkins.Annotations[plugins.BasePluginsManifestAnnotation])
+		if !found || current.MinJenkinsVersion == manifest.MinJenkinsVersion || !reflect.DeepEqual(jenkins.Spec.Master.BasePlugins, basePlugins(current)) {
+			return false
+		}
+		logger.Info(fmt.Sprintf("Updating operator plugins to the manifest for Jenkins %s", manifest.MinJenkinsVersion))
+	} else {
+		logger.Info(fmt.Sprintf("Setting default operator plugins for Jenkins %s", manifest.MinJenkinsVersion))
+	}
+
+	jenkins.Spec.Master.BasePlugins = basePlugins(manifest)
+	if jenkins.Annotations == nil {
+		jenkins.Annotations = map[string]string{}
+	}
+	jenkins.Annotations[plugins.BasePluginsManifestAnnotation] = manifest.MinJenkinsVersion
+	return true
+}
+
+// getBasePluginManifests returns the embedded base plugin manifests merged with the manifests from --base-plugins-configmap
+func (r *JenkinsReconciler) getBasePluginManifests(namespace string) (plugins.BasePluginManifests, error) {
+	reader := r.APIReader
+	if reader == nil {
+		// offline validation reads the manifests from the fake client
+		reader = r.Client
+	}
+	return plugins.LoadBasePluginManifests(reader, r.BasePluginsConfigMap, namespace)
+}
+
+func basePlugins(manifest plugins.BasePluginManifest) (result []v1alpha2.Plugin) {
+	for _, value := range manifest.Plugins {
 		result = append(result, v1alpha2.Plugin{Name: value.Name, Version: value.Version})
 	}
 	return
@@ -0,0 +1,148 @@
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	jenkinsclient "github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/jenkinsmetrics"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/template"
+	k8sevent "github.com/jenkinsci/kubernetes-operator/pkg/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+	"github.com/jenkinsci/kubernetes-operator/pkg/metrics"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/probes"
+	"github.com/jenkinsci/kubernetes-operator/pkg/tracing"
+
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// JenkinsMetricsReconciler scrapes queue, executor, node and build metrics from Jenkins according to spec.monitoring.exporter
+type JenkinsMetricsReconciler struct {
+	Client                       client.Client
+	Scheme                       *runtime.Scheme
+	JenkinsAPIConnectionSettings jenkinsclient.JenkinsAPIConnectionSettings
+	ClientSet                    kubernetes.Clientset
+	Config                       rest.Config
+	NotificationEvents           *chan event.Event
+	Events                       k8sevent.Recorder
+	KubernetesClusterDomain      string
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *JenkinsMetricsReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	probes.Reconciles.Register("jenkins-metrics")
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("jenkins-metrics").
+		For(&v1alpha2.Jenkins{}).
+		Complete(r)
+}
+
+func (r *JenkinsMetricsReconciler) newJenkinsConfiguration(jenkins *v1alpha2.Jenkins) configuration.Configuration {
+	return configuration.Configuration{
+		Client:                       r.Client,
+		ClientSet:                    r.ClientSet,
+		Notifications:                r.NotificationEvents,
+		Events:                       r.Events,
+		Jenkins:                      jenkins,
+		Scheme:                       r.Scheme,
+		Config:                       &r.Config,
+		JenkinsAPIConnectionSettings: r.JenkinsAPIConnectionSettings,
+		KubernetesClusterDomain:      r.KubernetesClusterDomain,
+	}
+}
+
+// Reconcile scrapes metrics of Jenkins defined by Jenkins CR.
+func (r *JenkinsMetricsReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	done := probes.Reconciles.Track("jenkins-metrics")
+	ctx, span := tracing.Start(ctx, "ReconcileJenkinsMetrics", tracing.JenkinsAttributes(request.Namespace, request.Name)...)
+	result, err := r.reconcile(ctx, request)
+	tracing.End(span, err)
+	done(err)
+	return result, err
+}
+
+func (r *JenkinsMetricsReconciler) reconcile(ctx context.Context, request ctrl.Request) (reconcile.Result, error) {
+	jenkins := &v1alpha2.Jenkins{}
+	err := r.Client.Get(context.TODO(), request.NamespacedName, jenkins)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			metrics.DeleteJenkinsMetrics(&v1alpha2.Jenkins{ObjectMeta: metav1.ObjectMeta{Namespace: request.Namespace, Name: request.Name}})
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, errors.WithStack(err)
+	}
+	if err = template.Apply(context.TODO(), r.Client, jenkins); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if jenkins.Spec.Monitoring == nil || jenkins.Spec.Monitoring.Exporter == nil {
+		metrics.DeleteJenkinsMetrics(jenkins)
+		if jenkins.Status.Exporter == nil {
+			return reconcile.Result{}, nil
+		}
+		jenkins.Status.Exporter = nil
+		return reconcile.Result{}, errors.WithStack(r.Client.Status().Update(context.TODO(), jenkins))
+	}
+	interval := jenkinsmetrics.GetScrapeInterval(*jenkins.Spec.Monitoring.Exporter)
+	if isPlanMode(jenkins) {
+		return reconcile.Result{}, nil
+	}
+	var lastScrapeJenkinsTimeMillis int64
+	if jenkins.Status.Exporter != nil && jenkins.Status.Exporter.LastScrapeTime != nil {
+		if next := jenkins.Status.Exporter.LastScrapeTime.Add(interval); time.Now().Before(next) {
+			return reconcile.Result{RequeueAfter: time.Until(next)}, nil
+		}
+		lastScrapeJenkinsTimeMillis = jenkins.Status.Exporter.LastScrapeJenkinsTimeMillis
+	}
+	if !isJenkinsReady(jenkins) {
+		return reconcile.Result{RequeueAfter: notReadyRequeueInterval}, nil
+	}
+
+	config := r.newJenkinsConfiguration(jenkins)
+	jenkinsClient, err := config.GetJenkinsClient(ctx)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	logs, err := jenkinsClient.ExecuteScript(jenkinsmetrics.RenderScrapeScript(lastScrapeJenkinsTimeMillis))
+	if err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "couldn't scrape Jenkins metrics, logs '%s'", logs)
+	}
+	snapshot, err := jenkinsmetrics.ParseScrape(logs)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	metrics.SetQueue(jenkins, snapshot.QueueLength, snapshot.QueueBuildable, snapshot.QueueStuck)
+	metrics.SetExecutors(jenkins, snapshot.Executors, snapshot.BusyExecutors)
+	metrics.SetNodes(jenkins, snapshot.NodesOnline, snapshot.NodesOffline)
+	for _, build := range snapshot.Builds {
+		metrics.ObserveBuildDuration(jenkins, build.Result, time.Duration(build.DurationMillis)*time.Millisecond)
+	}
+	// the builds are observed already, the status is updated until it succeeds so they aren't reported again
+	now := metav1.Now()
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := r.Client.Get(context.TODO(), request.NamespacedName, jenkins); err != nil {
+			return err
+		}
+		jenkins.Status.Exporter = &v1alpha2.ExporterStatus{LastScrapeTime: &now, LastScrapeJenkinsTimeMillis: snapshot.TimeMillis}
+		return r.Client.Status().Update(context.TODO(), jenkins)
+	})
+	if err != nil {
+		return reconcile.Result{}, errors.WithStack(err)
+	}
+	log.ForJenkins(jenkins).V(log.VDebug).Info("Jenkins metrics have been scraped")
+
+	return reconcile.Result{RequeueAfter: interval}, nil
+}
@@ -0,0 +1,319 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	jenkinsclient "github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/backuprestore"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/operation"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/template"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/user/casc"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/user/seedjobs"
+	k8sevent "github.com/jenkinsci/kubernetes-operator/pkg/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+	"github.com/jenkinsci/kubernetes-operator/pkg/probes"
+	"github.com/jenkinsci/kubernetes-operator/pkg/tracing"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// operationCheckInterval is the interval of checks of Jenkins where the action is performed
+	operationCheckInterval = 10 * time.Second
+	// operationLogKey is the log key with name of the reconciled JenkinsOperation CR
+	operationLogKey = "jenkinsoperation"
+)
+
+// JenkinsOperationReconciler reconciles a JenkinsOperation object
+type JenkinsOperationReconciler struct {
+	Client                       client.Client
+	Scheme                       *runtime.Scheme
+	JenkinsAPIConnectionSettings jenkinsclient.JenkinsAPIConnectionSettings
+	ClientSet                    kubernetes.Clientset
+	Config                       rest.Config
+	Events                       k8sevent.Recorder
+	KubernetesClusterDomain      string
+	// AllowGroovy allows Groovy action which runs any script in Jenkins with administrator permissions
+	AllowGroovy bool
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *JenkinsOperationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	probes.Reconciles.Register("jenkinsoperation")
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha2.JenkinsOperation{}).
+		Complete(r)
+}
+
+func (r *JenkinsOperationReconciler) newJenkinsConfiguration(jenkins *v1alpha2.Jenkins) configuration.Configuration {
+	return configuration.Configuration{
+		Client:                       r.Client,
+		ClientSet:                    r.ClientSet,
+		Events:                       r.Events,
+		Jenkins:                      jenkins,
+		Scheme:                       r.Scheme,
+		Config:                       &r.Config,
+		JenkinsAPIConnectionSettings: r.JenkinsAPIConnectionSettings,
+		KubernetesClusterDomain:      r.KubernetesClusterDomain,
+	}
+}
+
+// +kubebuilder:rbac:groups=jenkins.io,resources=jenkinsoperations,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=jenkins.io,resources=jenkinsoperations/status,verbs=get;update;patch
+
+// Reconcile performs the action defined by JenkinsOperation CR once.
+func (r *JenkinsOperationReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	logger := log.Log.WithValues(log.NamespaceKey, request.Namespace, operationLogKey, request.Name)
+	logger.V(log.VDebug).Info("Reconciling JenkinsOperation")
+
+	done := probes.Reconciles.Track("jenkinsoperation")
+	ctx, span := tracing.Start(ctx, "ReconcileOperation", attribute.String("jenkinsoperation.name", request.Name), tracing.JenkinsNamespaceKey.String(request.Namespace))
+	result, err := r.reconcile(ctx, request, logger)
+	tracing.End(span, err)
+	done(err)
+	if err != nil && apierrors.IsConflict(err) {
+		return reconcile.Result{Requeue: true}, nil
+	}
+	return result, err
+}
+
+func (r *JenkinsOperationReconciler) reconcile(ctx context.Context, request ctrl.Request, logger logr.Logger) (reconcile.Result, error) {
+	op := &v1alpha2.JenkinsOperation{}
+	err := r.Client.Get(context.TODO(), request.NamespacedName, op)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, errors.WithStack(err)
+	}
+	logger = log.ForObject(op, operationLogKey)
+
+	if operation.IsFinished(op) {
+		return r.deleteExpiredOperation(op, logger) // the action is performed only once
+	}
+
+	if op.Status.StartTime == nil {
+		now := metav1.Now()
+		op.Status.Phase = v1alpha2.OperationPhasePending
+		op.Status.StartTime = &now
+		op.Status.Message = fmt.Sprintf("Waiting for Jenkins '%s'", op.Spec.JenkinsRef.Name)
+		if err = r.Client.Status().Update(context.TODO(), op); err != nil {
+			return reconcile.Result{}, errors.WithStack(err)
+		}
+	}
+
+	jenkins := &v1alpha2.Jenkins{}
+	err = r.Client.Get(context.TODO(), types.NamespacedName{Namespace: op.Namespace, Name: op.Spec.JenkinsRef.Name}, jenkins)
+	if err != nil && apierrors.IsNotFound(err) {
+		return r.finishOperation(op, nil, false, fmt.Sprintf("Jenkins CR '%s' not found", op.Spec.JenkinsRef.Name), logger)
+	} else if err != nil {
+		return reconcile.Result{}, errors.WithStack(err)
+	}
+	if err = template.Apply(ctx, r.Client, jenkins); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if messages := operation.Validate(op, jenkins); len(messages) > 0 {
+		return r.finishOperation(op, jenkins, false, strings.Join(messages, "; "), logger)
+	}
+	if op.Spec.Action == v1alpha2.GroovyOperationAction && !r.AllowGroovy {
+		return r.finishOperation(op, jenkins, false, "Groovy action is disabled, it's allowed by --operation-groovy operator flag", logger)
+	}
+	if operation.IsTimedOut(op, time.Now()) {
+		message := fmt.Sprintf("Action '%s' not completed after %s: %s", op.Spec.Action, operation.GetTimeout(op), op.Status.Message)
+		return r.finishOperation(op, jenkins, false, message, logger)
+	}
+
+	// Backup action is performed by Jenkins CR reconciler
+	if op.Status.Phase == v1alpha2.OperationPhaseRunning && op.Spec.Action == v1alpha2.BackupOperationAction {
+		if jenkins.Status.PendingBackup < op.Status.BackupNumber {
+			return r.scheduleBackup(op, jenkins, logger)
+		}
+		if jenkins.Status.LastBackup >= op.Status.BackupNumber {
+			return r.finishOperation(op, jenkins, true, fmt.Sprintf("Backup '%d' completed", op.Status.BackupNumber), logger)
+		}
+		return reconcile.Result{RequeueAfter: operationCheckInterval}, nil
+	}
+	// only safe restart is waited for, the result of other actions is recorded right after they are performed
+	if op.Status.Phase == v1alpha2.OperationPhaseRunning && op.Spec.Action != v1alpha2.SafeRestartOperationAction {
+		message := fmt.Sprintf("Action '%s' has been started but its result hasn't been recorded, it isn't performed again", op.Spec.Action)
+		return r.finishOperation(op, jenkins, false, message, logger)
+	}
+
+	if !meta.IsStatusConditionTrue(jenkins.Status.Conditions, v1alpha2.JenkinsReadyConditionType) && op.Status.Phase == v1alpha2.OperationPhasePending {
+		return reconcile.Result{RequeueAfter: operationCheckInterval}, nil
+	}
+	config := r.newJenkinsConfiguration(jenkins)
+	jenkinsClient, err := config.GetJenkinsClient(ctx)
+	if err != nil {
+		logger.V(log.VDebug).Info(fmt.Sprintf("Failed to connect to Jenkins: %s", err))
+		return reconcile.Result{RequeueAfter: operationCheckInterval}, nil
+	}
+
+	if op.Status.Phase == v1alpha2.OperationPhaseRunning {
+		return r.checkSafeRestart(op, jenkins, jenkinsClient, logger)
+	}
+	return r.startOperation(op, jenkins, jenkinsClient, logger)
+}
+
+// startOperation records Running phase before the action is performed, the update fails on conflict when the operation
+// has been already started, so the action isn't performed again after a stale read or a failed update of the result
+func (r *JenkinsOperationReconciler) startOperation(op *v1alpha2.JenkinsOperation, jenkins *v1alpha2.Jenkins,
+	jenkinsClient jenkinsclient.Jenkins, logger logr.Logger) (reconcile.Result, error) {
+	if op.Spec.Action == v1alpha2.BackupOperationAction {
+		op.Status.BackupNumber = jenkins.Status.LastBackup + 1
+		if jenkins.Status.PendingBackup != jenkins.Status.LastBackup {
+			op.Status.BackupNumber = jenkins.Status.PendingBackup
+		}
+	}
+	op.Status.Phase = v1alpha2.OperationPhaseRunning
+	op.Status.Message = fmt.Sprintf("Performing action '%s'", op.Spec.Action)
+	if err := r.Client.Status().Update(context.TODO(), op); err != nil {
+		return reconcile.Result{}, errors.WithStack(err)
+	}
+
+	logger.Info(fmt.Sprintf("Performing action '%s' in Jenkins '%s'", op.Spec.Action, jenkins.Name))
+	r.Events.Emitf(jenkins, k8sevent.TypeNormal, k8sevent.ReasonOperationStarted, "Performing action '%s' of JenkinsOperation '%s'", op.Spec.Action, op.Name)
+
+	switch op.Spec.Action {
+	case v1alpha2.BackupOperationAction:
+		return r.scheduleBackup(op, jenkins, logger)
+	case v1alpha2.SafeRestartOperationAction:
+		if err := jenkinsClient.SafeRestart(); err != nil {
+			return r.finishOperation(op, jenkins, false, fmt.Sprintf("Safe restart failed: %s", err), logger)
+		}
+		return r.setOperationRunning(op, "Waiting for Jenkins restart, running builds are completed first")
+	case v1alpha2.ReloadCascOperationAction:
+		if err := casc.New(jenkinsClient, r.Client, jenkins).Reload(); err != nil {
+			return r.finishOperation(op, jenkins, false, fmt.Sprintf("Configuration as Code failed: %s", err), logger)
+		}
+		return r.finishOperation(op, jenkins, true, "Configuration as Code has been applied", logger)
+	case v1alpha2.RerunSeedJobsOperationAction:
+		if err := seedjobs.BuildSeedJobs(jenkinsClient, jenkins); err != nil {
+			return r.finishOperation(op, jenkins, false, err.Error(), logger)
+		}
+		return r.finishOperation(op, jenkins, true, fmt.Sprintf("Builds of %d seed jobs have been queued", len(jenkins.Spec.SeedJobs)), logger)
+	case v1alpha2.GroovyOperationAction:
+		output, err := jenkinsClient.ExecuteScript(op.Spec.Groovy)
+		op.Status.Output = operation.ScriptOutput(output)
+		if err != nil {
+			return r.finishOperation(op, jenkins, false, fmt.Sprintf("Groovy script failed: %s", err), logger)
+		}
+		return r.finishOperation(op, jenkins, true, "Groovy script has been run", logger)
+	default:
+		return r.finishOperation(op, jenkins, false, fmt.Sprintf("Unsupported spec.action '%s'", op.Spec.Action), logger)
+	}
+}
+
+// scheduleBackup schedules the backup of the operation unless it has been already scheduled
+func (r *JenkinsOperationReconciler) scheduleBackup(op *v1alpha2.JenkinsOperation, jenkins *v1alpha2.Jenkins, logger logr.Logger) (reconcile.Result, error) {
+	if jenkins.Status.PendingBackup < op.Status.BackupNumber {
+		backupNumber, err := backuprestore.New(r.newJenkinsConfiguration(jenkins), logger).ScheduleBackup()
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		op.Status.BackupNumber = backupNumber
+	}
+	return r.setOperationRunning(op, fmt.Sprintf("Waiting for backup '%d'", op.Status.BackupNumber))
+}
+
+// checkSafeRestart checks if Jenkins has been restarted after the action has been started, Jenkins JVM is started again
+// by the safe restart
+func (r *JenkinsOperationReconciler) checkSafeRestart(op *v1alpha2.JenkinsOperation, jenkins *v1alpha2.Jenkins,
+	jenkinsClient jenkinsclient.Jenkins, logger logr.Logger) (reconcile.Result, error) {
+	output, err := jenkinsClient.ExecuteScript(operation.JVMStartTimeScript)
+	if err != nil {
+		logger.V(log.VDebug).Info(fmt.Sprintf("Failed to get JVM start time of Jenkins: %s", err))
+		return reconcile.Result{RequeueAfter: operationCheckInterval}, nil
+	}
+	startTime, err := operation.ParseJVMStartTime(output)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if startTime.After(op.Status.StartTime.Time) {
+		return r.finishOperation(op, jenkins, true, "Jenkins has been restarted", logger)
+	}
+	return reconcile.Result{RequeueAfter: operationCheckInterval}, nil
+}
+
+func (r *JenkinsOperationReconciler) setOperationRunning(op *v1alpha2.JenkinsOperation, message string) (reconcile.Result, error) {
+	op.Status.Phase = v1alpha2.OperationPhaseRunning
+	op.Status.Message = message
+	if err := r.Client.Status().Update(context.TODO(), op); err != nil {
+		return reconcile.Result{}, errors.WithStack(err)
+	}
+	return reconcile.Result{RequeueAfter: operationCheckInterval}, nil
+}
+
+// finishOperation records the result of the action in status and emits the event on Jenkins CR
+func (r *JenkinsOperationReconciler) finishOperation(op *v1alpha2.JenkinsOperation, jenkins *v1alpha2.Jenkins, succeeded bool,
+	message string, logger logr.Logger) (reconcile.Result, error) {
+	now := metav1.Now()
+	op.Status.CompletionTime = &now
+	op.Status.Message = message
+	if succeeded {
+		op.Status.Phase = v1alpha2.OperationPhaseSucceeded
+	} else {
+		op.Status.Phase = v1alpha2.OperationPhaseFailed
+	}
+	if err := r.Client.Status().Update(context.TODO(), op); err != nil {
+		return reconcile.Result{}, errors.WithStack(err)
+	}
+
+	if succeeded {
+		logger.Info(message)
+	} else {
+		logger.V(log.VWarn).Info(fmt.Sprintf("Action '%s' failed: %s", op.Spec.Action, message))
+	}
+	if jenkins != nil {
+		if succeeded {
+			r.Events.Emitf(jenkins, k8sevent.TypeNormal, k8sevent.ReasonOperationSucceeded, "Action '%s' of JenkinsOperation '%s' succeeded: %s", op.Spec.Action, op.Name, message)
+		} else {
+			r.Events.Emitf(jenkins, k8sevent.TypeWarning, k8sevent.ReasonOperationFailed, "Action '%s' of JenkinsOperation '%s' failed: %s", op.Spec.Action, op.Name, message)
+		}
+	}
+	return r.deleteExpiredOperation(op, logger)
+}
+
+// deleteExpiredOperation deletes the finished JenkinsOperation CR after spec.ttlSecondsAfterFinished
+func (r *JenkinsOperationReconciler) deleteExpiredOperation(op *v1alpha2.JenkinsOperation, logger logr.Logger) (reconcile.Result, error) {
+	expired, after := operation.IsExpired(op, time.Now())
+	if !expired {
+		return reconcile.Result{RequeueAfter: after}, nil
+	}
+	logger.V(log.VDebug).Info("Deleting JenkinsOperation, spec.ttlSecondsAfterFinished elapsed")
+	return reconcile.Result{}, errors.WithStack(client.IgnoreNotFound(r.Client.Delete(context.TODO(), op)))
+}
@@ -0,0 +1,207 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	jenkinsclient "github.com/jenkinsci/kubernetes-operator/pkg/client"
+	k8sevent "github.com/jenkinsci/kubernetes-operator/pkg/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+type fakeEvents struct {
+	reasons []k8sevent.Reason
+}
+
+func (f *fakeEvents) Emit(_ runtime.Object, _ k8sevent.Type, reason k8sevent.Reason, _ string) {
+	f.reasons = append(f.reasons, reason)
+}
+
+func (f *fakeEvents) Emitf(_ runtime.Object, _ k8sevent.Type, reason k8sevent.Reason, _ string, _ ...interface{}) {
+	f.reasons = append(f.reasons, reason)
+}
+
+func newOperationJenkins(lastBackup, pendingBackup uint64) *v1alpha2.Jenkins {
+	return &v1alpha2.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: "default"},
+		Spec: v1alpha2.JenkinsSpec{
+			Backup: v1alpha2.Backup{
+				ContainerName: "backup",
+				Action:        v1alpha2.Handler{Exec: &corev1.ExecAction{Command: []string{"backup.sh"}}},
+			},
+		},
+		Status: v1alpha2.JenkinsStatus{LastBackup: lastBackup, PendingBackup: pendingBackup},
+	}
+}
+
+func newOperation(action v1alpha2.OperationAction, groovy string) *v1alpha2.JenkinsOperation {
+	now := metav1.Now()
+	return &v1alpha2.JenkinsOperation{
+		ObjectMeta: metav1.ObjectMeta{Name: "operation", Namespace: "default"},
+		Spec: v1alpha2.JenkinsOperationSpec{
+			JenkinsRef: v1alpha2.JenkinsRef{Name: "jenkins"},
+			Action:     action,
+			Groovy:     groovy,
+		},
+		Status: v1alpha2.JenkinsOperationStatus{Phase: v1alpha2.OperationPhasePending, StartTime: &now},
+	}
+}
+
+func getObject(t *testing.T, k8sClient client.Client, object client.Object) {
+	require.NoError(t, k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: object.GetNamespace(), Name: object.GetName()}, object))
+}
+
+func TestJenkinsOperationReconciler_Backup(t *testing.T) {
+	log.SetupLogger(true)
+	require.NoError(t, v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme))
+
+	t.Run("schedules backup", func(t *testing.T) {
+		jenkins := newOperationJenkins(2, 2)
+		op := newOperation(v1alpha2.BackupOperationAction, "")
+		fakeClient := fake.NewClientBuilder().WithObjects(jenkins, op).Build()
+		reconciler := JenkinsOperationReconciler{Client: fakeClient, Scheme: scheme.Scheme, Events: &fakeEvents{}}
+
+		_, err := reconciler.startOperation(op, jenkins, nil, log.Log)
+
+		require.NoError(t, err)
+		getObject(t, fakeClient, op)
+		getObject(t, fakeClient, jenkins)
+		assert.Equal(t, v1alpha2.OperationPhaseRunning, op.Status.Phase)
+		assert.Equal(t, uint64(3), op.Status.BackupNumber)
+		assert.Equal(t, uint64(3), jenkins.Status.PendingBackup)
+	})
+	t.Run("schedules backup when claimed operation hasn't scheduled it", func(t *testing.T) {
+		jenkins := newOperationJenkins(2, 2)
+		op := newOperation(v1alpha2.BackupOperationAction, "")
+		op.Status.Phase = v1alpha2.OperationPhaseRunning
+		op.Status.BackupNumber = 3
+		fakeClient := fake.NewClientBuilder().WithObjects(jenkins, op).Build()
+		reconciler := JenkinsOperationReconciler{Client: fakeClient, Scheme: scheme.Scheme, Events: &fakeEvents{}}
+
+		_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Namespace: op.Namespace, Name: op.Name}})
+
+		require.NoError(t, err)
+		getObject(t, fakeClient, op)
+		getObject(t, fakeClient, jenkins)
+		assert.Equal(t, v1alpha2.OperationPhaseRunning, op.Status.Phase)
+		assert.Equal(t, uint64(3), jenkins.Status.PendingBackup)
+	})
+	t.Run("waits for backup in progress", func(t *testing.T) {
+		jenkins := newOperationJenkins(2, 3)
+		op := newOperation(v1alpha2.BackupOperationAction, "")
+		fakeClient := fake.NewClientBuilder().WithObjects(jenkins, op).Build()
+		reconciler := JenkinsOperationReconciler{Client: fakeClient, Scheme: scheme.Scheme, Events: &fakeEvents{}}
+
+		_, err := reconciler.startOperation(op, jenkins, nil, log.Log)
+
+		require.NoError(t, err)
+		getObject(t, fakeClient, op)
+		getObject(t, fakeClient, jenkins)
+		assert.Equal(t, uint64(3), op.Status.BackupNumber)
+		assert.Equal(t, uint64(3), jenkins.Status.PendingBackup)
+	})
+	t.Run("finishes after backup", func(t *testing.T) {
+		jenkins := newOperationJenkins(3, 3)
+		op := newOperation(v1alpha2.BackupOperationAction, "")
+		op.Status.Phase = v1alpha2.OperationPhaseRunning
+		op.Status.BackupNumber = 3
+		fakeClient := fake.NewClientBuilder().WithObjects(jenkins, op).Build()
+		events := &fakeEvents{}
+		reconciler := JenkinsOperationReconciler{Client: fakeClient, Scheme: scheme.Scheme, Events: events}
+
+		_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Namespace: op.Namespace, Name: op.Name}})
+
+		require.NoError(t, err)
+		getObject(t, fakeClient, op)
+		assert.Equal(t, v1alpha2.OperationPhaseSucceeded, op.Status.Phase)
+		assert.Equal(t, []k8sevent.Reason{k8sevent.ReasonOperationSucceeded}, events.reasons)
+	})
+}
+
+func TestJenkinsOperationReconciler_Groovy(t *testing.T) {
+	log.SetupLogger(true)
+	require.NoError(t, v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme))
+
+	t.Run("disabled", func(t *testing.T) {
+		jenkins := newOperationJenkins(0, 0)
+		op := newOperation(v1alpha2.GroovyOperationAction, "println 'test'")
+		fakeClient := fake.NewClientBuilder().WithObjects(jenkins, op).Build()
+		events := &fakeEvents{}
+		reconciler := JenkinsOperationReconciler{Client: fakeClient, Scheme: scheme.Scheme, Events: events}
+
+		_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Namespace: op.Namespace, Name: op.Name}})
+
+		require.NoError(t, err)
+		getObject(t, fakeClient, op)
+		assert.Equal(t, v1alpha2.OperationPhaseFailed, op.Status.Phase)
+		assert.Contains(t, op.Status.Message, "--operation-groovy")
+		assert.Equal(t, []k8sevent.Reason{k8sevent.ReasonOperationFailed}, events.reasons)
+	})
+	t.Run("enabled", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		jenkinsClient := jenkinsclient.NewMockJenkins(ctrl)
+		jenkinsClient.EXPECT().ExecuteScript("println 'test'").Return("test\n", nil)
+		jenkins := newOperationJenkins(0, 0)
+		op := newOperation(v1alpha2.GroovyOperationAction, "println 'test'")
+		fakeClient := fake.NewClientBuilder().WithObjects(jenkins, op).Build()
+		reconciler := JenkinsOperationReconciler{Client: fakeClient, Scheme: scheme.Scheme, Events: &fakeEvents{}, AllowGroovy: true}
+
+		_, err := reconciler.startOperation(op, jenkins, jenkinsClient, log.Log)
+
+		require.NoError(t, err)
+		getObject(t, fakeClient, op)
+		assert.Equal(t, v1alpha2.OperationPhaseSucceeded, op.Status.Phase)
+		assert.Equal(t, "test", op.Status.Output)
+	})
+	t.Run("doesn't run script when operation has been started by stale read", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		jenkinsClient := jenkinsclient.NewMockJenkins(ctrl)
+		jenkins := newOperationJenkins(0, 0)
+		op := newOperation(v1alpha2.GroovyOperationAction, "println 'test'")
+		fakeClient := fake.NewClientBuilder().WithObjects(jenkins, op).Build()
+		stale := op.DeepCopy()
+		getObject(t, fakeClient, stale)
+		started := stale.DeepCopy()
+		started.Status.Phase = v1alpha2.OperationPhaseRunning
+		require.NoError(t, fakeClient.Status().Update(context.TODO(), started))
+		reconciler := JenkinsOperationReconciler{Client: fakeClient, Scheme: scheme.Scheme, Events: &fakeEvents{}, AllowGroovy: true}
+
+		_, err := reconciler.startOperation(stale, jenkins, jenkinsClient, log.Log)
+
+		assert.True(t, apierrors.IsConflict(err))
+	})
+	t.Run("doesn't run script again when result hasn't been recorded", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		jenkins := newOperationJenkins(0, 0)
+		op := newOperation(v1alpha2.GroovyOperationAction, "println 'test'")
+		op.Status.Phase = v1alpha2.OperationPhaseRunning
+		fakeClient := fake.NewClientBuilder().WithObjects(jenkins, op).Build()
+		events := &fakeEvents{}
+		reconciler := JenkinsOperationReconciler{Client: fakeClient, Scheme: scheme.Scheme, Events: events, AllowGroovy: true}
+
+		_, err := reconciler.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Namespace: op.Namespace, Name: op.Name}})
+
+		require.NoError(t, err)
+		getObject(t, fakeClient, op)
+		assert.Equal(t, v1alpha2.OperationPhaseFailed, op.Status.Phase)
+		assert.Contains(t, op.Status.Message, "it isn't performed again")
+		assert.Equal(t, []k8sevent.Reason{k8sevent.ReasonOperationFailed}, events.reasons)
+	})
+}
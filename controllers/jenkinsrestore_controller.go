@@ -0,0 +1,527 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/backup"
+	jenkinsclient "github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/backuprestore"
+	k8sevent "github.com/jenkinsci/kubernetes-operator/pkg/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/reason"
+	"github.com/jenkinsci/kubernetes-operator/pkg/probes"
+	"github.com/jenkinsci/kubernetes-operator/pkg/tracing"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// restoreCheckInterval is the interval of checks of Jenkins where the backup is restored
+	restoreCheckInterval = 10 * time.Second
+	// restoreLogKey is the log key with name of the reconciled JenkinsRestore CR
+	restoreLogKey = "jenkinsrestore"
+	// maxVerificationHistory is the number of backup verification results kept in JenkinsRestore status
+	maxVerificationHistory = 10
+
+	verificationSucceededReason = "VerificationSucceeded"
+	verificationFailedReason    = "VerificationFailed"
+	backupNotFoundReason        = "BackupNotFound"
+	restoreInProgressReason     = "RestoreInProgress"
+	restoreSucceededReason      = "RestoreSucceeded"
+	restoreFailedReason         = "RestoreFailed"
+)
+
+// JenkinsRestoreReconciler reconciles a JenkinsRestore object
+type JenkinsRestoreReconciler struct {
+	Client                       client.Client
+	Scheme                       *runtime.Scheme
+	JenkinsAPIConnectionSettings jenkinsclient.JenkinsAPIConnectionSettings
+	ClientSet                    kubernetes.Clientset
+	Config                       rest.Config
+	NotificationEvents           *chan event.Event
+	Events                       k8sevent.Recorder
+	KubernetesClusterDomain      string
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *JenkinsRestoreReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	probes.Reconciles.Register("jenkinsrestore")
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha2.JenkinsRestore{}).
+		Owns(&v1alpha2.Jenkins{}).
+		Complete(r)
+}
+
+func (r *JenkinsRestoreReconciler) newJenkinsConfiguration(jenkins *v1alpha2.Jenkins) configuration.Configuration {
+	return configuration.Configuration{
+		Client:                       r.Client,
+		ClientSet:                    r.ClientSet,
+		Notifications:                r.NotificationEvents,
+		Events:                       r.Events,
+		Jenkins:                      jenkins,
+		Scheme:                       r.Scheme,
+		Config:                       &r.Config,
+		JenkinsAPIConnectionSettings: r.JenkinsAPIConnectionSettings,
+		KubernetesClusterDomain:      r.KubernetesClusterDomain,
+	}
+}
+
+// +kubebuilder:rbac:groups=jenkins.io,resources=jenkinsrestores,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=jenkins.io,resources=jenkinsrestores/status,verbs=get;update;patch
+
+// Reconcile performs the restore operation defined by JenkinsRestore CR.
+func (r *JenkinsRestoreReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	logger := log.Log.WithValues(log.NamespaceKey, request.Namespace, restoreLogKey, request.Name)
+	logger.V(log.VDebug).Info("Reconciling JenkinsRestore")
+
+	done := probes.Reconciles.Track("jenkinsrestore")
+	ctx, span := tracing.Start(ctx, "ReconcileRestore", attribute.String("jenkinsrestore.name", request.Name), tracing.JenkinsNamespaceKey.String(request.Namespace))
+	result, err := r.reconcile(ctx, request, logger)
+	tracing.End(span, err)
+	done(err)
+	if err != nil && apierrors.IsConflict(err) {
+		return reconcile.Result{Requeue: true}, nil
+	}
+	return result, err
+}
+
+func (r *JenkinsRestoreReconciler) reconcile(ctx context.Context, request ctrl.Request, logger logr.Logger) (reconcile.Result, error) {
+	restore := &v1alpha2.JenkinsRestore{}
+	err := r.Client.Get(context.TODO(), request.NamespacedName, restore)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, errors.WithStack(err)
+	}
+	logger = log.ForObject(restore, restoreLogKey)
+
+	switch restore.Spec.Mode {
+	case v1alpha2.VerifyRestoreMode:
+		return r.reconcileVerification(ctx, restore, logger)
+	case v1alpha2.RestoreRestoreMode:
+		return r.reconcileRestore(restore, logger)
+	default:
+		logger.V(log.VWarn).Info(fmt.Sprintf("Unsupported spec.mode '%s'", restore.Spec.Mode))
+		return reconcile.Result{}, nil
+	}
+}
+
+func (r *JenkinsRestoreReconciler) reconcileVerification(ctx context.Context, restore *v1alpha2.JenkinsRestore, logger logr.Logger) (reconcile.Result, error) {
+	if restore.Status.Phase == v1alpha2.RestorePhaseRunning {
+		return r.checkVerification(ctx, restore, logger)
+	}
+
+	due, after := backuprestore.IsVerificationDue(restore, time.Now())
+	if !due {
+		return reconcile.Result{RequeueAfter: after}, nil
+	}
+	return r.startVerification(restore, logger)
+}
+
+func (r *JenkinsRestoreReconciler) startVerification(restore *v1alpha2.JenkinsRestore, logger logr.Logger) (reconcile.Result, error) {
+	source := &v1alpha2.Jenkins{}
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Namespace: restore.Namespace, Name: restore.Spec.JenkinsRef.Name}, source)
+	if err != nil && apierrors.IsNotFound(err) {
+		return r.finishVerification(restore, nil, v1alpha2.RestoreVerificationResult{
+			Message: fmt.Sprintf("Jenkins CR '%s' not found", restore.Spec.JenkinsRef.Name),
+		}, logger)
+	} else if err != nil {
+		return reconcile.Result{}, errors.WithStack(err)
+	}
+
+	if !backup.IsRestoreConfigured(source) {
+		return r.finishVerification(restore, source, v1alpha2.RestoreVerificationResult{
+			Message: fmt.Sprintf("Backup restore is not configured in Jenkins CR '%s'", source.Name),
+		}, logger)
+	}
+
+	if source.Status.LastBackup == 0 {
+		message := fmt.Sprintf("Jenkins '%s' has no backup yet", source.Name)
+		if restore.Status.Phase != v1alpha2.RestorePhasePending {
+			logger.Info(message + ", waiting for the first backup")
+			restore.Status.Phase = v1alpha2.RestorePhasePending
+			meta.SetStatusCondition(&restore.Status.Conditions, metav1.Condition{
+				Type:               v1alpha2.BackupVerifiedConditionType,
+				Status:             metav1.ConditionUnknown,
+				ObservedGeneration: restore.Generation,
+				Reason:             backupNotFoundReason,
+				Message:            message,
+			})
+			if err = r.Client.Status().Update(context.TODO(), restore); err != nil {
+				return reconcile.Result{}, errors.WithStack(err)
+			}
+		}
+		return reconcile.Result{RequeueAfter: restoreCheckInterval}, nil
+	}
+
+	jenkins := backuprestore.NewVerificationJenkins(restore, source, source.Status.LastBackup)
+	if err = controllerutil.SetControllerReference(restore, jenkins, r.Scheme); err != nil {
+		return reconcile.Result{}, errors.WithStack(err)
+	}
+	if err = r.Client.Create(context.TODO(), jenkins); err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			// leftover of interrupted verification, start from scratch
+			return reconcile.Result{RequeueAfter: restoreCheckInterval}, r.deleteVerificationJenkins(restore)
+		}
+		return reconcile.Result{}, errors.WithStack(err)
+	}
+
+	logger.Info(fmt.Sprintf("Verifying backup '%d' of Jenkins '%s' in Jenkins '%s'", source.Status.LastBackup, source.Name, jenkins.Name))
+	now := metav1.Now()
+	restore.Status.Phase = v1alpha2.RestorePhaseRunning
+	restore.Status.StartTime = &now
+	if err = r.Client.Status().Update(context.TODO(), restore); err != nil {
+		return reconcile.Result{}, errors.WithStack(err)
+	}
+
+	return reconcile.Result{RequeueAfter: restoreCheckInterval}, nil
+}
+
+func (r *JenkinsRestoreReconciler) checkVerification(ctx context.Context, restore *v1alpha2.JenkinsRestore, logger logr.Logger) (reconcile.Result, error) {
+	source := &v1alpha2.Jenkins{}
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Namespace: restore.Namespace, Name: restore.Spec.JenkinsRef.Name}, source)
+	if err != nil && apierrors.IsNotFound(err) {
+		source = nil
+	} else if err != nil {
+		return reconcile.Result{}, errors.WithStack(err)
+	}
+
+	jenkins := &v1alpha2.Jenkins{}
+	err = r.Client.Get(context.TODO(), types.NamespacedName{Namespace: restore.Namespace, Name: backuprestore.GetVerificationJenkinsName(restore)}, jenkins)
+	if err != nil && apierrors.IsNotFound(err) {
+		return r.finishVerification(restore, source, v1alpha2.RestoreVerificationResult{
+			Message: "Temporary Jenkins CR has been deleted before the verification completed",
+		}, logger)
+	} else if err != nil {
+		return reconcile.Result{}, errors.WithStack(err)
+	}
+
+	ready := meta.IsStatusConditionTrue(jenkins.Status.Conditions, v1alpha2.JenkinsReadyConditionType)
+	if !ready || jenkins.Status.RestoredBackup == 0 {
+		timeout := time.Duration(backuprestore.GetVerificationTimeout(restore)) * time.Second
+		if restore.Status.StartTime != nil && time.Since(restore.Status.StartTime.Time) > timeout {
+			message := fmt.Sprintf("Jenkins with restored backup is not ready after %s", timeout)
+			if condition := meta.FindStatusCondition(jenkins.Status.Conditions, v1alpha2.JenkinsReadyConditionType); condition != nil && len(condition.Message) > 0 {
+				message = fmt.Sprintf("%s: %s", message, condition.Message)
+			}
+			return r.finishVerification(restore, source, v1alpha2.RestoreVerificationResult{
+				BackupNumber: jenkins.Status.RestoredBackup,
+				Message:      message,
+			}, logger)
+		}
+		return reconcile.Result{RequeueAfter: restoreCheckInterval}, nil
+	}
+
+	config := r.newJenkinsConfiguration(jenkins)
+	jenkinsClient, err := config.GetJenkinsClient(ctx)
+	if err != nil {
+		logger.V(log.VWarn).Info(fmt.Sprintf("Failed to connect to Jenkins with restored backup: %s", err))
+		return reconcile.Result{RequeueAfter: restoreCheckInterval}, nil
+	}
+	jobs, err := jenkinsClient.GetAllJobNames()
+	if err != nil {
+		logger.V(log.VWarn).Info(fmt.Sprintf("Failed to get jobs from Jenkins with restored backup: %s", err))
+		return reconcile.Result{RequeueAfter: restoreCheckInterval}, nil
+	}
+
+	result := v1alpha2.RestoreVerificationResult{
+		BackupNumber: jenkins.Status.RestoredBackup,
+		Jobs:         int32(len(jobs)),
+	}
+	var minimumJobs int32
+	if restore.Spec.Verification != nil {
+		minimumJobs = restore.Spec.Verification.MinimumJobs
+	}
+	if result.Jobs < minimumJobs {
+		result.Message = fmt.Sprintf("Backup '%d' restored, found %d jobs, expected at least %d", result.BackupNumber, result.Jobs, minimumJobs)
+	} else {
+		result.Succeeded = true
+		result.Message = fmt.Sprintf("Backup '%d' restored, Jenkins is ready, found %d jobs", result.BackupNumber, result.Jobs)
+	}
+	return r.finishVerification(restore, source, result, logger)
+}
+
+// finishVerification deletes the temporary Jenkins, records the result in status and notifies about it
+func (r *JenkinsRestoreReconciler) finishVerification(restore *v1alpha2.JenkinsRestore, source *v1alpha2.Jenkins,
+	result v1alpha2.RestoreVerificationResult, logger logr.Logger) (reconcile.Result, error) {
+	if err := r.deleteVerificationJenkins(restore); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	now := metav1.Now()
+	result.StartTime = now
+	if restore.Status.StartTime != nil {
+		result.StartTime = *restore.Status.StartTime
+	}
+	result.CompletionTime = now
+
+	restore.Status.LastVerification = &result
+	restore.Status.Verifications = append(restore.Status.Verifications, result)
+	if len(restore.Status.Verifications) > maxVerificationHistory {
+		restore.Status.Verifications = restore.Status.Verifications[len(restore.Status.Verifications)-maxVerificationHistory:]
+	}
+	restore.Status.StartTime = nil
+
+	condition := metav1.Condition{
+		Type:               v1alpha2.BackupVerifiedConditionType,
+		ObservedGeneration: restore.Generation,
+		Message:            result.Message,
+	}
+	if result.Succeeded {
+		restore.Status.Phase = v1alpha2.RestorePhaseSucceeded
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = verificationSucceededReason
+	} else {
+		restore.Status.Phase = v1alpha2.RestorePhaseFailed
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = verificationFailedReason
+	}
+	meta.SetStatusCondition(&restore.Status.Conditions, condition)
+	if err := r.Client.Status().Update(context.TODO(), restore); err != nil {
+		return reconcile.Result{}, errors.WithStack(err)
+	}
+
+	if result.Succeeded {
+		logger.Info(result.Message)
+	} else {
+		logger.V(log.VWarn).Info("Backup verification failed: " + result.Message)
+	}
+	if source != nil {
+		if result.Succeeded {
+			*r.NotificationEvents <- event.Event{
+				Jenkins: *source,
+				Phase:   event.PhaseUser,
+				Level:   v1alpha2.NotificationLevelInfo,
+				Reason:  reason.NewBackupVerificationSucceeded(reason.OperatorSource, []string{result.Message}),
+			}
+		} else {
+			*r.NotificationEvents <- event.Event{
+				Jenkins: *source,
+				Phase:   event.PhaseUser,
+				Level:   v1alpha2.NotificationLevelWarning,
+				Reason:  reason.NewBackupVerificationFailed(reason.OperatorSource, []string{"Backup verification failed"}, result.Message),
+			}
+		}
+	}
+
+	_, after := backuprestore.IsVerificationDue(restore, now.Time)
+	return reconcile.Result{RequeueAfter: after}, nil
+}
+
+func (r *JenkinsRestoreReconciler) deleteVerificationJenkins(restore *v1alpha2.JenkinsRestore) error {
+	jenkins := &v1alpha2.Jenkins{}
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Namespace: restore.Namespace, Name: backuprestore.GetVerificationJenkinsName(restore)}, jenkins)
+	if err != nil && apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.WithStack(err)
+	}
+	if !metav1.IsControlledBy(jenkins, restore) {
+		return errors.Errorf("Jenkins CR '%s' is not managed by JenkinsRestore '%s'", jenkins.Name, restore.Name)
+	}
+
+	err = r.Client.Delete(context.TODO(), jenkins, client.PropagationPolicy(metav1.DeletePropagationBackground))
+	if err != nil && !apierrors.IsNotFound(err) {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+func (r *JenkinsRestoreReconciler) reconcileRestore(restore *v1alpha2.JenkinsRestore, logger logr.Logger) (reconcile.Result, error) {
+	switch restore.Status.Phase {
+	case v1alpha2.RestorePhaseSucceeded, v1alpha2.RestorePhaseFailed:
+		return reconcile.Result{}, nil // the backup is restored only once
+	case v1alpha2.RestorePhaseRunning:
+		return r.checkRestore(restore, logger)
+	default:
+		return r.startRestore(restore, logger)
+	}
+}
+
+func (r *JenkinsRestoreReconciler) startRestore(restore *v1alpha2.JenkinsRestore, logger logr.Logger) (reconcile.Result, error) {
+	source := &v1alpha2.Jenkins{}
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Namespace: restore.Namespace, Name: restore.Spec.JenkinsRef.Name}, source)
+	if err != nil && apierrors.IsNotFound(err) {
+		return r.finishRestore(restore, nil, false, fmt.Sprintf("Jenkins CR '%s' not found", restore.Spec.JenkinsRef.Name), logger)
+	} else if err != nil {
+		return reconcile.Result{}, errors.WithStack(err)
+	}
+
+	backupNumber := backuprestore.GetRestoreBackupNumber(restore, source)
+	if backupNumber == 0 {
+		return r.finishRestore(restore, source, false, fmt.Sprintf("Jenkins '%s' has no backup to restore", source.Name), logger)
+	}
+
+	targetName := backuprestore.GetRestoreTargetName(restore)
+	target := &v1alpha2.Jenkins{}
+	err = r.Client.Get(context.TODO(), types.NamespacedName{Namespace: restore.Namespace, Name: targetName}, target)
+	if err != nil && apierrors.IsNotFound(err) {
+		if restore.Spec.Restore == nil || !restore.Spec.Restore.Target.Create {
+			return r.finishRestore(restore, source, false, fmt.Sprintf("Jenkins CR '%s' not found", targetName), logger)
+		}
+		if !backup.IsRestoreConfigured(source) {
+			return r.finishRestore(restore, source, false, fmt.Sprintf("Backup restore is not configured in Jenkins CR '%s'", source.Name), logger)
+		}
+		target = backuprestore.NewRestoredJenkins(restore, source, backupNumber)
+		logger.Info(fmt.Sprintf("Creating Jenkins '%s' from backup '%d' of Jenkins '%s'", target.Name, backupNumber, source.Name))
+		if err = r.Client.Create(context.TODO(), target); err != nil {
+			return reconcile.Result{}, errors.WithStack(err)
+		}
+	} else if err != nil {
+		return reconcile.Result{}, errors.WithStack(err)
+	} else {
+		if !backup.IsRestoreConfigured(target) {
+			return r.finishRestore(restore, source, false, fmt.Sprintf("Backup restore is not configured in Jenkins CR '%s'", target.Name), logger)
+		}
+		logger.Info(fmt.Sprintf("Restoring backup '%d' of Jenkins '%s' in Jenkins '%s'", backupNumber, source.Name, target.Name))
+		target.Spec.Restore.RecoveryOnce = backupNumber
+		if err = r.Client.Update(context.TODO(), target); err != nil {
+			return reconcile.Result{}, errors.WithStack(err)
+		}
+	}
+
+	now := metav1.Now()
+	restore.Status.Phase = v1alpha2.RestorePhaseRunning
+	restore.Status.StartTime = &now
+	restore.Status.CompletionTime = nil
+	restore.Status.BackupNumber = backupNumber
+	restore.Status.TargetName = target.Name
+	restore.Status.TargetGeneration = target.Generation
+	restore.Status.Message = backuprestore.GetRestoreProgress(restore, target)
+	meta.SetStatusCondition(&restore.Status.Conditions, metav1.Condition{
+		Type:               v1alpha2.BackupRestoredConditionType,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: restore.Generation,
+		Reason:             restoreInProgressReason,
+		Message:            restore.Status.Message,
+	})
+	if err = r.Client.Status().Update(context.TODO(), restore); err != nil {
+		return reconcile.Result{}, errors.WithStack(err)
+	}
+
+	return reconcile.Result{RequeueAfter: restoreCheckInterval}, nil
+}
+
+func (r *JenkinsRestoreReconciler) checkRestore(restore *v1alpha2.JenkinsRestore, logger logr.Logger) (reconcile.Result, error) {
+	source := &v1alpha2.Jenkins{}
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Namespace: restore.Namespace, Name: restore.Spec.JenkinsRef.Name}, source)
+	if err != nil && apierrors.IsNotFound(err) {
+		source = nil
+	} else if err != nil {
+		return reconcile.Result{}, errors.WithStack(err)
+	}
+
+	target := &v1alpha2.Jenkins{}
+	err = r.Client.Get(context.TODO(), types.NamespacedName{Namespace: restore.Namespace, Name: restore.Status.TargetName}, target)
+	if err != nil && apierrors.IsNotFound(err) {
+		return r.finishRestore(restore, source, false, fmt.Sprintf("Jenkins CR '%s' has been deleted before the restore completed", restore.Status.TargetName), logger)
+	} else if err != nil {
+		return reconcile.Result{}, errors.WithStack(err)
+	}
+
+	message := backuprestore.GetRestoreProgress(restore, target)
+	if backuprestore.IsRestoreCompleted(restore, target) {
+		return r.finishRestore(restore, source, true, message, logger)
+	}
+
+	timeout := time.Duration(backuprestore.GetRestoreTimeout(restore)) * time.Second
+	if restore.Status.StartTime != nil && time.Since(restore.Status.StartTime.Time) > timeout {
+		return r.finishRestore(restore, source, false, fmt.Sprintf("Backup not restored after %s: %s", timeout, message), logger)
+	}
+
+	if message != restore.Status.Message {
+		logger.Info(message)
+		restore.Status.Message = message
+		if err = r.Client.Status().Update(context.TODO(), restore); err != nil {
+			return reconcile.Result{}, errors.WithStack(err)
+		}
+	}
+	return reconcile.Result{RequeueAfter: restoreCheckInterval}, nil
+}
+
+// finishRestore records the result of the restore in status and notifies about it
+func (r *JenkinsRestoreReconciler) finishRestore(restore *v1alpha2.JenkinsRestore, source *v1alpha2.Jenkins, succeeded bool,
+	message string, logger logr.Logger) (reconcile.Result, error) {
+	now := metav1.Now()
+	restore.Status.CompletionTime = &now
+	restore.Status.Message = message
+
+	condition := metav1.Condition{
+		Type:               v1alpha2.BackupRestoredConditionType,
+		ObservedGeneration: restore.Generation,
+		Message:            message,
+	}
+	if succeeded {
+		restore.Status.Phase = v1alpha2.RestorePhaseSucceeded
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = restoreSucceededReason
+	} else {
+		restore.Status.Phase = v1alpha2.RestorePhaseFailed
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = restoreFailedReason
+	}
+	meta.SetStatusCondition(&restore.Status.Conditions, condition)
+	if err := r.Client.Status().Update(context.TODO(), restore); err != nil {
+		return reconcile.Result{}, errors.WithStack(err)
+	}
+
+	if succeeded {
+		logger.Info(message)
+	} else {
+		logger.V(log.VWarn).Info("Restore failed: " + message)
+	}
+	if source != nil {
+		if succeeded {
+			*r.NotificationEvents <- event.Event{
+				Jenkins: *source,
+				Phase:   event.PhaseUser,
+				Level:   v1alpha2.NotificationLevelInfo,
+				Reason:  reason.NewBackupRestoreSucceeded(reason.OperatorSource, []string{message}),
+			}
+		} else {
+			*r.NotificationEvents <- event.Event{
+				Jenkins: *source,
+				Phase:   event.PhaseUser,
+				Level:   v1alpha2.NotificationLevelWarning,
+				Reason:  reason.NewBackupRestoreFailed(reason.OperatorSource, []string{"Restore failed"}, message),
+			}
+		}
+	}
+
+	return reconcile.Result{}, nil
+}
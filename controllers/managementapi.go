@@ -0,0 +1,67 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+	"github.com/jenkinsci/kubernetes-operator/pkg/management"
+
+	"github.com/pkg/errors"
+	"k8s.io/client-go/kubernetes"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// managementAPIReadHeaderTimeout limits the time the clients have to send the request headers
+const managementAPIReadHeaderTimeout = 10 * time.Second
+
+// ManagementAPI serves the REST API of managed Jenkins instances for developer portals, the callers are authorized
+// by RBAC rules of Jenkins CRs
+type ManagementAPI struct {
+	Client    client.Client
+	ClientSet kubernetes.Interface
+	// BindAddress is the address the management API binds to
+	BindAddress string
+	// TLSCertFile and TLSKeyFile are required, the API is served only over HTTPS
+	TLSCertFile string
+	TLSKeyFile  string
+}
+
+// SetupWithManager runs the management API with the Manager, it fails without the TLS certificate and key.
+func (m *ManagementAPI) SetupWithManager(mgr ctrl.Manager) error {
+	if len(m.TLSCertFile) == 0 || len(m.TLSKeyFile) == 0 {
+		return errors.New("management API requires --management-api-tls-cert-file and --management-api-tls-key-file")
+	}
+	return mgr.Add(m)
+}
+
+// NeedLeaderElection returns false, the management API is served by all operator replicas.
+func (m *ManagementAPI) NeedLeaderElection() bool {
+	return false
+}
+
+// Start serves the management API until the context is closed.
+func (m *ManagementAPI) Start(ctx context.Context) error {
+	auth := management.NewKubernetesAuth(m.ClientSet)
+	handler := management.New(m.Client, auth, auth)
+	mux := http.NewServeMux()
+	mux.Handle(management.BasePath, handler)
+	mux.Handle(management.BasePath+"/", handler)
+	server := &http.Server{Addr: m.BindAddress, Handler: mux, ReadHeaderTimeout: managementAPIReadHeaderTimeout}
+
+	go func() {
+		<-ctx.Done()
+		if err := server.Shutdown(context.Background()); err != nil {
+			log.Log.Error(err, "Failed to stop management API")
+		}
+	}()
+
+	log.Log.Info(fmt.Sprintf("Serving management API on %s with TLS", m.BindAddress))
+	if err := server.ListenAndServeTLS(m.TLSCertFile, m.TLSKeyFile); err != nil && err != http.ErrServerClosed {
+		return errors.WithStack(err)
+	}
+	return nil
+}
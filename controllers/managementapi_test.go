@@ -0,0 +1,15 @@
+package controllers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestManagementAPI_SetupWithManager(t *testing.T) {
+	t.Run("requires TLS certificate and key", func(t *testing.T) {
+		err := (&ManagementAPI{BindAddress: ":8083", TLSCertFile: "tls.crt"}).SetupWithManager(nil)
+
+		assert.EqualError(t, err, "management API requires --management-api-tls-cert-file and --management-api-tls-key-file")
+	})
+}
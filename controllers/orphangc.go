@@ -0,0 +1,157 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/user/seedjobs"
+	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
+	"github.com/jenkinsci/kubernetes-operator/pkg/gc"
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+	"github.com/jenkinsci/kubernetes-operator/pkg/metrics"
+	"github.com/jenkinsci/kubernetes-operator/pkg/probes"
+
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DefaultOrphanGCInterval is the default time between runs of the orphan garbage collector
+const DefaultOrphanGCInterval = time.Hour
+
+// orphanGCKinds are the kinds of objects created by the operator and the label selectors listing them
+var orphanGCKinds = []struct {
+	kind      string
+	newList   func() client.ObjectList
+	selectors []client.ListOption
+}{
+	{kind: "Secret", newList: func() client.ObjectList { return &corev1.SecretList{} },
+		selectors: []client.ListOption{client.MatchingLabels{constants.LabelAppKey: constants.LabelAppValue}}},
+	{kind: "ConfigMap", newList: func() client.ObjectList { return &corev1.ConfigMapList{} },
+		selectors: []client.ListOption{client.MatchingLabels{constants.LabelAppKey: constants.LabelAppValue}}},
+	{kind: "Service", newList: func() client.ObjectList { return &corev1.ServiceList{} },
+		selectors: []client.ListOption{client.MatchingLabels{constants.LabelAppKey: constants.LabelAppValue}}},
+	{kind: "Pod", newList: func() client.ObjectList { return &corev1.PodList{} },
+		selectors: []client.ListOption{client.MatchingLabels{constants.LabelAppKey: constants.LabelAppValue}, client.HasLabels{constants.LabelAgentOfKey}}},
+	{kind: "Deployment", newList: func() client.ObjectList { return &appsv1.DeploymentList{} },
+		selectors: []client.ListOption{client.HasLabels{seedjobs.DedicatedAgentLabelKey}}},
+}
+
+// OrphanGarbageCollector periodically removes Secrets, ConfigMaps, Services, agent pods and seed job agents created
+// by the operator for Jenkins CRs which don't exist anymore or for their older generations
+type OrphanGarbageCollector struct {
+	Client client.Client
+	// APIReader lists objects directly from the API server, so kinds which aren't watched aren't cached
+	APIReader client.Reader
+	// Namespace limits the collector to the namespace, all namespaces are collected if it's empty
+	Namespace string
+	Interval  time.Duration
+	Mode      gc.Mode
+	// MinAge is the age below which objects aren't collected, it defaults to gc.DefaultMinAge
+	MinAge time.Duration
+}
+
+// SetupWithManager adds the collector to the Manager, it runs only in the leader.
+func (r *OrphanGarbageCollector) SetupWithManager(mgr ctrl.Manager) error {
+	if r.Mode == gc.ModeDisabled || r.Interval <= 0 {
+		return nil
+	}
+	probes.Reconciles.Register("jenkins-orphan-gc")
+	if r.APIReader == nil {
+		r.APIReader = mgr.GetAPIReader()
+	}
+	if r.MinAge == 0 {
+		r.MinAge = gc.DefaultMinAge
+	}
+	return mgr.Add(r)
+}
+
+// Start runs the collector until the context is done.
+func (r *OrphanGarbageCollector) Start(ctx context.Context) error {
+	logger := log.Log.WithName("orphan-gc")
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		done := probes.Reconciles.Track("jenkins-orphan-gc")
+		orphans, err := r.Collect(ctx, time.Now())
+		done(err)
+		if err != nil {
+			logger.V(log.VWarn).Info(fmt.Sprintf("Orphan garbage collection failed: %s", err))
+			return
+		}
+		logger.V(log.VDebug).Info(fmt.Sprintf("Orphan garbage collection found %d object(s)", len(orphans)))
+	}, r.Interval)
+	return nil
+}
+
+// Collect finds orphaned objects and deletes them unless the collector runs in dry-run mode, it returns
+// the orphaned objects found
+func (r *OrphanGarbageCollector) Collect(ctx context.Context, now time.Time) ([]gc.Orphan, error) {
+	logger := log.Log.WithName("orphan-gc")
+	jenkinsList := &v1alpha2.JenkinsList{}
+	if err := r.APIReader.List(ctx, jenkinsList, client.InNamespace(r.Namespace)); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	jenkinses := map[types.NamespacedName]gc.Jenkins{}
+	for _, jenkins := range jenkinsList.Items {
+		jenkinses[types.NamespacedName{Namespace: jenkins.Namespace, Name: jenkins.Name}] = gc.Jenkins{UID: jenkins.UID, Generation: jenkins.Generation}
+	}
+
+	metrics.OrphanedObjects.Reset()
+	var found []gc.Orphan
+	for _, collected := range orphanGCKinds {
+		objects := map[types.NamespacedName]client.Object{}
+		var listed []metav1.Object
+		for _, selector := range collected.selectors {
+			list := collected.newList()
+			if err := r.APIReader.List(ctx, list, client.InNamespace(r.Namespace), selector); err != nil {
+				return found, errors.WithStack(err)
+			}
+			items, err := meta.ExtractList(list)
+			if err != nil {
+				return found, errors.WithStack(err)
+			}
+			for _, item := range items {
+				object := item.(client.Object)
+				key := types.NamespacedName{Namespace: object.GetNamespace(), Name: object.GetName()}
+				if _, duplicated := objects[key]; !duplicated {
+					objects[key] = object
+					listed = append(listed, object)
+				}
+			}
+		}
+
+		orphans := gc.FindOrphans(collected.kind, listed, jenkinses, r.MinAge, now)
+		found = append(found, orphans...)
+		deleted := map[string]int{}
+		orphansPerNamespace := map[string]int{}
+		for _, orphan := range orphans {
+			orphansPerNamespace[orphan.Namespace]++
+			if r.Mode == gc.ModeDryRun {
+				logger.Info(fmt.Sprintf("Dry-run, not deleting orphaned %s", orphan))
+				continue
+			}
+			object := objects[types.NamespacedName{Namespace: orphan.Namespace, Name: orphan.Name}]
+			err := r.Client.Delete(ctx, object, client.PropagationPolicy(metav1.DeletePropagationBackground))
+			if err != nil && !apierrors.IsNotFound(err) {
+				return found, errors.WithStack(err)
+			}
+			logger.Info(fmt.Sprintf("Deleted orphaned %s", orphan))
+			deleted[orphan.Namespace]++
+		}
+		for namespace, count := range orphansPerNamespace {
+			metrics.SetOrphanedObjects(namespace, collected.kind, count-deleted[namespace])
+		}
+		for namespace, count := range deleted {
+			metrics.AddOrphanedObjectsDeleted(namespace, collected.kind, count)
+		}
+	}
+	return found, nil
+}
@@ -0,0 +1,71 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
+	"github.com/jenkinsci/kubernetes-operator/pkg/gc"
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestOrphanGarbageCollector_Collect(t *testing.T) {
+	log.SetupLogger(true)
+	require.NoError(t, v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme))
+	newSecret := func(name, jenkins string) *corev1.Secret {
+		return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      name,
+			Labels:    map[string]string{constants.LabelAppKey: constants.LabelAppValue, constants.LabelJenkinsCRKey: jenkins},
+		}}
+	}
+	newClient := func() client.Client {
+		jenkins := &v1alpha2.Jenkins{ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"}}
+		return fake.NewClientBuilder().WithObjects(jenkins, newSecret("owned", "example"), newSecret("orphan", "deleted")).Build()
+	}
+	secretExists := func(t *testing.T, k8sClient client.Client, name string) bool {
+		err := k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: name}, &corev1.Secret{})
+		if apierrors.IsNotFound(err) {
+			return false
+		}
+		require.NoError(t, err)
+		return true
+	}
+
+	t.Run("deletes orphans", func(t *testing.T) {
+		k8sClient := newClient()
+		collector := OrphanGarbageCollector{Client: k8sClient, APIReader: k8sClient, Mode: gc.ModeDelete, MinAge: gc.DefaultMinAge}
+
+		orphans, err := collector.Collect(context.TODO(), time.Now().Add(time.Hour))
+
+		require.NoError(t, err)
+		require.Len(t, orphans, 1)
+		assert.Equal(t, "orphan", orphans[0].Name)
+		assert.False(t, secretExists(t, k8sClient, "orphan"))
+		assert.True(t, secretExists(t, k8sClient, "owned"))
+	})
+	t.Run("dry-run keeps orphans", func(t *testing.T) {
+		k8sClient := newClient()
+		collector := OrphanGarbageCollector{Client: k8sClient, APIReader: k8sClient, Mode: gc.ModeDryRun, MinAge: gc.DefaultMinAge}
+
+		orphans, err := collector.Collect(context.TODO(), time.Now().Add(time.Hour))
+
+		require.NoError(t, err)
+		require.Len(t, orphans, 1)
+		assert.Equal(t, "orphan", orphans[0].Name)
+		assert.True(t, secretExists(t, k8sClient, "orphan"))
+		assert.True(t, secretExists(t, k8sClient, "owned"))
+	})
+}
@@ -0,0 +1,113 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/user"
+	k8sevent "github.com/jenkinsci/kubernetes-operator/pkg/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// isPlanMode returns true if the operator has to compute the plan of actions instead of executing them
+func isPlanMode(jenkins *v1alpha2.Jenkins) bool {
+	return jenkins.Annotations[v1alpha2.PlanAnnotation] == "true"
+}
+
+// reconcilePlan computes actions which the reconcile loop would take and publishes them in status.plan without executing them
+func (r *JenkinsReconciler) reconcilePlan(ctx context.Context, config configuration.Configuration) (reconcile.Result, error) {
+	jenkins := config.Jenkins
+	plan, err := r.computePlan(ctx, config)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if jenkins.Status.Plan != nil && isPlanEqual(*jenkins.Status.Plan, *plan) {
+		return reconcile.Result{}, nil
+	}
+
+	now := metav1.Now()
+	plan.GeneratedTime = &now
+	jenkins.Status.Plan = plan
+	if err := r.Client.Status().Update(context.TODO(), jenkins); err != nil {
+		return reconcile.Result{}, errors.WithStack(err)
+	}
+
+	message := fmt.Sprintf("Plan has been published in status.plan: %s", planSummary(*plan))
+	log.ForJenkins(jenkins).Info(message)
+	config.Emit(k8sevent.TypeNormal, k8sevent.ReasonPlanGenerated, message)
+	return reconcile.Result{}, nil
+}
+
+func (r *JenkinsReconciler) computePlan(ctx context.Context, config configuration.Configuration) (*v1alpha2.JenkinsPlan, error) {
+	plan := &v1alpha2.JenkinsPlan{ObservedGeneration: config.Jenkins.Generation}
+
+	baseConfiguration := base.New(config, r.JenkinsAPIConnectionSettings)
+	messages, err := baseConfiguration.Validate(config.Jenkins)
+	if err != nil {
+		return nil, err
+	}
+	if len(messages) > 0 {
+		plan.Messages = append([]string{"Validation of base configuration failed, please correct Jenkins CR."}, messages...)
+		return plan, nil
+	}
+	if err := baseConfiguration.Plan(ctx, plan); err != nil {
+		return nil, err
+	}
+
+	// all scripts are applied again after Jenkins master pod restart
+	userConfig := config
+	if plan.PodRestartRequired {
+		userConfig.Jenkins = config.Jenkins.DeepCopy()
+		userConfig.Jenkins.Status.AppliedGroovyScripts = nil
+	}
+	userConfiguration := user.New(userConfig, nil)
+	messages, err = userConfiguration.Validate(userConfig.Jenkins)
+	if err != nil {
+		return nil, err
+	}
+	if len(messages) > 0 {
+		plan.Messages = append(plan.Messages, append([]string{"Validation of user configuration failed, please correct Jenkins CR"}, messages...)...)
+		return plan, nil
+	}
+	plan.Configurations, err = userConfiguration.Plan()
+	if err != nil {
+		return nil, err
+	}
+
+	return plan, nil
+}
+
+// clearPlan removes the plan from the status when the plan mode has been disabled
+func (r *JenkinsReconciler) clearPlan(jenkins *v1alpha2.Jenkins) error {
+	if jenkins.Status.Plan == nil {
+		return nil
+	}
+	jenkins.Status.Plan = nil
+	return errors.WithStack(r.Client.Status().Update(context.TODO(), jenkins))
+}
+
+// isPlanEqual compares plans ignoring the time when they have been computed
+func isPlanEqual(a, b v1alpha2.JenkinsPlan) bool {
+	a.GeneratedTime, b.GeneratedTime = nil, nil
+	return reflect.DeepEqual(a, b)
+}
+
+func planSummary(plan v1alpha2.JenkinsPlan) string {
+	restart := "no pod restart"
+	if plan.PodRestartRequired {
+		restart = "pod restart required"
+	} else if plan.JenkinsRestartRequired {
+		restart = "Jenkins safe restart required"
+	}
+	return fmt.Sprintf("%s, %d plugin change(s), %d configuration change(s), %d message(s)",
+		restart, len(plan.Plugins), len(plan.Configurations), len(plan.Messages))
+}
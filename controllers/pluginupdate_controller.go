@@ -0,0 +1,242 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	jenkinsclient "github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/pluginupdates"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/template"
+	k8sevent "github.com/jenkinsci/kubernetes-operator/pkg/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/plugins"
+	"github.com/jenkinsci/kubernetes-operator/pkg/probes"
+	"github.com/jenkinsci/kubernetes-operator/pkg/tracing"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// pendingUpgradeCheckInterval is the interval of checks of Jenkins readiness after the automatic plugin upgrade
+	pendingUpgradeCheckInterval = 30 * time.Second
+	// fetchInstalledPlugins is the depth of Jenkins API plugin list query
+	fetchInstalledPlugins = 1
+)
+
+// PluginUpdateReconciler checks plugin updates of Jenkins and upgrades plugins according to spec.pluginUpdates
+type PluginUpdateReconciler struct {
+	Client                       client.Client
+	Scheme                       *runtime.Scheme
+	JenkinsAPIConnectionSettings jenkinsclient.JenkinsAPIConnectionSettings
+	ClientSet                    kubernetes.Clientset
+	Config                       rest.Config
+	NotificationEvents           *chan event.Event
+	Events                       k8sevent.Recorder
+	KubernetesClusterDomain      string
+	// FetchUpdateCenter downloads the update center JSON, it defaults to plugins.FetchUpdateCenter
+	FetchUpdateCenter func(ctx context.Context, url string) (*plugins.UpdateCenter, error)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *PluginUpdateReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	probes.Reconciles.Register("jenkins-plugin-updates")
+	if r.FetchUpdateCenter == nil {
+		r.FetchUpdateCenter = plugins.FetchUpdateCenter
+	}
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("jenkins-plugin-updates").
+		For(&v1alpha2.Jenkins{}).
+		Complete(r)
+}
+
+func (r *PluginUpdateReconciler) newJenkinsConfiguration(jenkins *v1alpha2.Jenkins) configuration.Configuration {
+	return configuration.Configuration{
+		Client:                       r.Client,
+		ClientSet:                    r.ClientSet,
+		Notifications:                r.NotificationEvents,
+		Events:                       r.Events,
+		Jenkins:                      jenkins,
+		Scheme:                       r.Scheme,
+		Config:                       &r.Config,
+		JenkinsAPIConnectionSettings: r.JenkinsAPIConnectionSettings,
+		KubernetesClusterDomain:      r.KubernetesClusterDomain,
+	}
+}
+
+// Reconcile checks plugin updates of Jenkins defined by Jenkins CR.
+func (r *PluginUpdateReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	done := probes.Reconciles.Track("jenkins-plugin-updates")
+	ctx, span := tracing.Start(ctx, "ReconcilePluginUpdates", tracing.JenkinsAttributes(request.Namespace, request.Name)...)
+	result, err := r.reconcile(ctx, request)
+	tracing.End(span, err)
+	done(err)
+	if err != nil && apierrors.IsConflict(err) {
+		return reconcile.Result{Requeue: true}, nil
+	}
+	return result, err
+}
+
+func (r *PluginUpdateReconciler) reconcile(ctx context.Context, request ctrl.Request) (reconcile.Result, error) {
+	jenkins := &v1alpha2.Jenkins{}
+	err := r.Client.Get(context.TODO(), request.NamespacedName, jenkins)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, errors.WithStack(err)
+	}
+	if err = template.Apply(context.TODO(), r.Client, jenkins); err != nil {
+		return reconcile.Result{}, err
+	}
+	logger := log.ForJenkins(jenkins)
+
+	settings := jenkins.Spec.PluginUpdates
+	if settings == nil {
+		if jenkins.Status.PluginUpdates == nil {
+			return reconcile.Result{}, nil
+		}
+		jenkins.Status.PluginUpdates = nil
+		return reconcile.Result{}, errors.WithStack(r.Client.Status().Update(context.TODO(), jenkins))
+	}
+	if isPlanMode(jenkins) {
+		return reconcile.Result{}, nil
+	}
+
+	config := r.newJenkinsConfiguration(jenkins)
+	status := jenkins.Status.PluginUpdates
+	if status == nil {
+		status = &v1alpha2.PluginUpdatesStatus{}
+	}
+	if status.PendingUpgrade != nil {
+		return r.checkPendingUpgrade(config, *settings, status, logger)
+	}
+
+	interval := pluginupdates.GetCheckInterval(*settings)
+	if status.LastCheckTime != nil {
+		if next := status.LastCheckTime.Add(interval); time.Now().Before(next) {
+			return reconcile.Result{RequeueAfter: time.Until(next)}, nil
+		}
+	}
+	if !isJenkinsReady(jenkins) {
+		return reconcile.Result{RequeueAfter: notReadyRequeueInterval}, nil
+	}
+
+	jenkinsClient, err := config.GetJenkinsClient(ctx)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	installedPlugins, err := jenkinsClient.GetPlugins(fetchInstalledPlugins)
+	if err != nil {
+		return reconcile.Result{}, errors.WithStack(err)
+	}
+	updateCenter, err := r.FetchUpdateCenter(ctx, pluginupdates.GetUpdateCenterURL(*settings))
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	now := metav1.Now()
+	status.LastCheckTime = &now
+	status.AvailableUpgrades = pluginupdates.AvailableUpgrades(installedPlugins, updateCenter)
+	logger.V(log.VDebug).Info(fmt.Sprintf("%d plugin upgrade(s) available", len(status.AvailableUpgrades)))
+
+	upgrades := pluginupdates.SelectUpgrades(settings.AutoUpgradePolicy, status.AvailableUpgrades, status.LastUpgrade)
+	if template.IsTemplated(jenkins) && len(upgrades) > 0 {
+		// the plugins of Jenkins CR created from JenkinsTemplate are upgraded by updating the template
+		logger.Info(fmt.Sprintf("Skipping automatic upgrade of %d plugin(s), update JenkinsTemplate '%s' instead", len(upgrades), jenkins.Spec.From.Name))
+		upgrades = nil
+	}
+	if applied := pluginupdates.Apply(jenkins, upgrades); len(applied) > 0 {
+		if err = r.Client.Update(context.TODO(), jenkins); err != nil {
+			return reconcile.Result{}, errors.WithStack(err)
+		}
+		status.PendingUpgrade = &v1alpha2.PluginAutoUpgrade{
+			StartTime:  &now,
+			Generation: jenkins.Generation,
+			Plugins:    applied,
+		}
+		message := fmt.Sprintf("Upgrading plugins %s", formatPluginUpgrades(applied))
+		logger.Info(message)
+		config.Emit(k8sevent.TypeNormal, k8sevent.ReasonPluginUpgradeStarted, message)
+	}
+
+	jenkins.Status.PluginUpdates = status
+	if err = r.Client.Status().Update(context.TODO(), jenkins); err != nil {
+		return reconcile.Result{}, errors.WithStack(err)
+	}
+	if status.PendingUpgrade != nil {
+		return reconcile.Result{RequeueAfter: pendingUpgradeCheckInterval}, nil
+	}
+	return reconcile.Result{RequeueAfter: interval}, nil
+}
+
+// checkPendingUpgrade finishes the automatic upgrade when Jenkins becomes ready and rolls back plugins when it doesn't become ready in time
+func (r *PluginUpdateReconciler) checkPendingUpgrade(config configuration.Configuration, settings v1alpha2.PluginUpdates, status *v1alpha2.PluginUpdatesStatus, logger logr.Logger) (reconcile.Result, error) {
+	jenkins := config.Jenkins
+	pending := status.PendingUpgrade
+
+	ready := meta.FindStatusCondition(jenkins.Status.Conditions, v1alpha2.JenkinsReadyConditionType)
+	if ready != nil && ready.Status == metav1.ConditionTrue && ready.ObservedGeneration >= pending.Generation {
+		pending.Message = "Jenkins is ready with the upgraded plugins"
+		status.PendingUpgrade, status.LastUpgrade = nil, pending
+		// the upgraded plugins are checked again with the next update check
+		status.AvailableUpgrades = nil
+		jenkins.Status.PluginUpdates = status
+		if err := r.Client.Status().Update(context.TODO(), jenkins); err != nil {
+			return reconcile.Result{}, errors.WithStack(err)
+		}
+
+		message := fmt.Sprintf("Plugins have been upgraded %s", formatPluginUpgrades(pending.Plugins))
+		logger.Info(message)
+		config.Emit(k8sevent.TypeNormal, k8sevent.ReasonPluginUpgradeCompleted, message)
+		return reconcile.Result{RequeueAfter: pluginupdates.GetCheckInterval(settings)}, nil
+	}
+
+	timeout := pluginupdates.GetRollbackTimeout(settings)
+	if deadline := pending.StartTime.Add(timeout); time.Now().Before(deadline) {
+		return reconcile.Result{RequeueAfter: pendingUpgradeCheckInterval}, nil
+	}
+
+	pluginupdates.Rollback(jenkins, pending.Plugins)
+	if err := r.Client.Update(context.TODO(), jenkins); err != nil {
+		return reconcile.Result{}, errors.WithStack(err)
+	}
+	pending.RolledBack = true
+	pending.Message = fmt.Sprintf("Jenkins didn't become ready in %s, plugins have been rolled back", timeout)
+	status.PendingUpgrade, status.LastUpgrade = nil, pending
+	jenkins.Status.PluginUpdates = status
+	if err := r.Client.Status().Update(context.TODO(), jenkins); err != nil {
+		return reconcile.Result{}, errors.WithStack(err)
+	}
+
+	message := fmt.Sprintf("Jenkins didn't become ready in %s after upgrade, rolling back plugins %s", timeout, formatPluginUpgrades(pending.Plugins))
+	logger.V(log.VWarn).Info(message)
+	config.Emit(k8sevent.TypeWarning, k8sevent.ReasonPluginUpgradeRolledBack, message)
+	return reconcile.Result{RequeueAfter: pluginupdates.GetCheckInterval(settings)}, nil
+}
+
+func isJenkinsReady(jenkins *v1alpha2.Jenkins) bool {
+	return meta.IsStatusConditionTrue(jenkins.Status.Conditions, v1alpha2.JenkinsReadyConditionType)
+}
+
+func formatPluginUpgrades(upgrades []v1alpha2.PluginUpgrade) string {
+	var formatted []string
+	for _, upgrade := range upgrades {
+		formatted = append(formatted, fmt.Sprintf("'%s:%s' -> '%s'", upgrade.Name, upgrade.CurrentVersion, upgrade.AvailableVersion))
+	}
+	return strings.Join(formatted, ", ")
+}
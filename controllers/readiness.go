@@ -0,0 +1,113 @@
+package controllers
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	jenkinsclient "github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/health"
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+	"github.com/jenkinsci/kubernetes-operator/pkg/metrics"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/reason"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// notReadyRequeueInterval is the interval of checks of other controllers when Jenkins is not ready
+const notReadyRequeueInterval = 10 * time.Second
+
+// ensureReadiness assesses Jenkins health and sets the Ready condition, Jenkins is checked again until it becomes ready
+func (r *JenkinsReconciler) ensureReadiness(config configuration.Configuration, jenkinsClient jenkinsclient.Jenkins) (reconcile.Result, error) {
+	assessment, err := health.New(config, jenkinsClient).Check()
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if err = r.setReadyCondition(config.Jenkins, assessment); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	// ready Jenkins is reconciled again after the drift check period to revert changes made outside of the operator
+	settings := r.requeueSettings(config.Jenkins)
+	if !assessment.Healthy {
+		return reconcile.Result{RequeueAfter: settings.Interval}, nil
+	}
+	return reconcile.Result{RequeueAfter: untilDeferredActions(config.Jenkins, settings.DriftCheckPeriod, time.Now())}, nil
+}
+
+// untilDeferredActions shortens the requeue period to the start of the next maintenance window when there are deferred actions
+func untilDeferredActions(jenkins *v1alpha2.Jenkins, period time.Duration, now time.Time) time.Duration {
+	for _, deferred := range jenkins.Status.DeferredActions {
+		if deferred.NextWindow == nil {
+			continue
+		}
+		// the window is already active when the start is reached
+		until := deferred.NextWindow.Sub(now) + time.Second
+		if until < time.Second {
+			until = time.Second
+		}
+		if period == 0 || until < period {
+			period = until
+		}
+	}
+	return period
+}
+
+// setReadyCondition updates the Ready condition if it has changed and notifies about readiness transitions
+func (r *JenkinsReconciler) setReadyCondition(jenkins *v1alpha2.Jenkins, assessment health.Assessment) error {
+	metrics.SetReady(jenkins, assessment.Healthy)
+	status := metav1.ConditionFalse
+	if assessment.Healthy {
+		status = metav1.ConditionTrue
+	}
+	message := strings.Join(assessment.Messages, "; ")
+
+	current := meta.FindStatusCondition(jenkins.Status.Conditions, v1alpha2.JenkinsReadyConditionType)
+	if current != nil && current.Status == status && current.Reason == assessment.Reason &&
+		current.Message == message && current.ObservedGeneration == jenkins.Generation {
+		return nil
+	}
+
+	meta.SetStatusCondition(&jenkins.Status.Conditions, metav1.Condition{
+		Type:               v1alpha2.JenkinsReadyConditionType,
+		Status:             status,
+		ObservedGeneration: jenkins.Generation,
+		Reason:             assessment.Reason,
+		Message:            message,
+	})
+	if err := r.Client.Status().Update(context.TODO(), jenkins); err != nil {
+		return errors.WithStack(err)
+	}
+
+	if current != nil && current.Status == status {
+		return nil
+	}
+
+	logger := log.ForJenkins(jenkins)
+	if assessment.Healthy {
+		logger.Info(message)
+		*r.NotificationEvents <- event.Event{
+			Jenkins: *jenkins,
+			Phase:   event.PhaseUser,
+			Level:   v1alpha2.NotificationLevelInfo,
+			Reason:  reason.NewJenkinsReady(reason.OperatorSource, assessment.Messages),
+		}
+		return nil
+	}
+
+	logger.V(log.VWarn).Info("Jenkins is not ready: " + message)
+	*r.NotificationEvents <- event.Event{
+		Jenkins: *jenkins,
+		Phase:   event.PhaseUser,
+		Level:   v1alpha2.NotificationLevelWarning,
+		Reason:  reason.NewJenkinsNotReady(reason.OperatorSource, []string{"Jenkins is not ready"}, assessment.Messages...),
+	}
+	return nil
+}
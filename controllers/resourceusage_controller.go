@@ -0,0 +1,198 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/resourceusage"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/template"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/user/agents"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/user/seedjobs"
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+	"github.com/jenkinsci/kubernetes-operator/pkg/metrics"
+	"github.com/jenkinsci/kubernetes-operator/pkg/probes"
+	"github.com/jenkinsci/kubernetes-operator/pkg/tracing"
+
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// ResourceUsageReconciler reports resource requests and usage of Jenkins master and agent pods according to
+// spec.resourceUsage
+type ResourceUsageReconciler struct {
+	Client    client.Client
+	ClientSet kubernetes.Interface
+	// teams are the teams of the reported Jenkins CRs, the metrics of deleted Jenkins CR are removed by them
+	teams sync.Map
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ResourceUsageReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	probes.Reconciles.Register("jenkins-resource-usage")
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("jenkins-resource-usage").
+		For(&v1alpha2.Jenkins{}).
+		Complete(r)
+}
+
+// Reconcile collects the resource usage of Jenkins defined by Jenkins CR.
+func (r *ResourceUsageReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	done := probes.Reconciles.Track("jenkins-resource-usage")
+	ctx, span := tracing.Start(ctx, "ReconcileResourceUsage", tracing.JenkinsAttributes(request.Namespace, request.Name)...)
+	result, err := r.reconcile(ctx, request)
+	tracing.End(span, err)
+	done(err)
+	if err != nil && apierrors.IsConflict(err) {
+		return reconcile.Result{Requeue: true}, nil
+	}
+	return result, err
+}
+
+func (r *ResourceUsageReconciler) reconcile(ctx context.Context, request ctrl.Request) (reconcile.Result, error) {
+	jenkins := &v1alpha2.Jenkins{}
+	err := r.Client.Get(ctx, request.NamespacedName, jenkins)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			if team, found := r.teams.Load(request.NamespacedName); found {
+				metrics.DeleteResourceUsage(&v1alpha2.Jenkins{ObjectMeta: metav1.ObjectMeta{Namespace: request.Namespace, Name: request.Name}}, team.(string))
+				r.teams.Delete(request.NamespacedName)
+			}
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, errors.WithStack(err)
+	}
+	if err = template.Apply(ctx, r.Client, jenkins); err != nil {
+		return reconcile.Result{}, err
+	}
+	original := jenkins.DeepCopy()
+
+	settings := jenkins.Spec.ResourceUsage
+	if settings == nil || jenkins.DeletionTimestamp != nil {
+		if jenkins.Status.ResourceUsage == nil {
+			return reconcile.Result{}, nil
+		}
+		metrics.DeleteResourceUsage(jenkins, jenkins.Status.ResourceUsage.Team)
+		r.teams.Delete(request.NamespacedName)
+		jenkins.Status.ResourceUsage = nil
+		return reconcile.Result{}, errors.WithStack(r.Client.Status().Patch(ctx, jenkins, client.MergeFrom(original)))
+	}
+	if isPlanMode(jenkins) {
+		return reconcile.Result{}, nil
+	}
+
+	team := resourceusage.GetTeam(jenkins)
+	interval := resourceusage.GetCheckInterval(*settings)
+	if previous := jenkins.Status.ResourceUsage; previous != nil && previous.LastCheckTime != nil && previous.Team == team {
+		if next := previous.LastCheckTime.Add(interval); time.Now().Before(next) {
+			return reconcile.Result{RequeueAfter: time.Until(next)}, nil
+		}
+	}
+
+	status, err := r.collect(ctx, jenkins)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	status.Team = team
+	if previous := jenkins.Status.ResourceUsage; previous != nil && previous.Team != team {
+		metrics.DeleteResourceUsage(jenkins, previous.Team)
+	}
+	metrics.SetResourceUsage(jenkins, *status)
+	r.teams.Store(request.NamespacedName, team)
+
+	// the patch changes only status.resourceUsage, so it doesn't conflict with the updates of Jenkins reconciler
+	jenkins.Status.ResourceUsage = status
+	if err = r.Client.Status().Patch(ctx, jenkins, client.MergeFrom(original)); err != nil {
+		return reconcile.Result{}, errors.WithStack(err)
+	}
+	return reconcile.Result{RequeueAfter: interval}, nil
+}
+
+// collect aggregates requests of Jenkins master and agent pods and their usage reported by metrics-server, only
+// the requests are reported when metrics-server isn't available
+func (r *ResourceUsageReconciler) collect(ctx context.Context, jenkins *v1alpha2.Jenkins) (*v1alpha2.ResourceUsageStatus, error) {
+	masterPods, agentPods, err := r.listPods(ctx, jenkins)
+	if err != nil {
+		return nil, err
+	}
+
+	now := metav1.Now()
+	status := &v1alpha2.ResourceUsageStatus{LastCheckTime: &now}
+	data, err := r.ClientSet.CoreV1().RESTClient().Get().AbsPath(resourceusage.PodMetricsPath(jenkins.Namespace)).DoRaw(ctx)
+	var usage map[string]corev1.ResourceList
+	if err == nil {
+		usage, err = resourceusage.ParsePodMetrics(data)
+	}
+	if err != nil {
+		status.Message = fmt.Sprintf("Actual usage isn't reported, metrics-server isn't available: %s", err)
+		log.ForJenkins(jenkins).V(log.VDebug).Info(status.Message)
+	}
+
+	status.Master = resourceusage.Aggregate(masterPods, usage)
+	status.Agents = resourceusage.Aggregate(agentPods, usage)
+	return status, nil
+}
+
+// listPods returns Jenkins master pod and the agent pods, which are build agents and seed job agents
+func (r *ResourceUsageReconciler) listPods(ctx context.Context, jenkins *v1alpha2.Jenkins) (masterPods, agentPods []corev1.Pod, err error) {
+	master := &corev1.Pod{}
+	err = r.Client.Get(ctx, types.NamespacedName{Namespace: jenkins.Namespace, Name: resources.GetJenkinsMasterPodName(jenkins)}, master)
+	if err == nil {
+		masterPods = append(masterPods, *master)
+	} else if !apierrors.IsNotFound(err) {
+		return nil, nil, errors.WithStack(err)
+	}
+	buildPods := &corev1.PodList{}
+	if err = r.Client.List(ctx, buildPods, client.InNamespace(jenkins.Namespace), client.MatchingLabels(agents.BuildPodLabels(jenkins))); err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+	agentPods = buildPods.Items
+
+	seedJobAgentPods, err := r.listSeedJobAgentPods(ctx, jenkins)
+	if err != nil {
+		return nil, nil, err
+	}
+	return masterPods, append(agentPods, seedJobAgentPods...), nil
+}
+
+// listSeedJobAgentPods returns the pods of seed job agent Deployments controlled by Jenkins CR
+func (r *ResourceUsageReconciler) listSeedJobAgentPods(ctx context.Context, jenkins *v1alpha2.Jenkins) ([]corev1.Pod, error) {
+	if len(jenkins.Spec.SeedJobs) == 0 {
+		return nil, nil
+	}
+	deployments := &appsv1.DeploymentList{}
+	if err := r.Client.List(ctx, deployments, client.InNamespace(jenkins.Namespace)); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var pods []corev1.Pod
+	for _, deployment := range deployments.Items {
+		deployment := deployment
+		if !metav1.IsControlledBy(&deployment, jenkins) || !seedjobs.IsAgentDeployment(*jenkins, deployment) || deployment.Spec.Selector == nil {
+			continue
+		}
+		deploymentPods := &corev1.PodList{}
+		if err := r.Client.List(ctx, deploymentPods, client.InNamespace(jenkins.Namespace), client.MatchingLabels(deployment.Spec.Selector.MatchLabels)); err != nil {
+			return nil, errors.WithStack(err)
+		}
+		// the selector of seed job agents is the same for all Jenkins CRs, the pods are matched by their ReplicaSet
+		for _, pod := range deploymentPods.Items {
+			if owner := metav1.GetControllerOf(&pod); owner != nil && strings.HasPrefix(owner.Name, deployment.Name+"-") {
+				pods = append(pods, pod)
+			}
+		}
+	}
+	return pods, nil
+}
@@ -0,0 +1,75 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/user/seedjobs"
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+	"github.com/jenkinsci/kubernetes-operator/pkg/metrics"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestResourceUsageReconciler(t *testing.T) {
+	log.SetupLogger(true)
+	require.NoError(t, v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme))
+
+	t.Run("deletes metrics of deleted Jenkins CR", func(t *testing.T) {
+		jenkins := &v1alpha2.Jenkins{ObjectMeta: metav1.ObjectMeta{Name: "deleted", Namespace: "default"}}
+		metrics.SetResourceUsage(jenkins, v1alpha2.ResourceUsageStatus{Team: "team-a"})
+		reconciler := &ResourceUsageReconciler{Client: fake.NewClientBuilder().Build()}
+		reconciler.teams.Store(types.NamespacedName{Namespace: "default", Name: "deleted"}, "team-a")
+
+		_, err := reconciler.reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "deleted"}})
+
+		require.NoError(t, err)
+		assert.False(t, metrics.CPURequestsCores.DeleteLabelValues("default", "deleted", "team-a", "master"))
+		assert.False(t, metrics.MemoryRequestsBytes.DeleteLabelValues("default", "deleted", "team-a", "agents"))
+	})
+	t.Run("counts seed job agent pods", func(t *testing.T) {
+		jenkins := &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default", UID: "example-uid"},
+			Spec:       v1alpha2.JenkinsSpec{SeedJobs: []v1alpha2.SeedJob{{ID: "jobs"}}},
+		}
+		newDeployment := func(name string, owner types.UID) *appsv1.Deployment {
+			return &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", OwnerReferences: []metav1.OwnerReference{
+					{Name: "example", UID: owner, Controller: &[]bool{true}[0]},
+				}},
+				Spec: appsv1.DeploymentSpec{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "seed-job-agent-selector"}}},
+			}
+		}
+		newPod := func(name, replicaSet string) *corev1.Pod {
+			return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				Namespace:       "default",
+				Labels:          map[string]string{"app": "seed-job-agent-selector"},
+				OwnerReferences: []metav1.OwnerReference{{Kind: "ReplicaSet", Name: replicaSet, Controller: &[]bool{true}[0]}},
+			}}
+		}
+		reconciler := &ResourceUsageReconciler{Client: fake.NewClientBuilder().WithObjects(
+			jenkins,
+			newDeployment(seedjobs.AgentName+"-example", "example-uid"),
+			newDeployment(seedjobs.AgentName+"-other", "other-uid"),
+			newPod("agent", seedjobs.AgentName+"-example-5d4f"),
+			newPod("other-agent", seedjobs.AgentName+"-other-5d4f"),
+		).Build()}
+
+		masterPods, agentPods, err := reconciler.listPods(context.TODO(), jenkins)
+
+		require.NoError(t, err)
+		assert.Empty(t, masterPods)
+		require.Len(t, agentPods, 1)
+		assert.Equal(t, "agent", agentPods[0].Name)
+	})
+}
@@ -0,0 +1,161 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	jenkinsclient "github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/user/sharedlibraries"
+	k8sevent "github.com/jenkinsci/kubernetes-operator/pkg/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+	"github.com/jenkinsci/kubernetes-operator/pkg/tracing"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// SharedLibraryWebhookPath is the path of Git push webhooks clearing the shared library cache,
+	// the full path is /shared-libraries/<namespace>/<jenkins>/<library>
+	SharedLibraryWebhookPath = "/shared-libraries/"
+	// maxWebhookPayloadSize is the maximum size of the webhook payload, it's the limit of GitHub webhooks
+	maxWebhookPayloadSize = 25 << 20
+)
+
+// SharedLibraryWebhook clears the cache of shared libraries from spec.sharedLibraries on Git push webhooks
+type SharedLibraryWebhook struct {
+	Client                       client.Client
+	Scheme                       *runtime.Scheme
+	JenkinsAPIConnectionSettings jenkinsclient.JenkinsAPIConnectionSettings
+	ClientSet                    kubernetes.Clientset
+	Config                       rest.Config
+	Events                       k8sevent.Recorder
+	KubernetesClusterDomain      string
+	// BindAddress is the address the webhook endpoint binds to
+	BindAddress string
+}
+
+// SetupWithManager runs the webhook endpoint with the Manager.
+func (w *SharedLibraryWebhook) SetupWithManager(mgr ctrl.Manager) error {
+	return mgr.Add(w)
+}
+
+// NeedLeaderElection returns false, the webhooks are served by all operator replicas.
+func (w *SharedLibraryWebhook) NeedLeaderElection() bool {
+	return false
+}
+
+// Start serves the webhook endpoint until the context is closed.
+func (w *SharedLibraryWebhook) Start(ctx context.Context) error {
+	mux := http.NewServeMux()
+	mux.Handle(SharedLibraryWebhookPath, w)
+	server := &http.Server{Addr: w.BindAddress, Handler: mux}
+
+	go func() {
+		<-ctx.Done()
+		if err := server.Shutdown(context.Background()); err != nil {
+			log.Log.Error(err, "Failed to stop shared library webhook endpoint")
+		}
+	}()
+
+	log.Log.Info(fmt.Sprintf("Serving shared library webhooks on %s", w.BindAddress))
+	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// ServeHTTP clears the shared library cache when the webhook is authorized by the library webhook secret.
+func (w *SharedLibraryWebhook) ServeHTTP(response http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodPost {
+		http.Error(response, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	parts := strings.Split(strings.TrimPrefix(request.URL.Path, SharedLibraryWebhookPath), "/")
+	if len(parts) != 3 {
+		http.NotFound(response, request)
+		return
+	}
+	namespace, name, libraryName := parts[0], parts[1], parts[2]
+
+	ctx, span := tracing.Start(request.Context(), "SharedLibraryWebhook", tracing.JenkinsAttributes(namespace, name)...)
+	status, err := w.clearCache(ctx, request, namespace, name, libraryName)
+	tracing.End(span, err)
+	if err != nil {
+		log.Log.WithValues(log.NamespaceKey, namespace, log.JenkinsKey, name).
+			Error(err, fmt.Sprintf("Failed to clear '%s' shared library cache", libraryName))
+	}
+	http.Error(response, http.StatusText(status), status)
+}
+
+func (w *SharedLibraryWebhook) clearCache(ctx context.Context, request *http.Request, namespace, name, libraryName string) (int, error) {
+	jenkins := &v1alpha2.Jenkins{}
+	if err := w.Client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, jenkins); err != nil {
+		if apierrors.IsNotFound(err) {
+			return http.StatusNotFound, nil
+		}
+		return http.StatusInternalServerError, errors.WithStack(err)
+	}
+	// webhooks of Jenkins being torn down are deregistered
+	if jenkins.DeletionTimestamp != nil {
+		return http.StatusGone, nil
+	}
+
+	var library *v1alpha2.SharedLibrary
+	for i := range jenkins.Spec.SharedLibraries {
+		if jenkins.Spec.SharedLibraries[i].Name == libraryName {
+			library = &jenkins.Spec.SharedLibraries[i]
+		}
+	}
+	if library == nil || library.Cache == nil || library.Cache.WebhookSecretKeySelector == nil {
+		return http.StatusNotFound, nil
+	}
+
+	selector := library.Cache.WebhookSecretKeySelector
+	secret := &corev1.Secret{}
+	if err := w.Client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: selector.Name}, secret); err != nil {
+		return http.StatusInternalServerError, errors.WithStack(err)
+	}
+	payload, err := ioutil.ReadAll(http.MaxBytesReader(nil, request.Body, maxWebhookPayloadSize))
+	if err != nil {
+		return http.StatusBadRequest, nil
+	}
+	if !sharedlibraries.VerifyWebhook(request.Header, payload, string(secret.Data[selector.Key])) {
+		return http.StatusUnauthorized, nil
+	}
+
+	config := configuration.Configuration{
+		Client:                       w.Client,
+		ClientSet:                    w.ClientSet,
+		Events:                       w.Events,
+		Jenkins:                      jenkins,
+		Scheme:                       w.Scheme,
+		Config:                       &w.Config,
+		JenkinsAPIConnectionSettings: w.JenkinsAPIConnectionSettings,
+		KubernetesClusterDomain:      w.KubernetesClusterDomain,
+	}
+	jenkinsClient, err := config.GetJenkinsClient(ctx)
+	if err != nil {
+		return http.StatusServiceUnavailable, err
+	}
+	if err := sharedlibraries.ClearCache(jenkinsClient, libraryName); err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	message := fmt.Sprintf("Shared library '%s' cache has been cleared by the webhook", libraryName)
+	log.ForJenkins(jenkins).Info(message)
+	config.Emit(k8sevent.TypeNormal, k8sevent.ReasonSharedLibraryCacheCleared, message)
+	return http.StatusOK, nil
+}
@@ -0,0 +1,152 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/template"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/updatecentermirror"
+	k8sevent "github.com/jenkinsci/kubernetes-operator/pkg/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+	"github.com/jenkinsci/kubernetes-operator/pkg/plugins"
+	"github.com/jenkinsci/kubernetes-operator/pkg/probes"
+	"github.com/jenkinsci/kubernetes-operator/pkg/tracing"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// UpdateCenterMirrorReconciler generates the update center JSON with approved plugins according to spec.updateCenterMirror
+type UpdateCenterMirrorReconciler struct {
+	Client client.Client
+	Scheme *runtime.Scheme
+	Events k8sevent.Recorder
+	// FetchUpdateCenter downloads the upstream update center JSON, it defaults to plugins.FetchUpdateCenterDocument
+	FetchUpdateCenter func(ctx context.Context, url string) (map[string]interface{}, error)
+	// ServerSideApply enables server-side apply of the mirror ConfigMap
+	ServerSideApply bool
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *UpdateCenterMirrorReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	probes.Reconciles.Register("jenkins-update-center-mirror")
+	if r.FetchUpdateCenter == nil {
+		r.FetchUpdateCenter = plugins.FetchUpdateCenterDocument
+	}
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("jenkins-update-center-mirror").
+		For(&v1alpha2.Jenkins{}).
+		Complete(r)
+}
+
+// Reconcile generates the update center mirror of Jenkins defined by Jenkins CR.
+func (r *UpdateCenterMirrorReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	done := probes.Reconciles.Track("jenkins-update-center-mirror")
+	ctx, span := tracing.Start(ctx, "ReconcileUpdateCenterMirror", tracing.JenkinsAttributes(request.Namespace, request.Name)...)
+	result, err := r.reconcile(ctx, request)
+	tracing.End(span, err)
+	done(err)
+	if err != nil && apierrors.IsConflict(err) {
+		return reconcile.Result{Requeue: true}, nil
+	}
+	return result, err
+}
+
+func (r *UpdateCenterMirrorReconciler) reconcile(ctx context.Context, request ctrl.Request) (reconcile.Result, error) {
+	jenkins := &v1alpha2.Jenkins{}
+	err := r.Client.Get(context.TODO(), request.NamespacedName, jenkins)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, errors.WithStack(err)
+	}
+	if err = template.Apply(context.TODO(), r.Client, jenkins); err != nil {
+		return reconcile.Result{}, err
+	}
+	logger := log.ForJenkins(jenkins)
+
+	settings := jenkins.Spec.UpdateCenterMirror
+	if settings == nil {
+		if jenkins.Status.UpdateCenterMirror == nil {
+			return reconcile.Result{}, nil
+		}
+		jenkins.Status.UpdateCenterMirror = nil
+		return reconcile.Result{}, errors.WithStack(r.Client.Status().Update(context.TODO(), jenkins))
+	}
+	if isPlanMode(jenkins) {
+		return reconcile.Result{}, nil
+	}
+
+	// the mirror is generated again when approved plugins could change
+	interval := updatecentermirror.GetRefreshInterval(*settings)
+	status := jenkins.Status.UpdateCenterMirror
+	if status != nil && status.LastGenerationTime != nil && status.ObservedGeneration == jenkins.Generation {
+		if next := status.LastGenerationTime.Add(interval); time.Now().Before(next) {
+			return reconcile.Result{RequeueAfter: time.Until(next)}, nil
+		}
+	}
+
+	// the signing key is created by the base configuration together with the Jenkins master pod
+	signingSecret := &corev1.Secret{}
+	err = r.Client.Get(context.TODO(), types.NamespacedName{Name: resources.GetUpdateCenterMirrorName(jenkins), Namespace: jenkins.Namespace}, signingSecret)
+	if apierrors.IsNotFound(err) {
+		return reconcile.Result{RequeueAfter: notReadyRequeueInterval}, nil
+	} else if err != nil {
+		return reconcile.Result{}, errors.WithStack(err)
+	}
+
+	config := configuration.Configuration{Client: r.Client, Events: r.Events, Jenkins: jenkins, Scheme: r.Scheme, ServerSideApply: r.ServerSideApply}
+	approvedPlugins, err := r.generate(ctx, config, *settings, signingSecret)
+	if err != nil {
+		message := fmt.Sprintf("Update center mirror couldn't be generated: %s", err)
+		logger.V(log.VWarn).Info(message)
+		config.Emit(k8sevent.TypeWarning, k8sevent.ReasonUpdateCenterMirrorFailed, message)
+		return reconcile.Result{}, err
+	}
+
+	now := metav1.Now()
+	jenkins.Status.UpdateCenterMirror = &v1alpha2.UpdateCenterMirrorStatus{
+		LastGenerationTime: &now,
+		ObservedGeneration: jenkins.Generation,
+		Plugins:            approvedPlugins,
+	}
+	if err = r.Client.Status().Update(context.TODO(), jenkins); err != nil {
+		return reconcile.Result{}, errors.WithStack(err)
+	}
+
+	message := fmt.Sprintf("Update center mirror has been generated with %d plugins", approvedPlugins)
+	logger.V(log.VDebug).Info(message)
+	config.Emit(k8sevent.TypeNormal, k8sevent.ReasonUpdateCenterMirrorGenerated, message)
+	return reconcile.Result{RequeueAfter: interval}, nil
+}
+
+// generate writes the signed update center JSON to the config map mounted in the Jenkins master pod and returns the number of plugins
+func (r *UpdateCenterMirrorReconciler) generate(ctx context.Context, config configuration.Configuration, settings v1alpha2.UpdateCenterMirror, signingSecret *corev1.Secret) (int, error) {
+	upstream, err := r.FetchUpdateCenter(ctx, updatecentermirror.GetUpstreamURL(settings))
+	if err != nil {
+		return 0, err
+	}
+
+	updateCenter, approvedPlugins, err := updatecentermirror.Generate(config.Jenkins, upstream, signingSecret.Data[corev1.TLSPrivateKeyKey], signingSecret.Data[corev1.TLSCertKey])
+	if err != nil {
+		return 0, err
+	}
+
+	configMap := resources.NewUpdateCenterMirrorConfigMap(resources.NewResourceObjectMeta(config.Jenkins), config.Jenkins, updateCenter)
+	if err = config.CreateOrUpdateResource(configMap); err != nil {
+		return 0, errors.WithStack(err)
+	}
+	return approvedPlugins, nil
+}
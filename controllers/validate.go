@@ -0,0 +1,41 @@
+package controllers
+
+import (
+	"context"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/template"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/user"
+	"github.com/jenkinsci/kubernetes-operator/pkg/failure"
+)
+
+// Validate applies Jenkins templates and defaults to Jenkins CR and validates its base and user configuration the same
+// way as the reconcile loop does before it creates any object, nothing is done in Jenkins. It's used to validate
+// Jenkins CRs offline, the client may serve only the objects read from manifests then.
+func (r *JenkinsReconciler) Validate(ctx context.Context, jenkins *v1alpha2.Jenkins) (baseMessages, userMessages []string, err error) {
+	if err = template.Apply(ctx, r.Client, jenkins); err != nil {
+		return nil, nil, err
+	}
+	basePluginManifests, err := r.getBasePluginManifests(jenkins.Namespace)
+	if err == nil {
+		_, err = r.setDefaults(jenkins, basePluginManifests)
+	}
+	if failure.CategoryOf(err) == failure.CategoryConfigurationInvalid {
+		return []string{err.Error()}, nil, nil
+	} else if err != nil {
+		return nil, nil, err
+	}
+
+	config := r.newJenkinsReconcilier(jenkins)
+	config.BasePluginManifests = basePluginManifests
+	baseMessages, err = base.New(config, r.JenkinsAPIConnectionSettings).Validate(jenkins)
+	if err != nil {
+		return nil, nil, err
+	}
+	userMessages, err = user.New(config, nil).Validate(jenkins)
+	if err != nil {
+		return nil, nil, err
+	}
+	return baseMessages, userMessages, nil
+}
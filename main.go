@@ -17,30 +17,43 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"os"
 	r "runtime"
+	"strings"
 
 	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
 	"github.com/jenkinsci/kubernetes-operator/controllers"
+	"github.com/jenkinsci/kubernetes-operator/pkg/audit"
+	"github.com/jenkinsci/kubernetes-operator/pkg/cache"
 	"github.com/jenkinsci/kubernetes-operator/pkg/client"
 	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/requeue"
 	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
 	"github.com/jenkinsci/kubernetes-operator/pkg/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/gc"
 	"github.com/jenkinsci/kubernetes-operator/pkg/log"
 	"github.com/jenkinsci/kubernetes-operator/pkg/notifications"
 	e "github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/plugins"
+	"github.com/jenkinsci/kubernetes-operator/pkg/probes"
+	"github.com/jenkinsci/kubernetes-operator/pkg/tracing"
+	"github.com/jenkinsci/kubernetes-operator/pkg/validation"
 	"github.com/jenkinsci/kubernetes-operator/version"
 
 	routev1 "github.com/openshift/api/route/v1"
 	"github.com/pkg/errors"
+	uberzap "go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/kubernetes"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -75,6 +88,10 @@ func init() {
 }
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "validate" {
+		os.Exit(validate(os.Args[2:]))
+	}
+
 	var metricsAddr string
 	var enableLeaderElection bool
 	var probeAddr string
@@ -94,15 +111,41 @@ func main() {
 	port := flag.Int("jenkins-api-port", 0, "The port on which Jenkins API is running. Note: If you want to use nodePort don't set this setting and --jenkins-api-use-nodeport must be true.")
 	useNodePort := flag.Bool("jenkins-api-use-nodeport", false, "Connect to Jenkins API using the service nodePort instead of service port. If you want to set this as true - don't set --jenkins-api-port.")
 	kubernetesClusterDomain := flag.String("cluster-domain", "cluster.local", "Use custom domain name instead of 'cluster.local'.")
+	hungReconcileTimeout := flag.Duration("hung-reconcile-timeout", probes.DefaultHungReconcileTimeout, "The time after which the running reconcile loop is considered hung and the liveness probe fails.")
+	reconcileProfile := flag.String("reconcile-profile", string(requeue.ProfileBalanced), "The timing profile of Jenkins reconcile loops: aggressive, balanced or relaxed. It can be overridden by the jenkins.io/reconcile-profile annotation.")
+	requeueInterval := flag.Duration("requeue-interval", 0, "The time between checks while Jenkins isn't ready, it overrides the reconcile profile.")
+	maxErrorBackoff := flag.Duration("max-error-backoff", 0, "The cap of the backoff after failed reconcile loops, it overrides the reconcile profile.")
+	driftCheckPeriod := flag.Duration("drift-check-period", 0, "The time after which ready Jenkins is reconciled again, 0 disables drift checks, it overrides the reconcile profile.")
+	basePluginsConfigMap := flag.String("base-plugins-configmap", "", "The name of ConfigMap in the namespace of Jenkins CR with base plugin manifests per Jenkins version, they override the manifests embedded in the operator.")
+	serverSideApply := flag.Bool("server-side-apply", false, "Manage ConfigMaps, Services, StatefulSets, Pods and RBAC with server-side apply, so the fields set by others are kept.")
+	cacheFiltering := flag.Bool("cache-filtering", true, "Cache only Pods managed by the operator and agent pods and watch only managed Secrets and ConfigMaps, other Pods and all Secrets and ConfigMaps are read from the API server.")
+	orphanGCMode := flag.String("orphan-gc", string(gc.ModeDryRun), "The mode of the garbage collector of objects created for deleted Jenkins CRs or their older generations: disabled, dry-run (report only) or delete.")
+	allowedSCCs := flag.String("allowed-security-context-constraints", "", "The comma separated names of OpenShift SecurityContextConstraints which can be granted to Jenkins by spec.openShift.securityContextConstraints, the operator role must be allowed to use them.")
+	operationGroovy := flag.Bool("operation-groovy", false, "Allow Groovy action of JenkinsOperation CRs, it runs any script in Jenkins with administrator permissions.")
+	orphanGCInterval := flag.Duration("orphan-gc-interval", controllers.DefaultOrphanGCInterval, "The time between runs of the orphan garbage collector.")
+	sharedLibraryWebhookAddr := flag.String("shared-library-webhook-bind-address", "", "The address the shared library cache webhook endpoint binds to. The endpoint is disabled if empty.")
+	managementAPIAddr := flag.String("management-api-bind-address", "", "The address the management API for developer portals binds to. The API is disabled if empty.")
+	managementAPITLSCert := flag.String("management-api-tls-cert-file", "", "The TLS certificate of the management API, required when the API is enabled.")
+	managementAPITLSKey := flag.String("management-api-tls-key-file", "", "The TLS private key of the management API, required when the API is enabled.")
+	tracingOptions := tracing.Options{}
+	flag.StringVar(&tracingOptions.Endpoint, "tracing-otlp-endpoint", "", "The address (host:port) of OTLP gRPC collector where traces are exported. Tracing is disabled if empty.")
+	flag.BoolVar(&tracingOptions.Insecure, "tracing-otlp-insecure", false, "Disable TLS of the connection to the OTLP collector.")
+	flag.Float64Var(&tracingOptions.SampleRatio, "tracing-sample-ratio", 1, "The ratio of sampled reconcile traces, between 0 and 1.")
 	opts := zap.Options{
 		Development: true,
+		NewEncoder:  log.NewJSONEncoder,
 	}
 	opts.BindFlags(flag.CommandLine)
 	flag.Parse()
 
 	debug := &opts.Development
-	log.Debug = *debug
-	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
+	if opts.Level == nil {
+		// messages are filtered by the operator logger, so debug messages can be enabled for a single CR by annotation
+		level := uberzap.NewAtomicLevelAt(zapcore.DebugLevel)
+		opts.Level = &level
+	}
+	// the leveled logger wrapper adds a frame to every call
+	log.Setup(zap.New(zap.UseFlagOptions(&opts), zap.RawZapOpts(uberzap.AddCallerSkip(1))), *debug)
 	printInfo()
 
 	namespace, found := os.LookupEnv("WATCH_NAMESPACE")
@@ -120,13 +163,53 @@ func main() {
 		}
 	}
 
+	// setup reconcile timing, the flags set explicitly override the profile
+	requeueSettings, err := requeue.ForProfile(requeue.Profile(*reconcileProfile))
+	if err != nil {
+		fatal(errors.Wrap(err, "invalid command line parameters"), *debug)
+	}
+	orphanGC, err := gc.ParseMode(*orphanGCMode)
+	if err != nil {
+		fatal(errors.Wrap(err, "invalid command line parameters"), *debug)
+	}
+	flag.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "requeue-interval":
+			requeueSettings.Interval = *requeueInterval
+		case "max-error-backoff":
+			requeueSettings.MaxErrorBackoff = *maxErrorBackoff
+		case "drift-check-period":
+			requeueSettings.DriftCheckPeriod = *driftCheckPeriod
+		}
+	})
+	if err := requeueSettings.Validate(); err != nil {
+		fatal(errors.Wrap(err, "invalid command line parameters"), *debug)
+	}
+
+	// setup tracing
+	if err := tracingOptions.Validate(); err != nil {
+		fatal(errors.Wrap(err, "invalid command line parameters"), *debug)
+	}
+	shutdownTracing, err := tracing.Setup(context.Background(), tracingOptions)
+	if err != nil {
+		fatal(errors.Wrap(err, "failed to setup tracing"), *debug)
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			logger.Error(nil, fmt.Sprintf("failed to shutdown tracing: %s", err))
+		}
+	}()
+	if len(tracingOptions.Endpoint) > 0 {
+		logger.Info(fmt.Sprintf("Exporting traces to: %s", tracingOptions.Endpoint))
+	}
+
 	// get a config to talk to the API server
 	cfg, err := config.GetConfig()
 	if err != nil {
 		fatal(errors.Wrap(err, "failed to get config"), *debug)
 	}
 
-	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
+	managerOptions := ctrl.Options{
 		Scheme:                 scheme,
 		MetricsBindAddress:     fmt.Sprintf("%s:%d", metricsHost, metricsPort),
 		Port:                   9443,
@@ -134,16 +217,24 @@ func main() {
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       "c674355f.jenkins.io",
 		Namespace:              namespace,
-	})
+	}
+	if *cacheFiltering {
+		managerOptions.NewCache = cache.NewCacheFunc(cache.DefaultFilters()...)
+	}
+	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), managerOptions)
 	if err != nil {
 		fatal(errors.Wrap(err, "unable to start manager"), *debug)
 	}
 
 	// setup events
-	events, err := event.New(cfg, constants.OperatorName)
+	events, err := event.New(cfg, scheme, constants.OperatorName)
 	if err != nil {
 		fatal(errors.Wrap(err, "failed to setup events"), *debug)
 	}
+	// events of Jenkins CRs with spec.audit are recorded in the audit trail
+	auditEntries := make(chan audit.Entry, 100)
+	go audit.Listen(auditEntries, mgr.GetClient())
+	events = audit.NewRecorder(events, auditEntries)
 
 	// setup controller
 	clientSet, err := kubernetes.NewForConfig(cfg)
@@ -154,6 +245,10 @@ func main() {
 	if resources.IsRouteAPIAvailable(clientSet) {
 		logger.Info("Route API found: Route creation will be performed")
 	}
+	if resources.IsOpenShift(clientSet) {
+		logger.Info("SecurityContextConstraints API found: running in OpenShift")
+	}
+	resources.AllowSecurityContextConstraints(splitNames(*allowedSCCs))
 	notificationEvents := make(chan e.Event)
 	go notifications.Listen(notificationEvents, events, mgr.GetClient())
 
@@ -175,23 +270,186 @@ func main() {
 		ClientSet:                    *clientSet,
 		Config:                       *cfg,
 		NotificationEvents:           &notificationEvents,
+		Events:                       events,
 		KubernetesClusterDomain:      *kubernetesClusterDomain,
+		Requeue:                      requeueSettings,
+		BasePluginsConfigMap:         *basePluginsConfigMap,
+		ServerSideApply:              *serverSideApply,
 	}).SetupWithManager(mgr); err != nil {
 		fatal(errors.Wrap(err, "unable to create Jenkins controller"), *debug)
 	}
 
+	if err = (&controllers.JenkinsRestoreReconciler{
+		Client:                       mgr.GetClient(),
+		Scheme:                       mgr.GetScheme(),
+		JenkinsAPIConnectionSettings: jenkinsAPIConnectionSettings,
+		ClientSet:                    *clientSet,
+		Config:                       *cfg,
+		NotificationEvents:           &notificationEvents,
+		Events:                       events,
+		KubernetesClusterDomain:      *kubernetesClusterDomain,
+	}).SetupWithManager(mgr); err != nil {
+		fatal(errors.Wrap(err, "unable to create JenkinsRestore controller"), *debug)
+	}
+
+	if err = (&controllers.JenkinsOperationReconciler{
+		Client:                       mgr.GetClient(),
+		Scheme:                       mgr.GetScheme(),
+		JenkinsAPIConnectionSettings: jenkinsAPIConnectionSettings,
+		ClientSet:                    *clientSet,
+		Config:                       *cfg,
+		Events:                       events,
+		KubernetesClusterDomain:      *kubernetesClusterDomain,
+		AllowGroovy:                  *operationGroovy,
+	}).SetupWithManager(mgr); err != nil {
+		fatal(errors.Wrap(err, "unable to create JenkinsOperation controller"), *debug)
+	}
+
+	if err = (&controllers.PluginUpdateReconciler{
+		Client:                       mgr.GetClient(),
+		Scheme:                       mgr.GetScheme(),
+		JenkinsAPIConnectionSettings: jenkinsAPIConnectionSettings,
+		ClientSet:                    *clientSet,
+		Config:                       *cfg,
+		NotificationEvents:           &notificationEvents,
+		Events:                       events,
+		KubernetesClusterDomain:      *kubernetesClusterDomain,
+	}).SetupWithManager(mgr); err != nil {
+		fatal(errors.Wrap(err, "unable to create plugin updates controller"), *debug)
+	}
+
+	if err = (&controllers.DiskUsageReconciler{
+		Client:                       mgr.GetClient(),
+		Scheme:                       mgr.GetScheme(),
+		JenkinsAPIConnectionSettings: jenkinsAPIConnectionSettings,
+		ClientSet:                    *clientSet,
+		Config:                       *cfg,
+		NotificationEvents:           &notificationEvents,
+		Events:                       events,
+		KubernetesClusterDomain:      *kubernetesClusterDomain,
+	}).SetupWithManager(mgr); err != nil {
+		fatal(errors.Wrap(err, "unable to create disk usage controller"), *debug)
+	}
+
+	if err = (&controllers.JenkinsMetricsReconciler{
+		Client:                       mgr.GetClient(),
+		Scheme:                       mgr.GetScheme(),
+		JenkinsAPIConnectionSettings: jenkinsAPIConnectionSettings,
+		ClientSet:                    *clientSet,
+		Config:                       *cfg,
+		NotificationEvents:           &notificationEvents,
+		Events:                       events,
+		KubernetesClusterDomain:      *kubernetesClusterDomain,
+	}).SetupWithManager(mgr); err != nil {
+		fatal(errors.Wrap(err, "unable to create jenkins metrics controller"), *debug)
+	}
+
+	if err = (&controllers.AgentThrottleReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		fatal(errors.Wrap(err, "unable to create agent throttle controller"), *debug)
+	}
+
+	if err = (&controllers.UpdateCenterMirrorReconciler{
+		Client:          mgr.GetClient(),
+		Scheme:          mgr.GetScheme(),
+		Events:          events,
+		ServerSideApply: *serverSideApply,
+	}).SetupWithManager(mgr); err != nil {
+		fatal(errors.Wrap(err, "unable to create update center mirror controller"), *debug)
+	}
+
+	if err = (&controllers.ResourceUsageReconciler{
+		Client:    mgr.GetClient(),
+		ClientSet: clientSet,
+	}).SetupWithManager(mgr); err != nil {
+		fatal(errors.Wrap(err, "unable to create resource usage controller"), *debug)
+	}
+
+	if err = (&controllers.OrphanGarbageCollector{
+		Client:    mgr.GetClient(),
+		Namespace: namespace,
+		Interval:  *orphanGCInterval,
+		Mode:      orphanGC,
+	}).SetupWithManager(mgr); err != nil {
+		fatal(errors.Wrap(err, "unable to create orphan garbage collector"), *debug)
+	}
+
+	if len(*sharedLibraryWebhookAddr) > 0 {
+		if err = (&controllers.SharedLibraryWebhook{
+			Client:                       mgr.GetClient(),
+			Scheme:                       mgr.GetScheme(),
+			JenkinsAPIConnectionSettings: jenkinsAPIConnectionSettings,
+			ClientSet:                    *clientSet,
+			Config:                       *cfg,
+			Events:                       events,
+			KubernetesClusterDomain:      *kubernetesClusterDomain,
+			BindAddress:                  *sharedLibraryWebhookAddr,
+		}).SetupWithManager(mgr); err != nil {
+			fatal(errors.Wrap(err, "unable to create shared library webhook endpoint"), *debug)
+		}
+	}
+
+	if len(*managementAPIAddr) > 0 {
+		if err = (&controllers.ManagementAPI{
+			Client:      mgr.GetClient(),
+			ClientSet:   clientSet,
+			BindAddress: *managementAPIAddr,
+			TLSCertFile: *managementAPITLSCert,
+			TLSKeyFile:  *managementAPITLSKey,
+		}).SetupWithManager(mgr); err != nil {
+			fatal(errors.Wrap(err, "unable to create management API"), *debug)
+		}
+	}
+
 	if validateSecurityWarnings {
+		v1alpha2.SetBasePluginsConfigMap(mgr.GetAPIReader(), *basePluginsConfigMap)
 		if err = (&v1alpha2.Jenkins{}).SetupWebhookWithManager(mgr); err != nil {
 			fatal(errors.Wrap(err, "unable to create Webhook"), *debug)
 		}
 	}
 	// +kubebuilder:scaffold:builder
 
-	if err := mgr.AddHealthzCheck("health", healthz.Ping); err != nil {
+	diagnostics := probes.NewDiagnostics(probes.Reconciles, map[string]string{
+		"watchNamespace":           namespace,
+		"leaderElection":           fmt.Sprintf("%t", enableLeaderElection),
+		"validateSecurityWarnings": fmt.Sprintf("%t", validateSecurityWarnings),
+		"jenkinsAPIHostname":       *hostname,
+		"jenkinsAPIPort":           fmt.Sprintf("%d", *port),
+		"jenkinsAPIUseNodePort":    fmt.Sprintf("%t", *useNodePort),
+		"clusterDomain":            *kubernetesClusterDomain,
+		"hungReconcileTimeout":     hungReconcileTimeout.String(),
+		"reconcileProfile":         *reconcileProfile,
+		"requeueInterval":          requeueSettings.Interval.String(),
+		"maxErrorBackoff":          requeueSettings.MaxErrorBackoff.String(),
+		"driftCheckPeriod":         requeueSettings.DriftCheckPeriod.String(),
+		"orphanGC":                 string(orphanGC),
+		"orphanGCInterval":         orphanGCInterval.String(),
+		"operationGroovy":          fmt.Sprintf("%t", *operationGroovy),
+		"allowedSCCs":              *allowedSCCs,
+		"managementAPIBindAddress": *managementAPIAddr,
+	})
+	if err := mgr.AddHealthzCheck("ping", healthz.Ping); err != nil {
 		fatal(errors.Wrap(err, "unable to set up health check"), *debug)
 	}
-	if err := mgr.AddReadyzCheck("check", healthz.Ping); err != nil {
-		fatal(errors.Wrap(err, "unable to set up ready check"), *debug)
+	if err := mgr.AddHealthzCheck("controllers", diagnostics.Add("controllers", probes.Reconciles.LivenessChecker(*hungReconcileTimeout))); err != nil {
+		fatal(errors.Wrap(err, "unable to set up controllers health check"), *debug)
+	}
+	if err := mgr.AddReadyzCheck("api-server", diagnostics.Add("api-server", probes.APIServerChecker(clientSet.Discovery()))); err != nil {
+		fatal(errors.Wrap(err, "unable to set up API server ready check"), *debug)
+	}
+	if err := mgr.AddReadyzCheck("informer-cache", diagnostics.Add("informer-cache", probes.CacheSyncChecker(mgr.GetCache(), probes.DefaultCacheSyncTimeout))); err != nil {
+		fatal(errors.Wrap(err, "unable to set up informer cache ready check"), *debug)
+	}
+	if validateSecurityWarnings {
+		webhookCertificate := diagnostics.Add("webhook-certificate", probes.WebhookCertificateChecker(mgr.GetWebhookServer().CertDir))
+		if err := mgr.AddReadyzCheck("webhook-certificate", webhookCertificate); err != nil {
+			fatal(errors.Wrap(err, "unable to set up webhook certificate ready check"), *debug)
+		}
+	}
+	if err := mgr.AddMetricsExtraHandler(probes.DiagnosticsPath, diagnostics); err != nil {
+		fatal(errors.Wrap(err, "unable to set up diagnostics endpoint"), *debug)
 	}
 
 	logger.Info("starting manager")
@@ -208,3 +466,84 @@ func fatal(err error, debug bool) {
 	}
 	os.Exit(1)
 }
+
+// splitNames returns non-empty names from the comma separated list
+func splitNames(list string) []string {
+	var names []string
+	for _, name := range strings.Split(list, ",") {
+		if name = strings.TrimSpace(name); len(name) > 0 {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// validate validates Jenkins CRs from manifests offline, it returns 0 if all Jenkins CRs are valid, 1 if any is invalid
+// and 2 if they couldn't be validated
+func validate(args []string) int {
+	flags := flag.NewFlagSet("validate", flag.ContinueOnError)
+	fileName := flags.String("f", "", "The file with manifests of Jenkins CRs and Secrets, ConfigMaps and JenkinsTemplates referenced by them, - reads standard input.")
+	namespace := flags.String("namespace", "default", "The namespace of objects from manifests without namespace.")
+	updateCenter := flags.String("update-center", "", "The file with cached update center JSON or its URL, plugins are verified against it. Plugins aren't verified if empty.")
+	output := flags.String("output", validation.OutputJSON, "The output format of results: json or text.")
+	openShift := flags.Bool("openshift", false, "Validate Jenkins CRs as they were applied in OpenShift.")
+	basePluginsConfigMap := flags.String("base-plugins-configmap", "", "The name of ConfigMap from manifests with base plugin manifests per Jenkins version.")
+	allowedSCCs := flags.String("allowed-security-context-constraints", "", "The comma separated names of OpenShift SecurityContextConstraints allowed by the operator.")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if len(*fileName) == 0 {
+		_, _ = fmt.Fprintln(os.Stderr, "-f is required")
+		return 2
+	}
+	if *output != validation.OutputJSON && *output != validation.OutputText {
+		_, _ = fmt.Fprintf(os.Stderr, "unknown output format '%s', must be one of json or text\n", *output)
+		return 2
+	}
+
+	resources.AllowSecurityContextConstraints(splitNames(*allowedSCCs))
+	report, err := validateManifests(context.Background(), *fileName, *namespace, *updateCenter, *basePluginsConfigMap, *openShift)
+	if err == nil {
+		err = validation.WriteReport(os.Stdout, report, *output)
+	}
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "%s\n", err)
+		return 2
+	}
+	if !report.Valid {
+		return 1
+	}
+	return 0
+}
+
+func validateManifests(ctx context.Context, fileName, namespace, updateCenterSource, basePluginsConfigMap string, openShift bool) (validation.Report, error) {
+	reader := os.Stdin
+	if fileName != "-" {
+		file, err := os.Open(fileName)
+		if err != nil {
+			return validation.Report{}, errors.WithStack(err)
+		}
+		defer func() { _ = file.Close() }()
+		reader = file
+	}
+	objects, err := validation.ReadObjects(reader, scheme, namespace)
+	if err != nil {
+		return validation.Report{}, err
+	}
+
+	var updateCenter *plugins.UpdateCenter
+	if len(updateCenterSource) > 0 {
+		if updateCenter, err = validation.LoadUpdateCenter(ctx, updateCenterSource); err != nil {
+			return validation.Report{}, err
+		}
+	}
+
+	resources.AssumeOpenShift(openShift)
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+	jenkinsReconciler := &controllers.JenkinsReconciler{
+		Client:               k8sClient,
+		Scheme:               scheme,
+		BasePluginsConfigMap: basePluginsConfigMap,
+	}
+	return validation.New(k8sClient, jenkinsReconciler.Validate, updateCenter).Validate(ctx)
+}
@@ -0,0 +1,127 @@
+package audit
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
+	k8sevent "github.com/jenkinsci/kubernetes-operator/pkg/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/metrics"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// Record is the audit record of the operator action in Jenkins.
+type Record struct {
+	// Time is the time of the action
+	Time metav1.Time `json:"time"`
+	// Namespace is the namespace of Jenkins CR
+	Namespace string `json:"namespace"`
+	// Jenkins is the name of Jenkins CR
+	Jenkins string `json:"jenkins"`
+	// Generation is the generation of Jenkins CR spec the operator acted on
+	Generation int64 `json:"generation"`
+	// SpecHash is the hash of Jenkins CR spec the operator acted on
+	SpecHash string `json:"specHash"`
+	// ChangedBy is the field manager which changed the spec for the last time, e.g. kubectl or the GitOps controller
+	ChangedBy string `json:"changedBy,omitempty"`
+	// Action is the reason of the event, e.g. PluginBatchStarted or PodRestart
+	Action string `json:"action"`
+	// Type is the type of the event, Normal or Warning
+	Type string `json:"type"`
+	// Message describes the action
+	Message string `json:"message"`
+}
+
+func (r Record) String() string {
+	return fmt.Sprintf("%s %s/%s %s: %s", r.Time.UTC().Format(time.RFC3339), r.Namespace, r.Jenkins, r.Action, r.Message)
+}
+
+// NewRecord creates the audit record of the action in Jenkins
+func NewRecord(jenkins *v1alpha2.Jenkins, eventType k8sevent.Type, reason k8sevent.Reason, message string, now time.Time) Record {
+	return Record{
+		Time:       metav1.NewTime(now),
+		Namespace:  jenkins.Namespace,
+		Jenkins:    jenkins.Name,
+		Generation: jenkins.Generation,
+		SpecHash:   SpecHash(jenkins),
+		ChangedBy:  ChangedBy(jenkins),
+		Action:     string(reason),
+		Type:       string(eventType),
+		Message:    message,
+	}
+}
+
+// SpecHash returns the short SHA-256 hash of Jenkins CR spec
+func SpecHash(jenkins *v1alpha2.Jenkins) string {
+	spec, err := json.Marshal(jenkins.Spec)
+	if err != nil {
+		return ""
+	}
+	hash := sha256.Sum256(spec)
+	return hex.EncodeToString(hash[:8])
+}
+
+// ChangedBy returns the field manager which updated Jenkins CR spec for the last time, the operator itself is skipped
+func ChangedBy(jenkins *v1alpha2.Jenkins) string {
+	var manager string
+	var updated time.Time
+	for _, entry := range jenkins.ManagedFields {
+		if entry.Manager == constants.OperatorName || entry.FieldsV1 == nil || entry.Time == nil {
+			continue
+		}
+		if !bytes.Contains(entry.FieldsV1.Raw, []byte(`"f:spec"`)) {
+			continue
+		}
+		if len(manager) == 0 || !entry.Time.Time.Before(updated) {
+			manager, updated = entry.Manager, entry.Time.Time
+		}
+	}
+	return manager
+}
+
+// Entry is the record with Jenkins CR whose spec.audit tells where it's kept.
+type Entry struct {
+	Jenkins v1alpha2.Jenkins
+	Record  Record
+}
+
+type recorder struct {
+	recorder k8sevent.Recorder
+	entries  chan<- Entry
+	now      func() time.Time
+}
+
+// NewRecorder returns the recorder which emits events and sends the events of Jenkins CRs with spec.audit
+// as audit records to the entries channel, the record is dropped and counted when the channel is full
+func NewRecorder(eventRecorder k8sevent.Recorder, entries chan<- Entry) k8sevent.Recorder {
+	return &recorder{recorder: eventRecorder, entries: entries, now: time.Now}
+}
+
+func (r recorder) Emit(object runtime.Object, eventType k8sevent.Type, reason k8sevent.Reason, message string) {
+	r.recorder.Emit(object, eventType, reason, message)
+	r.audit(object, eventType, reason, message)
+}
+
+func (r recorder) Emitf(object runtime.Object, eventType k8sevent.Type, reason k8sevent.Reason, format string, args ...interface{}) {
+	r.recorder.Emitf(object, eventType, reason, format, args...)
+	r.audit(object, eventType, reason, fmt.Sprintf(format, args...))
+}
+
+func (r recorder) audit(object runtime.Object, eventType k8sevent.Type, reason k8sevent.Reason, message string) {
+	jenkins, ok := object.(*v1alpha2.Jenkins)
+	if !ok || jenkins.Spec.Audit == nil {
+		return
+	}
+	select {
+	case r.entries <- Entry{Jenkins: *jenkins.DeepCopy(), Record: NewRecord(jenkins, eventType, reason, message, r.now())}:
+	default:
+		metrics.IncAuditEntriesDropped(jenkins.Namespace, jenkins.Name)
+	}
+}
@@ -0,0 +1,172 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
+	k8sevent "github.com/jenkinsci/kubernetes-operator/pkg/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/metrics"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var now = time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func newJenkins() *v1alpha2.Jenkins {
+	return &v1alpha2.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "example", Generation: 3, UID: "uid"},
+		Spec:       v1alpha2.JenkinsSpec{Audit: &v1alpha2.Audit{}},
+	}
+}
+
+func managedFields(manager string, updated time.Time, fields string) metav1.ManagedFieldsEntry {
+	updatedAt := metav1.NewTime(updated)
+	return metav1.ManagedFieldsEntry{Manager: manager, Operation: metav1.ManagedFieldsOperationUpdate, Time: &updatedAt,
+		FieldsType: "FieldsV1", FieldsV1: &metav1.FieldsV1{Raw: []byte(fields)}}
+}
+
+func TestChangedBy(t *testing.T) {
+	t.Run("the last manager of spec", func(t *testing.T) {
+		jenkins := newJenkins()
+		jenkins.ManagedFields = []metav1.ManagedFieldsEntry{
+			managedFields("kubectl-client-side-apply", now.Add(-time.Hour), `{"f:spec":{"f:master":{}}}`),
+			managedFields("argocd-controller", now, `{"f:spec":{"f:seedJobs":{}}}`),
+			managedFields(constants.OperatorName, now.Add(time.Minute), `{"f:spec":{"f:master":{}}}`),
+			managedFields("kubectl-annotate", now.Add(time.Minute), `{"f:metadata":{"f:annotations":{}}}`),
+		}
+
+		assert.Equal(t, "argocd-controller", ChangedBy(jenkins))
+	})
+	t.Run("without managed fields", func(t *testing.T) {
+		assert.Empty(t, ChangedBy(newJenkins()))
+	})
+}
+
+func TestSpecHash(t *testing.T) {
+	jenkins := newJenkins()
+	hash := SpecHash(jenkins)
+	assert.Len(t, hash, 16)
+	assert.Equal(t, hash, SpecHash(jenkins.DeepCopy()))
+
+	jenkins.Spec.Master.Plugins = []v1alpha2.Plugin{{Name: "git", Version: "4.8.2"}}
+	assert.NotEqual(t, hash, SpecHash(jenkins))
+}
+
+func TestAppendRecord(t *testing.T) {
+	record := func(i int) Record {
+		return Record{Time: metav1.NewTime(now), Namespace: "default", Jenkins: "example", Action: "PodRestart", Message: fmt.Sprintf("restart %d", i)}
+	}
+
+	t.Run("ring buffer", func(t *testing.T) {
+		var records string
+		var err error
+		for i := 0; i < 5; i++ {
+			records, err = AppendRecord(records, record(i), 3)
+			require.NoError(t, err)
+		}
+
+		read, err := ReadRecords(&corev1.ConfigMap{Data: map[string]string{RecordsKey: records}})
+		require.NoError(t, err)
+		require.Len(t, read, 3)
+		for i, record := range read {
+			assert.Equal(t, fmt.Sprintf("restart %d", i+2), record.Message)
+			assert.True(t, record.Time.Equal(&metav1.Time{Time: now}))
+		}
+	})
+	t.Run("size limit", func(t *testing.T) {
+		large := record(0)
+		large.Message = strings.Repeat("x", 250*1024)
+		var records string
+		var err error
+		for i := 0; i < 4; i++ {
+			records, err = AppendRecord(records, large, DefaultMaxRecords)
+			require.NoError(t, err)
+		}
+
+		assert.Equal(t, 3, strings.Count(records, "\n"))
+		assert.Less(t, len(records), maxRecordsSize)
+	})
+}
+
+type fakeRecorder struct {
+	reasons []k8sevent.Reason
+}
+
+func (f *fakeRecorder) Emit(_ runtime.Object, _ k8sevent.Type, reason k8sevent.Reason, _ string) {
+	f.reasons = append(f.reasons, reason)
+}
+
+func (f *fakeRecorder) Emitf(_ runtime.Object, _ k8sevent.Type, reason k8sevent.Reason, _ string, _ ...interface{}) {
+	f.reasons = append(f.reasons, reason)
+}
+
+func TestRecorder(t *testing.T) {
+	events := &fakeRecorder{}
+	entries := make(chan Entry, 10)
+	recorder := NewRecorder(events, entries)
+
+	jenkins := newJenkins()
+	withoutAudit := newJenkins()
+	withoutAudit.Spec.Audit = nil
+	recorder.Emitf(jenkins, k8sevent.TypeNormal, k8sevent.ReasonPluginBatchStarted, "Plugins downloaded to Jenkins master: %s", "git")
+	recorder.Emit(withoutAudit, k8sevent.TypeNormal, k8sevent.ReasonBackupTriggered, "Backup triggered")
+	recorder.Emit(&corev1.Pod{}, k8sevent.TypeNormal, k8sevent.ReasonBackupTriggered, "Backup triggered")
+
+	assert.Equal(t, []k8sevent.Reason{k8sevent.ReasonPluginBatchStarted, k8sevent.ReasonBackupTriggered, k8sevent.ReasonBackupTriggered}, events.reasons)
+	require.Len(t, entries, 1)
+	entry := <-entries
+	assert.Equal(t, "example", entry.Record.Jenkins)
+	assert.Equal(t, int64(3), entry.Record.Generation)
+	assert.Equal(t, "PluginBatchStarted", entry.Record.Action)
+	assert.Equal(t, "Normal", entry.Record.Type)
+	assert.Equal(t, "Plugins downloaded to Jenkins master: git", entry.Record.Message)
+
+	t.Run("drops record when channel is full", func(t *testing.T) {
+		events := &fakeRecorder{}
+		entries := make(chan Entry, 1)
+		recorder := NewRecorder(events, entries)
+		dropped := testutil.ToFloat64(metrics.AuditEntriesDroppedTotal.WithLabelValues(jenkins.Namespace, jenkins.Name))
+
+		recorder.Emit(jenkins, k8sevent.TypeNormal, k8sevent.ReasonBackupTriggered, "Backup triggered")
+		recorder.Emit(jenkins, k8sevent.TypeNormal, k8sevent.ReasonBackupTriggered, "Backup triggered")
+
+		assert.Len(t, events.reasons, 2)
+		assert.Len(t, entries, 1)
+		assert.Equal(t, dropped+1, testutil.ToFloat64(metrics.AuditEntriesDroppedTotal.WithLabelValues(jenkins.Namespace, jenkins.Name)))
+	})
+}
+
+func TestWriteConfigMap(t *testing.T) {
+	require.NoError(t, v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme))
+	k8sClient := fake.NewClientBuilder().Build()
+	jenkins := newJenkins()
+	jenkins.Spec.Audit.MaxRecords = 2
+
+	for _, action := range []string{"SpecChanged", "PluginBatchStarted", "PodRestart"} {
+		require.NoError(t, writeConfigMap(k8sClient, jenkins, Record{Time: metav1.NewTime(now), Jenkins: jenkins.Name, Action: action}))
+	}
+
+	configMap := &corev1.ConfigMap{}
+	require.NoError(t, k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "jenkins-operator-audit-example"}, configMap))
+	assert.Equal(t, "example", configMap.Labels[constants.LabelJenkinsCRKey])
+	require.Len(t, configMap.OwnerReferences, 1)
+	assert.Equal(t, types.UID("uid"), configMap.OwnerReferences[0].UID)
+	records, err := ReadRecords(configMap)
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, "PluginBatchStarted", records[0].Action)
+	assert.Equal(t, "PodRestart", records[1].Action)
+}
@@ -0,0 +1,2 @@
+// Package audit keeps the append-only trail of the operator actions in Jenkins recorded from events of Jenkins CR
+package audit
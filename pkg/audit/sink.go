@@ -0,0 +1,173 @@
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// DefaultMaxRecords is the default number of the last records kept in the audit ConfigMap
+	DefaultMaxRecords = 500
+	// RecordsKey is the key of the audit ConfigMap with the records, one JSON record per line
+	RecordsKey = "audit.jsonl"
+	// maxRecordsSize keeps the ConfigMap below the 1 MiB limit of Kubernetes objects
+	maxRecordsSize = 900 * 1024
+	webhookTimeout = 10 * time.Second
+)
+
+// ConfigMapName returns the name of ConfigMap with the last audit records of Jenkins
+func ConfigMapName(jenkins string) string {
+	return fmt.Sprintf("%s-audit-%s", constants.OperatorName, jenkins)
+}
+
+// AppendRecord appends the record to the records of the ConfigMap data, the oldest records are removed when there are
+// more than maxRecords or they don't fit in the ConfigMap
+func AppendRecord(records string, record Record, maxRecords int) (string, error) {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	lines := append(strings.Split(strings.TrimSuffix(records, "\n"), "\n"), string(line))
+	if len(lines[0]) == 0 {
+		lines = lines[1:]
+	}
+	if len(lines) > maxRecords {
+		lines = lines[len(lines)-maxRecords:]
+	}
+	size := 0
+	for i := len(lines) - 1; i >= 0; i-- {
+		size += len(lines[i]) + 1
+		if size > maxRecordsSize {
+			lines = lines[i+1:]
+			break
+		}
+	}
+	return strings.Join(lines, "\n") + "\n", nil
+}
+
+// ReadRecords returns the records of the audit ConfigMap from the oldest one
+func ReadRecords(configMap *corev1.ConfigMap) ([]Record, error) {
+	var records []Record
+	scanner := bufio.NewScanner(strings.NewReader(configMap.Data[RecordsKey]))
+	scanner.Buffer(make([]byte, 64*1024), maxRecordsSize)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		record := Record{}
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return nil, errors.Wrapf(err, "invalid audit record '%s'", scanner.Text())
+		}
+		records = append(records, record)
+	}
+	return records, errors.WithStack(scanner.Err())
+}
+
+// Listen writes the audit records to the ConfigMap of Jenkins and sends them to the webhook of spec.audit, the records
+// are written one by one in order
+func Listen(entries <-chan Entry, k8sClient k8sclient.Client) {
+	httpClient := &http.Client{Timeout: webhookTimeout}
+	for entry := range entries {
+		logger := log.ForJenkins(&entry.Jenkins)
+		if err := writeConfigMap(k8sClient, &entry.Jenkins, entry.Record); err != nil {
+			logger.V(log.VWarn).Info(fmt.Sprintf("Failed to write audit record '%s': %s", entry.Record, err))
+		}
+		if entry.Jenkins.Spec.Audit.Webhook != nil {
+			if err := sendWebhook(k8sClient, httpClient, &entry.Jenkins, entry.Record); err != nil {
+				logger.V(log.VWarn).Info(fmt.Sprintf("Failed to send audit record '%s' to the webhook: %s", entry.Record, err))
+			}
+		}
+	}
+}
+
+func writeConfigMap(k8sClient k8sclient.Client, jenkins *v1alpha2.Jenkins, record Record) error {
+	maxRecords := DefaultMaxRecords
+	if jenkins.Spec.Audit.MaxRecords > 0 {
+		maxRecords = int(jenkins.Spec.Audit.MaxRecords)
+	}
+	name := types.NamespacedName{Namespace: jenkins.Namespace, Name: ConfigMapName(jenkins.Name)}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		configMap := &corev1.ConfigMap{}
+		err := k8sClient.Get(context.TODO(), name, configMap)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return errors.WithStack(err)
+		}
+		if apierrors.IsNotFound(err) {
+			configMap = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            name.Name,
+					Namespace:       name.Namespace,
+					Labels:          resources.BuildResourceLabels(jenkins),
+					OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(jenkins, v1alpha2.GroupVersion.WithKind(v1alpha2.Kind))},
+				},
+			}
+		}
+		records, err := AppendRecord(configMap.Data[RecordsKey], record, maxRecords)
+		if err != nil {
+			return err
+		}
+		if configMap.Data == nil {
+			configMap.Data = map[string]string{}
+		}
+		configMap.Data[RecordsKey] = records
+		if len(configMap.ResourceVersion) == 0 {
+			err = k8sClient.Create(context.TODO(), configMap)
+			if apierrors.IsAlreadyExists(err) {
+				// the cache hasn't seen the ConfigMap yet, it's read again
+				return apierrors.NewConflict(corev1.Resource("configmaps"), name.Name, err)
+			}
+			return err
+		}
+		return k8sClient.Update(context.TODO(), configMap)
+	})
+}
+
+func sendWebhook(k8sClient k8sclient.Client, httpClient *http.Client, jenkins *v1alpha2.Jenkins, record Record) error {
+	selector := jenkins.Spec.Audit.Webhook.URLSecretKeySelector
+	secret := &corev1.Secret{}
+	if err := k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: jenkins.Namespace, Name: selector.Name}, secret); err != nil {
+		return errors.WithStack(err)
+	}
+	url := strings.TrimSpace(string(secret.Data[selector.Key]))
+	if len(url) == 0 {
+		return errors.Errorf("audit webhook URL is empty in secret '%s/%s[%s]'", jenkins.Namespace, selector.Name, selector.Key)
+	}
+
+	body, err := json.Marshal(record)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer func() { _ = response.Body.Close() }()
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return errors.Errorf("audit webhook responded with status %d", response.StatusCode)
+	}
+	return nil
+}
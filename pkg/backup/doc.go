@@ -0,0 +1,3 @@
+// Package backup contains the registry of providers which back up and restore Jenkins home, the exec provider
+// running spec.backup.action and spec.restore.action in the sidecar container is registered by default
+package backup
@@ -0,0 +1,112 @@
+package backup
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	"github.com/pkg/errors"
+)
+
+// noBackup is returned by spec.restore.getLatestAction when there is no backup
+const noBackup = "-1"
+
+// execProvider runs spec.backup.action and spec.restore.action with the backup number in the sidecar container
+type execProvider struct{}
+
+func (execProvider) Validate(jenkins *v1alpha2.Jenkins) []string {
+	var messages []string
+	allContainers := map[string]v1alpha2.Container{}
+	for _, container := range jenkins.Spec.Master.Containers {
+		allContainers[container.Name] = container
+	}
+
+	restore := jenkins.Spec.Restore
+	if len(restore.ContainerName) > 0 {
+		_, found := allContainers[restore.ContainerName]
+		if !found {
+			messages = append(messages, fmt.Sprintf("restore container '%s' not found in CR spec.master.containers", restore.ContainerName))
+		}
+		if restore.Action.Exec == nil {
+			messages = append(messages, "spec.restore.action.exec is not configured")
+		}
+	}
+
+	backup := jenkins.Spec.Backup
+	if len(backup.ContainerName) > 0 {
+		_, found := allContainers[backup.ContainerName]
+		if !found {
+			messages = append(messages, fmt.Sprintf("backup container '%s' not found in CR spec.master.containers", backup.ContainerName))
+		}
+		if backup.Action.Exec == nil {
+			messages = append(messages, "spec.backup.action.exec is not configured")
+		}
+		if backup.Interval == 0 {
+			messages = append(messages, "spec.backup.interval is not configured")
+		}
+	}
+
+	if len(restore.ContainerName) > 0 && len(backup.ContainerName) == 0 {
+		messages = append(messages, "spec.backup.containerName is not configured")
+	}
+	if len(backup.ContainerName) > 0 && len(restore.ContainerName) == 0 {
+		messages = append(messages, "spec.restore.containerName is not configured")
+	}
+
+	return messages
+}
+
+func (execProvider) BackupConfigured(jenkins *v1alpha2.Jenkins) bool {
+	return len(jenkins.Spec.Backup.ContainerName) > 0 && jenkins.Spec.Backup.Action.Exec != nil
+}
+
+func (execProvider) RestoreConfigured(jenkins *v1alpha2.Jenkins) bool {
+	return len(jenkins.Spec.Restore.ContainerName) > 0 && jenkins.Spec.Restore.Action.Exec != nil
+}
+
+func (execProvider) Backup(target Target, number uint64) error {
+	backup := target.Jenkins.Spec.Backup
+	_, _, err := target.Exec(backup.ContainerName, withNumber(backup.Action.Exec.Command, number))
+	return err
+}
+
+func (execProvider) Restore(target Target, number uint64) error {
+	restore := target.Jenkins.Spec.Restore
+	_, _, err := target.Exec(restore.ContainerName, withNumber(restore.Action.Exec.Command, number))
+	return err
+}
+
+func (execProvider) LatestBackup(target Target) (uint64, bool, error) {
+	restore := target.Jenkins.Spec.Restore
+	if restore.GetLatestAction.Exec == nil {
+		return 0, false, nil
+	}
+	stdout, _, err := target.Exec(restore.ContainerName, restore.GetLatestAction.Exec.Command)
+	if err != nil {
+		return 0, true, err
+	}
+	return parseBackupNumber(stdout.String())
+}
+
+// parseBackupNumber parses the output of spec.restore.getLatestAction
+func parseBackupNumber(output string) (uint64, bool, error) {
+	value := strings.TrimSuffix(output, "\n")
+	if value == noBackup {
+		return 0, true, nil
+	}
+	number, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, true, errors.Wrapf(err, "invalid backup number '%s' returned by get last backup number action", value)
+	}
+	if number < 1 {
+		return 0, true, errors.Errorf("invalid backup number '%d' returned by get last backup number action", number)
+	}
+	return number, true, nil
+}
+
+// withNumber returns the command with the backup number as the last argument
+func withNumber(command []string, number uint64) []string {
+	return append(append([]string{}, command...), fmt.Sprintf("%d", number))
+}
@@ -0,0 +1,107 @@
+package backup
+
+import (
+	"bytes"
+	"sort"
+	"sync"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	"github.com/pkg/errors"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ExecProviderName is the name of the default provider
+const ExecProviderName = "exec"
+
+// Target is Jenkins backed up or restored by the provider with the clients to reach it
+type Target struct {
+	Jenkins *v1alpha2.Jenkins
+	Client  k8sclient.Client
+	// Exec runs the command in the container of Jenkins master pod
+	Exec func(containerName string, command []string) (stdout, stderr bytes.Buffer, err error)
+}
+
+// Provider backs up and restores Jenkins home, it's configured by spec.backup and spec.restore of Jenkins CR
+type Provider interface {
+	// Validate returns the problems with spec.backup and spec.restore
+	Validate(jenkins *v1alpha2.Jenkins) []string
+	// BackupConfigured tells if backups of Jenkins are configured
+	BackupConfigured(jenkins *v1alpha2.Jenkins) bool
+	// RestoreConfigured tells if restores of Jenkins are configured
+	RestoreConfigured(jenkins *v1alpha2.Jenkins) bool
+	// Backup makes the backup with the number
+	Backup(target Target, number uint64) error
+	// Restore restores the backup with the number
+	Restore(target Target, number uint64) error
+	// LatestBackup returns the number of the latest backup, 0 when there is no backup, supported is false when
+	// the provider can't tell it and the number from Jenkins CR status is used
+	LatestBackup(target Target) (number uint64, supported bool, err error)
+}
+
+var (
+	providersMutex sync.RWMutex
+	providers      = map[string]Provider{}
+)
+
+func init() {
+	Register(ExecProviderName, execProvider{})
+}
+
+// Register makes the provider available by the name in spec.backup.provider, it's meant to be called from init
+// functions of packages with custom providers, e.g. of internal object stores. It panics if the name is registered twice.
+func Register(name string, provider Provider) {
+	providersMutex.Lock()
+	defer providersMutex.Unlock()
+	if provider == nil {
+		panic("backup: registered provider is nil")
+	}
+	if _, duplicate := providers[name]; duplicate {
+		panic("backup: provider " + name + " registered twice")
+	}
+	providers[name] = provider
+}
+
+// Names returns the sorted names of registered providers
+func Names() []string {
+	providersMutex.RLock()
+	defer providersMutex.RUnlock()
+	var names []string
+	for name := range providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ProviderName returns the name of the provider of Jenkins from spec.backup.provider
+func ProviderName(jenkins *v1alpha2.Jenkins) string {
+	if len(jenkins.Spec.Backup.Provider) == 0 {
+		return ExecProviderName
+	}
+	return jenkins.Spec.Backup.Provider
+}
+
+// Get returns the provider of Jenkins, an error is returned when it isn't registered
+func Get(jenkins *v1alpha2.Jenkins) (Provider, error) {
+	name := ProviderName(jenkins)
+	providersMutex.RLock()
+	defer providersMutex.RUnlock()
+	provider, ok := providers[name]
+	if !ok {
+		return nil, errors.Errorf("backup provider '%s' isn't registered", name)
+	}
+	return provider, nil
+}
+
+// IsBackupConfigured tells if backups of Jenkins are configured with the registered provider
+func IsBackupConfigured(jenkins *v1alpha2.Jenkins) bool {
+	provider, err := Get(jenkins)
+	return err == nil && provider.BackupConfigured(jenkins)
+}
+
+// IsRestoreConfigured tells if restores of Jenkins are configured with the registered provider
+func IsRestoreConfigured(jenkins *v1alpha2.Jenkins) bool {
+	provider, err := Get(jenkins)
+	return err == nil && provider.RestoreConfigured(jenkins)
+}
@@ -0,0 +1,130 @@
+package backup
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+type fakeProvider struct {
+	execProvider
+}
+
+func (fakeProvider) BackupConfigured(*v1alpha2.Jenkins) bool {
+	return true
+}
+
+func newExecJenkins() *v1alpha2.Jenkins {
+	return &v1alpha2.Jenkins{
+		Spec: v1alpha2.JenkinsSpec{
+			Master: v1alpha2.JenkinsMaster{Containers: []v1alpha2.Container{{Name: "backup"}}},
+			Backup: v1alpha2.Backup{
+				ContainerName: "backup",
+				Action:        v1alpha2.Handler{Exec: &corev1.ExecAction{Command: []string{"/home/user/bin/backup.sh"}}},
+				Interval:      30,
+			},
+			Restore: v1alpha2.Restore{
+				ContainerName:   "backup",
+				Action:          v1alpha2.Handler{Exec: &corev1.ExecAction{Command: []string{"/home/user/bin/restore.sh"}}},
+				GetLatestAction: v1alpha2.Handler{Exec: &corev1.ExecAction{Command: []string{"/home/user/bin/get-latest.sh"}}},
+			},
+		},
+	}
+}
+
+func TestRegister(t *testing.T) {
+	Register("test-store", fakeProvider{})
+	defer func() {
+		providersMutex.Lock()
+		delete(providers, "test-store")
+		providersMutex.Unlock()
+	}()
+
+	assert.Equal(t, []string{ExecProviderName, "test-store"}, Names())
+	assert.Panics(t, func() { Register("test-store", fakeProvider{}) })
+
+	jenkins := &v1alpha2.Jenkins{Spec: v1alpha2.JenkinsSpec{Backup: v1alpha2.Backup{Provider: "test-store"}}}
+	provider, err := Get(jenkins)
+	require.NoError(t, err)
+	assert.Equal(t, fakeProvider{}, provider)
+	assert.True(t, IsBackupConfigured(jenkins))
+
+	jenkins.Spec.Backup.Provider = "missing"
+	_, err = Get(jenkins)
+	assert.EqualError(t, err, "backup provider 'missing' isn't registered")
+	assert.False(t, IsBackupConfigured(jenkins))
+}
+
+func TestExecProvider(t *testing.T) {
+	t.Run("configured", func(t *testing.T) {
+		jenkins := newExecJenkins()
+
+		assert.Empty(t, execProvider{}.Validate(jenkins))
+		assert.True(t, IsBackupConfigured(jenkins))
+		assert.True(t, IsRestoreConfigured(jenkins))
+		assert.False(t, IsBackupConfigured(&v1alpha2.Jenkins{}))
+	})
+	t.Run("missing container", func(t *testing.T) {
+		jenkins := newExecJenkins()
+		jenkins.Spec.Master.Containers = nil
+
+		assert.Equal(t, []string{
+			"restore container 'backup' not found in CR spec.master.containers",
+			"backup container 'backup' not found in CR spec.master.containers",
+		}, execProvider{}.Validate(jenkins))
+	})
+	t.Run("backup and restore with number", func(t *testing.T) {
+		var commands [][]string
+		target := Target{
+			Jenkins: newExecJenkins(),
+			Exec: func(containerName string, command []string) (bytes.Buffer, bytes.Buffer, error) {
+				commands = append(commands, append([]string{containerName}, command...))
+				return *bytes.NewBufferString("7\n"), bytes.Buffer{}, nil
+			},
+		}
+
+		require.NoError(t, execProvider{}.Backup(target, 8))
+		require.NoError(t, execProvider{}.Restore(target, 7))
+		latest, supported, err := execProvider{}.LatestBackup(target)
+
+		require.NoError(t, err)
+		assert.True(t, supported)
+		assert.Equal(t, uint64(7), latest)
+		assert.Equal(t, [][]string{
+			{"backup", "/home/user/bin/backup.sh", "8"},
+			{"backup", "/home/user/bin/restore.sh", "7"},
+			{"backup", "/home/user/bin/get-latest.sh"},
+		}, commands)
+		assert.Equal(t, []string{"/home/user/bin/backup.sh"}, target.Jenkins.Spec.Backup.Action.Exec.Command)
+	})
+	t.Run("latest backup not supported", func(t *testing.T) {
+		target := Target{Jenkins: newExecJenkins()}
+		target.Jenkins.Spec.Restore.GetLatestAction.Exec = nil
+
+		_, supported, err := execProvider{}.LatestBackup(target)
+
+		require.NoError(t, err)
+		assert.False(t, supported)
+	})
+}
+
+func TestParseBackupNumber(t *testing.T) {
+	number, supported, err := parseBackupNumber("-1\n")
+	require.NoError(t, err)
+	assert.True(t, supported)
+	assert.Equal(t, uint64(0), number)
+
+	number, _, err = parseBackupNumber("42\n")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(42), number)
+
+	_, _, err = parseBackupNumber("0")
+	assert.EqualError(t, err, "invalid backup number '0' returned by get last backup number action")
+	_, _, err = parseBackupNumber("abc")
+	assert.Error(t, err)
+}
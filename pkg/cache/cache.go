@@ -0,0 +1,392 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/rest"
+	toolscache "k8s.io/client-go/tools/cache"
+	crcache "sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// defaultResync is the resync period of filtered informers, it's the same as the default of controller-runtime
+const defaultResync = 10 * time.Hour
+
+// Selector selects the cached objects by label, the object is selected when the label exists if Value is empty
+type Selector struct {
+	Key   string
+	Value string
+}
+
+func (s Selector) String() string {
+	if len(s.Value) == 0 {
+		return s.Key
+	}
+	return fmt.Sprintf("%s=%s", s.Key, s.Value)
+}
+
+// covers tells if all objects matching the label selector of the List request are selected
+func (s Selector) covers(selector labels.Selector) bool {
+	if selector == nil {
+		return false
+	}
+	value, found := selector.RequiresExactMatch(s.Key)
+	return found && (len(s.Value) == 0 || value == s.Value)
+}
+
+// Filter restricts the cached objects of one kind, one informer is started per selector
+type Filter struct {
+	Object    client.Object
+	Selectors []Selector
+	// Transform removes the fields which the operator doesn't read from the cached objects, it's called after
+	// managed fields are removed
+	Transform func(object client.Object)
+	// Uncached makes Get and List read the objects from the API server, the informers only send events of the selected
+	// objects, so Transform can remove the fields the operator reads
+	Uncached bool
+}
+
+// DefaultFilters returns the filters of Secrets, ConfigMaps and Pods, the operator watches only the objects it manages
+// and the agent pods of Jenkins CRs. Secrets and ConfigMaps are read from the API server, their data isn't cached.
+func DefaultFilters() []Filter {
+	managed := Selector{Key: constants.LabelAppKey, Value: constants.LabelAppValue}
+	return []Filter{
+		{Object: &corev1.Secret{}, Selectors: []Selector{managed}, Transform: stripData, Uncached: true},
+		{Object: &corev1.ConfigMap{}, Selectors: []Selector{managed}, Transform: stripData, Uncached: true},
+		{Object: &corev1.Pod{}, Selectors: []Selector{managed, {Key: constants.LabelAgentOfKey}}, Transform: stripLastAppliedConfiguration},
+	}
+}
+
+// stripData removes the data of Secrets and ConfigMaps and the copy of the whole object kept by kubectl apply, they're
+// read from the API server
+func stripData(object client.Object) {
+	stripLastAppliedConfiguration(object)
+	switch typed := object.(type) {
+	case *corev1.Secret:
+		typed.Data = nil
+		typed.StringData = nil
+	case *corev1.ConfigMap:
+		typed.Data = nil
+		typed.BinaryData = nil
+	}
+}
+
+// stripLastAppliedConfiguration removes the copy of the whole object kept by kubectl apply, pods are never updated
+// by the operator so the annotation can be dropped from the cache
+func stripLastAppliedConfiguration(object client.Object) {
+	annotations := object.GetAnnotations()
+	if _, ok := annotations[corev1.LastAppliedConfigAnnotation]; ok {
+		delete(annotations, corev1.LastAppliedConfigAnnotation)
+		object.SetAnnotations(annotations)
+	}
+}
+
+// NewCacheFunc returns the cache constructor of controller-runtime manager, the objects of filtered kinds which
+// aren't selected are read from the API server. Managed fields are stripped from all cached objects of filtered kinds.
+func NewCacheFunc(filters ...Filter) crcache.NewCacheFunc {
+	return func(config *rest.Config, options crcache.Options) (crcache.Cache, error) {
+		defaultCache, err := crcache.New(config, options)
+		if err != nil {
+			return nil, err
+		}
+		if options.Scheme == nil || options.Mapper == nil {
+			return nil, errors.New("scheme and mapper are required by filtered cache")
+		}
+		apiReader, err := client.New(config, client.Options{Scheme: options.Scheme, Mapper: options.Mapper})
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		resync := defaultResync
+		if options.Resync != nil {
+			resync = *options.Resync
+		}
+
+		c := &filteredCache{
+			Cache:     defaultCache,
+			scheme:    options.Scheme,
+			apiReader: apiReader,
+			kinds:     map[schema.GroupVersionKind]*filteredKind{},
+		}
+		codecs := serializer.NewCodecFactory(options.Scheme)
+		for _, filter := range filters {
+			gvk, err := apiutil.GVKForObject(filter.Object, options.Scheme)
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+			mapping, err := options.Mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+			restClient, err := apiutil.RESTClientForGVK(gvk, false, config, codecs)
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+			kind := &filteredKind{gvk: gvk, uncached: filter.Uncached}
+			for _, selector := range filter.Selectors {
+				lw := newListWatch(restClient, mapping.Resource.Resource, options.Namespace, selector, filter.Transform)
+				informer := toolscache.NewSharedIndexInformer(lw, filter.Object.DeepCopyObject(), resync,
+					toolscache.Indexers{toolscache.NamespaceIndex: toolscache.MetaNamespaceIndexFunc})
+				kind.informers = append(kind.informers, selectedInformer{selector: selector, informer: informer})
+			}
+			c.kinds[gvk] = kind
+		}
+		return c, nil
+	}
+}
+
+// newListWatch returns ListWatch of the objects matching the selector without managed fields
+func newListWatch(restClient rest.Interface, resource, namespace string, selector Selector, transform func(client.Object)) *toolscache.ListWatch {
+	strip := func(object runtime.Object) error {
+		if cached, ok := object.(client.Object); ok {
+			cached.SetManagedFields(nil)
+			if transform != nil {
+				transform(cached)
+			}
+		}
+		return nil
+	}
+	lw := toolscache.NewFilteredListWatchFromClient(restClient, resource, namespace, func(options *metav1.ListOptions) {
+		options.LabelSelector = selector.String()
+	})
+	list, watchFunc := lw.ListFunc, lw.WatchFunc
+	lw.ListFunc = func(options metav1.ListOptions) (runtime.Object, error) {
+		object, err := list(options)
+		if err != nil {
+			return nil, err
+		}
+		return object, meta.EachListItem(object, strip)
+	}
+	lw.WatchFunc = func(options metav1.ListOptions) (watch.Interface, error) {
+		w, err := watchFunc(options)
+		if err != nil {
+			return nil, err
+		}
+		return watch.Filter(w, func(event watch.Event) (watch.Event, bool) {
+			_ = strip(event.Object)
+			return event, true
+		}), nil
+	}
+	return lw
+}
+
+type selectedInformer struct {
+	selector Selector
+	informer toolscache.SharedIndexInformer
+}
+
+type filteredKind struct {
+	gvk       schema.GroupVersionKind
+	informers []selectedInformer
+	uncached  bool
+}
+
+// filteredCache caches the selected objects of filtered kinds, other kinds are cached by controller-runtime cache
+type filteredCache struct {
+	crcache.Cache
+	scheme    *runtime.Scheme
+	apiReader client.Reader
+	kinds     map[schema.GroupVersionKind]*filteredKind
+}
+
+func (c *filteredCache) kindOf(object runtime.Object) (*filteredKind, error) {
+	gvk, err := apiutil.GVKForObject(object, c.scheme)
+	if err != nil {
+		return nil, err
+	}
+	if kind, ok := c.kinds[gvk]; ok {
+		return kind, nil
+	}
+	if strings.HasSuffix(gvk.Kind, "List") {
+		return c.kinds[gvk.GroupVersion().WithKind(strings.TrimSuffix(gvk.Kind, "List"))], nil
+	}
+	return nil, nil
+}
+
+// Get reads the object from filtered informers, the object which isn't selected is read from the API server
+func (c *filteredCache) Get(ctx context.Context, key client.ObjectKey, object client.Object) error {
+	kind, err := c.kindOf(object)
+	if err != nil {
+		return err
+	}
+	if kind == nil {
+		return c.Cache.Get(ctx, key, object)
+	}
+	if kind.uncached {
+		return c.apiReader.Get(ctx, key, object)
+	}
+	storeKey := key.Name
+	if len(key.Namespace) > 0 {
+		storeKey = key.Namespace + "/" + key.Name
+	}
+	for _, selected := range kind.informers {
+		cached, exists, err := selected.informer.GetIndexer().GetByKey(storeKey)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		if exists {
+			return copyInto(cached.(runtime.Object), object, kind.gvk)
+		}
+	}
+	return c.apiReader.Get(ctx, key, object)
+}
+
+// List lists the objects from the filtered informer when it has all requested objects, otherwise from the API server
+func (c *filteredCache) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	kind, err := c.kindOf(list)
+	if err != nil {
+		return err
+	}
+	if kind == nil {
+		return c.Cache.List(ctx, list, opts...)
+	}
+	if kind.uncached {
+		return c.apiReader.List(ctx, list, opts...)
+	}
+	listOptions := client.ListOptions{}
+	listOptions.ApplyOptions(opts)
+	if listOptions.FieldSelector != nil || listOptions.Limit > 0 {
+		return c.apiReader.List(ctx, list, opts...)
+	}
+	for _, selected := range kind.informers {
+		if !selected.selector.covers(listOptions.LabelSelector) {
+			continue
+		}
+		var cached []interface{}
+		if len(listOptions.Namespace) > 0 {
+			cached, err = selected.informer.GetIndexer().ByIndex(toolscache.NamespaceIndex, listOptions.Namespace)
+			if err != nil {
+				return errors.WithStack(err)
+			}
+		} else {
+			cached = selected.informer.GetIndexer().List()
+		}
+		items := make([]runtime.Object, 0, len(cached))
+		for _, item := range cached {
+			object := item.(client.Object)
+			if !listOptions.LabelSelector.Matches(labels.Set(object.GetLabels())) {
+				continue
+			}
+			copied := object.DeepCopyObject()
+			copied.GetObjectKind().SetGroupVersionKind(kind.gvk)
+			items = append(items, copied)
+		}
+		return errors.WithStack(meta.SetList(list, items))
+	}
+	return c.apiReader.List(ctx, list, opts...)
+}
+
+// GetInformer returns the informer of the object, events of filtered kinds are sent for the selected objects only
+func (c *filteredCache) GetInformer(ctx context.Context, object client.Object) (crcache.Informer, error) {
+	kind, err := c.kindOf(object)
+	if err != nil {
+		return nil, err
+	}
+	if kind == nil {
+		return c.Cache.GetInformer(ctx, object)
+	}
+	return kind, nil
+}
+
+// GetInformerForKind returns the informer of the kind, events of filtered kinds are sent for the selected objects only
+func (c *filteredCache) GetInformerForKind(ctx context.Context, gvk schema.GroupVersionKind) (crcache.Informer, error) {
+	if kind, ok := c.kinds[gvk]; ok {
+		return kind, nil
+	}
+	return c.Cache.GetInformerForKind(ctx, gvk)
+}
+
+// Start runs the filtered informers and controller-runtime cache until the context is closed
+func (c *filteredCache) Start(ctx context.Context) error {
+	for _, kind := range c.kinds {
+		for _, selected := range kind.informers {
+			go selected.informer.Run(ctx.Done())
+		}
+	}
+	return c.Cache.Start(ctx)
+}
+
+// WaitForCacheSync waits for the filtered informers and controller-runtime cache
+func (c *filteredCache) WaitForCacheSync(ctx context.Context) bool {
+	var synced []toolscache.InformerSynced
+	for _, kind := range c.kinds {
+		synced = append(synced, kind.HasSynced)
+	}
+	if !toolscache.WaitForCacheSync(ctx.Done(), synced...) {
+		return false
+	}
+	return c.Cache.WaitForCacheSync(ctx)
+}
+
+// IndexField adds the field index to controller-runtime cache, filtered kinds can't be indexed
+func (c *filteredCache) IndexField(ctx context.Context, object client.Object, field string, extractValue client.IndexerFunc) error {
+	kind, err := c.kindOf(object)
+	if err != nil {
+		return err
+	}
+	if kind != nil {
+		return errors.Errorf("field index '%s' isn't supported by filtered cache of %s", field, kind.gvk.Kind)
+	}
+	return c.Cache.IndexField(ctx, object, field, extractValue)
+}
+
+// AddEventHandler adds the handler to all informers of the kind
+func (k *filteredKind) AddEventHandler(handler toolscache.ResourceEventHandler) {
+	for _, selected := range k.informers {
+		selected.informer.AddEventHandler(handler)
+	}
+}
+
+// AddEventHandlerWithResyncPeriod adds the handler to all informers of the kind
+func (k *filteredKind) AddEventHandlerWithResyncPeriod(handler toolscache.ResourceEventHandler, resyncPeriod time.Duration) {
+	for _, selected := range k.informers {
+		selected.informer.AddEventHandlerWithResyncPeriod(handler, resyncPeriod)
+	}
+}
+
+// AddIndexers adds the indexers to all informers of the kind
+func (k *filteredKind) AddIndexers(indexers toolscache.Indexers) error {
+	for _, selected := range k.informers {
+		if err := selected.informer.AddIndexers(indexers); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	return nil
+}
+
+// HasSynced tells if all informers of the kind have synced
+func (k *filteredKind) HasSynced() bool {
+	for _, selected := range k.informers {
+		if !selected.informer.HasSynced() {
+			return false
+		}
+	}
+	return true
+}
+
+func copyInto(cached runtime.Object, out client.Object, gvk schema.GroupVersionKind) error {
+	outValue := reflect.ValueOf(out)
+	cachedValue := reflect.ValueOf(cached.DeepCopyObject())
+	if outValue.Type() != cachedValue.Type() {
+		return apierrors.NewInternalError(errors.Errorf("cache has %T, got %T", cached, out))
+	}
+	outValue.Elem().Set(cachedValue.Elem())
+	out.GetObjectKind().SetGroupVersionKind(gvk)
+	return nil
+}
@@ -0,0 +1,151 @@
+package cache
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/scheme"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestSelector_covers(t *testing.T) {
+	managed := Selector{Key: constants.LabelAppKey, Value: constants.LabelAppValue}
+	agents := Selector{Key: constants.LabelAgentOfKey}
+
+	assert.True(t, managed.covers(labels.SelectorFromSet(map[string]string{constants.LabelAppKey: constants.LabelAppValue, "jenkins-cr": "jenkins"})))
+	assert.False(t, managed.covers(labels.SelectorFromSet(map[string]string{constants.LabelAppKey: "other"})))
+	assert.False(t, managed.covers(labels.SelectorFromSet(map[string]string{"jenkins-cr": "jenkins"})))
+	assert.False(t, managed.covers(nil))
+	assert.True(t, agents.covers(labels.SelectorFromSet(map[string]string{constants.LabelAgentOfKey: "jenkins"})))
+	assert.Equal(t, "app=jenkins-operator", managed.String())
+	assert.Equal(t, constants.LabelAgentOfKey, agents.String())
+}
+
+func TestStripLastAppliedConfiguration(t *testing.T) {
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+		corev1.LastAppliedConfigAnnotation: "{}",
+		"other":                            "value",
+	}}}
+
+	stripLastAppliedConfiguration(pod)
+
+	assert.Equal(t, map[string]string{"other": "value"}, pod.Annotations)
+}
+
+func TestStripData(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{corev1.LastAppliedConfigAnnotation: "{}"}},
+		Data:       map[string][]byte{"password": []byte("secret")},
+	}
+	configMap := &corev1.ConfigMap{Data: map[string]string{"1-script.groovy": "println 'test'"}, BinaryData: map[string][]byte{"binary": {0}}}
+
+	stripData(secret)
+	stripData(configMap)
+
+	assert.Empty(t, secret.Annotations)
+	assert.Nil(t, secret.Data)
+	assert.Nil(t, configMap.Data)
+	assert.Nil(t, configMap.BinaryData)
+}
+
+func TestFilteredCache_Uncached(t *testing.T) {
+	ctx := context.TODO()
+	managed := Selector{Key: constants.LabelAppKey, Value: constants.LabelAppValue}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "jenkins-operator-credentials", Namespace: "default", Labels: map[string]string{constants.LabelAppKey: constants.LabelAppValue}},
+		Data:       map[string][]byte{"password": []byte("secret")},
+	}
+	stripped := secret.DeepCopy()
+	stripData(stripped)
+
+	kind := &filteredKind{gvk: schema.GroupVersionKind{Version: "v1", Kind: "Secret"}, uncached: true}
+	informer := toolscache.NewSharedIndexInformer(&toolscache.ListWatch{}, &corev1.Secret{}, 0,
+		toolscache.Indexers{toolscache.NamespaceIndex: toolscache.MetaNamespaceIndexFunc})
+	require.NoError(t, informer.GetIndexer().Add(stripped))
+	kind.informers = append(kind.informers, selectedInformer{selector: managed, informer: informer})
+	c := &filteredCache{
+		scheme:    scheme.Scheme,
+		apiReader: fake.NewFakeClientWithScheme(scheme.Scheme, secret.DeepCopy()),
+		kinds:     map[schema.GroupVersionKind]*filteredKind{kind.gvk: kind},
+	}
+
+	t.Run("get", func(t *testing.T) {
+		actual := &corev1.Secret{}
+		require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: "default", Name: secret.Name}, actual))
+		assert.Equal(t, secret.Data, actual.Data)
+	})
+	t.Run("list", func(t *testing.T) {
+		secrets := &corev1.SecretList{}
+		require.NoError(t, c.List(ctx, secrets, client.InNamespace("default"), client.MatchingLabels{constants.LabelAppKey: constants.LabelAppValue}))
+		require.Len(t, secrets.Items, 1)
+		assert.Equal(t, secret.Data, secrets.Items[0].Data)
+	})
+}
+
+func TestFilteredCache(t *testing.T) {
+	ctx := context.TODO()
+	managed := Selector{Key: constants.LabelAppKey, Value: constants.LabelAppValue}
+	agents := Selector{Key: constants.LabelAgentOfKey}
+	masterPod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "jenkins-master", Namespace: "default",
+		Labels: map[string]string{constants.LabelAppKey: constants.LabelAppValue}}}
+	agentPod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "default",
+		Labels: map[string]string{constants.LabelAgentOfKey: "jenkins"}}}
+	otherPod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "default"}}
+
+	kind := &filteredKind{gvk: schema.GroupVersionKind{Version: "v1", Kind: "Pod"}}
+	for _, selector := range []Selector{managed, agents} {
+		informer := toolscache.NewSharedIndexInformer(&toolscache.ListWatch{}, &corev1.Pod{}, 0,
+			toolscache.Indexers{toolscache.NamespaceIndex: toolscache.MetaNamespaceIndexFunc})
+		kind.informers = append(kind.informers, selectedInformer{selector: selector, informer: informer})
+	}
+	require.NoError(t, kind.informers[0].informer.GetIndexer().Add(masterPod))
+	require.NoError(t, kind.informers[1].informer.GetIndexer().Add(agentPod))
+	c := &filteredCache{
+		scheme:    scheme.Scheme,
+		apiReader: fake.NewFakeClientWithScheme(scheme.Scheme, masterPod.DeepCopy(), agentPod.DeepCopy(), otherPod.DeepCopy()),
+		kinds:     map[schema.GroupVersionKind]*filteredKind{kind.gvk: kind},
+	}
+
+	t.Run("get cached pods", func(t *testing.T) {
+		pod := &corev1.Pod{}
+		require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: "default", Name: "agent"}, pod))
+		assert.Equal(t, agentPod.Labels, pod.Labels)
+		assert.Equal(t, "Pod", pod.Kind)
+
+		require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: "default", Name: "jenkins-master"}, pod))
+		assert.Equal(t, masterPod.Labels, pod.Labels)
+	})
+	t.Run("get pod which isn't cached", func(t *testing.T) {
+		pod := &corev1.Pod{}
+		require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: "default", Name: "other"}, pod))
+		assert.Equal(t, "other", pod.Name)
+	})
+	t.Run("list cached pods", func(t *testing.T) {
+		pods := &corev1.PodList{}
+		require.NoError(t, c.List(ctx, pods, client.InNamespace("default"), client.MatchingLabels{constants.LabelAgentOfKey: "jenkins"}))
+		require.Len(t, pods.Items, 1)
+		assert.Equal(t, "agent", pods.Items[0].Name)
+
+		require.NoError(t, c.List(ctx, pods, client.InNamespace("other"), client.MatchingLabels{constants.LabelAgentOfKey: "jenkins"}))
+		assert.Empty(t, pods.Items)
+	})
+	t.Run("list pods which aren't selected", func(t *testing.T) {
+		pods := &corev1.PodList{}
+		require.NoError(t, c.List(ctx, pods, client.InNamespace("default")))
+		assert.Len(t, pods.Items, 3)
+	})
+	t.Run("field index of filtered kind", func(t *testing.T) {
+		err := c.IndexField(ctx, &corev1.Pod{}, "spec.nodeName", func(client.Object) []string { return nil })
+		assert.EqualError(t, err, "field index 'spec.nodeName' isn't supported by filtered cache of Pod")
+	})
+}
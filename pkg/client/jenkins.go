@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/cookiejar"
@@ -8,6 +9,10 @@ import (
 	"strings"
 	"time"
 
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+	"github.com/jenkinsci/kubernetes-operator/pkg/failure"
+	"github.com/jenkinsci/kubernetes-operator/pkg/tracing"
+
 	"github.com/bndr/gojenkins"
 	"github.com/pkg/errors"
 )
@@ -110,10 +115,10 @@ func (j JenkinsAPIConnectionSettings) BuildJenkinsAPIUrl(serviceName string, ser
 	}
 
 	if j.Hostname != "" && j.UseNodePort {
-		return fmt.Sprintf("http://%s:%d", j.Hostname, serviceNodePort)
+		return resources.GetJenkinsURL(j.Hostname, serviceNodePort, "")
 	}
 
-	return fmt.Sprintf("http://%s:%d", j.Hostname, j.Port)
+	return resources.GetJenkinsURL(j.Hostname, int32(j.Port), "")
 }
 
 // Validate validates jenkins API connection settings.
@@ -134,16 +139,18 @@ func (j JenkinsAPIConnectionSettings) Validate() error {
 }
 
 // NewUserAndPasswordAuthorization creates Jenkins API client with user and password authorization.
-func NewUserAndPasswordAuthorization(url, userName, passwordOrToken string) (Jenkins, error) {
-	return newClient(url, userName, passwordOrToken)
+// The span in ctx is the parent of Jenkins API calls spans.
+func NewUserAndPasswordAuthorization(ctx context.Context, url, userName, passwordOrToken string) (Jenkins, error) {
+	return newClient(ctx, url, userName, passwordOrToken)
 }
 
 // NewBearerTokenAuthorization creates Jenkins API client with bearer token authorization.
-func NewBearerTokenAuthorization(url, token string) (Jenkins, error) {
-	return newClient(url, "", token)
+// The span in ctx is the parent of Jenkins API calls spans.
+func NewBearerTokenAuthorization(ctx context.Context, url, token string) (Jenkins, error) {
+	return newClient(ctx, url, "", token)
 }
 
-func newClient(url, userName, passwordOrToken string) (Jenkins, error) {
+func newClient(ctx context.Context, url, userName, passwordOrToken string) (Jenkins, error) {
 	if strings.HasSuffix(url, "/") {
 		url = url[:len(url)-1]
 	}
@@ -167,6 +174,7 @@ func newClient(url, userName, passwordOrToken string) (Jenkins, error) {
 	} else {
 		httpClient.Transport = &setBearerToken{token: passwordOrToken, rt: httpClient.Transport}
 	}
+	httpClient.Transport = tracing.NewTransport(ctx, httpClient.Transport)
 
 	jenkinsClient.Requester = &gojenkins.Requester{
 		Base:      url,
@@ -175,15 +183,15 @@ func newClient(url, userName, passwordOrToken string) (Jenkins, error) {
 		BasicAuth: basicAuth,
 	}
 	if _, err := jenkinsClient.Init(); err != nil {
-		return nil, errors.Wrap(err, "couldn't init Jenkins API client")
+		return nil, failure.Transient(errors.Wrap(err, "couldn't init Jenkins API client"))
 	}
 
 	status, err := jenkinsClient.Poll()
 	if err != nil {
-		return nil, errors.Wrap(err, "couldn't poll data from Jenkins API")
+		return nil, failure.Transient(errors.Wrap(err, "couldn't poll data from Jenkins API"))
 	}
 	if status != http.StatusOK {
-		return nil, errors.Errorf("couldn't poll data from Jenkins API, invalid status code returned: %d", status)
+		return nil, failure.New(failure.ForHTTPStatus(status), errors.Errorf("couldn't poll data from Jenkins API, invalid status code returned: %d", status))
 	}
 
 	return jenkinsClient, nil
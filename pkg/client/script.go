@@ -8,6 +8,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/jenkinsci/kubernetes-operator/pkg/failure"
+
 	"github.com/bndr/gojenkins"
 	"github.com/pkg/errors"
 )
@@ -24,6 +26,11 @@ func (e GroovyScriptExecutionFailed) Error() string {
 	return "script execution failed"
 }
 
+// Category returns the category of the failure, the script has to be fixed in Jenkins CR or the referenced resources
+func (e GroovyScriptExecutionFailed) Category() failure.Category {
+	return failure.CategoryConfigurationInvalid
+}
+
 func (jenkins *jenkins) ExecuteScript(script string) (string, error) {
 	now := time.Now().Unix()
 	verifier := fmt.Sprintf("verifier-%d", now)
@@ -46,12 +53,12 @@ func (jenkins *jenkins) executeScript(script string, verifier string) (string, e
 
 	r, err := jenkins.Requester.Do(ar, &output, nil)
 	if err != nil {
-		return "", errors.Wrapf(err, "couldn't execute groovy script, logs '%s'", output)
+		return "", failure.Transient(errors.Wrapf(err, "couldn't execute groovy script, logs '%s'", output))
 	}
 	defer r.Body.Close()
 
 	if r.StatusCode != http.StatusOK {
-		return output, errors.Errorf("invalid status code '%d', logs '%s'", r.StatusCode, output)
+		return output, failure.New(failure.ForHTTPStatus(r.StatusCode), errors.Errorf("invalid status code '%d', logs '%s'", r.StatusCode, output))
 	}
 
 	if !strings.Contains(output, verifier) {
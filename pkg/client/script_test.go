@@ -7,6 +7,8 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/jenkinsci/kubernetes-operator/pkg/failure"
+
 	"github.com/bndr/gojenkins"
 	"github.com/stretchr/testify/assert"
 )
@@ -64,6 +66,7 @@ func Test_ExecuteScript(t *testing.T) {
 		logs, err := jenkinsClient.executeScript(script, verifier)
 		assert.EqualError(t, err, "script execution failed", logs)
 		assert.Equal(t, response, logs)
+		assert.Equal(t, failure.CategoryConfigurationInvalid, failure.CategoryOf(err))
 	})
 	t.Run("throw 500", func(t *testing.T) {
 		ts := httptest.NewTLSServer(http.HandlerFunc(func(responseWriter http.ResponseWriter, request *http.Request) {
@@ -84,5 +87,6 @@ func Test_ExecuteScript(t *testing.T) {
 
 		logs, err := jenkinsClient.executeScript(script, verifier)
 		assert.EqualError(t, err, "invalid status code '500', logs ''", logs)
+		assert.Equal(t, failure.CategoryTransient, failure.CategoryOf(err))
 	})
 }
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/jenkinsci/kubernetes-operator/pkg/failure"
+
 	"github.com/pkg/errors"
 )
 
@@ -36,7 +38,7 @@ func (jenkins *jenkins) GenerateToken(userName, tokenName string) (*UserToken, e
 	data := map[string]string{"newTokenName": tokenName}
 	r, err := jenkins.Requester.Post(endpoint, nil, token.raw, data)
 	if err != nil {
-		return nil, errors.Wrap(err, "couldn't generate API token")
+		return nil, failure.Transient(errors.Wrap(err, "couldn't generate API token"))
 	}
 	defer r.Body.Close()
 
@@ -48,5 +50,5 @@ func (jenkins *jenkins) GenerateToken(userName, tokenName string) (*UserToken, e
 		return nil, errors.New(token.raw.Status)
 	}
 
-	return nil, errors.Errorf("couldn't generate API token: %d", r.StatusCode)
+	return nil, failure.New(failure.ForHTTPStatus(r.StatusCode), errors.Errorf("couldn't generate API token: %d", r.StatusCode))
 }
@@ -1,21 +1,27 @@
 package backuprestore
 
 import (
+	"bytes"
 	"context"
 	"fmt"
-	"strconv"
 	"strings"
 	"time"
 
 	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/backup"
 	jenkinsclient "github.com/jenkinsci/kubernetes-operator/pkg/client"
 	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
 	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/template"
+	k8sevent "github.com/jenkinsci/kubernetes-operator/pkg/event"
 	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+	"github.com/jenkinsci/kubernetes-operator/pkg/metrics"
+	"github.com/jenkinsci/kubernetes-operator/pkg/probes"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	k8s "sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -72,54 +78,35 @@ func New(configuration configuration.Configuration, logger logr.Logger) *BackupA
 
 // Validate validates backup and restore configuration
 func (bar *BackupAndRestore) Validate() []string {
-	var messages []string
-	allContainers := map[string]v1alpha2.Container{}
-	for _, container := range bar.Configuration.Jenkins.Spec.Master.Containers {
-		allContainers[container.Name] = container
-	}
-
-	restore := bar.Configuration.Jenkins.Spec.Restore
-	if len(restore.ContainerName) > 0 {
-		_, found := allContainers[restore.ContainerName]
-		if !found {
-			messages = append(messages, fmt.Sprintf("restore container '%s' not found in CR spec.master.containers", restore.ContainerName))
-		}
-		if restore.Action.Exec == nil {
-			messages = append(messages, "spec.restore.action.exec is not configured")
-		}
-	}
-
-	backup := bar.Configuration.Jenkins.Spec.Backup
-	if len(backup.ContainerName) > 0 {
-		_, found := allContainers[backup.ContainerName]
-		if !found {
-			messages = append(messages, fmt.Sprintf("backup container '%s' not found in CR spec.master.containers", backup.ContainerName))
-		}
-		if backup.Action.Exec == nil {
-			messages = append(messages, "spec.backup.action.exec is not configured")
-		}
-		if backup.Interval == 0 {
-			messages = append(messages, "spec.backup.interval is not configured")
-		}
+	provider, err := backup.Get(bar.Configuration.Jenkins)
+	if err != nil {
+		return []string{fmt.Sprintf("spec.backup.provider: %s, registered providers are %s", err, strings.Join(backup.Names(), ", "))}
 	}
+	return provider.Validate(bar.Configuration.Jenkins)
+}
 
-	if len(restore.ContainerName) > 0 && len(backup.ContainerName) == 0 {
-		messages = append(messages, "spec.backup.containerName is not configured")
+// target returns Jenkins of the configuration backed up or restored by the provider
+func (bar *BackupAndRestore) target() backup.Target {
+	podName := resources.GetJenkinsMasterPodName(bar.Configuration.Jenkins)
+	return backup.Target{
+		Jenkins: bar.Configuration.Jenkins,
+		Client:  bar.Client,
+		Exec: func(containerName string, command []string) (bytes.Buffer, bytes.Buffer, error) {
+			// backup and restore commands can run longer than the hung reconcile timeout
+			defer probes.Reconciles.LongRunning("backup-restore")()
+			return bar.Exec(podName, containerName, command)
+		},
 	}
-	if len(backup.ContainerName) > 0 && len(restore.ContainerName) == 0 {
-		messages = append(messages, "spec.restore.containerName is not configured")
-	}
-
-	return messages
 }
 
-// helper value indicating no saved backup
-const noBackup = "-1"
-
 // Restore performs Jenkins restore backup operation
 func (bar *BackupAndRestore) Restore(jenkinsClient jenkinsclient.Jenkins) error {
 	jenkins := bar.Configuration.Jenkins
-	if len(jenkins.Spec.Restore.ContainerName) == 0 || jenkins.Spec.Restore.Action.Exec == nil {
+	provider, err := backup.Get(jenkins)
+	if err != nil {
+		return err
+	}
+	if !provider.RestoreConfigured(jenkins) {
 		bar.logger.V(log.VDebug).Info("Skipping restore backup, backup restore not configured")
 		return nil
 	}
@@ -128,7 +115,12 @@ func (bar *BackupAndRestore) Restore(jenkinsClient jenkinsclient.Jenkins) error
 		return nil
 	}
 
-	if jenkins.Status.LastBackup == 0 && jenkins.Spec.Restore.GetLatestAction.Exec == nil {
+	var backupNumber = jenkins.Status.LastBackup
+	latest, supported, err := provider.LatestBackup(bar.target())
+	if err != nil {
+		return err
+	}
+	if !supported && jenkins.Status.LastBackup == 0 && jenkins.Spec.Restore.RecoveryOnce == 0 {
 		bar.logger.V(log.VDebug).Info("Skipping restore backup")
 		if jenkins.Status.PendingBackup == 0 {
 			jenkins.Status.PendingBackup = 1
@@ -137,32 +129,14 @@ func (bar *BackupAndRestore) Restore(jenkinsClient jenkinsclient.Jenkins) error
 		return nil
 	}
 
-	podName := resources.GetJenkinsMasterPodName(jenkins)
-	var backupNumber = jenkins.Status.LastBackup
-
-	if jenkins.Spec.Restore.GetLatestAction.Exec != nil {
-		command := jenkins.Spec.Restore.GetLatestAction.Exec.Command
-		backupNumberRaw, _, err := bar.Exec(podName, jenkins.Spec.Restore.ContainerName, command)
-		if err != nil {
-			return err
-		}
-
-		backupNumberString := strings.TrimSuffix(backupNumberRaw.String(), "\n")
-		if backupNumberString == noBackup {
-			bar.logger.V(log.VDebug).Info("Skipping restore backup, get latest action returned -1")
+	if supported {
+		if latest == 0 {
+			bar.logger.V(log.VDebug).Info("Skipping restore backup, no backup returned by the provider")
 			jenkins.Status.LastBackup = 0
 			jenkins.Status.PendingBackup = 1
 			return bar.Client.Status().Update(context.TODO(), jenkins)
 		}
-
-		backupNumber, err = strconv.ParseUint(backupNumberString, 10, 64)
-		if err != nil {
-			return errors.Wrapf(err, "invalid backup number '%s' returned by get last backup number action", backupNumberString)
-		}
-
-		if backupNumber < 1 {
-			return errors.Errorf("invalid backup number '%d' returned by get last backup number action", backupNumber)
-		}
+		backupNumber = latest
 	} else {
 		bar.logger.V(log.VWarn).Info("spec.restore.getLatestAction not set, you may loose backup history when Jenkins CR status will be clear")
 	}
@@ -171,9 +145,8 @@ func (bar *BackupAndRestore) Restore(jenkinsClient jenkinsclient.Jenkins) error
 		backupNumber = jenkins.Spec.Restore.RecoveryOnce
 	}
 	bar.logger.Info(fmt.Sprintf("Restoring backup '%d'", backupNumber))
-	command := jenkins.Spec.Restore.Action.Exec.Command
-	command = append(command, fmt.Sprintf("%d", backupNumber))
-	_, _, err := bar.Exec(podName, jenkins.Spec.Restore.ContainerName, command)
+	bar.Emitf(k8sevent.TypeNormal, k8sevent.ReasonRestoreStarted, "Restoring backup '%d'", backupNumber)
+	err = provider.Restore(bar.target(), backupNumber)
 
 	if err == nil {
 		_, err := jenkinsClient.ExecuteScript("Jenkins.instance.reload()")
@@ -181,8 +154,9 @@ func (bar *BackupAndRestore) Restore(jenkinsClient jenkinsclient.Jenkins) error
 			return err
 		}
 		//TODO fix me because we're doing two saves unatomically
+		patch := k8s.MergeFrom(jenkins.DeepCopy())
 		jenkins.Spec.Restore.RecoveryOnce = 0
-		err = bar.Client.Update(context.TODO(), jenkins)
+		err = bar.Client.Patch(context.TODO(), jenkins, patch)
 		if err != nil {
 			return err
 		}
@@ -194,48 +168,107 @@ func (bar *BackupAndRestore) Restore(jenkinsClient jenkinsclient.Jenkins) error
 		if err != nil {
 			return err
 		}
+		err = template.Apply(context.TODO(), bar.Client, jenkins)
+		if err != nil {
+			return err
+		}
 		bar.Configuration.Jenkins = jenkins
 
 		jenkins.Status.RestoredBackup = backupNumber
 		jenkins.Status.PendingBackup = backupNumber + 1
+		bar.Emitf(k8sevent.TypeNormal, k8sevent.ReasonRestoreCompleted, "Backup '%d' restored", backupNumber)
 		return bar.Client.Status().Update(context.TODO(), jenkins)
 	}
 
+	bar.Emitf(k8sevent.TypeWarning, k8sevent.ReasonRestoreFailed, "Failed to restore backup '%d': %s", backupNumber, err)
 	return err
 }
 
 // Backup performs Jenkins backup operation
 func (bar *BackupAndRestore) Backup(setBackupDoneBeforePodDeletion bool) error {
 	jenkins := bar.Configuration.Jenkins
-	if len(jenkins.Spec.Backup.ContainerName) == 0 || jenkins.Spec.Backup.Action.Exec == nil {
+	provider, err := backup.Get(jenkins)
+	if err != nil {
+		return err
+	}
+	if !provider.BackupConfigured(jenkins) {
 		bar.logger.V(log.VDebug).Info("Skipping restore backup, backup restore not configured")
 		return nil
 	}
+	if AreBackupsDisabled(jenkins) {
+		bar.logger.V(log.VDebug).Info(fmt.Sprintf("Skipping backup, backups disabled by '%s' label", BackupsDisabledLabelKey))
+		return nil
+	}
 	if jenkins.Status.PendingBackup == jenkins.Status.LastBackup {
 		bar.logger.V(log.VDebug).Info("Skipping backup")
 		return nil
 	}
 	backupNumber := jenkins.Status.PendingBackup
 	bar.logger.Info(fmt.Sprintf("Performing backup '%d'", backupNumber))
-	podName := resources.GetJenkinsMasterPodName(jenkins)
-	command := jenkins.Spec.Backup.Action.Exec.Command
-	command = append(command, fmt.Sprintf("%d", backupNumber))
-	_, _, err := bar.Exec(podName, jenkins.Spec.Backup.ContainerName, command)
+	bar.Emitf(k8sevent.TypeNormal, k8sevent.ReasonBackupTriggered, "Performing backup '%d'", backupNumber)
+	err = provider.Backup(bar.target(), backupNumber)
 
 	if err == nil {
 		bar.logger.V(log.VDebug).Info(fmt.Sprintf("Backup completed '%d', updating status", backupNumber))
 		if jenkins.Status.RestoredBackup == 0 {
 			jenkins.Status.RestoredBackup = backupNumber
 		}
+		backupTime := metav1.Now()
 		jenkins.Status.LastBackup = backupNumber
+		jenkins.Status.LastBackupTime = &backupTime
 		jenkins.Status.PendingBackup = backupNumber
 		jenkins.Status.BackupDoneBeforePodDeletion = setBackupDoneBeforePodDeletion
-		return bar.Client.Status().Update(context.TODO(), jenkins)
+		if err = bar.Client.Status().Update(context.TODO(), jenkins); err != nil {
+			return err
+		}
+		metrics.SetLastBackupTime(jenkins)
+		return nil
 	}
 
+	bar.Emitf(k8sevent.TypeWarning, k8sevent.ReasonBackupFailed, "Failed to perform backup '%d': %s", backupNumber, err)
 	return err
 }
 
+// ScheduleRequestedBackup schedules the backup when jenkins.io/backup-now annotation is set and removes the annotation,
+// the backup is performed by Backup in the same way as the backup triggered by spec.backup.interval
+func (bar *BackupAndRestore) ScheduleRequestedBackup() error {
+	jenkins := bar.Configuration.Jenkins
+	if _, requested := jenkins.Annotations[v1alpha2.BackupNowAnnotation]; !requested {
+		return nil
+	}
+
+	if !backup.IsBackupConfigured(jenkins) {
+		bar.logger.V(log.VWarn).Info(fmt.Sprintf("Ignoring '%s' annotation, backup not configured", v1alpha2.BackupNowAnnotation))
+	} else if AreBackupsDisabled(jenkins) {
+		bar.logger.V(log.VWarn).Info(fmt.Sprintf("Ignoring '%s' annotation, backups disabled by '%s' label", v1alpha2.BackupNowAnnotation, BackupsDisabledLabelKey))
+	} else if jenkins.Status.PendingBackup == jenkins.Status.LastBackup {
+		backupNumber, err := bar.ScheduleBackup()
+		if err != nil {
+			return err
+		}
+		bar.logger.Info(fmt.Sprintf("Backup '%d' requested by '%s' annotation", backupNumber, v1alpha2.BackupNowAnnotation))
+	}
+	return bar.RemoveJenkinsAnnotation(v1alpha2.BackupNowAnnotation)
+}
+
+// ScheduleBackup schedules the pending backup when there is no backup in progress, it returns the number of backup
+// which will be performed by Backup
+func (bar *BackupAndRestore) ScheduleBackup() (uint64, error) {
+	jenkins := bar.Configuration.Jenkins
+	if jenkins.Status.PendingBackup != jenkins.Status.LastBackup {
+		return jenkins.Status.PendingBackup, nil
+	}
+	// update the copy to keep the spec rendered from Jenkins templates
+	updated := jenkins.DeepCopy()
+	updated.Status.PendingBackup++
+	if err := bar.Client.Status().Update(context.TODO(), updated); err != nil {
+		return 0, errors.WithStack(err)
+	}
+	jenkins.Status.PendingBackup = updated.Status.PendingBackup
+	jenkins.ResourceVersion = updated.ResourceVersion
+	return jenkins.Status.PendingBackup, nil
+}
+
 func triggerBackup(ticker *time.Ticker, k8sClient k8s.Client, logger logr.Logger, namespace, name string) {
 	for range ticker.C {
 		jenkins := &v1alpha2.Jenkins{}
@@ -260,7 +293,8 @@ func triggerBackup(ticker *time.Ticker, k8sClient k8s.Client, logger logr.Logger
 func (bar *BackupAndRestore) EnsureBackupTrigger() error {
 	trigger, found := triggers.get(bar.Configuration.Jenkins.Namespace, bar.Configuration.Jenkins.Name)
 
-	isBackupConfigured := len(bar.Configuration.Jenkins.Spec.Backup.ContainerName) > 0 && bar.Configuration.Jenkins.Spec.Backup.Interval > 0
+	isBackupConfigured := backup.IsBackupConfigured(bar.Configuration.Jenkins) && bar.Configuration.Jenkins.Spec.Backup.Interval > 0 &&
+		!AreBackupsDisabled(bar.Configuration.Jenkins)
 	if found && !isBackupConfigured {
 		bar.StopBackupTrigger()
 		return nil
@@ -0,0 +1,87 @@
+package backuprestore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestBackupAndRestore_ScheduleRequestedBackup(t *testing.T) {
+	log.SetupLogger(true)
+	require.NoError(t, v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme))
+	newJenkins := func(annotations map[string]string) *v1alpha2.Jenkins {
+		return &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: "default", Annotations: annotations},
+			Spec: v1alpha2.JenkinsSpec{
+				Backup: v1alpha2.Backup{
+					ContainerName: "backup",
+					Action:        v1alpha2.Handler{Exec: &corev1.ExecAction{Command: []string{"backup.sh"}}},
+				},
+			},
+			Status: v1alpha2.JenkinsStatus{LastBackup: 2, PendingBackup: 2},
+		}
+	}
+	schedule := func(t *testing.T, jenkins *v1alpha2.Jenkins) *v1alpha2.Jenkins {
+		fakeClient := fake.NewClientBuilder().WithObjects(jenkins).Build()
+		bar := New(configuration.Configuration{Client: fakeClient, Jenkins: jenkins, Scheme: scheme.Scheme}, log.Log)
+
+		require.NoError(t, bar.ScheduleRequestedBackup())
+
+		updated := &v1alpha2.Jenkins{}
+		require.NoError(t, fakeClient.Get(context.TODO(), types.NamespacedName{Name: jenkins.Name, Namespace: jenkins.Namespace}, updated))
+		assert.NotContains(t, updated.Annotations, v1alpha2.BackupNowAnnotation)
+		assert.NotContains(t, jenkins.Annotations, v1alpha2.BackupNowAnnotation)
+		return updated
+	}
+	requested := map[string]string{v1alpha2.BackupNowAnnotation: "2021-01-01T00:00:00Z"}
+
+	t.Run("not requested", func(t *testing.T) {
+		updated := schedule(t, newJenkins(nil))
+
+		assert.Equal(t, uint64(2), updated.Status.PendingBackup)
+	})
+	t.Run("requested", func(t *testing.T) {
+		jenkins := newJenkins(requested)
+
+		updated := schedule(t, jenkins)
+
+		assert.Equal(t, uint64(3), updated.Status.PendingBackup)
+		assert.Equal(t, uint64(3), jenkins.Status.PendingBackup)
+		assert.Equal(t, uint64(2), updated.Status.LastBackup)
+	})
+	t.Run("backup in progress", func(t *testing.T) {
+		jenkins := newJenkins(requested)
+		jenkins.Status.PendingBackup = 3
+
+		updated := schedule(t, jenkins)
+
+		assert.Equal(t, uint64(3), updated.Status.PendingBackup)
+	})
+	t.Run("backup not configured", func(t *testing.T) {
+		jenkins := newJenkins(requested)
+		jenkins.Spec.Backup = v1alpha2.Backup{}
+
+		updated := schedule(t, jenkins)
+
+		assert.Equal(t, uint64(2), updated.Status.PendingBackup)
+	})
+	t.Run("backups disabled", func(t *testing.T) {
+		jenkins := newJenkins(requested)
+		jenkins.Labels = map[string]string{BackupsDisabledLabelKey: "true"}
+
+		updated := schedule(t, jenkins)
+
+		assert.Equal(t, uint64(2), updated.Status.PendingBackup)
+	})
+}
@@ -0,0 +1,86 @@
+package backuprestore
+
+import (
+	"fmt"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DefaultRestoreTimeout is the default time in seconds to wait until the backup is restored and Jenkins is ready
+const DefaultRestoreTimeout = uint64(15 * 60)
+
+// GetRestoreTargetName returns name of Jenkins CR where the backup is restored
+func GetRestoreTargetName(restore *v1alpha2.JenkinsRestore) string {
+	if restore.Spec.Restore == nil || len(restore.Spec.Restore.Target.Name) == 0 {
+		return restore.Spec.JenkinsRef.Name
+	}
+	return restore.Spec.Restore.Target.Name
+}
+
+// GetRestoreBackupNumber returns number of backup to restore, the latest backup of the source Jenkins is used if not set
+func GetRestoreBackupNumber(restore *v1alpha2.JenkinsRestore, source *v1alpha2.Jenkins) uint64 {
+	if restore.Spec.Restore == nil || restore.Spec.Restore.BackupNumber == 0 {
+		return source.Status.LastBackup
+	}
+	return restore.Spec.Restore.BackupNumber
+}
+
+// GetRestoreTimeout returns how long the operator waits until the backup is restored and Jenkins is ready
+func GetRestoreTimeout(restore *v1alpha2.JenkinsRestore) uint64 {
+	if restore.Spec.Restore == nil || restore.Spec.Restore.Timeout == 0 {
+		return DefaultRestoreTimeout
+	}
+	return restore.Spec.Restore.Timeout
+}
+
+// NewRestoredJenkins returns Jenkins CR created from the spec of the source Jenkins CR which restores the backup.
+// Backups of the new Jenkins are disabled because they would be stored alongside the backups of the source Jenkins.
+func NewRestoredJenkins(restore *v1alpha2.JenkinsRestore, source *v1alpha2.Jenkins, backupNumber uint64) *v1alpha2.Jenkins {
+	spec := source.Spec.DeepCopy()
+	spec.Restore.RecoveryOnce = backupNumber
+	spec.Service = releaseServiceAddresses(spec.Service)
+	spec.SlaveService = releaseServiceAddresses(spec.SlaveService)
+
+	return &v1alpha2.Jenkins{
+		TypeMeta: v1alpha2.JenkinsTypeMeta(),
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GetRestoreTargetName(restore),
+			Namespace: restore.Namespace,
+			Labels:    map[string]string{BackupsDisabledLabelKey: "true"},
+		},
+		Spec: *spec,
+	}
+}
+
+// IsRestoreCompleted returns true if the backup requested by JenkinsRestore CR has been restored in the target Jenkins
+// and the Jenkins is ready
+func IsRestoreCompleted(restore *v1alpha2.JenkinsRestore, target *v1alpha2.Jenkins) bool {
+	if target.Spec.Restore.RecoveryOnce != 0 || target.Generation <= restore.Status.TargetGeneration {
+		return false
+	}
+	if target.Status.RestoredBackup != restore.Status.BackupNumber {
+		return false
+	}
+	ready := meta.FindStatusCondition(target.Status.Conditions, v1alpha2.JenkinsReadyConditionType)
+	return ready != nil && ready.Status == metav1.ConditionTrue && ready.ObservedGeneration == target.Generation
+}
+
+// GetRestoreProgress returns message describing the progress of the restore in the target Jenkins
+func GetRestoreProgress(restore *v1alpha2.JenkinsRestore, target *v1alpha2.Jenkins) string {
+	if target.Spec.Restore.RecoveryOnce != 0 {
+		if target.Status.BaseConfigurationCompletedTime == nil {
+			return fmt.Sprintf("Waiting for Jenkins '%s' to be provisioned", target.Name)
+		}
+		return fmt.Sprintf("Restoring backup '%d' in Jenkins '%s'", restore.Status.BackupNumber, target.Name)
+	}
+	if target.Status.RestoredBackup != restore.Status.BackupNumber {
+		return fmt.Sprintf("Jenkins '%s' restored backup '%d' instead of '%d'", target.Name, target.Status.RestoredBackup, restore.Status.BackupNumber)
+	}
+	if IsRestoreCompleted(restore, target) {
+		return fmt.Sprintf("Backup '%d' restored in Jenkins '%s'", restore.Status.BackupNumber, target.Name)
+	}
+	return fmt.Sprintf("Backup '%d' restored, waiting for Jenkins '%s' to become ready", restore.Status.BackupNumber, target.Name)
+}
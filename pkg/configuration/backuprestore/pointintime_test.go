@@ -0,0 +1,116 @@
+package backuprestore
+
+import (
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetRestoreTargetName(t *testing.T) {
+	t.Run("defaults to source Jenkins", func(t *testing.T) {
+		restore := &v1alpha2.JenkinsRestore{Spec: v1alpha2.JenkinsRestoreSpec{JenkinsRef: v1alpha2.JenkinsRef{Name: "jenkins"}}}
+
+		assert.Equal(t, "jenkins", GetRestoreTargetName(restore))
+	})
+	t.Run("target set", func(t *testing.T) {
+		restore := &v1alpha2.JenkinsRestore{Spec: v1alpha2.JenkinsRestoreSpec{
+			JenkinsRef: v1alpha2.JenkinsRef{Name: "jenkins"},
+			Restore:    &v1alpha2.RestoreBackup{Target: v1alpha2.RestoreTarget{Name: "restored"}},
+		}}
+
+		assert.Equal(t, "restored", GetRestoreTargetName(restore))
+	})
+}
+
+func TestGetRestoreBackupNumber(t *testing.T) {
+	source := &v1alpha2.Jenkins{Status: v1alpha2.JenkinsStatus{LastBackup: 12}}
+
+	assert.Equal(t, uint64(12), GetRestoreBackupNumber(&v1alpha2.JenkinsRestore{}, source))
+	assert.Equal(t, uint64(5), GetRestoreBackupNumber(&v1alpha2.JenkinsRestore{
+		Spec: v1alpha2.JenkinsRestoreSpec{Restore: &v1alpha2.RestoreBackup{BackupNumber: 5}},
+	}, source))
+}
+
+func TestNewRestoredJenkins(t *testing.T) {
+	restore := &v1alpha2.JenkinsRestore{
+		ObjectMeta: metav1.ObjectMeta{Name: "restore", Namespace: "default"},
+		Spec: v1alpha2.JenkinsRestoreSpec{
+			JenkinsRef: v1alpha2.JenkinsRef{Name: "jenkins"},
+			Restore:    &v1alpha2.RestoreBackup{Target: v1alpha2.RestoreTarget{Name: "restored", Create: true}},
+		},
+	}
+	source := &v1alpha2.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: "default"},
+		Spec: v1alpha2.JenkinsSpec{
+			SeedJobs:     []v1alpha2.SeedJob{{ID: "jenkins-operator"}},
+			Service:      v1alpha2.Service{NodePort: 30303},
+			SlaveService: v1alpha2.Service{NodePort: 30304},
+			Restore:      v1alpha2.Restore{ContainerName: "backup"},
+		},
+	}
+
+	jenkins := NewRestoredJenkins(restore, source, 5)
+
+	assert.Equal(t, "restored", jenkins.Name)
+	assert.Equal(t, "default", jenkins.Namespace)
+	assert.True(t, AreBackupsDisabled(jenkins))
+	assert.False(t, IsVerificationJenkins(jenkins))
+	assert.Equal(t, source.Spec.SeedJobs, jenkins.Spec.SeedJobs)
+	assert.Equal(t, int32(0), jenkins.Spec.Service.NodePort)
+	assert.Equal(t, int32(0), jenkins.Spec.SlaveService.NodePort)
+	assert.Equal(t, "backup", jenkins.Spec.Restore.ContainerName)
+	assert.Equal(t, uint64(5), jenkins.Spec.Restore.RecoveryOnce)
+	assert.Equal(t, uint64(0), source.Spec.Restore.RecoveryOnce)
+}
+
+func TestIsRestoreCompleted(t *testing.T) {
+	restore := &v1alpha2.JenkinsRestore{Status: v1alpha2.JenkinsRestoreStatus{BackupNumber: 5, TargetGeneration: 2}}
+	newTarget := func(generation int64, recoveryOnce, restoredBackup uint64, ready metav1.ConditionStatus) *v1alpha2.Jenkins {
+		return &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Generation: generation},
+			Spec:       v1alpha2.JenkinsSpec{Restore: v1alpha2.Restore{RecoveryOnce: recoveryOnce}},
+			Status: v1alpha2.JenkinsStatus{
+				BaseConfigurationCompletedTime: &metav1.Time{},
+				RestoredBackup:                 restoredBackup,
+				Conditions: []metav1.Condition{{
+					Type:               v1alpha2.JenkinsReadyConditionType,
+					Status:             ready,
+					ObservedGeneration: generation,
+				}},
+			},
+		}
+	}
+
+	t.Run("restore requested", func(t *testing.T) {
+		target := newTarget(2, 5, 3, metav1.ConditionTrue)
+
+		assert.False(t, IsRestoreCompleted(restore, target))
+		assert.Equal(t, "Restoring backup '5' in Jenkins 'jenkins'", GetRestoreProgress(restore, target))
+	})
+	t.Run("backup restored, Jenkins not ready", func(t *testing.T) {
+		target := newTarget(3, 0, 5, metav1.ConditionFalse)
+
+		assert.False(t, IsRestoreCompleted(restore, target))
+		assert.Equal(t, "Backup '5' restored, waiting for Jenkins 'jenkins' to become ready", GetRestoreProgress(restore, target))
+	})
+	t.Run("backup restored, Jenkins ready", func(t *testing.T) {
+		target := newTarget(3, 0, 5, metav1.ConditionTrue)
+
+		assert.True(t, IsRestoreCompleted(restore, target))
+		assert.Equal(t, "Backup '5' restored in Jenkins 'jenkins'", GetRestoreProgress(restore, target))
+	})
+	t.Run("restore request not observed yet", func(t *testing.T) {
+		target := newTarget(2, 0, 5, metav1.ConditionTrue)
+
+		assert.False(t, IsRestoreCompleted(restore, target))
+	})
+	t.Run("Ready condition is outdated", func(t *testing.T) {
+		target := newTarget(3, 0, 5, metav1.ConditionTrue)
+		target.Status.Conditions[0].ObservedGeneration = 2
+
+		assert.False(t, IsRestoreCompleted(restore, target))
+	})
+}
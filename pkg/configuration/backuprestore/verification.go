@@ -0,0 +1,117 @@
+package backuprestore
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// VerificationLabelKey is the label set on temporary Jenkins CR created to verify the backup, the value is
+	// the name of JenkinsRestore CR
+	VerificationLabelKey = "jenkins.io/backup-verification"
+	// BackupsDisabledLabelKey is the label which disables backups of Jenkins CR created by the operator from backup,
+	// the backups are made again when the label is removed
+	BackupsDisabledLabelKey = "jenkins.io/backups-disabled"
+
+	verificationJenkinsSuffix = "verification"
+
+	// DefaultVerificationInterval is the default interval of the backup verification in seconds
+	DefaultVerificationInterval = uint64(24 * 60 * 60)
+	// DefaultVerificationTimeout is the default time in seconds to wait until the temporary Jenkins is ready
+	DefaultVerificationTimeout = uint64(15 * 60)
+)
+
+// AreBackupsDisabled returns true if backups of Jenkins CR are disabled by the BackupsDisabledLabelKey label
+func AreBackupsDisabled(jenkins *v1alpha2.Jenkins) bool {
+	return jenkins.Labels[BackupsDisabledLabelKey] == "true"
+}
+
+// IsVerificationJenkins returns true if Jenkins CR has been created by the operator to verify the backup
+func IsVerificationJenkins(jenkins *v1alpha2.Jenkins) bool {
+	_, ok := jenkins.Labels[VerificationLabelKey]
+	return ok
+}
+
+// GetVerificationJenkinsName returns name of the temporary Jenkins CR used to verify the backup
+func GetVerificationJenkinsName(restore *v1alpha2.JenkinsRestore) string {
+	return fmt.Sprintf("%s-%s", restore.Name, verificationJenkinsSuffix)
+}
+
+// GetVerificationInterval returns how often the backup is verified
+func GetVerificationInterval(restore *v1alpha2.JenkinsRestore) uint64 {
+	if restore.Spec.Verification == nil || restore.Spec.Verification.Interval == nil {
+		return DefaultVerificationInterval
+	}
+	return *restore.Spec.Verification.Interval
+}
+
+// GetVerificationTimeout returns how long the operator waits until the temporary Jenkins is ready
+func GetVerificationTimeout(restore *v1alpha2.JenkinsRestore) uint64 {
+	if restore.Spec.Verification == nil || restore.Spec.Verification.Timeout == 0 {
+		return DefaultVerificationTimeout
+	}
+	return restore.Spec.Verification.Timeout
+}
+
+// IsVerificationDue tells if the next backup verification should be started, if not the time left to the next
+// verification is returned, zero means that the backup won't be verified again
+func IsVerificationDue(restore *v1alpha2.JenkinsRestore, now time.Time) (bool, time.Duration) {
+	last := restore.Status.LastVerification
+	if last == nil {
+		return true, 0
+	}
+	interval := GetVerificationInterval(restore)
+	if interval == 0 {
+		return false, 0
+	}
+
+	next := last.CompletionTime.Add(time.Duration(interval) * time.Second)
+	if !now.Before(next) {
+		return true, 0
+	}
+	return false, next.Sub(now)
+}
+
+// NewVerificationJenkins returns temporary Jenkins CR which restores the backup of the source Jenkins CR.
+// The user configuration, seed jobs and notifications are not copied, so restored jobs are not run and the temporary
+// instance doesn't communicate with the outside world, the backups are not made by the temporary instance.
+// Its Jenkins home claim is deleted with it, so the next verification doesn't mount the restored data again.
+func NewVerificationJenkins(restore *v1alpha2.JenkinsRestore, source *v1alpha2.Jenkins, backupNumber uint64) *v1alpha2.Jenkins {
+	spec := source.Spec.DeepCopy()
+	spec.SeedJobs = nil
+	spec.Notifications = nil
+	spec.GroovyScripts = v1alpha2.GroovyScripts{}
+	spec.ConfigurationAsCode = v1alpha2.ConfigurationAsCode{}
+	spec.Backup.MakeBackupBeforePodDeletion = false
+	spec.Restore.RecoveryOnce = backupNumber
+	spec.Service = releaseServiceAddresses(spec.Service)
+	spec.SlaveService = releaseServiceAddresses(spec.SlaveService)
+	if spec.Persistence != nil {
+		spec.Persistence.ReclaimPolicy = v1alpha2.PersistenceReclaimDelete
+	}
+
+	return &v1alpha2.Jenkins{
+		TypeMeta: v1alpha2.JenkinsTypeMeta(),
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GetVerificationJenkinsName(restore),
+			Namespace: restore.Namespace,
+			Labels: map[string]string{
+				VerificationLabelKey:    restore.Name,
+				BackupsDisabledLabelKey: "true",
+			},
+		},
+		Spec: *spec,
+	}
+}
+
+// releaseServiceAddresses returns service without fixed node port and load balancer IP, they are already allocated by
+// the source Jenkins
+func releaseServiceAddresses(service v1alpha2.Service) v1alpha2.Service {
+	service.NodePort = 0
+	service.LoadBalancerIP = ""
+	return service
+}
@@ -0,0 +1,129 @@
+package backuprestore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewVerificationJenkins(t *testing.T) {
+	restore := &v1alpha2.JenkinsRestore{
+		ObjectMeta: metav1.ObjectMeta{Name: "drill", Namespace: "default"},
+	}
+	source := &v1alpha2.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: "default"},
+		Spec: v1alpha2.JenkinsSpec{
+			Master: v1alpha2.JenkinsMaster{
+				Containers: []v1alpha2.Container{{Name: "jenkins-master"}, {Name: "backup"}},
+			},
+			SeedJobs:      []v1alpha2.SeedJob{{ID: "jenkins-operator"}},
+			Notifications: []v1alpha2.Notification{{Name: "slack"}},
+			Service: v1alpha2.Service{
+				Type:           corev1.ServiceTypeNodePort,
+				Port:           8080,
+				NodePort:       30303,
+				LoadBalancerIP: "10.0.0.1",
+			},
+			Backup: v1alpha2.Backup{
+				ContainerName:               "backup",
+				Interval:                    30,
+				MakeBackupBeforePodDeletion: true,
+			},
+			Persistence: &v1alpha2.Persistence{ReclaimPolicy: v1alpha2.PersistenceReclaimRetain},
+			Restore: v1alpha2.Restore{
+				ContainerName: "backup",
+				RecoveryOnce:  3,
+			},
+			GroovyScripts: v1alpha2.GroovyScripts{
+				Customization: v1alpha2.Customization{Configurations: []v1alpha2.ConfigMapRef{{Name: "groovy"}}},
+			},
+			ConfigurationAsCode: v1alpha2.ConfigurationAsCode{
+				Customization: v1alpha2.Customization{Configurations: []v1alpha2.ConfigMapRef{{Name: "casc"}}},
+			},
+		},
+	}
+
+	jenkins := NewVerificationJenkins(restore, source, 7)
+
+	assert.Equal(t, "drill-verification", jenkins.Name)
+	assert.Equal(t, "default", jenkins.Namespace)
+	assert.True(t, IsVerificationJenkins(jenkins))
+	assert.True(t, AreBackupsDisabled(jenkins))
+	assert.Equal(t, source.Spec.Master, jenkins.Spec.Master)
+	assert.Nil(t, jenkins.Spec.SeedJobs)
+	assert.Nil(t, jenkins.Spec.Notifications)
+	assert.Empty(t, jenkins.Spec.GroovyScripts.Configurations)
+	assert.Empty(t, jenkins.Spec.ConfigurationAsCode.Configurations)
+	assert.Equal(t, corev1.ServiceTypeNodePort, jenkins.Spec.Service.Type)
+	assert.Equal(t, int32(8080), jenkins.Spec.Service.Port)
+	assert.Equal(t, int32(0), jenkins.Spec.Service.NodePort)
+	assert.Empty(t, jenkins.Spec.Service.LoadBalancerIP)
+	assert.Equal(t, "backup", jenkins.Spec.Backup.ContainerName)
+	assert.False(t, jenkins.Spec.Backup.MakeBackupBeforePodDeletion)
+	assert.Equal(t, uint64(7), jenkins.Spec.Restore.RecoveryOnce)
+	assert.Equal(t, v1alpha2.PersistenceReclaimDelete, jenkins.Spec.Persistence.ReclaimPolicy)
+
+	// source CR is not modified
+	assert.Len(t, source.Spec.SeedJobs, 1)
+	assert.Equal(t, int32(30303), source.Spec.Service.NodePort)
+	assert.Equal(t, uint64(3), source.Spec.Restore.RecoveryOnce)
+	assert.Equal(t, v1alpha2.PersistenceReclaimRetain, source.Spec.Persistence.ReclaimPolicy)
+	assert.False(t, IsVerificationJenkins(source))
+}
+
+func TestIsVerificationDue(t *testing.T) {
+	now := time.Now()
+	interval := func(seconds uint64) *uint64 { return &seconds }
+	completedAt := func(ago time.Duration) *v1alpha2.RestoreVerificationResult {
+		return &v1alpha2.RestoreVerificationResult{CompletionTime: metav1.NewTime(now.Add(-ago))}
+	}
+
+	t.Run("never verified", func(t *testing.T) {
+		due, after := IsVerificationDue(&v1alpha2.JenkinsRestore{}, now)
+
+		assert.True(t, due)
+		assert.Equal(t, time.Duration(0), after)
+	})
+	t.Run("default interval not elapsed", func(t *testing.T) {
+		restore := &v1alpha2.JenkinsRestore{Status: v1alpha2.JenkinsRestoreStatus{LastVerification: completedAt(time.Hour)}}
+
+		due, after := IsVerificationDue(restore, now)
+
+		assert.False(t, due)
+		assert.Equal(t, 23*time.Hour, after)
+	})
+	t.Run("interval elapsed", func(t *testing.T) {
+		restore := &v1alpha2.JenkinsRestore{
+			Spec:   v1alpha2.JenkinsRestoreSpec{Verification: &v1alpha2.RestoreVerification{Interval: interval(60)}},
+			Status: v1alpha2.JenkinsRestoreStatus{LastVerification: completedAt(2 * time.Minute)},
+		}
+
+		due, after := IsVerificationDue(restore, now)
+
+		assert.True(t, due)
+		assert.Equal(t, time.Duration(0), after)
+	})
+	t.Run("verify only once", func(t *testing.T) {
+		restore := &v1alpha2.JenkinsRestore{
+			Spec:   v1alpha2.JenkinsRestoreSpec{Verification: &v1alpha2.RestoreVerification{Interval: interval(0)}},
+			Status: v1alpha2.JenkinsRestoreStatus{LastVerification: completedAt(48 * time.Hour)},
+		}
+
+		due, after := IsVerificationDue(restore, now)
+
+		assert.False(t, due)
+		assert.Equal(t, time.Duration(0), after)
+	})
+}
+
+func TestGetVerificationTimeout(t *testing.T) {
+	assert.Equal(t, DefaultVerificationTimeout, GetVerificationTimeout(&v1alpha2.JenkinsRestore{}))
+	assert.Equal(t, uint64(60), GetVerificationTimeout(&v1alpha2.JenkinsRestore{
+		Spec: v1alpha2.JenkinsRestoreSpec{Verification: &v1alpha2.RestoreVerification{Timeout: 60}},
+	}))
+}
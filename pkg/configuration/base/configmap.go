@@ -8,7 +8,7 @@ import (
 )
 
 func (r *JenkinsBaseConfigurationReconciler) createScriptsConfigMap(meta metav1.ObjectMeta) error {
-	configMap, err := resources.NewScriptsConfigMap(meta, r.Configuration.Jenkins)
+	configMap, err := resources.NewScriptsConfigMap(meta, r.Configuration.Jenkins, r.Configuration.BasePluginManifests.Dependencies())
 	if err != nil {
 		return err
 	}
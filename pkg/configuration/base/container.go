@@ -1,9 +1,14 @@
 package base
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	k8sevent "github.com/jenkinsci/kubernetes-operator/pkg/event"
+
+	stackerr "github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 )
 
@@ -91,3 +96,71 @@ func compareContainerResources(expected corev1.ResourceRequirements, actual core
 	}
 	return true
 }
+
+// updateContainerStatuses reports the state of init containers and sidecars from spec.master in Jenkins CR status,
+// the event is emitted when the container fails
+func (r *JenkinsBaseConfigurationReconciler) updateContainerStatuses(pod corev1.Pod) error {
+	jenkins := r.Configuration.Jenkins
+	if len(pod.Status.InitContainerStatuses) == 0 && len(pod.Status.ContainerStatuses) == 0 {
+		// the pod hasn't been started yet, the statuses of the previous pod are kept
+		return nil
+	}
+	statuses := getContainerStatuses(jenkins, pod)
+	if reflect.DeepEqual(statuses, jenkins.Status.Containers) {
+		return nil
+	}
+
+	previousFailures := map[string]string{}
+	for _, status := range jenkins.Status.Containers {
+		previousFailures[status.Name] = status.Failure
+	}
+	for _, status := range statuses {
+		if len(status.Failure) > 0 && status.Failure != previousFailures[status.Name] {
+			message := fmt.Sprintf("%s container '%s' of Jenkins master pod has failed: %s", status.Type, status.Name, status.Failure)
+			r.logger.Info(message)
+			r.Configuration.Emit(k8sevent.TypeWarning, k8sevent.ReasonContainerFailed, message)
+		}
+	}
+
+	jenkins.Status.Containers = statuses
+	return stackerr.WithStack(r.Client.Status().Update(context.TODO(), jenkins))
+}
+
+func getContainerStatuses(jenkins *v1alpha2.Jenkins, pod corev1.Pod) []v1alpha2.ContainerStatus {
+	var statuses []v1alpha2.ContainerStatus
+	for _, container := range jenkins.Spec.Master.InitContainers {
+		statuses = append(statuses, newContainerStatus(container.Name, v1alpha2.InitContainerType, pod.Status.InitContainerStatuses))
+	}
+	if len(jenkins.Spec.Master.Containers) > 1 {
+		for _, container := range jenkins.Spec.Master.Containers[1:] {
+			statuses = append(statuses, newContainerStatus(container.Name, v1alpha2.SidecarContainerType, pod.Status.ContainerStatuses))
+		}
+	}
+	return statuses
+}
+
+func newContainerStatus(name string, containerType v1alpha2.ContainerType, podStatuses []corev1.ContainerStatus) v1alpha2.ContainerStatus {
+	status := v1alpha2.ContainerStatus{Name: name, Type: containerType}
+	for _, podStatus := range podStatuses {
+		if podStatus.Name != name {
+			continue
+		}
+		status.Ready = podStatus.Ready
+		status.RestartCount = podStatus.RestartCount
+		status.Failure = getContainerFailure(podStatus.State)
+	}
+	return status
+}
+
+func getContainerFailure(state corev1.ContainerState) string {
+	if waiting := state.Waiting; waiting != nil && waiting.Reason != "ContainerCreating" && waiting.Reason != "PodInitializing" {
+		if len(waiting.Message) > 0 {
+			return fmt.Sprintf("%s: %s", waiting.Reason, waiting.Message)
+		}
+		return waiting.Reason
+	}
+	if terminated := state.Terminated; terminated != nil && terminated.ExitCode != 0 {
+		return fmt.Sprintf("exited with code %d: %s", terminated.ExitCode, terminated.Reason)
+	}
+	return ""
+}
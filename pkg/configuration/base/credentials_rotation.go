@@ -0,0 +1,201 @@
+package base
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	jenkinsclient "github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+	k8sevent "github.com/jenkinsci/kubernetes-operator/pkg/event"
+
+	stackerr "github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	rotatedTokenNameFmt = "token-%d"
+	rotatedTokenPrefix  = "rotated-token:"
+
+	// credentials secret keys which keep the state of the unfinished rotation, so it can be resumed after the
+	// operator restart or a failed update
+	pendingPasswordKey  = "pendingPassword"
+	pendingTokenNameKey = "pendingTokenName"
+	rotatedTokenNameKey = "rotatedTokenName"
+)
+
+// the password is base64 encoded so it doesn't have to be escaped, the old tokens are valid until the rotation is finished,
+// the token with the same name left by an interrupted rotation is revoked so the script can be run again
+const rotateOperatorCredentialsGroovyScriptFmt = `import hudson.model.User
+import hudson.security.HudsonPrivateSecurityRealm
+import jenkins.security.ApiTokenProperty
+
+def user = User.getById('%s', false)
+if (user == null) {
+	throw new IllegalStateException("User '%s' not found")
+}
+user.addProperty(HudsonPrivateSecurityRealm.Details.fromPlainPassword(new String('%s'.decodeBase64(), 'UTF-8')))
+def property = user.getProperty(ApiTokenProperty.class)
+if (property == null) {
+	property = new ApiTokenProperty()
+	user.addProperty(property)
+}
+def tokenStore = property.getTokenStore()
+tokenStore.getTokenListSortedByName().findAll { it.name == '%s' }.each { tokenStore.revokeToken(it.uuid) }
+def token = tokenStore.generateNewToken('%s')
+user.save()
+println('%s' + token.plainValue)
+`
+
+const revokeOperatorTokensGroovyScriptFmt = `import hudson.model.User
+import jenkins.security.ApiTokenProperty
+
+def user = User.getById('%s', false)
+def tokenStore = user.getProperty(ApiTokenProperty.class).getTokenStore()
+tokenStore.getTokenListSortedByName().findAll { it.name != '%s' }.each { tokenStore.revokeToken(it.uuid) }
+user.save()
+`
+
+// isCredentialsRotationDue tells if the operator user credentials have to be rotated, the first rotation is made
+// the period after Jenkins master pod has been provisioned
+func isCredentialsRotationDue(jenkins *v1alpha2.Jenkins, now time.Time) bool {
+	rotation := jenkins.Spec.JenkinsAPISettings.CredentialsRotation
+	if rotation == nil || jenkins.Spec.JenkinsAPISettings.AuthorizationStrategy != v1alpha2.CreateUserAuthorizationStrategy {
+		return false
+	}
+	last := jenkins.Status.LastCredentialsRotationTime
+	if last == nil {
+		last = jenkins.Status.ProvisionStartTime
+	}
+	if last == nil {
+		return false
+	}
+	return !now.Before(last.Add(time.Duration(rotation.Period) * time.Second))
+}
+
+// rotateOperatorCredentials sets the new password and API token of the operator user in Jenkins and in the operator
+// credentials secret, then it revokes the old tokens. Operator clients are created from the secret so they use
+// the new token since the secret is updated. Every step is recorded in the secret, the unfinished rotation is resumed
+// by the next reconcile.
+func (r *JenkinsBaseConfigurationReconciler) rotateOperatorCredentials(jenkinsClient jenkinsclient.Jenkins) (bool, error) {
+	jenkins := r.Configuration.Jenkins
+	credentialsSecret := &corev1.Secret{}
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: resources.GetOperatorCredentialsSecretName(jenkins), Namespace: jenkins.Namespace}, credentialsSecret)
+	if err != nil {
+		return false, stackerr.WithStack(err)
+	}
+	userName := string(credentialsSecret.Data[resources.OperatorCredentialsSecretUserNameKey])
+
+	_, pending := credentialsSecret.Data[pendingTokenNameKey]
+	_, rotated := credentialsSecret.Data[rotatedTokenNameKey]
+	if !pending && !rotated {
+		if !isCredentialsRotationDue(jenkins, time.Now()) {
+			return false, nil
+		}
+		r.logger.Info("Rotating operator user credentials")
+		credentialsSecret.Data[pendingPasswordKey] = []byte(resources.NewOperatorPassword())
+		credentialsSecret.Data[pendingTokenNameKey] = []byte(fmt.Sprintf(rotatedTokenNameFmt, time.Now().Unix()))
+		if err = r.UpdateResource(credentialsSecret); err != nil {
+			return false, stackerr.WithStack(err)
+		}
+		pending = true
+	} else {
+		r.logger.Info("Resuming operator user credentials rotation")
+	}
+
+	if pending {
+		password := string(credentialsSecret.Data[pendingPasswordKey])
+		tokenName := string(credentialsSecret.Data[pendingTokenNameKey])
+		logs, err := jenkinsClient.ExecuteScript(fmt.Sprintf(rotateOperatorCredentialsGroovyScriptFmt, userName, userName,
+			base64.StdEncoding.EncodeToString([]byte(password)), tokenName, tokenName, rotatedTokenPrefix))
+		if err != nil {
+			return false, stackerr.Wrapf(err, "couldn't rotate operator user credentials, logs '%s'", logs)
+		}
+		token, err := parseRotatedToken(logs)
+		if err != nil {
+			return false, err
+		}
+
+		// the status keeps the rotation time with seconds precision
+		creationTime, _ := time.Now().UTC().Truncate(time.Second).MarshalText()
+		credentialsSecret.Data[resources.OperatorCredentialsSecretPasswordKey] = []byte(password)
+		credentialsSecret.Data[resources.OperatorCredentialsSecretTokenKey] = []byte(token)
+		credentialsSecret.Data[resources.OperatorCredentialsSecretTokenCreationKey] = creationTime
+		credentialsSecret.Data[rotatedTokenNameKey] = []byte(tokenName)
+		delete(credentialsSecret.Data, pendingPasswordKey)
+		delete(credentialsSecret.Data, pendingTokenNameKey)
+		if err = r.UpdateResource(credentialsSecret); err != nil {
+			return false, stackerr.WithStack(err)
+		}
+	}
+
+	if err = r.recordRotatedOperatorCredentials(credentialsSecret); err != nil {
+		return false, err
+	}
+
+	tokenName := string(credentialsSecret.Data[rotatedTokenNameKey])
+	if logs, err := jenkinsClient.ExecuteScript(fmt.Sprintf(revokeOperatorTokensGroovyScriptFmt, userName, tokenName)); err != nil {
+		return false, stackerr.Wrapf(err, "couldn't revoke old operator user tokens, logs '%s'", logs)
+	}
+	delete(credentialsSecret.Data, rotatedTokenNameKey)
+	if err = r.UpdateResource(credentialsSecret); err != nil {
+		return false, stackerr.WithStack(err)
+	}
+
+	message := "Operator user password and API token have been rotated"
+	r.logger.Info(message)
+	r.Configuration.Emit(k8sevent.TypeNormal, k8sevent.ReasonOperatorCredentialsRotated, message)
+	return true, nil
+}
+
+// ensureRotatedOperatorCredentialsRecorded records the credentials of the interrupted rotation in the status before
+// the Jenkins master pod is verified, the changed password would cause the restart of the pod otherwise
+func (r *JenkinsBaseConfigurationReconciler) ensureRotatedOperatorCredentialsRecorded() error {
+	credentialsSecret := &corev1.Secret{}
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: resources.GetOperatorCredentialsSecretName(r.Configuration.Jenkins), Namespace: r.Configuration.Jenkins.Namespace}, credentialsSecret)
+	if apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return stackerr.WithStack(err)
+	}
+	return r.recordRotatedOperatorCredentials(credentialsSecret)
+}
+
+// recordRotatedOperatorCredentials sets the hash of the rotated credentials and the rotation time in the status,
+// the new hash prevents the restart of Jenkins master pod caused by the changed password
+func (r *JenkinsBaseConfigurationReconciler) recordRotatedOperatorCredentials(credentialsSecret *corev1.Secret) error {
+	if _, rotated := credentialsSecret.Data[rotatedTokenNameKey]; !rotated {
+		return nil
+	}
+	jenkins := r.Configuration.Jenkins
+	rotationTime := metav1.Now()
+	if err := rotationTime.UnmarshalText(credentialsSecret.Data[resources.OperatorCredentialsSecretTokenCreationKey]); err != nil {
+		return stackerr.WithStack(err)
+	}
+	if jenkins.Status.LastCredentialsRotationTime != nil && !jenkins.Status.LastCredentialsRotationTime.Before(&rotationTime) {
+		return nil
+	}
+
+	userAndPasswordHash, err := r.calculateUserAndPasswordHash()
+	if err != nil {
+		return err
+	}
+	jenkins.Status.UserAndPasswordHash = userAndPasswordHash
+	jenkins.Status.LastCredentialsRotationTime = &rotationTime
+	return stackerr.WithStack(r.Client.Status().Update(context.TODO(), jenkins))
+}
+
+func parseRotatedToken(logs string) (string, error) {
+	for _, line := range strings.Split(logs, "\n") {
+		if strings.HasPrefix(line, rotatedTokenPrefix) {
+			return strings.TrimSpace(strings.TrimPrefix(line, rotatedTokenPrefix)), nil
+		}
+	}
+	return "", stackerr.New("rotated operator user token not found in the groovy script output")
+}
@@ -0,0 +1,218 @@
+package base
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	jenkinsclient "github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestIsCredentialsRotationDue(t *testing.T) {
+	now := time.Now()
+	newJenkins := func(rotation *v1alpha2.CredentialsRotation, provisionStartTime, lastRotationTime time.Time) *v1alpha2.Jenkins {
+		provisioned := metav1.NewTime(provisionStartTime)
+		jenkins := &v1alpha2.Jenkins{
+			Spec: v1alpha2.JenkinsSpec{
+				JenkinsAPISettings: v1alpha2.JenkinsAPISettings{
+					AuthorizationStrategy: v1alpha2.CreateUserAuthorizationStrategy,
+					CredentialsRotation:   rotation,
+				},
+			},
+			Status: v1alpha2.JenkinsStatus{ProvisionStartTime: &provisioned},
+		}
+		if !lastRotationTime.IsZero() {
+			rotated := metav1.NewTime(lastRotationTime)
+			jenkins.Status.LastCredentialsRotationTime = &rotated
+		}
+		return jenkins
+	}
+	daily := &v1alpha2.CredentialsRotation{Period: 24 * 60 * 60}
+
+	t.Run("disabled", func(t *testing.T) {
+		assert.False(t, isCredentialsRotationDue(newJenkins(nil, now.Add(-48*time.Hour), time.Time{}), now))
+	})
+	t.Run("service account strategy", func(t *testing.T) {
+		jenkins := newJenkins(daily, now.Add(-48*time.Hour), time.Time{})
+		jenkins.Spec.JenkinsAPISettings.AuthorizationStrategy = v1alpha2.ServiceAccountAuthorizationStrategy
+		assert.False(t, isCredentialsRotationDue(jenkins, now))
+	})
+	t.Run("first rotation after provisioning", func(t *testing.T) {
+		assert.False(t, isCredentialsRotationDue(newJenkins(daily, now.Add(-time.Hour), time.Time{}), now))
+		assert.True(t, isCredentialsRotationDue(newJenkins(daily, now.Add(-25*time.Hour), time.Time{}), now))
+	})
+	t.Run("after last rotation", func(t *testing.T) {
+		assert.False(t, isCredentialsRotationDue(newJenkins(daily, now.Add(-48*time.Hour), now.Add(-time.Hour)), now))
+		assert.True(t, isCredentialsRotationDue(newJenkins(daily, now.Add(-48*time.Hour), now.Add(-24*time.Hour)), now))
+	})
+}
+
+func TestRotateOperatorCredentials(t *testing.T) {
+	log.SetupLogger(true)
+	require.NoError(t, v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme))
+	provisioned := metav1.NewTime(time.Now().Add(-2 * time.Hour))
+	jenkins := &v1alpha2.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: defaultNamespace},
+		Spec: v1alpha2.JenkinsSpec{
+			JenkinsAPISettings: v1alpha2.JenkinsAPISettings{
+				AuthorizationStrategy: v1alpha2.CreateUserAuthorizationStrategy,
+				CredentialsRotation:   &v1alpha2.CredentialsRotation{Period: 60 * 60},
+			},
+		},
+		Status: v1alpha2.JenkinsStatus{ProvisionStartTime: &provisioned, UserAndPasswordHash: "old"},
+	}
+	credentialsSecret := resources.NewOperatorCredentialsSecret(resources.NewResourceObjectMeta(jenkins), jenkins)
+	oldPassword := string(credentialsSecret.Data[resources.OperatorCredentialsSecretPasswordKey])
+	credentialsSecret.Data[resources.OperatorCredentialsSecretTokenKey] = []byte("old-token")
+	fakeClient := fake.NewClientBuilder().WithObjects(jenkins, credentialsSecret).Build()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	jenkinsClient := jenkinsclient.NewMockJenkins(ctrl)
+	gomock.InOrder(
+		jenkinsClient.EXPECT().ExecuteScript(gomock.Any()).Return("rotated-token:new-token\nverifier", nil),
+		jenkinsClient.EXPECT().ExecuteScript(gomock.Any()).Return("", nil),
+	)
+	reconciler := New(configuration.Configuration{Client: fakeClient, Jenkins: jenkins, Scheme: scheme.Scheme}, jenkinsclient.JenkinsAPIConnectionSettings{})
+
+	rotated, err := reconciler.rotateOperatorCredentials(jenkinsClient)
+
+	require.NoError(t, err)
+	assert.True(t, rotated)
+	secret := &corev1.Secret{}
+	require.NoError(t, fakeClient.Get(context.TODO(), types.NamespacedName{Name: credentialsSecret.Name, Namespace: defaultNamespace}, secret))
+	assert.Equal(t, "new-token", string(secret.Data[resources.OperatorCredentialsSecretTokenKey]))
+	assert.NotEqual(t, oldPassword, string(secret.Data[resources.OperatorCredentialsSecretPasswordKey]))
+	assert.NotEmpty(t, secret.Data[resources.OperatorCredentialsSecretTokenCreationKey])
+
+	updated := &v1alpha2.Jenkins{}
+	require.NoError(t, fakeClient.Get(context.TODO(), types.NamespacedName{Name: jenkins.Name, Namespace: defaultNamespace}, updated))
+	require.NotNil(t, updated.Status.LastCredentialsRotationTime)
+	expectedHash, err := reconciler.calculateUserAndPasswordHash()
+	require.NoError(t, err)
+	assert.Equal(t, expectedHash, updated.Status.UserAndPasswordHash)
+
+	rotated, err = reconciler.rotateOperatorCredentials(jenkinsClient)
+
+	require.NoError(t, err)
+	assert.False(t, rotated)
+}
+
+func TestResumeOperatorCredentialsRotation(t *testing.T) {
+	log.SetupLogger(true)
+	require.NoError(t, v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme))
+	newJenkins := func() *v1alpha2.Jenkins {
+		provisioned := metav1.NewTime(time.Now().Add(-2 * time.Hour))
+		rotated := metav1.NewTime(time.Now().Add(-time.Minute))
+		return &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: defaultNamespace},
+			Spec: v1alpha2.JenkinsSpec{
+				JenkinsAPISettings: v1alpha2.JenkinsAPISettings{
+					AuthorizationStrategy: v1alpha2.CreateUserAuthorizationStrategy,
+					CredentialsRotation:   &v1alpha2.CredentialsRotation{Period: 60 * 60},
+				},
+			},
+			Status: v1alpha2.JenkinsStatus{ProvisionStartTime: &provisioned, LastCredentialsRotationTime: &rotated, UserAndPasswordHash: "old"},
+		}
+	}
+	getSecret := func(t *testing.T, fakeClient k8sclient.Client, name string) *corev1.Secret {
+		secret := &corev1.Secret{}
+		require.NoError(t, fakeClient.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: defaultNamespace}, secret))
+		return secret
+	}
+
+	t.Run("Jenkins not updated", func(t *testing.T) {
+		jenkins := newJenkins()
+		credentialsSecret := resources.NewOperatorCredentialsSecret(resources.NewResourceObjectMeta(jenkins), jenkins)
+		credentialsSecret.Data[pendingPasswordKey] = []byte("pending-password")
+		credentialsSecret.Data[pendingTokenNameKey] = []byte("token-1")
+		fakeClient := fake.NewClientBuilder().WithObjects(jenkins, credentialsSecret).Build()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		jenkinsClient := jenkinsclient.NewMockJenkins(ctrl)
+		gomock.InOrder(
+			jenkinsClient.EXPECT().ExecuteScript(gomock.All(
+				&containsMatcher{"generateNewToken('token-1')"},
+				&containsMatcher{base64.StdEncoding.EncodeToString([]byte("pending-password"))},
+			)).Return("rotated-token:new-token", nil),
+			jenkinsClient.EXPECT().ExecuteScript(&containsMatcher{"it.name != 'token-1'"}).Return("", nil),
+		)
+		reconciler := New(configuration.Configuration{Client: fakeClient, Jenkins: jenkins, Scheme: scheme.Scheme}, jenkinsclient.JenkinsAPIConnectionSettings{})
+
+		rotated, err := reconciler.rotateOperatorCredentials(jenkinsClient)
+
+		require.NoError(t, err)
+		assert.True(t, rotated)
+		secret := getSecret(t, fakeClient, credentialsSecret.Name)
+		assert.Equal(t, "pending-password", string(secret.Data[resources.OperatorCredentialsSecretPasswordKey]))
+		assert.Equal(t, "new-token", string(secret.Data[resources.OperatorCredentialsSecretTokenKey]))
+		assert.NotContains(t, secret.Data, pendingPasswordKey)
+		assert.NotContains(t, secret.Data, pendingTokenNameKey)
+		assert.NotContains(t, secret.Data, rotatedTokenNameKey)
+		expectedHash, err := reconciler.calculateUserAndPasswordHash()
+		require.NoError(t, err)
+		assert.Equal(t, expectedHash, jenkins.Status.UserAndPasswordHash)
+	})
+	t.Run("status not updated", func(t *testing.T) {
+		jenkins := newJenkins()
+		credentialsSecret := resources.NewOperatorCredentialsSecret(resources.NewResourceObjectMeta(jenkins), jenkins)
+		creationTime, _ := time.Now().UTC().Truncate(time.Second).MarshalText()
+		credentialsSecret.Data[resources.OperatorCredentialsSecretTokenCreationKey] = creationTime
+		credentialsSecret.Data[rotatedTokenNameKey] = []byte("token-1")
+		fakeClient := fake.NewClientBuilder().WithObjects(jenkins, credentialsSecret).Build()
+		reconciler := New(configuration.Configuration{Client: fakeClient, Jenkins: jenkins, Scheme: scheme.Scheme}, jenkinsclient.JenkinsAPIConnectionSettings{})
+
+		err := reconciler.ensureRotatedOperatorCredentialsRecorded()
+
+		require.NoError(t, err)
+		expectedHash, err := reconciler.calculateUserAndPasswordHash()
+		require.NoError(t, err)
+		updated := &v1alpha2.Jenkins{}
+		require.NoError(t, fakeClient.Get(context.TODO(), types.NamespacedName{Name: jenkins.Name, Namespace: defaultNamespace}, updated))
+		assert.Equal(t, expectedHash, updated.Status.UserAndPasswordHash)
+		require.NotNil(t, updated.Status.LastCredentialsRotationTime)
+		assert.Equal(t, string(creationTime), updated.Status.LastCredentialsRotationTime.UTC().Format(time.RFC3339))
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		jenkinsClient := jenkinsclient.NewMockJenkins(ctrl)
+		jenkinsClient.EXPECT().ExecuteScript(&containsMatcher{"it.name != 'token-1'"}).Return("", nil)
+
+		rotated, err := reconciler.rotateOperatorCredentials(jenkinsClient)
+
+		require.NoError(t, err)
+		assert.True(t, rotated)
+		assert.NotContains(t, getSecret(t, fakeClient, credentialsSecret.Name).Data, rotatedTokenNameKey)
+	})
+}
+
+type containsMatcher struct {
+	substring string
+}
+
+func (m *containsMatcher) Matches(x interface{}) bool {
+	s, ok := x.(string)
+	return ok && strings.Contains(s, m.substring)
+}
+
+func (m *containsMatcher) String() string {
+	return fmt.Sprintf("contains '%s'", m.substring)
+}
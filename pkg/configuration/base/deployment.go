@@ -6,6 +6,7 @@ import (
 
 	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
 	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+	k8sevent "github.com/jenkinsci/kubernetes-operator/pkg/event"
 	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
 	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/reason"
 	"github.com/jenkinsci/kubernetes-operator/version"
@@ -13,6 +14,7 @@ import (
 	stackerr "github.com/pkg/errors"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
@@ -38,15 +40,19 @@ func (r *JenkinsBaseConfigurationReconciler) ensureJenkinsDeployment(meta metav1
 			return reconcile.Result{}, stackerr.WithStack(err)
 		}
 
+		// the spec merged with JenkinsTemplate mustn't be written back, so only the changes are patched
+		patch := client.MergeFrom(r.Configuration.Jenkins.DeepCopy())
 		now := metav1.Now()
 		r.Configuration.Jenkins.Status = v1alpha2.JenkinsStatus{
 			OperatorVersion:     version.Version,
 			ProvisionStartTime:  &now,
 			LastBackup:          r.Configuration.Jenkins.Status.LastBackup,
+			LastBackupTime:      r.Configuration.Jenkins.Status.LastBackupTime,
 			PendingBackup:       r.Configuration.Jenkins.Status.LastBackup,
 			UserAndPasswordHash: userAndPasswordHash,
 		}
-		return reconcile.Result{Requeue: true}, r.Client.Update(context.TODO(), r.Configuration.Jenkins)
+		r.Configuration.Emit(k8sevent.TypeNormal, k8sevent.ReasonBaseConfigurationStarted, "Base configuration phase started")
+		return reconcile.Result{Requeue: true}, r.Client.Patch(context.TODO(), r.Configuration.Jenkins, patch)
 	} else if err != nil && !apierrors.IsNotFound(err) {
 		return reconcile.Result{}, stackerr.WithStack(err)
 	}
@@ -0,0 +1,46 @@
+package base
+
+import (
+	"context"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+
+	stackerr "github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ensureSlaveService creates the Jenkins slave service targeting the TCP agent listener, the service is deleted when
+// the listener is disabled by spec.inboundAgents.disableTCP
+func (r *JenkinsBaseConfigurationReconciler) ensureSlaveService(meta metav1.ObjectMeta) error {
+	jenkins := r.Configuration.Jenkins
+	name := resources.GetJenkinsSlavesServiceName(jenkins)
+	if resources.IsTCPAgentListenerDisabled(jenkins) {
+		service := &corev1.Service{}
+		service.Name = name
+		service.Namespace = meta.Namespace
+		if err := r.Client.Delete(context.TODO(), service); err != nil && !apierrors.IsNotFound(err) {
+			return stackerr.WithStack(err)
+		}
+		return nil
+	}
+	return r.createService(meta, name, jenkins.Spec.SlaveService, resources.GetInboundAgentsTCPPort(jenkins))
+}
+
+// ensureInboundAgentsNetworkPolicy creates the NetworkPolicy of Jenkins master pod from spec.inboundAgents.networkPolicy,
+// the NetworkPolicy is deleted when it isn't set
+func (r *JenkinsBaseConfigurationReconciler) ensureInboundAgentsNetworkPolicy(meta metav1.ObjectMeta) error {
+	jenkins := r.Configuration.Jenkins
+	if jenkins.Spec.InboundAgents == nil || jenkins.Spec.InboundAgents.NetworkPolicy == nil {
+		networkPolicy := &networkingv1.NetworkPolicy{}
+		networkPolicy.Name = resources.GetInboundAgentsNetworkPolicyName(jenkins)
+		networkPolicy.Namespace = meta.Namespace
+		if err := r.Client.Delete(context.TODO(), networkPolicy); err != nil && !apierrors.IsNotFound(err) {
+			return stackerr.WithStack(err)
+		}
+		return nil
+	}
+	return stackerr.WithStack(r.CreateOrUpdateResource(resources.NewInboundAgentsNetworkPolicy(meta, jenkins)))
+}
@@ -0,0 +1,75 @@
+package base
+
+import (
+	"context"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+
+	stackerr "github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ensureMonitoring creates PrometheusRule and Grafana dashboard ConfigMap when spec.monitoring.enabled is set
+// and deletes them otherwise
+func (r *JenkinsBaseConfigurationReconciler) ensureMonitoring(metaObject metav1.ObjectMeta) error {
+	if !resources.IsMonitoringEnabled(r.Configuration.Jenkins) {
+		return r.deleteMonitoring()
+	}
+
+	if err := r.ensurePrometheusRule(metaObject); err != nil {
+		return err
+	}
+	configMap, err := resources.NewGrafanaDashboardConfigMap(metaObject, r.Configuration.Jenkins)
+	if err != nil {
+		return err
+	}
+	return stackerr.WithStack(r.CreateOrUpdateResource(configMap))
+}
+
+// ensurePrometheusRule creates or updates PrometheusRule, it's skipped when Prometheus Operator isn't installed in the cluster
+func (r *JenkinsBaseConfigurationReconciler) ensurePrometheusRule(metaObject metav1.ObjectMeta) error {
+	expected := resources.NewPrometheusRule(metaObject, r.Configuration.Jenkins)
+	current := &unstructured.Unstructured{}
+	current.SetGroupVersionKind(resources.PrometheusRuleGroupVersionKind)
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: expected.GetName(), Namespace: expected.GetNamespace()}, current)
+	if meta.IsNoMatchError(err) {
+		r.logger.V(log.VWarn).Info("PrometheusRule API isn't available in the cluster, install Prometheus Operator to create alerts of Jenkins")
+		return nil
+	} else if apierrors.IsNotFound(err) {
+		return stackerr.WithStack(r.CreateResource(expected))
+	} else if err != nil {
+		return stackerr.WithStack(err)
+	}
+
+	// custom resources can't be updated without the resource version
+	expected.SetResourceVersion(current.GetResourceVersion())
+	return stackerr.WithStack(r.UpdateResource(expected))
+}
+
+// deleteMonitoring deletes the monitoring resources, they are created together so PrometheusRule is looked up
+// only when the dashboard ConfigMap exists
+func (r *JenkinsBaseConfigurationReconciler) deleteMonitoring() error {
+	jenkins := r.Configuration.Jenkins
+	configMap := &corev1.ConfigMap{}
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: resources.GetGrafanaDashboardConfigMapName(jenkins), Namespace: jenkins.Namespace}, configMap)
+	if err != nil {
+		return stackerr.WithStack(client.IgnoreNotFound(err))
+	}
+	r.logger.Info("Deleting Jenkins monitoring resources, spec.monitoring.enabled is unset")
+
+	rule := &unstructured.Unstructured{}
+	rule.SetGroupVersionKind(resources.PrometheusRuleGroupVersionKind)
+	rule.SetName(resources.GetPrometheusRuleName(jenkins))
+	rule.SetNamespace(jenkins.Namespace)
+	if err = r.Client.Delete(context.TODO(), rule); err != nil && !meta.IsNoMatchError(err) && !apierrors.IsNotFound(err) {
+		return stackerr.WithStack(err)
+	}
+	return stackerr.WithStack(client.IgnoreNotFound(r.Client.Delete(context.TODO(), configMap)))
+}
@@ -0,0 +1,52 @@
+package base
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestEnsureMonitoring(t *testing.T) {
+	require.NoError(t, v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme))
+	jenkins := &v1alpha2.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: defaultNamespace},
+		Spec:       v1alpha2.JenkinsSpec{Monitoring: &v1alpha2.Monitoring{Enabled: true}},
+	}
+	fakeClient := fake.NewClientBuilder().WithObjects(jenkins).Build()
+	reconciler := New(configuration.Configuration{Client: fakeClient, Jenkins: jenkins, Scheme: scheme.Scheme}, client.JenkinsAPIConnectionSettings{})
+	metaObject := resources.NewResourceObjectMeta(jenkins)
+	getRule := func() error {
+		rule := &unstructured.Unstructured{}
+		rule.SetGroupVersionKind(resources.PrometheusRuleGroupVersionKind)
+		return fakeClient.Get(context.TODO(), types.NamespacedName{Name: resources.GetPrometheusRuleName(jenkins), Namespace: defaultNamespace}, rule)
+	}
+	getConfigMap := func() error {
+		return fakeClient.Get(context.TODO(), types.NamespacedName{Name: resources.GetGrafanaDashboardConfigMapName(jenkins), Namespace: defaultNamespace}, &corev1.ConfigMap{})
+	}
+
+	require.NoError(t, reconciler.ensureMonitoring(metaObject))
+	require.NoError(t, reconciler.ensureMonitoring(metaObject))
+
+	assert.NoError(t, getRule())
+	assert.NoError(t, getConfigMap())
+
+	jenkins.Spec.Monitoring.Enabled = false
+	require.NoError(t, reconciler.ensureMonitoring(metaObject))
+
+	assert.True(t, apierrors.IsNotFound(getRule()))
+	assert.True(t, apierrors.IsNotFound(getConfigMap()))
+}
@@ -0,0 +1,344 @@
+package base
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
+	k8sevent "github.com/jenkinsci/kubernetes-operator/pkg/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/reason"
+
+	stackerr "github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const migrationCheckInterval = 5 * time.Second
+
+// ensurePersistence creates Jenkins home PersistentVolumeClaim, expands it when spec.persistence.size grows
+// and migrates Jenkins home when spec.persistence.storageClassName changes
+func (r *JenkinsBaseConfigurationReconciler) ensurePersistence() (reconcile.Result, error) {
+	jenkins := r.Configuration.Jenkins
+	if jenkins.Spec.Persistence == nil {
+		// the claim isn't deleted, so Jenkins home can be mounted again
+		if jenkins.Status.Persistence == nil {
+			return reconcile.Result{}, nil
+		}
+		jenkins.Status.Persistence = nil
+		return reconcile.Result{}, stackerr.WithStack(r.Client.Status().Update(context.TODO(), jenkins))
+	}
+
+	status := &v1alpha2.PersistenceStatus{}
+	if jenkins.Status.Persistence != nil {
+		status = jenkins.Status.Persistence.DeepCopy()
+	}
+
+	claimName, err := r.getActiveJenkinsHomeClaimName()
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	claim, err := r.ensureJenkinsHomeClaim(claimName, jenkins.Spec.Persistence.StorageClassName)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if err = r.setJenkinsHomeClaimActive(claim, true); err != nil {
+		return reconcile.Result{}, err
+	}
+	status.ClaimName = claim.Name
+	if claim.Spec.StorageClassName != nil {
+		status.StorageClassName = *claim.Spec.StorageClassName
+	}
+	if capacity, ok := claim.Status.Capacity[corev1.ResourceStorage]; ok {
+		status.Capacity = &capacity
+	}
+
+	if err = r.expandJenkinsHomeClaim(claim, status); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	result, err := r.ensurePersistenceMigration(claim, status)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if !reflect.DeepEqual(jenkins.Status.Persistence, status) {
+		jenkins.Status.Persistence = status
+		if err = r.Client.Status().Update(context.TODO(), jenkins); err != nil {
+			return reconcile.Result{}, stackerr.WithStack(err)
+		}
+	}
+	return result, nil
+}
+
+// getActiveJenkinsHomeClaimName returns the name of the claim labeled as active, the claim from status.persistence
+// or the default one are used when no claim is labeled, e.g. they've been created by older versions of the operator
+func (r *JenkinsBaseConfigurationReconciler) getActiveJenkinsHomeClaimName() (string, error) {
+	jenkins := r.Configuration.Jenkins
+	labels := resources.BuildResourceLabels(jenkins)
+	labels[constants.LabelJenkinsHomeActiveKey] = constants.LabelJenkinsHomeActiveValue
+	claims := &corev1.PersistentVolumeClaimList{}
+	if err := r.Client.List(context.TODO(), claims, client.InNamespace(jenkins.Namespace), client.MatchingLabels(labels)); err != nil {
+		return "", stackerr.WithStack(err)
+	}
+	if len(claims.Items) == 0 {
+		return resources.GetJenkinsHomeClaimName(jenkins), nil
+	}
+	// the migration labels the new claim before it removes the label from the old one
+	sort.Slice(claims.Items, func(i, j int) bool {
+		if !claims.Items[i].CreationTimestamp.Equal(&claims.Items[j].CreationTimestamp) {
+			return claims.Items[j].CreationTimestamp.Before(&claims.Items[i].CreationTimestamp)
+		}
+		return claims.Items[i].Name > claims.Items[j].Name
+	})
+	return claims.Items[0].Name, nil
+}
+
+// setJenkinsHomeClaimActive adds or removes the label of the claim mounted as Jenkins home
+func (r *JenkinsBaseConfigurationReconciler) setJenkinsHomeClaimActive(claim *corev1.PersistentVolumeClaim, active bool) error {
+	if _, labeled := claim.Labels[constants.LabelJenkinsHomeActiveKey]; labeled == active {
+		return nil
+	}
+	if active {
+		if claim.Labels == nil {
+			claim.Labels = map[string]string{}
+		}
+		claim.Labels[constants.LabelJenkinsHomeActiveKey] = constants.LabelJenkinsHomeActiveValue
+	} else {
+		delete(claim.Labels, constants.LabelJenkinsHomeActiveKey)
+	}
+	return stackerr.WithStack(r.Client.Update(context.TODO(), claim))
+}
+
+// ensureJenkinsHomeClaim creates the claim without the owner reference, so Jenkins home survives deletion of Jenkins CR
+func (r *JenkinsBaseConfigurationReconciler) ensureJenkinsHomeClaim(name string, storageClassName *string) (*corev1.PersistentVolumeClaim, error) {
+	jenkins := r.Configuration.Jenkins
+	claim := &corev1.PersistentVolumeClaim{}
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: jenkins.Namespace}, claim)
+	if err == nil {
+		return claim, nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return nil, stackerr.WithStack(err)
+	}
+
+	claim = resources.NewJenkinsHomePersistentVolumeClaim(jenkins, name, storageClassName)
+	r.logger.Info(fmt.Sprintf("Creating a new Jenkins home PersistentVolumeClaim %s/%s", claim.Namespace, claim.Name))
+	if err = r.Client.Create(context.TODO(), claim); err != nil {
+		return nil, stackerr.WithStack(err)
+	}
+	return claim, nil
+}
+
+// expandJenkinsHomeClaim requests the new size of the claim, the rejected expansion is reported in the status
+// and it doesn't stop the reconcile loop because Jenkins can still run with the current size
+func (r *JenkinsBaseConfigurationReconciler) expandJenkinsHomeClaim(claim *corev1.PersistentVolumeClaim, status *v1alpha2.PersistenceStatus) error {
+	size := r.Configuration.Jenkins.Spec.Persistence.Size
+	requested := claim.Spec.Resources.Requests[corev1.ResourceStorage]
+	if size.Cmp(requested) <= 0 {
+		status.Message = ""
+		return nil
+	}
+
+	expanded := claim.DeepCopy()
+	if expanded.Spec.Resources.Requests == nil {
+		expanded.Spec.Resources.Requests = corev1.ResourceList{}
+	}
+	expanded.Spec.Resources.Requests[corev1.ResourceStorage] = size
+	err := r.Client.Update(context.TODO(), expanded)
+	if apierrors.IsConflict(err) {
+		return stackerr.WithStack(err)
+	}
+	if err != nil {
+		message := fmt.Sprintf("Failed to expand PersistentVolumeClaim '%s' from %s to %s: %s", claim.Name, requested.String(), size.String(), err)
+		if status.Message != message {
+			r.logger.V(log.VWarn).Info(message)
+			r.Configuration.Emit(k8sevent.TypeWarning, k8sevent.ReasonPersistentVolumeClaimExpansionFailed, message)
+		}
+		status.Message = message
+		return nil
+	}
+
+	*claim = *expanded
+	status.Message = ""
+	message := fmt.Sprintf("PersistentVolumeClaim '%s' has been expanded from %s to %s", claim.Name, requested.String(), size.String())
+	r.logger.Info(message)
+	r.Configuration.Emit(k8sevent.TypeNormal, k8sevent.ReasonPersistentVolumeClaimExpanded, message)
+	return nil
+}
+
+// ensurePersistenceMigration copies Jenkins home to the claim with the new storage class, Jenkins is stopped during the copy
+// and it's started with the new claim when the copy succeeds, the old claim is kept
+func (r *JenkinsBaseConfigurationReconciler) ensurePersistenceMigration(claim *corev1.PersistentVolumeClaim, status *v1alpha2.PersistenceStatus) (reconcile.Result, error) {
+	jenkins := r.Configuration.Jenkins
+	storageClassName := jenkins.Spec.Persistence.StorageClassName
+
+	if status.Migration != nil && (storageClassName == nil || status.Migration.StorageClassName != *storageClassName) {
+		r.logger.Info(fmt.Sprintf("Storage class has changed, cancelling the migration to '%s'", status.Migration.StorageClassName))
+		if err := r.deleteMigrationPod(); err != nil {
+			return reconcile.Result{}, err
+		}
+		status.Migration = nil
+	}
+	if storageClassName == nil || *storageClassName == status.StorageClassName {
+		return reconcile.Result{}, r.Configuration.ResolveDeferredAction(v1alpha2.DisruptiveActionStorageMigration)
+	}
+
+	if status.Migration == nil {
+		message := fmt.Sprintf("Storage class has changed from '%s' to '%s'", status.StorageClassName, *storageClassName)
+		if deferred, err := r.Configuration.DeferDisruptiveAction(v1alpha2.DisruptiveActionStorageMigration, message); err != nil || deferred {
+			return reconcile.Result{}, err
+		}
+		targetClaimName := resources.GetJenkinsHomeMigrationClaimName(jenkins, *storageClassName)
+		if _, err := r.ensureJenkinsHomeClaim(targetClaimName, storageClassName); err != nil {
+			return reconcile.Result{}, err
+		}
+		now := metav1.Now()
+		status.Migration = &v1alpha2.PersistenceMigration{
+			TargetClaimName:  targetClaimName,
+			StorageClassName: *storageClassName,
+			Phase:            v1alpha2.PersistenceMigrationCopying,
+			StartTime:        &now,
+		}
+		message = fmt.Sprintf("Migrating Jenkins home from storage class '%s' to '%s'", status.StorageClassName, *storageClassName)
+		r.logger.Info(message)
+		r.Configuration.Emit(k8sevent.TypeNormal, k8sevent.ReasonPersistenceMigrationStarted, message)
+	}
+	migration := status.Migration
+
+	migrationPod := &corev1.Pod{}
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: resources.GetJenkinsHomeMigrationPodName(jenkins), Namespace: jenkins.Namespace}, migrationPod)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return reconcile.Result{}, stackerr.WithStack(err)
+	}
+	migrationPodExists := err == nil
+
+	snapshotRequired := len(jenkins.Spec.Persistence.Migration.VolumeSnapshotClassName) > 0 && len(migration.SnapshotName) == 0
+	if migration.Phase == v1alpha2.PersistenceMigrationFailed {
+		// Jenkins runs with the old claim until the failed migration pod is deleted or the snapshot is disabled
+		if migrationPodExists || snapshotRequired {
+			return reconcile.Result{}, nil
+		}
+		r.logger.Info(fmt.Sprintf("Retrying the migration of Jenkins home to storage class '%s'", migration.StorageClassName))
+		migration.Phase = v1alpha2.PersistenceMigrationCopying
+		migration.Message = ""
+	}
+
+	stopped, err := r.stopJenkinsForMigration(migration)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if !stopped {
+		return reconcile.Result{Requeue: true, RequeueAfter: migrationCheckInterval}, nil
+	}
+
+	if snapshotRequired {
+		snapshotName := fmt.Sprintf("%s-%d", claim.Name, time.Now().Unix())
+		snapshot := resources.NewJenkinsHomeVolumeSnapshot(jenkins, snapshotName, claim.Name, jenkins.Spec.Persistence.Migration.VolumeSnapshotClassName)
+		if err = r.Client.Create(context.TODO(), snapshot); err != nil {
+			if meta.IsNoMatchError(err) {
+				r.failPersistenceMigration(migration, "VolumeSnapshot API isn't available in the cluster, remove spec.persistence.migration.volumeSnapshotClassName to migrate without the snapshot")
+				return reconcile.Result{}, nil
+			}
+			return reconcile.Result{}, stackerr.WithStack(err)
+		}
+		r.logger.Info(fmt.Sprintf("VolumeSnapshot '%s' of PersistentVolumeClaim '%s' has been created", snapshotName, claim.Name))
+		migration.SnapshotName = snapshotName
+	}
+
+	if !migrationPodExists {
+		migrationPod = resources.NewJenkinsHomeMigrationPod(jenkins, claim.Name, migration.TargetClaimName)
+		r.logger.Info(fmt.Sprintf("Creating Jenkins home migration pod %s/%s", migrationPod.Namespace, migrationPod.Name))
+		if err = r.CreateResource(migrationPod); err != nil {
+			return reconcile.Result{}, stackerr.WithStack(err)
+		}
+		return reconcile.Result{Requeue: true, RequeueAfter: migrationCheckInterval}, nil
+	}
+
+	switch migrationPod.Status.Phase {
+	case corev1.PodSucceeded:
+		targetClaim := &corev1.PersistentVolumeClaim{}
+		if err = r.Client.Get(context.TODO(), types.NamespacedName{Name: migration.TargetClaimName, Namespace: jenkins.Namespace}, targetClaim); err != nil {
+			return reconcile.Result{}, stackerr.WithStack(err)
+		}
+		if err = r.setJenkinsHomeClaimActive(targetClaim, true); err != nil {
+			return reconcile.Result{}, err
+		}
+		if err = r.setJenkinsHomeClaimActive(claim, false); err != nil {
+			return reconcile.Result{}, err
+		}
+		if err = r.deleteMigrationPod(); err != nil {
+			return reconcile.Result{}, err
+		}
+		message := fmt.Sprintf("Jenkins home has been migrated from PersistentVolumeClaim '%s' to '%s', the old claim has been kept",
+			claim.Name, migration.TargetClaimName)
+		status.ClaimName = migration.TargetClaimName
+		status.StorageClassName = migration.StorageClassName
+		status.Capacity = nil
+		status.Migration = nil
+		r.logger.Info(message)
+		r.Configuration.Emit(k8sevent.TypeNormal, k8sevent.ReasonPersistenceMigrationCompleted, message)
+		return reconcile.Result{Requeue: true}, nil
+	case corev1.PodFailed:
+		r.failPersistenceMigration(migration, fmt.Sprintf("Migration pod '%s' has failed, delete it to retry the migration", migrationPod.Name))
+		return reconcile.Result{}, nil
+	default:
+		return reconcile.Result{Requeue: true, RequeueAfter: migrationCheckInterval}, nil
+	}
+}
+
+// stopJenkinsForMigration deletes Jenkins master pod, it returns true when the pod doesn't exist
+func (r *JenkinsBaseConfigurationReconciler) stopJenkinsForMigration(migration *v1alpha2.PersistenceMigration) (bool, error) {
+	if useStatefulSetForJenkinsMaster(r.Configuration.Jenkins) {
+		// the StatefulSet would create the deleted pod again
+		if err := r.stopJenkinsMasterStatefulSet(); err != nil {
+			return false, err
+		}
+	}
+	jenkinsMasterPod, err := r.Configuration.GetJenkinsMasterPod()
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, stackerr.WithStack(err)
+	}
+	if r.IsJenkinsTerminating(*jenkinsMasterPod) {
+		return false, nil
+	}
+
+	message := fmt.Sprintf("Jenkins home is being migrated to storage class '%s'", migration.StorageClassName)
+	r.logger.Info(message)
+	return false, r.Configuration.RestartJenkinsMasterPod(reason.NewPodRestart(reason.OperatorSource, []string{message}))
+}
+
+func (r *JenkinsBaseConfigurationReconciler) failPersistenceMigration(migration *v1alpha2.PersistenceMigration, message string) {
+	migration.Phase = v1alpha2.PersistenceMigrationFailed
+	migration.Message = message
+	message = fmt.Sprintf("Migration of Jenkins home to storage class '%s' has failed, Jenkins is started with the old claim: %s", migration.StorageClassName, message)
+	r.logger.V(log.VWarn).Info(message)
+	r.Configuration.Emit(k8sevent.TypeWarning, k8sevent.ReasonPersistenceMigrationFailed, message)
+}
+
+func (r *JenkinsBaseConfigurationReconciler) deleteMigrationPod() error {
+	migrationPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      resources.GetJenkinsHomeMigrationPodName(r.Configuration.Jenkins),
+			Namespace: r.Configuration.Jenkins.Namespace,
+		},
+	}
+	err := r.Client.Delete(context.TODO(), migrationPod)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return stackerr.WithStack(err)
+	}
+	return nil
+}
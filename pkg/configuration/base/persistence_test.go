@@ -0,0 +1,172 @@
+package base
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestEnsurePersistence(t *testing.T) {
+	log.SetupLogger(true)
+	require.NoError(t, v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme))
+	storageClassName := "standard"
+	newJenkins := func(size string) *v1alpha2.Jenkins {
+		return &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: defaultNamespace},
+			Spec: v1alpha2.JenkinsSpec{
+				Master: v1alpha2.JenkinsMaster{
+					Containers: []v1alpha2.Container{{Name: resources.JenkinsMasterContainerName, Image: "jenkins/jenkins:lts"}},
+				},
+				Persistence: &v1alpha2.Persistence{
+					Size:             resource.MustParse(size),
+					StorageClassName: &storageClassName,
+				},
+			},
+		}
+	}
+	getClaim := func(t *testing.T, reconciler *JenkinsBaseConfigurationReconciler) *corev1.PersistentVolumeClaim {
+		claim := &corev1.PersistentVolumeClaim{}
+		err := reconciler.Client.Get(context.TODO(), types.NamespacedName{Name: "jenkins-operator-home-jenkins", Namespace: defaultNamespace}, claim)
+		require.NoError(t, err)
+		return claim
+	}
+
+	t.Run("creates claim", func(t *testing.T) {
+		jenkins := newJenkins("10Gi")
+		reconciler := New(configuration.Configuration{
+			Client:  fake.NewClientBuilder().WithObjects(jenkins).Build(),
+			Jenkins: jenkins,
+		}, client.JenkinsAPIConnectionSettings{})
+
+		result, err := reconciler.ensurePersistence()
+
+		require.NoError(t, err)
+		assert.False(t, result.Requeue)
+		claim := getClaim(t, reconciler)
+		assert.Equal(t, resource.MustParse("10Gi"), claim.Spec.Resources.Requests[corev1.ResourceStorage])
+		assert.Empty(t, claim.OwnerReferences)
+		assert.Equal(t, constants.LabelJenkinsHomeActiveValue, claim.Labels[constants.LabelJenkinsHomeActiveKey])
+		assert.Equal(t, &v1alpha2.PersistenceStatus{ClaimName: claim.Name, StorageClassName: storageClassName}, jenkins.Status.Persistence)
+	})
+	t.Run("expands claim", func(t *testing.T) {
+		jenkins := newJenkins("20Gi")
+		claim := resources.NewJenkinsHomePersistentVolumeClaim(newJenkins("10Gi"), resources.GetJenkinsHomeClaimName(jenkins), &storageClassName)
+		reconciler := New(configuration.Configuration{
+			Client:  fake.NewClientBuilder().WithObjects(jenkins, claim).Build(),
+			Jenkins: jenkins,
+		}, client.JenkinsAPIConnectionSettings{})
+
+		_, err := reconciler.ensurePersistence()
+
+		require.NoError(t, err)
+		assert.Equal(t, resource.MustParse("20Gi"), getClaim(t, reconciler).Spec.Resources.Requests[corev1.ResourceStorage])
+		assert.Empty(t, jenkins.Status.Persistence.Message)
+	})
+	t.Run("expands claim without requests", func(t *testing.T) {
+		jenkins := newJenkins("20Gi")
+		claim := resources.NewJenkinsHomePersistentVolumeClaim(newJenkins("10Gi"), resources.GetJenkinsHomeClaimName(jenkins), &storageClassName)
+		claim.Spec.Resources.Requests = nil
+		reconciler := New(configuration.Configuration{
+			Client:  fake.NewClientBuilder().WithObjects(jenkins, claim).Build(),
+			Jenkins: jenkins,
+		}, client.JenkinsAPIConnectionSettings{})
+
+		_, err := reconciler.ensurePersistence()
+
+		require.NoError(t, err)
+		assert.Equal(t, resource.MustParse("20Gi"), getClaim(t, reconciler).Spec.Resources.Requests[corev1.ResourceStorage])
+	})
+	t.Run("starts migration when storage class changes", func(t *testing.T) {
+		jenkins := newJenkins("10Gi")
+		oldStorageClassName := "slow"
+		claim := resources.NewJenkinsHomePersistentVolumeClaim(jenkins, resources.GetJenkinsHomeClaimName(jenkins), &oldStorageClassName)
+		reconciler := New(configuration.Configuration{
+			Client:  fake.NewClientBuilder().WithObjects(jenkins, claim).Build(),
+			Jenkins: jenkins,
+			Scheme:  scheme.Scheme,
+		}, client.JenkinsAPIConnectionSettings{})
+
+		result, err := reconciler.ensurePersistence()
+
+		require.NoError(t, err)
+		assert.True(t, result.Requeue)
+		migration := jenkins.Status.Persistence.Migration
+		require.NotNil(t, migration)
+		assert.Equal(t, v1alpha2.PersistenceMigrationCopying, migration.Phase)
+		assert.Equal(t, "jenkins-operator-home-jenkins-standard", migration.TargetClaimName)
+		assert.Equal(t, oldStorageClassName, jenkins.Status.Persistence.StorageClassName)
+		migrationPod := &corev1.Pod{}
+		err = reconciler.Client.Get(context.TODO(), types.NamespacedName{Name: resources.GetJenkinsHomeMigrationPodName(jenkins), Namespace: defaultNamespace}, migrationPod)
+		require.NoError(t, err)
+		assert.Equal(t, claim.Name, migrationPod.Spec.Volumes[0].PersistentVolumeClaim.ClaimName)
+		assert.Equal(t, migration.TargetClaimName, migrationPod.Spec.Volumes[1].PersistentVolumeClaim.ClaimName)
+		assert.Equal(t, "jenkins/jenkins:lts", migrationPod.Spec.Containers[0].Image)
+	})
+	t.Run("labels migrated claim as active", func(t *testing.T) {
+		jenkins := newJenkins("10Gi")
+		oldStorageClassName := "slow"
+		oldClaim := resources.NewJenkinsHomePersistentVolumeClaim(jenkins, resources.GetJenkinsHomeClaimName(jenkins), &oldStorageClassName)
+		oldClaim.Labels[constants.LabelJenkinsHomeActiveKey] = constants.LabelJenkinsHomeActiveValue
+		targetClaimName := resources.GetJenkinsHomeMigrationClaimName(jenkins, storageClassName)
+		targetClaim := resources.NewJenkinsHomePersistentVolumeClaim(jenkins, targetClaimName, &storageClassName)
+		jenkins.Status.Persistence = &v1alpha2.PersistenceStatus{
+			ClaimName:        oldClaim.Name,
+			StorageClassName: oldStorageClassName,
+			Migration: &v1alpha2.PersistenceMigration{
+				TargetClaimName:  targetClaimName,
+				StorageClassName: storageClassName,
+				Phase:            v1alpha2.PersistenceMigrationCopying,
+			},
+		}
+		migrationPod := resources.NewJenkinsHomeMigrationPod(jenkins, oldClaim.Name, targetClaimName)
+		migrationPod.Status.Phase = corev1.PodSucceeded
+		reconciler := New(configuration.Configuration{
+			Client:  fake.NewClientBuilder().WithObjects(jenkins, oldClaim, targetClaim, migrationPod).Build(),
+			Jenkins: jenkins,
+			Scheme:  scheme.Scheme,
+		}, client.JenkinsAPIConnectionSettings{})
+
+		_, err := reconciler.ensurePersistence()
+
+		require.NoError(t, err)
+		assert.Equal(t, targetClaimName, jenkins.Status.Persistence.ClaimName)
+		assert.NotContains(t, getClaim(t, reconciler).Labels, constants.LabelJenkinsHomeActiveKey)
+		claim := &corev1.PersistentVolumeClaim{}
+		require.NoError(t, reconciler.Client.Get(context.TODO(), types.NamespacedName{Name: targetClaimName, Namespace: defaultNamespace}, claim))
+		assert.Equal(t, constants.LabelJenkinsHomeActiveValue, claim.Labels[constants.LabelJenkinsHomeActiveKey])
+	})
+	t.Run("mounts active claim without status", func(t *testing.T) {
+		// e.g. after spec.persistence has been removed and added again or Jenkins CR has been recreated
+		jenkins := newJenkins("10Gi")
+		oldStorageClassName := "slow"
+		oldClaim := resources.NewJenkinsHomePersistentVolumeClaim(jenkins, resources.GetJenkinsHomeClaimName(jenkins), &oldStorageClassName)
+		activeClaim := resources.NewJenkinsHomePersistentVolumeClaim(jenkins, resources.GetJenkinsHomeMigrationClaimName(jenkins, storageClassName), &storageClassName)
+		activeClaim.Labels[constants.LabelJenkinsHomeActiveKey] = constants.LabelJenkinsHomeActiveValue
+		reconciler := New(configuration.Configuration{
+			Client:  fake.NewClientBuilder().WithObjects(jenkins, oldClaim, activeClaim).Build(),
+			Jenkins: jenkins,
+		}, client.JenkinsAPIConnectionSettings{})
+
+		result, err := reconciler.ensurePersistence()
+
+		require.NoError(t, err)
+		assert.False(t, result.Requeue)
+		assert.Equal(t, &v1alpha2.PersistenceStatus{ClaimName: activeClaim.Name, StorageClassName: storageClassName}, jenkins.Status.Persistence)
+		assert.Equal(t, activeClaim.Name, resources.GetJenkinsHomeClaimName(jenkins))
+	})
+}
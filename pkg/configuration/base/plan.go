@@ -0,0 +1,122 @@
+package base
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/plugins"
+
+	"github.com/bndr/gojenkins"
+	stackerr "github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// Plan computes actions which Reconcile would take on Jenkins master pod and plugins without executing them
+func (r *JenkinsBaseConfigurationReconciler) Plan(ctx context.Context, plan *v1alpha2.JenkinsPlan) error {
+	if useDeploymentForJenkinsMaster(r.Configuration.Jenkins) {
+		_, err := r.GetJenkinsDeployment()
+		if apierrors.IsNotFound(err) {
+			plan.PodRestartRequired = true
+			plan.PodRestartReasons = append(plan.PodRestartReasons, "Jenkins Deployment will be created")
+			plan.Plugins = planPlugins(&gojenkins.Plugins{Raw: &gojenkins.PluginResponse{}}, r.Configuration.Jenkins.Spec.Master.BasePlugins, r.Configuration.Jenkins.Spec.Master.Plugins)
+			return nil
+		} else if err != nil {
+			return stackerr.WithStack(err)
+		}
+		plan.Messages = append(plan.Messages, "Plan isn't supported for the existing Jenkins Deployment, pod restarts and plugin changes aren't computed")
+		return nil
+	}
+
+	currentJenkinsMasterPod, err := r.Configuration.GetJenkinsMasterPod()
+	if apierrors.IsNotFound(err) {
+		plan.PodRestartRequired = true
+		plan.PodRestartReasons = append(plan.PodRestartReasons, "Jenkins master pod will be created")
+		plan.Plugins = planPlugins(&gojenkins.Plugins{Raw: &gojenkins.PluginResponse{}}, r.Configuration.Jenkins.Spec.Master.BasePlugins, r.Configuration.Jenkins.Spec.Master.Plugins)
+		return nil
+	} else if err != nil {
+		return stackerr.WithStack(err)
+	}
+
+	if r.IsJenkinsTerminating(*currentJenkinsMasterPod) {
+		plan.Messages = append(plan.Messages, "Jenkins master pod is terminating, plugin changes can't be computed")
+		return nil
+	}
+
+	userAndPasswordHash, err := r.calculateUserAndPasswordHash()
+	if err != nil {
+		return err
+	}
+	if restartReason := r.checkForPodRecreation(*currentJenkinsMasterPod, userAndPasswordHash); restartReason.HasMessages() {
+		plan.PodRestartRequired = true
+		plan.PodRestartReasons = append(plan.PodRestartReasons, restartReason.Short()...)
+	}
+	if persistence, status := r.Configuration.Jenkins.Spec.Persistence, r.Configuration.Jenkins.Status.Persistence; persistence != nil && status != nil &&
+		persistence.StorageClassName != nil && *persistence.StorageClassName != status.StorageClassName {
+		plan.PodRestartRequired = true
+		plan.PodRestartReasons = append(plan.PodRestartReasons, fmt.Sprintf("Jenkins home will be migrated to storage class '%s'", *persistence.StorageClassName))
+	}
+
+	if !isPodReady(*currentJenkinsMasterPod) {
+		plan.Messages = append(plan.Messages, "Jenkins master pod isn't ready, plugin changes can't be computed")
+		return nil
+	}
+
+	jenkinsClient, err := r.Configuration.GetJenkinsClient(ctx)
+	if err != nil {
+		return err
+	}
+	allPluginsInJenkins, err := jenkinsClient.GetPlugins(fetchAllPlugins)
+	if err != nil {
+		return stackerr.WithStack(err)
+	}
+	plan.Plugins = planPlugins(allPluginsInJenkins, r.Configuration.Jenkins.Spec.Master.BasePlugins, r.Configuration.Jenkins.Spec.Master.Plugins)
+	if len(plan.Plugins) > 0 && !plan.PodRestartRequired {
+		if hasPluginBatchFailed(r.Configuration.Jenkins.Status.PluginBatch, plan.Plugins) {
+			plan.PodRestartRequired = true
+			plan.PodRestartReasons = append(plan.PodRestartReasons, "Some plugins have changed and failed in the last plugin batch")
+		} else {
+			plan.JenkinsRestartRequired = true
+		}
+	}
+
+	return nil
+}
+
+func isPodReady(pod corev1.Pod) bool {
+	if pod.Status.Phase != corev1.PodRunning {
+		return false
+	}
+	for _, containerStatus := range pod.Status.ContainerStatuses {
+		if !containerStatus.Ready {
+			return false
+		}
+	}
+	return true
+}
+
+// planPlugins returns required plugins which are missing or have different version in Jenkins
+func planPlugins(allPluginsInJenkins *gojenkins.Plugins, allRequiredPlugins ...[]v1alpha2.Plugin) []v1alpha2.PlannedPluginChange {
+	var changes []v1alpha2.PlannedPluginChange
+	for _, requiredPlugins := range allRequiredPlugins {
+		for _, plugin := range requiredPlugins {
+			found, ok := isPluginInstalled(allPluginsInJenkins, plugin)
+			if !ok {
+				changes = append(changes, v1alpha2.PlannedPluginChange{Name: plugin.Name, Action: v1alpha2.PluginActionInstall, Version: plugin.Version})
+				continue
+			}
+			if found.Version == plugin.Version {
+				continue
+			}
+
+			change := v1alpha2.PlannedPluginChange{Name: plugin.Name, Action: v1alpha2.PluginActionUpgrade, CurrentVersion: found.Version, Version: plugin.Version}
+			if plugins.CompareVersions(plugin.Version, found.Version) < 0 {
+				change.Action = v1alpha2.PluginActionDowngrade
+			}
+			changes = append(changes, change)
+		}
+	}
+
+	return changes
+}
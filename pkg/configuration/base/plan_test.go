@@ -0,0 +1,107 @@
+package base
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+
+	"github.com/bndr/gojenkins"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestPlanPlugins(t *testing.T) {
+	pluginsInJenkins := &gojenkins.Plugins{
+		Raw: &gojenkins.PluginResponse{
+			Plugins: []gojenkins.Plugin{
+				{ShortName: "up-to-date", Active: true, Enabled: true, Version: "1.0"},
+				{ShortName: "older", Active: true, Enabled: true, Version: "1.0"},
+				{ShortName: "newer", Active: true, Enabled: true, Version: "2.0"},
+				{ShortName: "disabled", Active: false, Enabled: false, Version: "1.0"},
+			},
+		},
+	}
+	basePlugins := []v1alpha2.Plugin{{Name: "up-to-date", Version: "1.0"}, {Name: "older", Version: "1.1"}}
+	userPlugins := []v1alpha2.Plugin{{Name: "newer", Version: "1.9"}, {Name: "disabled", Version: "1.0"}, {Name: "missing", Version: "0.1"}}
+
+	got := planPlugins(pluginsInJenkins, basePlugins, userPlugins)
+
+	assert.Equal(t, []v1alpha2.PlannedPluginChange{
+		{Name: "older", Action: v1alpha2.PluginActionUpgrade, CurrentVersion: "1.0", Version: "1.1"},
+		{Name: "newer", Action: v1alpha2.PluginActionDowngrade, CurrentVersion: "2.0", Version: "1.9"},
+		{Name: "disabled", Action: v1alpha2.PluginActionInstall, Version: "1.0"},
+		{Name: "missing", Action: v1alpha2.PluginActionInstall, Version: "0.1"},
+	}, got)
+}
+
+func TestJenkinsBaseConfigurationReconciler_Plan(t *testing.T) {
+	log.SetupLogger(true)
+
+	t.Run("Jenkins master pod doesn't exist", func(t *testing.T) {
+		// given
+		err := v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme)
+		require.NoError(t, err)
+		jenkins := &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: "default"},
+			Spec: v1alpha2.JenkinsSpec{
+				Master: v1alpha2.JenkinsMaster{
+					BasePlugins: []v1alpha2.Plugin{{Name: "plugin-name", Version: "0.0.1"}},
+				},
+			},
+		}
+		config := configuration.Configuration{
+			Client:  fake.NewClientBuilder().Build(),
+			Jenkins: jenkins,
+			Scheme:  scheme.Scheme,
+		}
+		reconciler := New(config, client.JenkinsAPIConnectionSettings{})
+		plan := &v1alpha2.JenkinsPlan{}
+
+		// when
+		err = reconciler.Plan(context.TODO(), plan)
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, plan.PodRestartRequired)
+		assert.Equal(t, []string{"Jenkins master pod will be created"}, plan.PodRestartReasons)
+		assert.Equal(t, []v1alpha2.PlannedPluginChange{
+			{Name: "plugin-name", Action: v1alpha2.PluginActionInstall, Version: "0.0.1"},
+		}, plan.Plugins)
+	})
+	t.Run("Jenkins Deployment exists", func(t *testing.T) {
+		// given
+		err := v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme)
+		require.NoError(t, err)
+		jenkins := &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: "default"},
+			Spec: v1alpha2.JenkinsSpec{
+				Master: v1alpha2.JenkinsMaster{WorkloadType: v1alpha2.WorkloadTypeDeployment},
+			},
+		}
+		deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: resources.GetJenkinsDeploymentName(jenkins), Namespace: "default"}}
+		config := configuration.Configuration{
+			Client:  fake.NewClientBuilder().WithObjects(deployment).Build(),
+			Jenkins: jenkins,
+			Scheme:  scheme.Scheme,
+		}
+		reconciler := New(config, client.JenkinsAPIConnectionSettings{})
+		plan := &v1alpha2.JenkinsPlan{}
+
+		// when
+		err = reconciler.Plan(context.TODO(), plan)
+
+		// then
+		require.NoError(t, err)
+		assert.False(t, plan.PodRestartRequired)
+		assert.Equal(t, []string{"Plan isn't supported for the existing Jenkins Deployment, pod restarts and plugin changes aren't computed"}, plan.Messages)
+	})
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
 	jenkinsclient "github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/failure"
 
 	"github.com/bndr/gojenkins"
 	"github.com/jenkinsci/kubernetes-operator/pkg/log"
@@ -12,10 +13,11 @@ import (
 	stackerr "github.com/pkg/errors"
 )
 
-func (r *JenkinsBaseConfigurationReconciler) verifyPlugins(jenkinsClient jenkinsclient.Jenkins) (bool, error) {
+// verifyPlugins returns messages describing required plugins which are missing or have incompatible version in Jenkins
+func (r *JenkinsBaseConfigurationReconciler) verifyPlugins(jenkinsClient jenkinsclient.Jenkins) ([]string, error) {
 	allPluginsInJenkins, err := jenkinsClient.GetPlugins(fetchAllPlugins)
 	if err != nil {
-		return false, stackerr.WithStack(err)
+		return nil, failure.PluginFailure(stackerr.WithStack(err))
 	}
 
 	var installedPlugins []string
@@ -26,23 +28,25 @@ func (r *JenkinsBaseConfigurationReconciler) verifyPlugins(jenkinsClient jenkins
 	}
 	r.logger.V(log.VDebug).Info(fmt.Sprintf("Installed plugins '%+v'", installedPlugins))
 
-	status := true
+	var messages []string
 	allRequiredPlugins := [][]v1alpha2.Plugin{r.Configuration.Jenkins.Spec.Master.BasePlugins, r.Configuration.Jenkins.Spec.Master.Plugins}
 	for _, requiredPlugins := range allRequiredPlugins {
 		for _, plugin := range requiredPlugins {
 			if _, ok := isPluginInstalled(allPluginsInJenkins, plugin); !ok {
-				r.logger.V(log.VWarn).Info(fmt.Sprintf("Missing plugin '%s'", plugin))
-				status = false
+				message := fmt.Sprintf("Missing plugin '%s:%s'", plugin.Name, plugin.Version)
+				r.logger.V(log.VWarn).Info(message)
+				messages = append(messages, message)
 				continue
 			}
 			if found, ok := isPluginVersionCompatible(allPluginsInJenkins, plugin); !ok {
-				r.logger.V(log.VWarn).Info(fmt.Sprintf("Incompatible plugin '%s' version, actual '%+v'", plugin, found.Version))
-				status = false
+				message := fmt.Sprintf("Incompatible plugin '%s:%s' version, actual '%s'", plugin.Name, plugin.Version, found.Version)
+				r.logger.V(log.VWarn).Info(message)
+				messages = append(messages, message)
 			}
 		}
 	}
 
-	return status, nil
+	return messages, nil
 }
 
 func isPluginVersionCompatible(plugins *gojenkins.Plugins, plugin v1alpha2.Plugin) (gojenkins.Plugin, bool) {
@@ -0,0 +1,298 @@
+package base
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	jenkinsclient "github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/operation"
+	k8sevent "github.com/jenkinsci/kubernetes-operator/pkg/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/failure"
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/reason"
+	"github.com/jenkinsci/kubernetes-operator/pkg/plugins"
+
+	"github.com/bndr/gojenkins"
+	stackerr "github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// pluginBatchCheckInterval is the time between checks if Jenkins has been restarted with the plugin batch
+	pluginBatchCheckInterval = 10 * time.Second
+	// pluginBatchRestartTimeout is the time after which Jenkins master pod is restarted when the safe restart hasn't
+	// happened, e.g. because of builds which never finish
+	pluginBatchRestartTimeout = time.Hour
+)
+
+// failedPluginPattern matches the plugins reported by the install script as not downloaded
+var failedPluginPattern = regexp.MustCompile(`(?:Not downloaded|Download integrity): (\S+)`)
+
+// ensurePluginBatch installs all plugin changes in running Jenkins in one batch and schedules one safe restart to load
+// them, Jenkins master pod is restarted when the batch can't be installed
+func (r *JenkinsBaseConfigurationReconciler) ensurePluginBatch(jenkinsClient jenkinsclient.Jenkins, pluginMessages []string) (reconcile.Result, error) {
+	jenkins := r.Configuration.Jenkins
+	if batch := jenkins.Status.PluginBatch; batch != nil && batch.CompletionTime == nil {
+		return r.checkPluginBatch(jenkinsClient, pluginMessages)
+	}
+	if len(pluginMessages) == 0 {
+		return reconcile.Result{}, r.Configuration.ResolveDeferredAction(v1alpha2.DisruptiveActionPluginChanges)
+	}
+	if jenkins.Status.UserConfigurationCompletedTime != nil {
+		deferred, err := r.Configuration.DeferDisruptiveAction(v1alpha2.DisruptiveActionPluginChanges, strings.Join(pluginMessages, "; "))
+		if err != nil || deferred {
+			// the rest of the base configuration is reconciled with the current plugins
+			return reconcile.Result{}, err
+		}
+	}
+
+	allPluginsInJenkins, err := jenkinsClient.GetPlugins(fetchAllPlugins)
+	if err != nil {
+		return reconcile.Result{}, failure.PluginFailure(stackerr.WithStack(err))
+	}
+	changes := planPlugins(allPluginsInJenkins, jenkins.Spec.Master.BasePlugins, jenkins.Spec.Master.Plugins)
+	if len(changes) == 0 || hasPluginBatchFailed(jenkins.Status.PluginBatch, changes) {
+		// the same changes have failed in the last batch, all plugins are installed again with the new pod
+		return r.restartForPlugins(pluginMessages)
+	}
+
+	r.Configuration.Emit(k8sevent.TypeWarning, k8sevent.ReasonPluginVerificationFailed, strings.Join(pluginMessages, "; "))
+	installPlugins, err := plugins.InstallOrder(toInstallPlugins(changes), r.Configuration.BasePluginManifests.Dependencies())
+	if err != nil {
+		return reconcile.Result{}, failure.PluginFailure(err)
+	}
+	installPlugins, err = resources.ResolvePluginDownloadURLs(jenkins, installPlugins)
+	if err != nil {
+		return reconcile.Result{}, failure.PluginFailure(err)
+	}
+
+	now := metav1.Now()
+	batch := newPluginBatch(changes, now)
+	r.logger.Info(fmt.Sprintf("Installing %d plugin change(s) in one batch", len(changes)))
+	command := resources.GetInstallPluginsCommand(jenkins, installPlugins)
+	stdout, stderr, err := r.Configuration.Exec(resources.GetJenkinsMasterPodName(jenkins), resources.JenkinsMasterContainerName, command)
+	if err != nil {
+		r.logger.V(log.VWarn).Info(fmt.Sprintf("Plugin batch download failed: %s, stdout '%s', stderr '%s'", err, stdout.String(), stderr.String()))
+		failPluginBatch(&batch, failedPluginPattern.FindAllStringSubmatch(stderr.String(), -1))
+		batch.CompletionTime = &now
+		jenkins.Status.PluginBatch = &batch
+		if err = r.Client.Status().Update(context.TODO(), jenkins); err != nil {
+			return reconcile.Result{}, stackerr.WithStack(err)
+		}
+		r.Configuration.Emit(k8sevent.TypeWarning, k8sevent.ReasonPluginBatchFailed, "Plugins couldn't be downloaded to Jenkins master, restarting Jenkins master pod")
+		return r.restartForPlugins(pluginMessages)
+	}
+
+	jenkins.Status.PluginBatch = &batch
+	if err = r.Client.Status().Update(context.TODO(), jenkins); err != nil {
+		return reconcile.Result{}, stackerr.WithStack(err)
+	}
+	r.Configuration.Emitf(k8sevent.TypeNormal, k8sevent.ReasonPluginBatchStarted, "Plugins downloaded to Jenkins master: %s", pluginChangesSummary(changes))
+	return r.scheduleSafeRestart(jenkinsClient)
+}
+
+// checkPluginBatch waits until Jenkins has been restarted by the safe restart and verifies the plugins of the batch
+func (r *JenkinsBaseConfigurationReconciler) checkPluginBatch(jenkinsClient jenkinsclient.Jenkins, pluginMessages []string) (reconcile.Result, error) {
+	jenkins := r.Configuration.Jenkins
+	batch := jenkins.Status.PluginBatch
+	if batch.RestartTime == nil {
+		return r.scheduleSafeRestart(jenkinsClient)
+	}
+
+	output, err := jenkinsClient.ExecuteScript(operation.JVMStartTimeScript)
+	if err != nil {
+		r.logger.V(log.VDebug).Info(fmt.Sprintf("Failed to get JVM start time of Jenkins: %s", err))
+		if isPluginBatchRestartTimedOut(batch, time.Now()) {
+			return r.timeOutPluginBatch(pluginMessages)
+		}
+		return reconcile.Result{Requeue: true, RequeueAfter: pluginBatchCheckInterval}, nil
+	}
+	startTime, err := operation.ParseJVMStartTime(output)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if !startTime.After(batch.RestartTime.Time) {
+		if isPluginBatchRestartTimedOut(batch, time.Now()) {
+			return r.timeOutPluginBatch(pluginMessages)
+		}
+		r.logger.V(log.VDebug).Info("Waiting for the safe restart of Jenkins loading the plugin batch, builds are still running")
+		return reconcile.Result{Requeue: true, RequeueAfter: pluginBatchCheckInterval}, nil
+	}
+
+	allPluginsInJenkins, err := jenkinsClient.GetPlugins(fetchAllPlugins)
+	if err != nil {
+		return reconcile.Result{}, failure.PluginFailure(stackerr.WithStack(err))
+	}
+	failed := verifyPluginBatch(batch, allPluginsInJenkins)
+	now := metav1.Now()
+	batch.CompletionTime = &now
+	if err = r.Client.Status().Update(context.TODO(), jenkins); err != nil {
+		return reconcile.Result{}, stackerr.WithStack(err)
+	}
+	if failed > 0 {
+		message := fmt.Sprintf("%d of %d plugin change(s) haven't been loaded by Jenkins, restarting Jenkins master pod", failed, len(batch.Plugins))
+		r.Configuration.Emit(k8sevent.TypeWarning, k8sevent.ReasonPluginBatchFailed, message)
+		return r.restartForPlugins(pluginMessages)
+	}
+
+	message := fmt.Sprintf("Jenkins has been restarted with %d plugin change(s)", len(batch.Plugins))
+	r.logger.Info(message)
+	r.Configuration.Emit(k8sevent.TypeNormal, k8sevent.ReasonPluginBatchCompleted, message)
+	return reconcile.Result{Requeue: true}, nil
+}
+
+// timeOutPluginBatch completes the plugin batch which hasn't been loaded by the safe restart in time and restarts Jenkins
+// master pod instead
+func (r *JenkinsBaseConfigurationReconciler) timeOutPluginBatch(pluginMessages []string) (reconcile.Result, error) {
+	jenkins := r.Configuration.Jenkins
+	batch := jenkins.Status.PluginBatch
+	failPendingPluginBatch(batch)
+	now := metav1.Now()
+	batch.CompletionTime = &now
+	if err := r.Client.Status().Update(context.TODO(), jenkins); err != nil {
+		return reconcile.Result{}, stackerr.WithStack(err)
+	}
+	message := fmt.Sprintf("Jenkins hasn't been restarted by the safe restart within %s, restarting Jenkins master pod", pluginBatchRestartTimeout)
+	r.Configuration.Emit(k8sevent.TypeWarning, k8sevent.ReasonPluginBatchFailed, message)
+	return r.restartForPlugins(pluginMessages)
+}
+
+// scheduleSafeRestart schedules one safe restart of Jenkins loading the plugin batch, Jenkins waits for running builds
+func (r *JenkinsBaseConfigurationReconciler) scheduleSafeRestart(jenkinsClient jenkinsclient.Jenkins) (reconcile.Result, error) {
+	jenkins := r.Configuration.Jenkins
+	now := metav1.Now()
+	if err := jenkinsClient.SafeRestart(); err != nil {
+		return reconcile.Result{}, stackerr.WithStack(err)
+	}
+	jenkins.Status.PluginBatch.RestartTime = &now
+	if err := r.Client.Status().Update(context.TODO(), jenkins); err != nil {
+		return reconcile.Result{}, stackerr.WithStack(err)
+	}
+	r.logger.Info("Safe restart of Jenkins loading the plugin batch has been scheduled")
+	return reconcile.Result{Requeue: true, RequeueAfter: pluginBatchCheckInterval}, nil
+}
+
+// restartForPlugins restarts Jenkins master pod, the init script installs all required plugins
+func (r *JenkinsBaseConfigurationReconciler) restartForPlugins(pluginMessages []string) (reconcile.Result, error) {
+	if r.Configuration.Jenkins.Status.UserConfigurationCompletedTime != nil {
+		deferred, err := r.Configuration.DeferDisruptiveAction(v1alpha2.DisruptiveActionPluginChanges, strings.Join(pluginMessages, "; "))
+		if err != nil || deferred {
+			return reconcile.Result{}, err
+		}
+	}
+
+	message := "Some plugins have changed, restarting Jenkins"
+	r.logger.Info(message)
+
+	restartReason := reason.NewPodRestart(
+		reason.OperatorSource,
+		[]string{message},
+		append([]string{message}, pluginMessages...)...,
+	)
+	return reconcile.Result{Requeue: true}, r.Configuration.RestartJenkinsMasterPod(restartReason)
+}
+
+func newPluginBatch(changes []v1alpha2.PlannedPluginChange, startTime metav1.Time) v1alpha2.PluginBatchStatus {
+	batch := v1alpha2.PluginBatchStatus{StartTime: &startTime}
+	for _, change := range changes {
+		batch.Plugins = append(batch.Plugins, v1alpha2.PluginChangeStatus{PlannedPluginChange: change, Phase: v1alpha2.PluginChangePhasePending})
+	}
+	return batch
+}
+
+// failPluginBatch marks the plugins which couldn't be downloaded as failed, the whole batch fails when the install
+// script didn't report them
+func failPluginBatch(batch *v1alpha2.PluginBatchStatus, failedPlugins [][]string) {
+	failed := map[string]bool{}
+	for _, match := range failedPlugins {
+		failed[match[1]] = true
+	}
+	for i, plugin := range batch.Plugins {
+		batch.Plugins[i].Phase = v1alpha2.PluginChangePhaseFailed
+		if len(failed) == 0 || failed[plugin.Name] || failed[plugin.Name+"-plugin"] {
+			batch.Plugins[i].Message = fmt.Sprintf("Plugin '%s:%s' couldn't be downloaded", plugin.Name, plugin.Version)
+		} else {
+			batch.Plugins[i].Message = "Another plugin of the batch couldn't be downloaded"
+		}
+	}
+}
+
+// isPluginBatchRestartTimedOut tells if the safe restart scheduled for the plugin batch hasn't happened within
+// pluginBatchRestartTimeout
+func isPluginBatchRestartTimedOut(batch *v1alpha2.PluginBatchStatus, now time.Time) bool {
+	return batch.RestartTime != nil && now.Sub(batch.RestartTime.Time) > pluginBatchRestartTimeout
+}
+
+// failPendingPluginBatch marks the plugins which haven't been loaded by the safe restart as failed, they're installed
+// again with the new pod
+func failPendingPluginBatch(batch *v1alpha2.PluginBatchStatus) {
+	for i, plugin := range batch.Plugins {
+		if plugin.Phase == v1alpha2.PluginChangePhasePending {
+			batch.Plugins[i].Phase = v1alpha2.PluginChangePhaseFailed
+			batch.Plugins[i].Message = fmt.Sprintf("Jenkins hasn't been restarted within %s", pluginBatchRestartTimeout)
+		}
+	}
+}
+
+// verifyPluginBatch sets the phase of every plugin change in the batch after Jenkins restart, it returns
+// the number of failed changes
+func verifyPluginBatch(batch *v1alpha2.PluginBatchStatus, allPluginsInJenkins *gojenkins.Plugins) int {
+	failed := 0
+	for i, plugin := range batch.Plugins {
+		found, ok := isPluginInstalled(allPluginsInJenkins, v1alpha2.Plugin{Name: plugin.Name, Version: plugin.Version})
+		switch {
+		case !ok:
+			batch.Plugins[i].Phase = v1alpha2.PluginChangePhaseFailed
+			batch.Plugins[i].Message = fmt.Sprintf("Plugin '%s' isn't active in Jenkins", plugin.Name)
+			failed++
+		case found.Version != plugin.Version:
+			batch.Plugins[i].Phase = v1alpha2.PluginChangePhaseFailed
+			batch.Plugins[i].Message = fmt.Sprintf("Jenkins has loaded version '%s'", found.Version)
+			failed++
+		default:
+			batch.Plugins[i].Phase = v1alpha2.PluginChangePhaseInstalled
+			batch.Plugins[i].Message = ""
+		}
+	}
+	return failed
+}
+
+// hasPluginBatchFailed tells if any of the changes has failed in the last batch
+func hasPluginBatchFailed(batch *v1alpha2.PluginBatchStatus, changes []v1alpha2.PlannedPluginChange) bool {
+	if batch == nil {
+		return false
+	}
+	for _, plugin := range batch.Plugins {
+		if plugin.Phase != v1alpha2.PluginChangePhaseFailed {
+			continue
+		}
+		for _, change := range changes {
+			if change.Name == plugin.Name && change.Version == plugin.Version {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func toInstallPlugins(changes []v1alpha2.PlannedPluginChange) []plugins.Plugin {
+	var installPlugins []plugins.Plugin
+	for _, change := range changes {
+		installPlugins = append(installPlugins, plugins.Plugin{Name: change.Name, Version: change.Version})
+	}
+	return installPlugins
+}
+
+func pluginChangesSummary(changes []v1alpha2.PlannedPluginChange) string {
+	var summary []string
+	for _, change := range changes {
+		summary = append(summary, fmt.Sprintf("%s %s:%s", change.Action, change.Name, change.Version))
+	}
+	return strings.Join(summary, ", ")
+}
@@ -0,0 +1,124 @@
+package base
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	"github.com/bndr/gojenkins"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestFailPluginBatch(t *testing.T) {
+	changes := []v1alpha2.PlannedPluginChange{
+		{Name: "docker", Action: v1alpha2.PluginActionInstall, Version: "1.2"},
+		{Name: "git", Action: v1alpha2.PluginActionUpgrade, CurrentVersion: "4.7", Version: "4.8"},
+	}
+
+	t.Run("reported plugin", func(t *testing.T) {
+		batch := newPluginBatch(changes, metav1.Now())
+		stderr := "Failed to download plugin: docker or docker-plugin\nSome plugins failed to download! Not downloaded: docker\n"
+
+		failPluginBatch(&batch, failedPluginPattern.FindAllStringSubmatch(stderr, -1))
+
+		assert.Equal(t, v1alpha2.PluginChangePhaseFailed, batch.Plugins[0].Phase)
+		assert.Equal(t, "Plugin 'docker:1.2' couldn't be downloaded", batch.Plugins[0].Message)
+		assert.Equal(t, v1alpha2.PluginChangePhaseFailed, batch.Plugins[1].Phase)
+		assert.Equal(t, "Another plugin of the batch couldn't be downloaded", batch.Plugins[1].Message)
+	})
+	t.Run("integrity of renamed plugin", func(t *testing.T) {
+		batch := newPluginBatch(changes, metav1.Now())
+		stderr := "Some plugins failed to download! Download integrity: docker-plugin\n"
+
+		failPluginBatch(&batch, failedPluginPattern.FindAllStringSubmatch(stderr, -1))
+
+		assert.Equal(t, "Plugin 'docker:1.2' couldn't be downloaded", batch.Plugins[0].Message)
+		assert.Equal(t, "Another plugin of the batch couldn't be downloaded", batch.Plugins[1].Message)
+	})
+	t.Run("no plugin reported", func(t *testing.T) {
+		batch := newPluginBatch(changes, metav1.Now())
+
+		failPluginBatch(&batch, failedPluginPattern.FindAllStringSubmatch("command terminated with exit code 137", -1))
+
+		assert.Equal(t, "Plugin 'docker:1.2' couldn't be downloaded", batch.Plugins[0].Message)
+		assert.Equal(t, "Plugin 'git:4.8' couldn't be downloaded", batch.Plugins[1].Message)
+	})
+}
+
+func TestVerifyPluginBatch(t *testing.T) {
+	pluginsInJenkins := &gojenkins.Plugins{
+		Raw: &gojenkins.PluginResponse{
+			Plugins: []gojenkins.Plugin{
+				{ShortName: "git", Version: "4.8", Active: true, Enabled: true},
+				{ShortName: "kubernetes", Version: "1.29.0", Active: true, Enabled: true},
+				{ShortName: "docker", Version: "1.2", Active: false, Enabled: true},
+			},
+		},
+	}
+	batch := newPluginBatch([]v1alpha2.PlannedPluginChange{
+		{Name: "git", Action: v1alpha2.PluginActionUpgrade, CurrentVersion: "4.7", Version: "4.8"},
+		{Name: "kubernetes", Action: v1alpha2.PluginActionUpgrade, CurrentVersion: "1.29.0", Version: "1.29.2"},
+		{Name: "docker", Action: v1alpha2.PluginActionInstall, Version: "1.2"},
+		{Name: "missing", Action: v1alpha2.PluginActionInstall, Version: "0.1"},
+	}, metav1.Now())
+
+	failed := verifyPluginBatch(&batch, pluginsInJenkins)
+
+	assert.Equal(t, 3, failed)
+	assert.Equal(t, v1alpha2.PluginChangePhaseInstalled, batch.Plugins[0].Phase)
+	assert.Empty(t, batch.Plugins[0].Message)
+	assert.Equal(t, v1alpha2.PluginChangePhaseFailed, batch.Plugins[1].Phase)
+	assert.Equal(t, "Jenkins has loaded version '1.29.0'", batch.Plugins[1].Message)
+	assert.Equal(t, v1alpha2.PluginChangePhaseFailed, batch.Plugins[2].Phase)
+	assert.Equal(t, "Plugin 'docker' isn't active in Jenkins", batch.Plugins[2].Message)
+	assert.Equal(t, v1alpha2.PluginChangePhaseFailed, batch.Plugins[3].Phase)
+}
+
+func TestHasPluginBatchFailed(t *testing.T) {
+	changes := []v1alpha2.PlannedPluginChange{{Name: "git", Action: v1alpha2.PluginActionUpgrade, Version: "4.8"}}
+
+	t.Run("no batch", func(t *testing.T) {
+		assert.False(t, hasPluginBatchFailed(nil, changes))
+	})
+	t.Run("same version failed", func(t *testing.T) {
+		batch := &v1alpha2.PluginBatchStatus{Plugins: []v1alpha2.PluginChangeStatus{
+			{PlannedPluginChange: v1alpha2.PlannedPluginChange{Name: "git", Version: "4.8"}, Phase: v1alpha2.PluginChangePhaseFailed},
+		}}
+		assert.True(t, hasPluginBatchFailed(batch, changes))
+	})
+	t.Run("other version failed", func(t *testing.T) {
+		batch := &v1alpha2.PluginBatchStatus{Plugins: []v1alpha2.PluginChangeStatus{
+			{PlannedPluginChange: v1alpha2.PlannedPluginChange{Name: "git", Version: "4.7"}, Phase: v1alpha2.PluginChangePhaseFailed},
+		}}
+		assert.False(t, hasPluginBatchFailed(batch, changes))
+	})
+	t.Run("same version installed", func(t *testing.T) {
+		batch := &v1alpha2.PluginBatchStatus{Plugins: []v1alpha2.PluginChangeStatus{
+			{PlannedPluginChange: v1alpha2.PlannedPluginChange{Name: "git", Version: "4.8"}, Phase: v1alpha2.PluginChangePhaseInstalled},
+		}}
+		assert.False(t, hasPluginBatchFailed(batch, changes))
+	})
+}
+
+func TestIsPluginBatchRestartTimedOut(t *testing.T) {
+	restartTime := metav1.NewTime(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	assert.False(t, isPluginBatchRestartTimedOut(&v1alpha2.PluginBatchStatus{}, restartTime.Add(2*pluginBatchRestartTimeout)))
+	assert.False(t, isPluginBatchRestartTimedOut(&v1alpha2.PluginBatchStatus{RestartTime: &restartTime}, restartTime.Add(pluginBatchRestartTimeout)))
+	assert.True(t, isPluginBatchRestartTimedOut(&v1alpha2.PluginBatchStatus{RestartTime: &restartTime}, restartTime.Add(pluginBatchRestartTimeout+time.Second)))
+}
+
+func TestFailPendingPluginBatch(t *testing.T) {
+	batch := &v1alpha2.PluginBatchStatus{Plugins: []v1alpha2.PluginChangeStatus{
+		{PlannedPluginChange: v1alpha2.PlannedPluginChange{Name: "git", Version: "4.8"}, Phase: v1alpha2.PluginChangePhasePending},
+		{PlannedPluginChange: v1alpha2.PlannedPluginChange{Name: "job-dsl", Version: "1.77"}, Phase: v1alpha2.PluginChangePhaseFailed, Message: "Plugin 'job-dsl:1.77' couldn't be downloaded"},
+	}}
+
+	failPendingPluginBatch(batch)
+
+	assert.Equal(t, v1alpha2.PluginChangePhaseFailed, batch.Plugins[0].Phase)
+	assert.Equal(t, "Jenkins hasn't been restarted within 1h0m0s", batch.Plugins[0].Message)
+	assert.Equal(t, "Plugin 'job-dsl:1.77' couldn't be downloaded", batch.Plugins[1].Message)
+}
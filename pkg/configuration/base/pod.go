@@ -4,10 +4,13 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"strings"
 
 	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
 	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/backuprestore"
 	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+	k8sevent "github.com/jenkinsci/kubernetes-operator/pkg/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
 	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
 	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/reason"
 	"github.com/jenkinsci/kubernetes-operator/version"
@@ -19,6 +22,23 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
+const (
+	credentialsChangedMessage = "User or password have changed"
+	recoveryOnceMessage       = "spec.restore.recoveryOnce is set"
+)
+
+// isUrgentPodRecreation returns true when the pod is recreated to apply new credentials or to restore a backup,
+// they aren't deferred until the maintenance window
+func isUrgentPodRecreation(restartReason reason.Reason) bool {
+	for _, message := range restartReason.Short() {
+		// the single message is prefixed with the source of the restart
+		if strings.HasSuffix(message, credentialsChangedMessage) || strings.HasSuffix(message, recoveryOnceMessage) {
+			return true
+		}
+	}
+	return false
+}
+
 func (r *JenkinsBaseConfigurationReconciler) checkForPodRecreation(currentJenkinsMasterPod corev1.Pod, userAndPasswordHash string) reason.Reason {
 	var messages []string
 	var verbose []string
@@ -36,12 +56,12 @@ func (r *JenkinsBaseConfigurationReconciler) checkForPodRecreation(currentJenkin
 	userAndPasswordHashStatusNotEmpty := r.Configuration.Jenkins.Status.UserAndPasswordHash != ""
 
 	if userAndPasswordHashIsDifferent && userAndPasswordHashStatusNotEmpty {
-		messages = append(messages, "User or password have changed")
+		messages = append(messages, credentialsChangedMessage)
 		verbose = append(verbose, "User or password have changed, recreating pod")
 	}
 
 	if r.Configuration.Jenkins.Spec.Restore.RecoveryOnce != 0 && r.Configuration.Jenkins.Status.RestoredBackup != 0 {
-		messages = append(messages, "spec.restore.recoveryOnce is set")
+		messages = append(messages, recoveryOnceMessage)
 		verbose = append(verbose, "spec.restore.recoveryOnce is set, recreating pod")
 	}
 
@@ -52,16 +72,14 @@ func (r *JenkinsBaseConfigurationReconciler) checkForPodRecreation(currentJenkin
 	}
 
 	//FIXME too hacky
-	var jenkinsSecurityContext *corev1.PodSecurityContext
-	if r.Configuration.Jenkins.Spec.Master.SecurityContext == nil {
+	jenkinsSecurityContext := resources.GetJenkinsMasterPodSecurityContext(r.Configuration.Jenkins)
+	if jenkinsSecurityContext == nil {
 		jenkinsSecurityContext = &corev1.PodSecurityContext{}
-	} else {
-		jenkinsSecurityContext = r.Configuration.Jenkins.Spec.Master.SecurityContext
 	}
 	if !reflect.DeepEqual(jenkinsSecurityContext, currentJenkinsMasterPod.Spec.SecurityContext) {
 		messages = append(messages, "Jenkins pod security context has changed")
 		verbose = append(verbose, fmt.Sprintf("Jenkins pod security context has changed, actual '%+v' required '%+v'",
-			currentJenkinsMasterPod.Spec.SecurityContext, r.Configuration.Jenkins.Spec.Master.SecurityContext))
+			currentJenkinsMasterPod.Spec.SecurityContext, jenkinsSecurityContext))
 	}
 
 	if !compareImagePullSecrets(r.Configuration.Jenkins.Spec.Master.ImagePullSecrets, currentJenkinsMasterPod.Spec.ImagePullSecrets) {
@@ -94,10 +112,11 @@ func (r *JenkinsBaseConfigurationReconciler) checkForPodRecreation(currentJenkin
 			currentJenkinsMasterPod.Spec.Volumes, r.Configuration.Jenkins.Spec.Master.Volumes))
 	}
 
-	if len(r.Configuration.Jenkins.Spec.Master.Containers) != len(currentJenkinsMasterPod.Spec.Containers) {
+	expectedContainers := resources.NewJenkinsMasterPodContainers(r.Configuration.Jenkins)
+	if len(expectedContainers) != len(currentJenkinsMasterPod.Spec.Containers) {
 		messages = append(messages, "Jenkins amount of containers has changed")
 		verbose = append(verbose, fmt.Sprintf("Jenkins amount of containers has changed, actual '%+v' required '%+v'",
-			len(currentJenkinsMasterPod.Spec.Containers), len(r.Configuration.Jenkins.Spec.Master.Containers)))
+			len(currentJenkinsMasterPod.Spec.Containers), len(expectedContainers)))
 	}
 
 	if r.Configuration.Jenkins.Spec.Master.PriorityClassName != currentJenkinsMasterPod.Spec.PriorityClassName {
@@ -106,6 +125,25 @@ func (r *JenkinsBaseConfigurationReconciler) checkForPodRecreation(currentJenkin
 			currentJenkinsMasterPod.Spec.PriorityClassName, r.Configuration.Jenkins.Spec.Master.PriorityClassName))
 	}
 
+	expectedInitContainers := resources.NewJenkinsMasterPodInitContainers(r.Configuration.Jenkins)
+	if len(expectedInitContainers) != len(currentJenkinsMasterPod.Spec.InitContainers) {
+		messages = append(messages, "Jenkins amount of init containers has changed")
+		verbose = append(verbose, fmt.Sprintf("Jenkins amount of init containers has changed, actual '%+v' required '%+v'",
+			len(currentJenkinsMasterPod.Spec.InitContainers), len(expectedInitContainers)))
+	} else {
+		for i, actualInitContainer := range currentJenkinsMasterPod.Spec.InitContainers {
+			// init containers are run in order, so they are compared by position
+			if expectedInitContainers[i].Name != actualInitContainer.Name {
+				messages = append(messages, "Jenkins init containers have changed")
+				verbose = append(verbose, fmt.Sprintf("Init container '%s' has been replaced by '%s'", actualInitContainer.Name, expectedInitContainers[i].Name))
+				continue
+			}
+			containerMessages, verboseMessages := r.compareContainers(expectedInitContainers[i], actualInitContainer)
+			messages = append(messages, containerMessages...)
+			verbose = append(verbose, verboseMessages...)
+		}
+	}
+
 	customResourceReplaced := (r.Configuration.Jenkins.Status.BaseConfigurationCompletedTime == nil ||
 		r.Configuration.Jenkins.Status.UserConfigurationCompletedTime == nil) &&
 		r.Configuration.Jenkins.Status.UserAndPasswordHash == ""
@@ -116,18 +154,10 @@ func (r *JenkinsBaseConfigurationReconciler) checkForPodRecreation(currentJenkin
 	}
 
 	for _, actualContainer := range currentJenkinsMasterPod.Spec.Containers {
-		if actualContainer.Name == resources.JenkinsMasterContainerName {
-			containerMessages, verboseMessages := r.compareContainers(resources.NewJenkinsMasterContainer(r.Configuration.Jenkins), actualContainer)
-			messages = append(messages, containerMessages...)
-			verbose = append(verbose, verboseMessages...)
-			continue
-		}
-
 		var expectedContainer *corev1.Container
-		for _, jenkinsContainer := range r.Configuration.Jenkins.Spec.Master.Containers {
-			if jenkinsContainer.Name == actualContainer.Name {
-				tmp := resources.ConvertJenkinsContainerToKubernetesContainer(jenkinsContainer)
-				expectedContainer = &tmp
+		for i, container := range expectedContainers {
+			if container.Name == actualContainer.Name {
+				expectedContainer = &expectedContainers[i]
 			}
 		}
 
@@ -152,9 +182,17 @@ func (r *JenkinsBaseConfigurationReconciler) ensureJenkinsMasterPod(meta metav1.
 		return reconcile.Result{}, err
 	}
 
+	if err = r.ensureJenkinsMasterWorkload(meta); err != nil {
+		return reconcile.Result{}, err
+	}
+
 	// Check if this Pod already exists
 	currentJenkinsMasterPod, err := r.Configuration.GetJenkinsMasterPod()
 	if err != nil && apierrors.IsNotFound(err) {
+		if useStatefulSetForJenkinsMaster(r.Configuration.Jenkins) {
+			r.logger.V(log.VDebug).Info("Waiting for the StatefulSet to create Jenkins master pod")
+			return reconcile.Result{Requeue: true}, nil
+		}
 		jenkinsMasterPod := resources.NewJenkinsMasterPod(meta, r.Configuration.Jenkins)
 		*r.Notifications <- event.Event{
 			Jenkins: *r.Configuration.Jenkins,
@@ -163,24 +201,30 @@ func (r *JenkinsBaseConfigurationReconciler) ensureJenkinsMasterPod(meta metav1.
 			Reason:  reason.NewPodCreation(reason.OperatorSource, []string{"Creating a new Jenkins Master Pod"}),
 		}
 		r.logger.Info(fmt.Sprintf("Creating a new Jenkins Master Pod %s/%s", jenkinsMasterPod.Namespace, jenkinsMasterPod.Name))
-		err = r.CreateResource(jenkinsMasterPod)
+		if r.ServerSideApply {
+			err = r.ApplyResource(jenkinsMasterPod)
+		} else {
+			err = r.CreateResource(jenkinsMasterPod)
+		}
 		if err != nil {
 			return reconcile.Result{}, stackerr.WithStack(err)
 		}
-
-		now := metav1.Now()
-		r.Configuration.Jenkins.Status = v1alpha2.JenkinsStatus{
-			OperatorVersion:     version.Version,
-			ProvisionStartTime:  &now,
-			LastBackup:          r.Configuration.Jenkins.Status.LastBackup,
-			PendingBackup:       r.Configuration.Jenkins.Status.LastBackup,
-			UserAndPasswordHash: userAndPasswordHash,
-		}
-		return reconcile.Result{Requeue: true}, r.Client.Status().Update(context.TODO(), r.Configuration.Jenkins)
+		return reconcile.Result{Requeue: true}, r.startProvisioning(userAndPasswordHash)
 	} else if err != nil && !apierrors.IsNotFound(err) {
 		return reconcile.Result{}, stackerr.WithStack(err)
 	}
 
+	if useStatefulSetForJenkinsMaster(r.Configuration.Jenkins) && isNewStatefulSetPod(r.Configuration.Jenkins, *currentJenkinsMasterPod) {
+		*r.Notifications <- event.Event{
+			Jenkins: *r.Configuration.Jenkins,
+			Phase:   event.PhaseBase,
+			Level:   v1alpha2.NotificationLevelInfo,
+			Reason:  reason.NewPodCreation(reason.KubernetesSource, []string{"Jenkins Master Pod has been created by the StatefulSet"}),
+		}
+		r.logger.Info(fmt.Sprintf("Jenkins Master Pod %s/%s has been created by the StatefulSet", currentJenkinsMasterPod.Namespace, currentJenkinsMasterPod.Name))
+		return reconcile.Result{Requeue: true}, r.startProvisioning(userAndPasswordHash)
+	}
+
 	if currentJenkinsMasterPod == nil {
 		return reconcile.Result{Requeue: true}, nil
 	}
@@ -202,16 +246,61 @@ func (r *JenkinsBaseConfigurationReconciler) ensureJenkinsMasterPod(meta metav1.
 		return reconcile.Result{Requeue: true}, nil
 	}
 
+	if err = r.updateContainerStatuses(*currentJenkinsMasterPod); err != nil {
+		return reconcile.Result{}, err
+	}
+
 	if !r.IsJenkinsTerminating(*currentJenkinsMasterPod) {
 		restartReason := r.checkForPodRecreation(*currentJenkinsMasterPod, userAndPasswordHash)
 		if restartReason.HasMessages() {
+			// the pod which doesn't run or hasn't been configured yet is recreated at any time
+			if currentJenkinsMasterPod.Status.Phase == corev1.PodRunning && r.Configuration.Jenkins.Status.UserConfigurationCompletedTime != nil &&
+				!isUrgentPodRecreation(restartReason) {
+				deferred, err := r.Configuration.DeferDisruptiveAction(v1alpha2.DisruptiveActionPodRestart, strings.Join(restartReason.Short(), "; "))
+				if err != nil {
+					return reconcile.Result{}, err
+				}
+				if deferred {
+					r.logger.V(log.VDebug).Info("Recreation of Jenkins master pod has been deferred until the maintenance window")
+					return reconcile.Result{}, nil
+				}
+			}
 			for _, msg := range restartReason.Verbose() {
 				r.logger.Info(msg)
 			}
 
 			return reconcile.Result{Requeue: true}, r.Configuration.RestartJenkinsMasterPod(restartReason)
 		}
+		if err = r.Configuration.ResolveDeferredAction(v1alpha2.DisruptiveActionPodRestart); err != nil {
+			return reconcile.Result{}, err
+		}
 	}
 
 	return reconcile.Result{}, nil
 }
+
+// startProvisioning resets the status of Jenkins CR for the new Jenkins master pod, the operator configures it from scratch
+func (r *JenkinsBaseConfigurationReconciler) startProvisioning(userAndPasswordHash string) error {
+	now := metav1.Now()
+	r.Configuration.Jenkins.Status = v1alpha2.JenkinsStatus{
+		OperatorVersion:     version.Version,
+		ProvisionStartTime:  &now,
+		LastBackup:          r.Configuration.Jenkins.Status.LastBackup,
+		LastBackupTime:      r.Configuration.Jenkins.Status.LastBackupTime,
+		PendingBackup:       r.Configuration.Jenkins.Status.LastBackup,
+		UserAndPasswordHash: userAndPasswordHash,
+		PluginUpdates:       r.Configuration.Jenkins.Status.PluginUpdates,
+		Persistence:         r.Configuration.Jenkins.Status.Persistence,
+		AuditedGeneration:   r.Configuration.Jenkins.Status.AuditedGeneration,
+		// builds completed during the restart are reported by the next scrape
+		Exporter: r.Configuration.Jenkins.Status.Exporter,
+		// the configuration which has failed in the canary isn't applied to the new pod
+		FailedCanaryConfigurationHash: r.Configuration.Jenkins.Status.FailedCanaryConfigurationHash,
+		// the credentials rotated before the restart are still in use
+		LastCredentialsRotationTime: r.Configuration.Jenkins.Status.LastCredentialsRotationTime,
+		// failures of the previous pod are reported until the new pod starts
+		Containers: r.Configuration.Jenkins.Status.Containers,
+	}
+	r.Configuration.Emit(k8sevent.TypeNormal, k8sevent.ReasonBaseConfigurationStarted, "Base configuration phase started")
+	return r.Client.Status().Update(context.TODO(), r.Configuration.Jenkins)
+}
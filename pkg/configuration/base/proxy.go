@@ -0,0 +1,45 @@
+package base
+
+import (
+	"context"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+
+	stackerr "github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// ensureProxySecret creates the Secret with the proxy URLs which contain the credentials from spec.proxy.credentials,
+// the credentials are URL-encoded so they can contain any characters
+func (r *JenkinsBaseConfigurationReconciler) ensureProxySecret(meta metav1.ObjectMeta) error {
+	proxy := r.Configuration.Jenkins.Spec.Proxy
+	if proxy == nil || proxy.Credentials == nil {
+		return nil
+	}
+
+	username, err := r.getSecretKeyValue(proxy.Credentials.UsernameSecretKeySelector)
+	if err != nil {
+		return err
+	}
+	password, err := r.getSecretKeyValue(proxy.Credentials.PasswordSecretKeySelector)
+	if err != nil {
+		return err
+	}
+	return stackerr.WithStack(r.CreateOrUpdateResource(resources.NewProxySecret(meta, r.Configuration.Jenkins, username, password)))
+}
+
+func (r *JenkinsBaseConfigurationReconciler) getSecretKeyValue(selector v1alpha2.SecretKeySelector) (string, error) {
+	secret := &corev1.Secret{}
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: selector.Name, Namespace: r.Configuration.Jenkins.Namespace}, secret)
+	if err != nil {
+		return "", stackerr.WithStack(err)
+	}
+	value, ok := secret.Data[selector.Key]
+	if !ok {
+		return "", stackerr.Errorf("key '%s' not found in Secret '%s'", selector.Key, selector.Name)
+	}
+	return string(value), nil
+}
@@ -84,6 +84,32 @@ func (r *JenkinsBaseConfigurationReconciler) ensureExtraRBAC(meta metav1.ObjectM
 	return nil
 }
 
+// ensureSecurityContextConstraintsRBAC grants SCCs from spec.openShift.securityContextConstraints to the Jenkins service account
+func (r *JenkinsBaseConfigurationReconciler) ensureSecurityContextConstraintsRBAC(meta metav1.ObjectMeta) error {
+	jenkins := r.Configuration.Jenkins
+	name := resources.GetSecurityContextConstraintsRoleName(jenkins)
+	if len(resources.GetSecurityContextConstraints(jenkins)) == 0 {
+		for _, obj := range []client.Object{&rbacv1.RoleBinding{}, &rbacv1.Role{}} {
+			obj.SetName(name)
+			obj.SetNamespace(meta.Namespace)
+			if err := r.Client.Delete(context.TODO(), obj); err != nil && !errors.IsNotFound(err) {
+				return stackerr.WithStack(err)
+			}
+		}
+		return nil
+	}
+
+	if err := r.CreateOrUpdateResource(resources.NewSecurityContextConstraintsRole(meta, jenkins)); err != nil {
+		return stackerr.WithStack(err)
+	}
+	roleBinding := resources.NewRoleBinding(name, meta.Namespace, meta.Name, rbacv1.RoleRef{
+		APIGroup: "rbac.authorization.k8s.io",
+		Kind:     "Role",
+		Name:     name,
+	})
+	return stackerr.WithStack(r.CreateOrUpdateResource(roleBinding))
+}
+
 func getExtraRoleBindingName(serviceAccountName string, roleRef rbacv1.RoleRef) string {
 	var typeName string
 	if roleRef.Kind == "ClusterRole" {
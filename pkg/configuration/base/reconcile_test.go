@@ -9,6 +9,7 @@ import (
 	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
 	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
 	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/reason"
 
 	"github.com/bndr/gojenkins"
 	"github.com/golang/mock/gomock"
@@ -184,7 +185,7 @@ func TestJenkinsBaseConfigurationReconciler_verifyPlugins(t *testing.T) {
 		got, err := r.verifyPlugins(jenkinsClient)
 
 		assert.NoError(t, err)
-		assert.True(t, got)
+		assert.Empty(t, got)
 	})
 	t.Run("happy, not empty base and user plugins", func(t *testing.T) {
 		jenkins := &v1alpha2.Jenkins{
@@ -229,7 +230,7 @@ func TestJenkinsBaseConfigurationReconciler_verifyPlugins(t *testing.T) {
 		got, err := r.verifyPlugins(jenkinsClient)
 
 		assert.NoError(t, err)
-		assert.True(t, got)
+		assert.Empty(t, got)
 	})
 	t.Run("happy, not empty base and empty user plugins", func(t *testing.T) {
 		jenkins := &v1alpha2.Jenkins{
@@ -266,7 +267,7 @@ func TestJenkinsBaseConfigurationReconciler_verifyPlugins(t *testing.T) {
 		got, err := r.verifyPlugins(jenkinsClient)
 
 		assert.NoError(t, err)
-		assert.True(t, got)
+		assert.Empty(t, got)
 	})
 	t.Run("happy, empty base and not empty user plugins", func(t *testing.T) {
 		jenkins := &v1alpha2.Jenkins{
@@ -303,7 +304,7 @@ func TestJenkinsBaseConfigurationReconciler_verifyPlugins(t *testing.T) {
 		got, err := r.verifyPlugins(jenkinsClient)
 
 		assert.NoError(t, err)
-		assert.True(t, got)
+		assert.Empty(t, got)
 	})
 	t.Run("happy, plugin version matter for base plugins", func(t *testing.T) {
 		jenkins := &v1alpha2.Jenkins{
@@ -340,7 +341,7 @@ func TestJenkinsBaseConfigurationReconciler_verifyPlugins(t *testing.T) {
 		got, err := r.verifyPlugins(jenkinsClient)
 
 		assert.NoError(t, err)
-		assert.False(t, got)
+		assert.Equal(t, []string{"Incompatible plugin 'plugin-name:0.0.1' version, actual '0.0.2'"}, got)
 	})
 	t.Run("plugin version matter for user plugins", func(t *testing.T) {
 		jenkins := &v1alpha2.Jenkins{
@@ -377,7 +378,7 @@ func TestJenkinsBaseConfigurationReconciler_verifyPlugins(t *testing.T) {
 		got, err := r.verifyPlugins(jenkinsClient)
 
 		assert.NoError(t, err)
-		assert.False(t, got)
+		assert.NotEmpty(t, got)
 	})
 	t.Run("missing base plugin", func(t *testing.T) {
 		jenkins := &v1alpha2.Jenkins{
@@ -406,7 +407,7 @@ func TestJenkinsBaseConfigurationReconciler_verifyPlugins(t *testing.T) {
 		got, err := r.verifyPlugins(jenkinsClient)
 
 		assert.NoError(t, err)
-		assert.False(t, got)
+		assert.NotEmpty(t, got)
 	})
 	t.Run("missing user plugin", func(t *testing.T) {
 		jenkins := &v1alpha2.Jenkins{
@@ -435,7 +436,7 @@ func TestJenkinsBaseConfigurationReconciler_verifyPlugins(t *testing.T) {
 		got, err := r.verifyPlugins(jenkinsClient)
 
 		assert.NoError(t, err)
-		assert.False(t, got)
+		assert.NotEmpty(t, got)
 	})
 }
 
@@ -994,3 +995,34 @@ func TestCompareContainerResources(t *testing.T) {
 		assert.False(t, got)
 	})
 }
+
+func TestGetContainerStatuses(t *testing.T) {
+	jenkins := &v1alpha2.Jenkins{Spec: v1alpha2.JenkinsSpec{Master: v1alpha2.JenkinsMaster{
+		InitContainers: []v1alpha2.Container{{Name: "seed"}},
+		Containers:     []v1alpha2.Container{{Name: resources.JenkinsMasterContainerName}, {Name: "oauth-proxy"}, {Name: "log-shipper"}},
+	}}}
+	pod := corev1.Pod{Status: corev1.PodStatus{
+		InitContainerStatuses: []corev1.ContainerStatus{
+			{Name: "seed", Ready: true, State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 0, Reason: "Completed"}}},
+		},
+		ContainerStatuses: []corev1.ContainerStatus{
+			{Name: resources.JenkinsMasterContainerName, Ready: true},
+			{Name: "oauth-proxy", RestartCount: 3, State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff", Message: "back-off 40s"}}},
+			{Name: "log-shipper", Ready: true, State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}},
+		},
+	}}
+
+	statuses := getContainerStatuses(jenkins, pod)
+
+	assert.Equal(t, []v1alpha2.ContainerStatus{
+		{Name: "seed", Type: v1alpha2.InitContainerType, Ready: true},
+		{Name: "oauth-proxy", Type: v1alpha2.SidecarContainerType, RestartCount: 3, Failure: "CrashLoopBackOff: back-off 40s"},
+		{Name: "log-shipper", Type: v1alpha2.SidecarContainerType, Ready: true},
+	}, statuses)
+}
+
+func TestIsUrgentPodRecreation(t *testing.T) {
+	assert.True(t, isUrgentPodRecreation(reason.NewPodRestart(reason.OperatorSource, []string{"Jenkins pod labels have changed", credentialsChangedMessage})))
+	assert.True(t, isUrgentPodRecreation(reason.NewPodRestart(reason.OperatorSource, []string{recoveryOnceMessage})))
+	assert.False(t, isUrgentPodRecreation(reason.NewPodRestart(reason.OperatorSource, []string{"Jenkins pod labels have changed"})))
+}
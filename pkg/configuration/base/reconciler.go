@@ -17,9 +17,11 @@ import (
 	"github.com/jenkinsci/kubernetes-operator/pkg/groovy"
 	"github.com/jenkinsci/kubernetes-operator/pkg/log"
 	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/reason"
+	"github.com/jenkinsci/kubernetes-operator/pkg/tracing"
 
 	"github.com/go-logr/logr"
 	stackerr "github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -43,17 +45,19 @@ type JenkinsBaseConfigurationReconciler struct {
 func New(config configuration.Configuration, jenkinsAPIConnectionSettings jenkinsclient.JenkinsAPIConnectionSettings) *JenkinsBaseConfigurationReconciler {
 	return &JenkinsBaseConfigurationReconciler{
 		Configuration:                config,
-		logger:                       log.Log.WithValues("cr", config.Jenkins.Name),
+		logger:                       log.ForJenkinsPhase(config.Jenkins, log.PhaseBase),
 		jenkinsAPIConnectionSettings: jenkinsAPIConnectionSettings,
 	}
 }
 
 // Reconcile takes care of base configuration.
-func (r *JenkinsBaseConfigurationReconciler) Reconcile() (reconcile.Result, jenkinsclient.Jenkins, error) {
+func (r *JenkinsBaseConfigurationReconciler) Reconcile(ctx context.Context) (reconcile.Result, jenkinsclient.Jenkins, error) {
 	metaObject := resources.NewResourceObjectMeta(r.Configuration.Jenkins)
 
 	// Create Necessary Resources
+	_, span := tracing.Start(ctx, "EnsureResources")
 	err := r.ensureResourcesRequiredForJenkinsPod(metaObject)
+	tracing.End(span, err)
 	if err != nil {
 		return reconcile.Result{}, nil, err
 	}
@@ -72,7 +76,19 @@ func (r *JenkinsBaseConfigurationReconciler) Reconcile() (reconcile.Result, jenk
 		return result, nil, err
 	}
 
-	result, err := r.ensureJenkinsMasterPod(metaObject)
+	if err := r.ensureRotatedOperatorCredentialsRecorded(); err != nil {
+		return reconcile.Result{}, nil, err
+	}
+
+	result, err := r.ensurePersistence()
+	if err != nil {
+		return reconcile.Result{}, nil, err
+	}
+	if result.Requeue {
+		return result, nil, nil
+	}
+
+	result, err = r.ensureJenkinsMasterPod(metaObject)
 	if err != nil {
 		return reconcile.Result{}, nil, err
 	}
@@ -98,40 +114,45 @@ func (r *JenkinsBaseConfigurationReconciler) Reconcile() (reconcile.Result, jenk
 	}
 	r.logger.V(log.VDebug).Info("Jenkins master pod is ready")
 
-	jenkinsClient, err := r.Configuration.GetJenkinsClient()
+	jenkinsClient, err := r.Configuration.GetJenkinsClient(ctx)
 	if err != nil {
 		return reconcile.Result{}, nil, err
 	}
 	r.logger.V(log.VDebug).Info("Jenkins API client set")
 
-	ok, err := r.verifyPlugins(jenkinsClient)
+	_, span = tracing.Start(ctx, "VerifyPlugins")
+	pluginMessages, err := r.verifyPlugins(jenkinsClient)
+	span.SetAttributes(attribute.Int("jenkins.plugins.invalid", len(pluginMessages)))
+	tracing.End(span, err)
 	if err != nil {
 		return reconcile.Result{}, nil, err
 	}
-	if !ok {
-		//TODO add what plugins have been changed
-		message := "Some plugins have changed, restarting Jenkins"
-		r.logger.Info(message)
-
-		restartReason := reason.NewPodRestart(
-			reason.OperatorSource,
-			[]string{message},
-		)
-		return reconcile.Result{Requeue: true}, nil, r.Configuration.RestartJenkinsMasterPod(restartReason)
+	result, err = r.ensurePluginBatch(jenkinsClient, pluginMessages)
+	if err != nil || result.Requeue {
+		return result, nil, err
 	}
 
+	_, span = tracing.Start(ctx, "EnsureBaseConfiguration")
 	result, err = r.ensureBaseConfiguration(jenkinsClient)
+	tracing.End(span, err)
+	if err != nil || result.Requeue {
+		return result, jenkinsClient, err
+	}
 
-	return result, jenkinsClient, err
+	rotated, err := r.rotateOperatorCredentials(jenkinsClient)
+	if err != nil {
+		return reconcile.Result{}, nil, err
+	}
+	if rotated {
+		// the client created before the rotation uses the revoked token
+		return reconcile.Result{Requeue: true}, nil, nil
+	}
+
+	return result, jenkinsClient, nil
 }
 
 func useDeploymentForJenkinsMaster(jenkins *v1alpha2.Jenkins) bool {
-	if val, ok := jenkins.Annotations["jenkins.io/use-deployment"]; ok {
-		if val == "true" {
-			return true
-		}
-	}
-	return false
+	return resources.GetWorkloadType(jenkins) == v1alpha2.WorkloadTypeDeployment
 }
 
 func (r *JenkinsBaseConfigurationReconciler) ensureResourcesRequiredForJenkinsPod(metaObject metav1.ObjectMeta) error {
@@ -140,6 +161,18 @@ func (r *JenkinsBaseConfigurationReconciler) ensureResourcesRequiredForJenkinsPo
 	}
 	r.logger.V(log.VDebug).Info("Operator credentials secret is present")
 
+	if err := r.ensureProxySecret(metaObject); err != nil {
+		return err
+	}
+	r.logger.V(log.VDebug).Info("Proxy secret is up to date")
+
+	if r.Configuration.Jenkins.Spec.UpdateCenterMirror != nil {
+		if err := r.createUpdateCenterMirrorSigningSecret(metaObject); err != nil {
+			return err
+		}
+		r.logger.V(log.VDebug).Info("Update center mirror signing secret is present")
+	}
+
 	if err := r.createScriptsConfigMap(metaObject); err != nil {
 		return err
 	}
@@ -175,18 +208,40 @@ func (r *JenkinsBaseConfigurationReconciler) ensureResourcesRequiredForJenkinsPo
 	}
 	r.logger.V(log.VDebug).Info("Extra role bindings are present")
 
+	if resources.IsOpenShift(&r.ClientSet) {
+		if err := r.ensureSecurityContextConstraintsRBAC(metaObject); err != nil {
+			return err
+		}
+		r.logger.V(log.VDebug).Info("SecurityContextConstraints role and role binding are up to date")
+	}
+
 	httpServiceName := resources.GetJenkinsHTTPServiceName(r.Configuration.Jenkins)
 	if err := r.createService(metaObject, httpServiceName, r.Configuration.Jenkins.Spec.Service, constants.DefaultHTTPPortInt32); err != nil {
 		return err
 	}
 	r.logger.V(log.VDebug).Info("Jenkins HTTP Service is present")
 
-	if err := r.createService(metaObject, resources.GetJenkinsSlavesServiceName(r.Configuration.Jenkins), r.Configuration.Jenkins.Spec.SlaveService, constants.DefaultSlavePortInt32); err != nil {
+	if err := r.ensureSlaveService(metaObject); err != nil {
+		return err
+	}
+	r.logger.V(log.VDebug).Info("Jenkins slave Service is up to date")
+
+	if err := r.ensureInboundAgentsNetworkPolicy(metaObject); err != nil {
+		return err
+	}
+	r.logger.V(log.VDebug).Info("Jenkins inbound agents NetworkPolicy is up to date")
+
+	if err := r.ensureMonitoring(metaObject); err != nil {
 		return err
 	}
-	r.logger.V(log.VDebug).Info("Jenkins slave Service is present")
+	r.logger.V(log.VDebug).Info("Jenkins monitoring resources are up to date")
 
-	if resources.IsRouteAPIAvailable(&r.ClientSet) {
+	if resources.IsRouteAPIAvailable(&r.ClientSet) && resources.IsRouteDisabled(r.Configuration.Jenkins) {
+		if err := r.deleteRoute(metaObject, r.Configuration.Jenkins); err != nil {
+			return err
+		}
+		r.logger.V(log.VDebug).Info("Jenkins Route is disabled")
+	} else if resources.IsRouteAPIAvailable(&r.ClientSet) {
 		r.logger.V(log.VDebug).Info("Route API is available. Now creating route.")
 		if err := r.createRoute(metaObject, httpServiceName, r.Configuration.Jenkins); err != nil {
 			return err
@@ -214,6 +269,23 @@ func (r *JenkinsBaseConfigurationReconciler) createOperatorCredentialsSecret(met
 	return stackerr.WithStack(r.UpdateResource(resources.NewOperatorCredentialsSecret(meta, r.Configuration.Jenkins)))
 }
 
+// createUpdateCenterMirrorSigningSecret creates the key which signs the update center mirror, the existing key is kept
+func (r *JenkinsBaseConfigurationReconciler) createUpdateCenterMirrorSigningSecret(meta metav1.ObjectMeta) error {
+	found := &corev1.Secret{}
+	err := r.Configuration.Client.Get(context.TODO(), types.NamespacedName{Name: resources.GetUpdateCenterMirrorName(r.Configuration.Jenkins), Namespace: r.Configuration.Jenkins.ObjectMeta.Namespace}, found)
+	if err == nil {
+		return nil
+	} else if !apierrors.IsNotFound(err) {
+		return stackerr.WithStack(err)
+	}
+
+	secret, err := resources.NewUpdateCenterMirrorSigningSecret(meta, r.Configuration.Jenkins)
+	if err != nil {
+		return err
+	}
+	return stackerr.WithStack(r.CreateResource(secret))
+}
+
 func (r *JenkinsBaseConfigurationReconciler) calculateUserAndPasswordHash() (string, error) {
 	credentialsSecret := &corev1.Secret{}
 	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: resources.GetOperatorCredentialsSecretName(r.Configuration.Jenkins), Namespace: r.Configuration.Jenkins.ObjectMeta.Namespace}, credentialsSecret)
@@ -2,6 +2,7 @@ package resources
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
 	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
@@ -10,15 +11,19 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// the base groovy scripts are applied in the order of their names, so the numeric prefixes are zero-padded
 const (
-	basicSettingsGroovyScriptName               = "1-basic-settings.groovy"
-	enableCSRFGroovyScriptName                  = "2-enable-csrf.groovy"
-	disableUsageStatsGroovyScriptName           = "3-disable-usage-stats.groovy"
-	enableMasterAccessControlGroovyScriptName   = "4-enable-master-access-control.groovy"
-	disableInsecureFeaturesGroovyScriptName     = "5-disable-insecure-features.groovy"
-	configureKubernetesPluginGroovyScriptName   = "6-configure-kubernetes-plugin.groovy"
-	configureViewsGroovyScriptName              = "7-configure-views.groovy"
-	disableJobDslScriptApprovalGroovyScriptName = "8-disable-job-dsl-script-approval.groovy"
+	basicSettingsGroovyScriptName                 = "01-basic-settings.groovy"
+	enableCSRFGroovyScriptName                    = "02-enable-csrf.groovy"
+	disableUsageStatsGroovyScriptName             = "03-disable-usage-stats.groovy"
+	enableMasterAccessControlGroovyScriptName     = "04-enable-master-access-control.groovy"
+	disableInsecureFeaturesGroovyScriptName       = "05-disable-insecure-features.groovy"
+	configureKubernetesPluginGroovyScriptName     = "06-configure-kubernetes-plugin.groovy"
+	configureViewsGroovyScriptName                = "07-configure-views.groovy"
+	configureJobDSLScriptSecurityGroovyScriptName = "08-configure-job-dsl-script-security.groovy"
+	configureProxyGroovyScriptName                = "09-configure-proxy.groovy"
+	configureUpdateCenterGroovyScriptName         = "10-configure-update-center.groovy"
+	configureAgentListenerGroovyScriptName        = "11-configure-agent-listener.groovy"
 )
 
 const basicSettingsFmt = `
@@ -130,6 +135,7 @@ kubernetes.setServerUrl("https://kubernetes.default.svc.%s:443")
 kubernetes.setNamespace("%s")
 kubernetes.setJenkinsUrl("%s")
 kubernetes.setJenkinsTunnel("%s")
+kubernetes.setWebSocket(%t)
 kubernetes.setRetentionTimeout(15)
 if (add) {
 	jenkins.clouds.add(kubernetes)
@@ -162,16 +168,119 @@ if (jenkins.getView(nonSeedViewName) == null) {
 jenkins.save()
 `
 
-const disableJobDSLScriptApproval = `
+// configureJobDSLScriptSecurityFmt enables script security of Job DSL scripts only with spec.scriptApproval, seed jobs
+// wait for their scripts to be approved then
+const configureJobDSLScriptSecurityFmt = `
 import jenkins.model.Jenkins
 import javaposse.jobdsl.plugin.GlobalJobDslSecurityConfiguration
 import jenkins.model.GlobalConfiguration
 
-// disable Job DSL script approval
-GlobalConfiguration.all().get(GlobalJobDslSecurityConfiguration.class).useScriptSecurity=false
+GlobalConfiguration.all().get(GlobalJobDslSecurityConfiguration.class).useScriptSecurity=%t
 GlobalConfiguration.all().get(GlobalJobDslSecurityConfiguration.class).save()
 `
 
+const configureProxyFmt = `
+import hudson.ProxyConfiguration
+import hudson.slaves.EnvironmentVariablesNodeProperty
+import jenkins.model.Jenkins
+
+def jenkins = Jenkins.instance
+
+// used by update center and plugin manager, the credentials are read from the environment variables set from Secrets
+jenkins.proxy = new ProxyConfiguration('%s', %s, %s, '%s')
+jenkins.proxy.save()
+
+// propagate proxy to builds on all agents
+def globalNodeProperties = jenkins.getGlobalNodeProperties()
+def envVarsNodeProperty = globalNodeProperties.get(EnvironmentVariablesNodeProperty.class)
+if (envVarsNodeProperty == null) {
+    envVarsNodeProperty = new EnvironmentVariablesNodeProperty()
+    globalNodeProperties.add(envVarsNodeProperty)
+}
+def envVars = envVarsNodeProperty.getEnvVars()
+%s
+jenkins.save()
+`
+
+const configureUpdateCenterFmt = `
+import hudson.model.UpdateCenter
+import hudson.model.UpdateSite
+import jenkins.model.Jenkins
+
+def updateCenter = Jenkins.instance.updateCenter
+def mirrorURL = '%s'
+def defaultURL = '%s'
+def mirrorEnabled = %t
+def site = updateCenter.getById(UpdateCenter.ID_DEFAULT)
+
+if (mirrorEnabled) {
+    // the plugin manager can install only plugins from the update center mirror
+    if (updateCenter.sites.size() != 1 || site?.url != mirrorURL) {
+        updateCenter.sites.replaceBy([new UpdateSite(UpdateCenter.ID_DEFAULT, mirrorURL)])
+        updateCenter.save()
+    }
+} else if (site?.url == mirrorURL) {
+    updateCenter.sites.replaceBy([new UpdateSite(UpdateCenter.ID_DEFAULT, defaultURL)])
+    updateCenter.save()
+}
+`
+
+const configureAgentListenerFmt = `
+import jenkins.model.Jenkins
+
+def jenkins = Jenkins.instance
+// -1 disables the TCP agent listener, agents connect over WebSocket then
+def port = %d
+
+if (jenkins.getSlaveAgentPort() != port) {
+    jenkins.setSlaveAgentPort(port)
+    jenkins.save()
+    println("TCP agent listener port set to ${port}")
+}
+`
+
+func buildConfigureProxyGroovyScript(jenkins *v1alpha2.Jenkins) string {
+	proxyURL := jenkins.Spec.Proxy.HTTPSProxy
+	if len(proxyURL) == 0 {
+		proxyURL = jenkins.Spec.Proxy.HTTPProxy
+	}
+	host, port, err := ParseProxyURL(proxyURL)
+	if err != nil {
+		return ""
+	}
+
+	var envVars []string
+	for _, env := range GetProxyEnvs(jenkins) {
+		if env.Name == ProxyUsernameEnvName || env.Name == ProxyPasswordEnvName {
+			continue
+		}
+		if env.ValueFrom != nil {
+			// the proxy URLs with the credentials are read from the proxy Secret in the Jenkins master container
+			envVars = append(envVars, fmt.Sprintf("envVars.put('%s', System.getenv('%s'))", escapeGroovyString(env.Name), escapeGroovyString(env.Name)))
+		} else {
+			envVars = append(envVars, fmt.Sprintf("envVars.put('%s', '%s')", escapeGroovyString(env.Name), escapeGroovyString(env.Value)))
+		}
+	}
+
+	credentials := "null, null"
+	if jenkins.Spec.Proxy.Credentials != nil {
+		credentials = fmt.Sprintf("System.getenv('%s'), System.getenv('%s')", ProxyUsernameEnvName, ProxyPasswordEnvName)
+	}
+	var nonProxyHosts []string
+	for _, nonProxyHost := range GetJavaNonProxyHosts(jenkins) {
+		nonProxyHosts = append(nonProxyHosts, escapeGroovyString(nonProxyHost))
+	}
+	return fmt.Sprintf(configureProxyFmt, escapeGroovyString(host), port, credentials, strings.Join(nonProxyHosts, "\\n"), strings.Join(envVars, "\n"))
+}
+
+// groovyStringReplacer escapes the characters which end or break single-quoted Groovy strings
+var groovyStringReplacer = strings.NewReplacer(`\`, `\\`, `'`, `\'`, "\n", `\n`, "\r", `\r`)
+
+// escapeGroovyString escapes the value put into the single-quoted Groovy string
+func escapeGroovyString(value string) string {
+	return groovyStringReplacer.Replace(value)
+}
+
 // GetBaseConfigurationConfigMapName returns name of Kubernetes config map used to base configuration.
 func GetBaseConfigurationConfigMapName(jenkins *v1alpha2.Jenkins) string {
 	return fmt.Sprintf("%s-base-configuration-%s", constants.OperatorName, jenkins.ObjectMeta.Name)
@@ -205,16 +314,27 @@ func NewBaseConfigurationConfigMap(meta metav1.ObjectMeta, jenkins *v1alpha2.Jen
 		configureKubernetesPluginGroovyScriptName: fmt.Sprintf(configureKubernetesPluginFmt,
 			clusterDomain,
 			jenkins.ObjectMeta.Namespace,
-			fmt.Sprintf("http://%s:%d%s", jenkinsServiceFQDN, jenkins.Spec.Service.Port, suffix),
-			fmt.Sprintf("%s:%d", jenkinsSlavesServiceFQDN, jenkins.Spec.SlaveService.Port),
+			GetJenkinsURL(jenkinsServiceFQDN, jenkins.Spec.Service.Port, suffix),
+			GetJenkinsTunnel(jenkinsSlavesServiceFQDN, jenkins.Spec.SlaveService.Port),
+			IsWebSocketAgentsEnabled(jenkins),
 		),
-		configureViewsGroovyScriptName:              configureViews,
-		disableJobDslScriptApprovalGroovyScriptName: disableJobDSLScriptApproval,
+		configureViewsGroovyScriptName:                configureViews,
+		configureJobDSLScriptSecurityGroovyScriptName: fmt.Sprintf(configureJobDSLScriptSecurityFmt, jenkins.Spec.ScriptApproval != nil),
+		configureUpdateCenterGroovyScriptName: fmt.Sprintf(configureUpdateCenterFmt,
+			GetUpdateCenterMirrorURL(), constants.DefaultJenkinsUpdateCenterURL, jenkins.Spec.UpdateCenterMirror != nil),
 	}
 
 	if jenkins.Spec.Master.DisableCSRFProtection {
 		delete(groovyScriptsMap, enableCSRFGroovyScriptName)
 	}
+	if jenkins.Spec.Proxy != nil {
+		if script := buildConfigureProxyGroovyScript(jenkins); len(script) > 0 {
+			groovyScriptsMap[configureProxyGroovyScriptName] = script
+		}
+	}
+	if jenkins.Spec.InboundAgents != nil {
+		groovyScriptsMap[configureAgentListenerGroovyScriptName] = fmt.Sprintf(configureAgentListenerFmt, getAgentListenerPort(jenkins))
+	}
 	return &corev1.ConfigMap{
 		TypeMeta:   buildConfigMapTypeMeta(),
 		ObjectMeta: meta,
@@ -0,0 +1,240 @@
+package resources
+
+import (
+	"fmt"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/securityprofile"
+	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// BuildLogArchivalContainerName is the name of the sidecar container which uploads build logs to external storage
+	BuildLogArchivalContainerName = "build-log-archival"
+	// BuildLogArchivalScriptName is the name of the script which uploads build logs, it's run by the sidecar container
+	BuildLogArchivalScriptName = "build-log-archival.sh"
+	// BuildLogArchivalGCSCredentialsKey is the Secret key with GCS service account key
+	BuildLogArchivalGCSCredentialsKey = "service-account.json"
+
+	buildLogArchivalCredentialsVolumeName = "build-log-archival-credentials"
+	buildLogArchivalCredentialsVolumePath = jenkinsPath + "/build-log-archival-credentials"
+
+	defaultBuildLogArchivalInterval = uint64(60)
+)
+
+// BuildLogArchivalS3CredentialsKeys are the Secret keys with S3 credentials
+var BuildLogArchivalS3CredentialsKeys = []string{"AWS_ACCESS_KEY_ID", "AWS_SECRET_ACCESS_KEY"}
+
+// buildLogArchivalScript uploads logs of finished builds, a build is finished when its build.xml contains the result.
+// Uploaded builds are marked, so logs are uploaded only once.
+const buildLogArchivalScript = `#!/bin/bash
+set -u
+
+JOBS_DIR="${JENKINS_HOME}/jobs"
+MARKER=".log-archived"
+
+trap 'exit 0' TERM INT
+
+if [[ "${PROVIDER}" == "GCS" && -n "${GOOGLE_APPLICATION_CREDENTIALS:-}" ]]; then
+  gcloud auth activate-service-account --key-file="${GOOGLE_APPLICATION_CREDENTIALS}" --quiet || exit 1
+fi
+
+json_escape() {
+  local value="${1//\\/\\\\}"
+  printf '%s' "${value//\"/\\\"}"
+}
+
+upload() {
+  local file="$1" key="$2" job="$3" build="$4" result="$5"
+  local archived_at retain_until=""
+  archived_at="$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+  if [[ "${RETENTION_DAYS}" -gt 0 ]]; then
+    retain_until="$(date -u -d "@$(( $(date +%s) + RETENTION_DAYS * 86400 ))" +%Y-%m-%dT%H:%M:%SZ)"
+  fi
+
+  if [[ "${PROVIDER}" == "S3" ]]; then
+    local metadata="{\"job\":\"$(json_escape "${job}")\",\"build\":\"${build}\",\"result\":\"${result}\",\"archived-at\":\"${archived_at}\""
+    local args=()
+    if [[ -n "${S3_ENDPOINT:-}" ]]; then
+      args+=(--endpoint-url "${S3_ENDPOINT}")
+    fi
+    args+=(s3api put-object --bucket "${BUCKET}" --key "${key}" --body "${file}" --content-type "text/plain")
+    if [[ -n "${retain_until}" ]]; then
+      metadata="${metadata},\"retain-until\":\"${retain_until}\""
+      args+=(--tagging "retention-days=${RETENTION_DAYS}")
+    fi
+    aws "${args[@]}" --metadata "${metadata}}" > /dev/null
+  else
+    local headers=(-h "Content-Type:text/plain" -h "Custom-Time:${archived_at}"
+      -h "x-goog-meta-job:${job}" -h "x-goog-meta-build:${build}" -h "x-goog-meta-result:${result}")
+    if [[ -n "${retain_until}" ]]; then
+      headers+=(-h "x-goog-meta-retention-days:${RETENTION_DAYS}" -h "x-goog-meta-retain-until:${retain_until}")
+    fi
+    gsutil -q "${headers[@]}" cp "${file}" "gs://${BUCKET}/${key}"
+  fi
+}
+
+archive() {
+  find "${JOBS_DIR}" -type f -name build.xml -path '*/builds/*' 2> /dev/null | while read -r build_xml; do
+    local build_dir build job result
+    build_dir="$(dirname "${build_xml}")"
+    if [[ -f "${build_dir}/${MARKER}" || ! -f "${build_dir}/log" ]]; then
+      continue
+    fi
+    result="$(sed -n 's:.*<result>\(.*\)</result>.*:\1:p' "${build_xml}" | head -n 1)"
+    if [[ -z "${result}" ]]; then
+      continue # the build is still running
+    fi
+
+    build="$(basename "${build_dir}")"
+    job="${build_dir#"${JOBS_DIR}"/}"
+    job="${job%/builds/*}"
+    job="${job//\/jobs\//\/}"
+    if upload "${build_dir}/log" "${PREFIX}${job}/${build}/log" "${job}" "${build}" "${result}"; then
+      touch "${build_dir}/${MARKER}"
+      if [[ "${DELETE_AFTER_UPLOAD}" == "true" ]]; then
+        rm -f "${build_dir}/log"
+      fi
+      echo "Archived build log of ${job} #${build}"
+    else
+      echo "Failed to archive build log of ${job} #${build}" >&2
+    fi
+  done
+}
+
+while true; do
+  archive
+  sleep "${INTERVAL}" &
+  wait $!
+done
+`
+
+// GetBuildLogArchivalImage returns image of the sidecar container which uploads build logs
+func GetBuildLogArchivalImage(jenkins *v1alpha2.Jenkins) string {
+	archival := jenkins.Spec.BuildLogArchival
+	if len(archival.Image) > 0 {
+		return archival.Image
+	}
+	if archival.Provider == v1alpha2.GCSBuildLogArchivalProvider {
+		return constants.DefaultBuildLogArchivalGCSImage
+	}
+	return constants.DefaultBuildLogArchivalS3Image
+}
+
+func getBuildLogArchivalEnvs(jenkins *v1alpha2.Jenkins) []corev1.EnvVar {
+	archival := jenkins.Spec.BuildLogArchival
+	interval := archival.Interval
+	if interval == 0 {
+		interval = defaultBuildLogArchivalInterval
+	}
+
+	envs := []corev1.EnvVar{
+		{Name: "JENKINS_HOME", Value: GetJenkinsHomePath(jenkins)},
+		{Name: "PROVIDER", Value: string(archival.Provider)},
+		{Name: "BUCKET", Value: archival.Bucket},
+		{Name: "PREFIX", Value: archival.Prefix},
+		{Name: "INTERVAL", Value: fmt.Sprintf("%d", interval)},
+		{Name: "RETENTION_DAYS", Value: fmt.Sprintf("%d", archival.RetentionDays)},
+		{Name: "DELETE_AFTER_UPLOAD", Value: fmt.Sprintf("%t", archival.DeleteAfterUpload)},
+	}
+
+	switch archival.Provider {
+	case v1alpha2.S3BuildLogArchivalProvider:
+		if len(archival.Region) > 0 {
+			envs = append(envs,
+				corev1.EnvVar{Name: "AWS_REGION", Value: archival.Region},
+				corev1.EnvVar{Name: "AWS_DEFAULT_REGION", Value: archival.Region},
+			)
+		}
+		if len(archival.Endpoint) > 0 {
+			envs = append(envs, corev1.EnvVar{Name: "S3_ENDPOINT", Value: archival.Endpoint})
+		}
+		if jenkins.Spec.TrustedCABundle != nil {
+			envs = append(envs, corev1.EnvVar{Name: "AWS_CA_BUNDLE", Value: fmt.Sprintf("%s/%s", TrustedCABundleVolumePath, GetTrustedCABundleKey(jenkins))})
+		}
+	case v1alpha2.GCSBuildLogArchivalProvider:
+		if archival.CredentialsSecret != nil {
+			envs = append(envs, corev1.EnvVar{
+				Name:  "GOOGLE_APPLICATION_CREDENTIALS",
+				Value: fmt.Sprintf("%s/%s", buildLogArchivalCredentialsVolumePath, BuildLogArchivalGCSCredentialsKey),
+			})
+		}
+		if jenkins.Spec.TrustedCABundle != nil {
+			envs = append(envs, corev1.EnvVar{Name: "CLOUDSDK_CORE_CUSTOM_CA_CERTS_FILE", Value: fmt.Sprintf("%s/%s", TrustedCABundleVolumePath, GetTrustedCABundleKey(jenkins))})
+		}
+	}
+
+	return envs
+}
+
+// isBuildLogArchivalCredentialsVolumeRequired returns true if GCS credentials are mounted into the sidecar container
+func isBuildLogArchivalCredentialsVolumeRequired(jenkins *v1alpha2.Jenkins) bool {
+	archival := jenkins.Spec.BuildLogArchival
+	return archival != nil && archival.Provider == v1alpha2.GCSBuildLogArchivalProvider && archival.CredentialsSecret != nil
+}
+
+func getBuildLogArchivalCredentialsVolume(jenkins *v1alpha2.Jenkins) corev1.Volume {
+	secretVolumeSourceDefaultMode := corev1.SecretVolumeSourceDefaultMode
+	return corev1.Volume{
+		Name: buildLogArchivalCredentialsVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				DefaultMode: &secretVolumeSourceDefaultMode,
+				SecretName:  jenkins.Spec.BuildLogArchival.CredentialsSecret.Name,
+			},
+		},
+	}
+}
+
+// NewBuildLogArchivalContainer returns Kubernetes container which uploads finished build logs to external storage
+func NewBuildLogArchivalContainer(jenkins *v1alpha2.Jenkins) corev1.Container {
+	archival := jenkins.Spec.BuildLogArchival
+
+	container := corev1.Container{
+		Name:            BuildLogArchivalContainerName,
+		Image:           GetBuildLogArchivalImage(jenkins),
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		Command:         []string{"bash", fmt.Sprintf("%s/%s", JenkinsScriptsVolumePath, BuildLogArchivalScriptName)},
+		Env:             getBuildLogArchivalEnvs(jenkins),
+		Resources:       archival.Resources,
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      JenkinsHomeVolumeName,
+				MountPath: GetJenkinsHomePath(jenkins),
+			},
+			{
+				Name:      jenkinsScriptsVolumeName,
+				MountPath: JenkinsScriptsVolumePath,
+				ReadOnly:  true,
+			},
+		},
+	}
+	if isResourceRequirementsEmpty(container.Resources) {
+		container.Resources = NewResourceRequirements("50m", "64Mi", "200m", "256Mi")
+	}
+
+	if archival.CredentialsSecret != nil {
+		switch archival.Provider {
+		case v1alpha2.S3BuildLogArchivalProvider:
+			container.EnvFrom = []corev1.EnvFromSource{{
+				SecretRef: &corev1.SecretEnvSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: archival.CredentialsSecret.Name},
+				},
+			}}
+		case v1alpha2.GCSBuildLogArchivalProvider:
+			container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+				Name:      buildLogArchivalCredentialsVolumeName,
+				MountPath: buildLogArchivalCredentialsVolumePath,
+				ReadOnly:  true,
+			})
+		}
+	}
+
+	return securityprofile.Container(securityprofile.Get(jenkins), withProxyAndTrustedCABundle(jenkins, container))
+}
+
+func isResourceRequirementsEmpty(requirements corev1.ResourceRequirements) bool {
+	return len(requirements.Requests) == 0 && len(requirements.Limits) == 0
+}
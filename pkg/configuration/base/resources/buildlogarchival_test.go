@@ -0,0 +1,113 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
+	"github.com/jenkinsci/kubernetes-operator/pkg/plugins"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newBuildLogArchivalJenkins(archival *v1alpha2.BuildLogArchival) *v1alpha2.Jenkins {
+	return &v1alpha2.Jenkins{
+		Spec: v1alpha2.JenkinsSpec{
+			Master: v1alpha2.JenkinsMaster{
+				Containers: []v1alpha2.Container{{
+					Name: JenkinsMasterContainerName,
+					ReadinessProbe: &corev1.Probe{
+						Handler: corev1.Handler{HTTPGet: &corev1.HTTPGetAction{Path: "/login"}},
+					},
+					LivenessProbe: &corev1.Probe{
+						Handler: corev1.Handler{HTTPGet: &corev1.HTTPGetAction{Path: "/login"}},
+					},
+				}},
+			},
+			BuildLogArchival: archival,
+		},
+	}
+}
+
+func TestNewBuildLogArchivalContainer(t *testing.T) {
+	t.Run("S3 with credentials", func(t *testing.T) {
+		jenkins := newBuildLogArchivalJenkins(&v1alpha2.BuildLogArchival{
+			Provider:          v1alpha2.S3BuildLogArchivalProvider,
+			Bucket:            "build-logs",
+			Prefix:            "jenkins/",
+			Region:            "eu-west-1",
+			CredentialsSecret: &v1alpha2.SecretRef{Name: "s3-credentials"},
+			RetentionDays:     30,
+		})
+
+		container := NewBuildLogArchivalContainer(jenkins)
+
+		assert.Equal(t, BuildLogArchivalContainerName, container.Name)
+		assert.Equal(t, constants.DefaultBuildLogArchivalS3Image, container.Image)
+		assert.Equal(t, corev1.PullIfNotPresent, container.ImagePullPolicy)
+		assert.Equal(t, NewResourceRequirements("50m", "64Mi", "200m", "256Mi"), container.Resources)
+		assert.Equal(t, []corev1.EnvFromSource{{
+			SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "s3-credentials"}},
+		}}, container.EnvFrom)
+		assert.Contains(t, container.Env, corev1.EnvVar{Name: "BUCKET", Value: "build-logs"})
+		assert.Contains(t, container.Env, corev1.EnvVar{Name: "PREFIX", Value: "jenkins/"})
+		assert.Contains(t, container.Env, corev1.EnvVar{Name: "INTERVAL", Value: "60"})
+		assert.Contains(t, container.Env, corev1.EnvVar{Name: "RETENTION_DAYS", Value: "30"})
+		assert.Contains(t, container.Env, corev1.EnvVar{Name: "AWS_REGION", Value: "eu-west-1"})
+		assert.Len(t, container.VolumeMounts, 2)
+		assert.False(t, isBuildLogArchivalCredentialsVolumeRequired(jenkins))
+	})
+	t.Run("GCS with credentials", func(t *testing.T) {
+		jenkins := newBuildLogArchivalJenkins(&v1alpha2.BuildLogArchival{
+			Provider:          v1alpha2.GCSBuildLogArchivalProvider,
+			Bucket:            "build-logs",
+			CredentialsSecret: &v1alpha2.SecretRef{Name: "gcs-credentials"},
+			Interval:          300,
+			Image:             "google/cloud-sdk:latest",
+			Resources:         NewResourceRequirements("10m", "32Mi", "100m", "128Mi"),
+		})
+
+		container := NewBuildLogArchivalContainer(jenkins)
+
+		assert.Equal(t, "google/cloud-sdk:latest", container.Image)
+		assert.Equal(t, NewResourceRequirements("10m", "32Mi", "100m", "128Mi"), container.Resources)
+		assert.Nil(t, container.EnvFrom)
+		assert.Contains(t, container.Env, corev1.EnvVar{Name: "INTERVAL", Value: "300"})
+		assert.Contains(t, container.Env, corev1.EnvVar{
+			Name:  "GOOGLE_APPLICATION_CREDENTIALS",
+			Value: buildLogArchivalCredentialsVolumePath + "/" + BuildLogArchivalGCSCredentialsKey,
+		})
+		assert.Contains(t, container.VolumeMounts, corev1.VolumeMount{
+			Name:      buildLogArchivalCredentialsVolumeName,
+			MountPath: buildLogArchivalCredentialsVolumePath,
+			ReadOnly:  true,
+		})
+		assert.True(t, isBuildLogArchivalCredentialsVolumeRequired(jenkins))
+		assert.Contains(t, GetJenkinsMasterPodBaseVolumes(jenkins), getBuildLogArchivalCredentialsVolume(jenkins))
+	})
+}
+
+func TestNewJenkinsMasterPodContainers_BuildLogArchival(t *testing.T) {
+	jenkins := newBuildLogArchivalJenkins(nil)
+	assert.Len(t, NewJenkinsMasterPodContainers(jenkins), 1)
+
+	jenkins.Spec.BuildLogArchival = &v1alpha2.BuildLogArchival{Provider: v1alpha2.S3BuildLogArchivalProvider, Bucket: "build-logs"}
+	containers := NewJenkinsMasterPodContainers(jenkins)
+	require.Len(t, containers, 2)
+	assert.Equal(t, BuildLogArchivalContainerName, containers[1].Name)
+}
+
+func TestNewScriptsConfigMap_BuildLogArchival(t *testing.T) {
+	jenkins := newBuildLogArchivalJenkins(nil)
+	configMap, err := NewScriptsConfigMap(metav1.ObjectMeta{}, jenkins, plugins.BasePluginDependencies())
+	require.NoError(t, err)
+	assert.NotContains(t, configMap.Data, BuildLogArchivalScriptName)
+
+	jenkins.Spec.BuildLogArchival = &v1alpha2.BuildLogArchival{Provider: v1alpha2.GCSBuildLogArchivalProvider, Bucket: "build-logs"}
+	configMap, err = NewScriptsConfigMap(metav1.ObjectMeta{}, jenkins, plugins.BasePluginDependencies())
+	require.NoError(t, err)
+	assert.Equal(t, buildLogArchivalScript, configMap.Data[BuildLogArchivalScriptName])
+}
@@ -0,0 +1,106 @@
+package resources
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	// CanaryConfigurationHashAnnotation is the hash of configuration verified by the canary Jenkins pod
+	CanaryConfigurationHashAnnotation = "jenkins.io/canary-configuration-hash"
+	// LabelCanaryKey is the label of canary Jenkins pod, its value is the name of Jenkins CR
+	LabelCanaryKey = "jenkins-canary"
+)
+
+// GetCanaryPodName returns the name of canary Jenkins pod
+func GetCanaryPodName(jenkins *v1alpha2.Jenkins) string {
+	return fmt.Sprintf("jenkins-%s-canary", jenkins.Name)
+}
+
+// GetCanaryServiceName returns the name of Kubernetes service which exposes canary Jenkins HTTP endpoint
+func GetCanaryServiceName(jenkins *v1alpha2.Jenkins) string {
+	return fmt.Sprintf("%s-canary-%s", constants.OperatorName, jenkins.ObjectMeta.Name)
+}
+
+// BuildCanaryLabels returns labels of canary Jenkins pod, they don't match selectors of Jenkins services
+func BuildCanaryLabels(jenkins *v1alpha2.Jenkins) map[string]string {
+	return map[string]string{
+		constants.LabelAppKey: constants.LabelAppValue,
+		LabelCanaryKey:        jenkins.Name,
+	}
+}
+
+// NewCanaryPod builds the canary Jenkins pod cloned from Jenkins master pod, Jenkins home, persistent volume claims
+// and host paths are empty dirs and build logs aren't archived
+func NewCanaryPod(objectMeta metav1.ObjectMeta, jenkins *v1alpha2.Jenkins, configurationHash string) *corev1.Pod {
+	canary := jenkins.DeepCopy()
+	canary.Spec.Persistence = nil
+	canary.Spec.BuildLogArchival = nil
+
+	pod := NewJenkinsMasterPod(objectMeta, canary)
+	pod.Name = GetCanaryPodName(jenkins)
+	// the canary mustn't write to volumes of Jenkins master, they're replaced by empty dirs
+	for i, volume := range pod.Spec.Volumes {
+		if volume.PersistentVolumeClaim != nil || volume.HostPath != nil {
+			pod.Spec.Volumes[i].VolumeSource = corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}
+		}
+	}
+	labels := map[string]string{}
+	for key, value := range pod.Labels {
+		if key != constants.LabelJenkinsCRKey {
+			labels[key] = value
+		}
+	}
+	for key, value := range BuildCanaryLabels(jenkins) {
+		labels[key] = value
+	}
+	pod.Labels = labels
+	annotations := map[string]string{}
+	for key, value := range pod.Annotations {
+		annotations[key] = value
+	}
+	annotations[CanaryConfigurationHashAnnotation] = configurationHash
+	annotations[constants.AnnotationJenkinsGenerationKey] = strconv.FormatInt(jenkins.Generation, 10)
+	pod.Annotations = annotations
+	return pod
+}
+
+// NewCanaryService builds Kubernetes service which exposes canary Jenkins HTTP endpoint, the node port is used
+// when the operator reaches Jenkins API through the node port
+func NewCanaryService(objectMeta metav1.ObjectMeta, jenkins *v1alpha2.Jenkins, useNodePort bool) *corev1.Service {
+	serviceType := corev1.ServiceTypeClusterIP
+	if useNodePort {
+		serviceType = corev1.ServiceTypeNodePort
+	}
+	return &corev1.Service{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       ServiceKind,
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GetCanaryServiceName(jenkins),
+			Namespace: objectMeta.Namespace,
+			Labels:    objectMeta.Labels,
+		},
+		Spec: corev1.ServiceSpec{
+			Type:           serviceType,
+			Selector:       BuildCanaryLabels(jenkins),
+			IPFamilyPolicy: jenkins.Spec.Service.IPFamilyPolicy,
+			IPFamilies:     jenkins.Spec.Service.IPFamilies,
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "http",
+					Port:       constants.DefaultHTTPPortInt32,
+					TargetPort: intstr.FromInt(int(constants.DefaultHTTPPortInt32)),
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,70 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewCanaryPod(t *testing.T) {
+	jenkins := &v1alpha2.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"},
+		Spec: v1alpha2.JenkinsSpec{
+			Master: v1alpha2.JenkinsMaster{
+				Labels: map[string]string{"team": "ci"},
+				Containers: []v1alpha2.Container{{
+					Name:           JenkinsMasterContainerName,
+					Image:          "jenkins/jenkins:lts",
+					ReadinessProbe: &corev1.Probe{Handler: corev1.Handler{HTTPGet: &corev1.HTTPGetAction{Path: "/login"}}},
+					LivenessProbe:  &corev1.Probe{Handler: corev1.Handler{HTTPGet: &corev1.HTTPGetAction{Path: "/login"}}},
+				}},
+			},
+			Persistence: &v1alpha2.Persistence{},
+		},
+	}
+	jenkins.Spec.Master.Volumes = []corev1.Volume{
+		{Name: "cache", VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "cache"}}},
+		{Name: "docker", VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/var/run/docker.sock"}}},
+		{Name: "config", VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: "config"}}}},
+	}
+
+	pod := NewCanaryPod(NewResourceObjectMeta(jenkins), jenkins, "hash")
+
+	assert.Equal(t, "jenkins-example-canary", pod.Name)
+	assert.Equal(t, map[string]string{constants.LabelAppKey: constants.LabelAppValue, LabelCanaryKey: "example", "team": "ci"}, pod.Labels)
+	assert.Equal(t, "hash", pod.Annotations[CanaryConfigurationHashAnnotation])
+	assert.Empty(t, jenkins.Spec.Master.Annotations)
+	var homeVolume *corev1.Volume
+	for i, volume := range pod.Spec.Volumes {
+		if volume.Name == JenkinsHomeVolumeName {
+			homeVolume = &pod.Spec.Volumes[i]
+		}
+	}
+	require.NotNil(t, homeVolume)
+	assert.NotNil(t, homeVolume.EmptyDir)
+	assert.NotNil(t, jenkins.Spec.Persistence)
+	volumes := map[string]corev1.Volume{}
+	for _, volume := range pod.Spec.Volumes {
+		volumes[volume.Name] = volume
+	}
+	assert.NotNil(t, volumes["cache"].EmptyDir)
+	assert.NotNil(t, volumes["docker"].EmptyDir)
+	assert.NotNil(t, volumes["config"].ConfigMap)
+	assert.NotNil(t, jenkins.Spec.Master.Volumes[0].PersistentVolumeClaim)
+
+	service := NewCanaryService(NewResourceObjectMeta(jenkins), jenkins, false)
+
+	assert.Equal(t, "jenkins-operator-canary-example", service.Name)
+	assert.Equal(t, BuildCanaryLabels(jenkins), service.Spec.Selector)
+	assert.Equal(t, corev1.ServiceTypeClusterIP, service.Spec.Type)
+	assert.Equal(t, corev1.ServiceTypeNodePort, NewCanaryService(NewResourceObjectMeta(jenkins), jenkins, true).Spec.Type)
+	for key, value := range service.Spec.Selector {
+		assert.Equal(t, value, pod.Labels[key])
+	}
+}
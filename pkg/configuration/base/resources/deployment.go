@@ -11,6 +11,9 @@ import (
 	"k8s.io/utils/pointer"
 )
 
+// UseDeploymentAnnotation is the annotation of Jenkins CR which makes the operator run Jenkins master in the Deployment
+const UseDeploymentAnnotation = "jenkins.io/use-deployment"
+
 // NewJenkinsMasterPod builds Jenkins Master Kubernetes Pod resource.
 func NewJenkinsDeployment(objectMeta metav1.ObjectMeta, jenkins *v1alpha2.Jenkins) *appsv1.Deployment {
 	serviceAccountName := objectMeta.Name
@@ -31,9 +34,10 @@ func NewJenkinsDeployment(objectMeta metav1.ObjectMeta, jenkins *v1alpha2.Jenkin
 				Spec: corev1.PodSpec{
 					ServiceAccountName: serviceAccountName,
 					NodeSelector:       jenkins.Spec.Master.NodeSelector,
-					Containers:         newContainers(jenkins),
+					InitContainers:     NewJenkinsMasterPodInitContainers(jenkins),
+					Containers:         NewJenkinsMasterPodContainers(jenkins),
 					Volumes:            append(GetJenkinsMasterPodBaseVolumes(jenkins), jenkins.Spec.Master.Volumes...),
-					SecurityContext:    jenkins.Spec.Master.SecurityContext,
+					SecurityContext:    GetJenkinsMasterPodSecurityContext(jenkins),
 					ImagePullSecrets:   jenkins.Spec.Master.ImagePullSecrets,
 					Tolerations:        jenkins.Spec.Master.Tolerations,
 					PriorityClassName:  jenkins.Spec.Master.PriorityClassName,
@@ -0,0 +1,143 @@
+package resources
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	// NetworkPolicyKind the kind name for NetworkPolicy
+	NetworkPolicyKind = "NetworkPolicy"
+	// AgentPortEnvName is the environment variable read by Jenkins image to set the TCP agent listener port on startup
+	AgentPortEnvName = "JENKINS_SLAVE_AGENT_PORT"
+	// disabledAgentPort disables the TCP agent listener in Jenkins
+	disabledAgentPort = -1
+)
+
+// GetInboundAgentsTCPPort returns the port of the TCP agent listener in Jenkins master container
+func GetInboundAgentsTCPPort(jenkins *v1alpha2.Jenkins) int32 {
+	if jenkins.Spec.InboundAgents == nil || jenkins.Spec.InboundAgents.TCPPort == nil {
+		return constants.DefaultSlavePortInt32
+	}
+	return *jenkins.Spec.InboundAgents.TCPPort
+}
+
+// IsTCPAgentListenerDisabled tells if agents can connect to Jenkins only over WebSocket
+func IsTCPAgentListenerDisabled(jenkins *v1alpha2.Jenkins) bool {
+	return jenkins.Spec.InboundAgents != nil && jenkins.Spec.InboundAgents.DisableTCP
+}
+
+// IsWebSocketAgentsEnabled tells if agents created by the operator connect to Jenkins over WebSocket
+func IsWebSocketAgentsEnabled(jenkins *v1alpha2.Jenkins) bool {
+	return jenkins.Spec.InboundAgents != nil && jenkins.Spec.InboundAgents.WebSocket
+}
+
+// getAgentListenerPort returns the TCP agent listener port configured in Jenkins, -1 disables the listener
+func getAgentListenerPort(jenkins *v1alpha2.Jenkins) int32 {
+	if IsTCPAgentListenerDisabled(jenkins) {
+		return disabledAgentPort
+	}
+	return GetInboundAgentsTCPPort(jenkins)
+}
+
+// getInboundAgentsEnvs returns the environment variable setting the TCP agent listener port on Jenkins startup,
+// it's set only when spec.inboundAgents is set to keep the existing pods
+func getInboundAgentsEnvs(jenkins *v1alpha2.Jenkins) []corev1.EnvVar {
+	if jenkins.Spec.InboundAgents == nil {
+		return nil
+	}
+	return []corev1.EnvVar{{Name: AgentPortEnvName, Value: strconv.Itoa(int(getAgentListenerPort(jenkins)))}}
+}
+
+// getJenkinsMasterContainerPorts returns the ports of Jenkins master container, the agent port is omitted when
+// the TCP agent listener is disabled
+func getJenkinsMasterContainerPorts(jenkins *v1alpha2.Jenkins) []corev1.ContainerPort {
+	ports := []corev1.ContainerPort{
+		{
+			Name:          httpPortName,
+			ContainerPort: constants.DefaultHTTPPortInt32,
+			Protocol:      corev1.ProtocolTCP,
+		},
+	}
+	if !IsTCPAgentListenerDisabled(jenkins) {
+		ports = append(ports, corev1.ContainerPort{
+			Name:          slavePortName,
+			ContainerPort: GetInboundAgentsTCPPort(jenkins),
+			Protocol:      corev1.ProtocolTCP,
+		})
+	}
+	return ports
+}
+
+// GetInboundAgentsNetworkPolicyName returns name of the NetworkPolicy of Jenkins master pod
+func GetInboundAgentsNetworkPolicyName(jenkins *v1alpha2.Jenkins) string {
+	return fmt.Sprintf("%s-agents", GetResourceName(jenkins))
+}
+
+// NewInboundAgentsNetworkPolicy returns the NetworkPolicy allowing connections to Jenkins HTTP endpoint and ports of
+// sidecars from everywhere and to the TCP agent listener from spec.inboundAgents.networkPolicy.from
+func NewInboundAgentsNetworkPolicy(meta metav1.ObjectMeta, jenkins *v1alpha2.Jenkins) *networkingv1.NetworkPolicy {
+	meta.Name = GetInboundAgentsNetworkPolicyName(jenkins)
+	tcp := corev1.ProtocolTCP
+	httpPort := intstr.FromInt(int(constants.DefaultHTTPPortInt32))
+	rules := []networkingv1.NetworkPolicyIngressRule{
+		{
+			Ports: append([]networkingv1.NetworkPolicyPort{{Protocol: &tcp, Port: &httpPort}}, getSidecarNetworkPolicyPorts(jenkins)...),
+		},
+	}
+	if !IsTCPAgentListenerDisabled(jenkins) {
+		agentPort := intstr.FromInt(int(GetInboundAgentsTCPPort(jenkins)))
+		from := []networkingv1.NetworkPolicyPeer{{PodSelector: &metav1.LabelSelector{}}}
+		if jenkins.Spec.InboundAgents != nil && jenkins.Spec.InboundAgents.NetworkPolicy != nil && len(jenkins.Spec.InboundAgents.NetworkPolicy.From) > 0 {
+			from = jenkins.Spec.InboundAgents.NetworkPolicy.From
+		}
+		rules = append(rules, networkingv1.NetworkPolicyIngressRule{
+			Ports: []networkingv1.NetworkPolicyPort{{Protocol: &tcp, Port: &agentPort}},
+			From:  from,
+		})
+	}
+
+	return &networkingv1.NetworkPolicy{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       NetworkPolicyKind,
+			APIVersion: "networking.k8s.io/v1",
+		},
+		ObjectMeta: meta,
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{MatchLabels: BuildResourceLabels(jenkins)},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+			Ingress:     rules,
+		},
+	}
+}
+
+// getSidecarNetworkPolicyPorts returns ports of sidecars from spec.master.containers, the TCP agent listener port stays
+// restricted
+func getSidecarNetworkPolicyPorts(jenkins *v1alpha2.Jenkins) []networkingv1.NetworkPolicyPort {
+	var ports []networkingv1.NetworkPolicyPort
+	for _, container := range jenkins.Spec.Master.Containers {
+		if container.Name == JenkinsMasterContainerName {
+			continue
+		}
+		for _, containerPort := range container.Ports {
+			if !IsTCPAgentListenerDisabled(jenkins) && containerPort.ContainerPort == GetInboundAgentsTCPPort(jenkins) {
+				continue
+			}
+			protocol := containerPort.Protocol
+			if len(protocol) == 0 {
+				protocol = corev1.ProtocolTCP
+			}
+			port := intstr.FromInt(int(containerPort.ContainerPort))
+			ports = append(ports, networkingv1.NetworkPolicyPort{Protocol: &protocol, Port: &port})
+		}
+	}
+	return ports
+}
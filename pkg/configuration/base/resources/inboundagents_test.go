@@ -0,0 +1,125 @@
+package resources
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetJenkinsMasterContainerPorts(t *testing.T) {
+	port := int32(50001)
+
+	t.Run("default", func(t *testing.T) {
+		ports := getJenkinsMasterContainerPorts(&v1alpha2.Jenkins{})
+
+		require.Len(t, ports, 2)
+		assert.Equal(t, int32(50000), ports[1].ContainerPort)
+		assert.Nil(t, getInboundAgentsEnvs(&v1alpha2.Jenkins{}))
+	})
+	t.Run("pinned port", func(t *testing.T) {
+		jenkins := &v1alpha2.Jenkins{Spec: v1alpha2.JenkinsSpec{InboundAgents: &v1alpha2.InboundAgents{TCPPort: &port}}}
+
+		ports := getJenkinsMasterContainerPorts(jenkins)
+
+		require.Len(t, ports, 2)
+		assert.Equal(t, port, ports[1].ContainerPort)
+		assert.Equal(t, []corev1.EnvVar{{Name: AgentPortEnvName, Value: "50001"}}, getInboundAgentsEnvs(jenkins))
+	})
+	t.Run("TCP agent listener disabled", func(t *testing.T) {
+		jenkins := &v1alpha2.Jenkins{Spec: v1alpha2.JenkinsSpec{InboundAgents: &v1alpha2.InboundAgents{DisableTCP: true, WebSocket: true}}}
+
+		ports := getJenkinsMasterContainerPorts(jenkins)
+
+		require.Len(t, ports, 1)
+		assert.Equal(t, httpPortName, ports[0].Name)
+		assert.Equal(t, []corev1.EnvVar{{Name: AgentPortEnvName, Value: "-1"}}, getInboundAgentsEnvs(jenkins))
+	})
+}
+
+func TestNewInboundAgentsNetworkPolicy(t *testing.T) {
+	port := int32(50001)
+	jenkins := &v1alpha2.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"},
+		Spec: v1alpha2.JenkinsSpec{
+			InboundAgents: &v1alpha2.InboundAgents{TCPPort: &port, NetworkPolicy: &v1alpha2.InboundAgentsNetworkPolicy{}},
+		},
+	}
+
+	t.Run("default peers", func(t *testing.T) {
+		networkPolicy := NewInboundAgentsNetworkPolicy(NewResourceObjectMeta(jenkins), jenkins)
+
+		assert.Equal(t, "jenkins-operator-example-agents", networkPolicy.Name)
+		assert.Equal(t, BuildResourceLabels(jenkins), networkPolicy.Spec.PodSelector.MatchLabels)
+		require.Len(t, networkPolicy.Spec.Ingress, 2)
+		assert.Equal(t, 8080, networkPolicy.Spec.Ingress[0].Ports[0].Port.IntValue())
+		assert.Empty(t, networkPolicy.Spec.Ingress[0].From)
+		assert.Equal(t, 50001, networkPolicy.Spec.Ingress[1].Ports[0].Port.IntValue())
+		assert.Equal(t, []networkingv1.NetworkPolicyPeer{{PodSelector: &metav1.LabelSelector{}}}, networkPolicy.Spec.Ingress[1].From)
+	})
+	t.Run("custom peers", func(t *testing.T) {
+		withPeers := jenkins.DeepCopy()
+		from := []networkingv1.NetworkPolicyPeer{{IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.0/8"}}}
+		withPeers.Spec.InboundAgents.NetworkPolicy.From = from
+
+		networkPolicy := NewInboundAgentsNetworkPolicy(NewResourceObjectMeta(withPeers), withPeers)
+
+		require.Len(t, networkPolicy.Spec.Ingress, 2)
+		assert.Equal(t, from, networkPolicy.Spec.Ingress[1].From)
+	})
+	t.Run("TCP agent listener disabled", func(t *testing.T) {
+		webSocketOnly := jenkins.DeepCopy()
+		webSocketOnly.Spec.InboundAgents = &v1alpha2.InboundAgents{DisableTCP: true, WebSocket: true, NetworkPolicy: &v1alpha2.InboundAgentsNetworkPolicy{}}
+
+		networkPolicy := NewInboundAgentsNetworkPolicy(NewResourceObjectMeta(webSocketOnly), webSocketOnly)
+
+		require.Len(t, networkPolicy.Spec.Ingress, 1)
+		assert.Equal(t, 8080, networkPolicy.Spec.Ingress[0].Ports[0].Port.IntValue())
+	})
+	t.Run("sidecar ports", func(t *testing.T) {
+		withSidecars := jenkins.DeepCopy()
+		withSidecars.Spec.Master.Containers = []v1alpha2.Container{
+			{Name: JenkinsMasterContainerName, Ports: []corev1.ContainerPort{{ContainerPort: 9090}}},
+			{Name: "exporter", Ports: []corev1.ContainerPort{{ContainerPort: 9100}, {ContainerPort: 50001}, {ContainerPort: 8125, Protocol: corev1.ProtocolUDP}}},
+		}
+
+		networkPolicy := NewInboundAgentsNetworkPolicy(NewResourceObjectMeta(withSidecars), withSidecars)
+
+		require.Len(t, networkPolicy.Spec.Ingress, 2)
+		ports := networkPolicy.Spec.Ingress[0].Ports
+		require.Len(t, ports, 3)
+		assert.Equal(t, 9100, ports[1].Port.IntValue())
+		assert.Equal(t, corev1.ProtocolTCP, *ports[1].Protocol)
+		assert.Equal(t, 8125, ports[2].Port.IntValue())
+		assert.Equal(t, corev1.ProtocolUDP, *ports[2].Protocol)
+		assert.Empty(t, networkPolicy.Spec.Ingress[0].From)
+	})
+}
+
+func TestNewBaseConfigurationConfigMap_AgentListenerScript(t *testing.T) {
+	jenkins := &v1alpha2.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"},
+		Spec: v1alpha2.JenkinsSpec{
+			Master:        v1alpha2.JenkinsMaster{Containers: []v1alpha2.Container{{Name: JenkinsMasterContainerName}}},
+			InboundAgents: &v1alpha2.InboundAgents{},
+		},
+	}
+
+	configMap, err := NewBaseConfigurationConfigMap(NewResourceObjectMeta(jenkins), jenkins, "cluster.local")
+
+	require.NoError(t, err)
+	var names []string
+	for name := range configMap.Data {
+		names = append(names, name)
+	}
+	// the scripts are run in the order of their names
+	sort.Strings(names)
+	assert.Equal(t, configureAgentListenerGroovyScriptName, names[len(names)-1])
+	assert.Equal(t, basicSettingsGroovyScriptName, names[0])
+}
@@ -34,6 +34,14 @@ if (!operatorUserCreatedFile.exists()) {
 	jenkins.save()
 
 	operatorUserCreatedFile.createNewFile()
+} else {
+	// the password could have been rotated by the operator, the secret is the source of truth
+	def operatorUser = hudson.model.User.getById(new File('{{ .OperatorCredentialsPath }}/{{ .OperatorUserNameFile }}').text, false)
+	if (operatorUser != null) {
+		operatorUser.addProperty(HudsonPrivateSecurityRealm.Details.fromPlainPassword(
+			new File('{{ .OperatorCredentialsPath }}/{{ .OperatorPasswordFile }}').text))
+		operatorUser.save()
+	}
 }
 {{- end }}
 `))
@@ -50,7 +58,7 @@ func buildCreateJenkinsOperatorUserGroovyScript(jenkins *v1alpha2.Jenkins) (*str
 		OperatorCredentialsPath:     jenkinsOperatorCredentialsVolumePath,
 		OperatorUserNameFile:        OperatorCredentialsSecretUserNameKey,
 		OperatorPasswordFile:        OperatorCredentialsSecretPasswordKey,
-		OperatorUserCreatedFilePath: getJenkinsHomePath(jenkins) + "/operatorUserCreated",
+		OperatorUserCreatedFilePath: GetJenkinsHomePath(jenkins) + "/operatorUserCreated",
 	}
 
 	output, err := render.Render(createOperatorUserGroovyFmtTemplate, data)
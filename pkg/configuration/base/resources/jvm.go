@@ -0,0 +1,59 @@
+package resources
+
+import (
+	"fmt"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// MaxHeapToMemoryLimitRatio is the maximum ratio of JVM heap size to Jenkins master container memory limit
+const MaxHeapToMemoryLimitRatio = 0.8
+
+var garbageCollectorFlags = map[v1alpha2.GarbageCollector]string{
+	v1alpha2.G1GarbageCollector:         "-XX:+UseG1GC",
+	v1alpha2.ParallelGarbageCollector:   "-XX:+UseParallelGC",
+	v1alpha2.SerialGarbageCollector:     "-XX:+UseSerialGC",
+	v1alpha2.ZGarbageCollector:          "-XX:+UseZGC",
+	v1alpha2.ShenandoahGarbageCollector: "-XX:+UseShenandoahGC",
+}
+
+// GetGarbageCollectorFlag returns JVM flag which enables given garbage collector
+func GetGarbageCollectorFlag(gc v1alpha2.GarbageCollector) (string, bool) {
+	flag, ok := garbageCollectorFlags[gc]
+	return flag, ok
+}
+
+// FormatJVMMemory formats quantity as JVM memory size, in megabytes if possible
+func FormatJVMMemory(quantity resource.Quantity) string {
+	bytes := quantity.Value()
+	const megabyte = 1024 * 1024
+	if bytes%megabyte == 0 {
+		return fmt.Sprintf("%dm", bytes/megabyte)
+	}
+	return fmt.Sprintf("%d", bytes)
+}
+
+// GetJVMOpts returns Java options built from spec.master.jvm
+func GetJVMOpts(jenkins *v1alpha2.Jenkins) []string {
+	jvm := jenkins.Spec.Master.JVM
+	if jvm == nil {
+		return nil
+	}
+
+	var opts []string
+	if jvm.InitialHeap != nil {
+		opts = append(opts, "-Xms"+FormatJVMMemory(*jvm.InitialHeap))
+	}
+	if jvm.MaxHeap != nil {
+		opts = append(opts, "-Xmx"+FormatJVMMemory(*jvm.MaxHeap))
+	}
+	if jvm.MaxMetaspace != nil {
+		opts = append(opts, "-XX:MaxMetaspaceSize="+FormatJVMMemory(*jvm.MaxMetaspace))
+	}
+	if flag, ok := GetGarbageCollectorFlag(jvm.GarbageCollector); ok {
+		opts = append(opts, flag)
+	}
+	return append(opts, jvm.ExtraFlags...)
+}
@@ -0,0 +1,42 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestFormatJVMMemory(t *testing.T) {
+	assert.Equal(t, "2048m", FormatJVMMemory(resource.MustParse("2Gi")))
+	assert.Equal(t, "512m", FormatJVMMemory(resource.MustParse("512Mi")))
+	assert.Equal(t, "1000000000", FormatJVMMemory(resource.MustParse("1G")))
+}
+
+func TestGetJVMOpts(t *testing.T) {
+	t.Run("not set", func(t *testing.T) {
+		assert.Nil(t, GetJVMOpts(&v1alpha2.Jenkins{}))
+	})
+	t.Run("all options", func(t *testing.T) {
+		initialHeap := resource.MustParse("1Gi")
+		maxHeap := resource.MustParse("2Gi")
+		maxMetaspace := resource.MustParse("256Mi")
+		jenkins := &v1alpha2.Jenkins{Spec: v1alpha2.JenkinsSpec{Master: v1alpha2.JenkinsMaster{JVM: &v1alpha2.JVM{
+			InitialHeap:      &initialHeap,
+			MaxHeap:          &maxHeap,
+			MaxMetaspace:     &maxMetaspace,
+			GarbageCollector: v1alpha2.G1GarbageCollector,
+			ExtraFlags:       []string{"-XX:+HeapDumpOnOutOfMemoryError"},
+		}}}}
+
+		assert.Equal(t, []string{
+			"-Xms1024m",
+			"-Xmx2048m",
+			"-XX:MaxMetaspaceSize=256m",
+			"-XX:+UseG1GC",
+			"-XX:+HeapDumpOnOutOfMemoryError",
+		}, GetJVMOpts(jenkins))
+	})
+}
@@ -0,0 +1,224 @@
+package resources
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/backup"
+	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
+
+	stackerr "github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	// GrafanaDashboardFileName is the key of Grafana dashboard in the dashboard ConfigMap
+	GrafanaDashboardFileName = "jenkins.json"
+
+	defaultBackupInterval      = uint64(30)
+	minBackupStaleSeconds      = uint64(60 * 60)
+	diskNearFullRatio          = 0.9
+	reconcileFailingForMinutes = 15
+)
+
+// PrometheusRuleGroupVersionKind is the kind of Prometheus Operator rules, it's used as unstructured object
+// so the operator doesn't depend on the Prometheus Operator API
+var PrometheusRuleGroupVersionKind = schema.GroupVersionKind{Group: "monitoring.coreos.com", Version: "v1", Kind: "PrometheusRule"}
+
+var defaultDashboardLabels = map[string]string{"grafana_dashboard": "1"}
+
+// GetPrometheusRuleName returns the name of PrometheusRule with alerts of Jenkins
+func GetPrometheusRuleName(jenkins *v1alpha2.Jenkins) string {
+	return fmt.Sprintf("%s-alerts-%s", constants.OperatorName, jenkins.ObjectMeta.Name)
+}
+
+// GetGrafanaDashboardConfigMapName returns the name of ConfigMap with Grafana dashboard of Jenkins
+func GetGrafanaDashboardConfigMapName(jenkins *v1alpha2.Jenkins) string {
+	return fmt.Sprintf("%s-dashboard-%s", constants.OperatorName, jenkins.ObjectMeta.Name)
+}
+
+// IsMonitoringEnabled returns true when spec.monitoring.enabled is set
+func IsMonitoringEnabled(jenkins *v1alpha2.Jenkins) bool {
+	return jenkins.Spec.Monitoring != nil && jenkins.Spec.Monitoring.Enabled
+}
+
+// NewPrometheusRule builds PrometheusRule with alerts of Jenkins based on the metrics exported by the operator
+func NewPrometheusRule(meta metav1.ObjectMeta, jenkins *v1alpha2.Jenkins) *unstructured.Unstructured {
+	selector := jenkinsMetricsSelector(jenkins)
+	rules := []interface{}{
+		newAlertingRule("JenkinsDown", fmt.Sprintf("jenkins_operator_jenkins_ready%s == 0", selector), "5m", "critical",
+			fmt.Sprintf("Jenkins %s/%s isn't ready", jenkins.Namespace, jenkins.Name)),
+		newAlertingRule("JenkinsReconcileFailing", fmt.Sprintf("rate(jenkins_operator_reconcile_errors_total%s[5m]) > 0", selector),
+			fmt.Sprintf("%dm", reconcileFailingForMinutes), "warning",
+			fmt.Sprintf("The operator has failed to reconcile Jenkins %s/%s for %d minutes", jenkins.Namespace, jenkins.Name, reconcileFailingForMinutes)),
+		newAlertingRule("JenkinsHomeDiskNearFull", fmt.Sprintf("jenkins_operator_jenkins_home_used_bytes%s / jenkins_operator_jenkins_home_capacity_bytes%s > %g", selector, selector, diskNearFullRatio),
+			"5m", "warning", fmt.Sprintf("Jenkins home of %s/%s is more than %d%% full", jenkins.Namespace, jenkins.Name, int(diskNearFullRatio*100))),
+	}
+	if len(jenkins.Spec.Backup.ContainerName) > 0 || backup.IsBackupConfigured(jenkins) {
+		staleSeconds := getBackupStaleSeconds(jenkins.Spec.Backup)
+		rules = append(rules, newAlertingRule("JenkinsBackupStale", fmt.Sprintf("time() - jenkins_operator_last_backup_timestamp_seconds%s > %d", selector, staleSeconds),
+			"5m", "warning", fmt.Sprintf("The last backup of Jenkins %s/%s is older than %d seconds", jenkins.Namespace, jenkins.Name, staleSeconds)))
+	}
+
+	labels := map[string]string{}
+	for key, value := range meta.Labels {
+		labels[key] = value
+	}
+	for key, value := range jenkins.Spec.Monitoring.RuleLabels {
+		labels[key] = value
+	}
+
+	rule := &unstructured.Unstructured{}
+	rule.SetGroupVersionKind(PrometheusRuleGroupVersionKind)
+	rule.SetName(GetPrometheusRuleName(jenkins))
+	rule.SetNamespace(meta.Namespace)
+	rule.SetLabels(labels)
+	rule.Object["spec"] = map[string]interface{}{
+		"groups": []interface{}{
+			map[string]interface{}{
+				"name":  fmt.Sprintf("jenkins-%s", jenkins.Name),
+				"rules": rules,
+			},
+		},
+	}
+	return rule
+}
+
+// NewGrafanaDashboardConfigMap builds ConfigMap with Grafana dashboard of Jenkins
+func NewGrafanaDashboardConfigMap(meta metav1.ObjectMeta, jenkins *v1alpha2.Jenkins) (*corev1.ConfigMap, error) {
+	dashboard, err := json.MarshalIndent(newGrafanaDashboard(jenkins), "", "  ")
+	if err != nil {
+		return nil, stackerr.WithStack(err)
+	}
+
+	dashboardLabels := jenkins.Spec.Monitoring.DashboardLabels
+	if len(dashboardLabels) == 0 {
+		dashboardLabels = defaultDashboardLabels
+	}
+	labels := map[string]string{}
+	for key, value := range meta.Labels {
+		labels[key] = value
+	}
+	for key, value := range dashboardLabels {
+		labels[key] = value
+	}
+
+	return &corev1.ConfigMap{
+		TypeMeta: buildConfigMapTypeMeta(),
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GetGrafanaDashboardConfigMapName(jenkins),
+			Namespace: meta.Namespace,
+			Labels:    labels,
+		},
+		Data: map[string]string{
+			GrafanaDashboardFileName: string(dashboard),
+		},
+	}, nil
+}
+
+func getBackupStaleSeconds(backup v1alpha2.Backup) uint64 {
+	interval := backup.Interval
+	if interval == 0 {
+		interval = defaultBackupInterval
+	}
+	if stale := 3 * interval; stale > minBackupStaleSeconds {
+		return stale
+	}
+	return minBackupStaleSeconds
+}
+
+func jenkinsMetricsSelector(jenkins *v1alpha2.Jenkins) string {
+	return fmt.Sprintf(`{namespace="%s",jenkins="%s"}`, jenkins.Namespace, jenkins.Name)
+}
+
+func newAlertingRule(name, expr, forDuration, severity, summary string) map[string]interface{} {
+	return map[string]interface{}{
+		"alert": name,
+		"expr":  expr,
+		"for":   forDuration,
+		"labels": map[string]interface{}{
+			"severity": severity,
+		},
+		"annotations": map[string]interface{}{
+			"summary": summary,
+		},
+	}
+}
+
+type grafanaTarget struct {
+	Expr         string `json:"expr"`
+	LegendFormat string `json:"legendFormat,omitempty"`
+}
+
+type grafanaGridPos struct {
+	H int `json:"h"`
+	W int `json:"w"`
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+type grafanaPanel struct {
+	ID      int             `json:"id"`
+	Title   string          `json:"title"`
+	Type    string          `json:"type"`
+	GridPos grafanaGridPos  `json:"gridPos"`
+	Targets []grafanaTarget `json:"targets"`
+}
+
+// newGrafanaDashboard builds the dashboard with the metrics of Jenkins, panels are laid out in two columns
+func newGrafanaDashboard(jenkins *v1alpha2.Jenkins) map[string]interface{} {
+	selector := jenkinsMetricsSelector(jenkins)
+	panels := []grafanaPanel{
+		{Title: "Ready", Type: "stat", Targets: []grafanaTarget{
+			{Expr: "jenkins_operator_jenkins_ready" + selector},
+		}},
+		{Title: "Reconcile errors", Type: "timeseries", Targets: []grafanaTarget{
+			{Expr: fmt.Sprintf("increase(jenkins_operator_reconcile_errors_total%s[5m])", selector), LegendFormat: "errors"},
+		}},
+		{Title: "Queue", Type: "timeseries", Targets: []grafanaTarget{
+			{Expr: "jenkins_operator_jenkins_queue_length" + selector, LegendFormat: "waiting"},
+			{Expr: "jenkins_operator_jenkins_queue_buildable" + selector, LegendFormat: "buildable"},
+			{Expr: "jenkins_operator_jenkins_queue_stuck" + selector, LegendFormat: "stuck"},
+		}},
+		{Title: "Executors", Type: "timeseries", Targets: []grafanaTarget{
+			{Expr: "jenkins_operator_jenkins_executors" + selector, LegendFormat: "all"},
+			{Expr: "jenkins_operator_jenkins_executors_busy" + selector, LegendFormat: "busy"},
+		}},
+		{Title: "Nodes", Type: "timeseries", Targets: []grafanaTarget{
+			{Expr: "jenkins_operator_jenkins_nodes" + selector, LegendFormat: "{{state}}"},
+		}},
+		{Title: "Build duration p95", Type: "timeseries", Targets: []grafanaTarget{
+			{Expr: fmt.Sprintf("histogram_quantile(0.95, sum by (le, result) (rate(jenkins_operator_jenkins_build_duration_seconds_bucket%s[1h])))", selector), LegendFormat: "{{result}}"},
+		}},
+		{Title: "Agent pods", Type: "timeseries", Targets: []grafanaTarget{
+			{Expr: "jenkins_operator_agent_pods_running" + selector, LegendFormat: "running"},
+			{Expr: "jenkins_operator_agent_pods_pending" + selector, LegendFormat: "pending"},
+			{Expr: "jenkins_operator_agent_pods_max_concurrent" + selector, LegendFormat: "max concurrent"},
+		}},
+		{Title: "Jenkins home disk usage", Type: "timeseries", Targets: []grafanaTarget{
+			{Expr: fmt.Sprintf("jenkins_operator_jenkins_home_used_bytes%s / jenkins_operator_jenkins_home_capacity_bytes%s", selector, selector), LegendFormat: "used"},
+		}},
+		{Title: "Time since the last backup", Type: "stat", Targets: []grafanaTarget{
+			{Expr: "time() - jenkins_operator_last_backup_timestamp_seconds" + selector},
+		}},
+	}
+	for i := range panels {
+		panels[i].ID = i + 1
+		panels[i].GridPos = grafanaGridPos{H: 8, W: 12, X: (i % 2) * 12, Y: (i / 2) * 8}
+	}
+
+	return map[string]interface{}{
+		"uid":           fmt.Sprintf("jenkins-%s-%s", jenkins.Namespace, jenkins.Name),
+		"title":         fmt.Sprintf("Jenkins %s/%s", jenkins.Namespace, jenkins.Name),
+		"tags":          []string{constants.OperatorName},
+		"timezone":      "browser",
+		"schemaVersion": 27,
+		"refresh":       "1m",
+		"time":          map[string]string{"from": "now-6h", "to": "now"},
+		"panels":        panels,
+	}
+}
@@ -0,0 +1,82 @@
+package resources
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestNewPrometheusRule(t *testing.T) {
+	jenkins := &v1alpha2.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"},
+		Spec: v1alpha2.JenkinsSpec{
+			Monitoring: &v1alpha2.Monitoring{Enabled: true, RuleLabels: map[string]string{"release": "prometheus"}},
+		},
+	}
+	getAlerts := func(t *testing.T, rule *unstructured.Unstructured) map[string]string {
+		groups, found, err := unstructured.NestedSlice(rule.Object, "spec", "groups")
+		require.NoError(t, err)
+		require.True(t, found)
+		require.Len(t, groups, 1)
+		alerts := map[string]string{}
+		for _, item := range groups[0].(map[string]interface{})["rules"].([]interface{}) {
+			alert := item.(map[string]interface{})
+			alerts[alert["alert"].(string)] = alert["expr"].(string)
+		}
+		return alerts
+	}
+
+	t.Run("without backup", func(t *testing.T) {
+		rule := NewPrometheusRule(NewResourceObjectMeta(jenkins), jenkins)
+
+		assert.Equal(t, PrometheusRuleGroupVersionKind, rule.GroupVersionKind())
+		assert.Equal(t, "jenkins-operator-alerts-example", rule.GetName())
+		assert.Equal(t, "prometheus", rule.GetLabels()["release"])
+		assert.Equal(t, map[string]string{
+			"JenkinsDown":             `jenkins_operator_jenkins_ready{namespace="default",jenkins="example"} == 0`,
+			"JenkinsReconcileFailing": `rate(jenkins_operator_reconcile_errors_total{namespace="default",jenkins="example"}[5m]) > 0`,
+			"JenkinsHomeDiskNearFull": `jenkins_operator_jenkins_home_used_bytes{namespace="default",jenkins="example"} / jenkins_operator_jenkins_home_capacity_bytes{namespace="default",jenkins="example"} > 0.9`,
+		}, getAlerts(t, rule))
+	})
+	t.Run("with backup", func(t *testing.T) {
+		withBackup := jenkins.DeepCopy()
+		withBackup.Spec.Backup = v1alpha2.Backup{ContainerName: "backup", Interval: 7200}
+
+		alerts := getAlerts(t, NewPrometheusRule(NewResourceObjectMeta(withBackup), withBackup))
+
+		assert.Equal(t, `time() - jenkins_operator_last_backup_timestamp_seconds{namespace="default",jenkins="example"} > 21600`, alerts["JenkinsBackupStale"])
+	})
+}
+
+func TestNewGrafanaDashboardConfigMap(t *testing.T) {
+	jenkins := &v1alpha2.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"},
+		Spec:       v1alpha2.JenkinsSpec{Monitoring: &v1alpha2.Monitoring{Enabled: true}},
+	}
+
+	configMap, err := NewGrafanaDashboardConfigMap(NewResourceObjectMeta(jenkins), jenkins)
+
+	require.NoError(t, err)
+	assert.Equal(t, "jenkins-operator-dashboard-example", configMap.Name)
+	assert.Equal(t, "1", configMap.Labels["grafana_dashboard"])
+	dashboard := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal([]byte(configMap.Data[GrafanaDashboardFileName]), &dashboard))
+	assert.Equal(t, "Jenkins default/example", dashboard["title"])
+	assert.NotEmpty(t, dashboard["panels"])
+
+	t.Run("custom labels", func(t *testing.T) {
+		jenkins.Spec.Monitoring.DashboardLabels = map[string]string{"dashboards": "jenkins"}
+
+		configMap, err := NewGrafanaDashboardConfigMap(NewResourceObjectMeta(jenkins), jenkins)
+
+		require.NoError(t, err)
+		assert.Equal(t, "jenkins", configMap.Labels["dashboards"])
+		assert.NotContains(t, configMap.Labels, "grafana_dashboard")
+	})
+}
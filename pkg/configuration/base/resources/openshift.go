@@ -0,0 +1,95 @@
+package resources
+
+import (
+	"fmt"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	securityv1 "github.com/openshift/api/security/v1"
+	v1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// SecurityContextConstraintsResource is the resource of OpenShift SecurityContextConstraints
+	SecurityContextConstraintsResource = "securitycontextconstraints"
+	useVerb                            = "use"
+)
+
+var isSecurityContextConstraintsAPIAvailable = false
+var securityContextConstraintsAPIChecked = false
+var allowedSecurityContextConstraints []string
+
+// IsOpenShift tells if the operator runs in OpenShift, the SecurityContextConstraints API is available only there
+func IsOpenShift(clientSet *kubernetes.Clientset) bool {
+	if securityContextConstraintsAPIChecked {
+		return isSecurityContextConstraintsAPIAvailable
+	}
+	err := discovery.ServerSupportsVersion(clientSet, securityv1.SchemeGroupVersion)
+	securityContextConstraintsAPIChecked = true
+	isSecurityContextConstraintsAPIAvailable = err == nil
+	return isSecurityContextConstraintsAPIAvailable
+}
+
+// AssumeOpenShift makes IsOpenShift return the value without asking the API server, e.g. when Jenkins CR is validated
+// offline
+func AssumeOpenShift(openShift bool) {
+	isSecurityContextConstraintsAPIAvailable = openShift
+	securityContextConstraintsAPIChecked = true
+}
+
+// AllowSecurityContextConstraints sets names of SCCs which can be granted to Jenkins service accounts, the operator role
+// must be allowed to use them
+func AllowSecurityContextConstraints(names []string) {
+	allowedSecurityContextConstraints = names
+}
+
+// IsSecurityContextConstraintsAllowed tells if the SCC can be granted to Jenkins service accounts
+func IsSecurityContextConstraintsAllowed(name string) bool {
+	for _, allowed := range allowedSecurityContextConstraints {
+		if allowed == name {
+			return true
+		}
+	}
+	return false
+}
+
+// GetSecurityContextConstraints returns names of SCCs granted to the Jenkins service account
+func GetSecurityContextConstraints(jenkins *v1alpha2.Jenkins) []string {
+	if jenkins.Spec.OpenShift == nil {
+		return nil
+	}
+	return jenkins.Spec.OpenShift.SecurityContextConstraints
+}
+
+// GetSecurityContextConstraintsRoleName returns name of the role granting SCCs to the Jenkins service account
+func GetSecurityContextConstraintsRoleName(jenkins *v1alpha2.Jenkins) string {
+	return fmt.Sprintf("%s-scc", GetResourceName(jenkins))
+}
+
+// NewSecurityContextConstraintsRole returns rbac role allowing to use SCCs from spec.openShift.securityContextConstraints
+func NewSecurityContextConstraintsRole(meta metav1.ObjectMeta, jenkins *v1alpha2.Jenkins) *v1.Role {
+	meta.Name = GetSecurityContextConstraintsRoleName(jenkins)
+	return &v1.Role{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Role",
+			APIVersion: "rbac.authorization.k8s.io/v1",
+		},
+		ObjectMeta: meta,
+		Rules: []v1.PolicyRule{
+			{
+				APIGroups:     []string{securityv1.GroupName},
+				Resources:     []string{SecurityContextConstraintsResource},
+				ResourceNames: GetSecurityContextConstraints(jenkins),
+				Verbs:         []string{useVerb},
+			},
+		},
+	}
+}
+
+// IsRouteDisabled tells if the Route exposing Jenkins mustn't be created
+func IsRouteDisabled(jenkins *v1alpha2.Jenkins) bool {
+	return jenkins.Spec.OpenShift != nil && jenkins.Spec.OpenShift.Route.Disabled
+}
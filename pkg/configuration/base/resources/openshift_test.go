@@ -0,0 +1,91 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	routev1 "github.com/openshift/api/route/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewSecurityContextConstraintsRole(t *testing.T) {
+	jenkins := &v1alpha2.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"},
+		Spec: v1alpha2.JenkinsSpec{
+			OpenShift: &v1alpha2.OpenShift{SecurityContextConstraints: []string{"nonroot"}},
+		},
+	}
+
+	role := NewSecurityContextConstraintsRole(NewResourceObjectMeta(jenkins), jenkins)
+
+	assert.Equal(t, "jenkins-operator-example-scc", role.Name)
+	assert.Equal(t, "default", role.Namespace)
+	require.Len(t, role.Rules, 1)
+	assert.Equal(t, []string{"security.openshift.io"}, role.Rules[0].APIGroups)
+	assert.Equal(t, []string{"securitycontextconstraints"}, role.Rules[0].Resources)
+	assert.Equal(t, []string{"nonroot"}, role.Rules[0].ResourceNames)
+	assert.Equal(t, []string{"use"}, role.Rules[0].Verbs)
+}
+
+func TestUpdateRoute(t *testing.T) {
+	jenkins := &v1alpha2.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{Name: "example"},
+		Spec: v1alpha2.JenkinsSpec{
+			Service: v1alpha2.Service{Port: 8080},
+		},
+	}
+	route := routev1.Route{Spec: routev1.RouteSpec{Host: "jenkins-example.apps.example.com", Port: &routev1.RoutePort{}}}
+
+	t.Run("generated host", func(t *testing.T) {
+		actual := UpdateRoute(*route.DeepCopy(), jenkins)
+
+		assert.Equal(t, "jenkins-operator-http-example", actual.Spec.To.Name)
+		assert.Equal(t, int32(8080), actual.Spec.Port.TargetPort.IntVal)
+		assert.Equal(t, "jenkins-example.apps.example.com", actual.Spec.Host)
+	})
+	t.Run("spec.openShift.route", func(t *testing.T) {
+		withRoute := jenkins.DeepCopy()
+		withRoute.Spec.OpenShift = &v1alpha2.OpenShift{Route: v1alpha2.Route{
+			Host:        "jenkins.example.com",
+			Annotations: map[string]string{"haproxy.router.openshift.io/timeout": "5m"},
+		}}
+
+		actual := UpdateRoute(*route.DeepCopy(), withRoute)
+
+		assert.Equal(t, "jenkins.example.com", actual.Spec.Host)
+		assert.Equal(t, map[string]string{
+			"haproxy.router.openshift.io/timeout": "5m",
+			RouteAnnotationsAnnotation:            "haproxy.router.openshift.io/timeout",
+			RouteHostAnnotation:                   "jenkins.example.com",
+		}, actual.Annotations)
+		assert.False(t, IsRouteHostCleared(actual, withRoute))
+	})
+	t.Run("removed spec.openShift.route", func(t *testing.T) {
+		withRoute := jenkins.DeepCopy()
+		withRoute.Spec.OpenShift = &v1alpha2.OpenShift{Route: v1alpha2.Route{
+			Host:        "jenkins.example.com",
+			Annotations: map[string]string{"haproxy.router.openshift.io/timeout": "5m", "example.com/removed": "true"},
+		}}
+		actual := UpdateRoute(*route.DeepCopy(), withRoute)
+		actual.Annotations["example.com/other"] = "kept"
+		delete(withRoute.Spec.OpenShift.Route.Annotations, "example.com/removed")
+		withRoute.Spec.OpenShift.Route.Host = ""
+
+		assert.True(t, IsRouteHostCleared(actual, withRoute))
+		actual = UpdateRoute(actual, withRoute)
+
+		assert.Equal(t, map[string]string{
+			"haproxy.router.openshift.io/timeout": "5m",
+			"example.com/other":                   "kept",
+			RouteAnnotationsAnnotation:            "haproxy.router.openshift.io/timeout",
+		}, actual.Annotations)
+
+		actual = UpdateRoute(actual, jenkins)
+
+		assert.Equal(t, map[string]string{"example.com/other": "kept"}, actual.Annotations)
+		assert.False(t, IsRouteHostCleared(actual, jenkins))
+	})
+}
@@ -45,7 +45,12 @@ func NewOperatorCredentialsSecret(meta metav1.ObjectMeta, jenkins *v1alpha2.Jenk
 		ObjectMeta: meta,
 		Data: map[string][]byte{
 			OperatorCredentialsSecretUserNameKey: []byte(OperatorUserName),
-			OperatorCredentialsSecretPasswordKey: []byte(randomString(20)),
+			OperatorCredentialsSecretPasswordKey: []byte(NewOperatorPassword()),
 		},
 	}
 }
+
+// NewOperatorPassword generates the random password of the operator user
+func NewOperatorPassword() string {
+	return randomString(20)
+}
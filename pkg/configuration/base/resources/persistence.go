@@ -0,0 +1,141 @@
+package resources
+
+import (
+	"fmt"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/securityprofile"
+	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	// JenkinsHomeMigrationContainerName is the name of the container which copies Jenkins home to the new claim
+	JenkinsHomeMigrationContainerName = "jenkins-home-migration"
+
+	jenkinsHomeMigrationSourceVolumeName = "source"
+	jenkinsHomeMigrationSourcePath       = "/source"
+	jenkinsHomeMigrationTargetVolumeName = "target"
+	jenkinsHomeMigrationTargetPath       = "/target"
+)
+
+// VolumeSnapshotGroupVersionKind is the kind of CSI volume snapshots, it's used as unstructured object
+// so the operator doesn't depend on the snapshot controller API
+var VolumeSnapshotGroupVersionKind = schema.GroupVersionKind{Group: "snapshot.storage.k8s.io", Version: "v1", Kind: "VolumeSnapshot"}
+
+// GetJenkinsHomeClaimName returns the name of PersistentVolumeClaim mounted as Jenkins home, status.persistence.claimName
+// is set from the claim labeled as active before Jenkins master pod is created
+func GetJenkinsHomeClaimName(jenkins *v1alpha2.Jenkins) string {
+	if jenkins.Status.Persistence != nil && len(jenkins.Status.Persistence.ClaimName) > 0 {
+		return jenkins.Status.Persistence.ClaimName
+	}
+	return fmt.Sprintf("%s-home-%s", constants.OperatorName, jenkins.ObjectMeta.Name)
+}
+
+// GetJenkinsHomeMigrationClaimName returns the name of PersistentVolumeClaim with the new storage class
+func GetJenkinsHomeMigrationClaimName(jenkins *v1alpha2.Jenkins, storageClassName string) string {
+	return fmt.Sprintf("%s-home-%s-%s", constants.OperatorName, jenkins.ObjectMeta.Name, storageClassName)
+}
+
+// GetJenkinsHomeMigrationPodName returns the name of the pod which copies Jenkins home to the new claim
+func GetJenkinsHomeMigrationPodName(jenkins *v1alpha2.Jenkins) string {
+	return fmt.Sprintf("%s-home-migration-%s", constants.OperatorName, jenkins.ObjectMeta.Name)
+}
+
+// NewJenkinsHomePersistentVolumeClaim builds Jenkins home PersistentVolumeClaim from spec.persistence
+func NewJenkinsHomePersistentVolumeClaim(jenkins *v1alpha2.Jenkins, name string, storageClassName *string) *corev1.PersistentVolumeClaim {
+	persistence := jenkins.Spec.Persistence
+	accessModes := persistence.AccessModes
+	if len(accessModes) == 0 {
+		accessModes = []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}
+	}
+	volumeMode := corev1.PersistentVolumeFilesystem
+	if persistence.VolumeMode != nil {
+		volumeMode = *persistence.VolumeMode
+	}
+
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: jenkins.ObjectMeta.Namespace,
+			Labels:    BuildResourceLabels(jenkins),
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      accessModes,
+			StorageClassName: storageClassName,
+			VolumeMode:       &volumeMode,
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: persistence.Size},
+			},
+		},
+	}
+}
+
+// NewJenkinsHomeMigrationPod builds the pod which copies Jenkins home from the old claim to the new one,
+// it runs with the Jenkins master image and security context, so the file ownership is kept
+func NewJenkinsHomeMigrationPod(jenkins *v1alpha2.Jenkins, sourceClaimName, targetClaimName string) *corev1.Pod {
+	jenkinsContainer := jenkins.Spec.Master.Containers[0]
+	return &corev1.Pod{
+		TypeMeta: buildPodTypeMeta(),
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GetJenkinsHomeMigrationPodName(jenkins),
+			Namespace: jenkins.ObjectMeta.Namespace,
+			Labels:    BuildResourceLabels(jenkins),
+		},
+		Spec: corev1.PodSpec{
+			RestartPolicy:    corev1.RestartPolicyNever,
+			NodeSelector:     jenkins.Spec.Master.NodeSelector,
+			SecurityContext:  GetJenkinsMasterPodSecurityContext(jenkins),
+			ImagePullSecrets: jenkins.Spec.Master.ImagePullSecrets,
+			Tolerations:      jenkins.Spec.Master.Tolerations,
+			Containers: []corev1.Container{
+				{
+					Name:            JenkinsHomeMigrationContainerName,
+					Image:           jenkinsContainer.Image,
+					ImagePullPolicy: jenkinsContainer.ImagePullPolicy,
+					SecurityContext: securityprofile.ContainerSecurityContext(securityprofile.Get(jenkins), nil),
+					Command: []string{"sh", "-c", fmt.Sprintf("set -e; rm -rf %[2]s/..?* %[2]s/.[!.]* %[2]s/*; cp -a %[1]s/. %[2]s/",
+						jenkinsHomeMigrationSourcePath, jenkinsHomeMigrationTargetPath)},
+					VolumeMounts: []corev1.VolumeMount{
+						{Name: jenkinsHomeMigrationSourceVolumeName, MountPath: jenkinsHomeMigrationSourcePath, ReadOnly: true},
+						{Name: jenkinsHomeMigrationTargetVolumeName, MountPath: jenkinsHomeMigrationTargetPath},
+					},
+				},
+			},
+			Volumes: []corev1.Volume{
+				{
+					Name: jenkinsHomeMigrationSourceVolumeName,
+					VolumeSource: corev1.VolumeSource{
+						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: sourceClaimName, ReadOnly: true},
+					},
+				},
+				{
+					Name: jenkinsHomeMigrationTargetVolumeName,
+					VolumeSource: corev1.VolumeSource{
+						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: targetClaimName},
+					},
+				},
+			},
+		},
+	}
+}
+
+// NewJenkinsHomeVolumeSnapshot builds VolumeSnapshot of Jenkins home claim
+func NewJenkinsHomeVolumeSnapshot(jenkins *v1alpha2.Jenkins, name, claimName, volumeSnapshotClassName string) *unstructured.Unstructured {
+	snapshot := &unstructured.Unstructured{}
+	snapshot.SetGroupVersionKind(VolumeSnapshotGroupVersionKind)
+	snapshot.SetName(name)
+	snapshot.SetNamespace(jenkins.ObjectMeta.Namespace)
+	snapshot.SetLabels(BuildResourceLabels(jenkins))
+	snapshot.Object["spec"] = map[string]interface{}{
+		"volumeSnapshotClassName": volumeSnapshotClassName,
+		"source": map[string]interface{}{
+			"persistentVolumeClaimName": claimName,
+		},
+	}
+	return snapshot
+}
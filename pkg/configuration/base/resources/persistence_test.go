@@ -0,0 +1,66 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetJenkinsHomeClaimName(t *testing.T) {
+	jenkins := &v1alpha2.Jenkins{ObjectMeta: metav1.ObjectMeta{Name: "jenkins"}}
+	t.Run("default", func(t *testing.T) {
+		assert.Equal(t, "jenkins-operator-home-jenkins", GetJenkinsHomeClaimName(jenkins))
+	})
+	t.Run("migrated", func(t *testing.T) {
+		migrated := jenkins.DeepCopy()
+		migrated.Status.Persistence = &v1alpha2.PersistenceStatus{ClaimName: GetJenkinsHomeMigrationClaimName(jenkins, "fast")}
+
+		assert.Equal(t, "jenkins-operator-home-jenkins-fast", GetJenkinsHomeClaimName(migrated))
+	})
+}
+
+func TestNewJenkinsHomePersistentVolumeClaim(t *testing.T) {
+	jenkins := &v1alpha2.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: "default"},
+		Spec: v1alpha2.JenkinsSpec{Persistence: &v1alpha2.Persistence{
+			Size: resource.MustParse("10Gi"),
+		}},
+	}
+	storageClassName := "fast"
+
+	claim := NewJenkinsHomePersistentVolumeClaim(jenkins, "jenkins-operator-home-jenkins", &storageClassName)
+
+	volumeMode := corev1.PersistentVolumeFilesystem
+	assert.Equal(t, "default", claim.Namespace)
+	assert.Empty(t, claim.OwnerReferences)
+	assert.Equal(t, BuildResourceLabels(jenkins), claim.Labels)
+	assert.Equal(t, corev1.PersistentVolumeClaimSpec{
+		AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+		StorageClassName: &storageClassName,
+		VolumeMode:       &volumeMode,
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")},
+		},
+	}, claim.Spec)
+}
+
+func TestGetJenkinsHomeVolumeSource(t *testing.T) {
+	t.Run("emptyDir", func(t *testing.T) {
+		assert.Equal(t, corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}, getJenkinsHomeVolumeSource(&v1alpha2.Jenkins{}))
+	})
+	t.Run("persistent volume claim", func(t *testing.T) {
+		jenkins := &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Name: "jenkins"},
+			Spec:       v1alpha2.JenkinsSpec{Persistence: &v1alpha2.Persistence{Size: resource.MustParse("10Gi")}},
+		}
+
+		assert.Equal(t, corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "jenkins-operator-home-jenkins"},
+		}, getJenkinsHomeVolumeSource(jenkins))
+	})
+}
@@ -5,7 +5,7 @@ import (
 	"strings"
 
 	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
-	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/securityprofile"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -63,7 +63,7 @@ func GetJenkinsMasterContainerBaseEnvs(jenkins *v1alpha2.Jenkins) []corev1.EnvVa
 	envVars := []corev1.EnvVar{
 		{
 			Name:  "COPY_REFERENCE_FILE_LOG",
-			Value: fmt.Sprintf("%s/%s", getJenkinsHomePath(jenkins), "copy_reference_file.log"),
+			Value: fmt.Sprintf("%s/%s", GetJenkinsHomePath(jenkins), "copy_reference_file.log"),
 		},
 	}
 
@@ -74,11 +74,21 @@ func GetJenkinsMasterContainerBaseEnvs(jenkins *v1alpha2.Jenkins) []corev1.EnvVa
 		})
 	}
 
+	envVars = append(envVars, GetProxyEnvs(jenkins)...)
+	envVars = append(envVars, getInboundAgentsEnvs(jenkins)...)
+
+	if jenkins.Spec.TrustedCABundle != nil {
+		envVars = append(envVars, corev1.EnvVar{
+			Name:  "CURL_CA_BUNDLE",
+			Value: getCurlCABundlePath(jenkins),
+		})
+	}
+
 	return envVars
 }
 
-// getJenkinsHomePath fetches the Home Path for Jenkins
-func getJenkinsHomePath(jenkins *v1alpha2.Jenkins) string {
+// GetJenkinsHomePath fetches the Home Path for Jenkins
+func GetJenkinsHomePath(jenkins *v1alpha2.Jenkins) string {
 	defaultJenkinsHomePath := "/var/lib/jenkins"
 	for _, envVar := range jenkins.Spec.Master.Containers[0].Env {
 		if envVar.Name == "JENKINS_HOME" {
@@ -88,6 +98,16 @@ func getJenkinsHomePath(jenkins *v1alpha2.Jenkins) string {
 	return defaultJenkinsHomePath
 }
 
+// getJenkinsHomeVolumeSource returns PersistentVolumeClaim volume when spec.persistence is set, otherwise emptyDir
+func getJenkinsHomeVolumeSource(jenkins *v1alpha2.Jenkins) corev1.VolumeSource {
+	if jenkins.Spec.Persistence == nil {
+		return corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}
+	}
+	return corev1.VolumeSource{
+		PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: GetJenkinsHomeClaimName(jenkins)},
+	}
+}
+
 // GetJenkinsMasterPodBaseVolumes returns Jenkins master pod volumes required by operator
 func GetJenkinsMasterPodBaseVolumes(jenkins *v1alpha2.Jenkins) []corev1.Volume {
 	configMapVolumeSourceDefaultMode := corev1.ConfigMapVolumeSourceDefaultMode
@@ -95,10 +115,8 @@ func GetJenkinsMasterPodBaseVolumes(jenkins *v1alpha2.Jenkins) []corev1.Volume {
 	var scriptsVolumeDefaultMode int32 = 0777
 	volumes := []corev1.Volume{
 		{
-			Name: JenkinsHomeVolumeName,
-			VolumeSource: corev1.VolumeSource{
-				EmptyDir: &corev1.EmptyDirVolumeSource{},
-			},
+			Name:         JenkinsHomeVolumeName,
+			VolumeSource: getJenkinsHomeVolumeSource(jenkins),
 		},
 		{
 			Name: jenkinsScriptsVolumeName,
@@ -155,6 +173,16 @@ func GetJenkinsMasterPodBaseVolumes(jenkins *v1alpha2.Jenkins) []corev1.Volume {
 			},
 		})
 	}
+	if jenkins.Spec.TrustedCABundle != nil {
+		volumes = append(volumes, getTrustedCABundleVolume(jenkins))
+	}
+	if isBuildLogArchivalCredentialsVolumeRequired(jenkins) {
+		volumes = append(volumes, getBuildLogArchivalCredentialsVolume(jenkins))
+	}
+	if jenkins.Spec.UpdateCenterMirror != nil {
+		volumes = append(volumes, getUpdateCenterMirrorVolumes(jenkins)...)
+	}
+	volumes = append(volumes, securityprofile.Volumes(securityprofile.Get(jenkins))...)
 
 	return volumes
 }
@@ -172,7 +200,7 @@ func GetJenkinsMasterContainerBaseVolumeMounts(jenkins *v1alpha2.Jenkins) []core
 	volumeMounts := []corev1.VolumeMount{
 		{
 			Name:      JenkinsHomeVolumeName,
-			MountPath: getJenkinsHomePath(jenkins),
+			MountPath: GetJenkinsHomePath(jenkins),
 			ReadOnly:  false,
 		},
 		{
@@ -206,6 +234,12 @@ func GetJenkinsMasterContainerBaseVolumeMounts(jenkins *v1alpha2.Jenkins) []core
 			ReadOnly:  true,
 		})
 	}
+	if jenkins.Spec.TrustedCABundle != nil {
+		volumeMounts = append(volumeMounts, getTrustedCABundleVolumeMount())
+	}
+	if jenkins.Spec.UpdateCenterMirror != nil {
+		volumeMounts = append(volumeMounts, getUpdateCenterMirrorVolumeMounts(jenkins)...)
+	}
 
 	return volumeMounts
 }
@@ -219,7 +253,7 @@ func NewJenkinsMasterContainer(jenkins *v1alpha2.Jenkins) corev1.Container {
 
 	jenkinsHomeEnvVar := corev1.EnvVar{
 		Name:  "JENKINS_HOME",
-		Value: getJenkinsHomePath(jenkins),
+		Value: GetJenkinsHomePath(jenkins),
 	}
 
 	jenkinsHomeEnvVarExists := false
@@ -234,33 +268,26 @@ func NewJenkinsMasterContainer(jenkins *v1alpha2.Jenkins) corev1.Container {
 		envs = append(envs, jenkinsHomeEnvVar)
 	}
 
+	envs = appendJavaOpts(envs, GetJVMOpts(jenkins)...)
+	envs = appendJavaOpts(envs, getProxyJavaOpts(jenkins)...)
+	envs = appendJavaOpts(envs, getTrustedCABundleJavaOpts(jenkins)...)
+
 	setLivenessAndReadinessPath(jenkins)
 
-	return corev1.Container{
+	return securityprofile.Container(securityprofile.Get(jenkins), corev1.Container{
 		Name:            JenkinsMasterContainerName,
 		Image:           jenkinsContainer.Image,
 		ImagePullPolicy: jenkinsContainer.ImagePullPolicy,
 		Command:         jenkinsContainer.Command,
 		LivenessProbe:   jenkinsContainer.LivenessProbe,
 		ReadinessProbe:  jenkinsContainer.ReadinessProbe,
-		Ports: []corev1.ContainerPort{
-			{
-				Name:          httpPortName,
-				ContainerPort: constants.DefaultHTTPPortInt32,
-				Protocol:      corev1.ProtocolTCP,
-			},
-			{
-				Name:          slavePortName,
-				ContainerPort: constants.DefaultSlavePortInt32,
-				Protocol:      corev1.ProtocolTCP,
-			},
-		},
+		Ports:           getJenkinsMasterContainerPorts(jenkins),
 		SecurityContext: jenkinsContainer.SecurityContext,
 		Env:             envs,
 		EnvFrom:         jenkinsContainer.EnvFrom,
 		Resources:       jenkinsContainer.Resources,
 		VolumeMounts:    append(GetJenkinsMasterContainerBaseVolumeMounts(jenkins), jenkinsContainer.VolumeMounts...),
-	}
+	})
 }
 
 func setLivenessAndReadinessPath(jenkins *v1alpha2.Jenkins) {
@@ -331,21 +358,65 @@ func ConvertJenkinsContainerToKubernetesContainer(container v1alpha2.Container)
 	}
 }
 
-func newContainers(jenkins *v1alpha2.Jenkins) (containers []corev1.Container) {
+// NewJenkinsSidecarContainer returns Kubernetes container for Jenkins master pod sidecar, e.g. backup container
+func NewJenkinsSidecarContainer(jenkins *v1alpha2.Jenkins, container v1alpha2.Container) corev1.Container {
+	return securityprofile.Container(securityprofile.Get(jenkins), withProxyAndTrustedCABundle(jenkins, ConvertJenkinsContainerToKubernetesContainer(container)))
+}
+
+// NewJenkinsMasterPodContainers returns all containers of Jenkins master pod, the containers from CR and the sidecars
+// managed by operator
+func NewJenkinsMasterPodContainers(jenkins *v1alpha2.Jenkins) (containers []corev1.Container) {
 	containers = append(containers, NewJenkinsMasterContainer(jenkins))
 
 	for _, container := range jenkins.Spec.Master.Containers[1:] {
-		containers = append(containers, ConvertJenkinsContainerToKubernetesContainer(container))
+		containers = append(containers, NewJenkinsSidecarContainer(jenkins, container))
+	}
+	if jenkins.Spec.BuildLogArchival != nil {
+		containers = append(containers, NewBuildLogArchivalContainer(jenkins))
 	}
 
 	return
 }
 
+// NewJenkinsMasterPodInitContainers returns init containers of Jenkins master pod from spec.master.initContainers
+func NewJenkinsMasterPodInitContainers(jenkins *v1alpha2.Jenkins) (containers []corev1.Container) {
+	for _, container := range jenkins.Spec.Master.InitContainers {
+		containers = append(containers, NewJenkinsSidecarContainer(jenkins, container))
+	}
+	return
+}
+
+// GetJenkinsMasterPodSecurityContext returns the security context of Jenkins master pod complying with spec.securityProfile
+func GetJenkinsMasterPodSecurityContext(jenkins *v1alpha2.Jenkins) *corev1.PodSecurityContext {
+	return securityprofile.PodSecurityContext(securityprofile.Get(jenkins), jenkins.Spec.Master.SecurityContext)
+}
+
 // GetJenkinsMasterPodName returns Jenkins pod name for given CR
 func GetJenkinsMasterPodName(jenkins *v1alpha2.Jenkins) string {
+	if GetWorkloadType(jenkins) == v1alpha2.WorkloadTypeStatefulSet {
+		// the only pod of StatefulSet has the ordinal 0
+		return fmt.Sprintf("%s-0", GetJenkinsStatefulSetName(jenkins))
+	}
+	return GetJenkinsMasterBarePodName(jenkins)
+}
+
+// GetJenkinsMasterBarePodName returns the name of Jenkins master pod created directly by the operator
+func GetJenkinsMasterBarePodName(jenkins *v1alpha2.Jenkins) string {
 	return fmt.Sprintf("jenkins-%s", jenkins.Name)
 }
 
+// GetWorkloadType returns the Kubernetes workload running Jenkins master, it defaults to Pod
+// or to Deployment when jenkins.io/use-deployment annotation is set
+func GetWorkloadType(jenkins *v1alpha2.Jenkins) v1alpha2.WorkloadType {
+	if len(jenkins.Spec.Master.WorkloadType) > 0 {
+		return jenkins.Spec.Master.WorkloadType
+	}
+	if jenkins.Annotations[UseDeploymentAnnotation] == "true" {
+		return v1alpha2.WorkloadTypeDeployment
+	}
+	return v1alpha2.WorkloadTypePod
+}
+
 // GetJenkinsMasterPodLabels returns Jenkins pod labels for given CR
 func GetJenkinsMasterPodLabels(jenkins v1alpha2.Jenkins) map[string]string {
 	var labels map[string]string
@@ -374,9 +445,10 @@ func NewJenkinsMasterPod(objectMeta metav1.ObjectMeta, jenkins *v1alpha2.Jenkins
 			ServiceAccountName: serviceAccountName,
 			RestartPolicy:      corev1.RestartPolicyNever,
 			NodeSelector:       jenkins.Spec.Master.NodeSelector,
-			Containers:         newContainers(jenkins),
+			InitContainers:     NewJenkinsMasterPodInitContainers(jenkins),
+			Containers:         NewJenkinsMasterPodContainers(jenkins),
 			Volumes:            append(GetJenkinsMasterPodBaseVolumes(jenkins), jenkins.Spec.Master.Volumes...),
-			SecurityContext:    jenkins.Spec.Master.SecurityContext,
+			SecurityContext:    GetJenkinsMasterPodSecurityContext(jenkins),
 			ImagePullSecrets:   jenkins.Spec.Master.ImagePullSecrets,
 			Tolerations:        jenkins.Spec.Master.Tolerations,
 			PriorityClassName:  jenkins.Spec.Master.PriorityClassName,
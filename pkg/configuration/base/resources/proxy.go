@@ -0,0 +1,313 @@
+package resources
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	trustedCABundleVolumeName = "trusted-ca-bundle"
+	// TrustedCABundleVolumePath is a path where the CA certificates from spec.trustedCABundle are mounted
+	TrustedCABundleVolumePath = jenkinsPath + "/trusted-ca-bundle"
+	// TrustStorePassword is the password of Java trust store generated by init script
+	TrustStorePassword = "changeit"
+
+	trustedCADirectoryName = ".trusted-ca"
+	trustStoreFileName     = "cacerts"
+	curlCABundleFileName   = "ca-bundle.crt"
+
+	// ProxyUsernameEnvName is the environment variable with the proxy username read from spec.proxy.credentials
+	ProxyUsernameEnvName = "PROXY_USERNAME"
+	// ProxyPasswordEnvName is the environment variable with the proxy password read from spec.proxy.credentials
+	ProxyPasswordEnvName = "PROXY_PASSWORD"
+	// ProxySecretHTTPProxyKey is the key of HTTP proxy URL with the credentials in the proxy Secret
+	ProxySecretHTTPProxyKey = "httpProxy"
+	// ProxySecretHTTPSProxyKey is the key of HTTPS proxy URL with the credentials in the proxy Secret
+	ProxySecretHTTPSProxyKey = "httpsProxy"
+)
+
+// defaultNoProxyHosts are the hosts which are always accessed directly, without a proxy
+var defaultNoProxyHosts = []string{"localhost", "127.0.0.1", ".svc", ".cluster.local"}
+
+// GetNoProxyHosts returns list of hosts which should be accessed without proxy
+func GetNoProxyHosts(jenkins *v1alpha2.Jenkins) []string {
+	hosts := append([]string{}, defaultNoProxyHosts...)
+	if jenkins.Spec.Proxy == nil {
+		return hosts
+	}
+
+	for _, host := range jenkins.Spec.Proxy.NoProxy {
+		found := false
+		for _, defaultHost := range defaultNoProxyHosts {
+			if host == defaultHost {
+				found = true
+				break
+			}
+		}
+		if !found {
+			hosts = append(hosts, host)
+		}
+	}
+	return hosts
+}
+
+// GetProxyEnvs returns environment variables which configure HTTP(S) proxy for tools like curl
+func GetProxyEnvs(jenkins *v1alpha2.Jenkins) []corev1.EnvVar {
+	proxy := jenkins.Spec.Proxy
+	if proxy == nil {
+		return nil
+	}
+
+	var envs []corev1.EnvVar
+	if len(proxy.HTTPProxy) > 0 {
+		envs = append(envs,
+			newProxyURLEnv(jenkins, "HTTP_PROXY", proxy.HTTPProxy, ProxySecretHTTPProxyKey),
+			newProxyURLEnv(jenkins, "http_proxy", proxy.HTTPProxy, ProxySecretHTTPProxyKey),
+		)
+	}
+	if len(proxy.HTTPSProxy) > 0 {
+		envs = append(envs,
+			newProxyURLEnv(jenkins, "HTTPS_PROXY", proxy.HTTPSProxy, ProxySecretHTTPSProxyKey),
+			newProxyURLEnv(jenkins, "https_proxy", proxy.HTTPSProxy, ProxySecretHTTPSProxyKey),
+		)
+	}
+	if len(envs) == 0 {
+		return nil
+	}
+	if proxy.Credentials != nil {
+		// the credentials are read by the proxy groovy script
+		envs = append([]corev1.EnvVar{
+			newSecretKeyEnv(ProxyUsernameEnvName, proxy.Credentials.UsernameSecretKeySelector),
+			newSecretKeyEnv(ProxyPasswordEnvName, proxy.Credentials.PasswordSecretKeySelector),
+		}, envs...)
+	}
+
+	noProxy := strings.Join(GetNoProxyHosts(jenkins), ",")
+	return append(envs,
+		corev1.EnvVar{Name: "NO_PROXY", Value: noProxy},
+		corev1.EnvVar{Name: "no_proxy", Value: noProxy},
+	)
+}
+
+// newProxyURLEnv returns the environment variable with the proxy URL, the URL with the credentials is read
+// from the proxy Secret
+func newProxyURLEnv(jenkins *v1alpha2.Jenkins, name, proxyURL, secretKey string) corev1.EnvVar {
+	if jenkins.Spec.Proxy.Credentials == nil {
+		return corev1.EnvVar{Name: name, Value: proxyURL}
+	}
+	return corev1.EnvVar{
+		Name: name,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: GetProxySecretName(jenkins)}, Key: secretKey},
+		},
+	}
+}
+
+// GetProxySecretName returns name of the Secret with the proxy URLs which contain the credentials from spec.proxy.credentials
+func GetProxySecretName(jenkins *v1alpha2.Jenkins) string {
+	return fmt.Sprintf("%s-proxy-%s", constants.OperatorName, jenkins.Name)
+}
+
+// NewProxySecret builds the Secret with the proxy URLs which contain the URL-encoded username and password
+func NewProxySecret(meta metav1.ObjectMeta, jenkins *v1alpha2.Jenkins, username, password string) *corev1.Secret {
+	meta.Name = GetProxySecretName(jenkins)
+	data := map[string][]byte{}
+	if proxy := jenkins.Spec.Proxy; proxy != nil {
+		if len(proxy.HTTPProxy) > 0 {
+			data[ProxySecretHTTPProxyKey] = []byte(withProxyCredentials(proxy.HTTPProxy, username, password))
+		}
+		if len(proxy.HTTPSProxy) > 0 {
+			data[ProxySecretHTTPSProxyKey] = []byte(withProxyCredentials(proxy.HTTPSProxy, username, password))
+		}
+	}
+	return &corev1.Secret{
+		TypeMeta:   buildSecretTypeMeta(),
+		ObjectMeta: meta,
+		Data:       data,
+	}
+}
+
+// withProxyCredentials adds the URL-encoded credentials to the proxy URL
+func withProxyCredentials(proxyURL, username, password string) string {
+	credentials := url.UserPassword(username, password).String() + "@"
+	if index := strings.Index(proxyURL, "://"); index >= 0 {
+		return proxyURL[:index+3] + credentials + proxyURL[index+3:]
+	}
+	return credentials + proxyURL
+}
+
+func newSecretKeyEnv(name string, selector v1alpha2.SecretKeySelector) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: name,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: selector.LocalObjectReference, Key: selector.Key},
+		},
+	}
+}
+
+// ParseProxyURL returns host and port of the proxy URL, the port defaults to 80 or 443 depending on URL scheme
+func ParseProxyURL(proxyURL string) (host string, port string, err error) {
+	parsed, err := url.Parse(proxyURL)
+	if err != nil {
+		return "", "", err
+	}
+	if len(parsed.Hostname()) == 0 {
+		return "", "", fmt.Errorf("host not set in '%s'", proxyURL)
+	}
+
+	port = parsed.Port()
+	if len(port) == 0 {
+		if parsed.Scheme == "https" {
+			port = "443"
+		} else {
+			port = "80"
+		}
+	}
+	return parsed.Hostname(), port, nil
+}
+
+// GetJavaNonProxyHosts returns no proxy hosts in the format of Java http.nonProxyHosts system property
+func GetJavaNonProxyHosts(jenkins *v1alpha2.Jenkins) []string {
+	var hosts []string
+	for _, host := range GetNoProxyHosts(jenkins) {
+		if strings.HasPrefix(host, ".") {
+			host = "*" + host
+		}
+		hosts = append(hosts, host)
+	}
+	return hosts
+}
+
+// getProxyJavaOpts returns Java system properties which configure HTTP(S) proxy
+func getProxyJavaOpts(jenkins *v1alpha2.Jenkins) []string {
+	proxy := jenkins.Spec.Proxy
+	if proxy == nil {
+		return nil
+	}
+
+	var opts []string
+	if host, port, err := ParseProxyURL(proxy.HTTPProxy); len(proxy.HTTPProxy) > 0 && err == nil {
+		opts = append(opts, fmt.Sprintf("-Dhttp.proxyHost=%s", host), fmt.Sprintf("-Dhttp.proxyPort=%s", port))
+	}
+	if host, port, err := ParseProxyURL(proxy.HTTPSProxy); len(proxy.HTTPSProxy) > 0 && err == nil {
+		opts = append(opts, fmt.Sprintf("-Dhttps.proxyHost=%s", host), fmt.Sprintf("-Dhttps.proxyPort=%s", port))
+	}
+	if len(opts) == 0 {
+		return nil
+	}
+
+	return append(opts, fmt.Sprintf("-Dhttp.nonProxyHosts=%s", strings.Join(GetJavaNonProxyHosts(jenkins), "|")))
+}
+
+// GetTrustedCABundleKey returns the ConfigMap key with CA certificates
+func GetTrustedCABundleKey(jenkins *v1alpha2.Jenkins) string {
+	if jenkins.Spec.TrustedCABundle == nil || len(jenkins.Spec.TrustedCABundle.Key) == 0 {
+		return constants.DefaultTrustedCABundleKey
+	}
+	return jenkins.Spec.TrustedCABundle.Key
+}
+
+func getTrustedCADirectory(jenkins *v1alpha2.Jenkins) string {
+	return fmt.Sprintf("%s/%s", GetJenkinsHomePath(jenkins), trustedCADirectoryName)
+}
+
+func getTrustStorePath(jenkins *v1alpha2.Jenkins) string {
+	return fmt.Sprintf("%s/%s", getTrustedCADirectory(jenkins), trustStoreFileName)
+}
+
+func getCurlCABundlePath(jenkins *v1alpha2.Jenkins) string {
+	return fmt.Sprintf("%s/%s", getTrustedCADirectory(jenkins), curlCABundleFileName)
+}
+
+// getTrustedCABundleJavaOpts returns Java system properties which configure trust store generated by init script
+func getTrustedCABundleJavaOpts(jenkins *v1alpha2.Jenkins) []string {
+	if jenkins.Spec.TrustedCABundle == nil {
+		return nil
+	}
+
+	return []string{
+		fmt.Sprintf("-Djavax.net.ssl.trustStore=%s", getTrustStorePath(jenkins)),
+		fmt.Sprintf("-Djavax.net.ssl.trustStorePassword=%s", TrustStorePassword),
+	}
+}
+
+func getTrustedCABundleVolume(jenkins *v1alpha2.Jenkins) corev1.Volume {
+	configMapVolumeSourceDefaultMode := corev1.ConfigMapVolumeSourceDefaultMode
+	return corev1.Volume{
+		Name: trustedCABundleVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				DefaultMode: &configMapVolumeSourceDefaultMode,
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: jenkins.Spec.TrustedCABundle.Name,
+				},
+			},
+		},
+	}
+}
+
+func getTrustedCABundleVolumeMount() corev1.VolumeMount {
+	return corev1.VolumeMount{
+		Name:      trustedCABundleVolumeName,
+		MountPath: TrustedCABundleVolumePath,
+		ReadOnly:  true,
+	}
+}
+
+// appendJavaOpts appends Java options to the JAVA_OPTS environment variable, it's added if not exists
+func appendJavaOpts(envs []corev1.EnvVar, opts ...string) []corev1.EnvVar {
+	if len(opts) == 0 {
+		return envs
+	}
+
+	for i, env := range envs {
+		if env.Name == constants.JavaOpsVariableName {
+			envs[i].Value = strings.TrimSpace(env.Value + " " + strings.Join(opts, " "))
+			return envs
+		}
+	}
+	return append(envs, corev1.EnvVar{Name: constants.JavaOpsVariableName, Value: strings.Join(opts, " ")})
+}
+
+// withProxyAndTrustedCABundle adds proxy environment variables and trusted CA bundle volume mount to the container,
+// variables and mounts defined by user take precedence
+func withProxyAndTrustedCABundle(jenkins *v1alpha2.Jenkins, container corev1.Container) corev1.Container {
+	envs := append([]corev1.EnvVar{}, container.Env...)
+	for _, proxyEnv := range GetProxyEnvs(jenkins) {
+		found := false
+		for _, env := range container.Env {
+			if env.Name == proxyEnv.Name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			envs = append(envs, proxyEnv)
+		}
+	}
+	if len(envs) > 0 {
+		container.Env = envs
+	}
+
+	if jenkins.Spec.TrustedCABundle != nil {
+		found := false
+		for _, volumeMount := range container.VolumeMounts {
+			if volumeMount.Name == trustedCABundleVolumeName {
+				found = true
+				break
+			}
+		}
+		if !found {
+			container.VolumeMounts = append(append([]corev1.VolumeMount{}, container.VolumeMounts...), getTrustedCABundleVolumeMount())
+		}
+	}
+
+	return container
+}
@@ -1,12 +1,15 @@
 package resources
 
 import (
+	"sort"
 	"testing"
 
 	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 var jenkins = v1alpha2.Jenkins{
@@ -227,3 +230,49 @@ func TestSetLivenessAndReadinessPath(t *testing.T) {
 		assert.Equal(t, "/login", jenkins.Spec.Master.Containers[0].LivenessProbe.HTTPGet.Path)
 	})
 }
+
+func TestBaseConfigurationGroovyScriptNames(t *testing.T) {
+	names := []string{
+		basicSettingsGroovyScriptName,
+		enableCSRFGroovyScriptName,
+		disableUsageStatsGroovyScriptName,
+		enableMasterAccessControlGroovyScriptName,
+		disableInsecureFeaturesGroovyScriptName,
+		configureKubernetesPluginGroovyScriptName,
+		configureViewsGroovyScriptName,
+		configureJobDSLScriptSecurityGroovyScriptName,
+		configureProxyGroovyScriptName,
+		configureUpdateCenterGroovyScriptName,
+	}
+
+	assert.True(t, sort.StringsAreSorted(names), "scripts are applied in the order of names: %v", names)
+}
+
+func TestNewBaseConfigurationConfigMap_JobDSLScriptSecurity(t *testing.T) {
+	newJenkins := func(scriptApproval *v1alpha2.ScriptApproval) *v1alpha2.Jenkins {
+		return &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"},
+			Spec: v1alpha2.JenkinsSpec{
+				Master:         v1alpha2.JenkinsMaster{Containers: []v1alpha2.Container{{Name: JenkinsMasterContainerName}}},
+				ScriptApproval: scriptApproval,
+			},
+		}
+	}
+
+	t.Run("disabled without script approval", func(t *testing.T) {
+		jenkins := newJenkins(nil)
+
+		configMap, err := NewBaseConfigurationConfigMap(NewResourceObjectMeta(jenkins), jenkins, "cluster.local")
+
+		require.NoError(t, err)
+		assert.Contains(t, configMap.Data[configureJobDSLScriptSecurityGroovyScriptName], "useScriptSecurity=false")
+	})
+	t.Run("enabled with script approval", func(t *testing.T) {
+		jenkins := newJenkins(&v1alpha2.ScriptApproval{})
+
+		configMap, err := NewBaseConfigurationConfigMap(NewResourceObjectMeta(jenkins), jenkins, "cluster.local")
+
+		require.NoError(t, err)
+		assert.Contains(t, configMap.Data[configureJobDSLScriptSecurityGroovyScriptName], "useScriptSecurity=true")
+	})
+}
@@ -1,6 +1,9 @@
 package resources
 
 import (
+	"sort"
+	"strings"
+
 	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
 	routev1 "github.com/openshift/api/route/v1"
 
@@ -10,6 +13,13 @@ import (
 	"k8s.io/client-go/kubernetes"
 )
 
+const (
+	// RouteAnnotationsAnnotation lists keys of the Route annotations set from spec.openShift.route.annotations
+	RouteAnnotationsAnnotation = "jenkins.io/route-annotations"
+	// RouteHostAnnotation is the Route host set from spec.openShift.route.host
+	RouteHostAnnotation = "jenkins.io/route-host"
+)
+
 var isRouteAPIAvailable = false
 var routeAPIChecked = false
 
@@ -24,9 +34,49 @@ func UpdateRoute(actual routev1.Route, jenkins *v1alpha2.Jenkins) routev1.Route
 	if actual.Spec.Port.TargetPort.IntVal != port {
 		actual.Spec.Port.TargetPort = intstr.FromInt(int(port))
 	}
+	route := getRoute(jenkins)
+	if actual.Annotations == nil {
+		actual.Annotations = map[string]string{}
+	}
+	if len(route.Host) > 0 {
+		actual.Spec.Host = route.Host
+		actual.Annotations[RouteHostAnnotation] = route.Host
+	} else {
+		delete(actual.Annotations, RouteHostAnnotation)
+	}
+	// remove annotations which are no longer in spec.openShift.route.annotations
+	for _, key := range strings.Split(actual.Annotations[RouteAnnotationsAnnotation], ",") {
+		if _, ok := route.Annotations[key]; !ok {
+			delete(actual.Annotations, key)
+		}
+	}
+	delete(actual.Annotations, RouteAnnotationsAnnotation)
+	var keys []string
+	for key, value := range route.Annotations {
+		actual.Annotations[key] = value
+		keys = append(keys, key)
+	}
+	if len(keys) > 0 {
+		sort.Strings(keys)
+		actual.Annotations[RouteAnnotationsAnnotation] = strings.Join(keys, ",")
+	}
 	return actual
 }
 
+// IsRouteHostCleared tells if the host set from spec.openShift.route.host has been removed from Jenkins CR, OpenShift
+// generates the host only for new Routes
+func IsRouteHostCleared(actual routev1.Route, jenkins *v1alpha2.Jenkins) bool {
+	host, ok := actual.Annotations[RouteHostAnnotation]
+	return ok && len(getRoute(jenkins).Host) == 0 && actual.Spec.Host == host
+}
+
+func getRoute(jenkins *v1alpha2.Jenkins) v1alpha2.Route {
+	if jenkins.Spec.OpenShift == nil {
+		return v1alpha2.Route{}
+	}
+	return jenkins.Spec.OpenShift.Route
+}
+
 //IsRouteAPIAvailable tells if the Route API is installed and discoverable
 func IsRouteAPIAvailable(clientSet *kubernetes.Clientset) bool {
 	if routeAPIChecked {
@@ -7,6 +7,8 @@ import (
 	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
 	"github.com/jenkinsci/kubernetes-operator/internal/render"
 	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
+	"github.com/jenkinsci/kubernetes-operator/pkg/failure"
+	"github.com/jenkinsci/kubernetes-operator/pkg/plugins"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -38,19 +40,20 @@ getArchiveFilename() {
 }
 
 download() {
-    local plugin originalPlugin version lock ignoreLockFile
+    local plugin originalPlugin version lock ignoreLockFile url
     plugin="$1"
     version="${2:-latest}"
     ignoreLockFile="${3:-}"
+    url="${4:-}"
     lock="$(getLockFile "$plugin")"
 
     if [[ $ignoreLockFile ]] || mkdir "$lock" &>/dev/null; then
-        if ! doDownload "$plugin" "$version"; then
+        if ! doDownload "$plugin" "$version" "$url"; then
             # some plugin don't follow the rules about artifact ID
             # typically: docker-plugin
             originalPlugin="$plugin"
             plugin="${plugin}-plugin"
-            if ! doDownload "$plugin" "$version"; then
+            if [[ -n "$url" ]] || ! doDownload "$plugin" "$version"; then
                 echo "Failed to download plugin: $originalPlugin or $plugin" >&2
                 echo "Not downloaded: ${originalPlugin}" >> "$FAILED"
                 return 1
@@ -70,6 +73,7 @@ doDownload() {
     local plugin version url jpi
     plugin="$1"
     version="$2"
+    url="${3:-}"
     jpi="$(getArchiveFilename "$plugin")"
 
     # If plugin already exists and is the same version do not download
@@ -78,7 +82,10 @@ doDownload() {
         return 0
     fi
 
-    if [[ "$version" == "latest" && -n "$JENKINS_UC_LATEST" ]]; then
+    if [[ -n "$url" ]]; then
+        # Download from the update site of the plugin resolved by the operator
+        :
+    elif [[ "$version" == "latest" && -n "$JENKINS_UC_LATEST" ]]; then
         # If version-specific Update Center is available, which is the case for LTS versions,
         # use it to resolve latest versions.
         url="$JENKINS_UC_LATEST/latest/${plugin}.hpi"
@@ -137,13 +144,22 @@ bundledPlugins() {
 versionFromPlugin() {
     local plugin=$1
     if [[ $plugin =~ .*:.* ]]; then
-        echo "${plugin##*:}"
+        plugin="${plugin#*:}"
+        echo "${plugin%%%%:*}"
     else
         echo "latest"
     fi
 
 }
 
+urlFromPlugin() {
+    local plugin=$1
+    plugin="${plugin#*:}"
+    if [[ $plugin =~ .*:.* ]]; then
+        echo "${plugin#*:}"
+    fi
+}
+
 installedPlugins() {
     for f in "$REF_DIR"/*.jpi; do
         echo "$(basename "$f" | sed -e 's/\.jpi//'):$(get_plugin_version "$f")"
@@ -166,7 +182,7 @@ jenkinsMajorMinorVersion() {
 }
 
 main() {
-    local plugin pluginVersion jenkinsVersion
+    local plugin pluginVersion pluginURL jenkinsVersion
     local plugins=()
 
     mkdir -p "$REF_DIR" || exit 1
@@ -190,7 +206,7 @@ main() {
     # Create lockfile manually before first run to make sure any explicit version set is used.
     echo "Creating initial locks..."
     for plugin in "${plugins[@]}"; do
-        mkdir "$(getLockFile "${plugin%%:*}")"
+        mkdir "$(getLockFile "${plugin%%%%:*}")"
     done
 
     echo "Analyzing war..."
@@ -211,13 +227,15 @@ main() {
     echo "Downloading plugins..."
     for plugin in "${plugins[@]}"; do
         pluginVersion=""
+        pluginURL=""
 
         if [[ $plugin =~ .*:.* ]]; then
             pluginVersion=$(versionFromPlugin "${plugin}")
-            plugin="${plugin%%:*}"
+            pluginURL=$(urlFromPlugin "${plugin}")
+            plugin="${plugin%%%%:*}"
         fi
 
-        download "$plugin" "$pluginVersion" "true" &
+        download "$plugin" "$pluginVersion" "true" "$pluginURL" &
     done
     wait
 
@@ -254,6 +272,11 @@ else
     echo "To print debug messages set environment variable 'DEBUG_JENKINS_OPERATOR' to 'true'"
 fi
 
+# OpenShift runs containers with an arbitrary UID which has no entry in /etc/passwd, e.g. git and ssh require it
+if ! whoami &> /dev/null && [ -w /etc/passwd ]; then
+  echo "jenkins:x:$(id -u):0:Jenkins:{{ .JenkinsHomePath }}:/bin/bash" >> /etc/passwd
+fi
+
 # https://wiki.jenkins.io/display/JENKINS/Post-initialization+script
 mkdir -p {{ .JenkinsHomePath }}/init.groovy.d
 cp -n {{ .InitConfigurationPath }}/*.groovy {{ .JenkinsHomePath }}/init.groovy.d
@@ -261,38 +284,96 @@ cp -n {{ .InitConfigurationPath }}/*.groovy {{ .JenkinsHomePath }}/init.groovy.d
 mkdir -p {{ .JenkinsHomePath }}/scripts
 cp {{ .JenkinsScriptsVolumePath }}/*.sh {{ .JenkinsHomePath }}/scripts
 chmod +x {{ .JenkinsHomePath }}/scripts/*.sh
+{{ if .TrustedCABundlePath }}
+echo "Configuring trusted CA certificates - begin"
+rm -rf {{ .TrustedCADirectory }}
+mkdir -p {{ .TrustedCADirectory }}
+if [ -f /etc/ssl/certs/ca-certificates.crt ]; then
+  cat /etc/ssl/certs/ca-certificates.crt {{ .TrustedCABundlePath }} > {{ .CurlCABundlePath }}
+else
+  cat {{ .TrustedCABundlePath }} > {{ .CurlCABundlePath }}
+fi
 
+JAVA_CACERTS="${JAVA_HOME}/lib/security/cacerts"
+if [ ! -f "${JAVA_CACERTS}" ]; then
+  JAVA_CACERTS="${JAVA_HOME}/jre/lib/security/cacerts"
+fi
+cp "${JAVA_CACERTS}" {{ .TrustStorePath }}
+chmod u+w {{ .TrustStorePath }}
+awk -v dir="{{ .TrustedCADirectory }}" '/-----BEGIN CERTIFICATE-----/ {n++} n > 0 {print > (dir "/trusted-ca-" n ".pem")}' {{ .TrustedCABundlePath }}
+for cert in {{ .TrustedCADirectory }}/trusted-ca-*.pem; do
+  keytool -importcert -noprompt -keystore {{ .TrustStorePath }} -storepass {{ .TrustStorePassword }} -alias "$(basename "${cert}" .pem)" -file "${cert}"
+done
+echo "Configuring trusted CA certificates - end"
+{{ end }}
 {{- $jenkinsHomePath := .JenkinsHomePath }}
 {{- $installPluginsCommand := .InstallPluginsCommand }}
 
-echo "Installing plugins required by Operator - begin"
-cat > {{ .JenkinsHomePath }}/base-plugins << EOF
-{{ range $index, $plugin := .BasePlugins }}
-{{ $plugin.Name }}:{{ $plugin.Version }}{{if $plugin.DownloadURL}}:{{ $plugin.DownloadURL }}{{end}}
+echo "Installing plugins - begin"
+cat > {{ .JenkinsHomePath }}/plugins << EOF
+{{ range $index, $plugin := .Plugins }}
+{{ $plugin.InstallString }}
 {{ end }}
 EOF
 
 if [[ -z "${OPENSHIFT_JENKINS_IMAGE_VERSION}" ]]; then
-  {{ $installPluginsCommand }} < {{ .JenkinsHomePath }}/base-plugins
+  {{ $installPluginsCommand }} < {{ .JenkinsHomePath }}/plugins
 else
-  {{ $installPluginsCommand }} {{ .JenkinsHomePath }}/base-plugins
+  {{ $installPluginsCommand }} {{ .JenkinsHomePath }}/plugins
 fi
-echo "Installing plugins required by Operator - end"
-
-echo "Installing plugins required by user - begin"
-cat > {{ .JenkinsHomePath }}/user-plugins << EOF
-{{ range $index, $plugin := .UserPlugins }}
-{{ $plugin.Name }}:{{ $plugin.Version }}{{if $plugin.DownloadURL}}:{{ $plugin.DownloadURL }}{{end}}
-{{ end }}
-EOF
-if [[ -z "${OPENSHIFT_JENKINS_IMAGE_VERSION}" ]]; then
-  {{ $installPluginsCommand }} < {{ .JenkinsHomePath }}/user-plugins
-else
-  {{ $installPluginsCommand }} {{ .JenkinsHomePath }}/user-plugins
-fi
-echo "Installing plugins required by user - end"
+echo "Installing plugins - end"
 `))
 
+// getInstallPlugins returns base and user plugins merged into one installation request in the dependency order,
+// the plugins are installed by one run of the install script
+func getInstallPlugins(jenkins *v1alpha2.Jenkins, dependencies plugins.Dependencies) ([]plugins.Plugin, error) {
+	batch := plugins.Batch(toPlugins(jenkins.Spec.Master.BasePlugins), toPlugins(jenkins.Spec.Master.Plugins))
+	installPlugins, err := plugins.InstallOrder(batch, dependencies)
+	if err != nil {
+		return nil, err
+	}
+	return ResolvePluginDownloadURLs(jenkins, installPlugins)
+}
+
+// ResolvePluginDownloadURLs sets the download URL of plugins which have spec.master.plugins[].updateSite
+// or spec.master.basePlugins[].updateSite set
+func ResolvePluginDownloadURLs(jenkins *v1alpha2.Jenkins, installPlugins []plugins.Plugin) ([]plugins.Plugin, error) {
+	sitesByPlugin := map[string]string{}
+	for _, plugin := range append(append([]v1alpha2.Plugin{}, jenkins.Spec.Master.BasePlugins...), jenkins.Spec.Master.Plugins...) {
+		if len(plugin.UpdateSite) > 0 {
+			sitesByPlugin[plugin.Name] = plugin.UpdateSite
+		}
+	}
+	if len(sitesByPlugin) == 0 {
+		return installPlugins, nil
+	}
+
+	var sites []plugins.UpdateSite
+	for _, site := range jenkins.Spec.Master.UpdateSites {
+		sites = append(sites, plugins.UpdateSite{Name: site.Name, URL: site.URL, DownloadURL: site.DownloadURL})
+	}
+	return plugins.ResolveDownloadURLs(installPlugins, sites, sitesByPlugin)
+}
+
+func toPlugins(jenkinsPlugins []v1alpha2.Plugin) []plugins.Plugin {
+	var converted []plugins.Plugin
+	for _, plugin := range jenkinsPlugins {
+		converted = append(converted, plugins.Plugin{Name: plugin.Name, Version: plugin.Version, DownloadURL: plugin.DownloadURL})
+	}
+	return converted
+}
+
+// GetInstallPluginsCommand returns the command run in Jenkins master container which downloads the plugins
+// to Jenkins home in one run of the install script, the locks left by the failed run are removed first
+func GetInstallPluginsCommand(jenkins *v1alpha2.Jenkins, installPlugins []plugins.Plugin) []string {
+	command := []string{"bash", "-c", fmt.Sprintf(`rm -rf %s/plugins/*.lock && %s/%s "$@"`,
+		GetJenkinsHomePath(jenkins), JenkinsScriptsVolumePath, installPluginsCommand), installPluginsCommand}
+	for _, plugin := range installPlugins {
+		command = append(command, plugin.InstallString())
+	}
+	return command
+}
+
 func buildConfigMapTypeMeta() metav1.TypeMeta {
 	return metav1.TypeMeta{
 		Kind:       "ConfigMap",
@@ -300,21 +381,36 @@ func buildConfigMapTypeMeta() metav1.TypeMeta {
 	}
 }
 
-func buildInitBashScript(jenkins *v1alpha2.Jenkins) (*string, error) {
+func buildInitBashScript(jenkins *v1alpha2.Jenkins, dependencies plugins.Dependencies) (*string, error) {
+	installPlugins, err := getInstallPlugins(jenkins, dependencies)
+	if err != nil {
+		return nil, failure.PluginFailure(err)
+	}
+
 	data := struct {
 		JenkinsHomePath          string
 		InitConfigurationPath    string
 		InstallPluginsCommand    string
 		JenkinsScriptsVolumePath string
-		BasePlugins              []v1alpha2.Plugin
-		UserPlugins              []v1alpha2.Plugin
+		Plugins                  []plugins.Plugin
+		TrustedCABundlePath      string
+		TrustedCADirectory       string
+		TrustStorePath           string
+		TrustStorePassword       string
+		CurlCABundlePath         string
 	}{
-		JenkinsHomePath:          getJenkinsHomePath(jenkins),
+		JenkinsHomePath:          GetJenkinsHomePath(jenkins),
 		InitConfigurationPath:    jenkinsInitConfigurationVolumePath,
-		BasePlugins:              jenkins.Spec.Master.BasePlugins,
-		UserPlugins:              jenkins.Spec.Master.Plugins,
+		Plugins:                  installPlugins,
 		InstallPluginsCommand:    installPluginsCommand,
 		JenkinsScriptsVolumePath: JenkinsScriptsVolumePath,
+		TrustedCADirectory:       getTrustedCADirectory(jenkins),
+		TrustStorePath:           getTrustStorePath(jenkins),
+		TrustStorePassword:       TrustStorePassword,
+		CurlCABundlePath:         getCurlCABundlePath(jenkins),
+	}
+	if jenkins.Spec.TrustedCABundle != nil {
+		data.TrustedCABundlePath = fmt.Sprintf("%s/%s", TrustedCABundleVolumePath, GetTrustedCABundleKey(jenkins))
 	}
 
 	output, err := render.Render(initBashTemplate, data)
@@ -329,21 +425,27 @@ func getScriptsConfigMapName(jenkins *v1alpha2.Jenkins) string {
 	return fmt.Sprintf("%s-scripts-%s", constants.OperatorName, jenkins.ObjectMeta.Name)
 }
 
-// NewScriptsConfigMap builds Kubernetes config map used to store scripts
-func NewScriptsConfigMap(meta metav1.ObjectMeta, jenkins *v1alpha2.Jenkins) (*corev1.ConfigMap, error) {
+// NewScriptsConfigMap builds Kubernetes config map used to store scripts, the plugins are installed in the order
+// of the dependencies
+func NewScriptsConfigMap(meta metav1.ObjectMeta, jenkins *v1alpha2.Jenkins, dependencies plugins.Dependencies) (*corev1.ConfigMap, error) {
 	meta.Name = getScriptsConfigMapName(jenkins)
 
-	initBashScript, err := buildInitBashScript(jenkins)
+	initBashScript, err := buildInitBashScript(jenkins, dependencies)
 	if err != nil {
 		return nil, err
 	}
 
+	data := map[string]string{
+		InitScriptName:        *initBashScript,
+		installPluginsCommand: fmt.Sprintf(installPluginsBashFmt, GetJenkinsHomePath(jenkins)),
+	}
+	if jenkins.Spec.BuildLogArchival != nil {
+		data[BuildLogArchivalScriptName] = buildLogArchivalScript
+	}
+
 	return &corev1.ConfigMap{
 		TypeMeta:   buildConfigMapTypeMeta(),
 		ObjectMeta: meta,
-		Data: map[string]string{
-			InitScriptName:        *initBashScript,
-			installPluginsCommand: fmt.Sprintf(installPluginsBashFmt, getJenkinsHomePath(jenkins)),
-		},
+		Data:       data,
 	}, nil
 }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
@@ -26,10 +27,21 @@ func UpdateService(actual corev1.Service, config v1alpha2.Service, targetPort in
 	actual.Spec.Type = config.Type
 	actual.Spec.LoadBalancerIP = config.LoadBalancerIP
 	actual.Spec.LoadBalancerSourceRanges = config.LoadBalancerSourceRanges
+	// the API server defaults IP families, keep them unless set explicitly
+	if config.IPFamilyPolicy != nil {
+		actual.Spec.IPFamilyPolicy = config.IPFamilyPolicy
+	}
+	if len(config.IPFamilies) > 0 {
+		actual.Spec.IPFamilies = config.IPFamilies
+	}
 	if len(actual.Spec.Ports) == 0 {
 		actual.Spec.Ports = []corev1.ServicePort{{}}
 	}
 	actual.Spec.Ports[0].Port = config.Port
+	if len(actual.Spec.Ports[0].Protocol) == 0 {
+		// protocol is the key of service ports required by server-side apply
+		actual.Spec.Ports[0].Protocol = corev1.ProtocolTCP
+	}
 	actual.Spec.Ports[0].TargetPort = intstr.IntOrString{IntVal: targetPort, Type: intstr.Int}
 	if config.NodePort != 0 {
 		actual.Spec.Ports[0].NodePort = config.NodePort
@@ -68,6 +80,23 @@ func GetJenkinsSlavesServiceFQDN(jenkins *v1alpha2.Jenkins, kubernetesClusterDom
 	return fmt.Sprintf("%s-slave-%s.%s.svc.%s", constants.OperatorName, jenkins.ObjectMeta.Name, jenkins.ObjectMeta.Namespace, clusterDomain), nil
 }
 
+// IsPrimaryIPFamilyChanged tells if the primary IP family of the service differs from ipFamilies, it's immutable so
+// the service must be created again
+func IsPrimaryIPFamilyChanged(actual corev1.Service, ipFamilies []corev1.IPFamily) bool {
+	return len(ipFamilies) > 0 && len(actual.Spec.IPFamilies) > 0 && actual.Spec.IPFamilies[0] != ipFamilies[0]
+}
+
+// GetJenkinsURL returns Jenkins URL for the host and port, IPv6 literals are enclosed in square brackets
+func GetJenkinsURL(host string, port int32, prefix string) string {
+	return fmt.Sprintf("http://%s%s", GetJenkinsTunnel(host, port), prefix)
+}
+
+// GetJenkinsTunnel returns host:port address used by agents to connect to Jenkins, IPv6 literals are enclosed
+// in square brackets
+func GetJenkinsTunnel(host string, port int32) string {
+	return net.JoinHostPort(strings.Trim(host, "[]"), strconv.Itoa(int(port)))
+}
+
 // GetClusterDomain returns Kubernetes cluster domain, default to "cluster.local"
 func getClusterDomain(kubernetesClusterDomain string) (string, error) {
 	isRunningInCluster, err := IsRunningInCluster()
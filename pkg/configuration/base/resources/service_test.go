@@ -0,0 +1,63 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestUpdateService(t *testing.T) {
+	requireDualStack := corev1.IPFamilyPolicyRequireDualStack
+	singleStack := corev1.IPFamilyPolicySingleStack
+	actual := corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{}},
+		Spec: corev1.ServiceSpec{
+			IPFamilyPolicy: &singleStack,
+			IPFamilies:     []corev1.IPFamily{corev1.IPv6Protocol},
+		},
+	}
+
+	t.Run("keeps defaulted IP families", func(t *testing.T) {
+		service := UpdateService(*actual.DeepCopy(), v1alpha2.Service{Port: 8080}, 8080)
+
+		assert.Equal(t, &singleStack, service.Spec.IPFamilyPolicy)
+		assert.Equal(t, []corev1.IPFamily{corev1.IPv6Protocol}, service.Spec.IPFamilies)
+	})
+	t.Run("dual-stack", func(t *testing.T) {
+		service := UpdateService(*actual.DeepCopy(), v1alpha2.Service{
+			Port:           8080,
+			IPFamilyPolicy: &requireDualStack,
+			IPFamilies:     []corev1.IPFamily{corev1.IPv6Protocol, corev1.IPv4Protocol},
+		}, 8080)
+
+		assert.Equal(t, &requireDualStack, service.Spec.IPFamilyPolicy)
+		assert.Equal(t, []corev1.IPFamily{corev1.IPv6Protocol, corev1.IPv4Protocol}, service.Spec.IPFamilies)
+	})
+}
+
+func TestGetJenkinsURL(t *testing.T) {
+	assert.Equal(t, "http://jenkins-operator-http-example.default.svc.cluster.local:8080/jenkins",
+		GetJenkinsURL("jenkins-operator-http-example.default.svc.cluster.local", 8080, "/jenkins"))
+	assert.Equal(t, "http://[fd00::10]:8080", GetJenkinsURL("fd00::10", 8080, ""))
+	assert.Equal(t, "http://[fd00::10]:8080", GetJenkinsURL("[fd00::10]", 8080, ""))
+}
+
+func TestGetJenkinsTunnel(t *testing.T) {
+	assert.Equal(t, "jenkins-operator-slave-example.default.svc.cluster.local:50000",
+		GetJenkinsTunnel("jenkins-operator-slave-example.default.svc.cluster.local", 50000))
+	assert.Equal(t, "10.0.0.10:50000", GetJenkinsTunnel("10.0.0.10", 50000))
+	assert.Equal(t, "[fd00::10]:50000", GetJenkinsTunnel("fd00::10", 50000))
+}
+
+func TestIsPrimaryIPFamilyChanged(t *testing.T) {
+	dualStack := corev1.Service{Spec: corev1.ServiceSpec{IPFamilies: []corev1.IPFamily{corev1.IPv4Protocol, corev1.IPv6Protocol}}}
+
+	assert.False(t, IsPrimaryIPFamilyChanged(dualStack, nil))
+	assert.False(t, IsPrimaryIPFamilyChanged(dualStack, []corev1.IPFamily{corev1.IPv4Protocol}))
+	assert.False(t, IsPrimaryIPFamilyChanged(corev1.Service{}, []corev1.IPFamily{corev1.IPv6Protocol}))
+	assert.True(t, IsPrimaryIPFamilyChanged(dualStack, []corev1.IPFamily{corev1.IPv6Protocol, corev1.IPv4Protocol}))
+}
@@ -0,0 +1,117 @@
+package resources
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
+
+	stackerr "github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// PodTemplateHashAnnotation is the annotation of Jenkins master StatefulSet with the hash of its pod template
+const PodTemplateHashAnnotation = "jenkins.io/pod-template-hash"
+
+// GetJenkinsStatefulSetName returns the name of Jenkins master StatefulSet for given CR
+func GetJenkinsStatefulSetName(jenkins *v1alpha2.Jenkins) string {
+	return fmt.Sprintf("jenkins-%s", jenkins.Name)
+}
+
+// GetJenkinsHeadlessServiceName returns the name of the headless service which gives Jenkins master pod
+// of the StatefulSet its DNS name
+func GetJenkinsHeadlessServiceName(jenkins *v1alpha2.Jenkins) string {
+	return fmt.Sprintf("%s-headless-%s", constants.OperatorName, jenkins.ObjectMeta.Name)
+}
+
+// NewJenkinsMasterStatefulSet builds the StatefulSet with a single Jenkins master pod, the update strategy is OnDelete
+// because the operator decides when the pod is recreated.
+func NewJenkinsMasterStatefulSet(objectMeta metav1.ObjectMeta, jenkins *v1alpha2.Jenkins) (*appsv1.StatefulSet, error) {
+	pod := NewJenkinsMasterPod(objectMeta, jenkins)
+	template := corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels:      pod.Labels,
+			Annotations: pod.Annotations,
+		},
+		Spec: pod.Spec,
+	}
+	template.Spec.RestartPolicy = corev1.RestartPolicyAlways
+
+	hash, err := hashPodTemplate(template)
+	if err != nil {
+		return nil, err
+	}
+
+	return &appsv1.StatefulSet{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "StatefulSet",
+			APIVersion: "apps/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        GetJenkinsStatefulSetName(jenkins),
+			Namespace:   objectMeta.Namespace,
+			Labels:      objectMeta.Labels,
+			Annotations: map[string]string{PodTemplateHashAnnotation: hash},
+		},
+		Spec: appsv1.StatefulSetSpec{
+			ServiceName:         GetJenkinsHeadlessServiceName(jenkins),
+			Selector:            &metav1.LabelSelector{MatchLabels: BuildResourceLabels(jenkins)},
+			Template:            template,
+			PodManagementPolicy: appsv1.OrderedReadyPodManagement,
+			UpdateStrategy:      appsv1.StatefulSetUpdateStrategy{Type: appsv1.OnDeleteStatefulSetStrategyType},
+		},
+	}, nil
+}
+
+// NewJenkinsHeadlessService builds the headless service governing Jenkins master StatefulSet, the address
+// is published before Jenkins is ready so agents and probes can resolve it during the startup
+func NewJenkinsHeadlessService(objectMeta metav1.ObjectMeta, jenkins *v1alpha2.Jenkins) *corev1.Service {
+	ports := []corev1.ServicePort{
+		{
+			Name:       "http",
+			Port:       constants.DefaultHTTPPortInt32,
+			TargetPort: intstr.FromInt(int(constants.DefaultHTTPPortInt32)),
+		},
+	}
+	if !IsTCPAgentListenerDisabled(jenkins) {
+		ports = append(ports, corev1.ServicePort{
+			Name:       "agent",
+			Port:       constants.DefaultSlavePortInt32,
+			TargetPort: intstr.FromInt(int(GetInboundAgentsTCPPort(jenkins))),
+		})
+	}
+	return &corev1.Service{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       ServiceKind,
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GetJenkinsHeadlessServiceName(jenkins),
+			Namespace: objectMeta.Namespace,
+			Labels:    objectMeta.Labels,
+		},
+		Spec: corev1.ServiceSpec{
+			ClusterIP:                corev1.ClusterIPNone,
+			Selector:                 BuildResourceLabels(jenkins),
+			PublishNotReadyAddresses: true,
+			IPFamilyPolicy:           jenkins.Spec.Service.IPFamilyPolicy,
+			IPFamilies:               jenkins.Spec.Service.IPFamilies,
+			Ports:                    ports,
+		},
+	}
+}
+
+func hashPodTemplate(template corev1.PodTemplateSpec) (string, error) {
+	data, err := json.Marshal(template)
+	if err != nil {
+		return "", stackerr.WithStack(err)
+	}
+	hash := sha256.Sum256(data)
+	return base64.StdEncoding.EncodeToString(hash[:]), nil
+}
@@ -0,0 +1,78 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetWorkloadType(t *testing.T) {
+	t.Run("default", func(t *testing.T) {
+		assert.Equal(t, v1alpha2.WorkloadTypePod, GetWorkloadType(&v1alpha2.Jenkins{}))
+	})
+	t.Run("use deployment annotation", func(t *testing.T) {
+		jenkins := &v1alpha2.Jenkins{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{UseDeploymentAnnotation: "true"}}}
+		assert.Equal(t, v1alpha2.WorkloadTypeDeployment, GetWorkloadType(jenkins))
+	})
+	t.Run("workload type overrides annotation", func(t *testing.T) {
+		jenkins := &v1alpha2.Jenkins{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{UseDeploymentAnnotation: "true"}}}
+		jenkins.Spec.Master.WorkloadType = v1alpha2.WorkloadTypeStatefulSet
+		assert.Equal(t, v1alpha2.WorkloadTypeStatefulSet, GetWorkloadType(jenkins))
+	})
+}
+
+func TestGetJenkinsMasterPodName(t *testing.T) {
+	jenkins := &v1alpha2.Jenkins{ObjectMeta: metav1.ObjectMeta{Name: "example"}}
+	assert.Equal(t, "jenkins-example", GetJenkinsMasterPodName(jenkins))
+
+	jenkins.Spec.Master.WorkloadType = v1alpha2.WorkloadTypeStatefulSet
+	assert.Equal(t, "jenkins-example-0", GetJenkinsMasterPodName(jenkins))
+	assert.Equal(t, "jenkins-example", GetJenkinsMasterBarePodName(jenkins))
+}
+
+func TestNewJenkinsMasterStatefulSet(t *testing.T) {
+	jenkins := &v1alpha2.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{Name: "example", Namespace: "default"},
+		Spec: v1alpha2.JenkinsSpec{
+			Master: v1alpha2.JenkinsMaster{
+				WorkloadType: v1alpha2.WorkloadTypeStatefulSet,
+				Containers: []v1alpha2.Container{{
+					Name:           JenkinsMasterContainerName,
+					Image:          "jenkins/jenkins:lts",
+					ReadinessProbe: &corev1.Probe{Handler: corev1.Handler{HTTPGet: &corev1.HTTPGetAction{Path: "/login"}}},
+					LivenessProbe:  &corev1.Probe{Handler: corev1.Handler{HTTPGet: &corev1.HTTPGetAction{Path: "/login"}}},
+				}},
+			},
+		},
+	}
+
+	statefulSet, err := NewJenkinsMasterStatefulSet(NewResourceObjectMeta(jenkins), jenkins)
+
+	require.NoError(t, err)
+	assert.Equal(t, "jenkins-example", statefulSet.Name)
+	assert.Nil(t, statefulSet.Spec.Replicas)
+	assert.Equal(t, "jenkins-operator-headless-example", statefulSet.Spec.ServiceName)
+	assert.Equal(t, appsv1.OnDeleteStatefulSetStrategyType, statefulSet.Spec.UpdateStrategy.Type)
+	assert.Equal(t, corev1.RestartPolicyAlways, statefulSet.Spec.Template.Spec.RestartPolicy)
+	assert.Equal(t, BuildResourceLabels(jenkins), statefulSet.Spec.Selector.MatchLabels)
+	for key, value := range statefulSet.Spec.Selector.MatchLabels {
+		assert.Equal(t, value, statefulSet.Spec.Template.Labels[key])
+	}
+	assert.NotEmpty(t, statefulSet.Annotations[PodTemplateHashAnnotation])
+
+	t.Run("hash changes with pod template", func(t *testing.T) {
+		changed := jenkins.DeepCopy()
+		changed.Spec.Master.Containers[0].Image = "jenkins/jenkins:2.303.1"
+
+		changedStatefulSet, err := NewJenkinsMasterStatefulSet(NewResourceObjectMeta(changed), changed)
+
+		require.NoError(t, err)
+		assert.NotEqual(t, statefulSet.Annotations[PodTemplateHashAnnotation], changedStatefulSet.Annotations[PodTemplateHashAnnotation])
+	})
+}
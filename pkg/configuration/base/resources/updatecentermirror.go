@@ -0,0 +1,118 @@
+package resources
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
+	"github.com/jenkinsci/kubernetes-operator/pkg/plugins"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// UpdateCenterMirrorJSONKey is the key of the generated update center JSON in the update center mirror config map
+	UpdateCenterMirrorJSONKey = "update-center.json"
+	// UpdateCenterMirrorVolumePath is a path where the update center mirror config map is mounted
+	UpdateCenterMirrorVolumePath = jenkinsPath + "/update-center-mirror"
+
+	updateCenterMirrorVolumeName            = "update-center-mirror"
+	updateCenterMirrorRootCAsVolumeName     = "update-center-root-cas"
+	updateCenterMirrorRootCAsDirectory      = "update-center-rootCAs"
+	updateCenterMirrorRootCAFileName        = "jenkins-operator.crt"
+	updateCenterMirrorCertificateCommonName = "Jenkins Operator update center"
+	updateCenterMirrorCertificateValidity   = 10 * 365 * 24 * time.Hour
+)
+
+// GetUpdateCenterMirrorName returns the name of the config map with the generated update center JSON
+// and of the secret with its signing key
+func GetUpdateCenterMirrorName(jenkins *v1alpha2.Jenkins) string {
+	return fmt.Sprintf("%s-update-center-%s", constants.OperatorName, jenkins.ObjectMeta.Name)
+}
+
+// GetUpdateCenterMirrorURL returns the URL of the update center mirror used by Jenkins plugin manager
+func GetUpdateCenterMirrorURL() string {
+	return fmt.Sprintf("file:%s/%s", UpdateCenterMirrorVolumePath, UpdateCenterMirrorJSONKey)
+}
+
+// NewUpdateCenterMirrorSigningSecret builds the Kubernetes secret with the key and the certificate
+// used to sign the update center mirror
+func NewUpdateCenterMirrorSigningSecret(meta metav1.ObjectMeta, jenkins *v1alpha2.Jenkins) (*corev1.Secret, error) {
+	key, certificate, err := plugins.NewSigningCertificate(updateCenterMirrorCertificateCommonName, updateCenterMirrorCertificateValidity)
+	if err != nil {
+		return nil, err
+	}
+
+	meta.Name = GetUpdateCenterMirrorName(jenkins)
+	return &corev1.Secret{
+		TypeMeta:   buildSecretTypeMeta(),
+		ObjectMeta: meta,
+		Type:       corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSPrivateKeyKey: key,
+			corev1.TLSCertKey:       certificate,
+		},
+	}, nil
+}
+
+// NewUpdateCenterMirrorConfigMap builds Kubernetes config map with the generated update center JSON
+func NewUpdateCenterMirrorConfigMap(meta metav1.ObjectMeta, jenkins *v1alpha2.Jenkins, updateCenter []byte) *corev1.ConfigMap {
+	meta.Name = GetUpdateCenterMirrorName(jenkins)
+	return &corev1.ConfigMap{
+		TypeMeta:   buildConfigMapTypeMeta(),
+		ObjectMeta: meta,
+		Data: map[string]string{
+			UpdateCenterMirrorJSONKey: string(updateCenter),
+		},
+	}
+}
+
+// getUpdateCenterMirrorVolumes returns the volume with the generated update center JSON, it's optional
+// because the JSON is generated after the pod is created, and the volume with the certificate trusted by Jenkins
+func getUpdateCenterMirrorVolumes(jenkins *v1alpha2.Jenkins) []corev1.Volume {
+	configMapVolumeSourceDefaultMode := corev1.ConfigMapVolumeSourceDefaultMode
+	secretVolumeSourceDefaultMode := corev1.SecretVolumeSourceDefaultMode
+	optional := true
+	return []corev1.Volume{
+		{
+			Name: updateCenterMirrorVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					DefaultMode: &configMapVolumeSourceDefaultMode,
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: GetUpdateCenterMirrorName(jenkins),
+					},
+					Optional: &optional,
+				},
+			},
+		},
+		{
+			Name: updateCenterMirrorRootCAsVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					DefaultMode: &secretVolumeSourceDefaultMode,
+					SecretName:  GetUpdateCenterMirrorName(jenkins),
+					// the signing key isn't mounted
+					Items: []corev1.KeyToPath{{Key: corev1.TLSCertKey, Path: updateCenterMirrorRootCAFileName}},
+				},
+			},
+		},
+	}
+}
+
+func getUpdateCenterMirrorVolumeMounts(jenkins *v1alpha2.Jenkins) []corev1.VolumeMount {
+	return []corev1.VolumeMount{
+		{
+			Name:      updateCenterMirrorVolumeName,
+			MountPath: UpdateCenterMirrorVolumePath,
+			ReadOnly:  true,
+		},
+		{
+			Name:      updateCenterMirrorRootCAsVolumeName,
+			MountPath: fmt.Sprintf("%s/%s", GetJenkinsHomePath(jenkins), updateCenterMirrorRootCAsDirectory),
+			ReadOnly:  true,
+		},
+	}
+}
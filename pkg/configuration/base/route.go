@@ -13,12 +13,28 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// deleteRoute deletes the Route disabled by spec.openShift.route.disabled
+func (r *JenkinsBaseConfigurationReconciler) deleteRoute(meta metav1.ObjectMeta, config *v1alpha2.Jenkins) error {
+	route := &routev1.Route{}
+	route.Name = getRouteName(config)
+	route.Namespace = meta.Namespace
+	if err := r.Client.Delete(context.TODO(), route); err != nil && !apierrors.IsNotFound(err) {
+		return stackerr.WithStack(err)
+	}
+	return nil
+}
+
+func getRouteName(config *v1alpha2.Jenkins) string {
+	return fmt.Sprintf("jenkins-%s", config.ObjectMeta.Name)
+}
+
 // createRoute takes the ServiceName and Creates the Route based on it
 func (r *JenkinsBaseConfigurationReconciler) createRoute(meta metav1.ObjectMeta, serviceName string, config *v1alpha2.Jenkins) error {
 	route := routev1.Route{}
-	name := fmt.Sprintf("jenkins-%s", config.ObjectMeta.Name)
+	name := getRouteName(config)
 	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: meta.Namespace}, &route)
 	if err != nil && apierrors.IsNotFound(err) {
 		port := &routev1.RoutePort{
@@ -50,6 +66,10 @@ func (r *JenkinsBaseConfigurationReconciler) createRoute(meta metav1.ObjectMeta,
 		}
 	} else if err != nil {
 		return stackerr.WithStack(err)
+	} else if resources.IsRouteHostCleared(route, config) {
+		// the Route is created again with the generated host in the next reconcile loop
+		err = r.Client.Delete(context.TODO(), &route, client.Preconditions{UID: &route.UID})
+		return stackerr.WithStack(client.IgnoreNotFound(err))
 	}
 
 	route.ObjectMeta.Labels = meta.Labels // make sure that user won't break service by hand
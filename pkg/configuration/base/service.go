@@ -2,6 +2,7 @@ package base
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
 	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
@@ -11,22 +12,32 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func (r *JenkinsBaseConfigurationReconciler) createService(meta metav1.ObjectMeta, name string, config v1alpha2.Service, targetPort int32) error {
+	if err := r.deleteServiceWithChangedIPFamily(name, meta.Namespace, config.IPFamilies); err != nil {
+		return err
+	}
+	expected := resources.UpdateService(corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: meta.Namespace,
+			Labels:    copyLabels(meta.Labels),
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: meta.Labels,
+		},
+	}, config, targetPort)
+	if r.ServerSideApply {
+		// the fields set by other field managers, e.g. annotations of cloud controllers, are kept
+		return stackerr.WithStack(r.ApplyResource(&expected))
+	}
+
 	service := corev1.Service{}
 	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: meta.Namespace}, &service)
 	if err != nil && apierrors.IsNotFound(err) {
-		service = resources.UpdateService(corev1.Service{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      name,
-				Namespace: meta.Namespace,
-				Labels:    meta.Labels,
-			},
-			Spec: corev1.ServiceSpec{
-				Selector: meta.Labels,
-			},
-		}, config, targetPort)
+		service = expected
 		if err = r.CreateResource(&service); err != nil {
 			return stackerr.WithStack(err)
 		}
@@ -38,3 +49,33 @@ func (r *JenkinsBaseConfigurationReconciler) createService(meta metav1.ObjectMet
 	service = resources.UpdateService(service, config, targetPort)
 	return stackerr.WithStack(r.UpdateResource(&service))
 }
+
+// deleteServiceWithChangedIPFamily deletes the service when its primary IP family is changed, the service is created
+// again with the new IP families
+func (r *JenkinsBaseConfigurationReconciler) deleteServiceWithChangedIPFamily(name, namespace string, ipFamilies []corev1.IPFamily) error {
+	if len(ipFamilies) == 0 {
+		return nil
+	}
+	service := corev1.Service{}
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: namespace}, &service)
+	if apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return stackerr.WithStack(err)
+	}
+	if !resources.IsPrimaryIPFamilyChanged(service, ipFamilies) {
+		return nil
+	}
+
+	r.logger.Info(fmt.Sprintf("Deleting Service '%s', its primary IP family %s can't be changed to %s", name, service.Spec.IPFamilies[0], ipFamilies[0]))
+	err = r.Client.Delete(context.TODO(), &service, client.Preconditions{UID: &service.UID})
+	return stackerr.WithStack(client.IgnoreNotFound(err))
+}
+
+func copyLabels(labels map[string]string) map[string]string {
+	copied := map[string]string{}
+	for key, value := range labels {
+		copied[key] = value
+	}
+	return copied
+}
@@ -15,11 +15,16 @@ import (
 )
 
 func (r *JenkinsBaseConfigurationReconciler) createServiceAccount(meta metav1.ObjectMeta) error {
-	serviceAccount := &corev1.ServiceAccount{}
-	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: meta.Name, Namespace: meta.Namespace}, serviceAccount)
 	annotations := r.Configuration.Jenkins.Spec.ServiceAccount.Annotations
 	msg := fmt.Sprintf("createServiceAccount with annotations %v", annotations)
 	r.logger.V(log.VDebug).Info(msg)
+	if r.ServerSideApply {
+		// the annotations removed from spec.serviceAccount are removed, the ones set by others are kept
+		return stackerr.WithStack(r.ApplyResource(resources.NewServiceAccount(meta, annotations)))
+	}
+
+	serviceAccount := &corev1.ServiceAccount{}
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: meta.Name, Namespace: meta.Namespace}, serviceAccount)
 	if err != nil && apierrors.IsNotFound(err) {
 		serviceAccount = resources.NewServiceAccount(meta, annotations)
 		if err = r.CreateResource(serviceAccount); err != nil {
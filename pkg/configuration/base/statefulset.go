@@ -0,0 +1,155 @@
+package base
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+
+	stackerr "github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func useStatefulSetForJenkinsMaster(jenkins *v1alpha2.Jenkins) bool {
+	return resources.GetWorkloadType(jenkins) == v1alpha2.WorkloadTypeStatefulSet
+}
+
+// ensureJenkinsMasterWorkload makes sure that only the workload of spec.master.workloadType runs Jenkins master,
+// both mustn't use Jenkins home at the same time
+func (r *JenkinsBaseConfigurationReconciler) ensureJenkinsMasterWorkload(meta metav1.ObjectMeta) error {
+	if !useStatefulSetForJenkinsMaster(r.Configuration.Jenkins) {
+		return r.deleteJenkinsMasterStatefulSet()
+	}
+
+	if err := r.deleteBareJenkinsMasterPod(); err != nil {
+		return err
+	}
+	if err := r.createHeadlessService(meta); err != nil {
+		return err
+	}
+	return r.ensureJenkinsMasterStatefulSet(meta)
+}
+
+// ensureJenkinsMasterStatefulSet creates the StatefulSet of Jenkins master or updates its pod template,
+// the pod is recreated with the new template when the operator deletes it
+func (r *JenkinsBaseConfigurationReconciler) ensureJenkinsMasterStatefulSet(meta metav1.ObjectMeta) error {
+	expected, err := resources.NewJenkinsMasterStatefulSet(meta, r.Configuration.Jenkins)
+	if err != nil {
+		return err
+	}
+
+	current := &appsv1.StatefulSet{}
+	err = r.Client.Get(context.TODO(), types.NamespacedName{Name: expected.Name, Namespace: expected.Namespace}, current)
+	if apierrors.IsNotFound(err) {
+		r.logger.Info(fmt.Sprintf("Creating a new Jenkins master StatefulSet %s/%s", expected.Namespace, expected.Name))
+		if r.ServerSideApply {
+			return stackerr.WithStack(r.ApplyResource(expected))
+		}
+		return stackerr.WithStack(r.CreateResource(expected))
+	} else if err != nil {
+		return stackerr.WithStack(err)
+	}
+
+	hash := expected.Annotations[resources.PodTemplateHashAnnotation]
+	if current.Annotations[resources.PodTemplateHashAnnotation] != hash {
+		r.logger.Info(fmt.Sprintf("Updating Jenkins master StatefulSet %s/%s", current.Namespace, current.Name))
+		if err = r.updateJenkinsMasterStatefulSet(current, expected); err != nil {
+			return err
+		}
+	}
+	if current.Spec.Replicas != nil && *current.Spec.Replicas == 1 {
+		return nil
+	}
+	return r.scaleJenkinsMasterStatefulSet(current, 1)
+}
+
+func (r *JenkinsBaseConfigurationReconciler) updateJenkinsMasterStatefulSet(current, expected *appsv1.StatefulSet) error {
+	if r.ServerSideApply {
+		return stackerr.WithStack(r.ApplyResource(expected))
+	}
+	if current.Annotations == nil {
+		current.Annotations = map[string]string{}
+	}
+	current.Annotations[resources.PodTemplateHashAnnotation] = expected.Annotations[resources.PodTemplateHashAnnotation]
+	current.Spec.Template = expected.Spec.Template
+	return stackerr.WithStack(r.UpdateResource(current))
+}
+
+// scaleJenkinsMasterStatefulSet patches only the replicas, they aren't set in the created and applied StatefulSet,
+// so the next server-side apply doesn't reset them
+func (r *JenkinsBaseConfigurationReconciler) scaleJenkinsMasterStatefulSet(statefulSet *appsv1.StatefulSet, replicas int32) error {
+	patch := client.MergeFrom(statefulSet.DeepCopy())
+	statefulSet.Spec.Replicas = pointer.Int32Ptr(replicas)
+	return stackerr.WithStack(r.Client.Patch(context.TODO(), statefulSet, patch, client.FieldOwner(configuration.FieldManager)))
+}
+
+// stopJenkinsMasterStatefulSet scales the StatefulSet down, so Jenkins master pod isn't created again
+// until ensureJenkinsMasterStatefulSet scales it up
+func (r *JenkinsBaseConfigurationReconciler) stopJenkinsMasterStatefulSet() error {
+	statefulSet := &appsv1.StatefulSet{}
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: resources.GetJenkinsStatefulSetName(r.Configuration.Jenkins), Namespace: r.Configuration.Jenkins.Namespace}, statefulSet)
+	if apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return stackerr.WithStack(err)
+	}
+	if statefulSet.Spec.Replicas != nil && *statefulSet.Spec.Replicas == 0 {
+		return nil
+	}
+	return r.scaleJenkinsMasterStatefulSet(statefulSet, 0)
+}
+
+func (r *JenkinsBaseConfigurationReconciler) createHeadlessService(meta metav1.ObjectMeta) error {
+	service := resources.NewJenkinsHeadlessService(meta, r.Configuration.Jenkins)
+	if err := r.deleteServiceWithChangedIPFamily(service.Name, service.Namespace, service.Spec.IPFamilies); err != nil {
+		return err
+	}
+	if r.ServerSideApply {
+		return stackerr.WithStack(r.ApplyResource(service))
+	}
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: service.Name, Namespace: service.Namespace}, &corev1.Service{})
+	if apierrors.IsNotFound(err) {
+		return stackerr.WithStack(r.CreateResource(service))
+	}
+	return stackerr.WithStack(err)
+}
+
+// isNewStatefulSetPod returns true when the StatefulSet has created Jenkins master pod after the base configuration
+// was started, the pod doesn't have the configuration applied by the operator
+func isNewStatefulSetPod(jenkins *v1alpha2.Jenkins, pod corev1.Pod) bool {
+	provisionStartTime := jenkins.Status.ProvisionStartTime
+	return provisionStartTime == nil || pod.CreationTimestamp.After(provisionStartTime.Time)
+}
+
+// deleteJenkinsMasterStatefulSet deletes the StatefulSet together with its pod when spec.master.workloadType isn't StatefulSet
+func (r *JenkinsBaseConfigurationReconciler) deleteJenkinsMasterStatefulSet() error {
+	statefulSet := &appsv1.StatefulSet{}
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: resources.GetJenkinsStatefulSetName(r.Configuration.Jenkins), Namespace: r.Configuration.Jenkins.Namespace}, statefulSet)
+	if err != nil {
+		return stackerr.WithStack(client.IgnoreNotFound(err))
+	}
+	r.logger.Info(fmt.Sprintf("Deleting Jenkins master StatefulSet %s/%s, spec.master.workloadType has changed", statefulSet.Namespace, statefulSet.Name))
+	return stackerr.WithStack(client.IgnoreNotFound(r.Client.Delete(context.TODO(), statefulSet)))
+}
+
+// deleteBareJenkinsMasterPod deletes Jenkins master pod created by the operator when spec.master.workloadType is StatefulSet
+func (r *JenkinsBaseConfigurationReconciler) deleteBareJenkinsMasterPod() error {
+	pod := &corev1.Pod{}
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: resources.GetJenkinsMasterBarePodName(r.Configuration.Jenkins), Namespace: r.Configuration.Jenkins.Namespace}, pod)
+	if err != nil {
+		return stackerr.WithStack(client.IgnoreNotFound(err))
+	}
+	if r.IsJenkinsTerminating(*pod) {
+		return nil
+	}
+	r.logger.Info(fmt.Sprintf("Deleting Jenkins master Pod %s/%s, spec.master.workloadType has changed", pod.Namespace, pod.Name))
+	return stackerr.WithStack(client.IgnoreNotFound(r.Client.Delete(context.TODO(), pod)))
+}
@@ -0,0 +1,75 @@
+package base
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestJenkinsMasterStatefulSetReplicas(t *testing.T) {
+	log.SetupLogger(true)
+	require.NoError(t, v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme))
+	jenkins := &v1alpha2.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: defaultNamespace},
+		Spec: v1alpha2.JenkinsSpec{
+			Master: v1alpha2.JenkinsMaster{
+				WorkloadType: v1alpha2.WorkloadTypeStatefulSet,
+				Containers: []v1alpha2.Container{{
+					Name:           resources.JenkinsMasterContainerName,
+					Image:          "jenkins/jenkins:lts",
+					ReadinessProbe: &corev1.Probe{Handler: corev1.Handler{HTTPGet: &corev1.HTTPGetAction{Path: "/login"}}},
+					LivenessProbe:  &corev1.Probe{Handler: corev1.Handler{HTTPGet: &corev1.HTTPGetAction{Path: "/login"}}},
+				}},
+			},
+		},
+	}
+	newReconciler := func(t *testing.T, replicas int32) (*JenkinsBaseConfigurationReconciler, *appsv1.StatefulSet) {
+		statefulSet, err := resources.NewJenkinsMasterStatefulSet(resources.NewResourceObjectMeta(jenkins), jenkins)
+		require.NoError(t, err)
+		statefulSet.Spec.Replicas = pointer.Int32Ptr(replicas)
+		reconciler := New(configuration.Configuration{
+			Client:  fake.NewClientBuilder().WithObjects(jenkins, statefulSet).Build(),
+			Jenkins: jenkins,
+			Scheme:  scheme.Scheme,
+		}, client.JenkinsAPIConnectionSettings{})
+		return reconciler, statefulSet
+	}
+	getReplicas := func(t *testing.T, reconciler *JenkinsBaseConfigurationReconciler, statefulSet *appsv1.StatefulSet) int32 {
+		current := &appsv1.StatefulSet{}
+		require.NoError(t, reconciler.Client.Get(context.TODO(), types.NamespacedName{Name: statefulSet.Name, Namespace: statefulSet.Namespace}, current))
+		assert.Equal(t, statefulSet.Annotations[resources.PodTemplateHashAnnotation], current.Annotations[resources.PodTemplateHashAnnotation])
+		return *current.Spec.Replicas
+	}
+
+	t.Run("stops StatefulSet", func(t *testing.T) {
+		reconciler, statefulSet := newReconciler(t, 1)
+
+		err := reconciler.stopJenkinsMasterStatefulSet()
+
+		require.NoError(t, err)
+		assert.Equal(t, int32(0), getReplicas(t, reconciler, statefulSet))
+	})
+	t.Run("scales stopped StatefulSet up", func(t *testing.T) {
+		reconciler, statefulSet := newReconciler(t, 0)
+
+		err := reconciler.ensureJenkinsMasterStatefulSet(resources.NewResourceObjectMeta(jenkins))
+
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), getReplicas(t, reconciler, statefulSet))
+	})
+}
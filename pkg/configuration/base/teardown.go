@@ -0,0 +1,133 @@
+package base
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/backup"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/backuprestore"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+	k8sevent "github.com/jenkinsci/kubernetes-operator/pkg/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+
+	stackerr "github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// TeardownFinalizer is the finalizer of Jenkins CR which makes the operator tear down Jenkins before the CR is deleted
+const TeardownFinalizer = "jenkins.io/teardown"
+
+// Teardown runs the ordered teardown of Jenkins CR being deleted: the final backup, backup trigger, extra role bindings
+// and Jenkins home claims. The resources owned by Jenkins CR are deleted by the garbage collector after the finalizer is removed.
+func (r *JenkinsBaseConfigurationReconciler) Teardown() error {
+	jenkins := r.Configuration.Jenkins
+	r.logger.Info("Tearing down Jenkins")
+
+	backupAndRestore := backuprestore.New(r.Configuration, r.logger)
+	if backupAndRestore.IsBackupTriggerEnabled() {
+		backupAndRestore.StopBackupTrigger()
+	}
+	if err := r.finalBackup(backupAndRestore); err != nil {
+		// Jenkins home is gone with the deleted claims, so the deletion waits until the backup succeeds or backups are disabled
+		if getReclaimPolicy(jenkins) == v1alpha2.PersistenceReclaimDelete {
+			return err
+		}
+		r.logger.V(log.VWarn).Info(fmt.Sprintf("Final backup failed, Jenkins home claims are retained: %s", err))
+	}
+
+	if err := r.deleteExtraRoleBindings(); err != nil {
+		return err
+	}
+	return r.reclaimJenkinsHomeClaims()
+}
+
+// finalBackup makes the backup of running Jenkins, it's skipped when backups aren't configured or Jenkins hasn't been configured yet
+func (r *JenkinsBaseConfigurationReconciler) finalBackup(backupAndRestore *backuprestore.BackupAndRestore) error {
+	jenkins := r.Configuration.Jenkins
+	if !backup.IsBackupConfigured(jenkins) || backuprestore.AreBackupsDisabled(jenkins) {
+		return nil
+	}
+	if jenkins.Status.UserConfigurationCompletedTime == nil {
+		r.logger.Info("Skipping final backup, Jenkins hasn't been configured")
+		return nil
+	}
+	pod, err := r.Configuration.GetJenkinsMasterPod()
+	if apierrors.IsNotFound(err) {
+		r.logger.Info("Skipping final backup, Jenkins master pod doesn't exist")
+		return nil
+	} else if err != nil {
+		return stackerr.WithStack(err)
+	}
+	if pod.Status.Phase != corev1.PodRunning || r.IsJenkinsTerminating(*pod) {
+		r.logger.Info("Skipping final backup, Jenkins master pod isn't running")
+		return nil
+	}
+
+	if jenkins.Status.LastBackup == jenkins.Status.PendingBackup {
+		jenkins.Status.PendingBackup++
+	}
+	return backupAndRestore.Backup(false)
+}
+
+// deleteExtraRoleBindings deletes role bindings of spec.roles, they aren't owned by Jenkins CR
+func (r *JenkinsBaseConfigurationReconciler) deleteExtraRoleBindings() error {
+	jenkins := r.Configuration.Jenkins
+	serviceAccountName := resources.NewResourceObjectMeta(jenkins).Name
+	roleBindings := &rbacv1.RoleBindingList{}
+	if err := r.Client.List(context.TODO(), roleBindings, client.InNamespace(jenkins.Namespace)); err != nil {
+		return stackerr.WithStack(err)
+	}
+	for _, roleBinding := range roleBindings.Items {
+		if !strings.HasPrefix(roleBinding.Name, getExtraRoleBindingName(serviceAccountName, rbacv1.RoleRef{Kind: "Role"})) &&
+			!strings.HasPrefix(roleBinding.Name, getExtraRoleBindingName(serviceAccountName, rbacv1.RoleRef{Kind: "ClusterRole"})) {
+			continue
+		}
+		r.logger.Info(fmt.Sprintf("Deleting RoleBinding '%s'", roleBinding.Name))
+		if err := r.Client.Delete(context.TODO(), &roleBinding); err != nil && !apierrors.IsNotFound(err) {
+			return stackerr.WithStack(err)
+		}
+	}
+	return nil
+}
+
+// reclaimJenkinsHomeClaims deletes Jenkins home claims, including the claims of migrations, when spec.persistence.reclaimPolicy is Delete
+func (r *JenkinsBaseConfigurationReconciler) reclaimJenkinsHomeClaims() error {
+	jenkins := r.Configuration.Jenkins
+	claims := &corev1.PersistentVolumeClaimList{}
+	err := r.Client.List(context.TODO(), claims, client.InNamespace(jenkins.Namespace), client.MatchingLabels(resources.BuildResourceLabels(jenkins)))
+	if err != nil {
+		return stackerr.WithStack(err)
+	}
+
+	for _, claim := range claims.Items {
+		if getReclaimPolicy(jenkins) != v1alpha2.PersistenceReclaimDelete {
+			message := fmt.Sprintf("Jenkins home PersistentVolumeClaim '%s' has been retained", claim.Name)
+			r.logger.Info(message)
+			r.Configuration.Emit(k8sevent.TypeNormal, k8sevent.ReasonPersistentVolumeClaimRetained, message)
+			continue
+		}
+		if err = r.Client.Delete(context.TODO(), &claim); err != nil && !apierrors.IsNotFound(err) {
+			return stackerr.WithStack(err)
+		}
+		message := fmt.Sprintf("Jenkins home PersistentVolumeClaim '%s' has been deleted", claim.Name)
+		r.logger.Info(message)
+		r.Configuration.Emit(k8sevent.TypeNormal, k8sevent.ReasonPersistentVolumeClaimDeleted, message)
+	}
+	return nil
+}
+
+// getReclaimPolicy returns spec.persistence.reclaimPolicy, the claims of backup verification Jenkins are always deleted
+func getReclaimPolicy(jenkins *v1alpha2.Jenkins) v1alpha2.PersistenceReclaimPolicy {
+	if backuprestore.IsVerificationJenkins(jenkins) {
+		return v1alpha2.PersistenceReclaimDelete
+	}
+	if jenkins.Spec.Persistence == nil || len(jenkins.Spec.Persistence.ReclaimPolicy) == 0 {
+		return v1alpha2.PersistenceReclaimRetain
+	}
+	return jenkins.Spec.Persistence.ReclaimPolicy
+}
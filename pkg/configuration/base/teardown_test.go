@@ -0,0 +1,147 @@
+package base
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/backuprestore"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestTeardown(t *testing.T) {
+	log.SetupLogger(true)
+	require.NoError(t, v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme))
+	storageClassName := "standard"
+	newJenkins := func(reclaimPolicy v1alpha2.PersistenceReclaimPolicy) *v1alpha2.Jenkins {
+		return &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: defaultNamespace},
+			Spec: v1alpha2.JenkinsSpec{
+				Master: v1alpha2.JenkinsMaster{
+					Containers: []v1alpha2.Container{{Name: resources.JenkinsMasterContainerName, Image: "jenkins/jenkins:lts"}},
+				},
+				Persistence: &v1alpha2.Persistence{
+					Size:          resource.MustParse("10Gi"),
+					ReclaimPolicy: reclaimPolicy,
+				},
+			},
+		}
+	}
+	newObjects := func(jenkins *v1alpha2.Jenkins) []k8sclient.Object {
+		serviceAccountName := resources.NewResourceObjectMeta(jenkins).Name
+		return []k8sclient.Object{
+			jenkins,
+			resources.NewJenkinsHomePersistentVolumeClaim(jenkins, resources.GetJenkinsHomeClaimName(jenkins), nil),
+			resources.NewJenkinsHomePersistentVolumeClaim(jenkins, resources.GetJenkinsHomeMigrationClaimName(jenkins, storageClassName), &storageClassName),
+			resources.NewRoleBinding(getExtraRoleBindingName(serviceAccountName, rbacv1.RoleRef{Kind: "ClusterRole", Name: "view"}), defaultNamespace, serviceAccountName, rbacv1.RoleRef{Kind: "ClusterRole", Name: "view"}),
+			resources.NewRoleBinding("other", defaultNamespace, "other", rbacv1.RoleRef{Kind: "Role", Name: "other"}),
+		}
+	}
+	listClaims := func(t *testing.T, reconciler *JenkinsBaseConfigurationReconciler) []string {
+		claims := &corev1.PersistentVolumeClaimList{}
+		require.NoError(t, reconciler.Client.List(context.TODO(), claims))
+		var names []string
+		for _, claim := range claims.Items {
+			names = append(names, claim.Name)
+		}
+		return names
+	}
+	listRoleBindings := func(t *testing.T, reconciler *JenkinsBaseConfigurationReconciler) []string {
+		roleBindings := &rbacv1.RoleBindingList{}
+		require.NoError(t, reconciler.Client.List(context.TODO(), roleBindings))
+		var names []string
+		for _, roleBinding := range roleBindings.Items {
+			names = append(names, roleBinding.Name)
+		}
+		return names
+	}
+
+	t.Run("retains claims by default", func(t *testing.T) {
+		jenkins := newJenkins("")
+		reconciler := New(configuration.Configuration{
+			Client:  fake.NewClientBuilder().WithObjects(newObjects(jenkins)...).Build(),
+			Jenkins: jenkins,
+		}, client.JenkinsAPIConnectionSettings{})
+
+		err := reconciler.Teardown()
+
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"jenkins-operator-home-jenkins", "jenkins-operator-home-jenkins-standard"}, listClaims(t, reconciler))
+		assert.Equal(t, []string{"other"}, listRoleBindings(t, reconciler))
+	})
+	t.Run("deletes claims", func(t *testing.T) {
+		jenkins := newJenkins(v1alpha2.PersistenceReclaimDelete)
+		reconciler := New(configuration.Configuration{
+			Client:  fake.NewClientBuilder().WithObjects(newObjects(jenkins)...).Build(),
+			Jenkins: jenkins,
+		}, client.JenkinsAPIConnectionSettings{})
+
+		err := reconciler.Teardown()
+
+		require.NoError(t, err)
+		assert.Empty(t, listClaims(t, reconciler))
+		assert.Equal(t, []string{"other"}, listRoleBindings(t, reconciler))
+	})
+	t.Run("deletes claims of backup verification Jenkins", func(t *testing.T) {
+		jenkins := newJenkins(v1alpha2.PersistenceReclaimRetain)
+		jenkins.Labels = map[string]string{backuprestore.VerificationLabelKey: "drill"}
+		reconciler := New(configuration.Configuration{
+			Client:  fake.NewClientBuilder().WithObjects(newObjects(jenkins)...).Build(),
+			Jenkins: jenkins,
+		}, client.JenkinsAPIConnectionSettings{})
+
+		err := reconciler.Teardown()
+
+		require.NoError(t, err)
+		assert.Empty(t, listClaims(t, reconciler))
+	})
+	t.Run("skips final backup of Jenkins which hasn't been configured", func(t *testing.T) {
+		jenkins := newJenkins(v1alpha2.PersistenceReclaimDelete)
+		jenkins.Spec.Backup = v1alpha2.Backup{
+			ContainerName: "backup",
+			Action:        v1alpha2.Handler{Exec: &corev1.ExecAction{Command: []string{"backup.sh"}}},
+		}
+		reconciler := New(configuration.Configuration{
+			Client:  fake.NewClientBuilder().WithObjects(newObjects(jenkins)...).Build(),
+			Jenkins: jenkins,
+		}, client.JenkinsAPIConnectionSettings{})
+
+		err := reconciler.Teardown()
+
+		require.NoError(t, err)
+		assert.Equal(t, uint64(0), jenkins.Status.PendingBackup)
+		assert.Empty(t, listClaims(t, reconciler))
+	})
+	t.Run("skips final backup when Jenkins master pod doesn't exist", func(t *testing.T) {
+		jenkins := newJenkins(v1alpha2.PersistenceReclaimDelete)
+		now := metav1.Now()
+		jenkins.Status.UserConfigurationCompletedTime = &now
+		jenkins.Spec.Backup = v1alpha2.Backup{
+			ContainerName: "backup",
+			Action:        v1alpha2.Handler{Exec: &corev1.ExecAction{Command: []string{"backup.sh"}}},
+		}
+		reconciler := New(configuration.Configuration{
+			Client:  fake.NewClientBuilder().WithObjects(newObjects(jenkins)...).Build(),
+			Jenkins: jenkins,
+		}, client.JenkinsAPIConnectionSettings{})
+
+		err := reconciler.Teardown()
+
+		require.NoError(t, err)
+		assert.Equal(t, uint64(0), jenkins.Status.PendingBackup)
+		assert.Empty(t, listClaims(t, reconciler))
+	})
+}
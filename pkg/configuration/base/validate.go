@@ -3,12 +3,19 @@ package base
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"path"
 	"regexp"
 	"strings"
 
 	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
 	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/maintenance"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/requeue"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/securityprofile"
 	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/notify"
 	"github.com/jenkinsci/kubernetes-operator/pkg/plugins"
 
 	docker "github.com/docker/distribution/reference"
@@ -16,6 +23,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 var (
@@ -43,8 +51,15 @@ func (r *JenkinsBaseConfigurationReconciler) Validate(jenkins *v1alpha2.Jenkins)
 			}
 		}
 	}
+	if msg := r.validateInitContainers(); len(msg) > 0 {
+		messages = append(messages, msg...)
+	}
+
+	if msg := r.validatePlugins(r.getRequiredBasePlugins(), jenkins.Spec.Master.BasePlugins, jenkins.Spec.Master.Plugins); len(msg) > 0 {
+		messages = append(messages, msg...)
+	}
 
-	if msg := r.validatePlugins(plugins.BasePlugins(), jenkins.Spec.Master.BasePlugins, jenkins.Spec.Master.Plugins); len(msg) > 0 {
+	if msg := jenkins.VerifyPluginPolicy(); len(msg) > 0 {
 		messages = append(messages, msg...)
 	}
 
@@ -52,6 +67,10 @@ func (r *JenkinsBaseConfigurationReconciler) Validate(jenkins *v1alpha2.Jenkins)
 		messages = append(messages, msg...)
 	}
 
+	if msg := r.validateJVM(); len(msg) > 0 {
+		messages = append(messages, msg...)
+	}
+
 	if msg, err := r.validateCustomization(r.Configuration.Jenkins.Spec.GroovyScripts.Customization, "spec.groovyScripts"); err != nil {
 		return nil, err
 	} else if len(msg) > 0 {
@@ -63,9 +82,83 @@ func (r *JenkinsBaseConfigurationReconciler) Validate(jenkins *v1alpha2.Jenkins)
 		messages = append(messages, msg...)
 	}
 
+	if msg := r.validateProxy(); len(msg) > 0 {
+		messages = append(messages, msg...)
+	}
+
+	if msg, err := r.validateTrustedCABundle(); err != nil {
+		return nil, err
+	} else if len(msg) > 0 {
+		messages = append(messages, msg...)
+	}
+
+	if msg, err := r.validateBuildLogArchival(); err != nil {
+		return nil, err
+	} else if len(msg) > 0 {
+		messages = append(messages, msg...)
+	}
+
+	if msg := r.validatePluginUpdates(); len(msg) > 0 {
+		messages = append(messages, msg...)
+	}
+
+	if msg := r.validateUpdateCenterMirror(); len(msg) > 0 {
+		messages = append(messages, msg...)
+	}
+
+	if msg := r.validateUpdateSites(); len(msg) > 0 {
+		messages = append(messages, msg...)
+	}
+
+	if msg, err := r.validatePersistence(); err != nil {
+		return nil, err
+	} else if len(msg) > 0 {
+		messages = append(messages, msg...)
+	}
+
+	if msg := r.validateDiskUsage(); len(msg) > 0 {
+		messages = append(messages, msg...)
+	}
+
+	if msg := r.validateCanary(); len(msg) > 0 {
+		messages = append(messages, msg...)
+	}
+
+	if msg := r.validateOpenShift(); len(msg) > 0 {
+		messages = append(messages, msg...)
+	}
+
+	if msg := r.validateServices(); len(msg) > 0 {
+		messages = append(messages, msg...)
+	}
+
+	if msg := r.validateInboundAgents(); len(msg) > 0 {
+		messages = append(messages, msg...)
+	}
+
+	if msg := securityprofile.Validate(jenkins); len(msg) > 0 {
+		messages = append(messages, msg...)
+	}
+
+	if msg := maintenance.Validate(jenkins.Spec.MaintenanceWindows); len(msg) > 0 {
+		messages = append(messages, msg...)
+	}
+
+	if msg := notify.Validate(jenkins.Spec.Notifications); len(msg) > 0 {
+		messages = append(messages, msg...)
+	}
+
+	if value, ok := jenkins.Annotations[log.LevelAnnotation]; ok && !log.IsValidLevel(value) {
+		messages = append(messages, fmt.Sprintf("unrecognized '%s' value of %s annotation, must be one of debug, info or warn", value, log.LevelAnnotation))
+	}
+	messages = append(messages, requeue.ValidateAnnotations(jenkins)...)
+
 	if jenkins.Spec.JenkinsAPISettings.AuthorizationStrategy != v1alpha2.CreateUserAuthorizationStrategy && jenkins.Spec.JenkinsAPISettings.AuthorizationStrategy != v1alpha2.ServiceAccountAuthorizationStrategy {
 		messages = append(messages, fmt.Sprintf("unrecognized '%s' spec.jenkinsAPISettings.authorizationStrategy", jenkins.Spec.JenkinsAPISettings.AuthorizationStrategy))
 	}
+	if jenkins.Spec.JenkinsAPISettings.CredentialsRotation != nil && jenkins.Spec.JenkinsAPISettings.AuthorizationStrategy != v1alpha2.CreateUserAuthorizationStrategy {
+		messages = append(messages, fmt.Sprintf("spec.jenkinsAPISettings.credentialsRotation is supported only by '%s' authorization strategy", v1alpha2.CreateUserAuthorizationStrategy))
+	}
 
 	return messages, nil
 }
@@ -253,6 +346,32 @@ func (r *JenkinsBaseConfigurationReconciler) validateContainer(container v1alpha
 	return messages
 }
 
+func (r *JenkinsBaseConfigurationReconciler) validateInitContainers() []string {
+	var messages []string
+	names := map[string]bool{}
+	for _, container := range r.Configuration.Jenkins.Spec.Master.Containers {
+		names[container.Name] = true
+	}
+	for _, container := range r.Configuration.Jenkins.Spec.Master.InitContainers {
+		if len(container.Name) == 0 {
+			messages = append(messages, "spec.master.initContainers[].name is not set")
+			continue
+		}
+		containerMessages := r.validateContainer(container)
+		if names[container.Name] {
+			containerMessages = append(containerMessages, "name is already used by another container")
+		}
+		names[container.Name] = true
+		if container.ReadinessProbe != nil || container.LivenessProbe != nil || container.Lifecycle != nil {
+			containerMessages = append(containerMessages, "readinessProbe, livenessProbe and lifecycle can't be set for init container")
+		}
+		for _, m := range containerMessages {
+			messages = append(messages, fmt.Sprintf("InitContainer `%s` - %s", container.Name, m))
+		}
+	}
+	return messages
+}
+
 func (r *JenkinsBaseConfigurationReconciler) validateContainerVolumeMounts(container v1alpha2.Container) []string {
 	var messages []string
 	allVolumes := append(resources.GetJenkinsMasterPodBaseVolumes(r.Configuration.Jenkins), r.Configuration.Jenkins.Spec.Master.Volumes...)
@@ -353,6 +472,19 @@ func (r *JenkinsBaseConfigurationReconciler) validatePlugins(requiredBasePlugins
 	return messages
 }
 
+// getRequiredBasePlugins returns plugins of the base plugin manifest selected for Jenkins image
+func (r *JenkinsBaseConfigurationReconciler) getRequiredBasePlugins() []plugins.Plugin {
+	manifests := r.Configuration.BasePluginManifests
+	if len(manifests) == 0 {
+		manifests = plugins.DefaultBasePluginManifests()
+	}
+	jenkins := r.Configuration.Jenkins
+	if len(jenkins.Spec.Master.Containers) == 0 {
+		return plugins.BasePlugins()
+	}
+	return manifests.ForImage(jenkins.Spec.Master.Containers[0].Image).Plugins
+}
+
 func (r *JenkinsBaseConfigurationReconciler) verifyBasePlugins(requiredBasePlugins []plugins.Plugin, basePlugins []v1alpha2.Plugin) []string {
 	var messages []string
 
@@ -408,3 +540,463 @@ func (r *JenkinsBaseConfigurationReconciler) validateCustomization(customization
 
 	return messages, nil
 }
+
+func (r *JenkinsBaseConfigurationReconciler) validateProxy() []string {
+	var messages []string
+	proxy := r.Configuration.Jenkins.Spec.Proxy
+	if proxy == nil {
+		return nil
+	}
+
+	if len(proxy.HTTPProxy) == 0 && len(proxy.HTTPSProxy) == 0 {
+		messages = append(messages, "spec.proxy.httpProxy or spec.proxy.httpsProxy must be set")
+	}
+	if len(proxy.HTTPProxy) > 0 {
+		if _, _, err := resources.ParseProxyURL(proxy.HTTPProxy); err != nil {
+			messages = append(messages, fmt.Sprintf("spec.proxy.httpProxy '%s' is invalid: %s", proxy.HTTPProxy, err))
+		}
+	}
+	if len(proxy.HTTPSProxy) > 0 {
+		if _, _, err := resources.ParseProxyURL(proxy.HTTPSProxy); err != nil {
+			messages = append(messages, fmt.Sprintf("spec.proxy.httpsProxy '%s' is invalid: %s", proxy.HTTPSProxy, err))
+		}
+	}
+	for _, host := range proxy.NoProxy {
+		if len(host) == 0 || strings.ContainsAny(host, " ,|'") {
+			messages = append(messages, fmt.Sprintf("spec.proxy.noProxy host '%s' is invalid", host))
+		}
+	}
+	if credentials := proxy.Credentials; credentials != nil {
+		if len(credentials.UsernameSecretKeySelector.Name) == 0 || len(credentials.UsernameSecretKeySelector.Key) == 0 {
+			messages = append(messages, "spec.proxy.credentials.usernameSecretKeySelector secret name and key must be set")
+		}
+		if len(credentials.PasswordSecretKeySelector.Name) == 0 || len(credentials.PasswordSecretKeySelector.Key) == 0 {
+			messages = append(messages, "spec.proxy.credentials.passwordSecretKeySelector secret name and key must be set")
+		}
+	}
+
+	return messages
+}
+
+func (r *JenkinsBaseConfigurationReconciler) validatePluginUpdates() []string {
+	pluginUpdates := r.Configuration.Jenkins.Spec.PluginUpdates
+	if pluginUpdates == nil || len(pluginUpdates.UpdateCenterURL) == 0 {
+		return nil
+	}
+
+	if parsed, err := url.Parse(pluginUpdates.UpdateCenterURL); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || len(parsed.Host) == 0 {
+		return []string{fmt.Sprintf("spec.pluginUpdates.updateCenterURL '%s' must be a valid HTTP(S) URL", pluginUpdates.UpdateCenterURL)}
+	}
+	return nil
+}
+
+func (r *JenkinsBaseConfigurationReconciler) validateUpdateSites() []string {
+	var messages []string
+	master := r.Configuration.Jenkins.Spec.Master
+	siteNames := map[string]bool{plugins.DefaultUpdateSiteName: true, plugins.ExperimentalUpdateSiteName: true}
+	for _, site := range master.UpdateSites {
+		if siteNames[site.Name] {
+			messages = append(messages, fmt.Sprintf("spec.master.updateSites name '%s' is not unique", site.Name))
+		} else if !plugins.NamePattern.MatchString(site.Name) {
+			messages = append(messages, fmt.Sprintf("spec.master.updateSites name '%s' must follow pattern '%s'", site.Name, plugins.NamePattern.String()))
+		}
+		siteNames[site.Name] = true
+		siteURLs := []string{site.URL}
+		if len(site.DownloadURL) > 0 {
+			siteURLs = append(siteURLs, site.DownloadURL)
+		}
+		for _, siteURL := range siteURLs {
+			if parsed, err := url.Parse(siteURL); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || len(parsed.Host) == 0 {
+				messages = append(messages, fmt.Sprintf("spec.master.updateSites '%s' URL '%s' must be a valid HTTP(S) URL", site.Name, siteURL))
+			}
+		}
+	}
+
+	for _, plugin := range append(append([]v1alpha2.Plugin{}, master.BasePlugins...), master.Plugins...) {
+		if len(plugin.UpdateSite) == 0 {
+			continue
+		}
+		if !siteNames[plugin.UpdateSite] {
+			messages = append(messages, fmt.Sprintf("update site '%s' of plugin '%s' not found in spec.master.updateSites", plugin.UpdateSite, plugin.Name))
+		}
+		if len(plugin.DownloadURL) > 0 {
+			messages = append(messages, fmt.Sprintf("plugin '%s' can't have both downloadURL and updateSite set", plugin.Name))
+		}
+	}
+	return messages
+}
+
+func (r *JenkinsBaseConfigurationReconciler) validateUpdateCenterMirror() []string {
+	mirror := r.Configuration.Jenkins.Spec.UpdateCenterMirror
+	if mirror == nil {
+		return nil
+	}
+
+	var messages []string
+	if len(mirror.UpstreamURL) > 0 {
+		if parsed, err := url.Parse(mirror.UpstreamURL); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || len(parsed.Host) == 0 {
+			messages = append(messages, fmt.Sprintf("spec.updateCenterMirror.upstreamURL '%s' must be a valid HTTP(S) URL", mirror.UpstreamURL))
+		}
+	}
+	for _, pattern := range mirror.Plugins {
+		if _, err := path.Match(pattern, ""); err != nil || len(pattern) == 0 {
+			messages = append(messages, fmt.Sprintf("spec.updateCenterMirror.plugins pattern '%s' is invalid", pattern))
+		}
+	}
+	return messages
+}
+
+func (r *JenkinsBaseConfigurationReconciler) validateCanary() []string {
+	if r.Configuration.Jenkins.Spec.Canary == nil || !r.Configuration.Jenkins.Spec.Canary.Enabled {
+		return nil
+	}
+	// the canary Jenkins is reached only through its service
+	if len(r.jenkinsAPIConnectionSettings.Hostname) > 0 || r.jenkinsAPIConnectionSettings.Port > 0 {
+		return []string{"spec.canary.enabled can't be set when the operator connects to Jenkins with --jenkins-api-hostname or --jenkins-api-port"}
+	}
+	return nil
+}
+
+func (r *JenkinsBaseConfigurationReconciler) validateOpenShift() []string {
+	openShift := r.Configuration.Jenkins.Spec.OpenShift
+	if openShift == nil {
+		return nil
+	}
+
+	var messages []string
+	for _, name := range openShift.SecurityContextConstraints {
+		if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+			messages = append(messages, fmt.Sprintf("spec.openShift.securityContextConstraints name '%s' is invalid: %s", name, strings.Join(errs, ", ")))
+		} else if !resources.IsSecurityContextConstraintsAllowed(name) {
+			messages = append(messages, fmt.Sprintf("spec.openShift.securityContextConstraints '%s' isn't allowed by --allowed-security-context-constraints operator flag", name))
+		}
+	}
+	if len(openShift.Route.Host) > 0 {
+		if errs := validation.IsDNS1123Subdomain(openShift.Route.Host); len(errs) > 0 {
+			messages = append(messages, fmt.Sprintf("spec.openShift.route.host '%s' is invalid: %s", openShift.Route.Host, strings.Join(errs, ", ")))
+		}
+	}
+	if len(messages) == 0 && len(openShift.SecurityContextConstraints) > 0 && !resources.IsOpenShift(&r.ClientSet) {
+		messages = append(messages, "spec.openShift.securityContextConstraints requires OpenShift, SecurityContextConstraints API isn't available")
+	}
+	return messages
+}
+
+func (r *JenkinsBaseConfigurationReconciler) validateServices() []string {
+	var messages []string
+	messages = append(messages, validateIPFamilies("spec.service", r.Configuration.Jenkins.Spec.Service)...)
+	messages = append(messages, validateIPFamilies("spec.slaveService", r.Configuration.Jenkins.Spec.SlaveService)...)
+	return messages
+}
+
+func (r *JenkinsBaseConfigurationReconciler) validateInboundAgents() []string {
+	inboundAgents := r.Configuration.Jenkins.Spec.InboundAgents
+	if inboundAgents == nil {
+		return nil
+	}
+
+	var messages []string
+	if inboundAgents.DisableTCP && !inboundAgents.WebSocket {
+		messages = append(messages, "spec.inboundAgents.disableTCP requires spec.inboundAgents.webSocket, agents can't connect to Jenkins otherwise")
+	}
+	if inboundAgents.DisableTCP && inboundAgents.TCPPort != nil {
+		messages = append(messages, "spec.inboundAgents.tcpPort can't be set when spec.inboundAgents.disableTCP is true")
+	}
+	if inboundAgents.TCPPort != nil && *inboundAgents.TCPPort == constants.DefaultHTTPPortInt32 {
+		messages = append(messages, fmt.Sprintf("spec.inboundAgents.tcpPort can't be %d, it's Jenkins HTTP port", constants.DefaultHTTPPortInt32))
+	}
+	return messages
+}
+
+func validateIPFamilies(field string, service v1alpha2.Service) []string {
+	var messages []string
+	policy := service.IPFamilyPolicy
+	if policy != nil && *policy != corev1.IPFamilyPolicySingleStack && *policy != corev1.IPFamilyPolicyPreferDualStack && *policy != corev1.IPFamilyPolicyRequireDualStack {
+		messages = append(messages, fmt.Sprintf("%s.ipFamilyPolicy '%s' is invalid, must be one of %s, %s or %s", field, *policy,
+			corev1.IPFamilyPolicySingleStack, corev1.IPFamilyPolicyPreferDualStack, corev1.IPFamilyPolicyRequireDualStack))
+	}
+
+	seen := map[corev1.IPFamily]bool{}
+	for _, family := range service.IPFamilies {
+		if family != corev1.IPv4Protocol && family != corev1.IPv6Protocol {
+			messages = append(messages, fmt.Sprintf("%s.ipFamilies '%s' is invalid, must be %s or %s", field, family, corev1.IPv4Protocol, corev1.IPv6Protocol))
+		} else if seen[family] {
+			messages = append(messages, fmt.Sprintf("%s.ipFamilies '%s' is duplicated", field, family))
+		}
+		seen[family] = true
+	}
+	if len(service.IPFamilies) > 2 {
+		messages = append(messages, fmt.Sprintf("%s.ipFamilies can contain at most 2 families", field))
+	}
+	if len(service.IPFamilies) == 2 && policy != nil && *policy == corev1.IPFamilyPolicySingleStack {
+		messages = append(messages, fmt.Sprintf("%s.ipFamilies with 2 families requires %s or %s %s.ipFamilyPolicy", field,
+			corev1.IPFamilyPolicyPreferDualStack, corev1.IPFamilyPolicyRequireDualStack, field))
+	}
+	return messages
+}
+
+func (r *JenkinsBaseConfigurationReconciler) validatePersistence() ([]string, error) {
+	persistence := r.Configuration.Jenkins.Spec.Persistence
+	if persistence == nil {
+		return nil, nil
+	}
+
+	var messages []string
+	if persistence.Size.Sign() <= 0 {
+		messages = append(messages, "spec.persistence.size must be greater than zero")
+	}
+	if persistence.StorageClassName != nil {
+		for _, msg := range validation.IsDNS1123Subdomain(*persistence.StorageClassName) {
+			messages = append(messages, fmt.Sprintf("spec.persistence.storageClassName '%s' is invalid: %s", *persistence.StorageClassName, msg))
+		}
+	}
+	if persistence.VolumeMode != nil && *persistence.VolumeMode != corev1.PersistentVolumeFilesystem {
+		messages = append(messages, fmt.Sprintf("spec.persistence.volumeMode '%s' is not supported, only Filesystem is supported", *persistence.VolumeMode))
+	}
+	if useDeploymentForJenkinsMaster(r.Configuration.Jenkins) {
+		messages = append(messages, fmt.Sprintf("spec.persistence can't be used with %s", getDeploymentSource(r.Configuration.Jenkins)))
+	}
+	if len(messages) > 0 {
+		return messages, nil
+	}
+
+	claim := &corev1.PersistentVolumeClaim{}
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: resources.GetJenkinsHomeClaimName(r.Configuration.Jenkins), Namespace: r.Configuration.Jenkins.ObjectMeta.Namespace}, claim)
+	if err != nil && apierrors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, stackerr.WithStack(err)
+	}
+
+	requested := claim.Spec.Resources.Requests[corev1.ResourceStorage]
+	if persistence.Size.Cmp(requested) < 0 {
+		return []string{fmt.Sprintf("spec.persistence.size %s can't be smaller than the size %s of PersistentVolumeClaim '%s'",
+			persistence.Size.String(), requested.String(), claim.Name)}, nil
+	}
+	return nil, nil
+}
+
+// getDeploymentSource describes how the Deployment workload type of Jenkins master has been set
+func getDeploymentSource(jenkins *v1alpha2.Jenkins) string {
+	if jenkins.Spec.Master.WorkloadType == v1alpha2.WorkloadTypeDeployment {
+		return "Deployment spec.master.workloadType"
+	}
+	return resources.UseDeploymentAnnotation + " annotation"
+}
+
+// diskCleanupReservedPaths are Jenkins home directories which can't be wiped by the cleanup policies
+var diskCleanupReservedPaths = []string{"jobs", "nodes", "plugins", "secrets", "users"}
+
+func (r *JenkinsBaseConfigurationReconciler) validateDiskUsage() []string {
+	diskUsage := r.Configuration.Jenkins.Spec.DiskUsage
+	if diskUsage == nil {
+		return nil
+	}
+
+	var messages []string
+	if diskUsage.WarningThreshold < 0 || diskUsage.WarningThreshold > 100 {
+		messages = append(messages, fmt.Sprintf("spec.diskUsage.warningThreshold %d must be between 1 and 100", diskUsage.WarningThreshold))
+	}
+	if useDeploymentForJenkinsMaster(r.Configuration.Jenkins) {
+		messages = append(messages, fmt.Sprintf("spec.diskUsage can't be used with %s", getDeploymentSource(r.Configuration.Jenkins)))
+	}
+
+	names := map[string]bool{}
+	for i, policy := range diskUsage.CleanupPolicies {
+		field := fmt.Sprintf("spec.diskUsage.cleanupPolicies[%d]", i)
+		if len(policy.Name) == 0 {
+			messages = append(messages, fmt.Sprintf("%s.name is not set", field))
+		} else if names[policy.Name] {
+			messages = append(messages, fmt.Sprintf("%s.name '%s' is duplicated", field, policy.Name))
+		}
+		names[policy.Name] = true
+
+		if policy.Threshold < 1 || policy.Threshold > 100 {
+			messages = append(messages, fmt.Sprintf("%s.threshold %d must be between 1 and 100", field, policy.Threshold))
+		}
+		if policy.DiscardOldBuilds == nil && !policy.WipeWorkspaces && len(policy.CachePaths) == 0 {
+			messages = append(messages, fmt.Sprintf("%s must set discardOldBuilds, wipeWorkspaces or cachePaths", field))
+		}
+		if discard := policy.DiscardOldBuilds; discard != nil && (discard.KeepBuilds < 0 || discard.KeepDays < 0 || discard.KeepBuilds+discard.KeepDays == 0) {
+			messages = append(messages, fmt.Sprintf("%s.discardOldBuilds must set positive keepBuilds or keepDays", field))
+		}
+		for _, cachePath := range policy.CachePaths {
+			if msg := validateDiskCleanupPath(cachePath); len(msg) > 0 {
+				messages = append(messages, fmt.Sprintf("%s.cachePaths '%s' %s", field, cachePath, msg))
+			}
+		}
+	}
+
+	return messages
+}
+
+func validateDiskCleanupPath(cachePath string) string {
+	if len(cachePath) == 0 || strings.HasPrefix(cachePath, "/") {
+		return "must be a path relative to Jenkins home"
+	}
+	segments := strings.Split(strings.Trim(cachePath, "/"), "/")
+	for _, segment := range segments {
+		if segment == "." || segment == ".." || len(segment) == 0 {
+			return "must not contain empty, '.' or '..' segments"
+		}
+	}
+	for _, reserved := range diskCleanupReservedPaths {
+		if segments[0] == reserved {
+			return fmt.Sprintf("must not be in the reserved '%s' directory", reserved)
+		}
+	}
+	return ""
+}
+
+func (r *JenkinsBaseConfigurationReconciler) validateTrustedCABundle() ([]string, error) {
+	trustedCABundle := r.Configuration.Jenkins.Spec.TrustedCABundle
+	if trustedCABundle == nil {
+		return nil, nil
+	}
+	if len(trustedCABundle.Name) == 0 {
+		return []string{"spec.trustedCABundle.name is not set"}, nil
+	}
+
+	configMap := &corev1.ConfigMap{}
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: trustedCABundle.Name, Namespace: r.Configuration.Jenkins.ObjectMeta.Namespace}, configMap)
+	if err != nil && apierrors.IsNotFound(err) {
+		return []string{fmt.Sprintf("ConfigMap '%s' not found for spec.trustedCABundle", trustedCABundle.Name)}, nil
+	} else if err != nil {
+		return nil, stackerr.WithStack(err)
+	}
+
+	key := resources.GetTrustedCABundleKey(r.Configuration.Jenkins)
+	bundle, ok := configMap.Data[key]
+	if !ok {
+		return []string{fmt.Sprintf("ConfigMap '%s' doesn't have key '%s' required by spec.trustedCABundle", trustedCABundle.Name, key)}, nil
+	}
+	if !strings.Contains(bundle, "-----BEGIN CERTIFICATE-----") {
+		return []string{fmt.Sprintf("ConfigMap '%s' key '%s' doesn't contain any PEM encoded certificate", trustedCABundle.Name, key)}, nil
+	}
+
+	return nil, nil
+}
+
+func (r *JenkinsBaseConfigurationReconciler) validateBuildLogArchival() ([]string, error) {
+	var messages []string
+	archival := r.Configuration.Jenkins.Spec.BuildLogArchival
+	if archival == nil {
+		return nil, nil
+	}
+
+	if archival.Provider != v1alpha2.S3BuildLogArchivalProvider && archival.Provider != v1alpha2.GCSBuildLogArchivalProvider {
+		messages = append(messages, fmt.Sprintf("unrecognized '%s' spec.buildLogArchival.provider", archival.Provider))
+	}
+	if len(archival.Bucket) == 0 {
+		messages = append(messages, "spec.buildLogArchival.bucket is not set")
+	}
+	if archival.Provider != v1alpha2.S3BuildLogArchivalProvider && (len(archival.Region) > 0 || len(archival.Endpoint) > 0) {
+		messages = append(messages, "spec.buildLogArchival.region and spec.buildLogArchival.endpoint are supported only by S3 provider")
+	}
+	if archival.RetentionDays < 0 {
+		messages = append(messages, "spec.buildLogArchival.retentionDays can't be negative")
+	}
+	image := resources.GetBuildLogArchivalImage(r.Configuration.Jenkins)
+	if !dockerImageRegexp.MatchString(image) && !docker.ReferenceRegexp.MatchString(image) {
+		messages = append(messages, fmt.Sprintf("spec.buildLogArchival.image '%s' is invalid", image))
+	}
+	for _, container := range r.Configuration.Jenkins.Spec.Master.Containers {
+		if container.Name == resources.BuildLogArchivalContainerName {
+			messages = append(messages, fmt.Sprintf("Jenkins Master container name '%s' is reserved by spec.buildLogArchival please choose different one", container.Name))
+		}
+	}
+
+	if archival.CredentialsSecret == nil {
+		return messages, nil
+	}
+	if len(archival.CredentialsSecret.Name) == 0 {
+		return append(messages, "spec.buildLogArchival.credentialsSecret.name is not set"), nil
+	}
+	secret := &corev1.Secret{}
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: archival.CredentialsSecret.Name, Namespace: r.Configuration.Jenkins.ObjectMeta.Namespace}, secret)
+	if err != nil && apierrors.IsNotFound(err) {
+		return append(messages, fmt.Sprintf("Secret '%s' not found for spec.buildLogArchival.credentialsSecret", archival.CredentialsSecret.Name)), nil
+	} else if err != nil {
+		return nil, stackerr.WithStack(err)
+	}
+
+	var requiredKeys []string
+	switch archival.Provider {
+	case v1alpha2.S3BuildLogArchivalProvider:
+		requiredKeys = resources.BuildLogArchivalS3CredentialsKeys
+	case v1alpha2.GCSBuildLogArchivalProvider:
+		requiredKeys = []string{resources.BuildLogArchivalGCSCredentialsKey}
+	}
+	for _, key := range requiredKeys {
+		if _, ok := secret.Data[key]; !ok {
+			messages = append(messages, fmt.Sprintf("Secret '%s' doesn't have key '%s' required by spec.buildLogArchival", archival.CredentialsSecret.Name, key))
+		}
+	}
+
+	return messages, nil
+}
+
+func (r *JenkinsBaseConfigurationReconciler) validateJVM() []string {
+	var messages []string
+	jvm := r.Configuration.Jenkins.Spec.Master.JVM
+	if jvm == nil {
+		return nil
+	}
+
+	memoryLimit := r.Configuration.Jenkins.Spec.Master.Containers[0].Resources.Limits.Memory()
+	if jvm.MaxHeap != nil {
+		if memoryLimit.IsZero() {
+			messages = append(messages, "spec.master.jvm.maxHeap requires memory limit of Jenkins Master container")
+		} else if float64(jvm.MaxHeap.Value()) > float64(memoryLimit.Value())*resources.MaxHeapToMemoryLimitRatio {
+			messages = append(messages, fmt.Sprintf("spec.master.jvm.maxHeap '%s' can't be greater than %d%% of Jenkins Master container memory limit '%s'",
+				jvm.MaxHeap.String(), int(resources.MaxHeapToMemoryLimitRatio*100), memoryLimit.String()))
+		}
+		if jvm.InitialHeap != nil && jvm.InitialHeap.Cmp(*jvm.MaxHeap) > 0 {
+			messages = append(messages, fmt.Sprintf("spec.master.jvm.initialHeap '%s' can't be greater than spec.master.jvm.maxHeap '%s'",
+				jvm.InitialHeap.String(), jvm.MaxHeap.String()))
+		}
+		if jvm.MaxMetaspace != nil && !memoryLimit.IsZero() && jvm.MaxHeap.Value()+jvm.MaxMetaspace.Value() > memoryLimit.Value() {
+			messages = append(messages, fmt.Sprintf("spec.master.jvm.maxHeap and spec.master.jvm.maxMetaspace together can't be greater than Jenkins Master container memory limit '%s'",
+				memoryLimit.String()))
+		}
+	}
+	if jvm.InitialHeap != nil && !memoryLimit.IsZero() && jvm.InitialHeap.Cmp(*memoryLimit) > 0 {
+		messages = append(messages, fmt.Sprintf("spec.master.jvm.initialHeap '%s' can't be greater than Jenkins Master container memory limit '%s'",
+			jvm.InitialHeap.String(), memoryLimit.String()))
+	}
+
+	if _, ok := resources.GetGarbageCollectorFlag(jvm.GarbageCollector); len(jvm.GarbageCollector) > 0 && !ok {
+		messages = append(messages, fmt.Sprintf("unrecognized '%s' spec.master.jvm.garbageCollector", jvm.GarbageCollector))
+	}
+
+	for _, flag := range jvm.ExtraFlags {
+		if !strings.HasPrefix(flag, "-") || strings.ContainsAny(flag, " \t\n") {
+			messages = append(messages, fmt.Sprintf("spec.master.jvm.extraFlags flag '%s' is invalid", flag))
+		}
+	}
+
+	var flags []string
+	for _, env := range r.Configuration.Jenkins.Spec.Master.Containers[0].Env {
+		if env.Name == constants.JavaOpsVariableName {
+			flags = append(flags, strings.Fields(env.Value)...)
+		}
+	}
+	flags = append(flags, jvm.ExtraFlags...)
+	isGarbageCollectorFlag := func(flag string) bool {
+		return strings.HasPrefix(flag, "-XX:+Use") && strings.HasSuffix(flag, "GC")
+	}
+	for _, flag := range flags {
+		switch {
+		case jvm.InitialHeap != nil && strings.HasPrefix(flag, "-Xms"):
+			messages = append(messages, fmt.Sprintf("JVM flag '%s' conflicts with spec.master.jvm.initialHeap", flag))
+		case jvm.MaxHeap != nil && strings.HasPrefix(flag, "-Xmx"):
+			messages = append(messages, fmt.Sprintf("JVM flag '%s' conflicts with spec.master.jvm.maxHeap", flag))
+		case jvm.MaxMetaspace != nil && strings.HasPrefix(flag, "-XX:MaxMetaspaceSize="):
+			messages = append(messages, fmt.Sprintf("JVM flag '%s' conflicts with spec.master.jvm.maxMetaspace", flag))
+		case len(jvm.GarbageCollector) > 0 && isGarbageCollectorFlag(flag):
+			messages = append(messages, fmt.Sprintf("JVM flag '%s' conflicts with spec.master.jvm.garbageCollector", flag))
+		}
+	}
+
+	return messages
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
@@ -962,3 +963,619 @@ func TestValidateJenkinsMasterContainerCommand(t *testing.T) {
 		assert.Len(t, got, 1)
 	})
 }
+
+func TestValidateProxy(t *testing.T) {
+	t.Run("not set", func(t *testing.T) {
+		baseReconcileLoop := New(configuration.Configuration{
+			Jenkins: &v1alpha2.Jenkins{},
+		}, client.JenkinsAPIConnectionSettings{})
+
+		assert.Nil(t, baseReconcileLoop.validateProxy())
+	})
+	t.Run("happy", func(t *testing.T) {
+		baseReconcileLoop := New(configuration.Configuration{
+			Jenkins: &v1alpha2.Jenkins{Spec: v1alpha2.JenkinsSpec{Proxy: &v1alpha2.Proxy{
+				HTTPProxy:  "http://proxy.example.com:3128",
+				HTTPSProxy: "http://proxy.example.com:3128",
+				NoProxy:    []string{".example.com"},
+			}}},
+		}, client.JenkinsAPIConnectionSettings{})
+
+		assert.Nil(t, baseReconcileLoop.validateProxy())
+	})
+	t.Run("missing proxy URL", func(t *testing.T) {
+		baseReconcileLoop := New(configuration.Configuration{
+			Jenkins: &v1alpha2.Jenkins{Spec: v1alpha2.JenkinsSpec{Proxy: &v1alpha2.Proxy{}}},
+		}, client.JenkinsAPIConnectionSettings{})
+
+		assert.Equal(t, []string{"spec.proxy.httpProxy or spec.proxy.httpsProxy must be set"}, baseReconcileLoop.validateProxy())
+	})
+	t.Run("invalid proxy URL and no proxy host", func(t *testing.T) {
+		baseReconcileLoop := New(configuration.Configuration{
+			Jenkins: &v1alpha2.Jenkins{Spec: v1alpha2.JenkinsSpec{Proxy: &v1alpha2.Proxy{
+				HTTPSProxy: "proxy.example.com",
+				NoProxy:    []string{"a.example.com,b.example.com"},
+			}}},
+		}, client.JenkinsAPIConnectionSettings{})
+
+		assert.Equal(t, []string{
+			"spec.proxy.httpsProxy 'proxy.example.com' is invalid: host not set in 'proxy.example.com'",
+			"spec.proxy.noProxy host 'a.example.com,b.example.com' is invalid",
+		}, baseReconcileLoop.validateProxy())
+	})
+}
+
+func TestValidatePluginUpdates(t *testing.T) {
+	t.Run("not set", func(t *testing.T) {
+		baseReconcileLoop := New(configuration.Configuration{
+			Jenkins: &v1alpha2.Jenkins{Spec: v1alpha2.JenkinsSpec{PluginUpdates: &v1alpha2.PluginUpdates{}}},
+		}, client.JenkinsAPIConnectionSettings{})
+
+		assert.Nil(t, baseReconcileLoop.validatePluginUpdates())
+	})
+	t.Run("happy", func(t *testing.T) {
+		baseReconcileLoop := New(configuration.Configuration{
+			Jenkins: &v1alpha2.Jenkins{Spec: v1alpha2.JenkinsSpec{PluginUpdates: &v1alpha2.PluginUpdates{
+				UpdateCenterURL: "https://updates.example.com/update-center.actual.json",
+			}}},
+		}, client.JenkinsAPIConnectionSettings{})
+
+		assert.Nil(t, baseReconcileLoop.validatePluginUpdates())
+	})
+	t.Run("invalid update center URL", func(t *testing.T) {
+		baseReconcileLoop := New(configuration.Configuration{
+			Jenkins: &v1alpha2.Jenkins{Spec: v1alpha2.JenkinsSpec{PluginUpdates: &v1alpha2.PluginUpdates{
+				UpdateCenterURL: "updates.example.com",
+			}}},
+		}, client.JenkinsAPIConnectionSettings{})
+
+		assert.Equal(t, []string{"spec.pluginUpdates.updateCenterURL 'updates.example.com' must be a valid HTTP(S) URL"}, baseReconcileLoop.validatePluginUpdates())
+	})
+}
+
+func TestValidateUpdateSites(t *testing.T) {
+	t.Run("happy", func(t *testing.T) {
+		baseReconcileLoop := New(configuration.Configuration{
+			Jenkins: &v1alpha2.Jenkins{Spec: v1alpha2.JenkinsSpec{Master: v1alpha2.JenkinsMaster{
+				UpdateSites: []v1alpha2.UpdateSite{{Name: "internal", URL: "https://updates.example.com"}},
+				Plugins: []v1alpha2.Plugin{
+					{Name: "docker", Version: "1.2", UpdateSite: "internal"},
+					{Name: "git", Version: "latest", UpdateSite: "experimental"},
+				},
+			}}},
+		}, client.JenkinsAPIConnectionSettings{})
+
+		assert.Nil(t, baseReconcileLoop.validateUpdateSites())
+	})
+	t.Run("invalid sites and plugins", func(t *testing.T) {
+		baseReconcileLoop := New(configuration.Configuration{
+			Jenkins: &v1alpha2.Jenkins{Spec: v1alpha2.JenkinsSpec{Master: v1alpha2.JenkinsMaster{
+				UpdateSites: []v1alpha2.UpdateSite{
+					{Name: "experimental", URL: "https://updates.example.com"},
+					{Name: "internal", URL: "updates.example.com"},
+				},
+				Plugins: []v1alpha2.Plugin{
+					{Name: "docker", Version: "1.2", UpdateSite: "missing"},
+					{Name: "git", Version: "4.8", UpdateSite: "internal", DownloadURL: "https://example.com/git.hpi"},
+				},
+			}}},
+		}, client.JenkinsAPIConnectionSettings{})
+
+		assert.Equal(t, []string{
+			"spec.master.updateSites name 'experimental' is not unique",
+			"spec.master.updateSites 'internal' URL 'updates.example.com' must be a valid HTTP(S) URL",
+			"update site 'missing' of plugin 'docker' not found in spec.master.updateSites",
+			"plugin 'git' can't have both downloadURL and updateSite set",
+		}, baseReconcileLoop.validateUpdateSites())
+	})
+}
+
+func TestValidateUpdateCenterMirror(t *testing.T) {
+	t.Run("happy", func(t *testing.T) {
+		baseReconcileLoop := New(configuration.Configuration{
+			Jenkins: &v1alpha2.Jenkins{Spec: v1alpha2.JenkinsSpec{UpdateCenterMirror: &v1alpha2.UpdateCenterMirror{
+				UpstreamURL: "https://updates.example.com/update-center.actual.json",
+				Plugins:     []string{"workflow-*"},
+			}}},
+		}, client.JenkinsAPIConnectionSettings{})
+
+		assert.Nil(t, baseReconcileLoop.validateUpdateCenterMirror())
+	})
+	t.Run("invalid upstream URL and pattern", func(t *testing.T) {
+		baseReconcileLoop := New(configuration.Configuration{
+			Jenkins: &v1alpha2.Jenkins{Spec: v1alpha2.JenkinsSpec{UpdateCenterMirror: &v1alpha2.UpdateCenterMirror{
+				UpstreamURL: "updates.example.com",
+				Plugins:     []string{"[workflow"},
+			}}},
+		}, client.JenkinsAPIConnectionSettings{})
+
+		assert.Equal(t, []string{
+			"spec.updateCenterMirror.upstreamURL 'updates.example.com' must be a valid HTTP(S) URL",
+			"spec.updateCenterMirror.plugins pattern '[workflow' is invalid",
+		}, baseReconcileLoop.validateUpdateCenterMirror())
+	})
+}
+
+func TestValidatePersistence(t *testing.T) {
+	newJenkins := func(persistence *v1alpha2.Persistence) *v1alpha2.Jenkins {
+		return &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: defaultNamespace},
+			Spec:       v1alpha2.JenkinsSpec{Persistence: persistence},
+		}
+	}
+	t.Run("happy", func(t *testing.T) {
+		baseReconcileLoop := New(configuration.Configuration{
+			Client:  fake.NewClientBuilder().Build(),
+			Jenkins: newJenkins(&v1alpha2.Persistence{Size: resource.MustParse("10Gi")}),
+		}, client.JenkinsAPIConnectionSettings{})
+
+		got, err := baseReconcileLoop.validatePersistence()
+
+		assert.NoError(t, err)
+		assert.Nil(t, got)
+	})
+	t.Run("invalid settings", func(t *testing.T) {
+		storageClassName := "Fast_SSD"
+		volumeMode := corev1.PersistentVolumeBlock
+		jenkins := newJenkins(&v1alpha2.Persistence{StorageClassName: &storageClassName, VolumeMode: &volumeMode})
+		jenkins.Annotations = map[string]string{"jenkins.io/use-deployment": "true"}
+		baseReconcileLoop := New(configuration.Configuration{
+			Client:  fake.NewClientBuilder().Build(),
+			Jenkins: jenkins,
+		}, client.JenkinsAPIConnectionSettings{})
+
+		got, err := baseReconcileLoop.validatePersistence()
+
+		assert.NoError(t, err)
+		require.Len(t, got, 4)
+		assert.Equal(t, "spec.persistence.size must be greater than zero", got[0])
+		assert.Contains(t, got[1], "spec.persistence.storageClassName 'Fast_SSD' is invalid")
+		assert.Equal(t, "spec.persistence.volumeMode 'Block' is not supported, only Filesystem is supported", got[2])
+		assert.Equal(t, "spec.persistence can't be used with jenkins.io/use-deployment annotation", got[3])
+	})
+	t.Run("size decreased", func(t *testing.T) {
+		jenkins := newJenkins(&v1alpha2.Persistence{Size: resource.MustParse("10Gi")})
+		claim := resources.NewJenkinsHomePersistentVolumeClaim(newJenkins(&v1alpha2.Persistence{Size: resource.MustParse("20Gi")}),
+			resources.GetJenkinsHomeClaimName(jenkins), nil)
+		baseReconcileLoop := New(configuration.Configuration{
+			Client:  fake.NewClientBuilder().WithObjects(claim).Build(),
+			Jenkins: jenkins,
+		}, client.JenkinsAPIConnectionSettings{})
+
+		got, err := baseReconcileLoop.validatePersistence()
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"spec.persistence.size 10Gi can't be smaller than the size 20Gi of PersistentVolumeClaim 'jenkins-operator-home-jenkins'"}, got)
+	})
+}
+
+func TestValidateDiskUsage(t *testing.T) {
+	t.Run("happy", func(t *testing.T) {
+		baseReconcileLoop := New(configuration.Configuration{
+			Jenkins: &v1alpha2.Jenkins{Spec: v1alpha2.JenkinsSpec{DiskUsage: &v1alpha2.DiskUsage{
+				WarningThreshold: 80,
+				CleanupPolicies: []v1alpha2.DiskCleanupPolicy{
+					{Name: "builds", Threshold: 85, DiscardOldBuilds: &v1alpha2.DiscardOldBuilds{KeepBuilds: 20}},
+					{Name: "caches", Threshold: 90, WipeWorkspaces: true, CachePaths: []string{"caches/git", "tools/"}},
+				},
+			}}},
+		}, client.JenkinsAPIConnectionSettings{})
+
+		assert.Nil(t, baseReconcileLoop.validateDiskUsage())
+	})
+	t.Run("invalid policies", func(t *testing.T) {
+		baseReconcileLoop := New(configuration.Configuration{
+			Jenkins: &v1alpha2.Jenkins{Spec: v1alpha2.JenkinsSpec{DiskUsage: &v1alpha2.DiskUsage{
+				CleanupPolicies: []v1alpha2.DiskCleanupPolicy{
+					{Name: "builds", Threshold: 85, DiscardOldBuilds: &v1alpha2.DiscardOldBuilds{}},
+					{Name: "builds", Threshold: 101},
+					{Name: "paths", Threshold: 90, CachePaths: []string{"/tmp", "caches/../jobs", "jobs/example"}},
+				},
+			}}},
+		}, client.JenkinsAPIConnectionSettings{})
+
+		assert.Equal(t, []string{
+			"spec.diskUsage.cleanupPolicies[0].discardOldBuilds must set positive keepBuilds or keepDays",
+			"spec.diskUsage.cleanupPolicies[1].name 'builds' is duplicated",
+			"spec.diskUsage.cleanupPolicies[1].threshold 101 must be between 1 and 100",
+			"spec.diskUsage.cleanupPolicies[1] must set discardOldBuilds, wipeWorkspaces or cachePaths",
+			"spec.diskUsage.cleanupPolicies[2].cachePaths '/tmp' must be a path relative to Jenkins home",
+			"spec.diskUsage.cleanupPolicies[2].cachePaths 'caches/../jobs' must not contain empty, '.' or '..' segments",
+			"spec.diskUsage.cleanupPolicies[2].cachePaths 'jobs/example' must not be in the reserved 'jobs' directory",
+		}, baseReconcileLoop.validateDiskUsage())
+	})
+}
+
+func TestValidateTrustedCABundle(t *testing.T) {
+	jenkins := &v1alpha2.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{Namespace: defaultNamespace},
+		Spec: v1alpha2.JenkinsSpec{TrustedCABundle: &v1alpha2.TrustedCABundle{
+			ConfigMapRef: v1alpha2.ConfigMapRef{Name: "ca-bundle"},
+		}},
+	}
+	t.Run("happy", func(t *testing.T) {
+		configMap := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: defaultNamespace, Name: "ca-bundle"},
+			Data:       map[string]string{constants.DefaultTrustedCABundleKey: "-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----\n"},
+		}
+		fakeClient := fake.NewClientBuilder().WithObjects(configMap).Build()
+		baseReconcileLoop := New(configuration.Configuration{
+			Client:  fakeClient,
+			Jenkins: jenkins,
+		}, client.JenkinsAPIConnectionSettings{})
+
+		got, err := baseReconcileLoop.validateTrustedCABundle()
+
+		assert.NoError(t, err)
+		assert.Nil(t, got)
+	})
+	t.Run("missing configmap", func(t *testing.T) {
+		fakeClient := fake.NewClientBuilder().Build()
+		baseReconcileLoop := New(configuration.Configuration{
+			Client:  fakeClient,
+			Jenkins: jenkins,
+		}, client.JenkinsAPIConnectionSettings{})
+
+		got, err := baseReconcileLoop.validateTrustedCABundle()
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"ConfigMap 'ca-bundle' not found for spec.trustedCABundle"}, got)
+	})
+	t.Run("missing key", func(t *testing.T) {
+		configMap := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: defaultNamespace, Name: "ca-bundle"},
+			Data:       map[string]string{"other.crt": "-----BEGIN CERTIFICATE-----"},
+		}
+		fakeClient := fake.NewClientBuilder().WithObjects(configMap).Build()
+		baseReconcileLoop := New(configuration.Configuration{
+			Client:  fakeClient,
+			Jenkins: jenkins,
+		}, client.JenkinsAPIConnectionSettings{})
+
+		got, err := baseReconcileLoop.validateTrustedCABundle()
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"ConfigMap 'ca-bundle' doesn't have key 'ca-bundle.crt' required by spec.trustedCABundle"}, got)
+	})
+	t.Run("no certificate", func(t *testing.T) {
+		configMap := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: defaultNamespace, Name: "ca-bundle"},
+			Data:       map[string]string{constants.DefaultTrustedCABundleKey: "garbage"},
+		}
+		fakeClient := fake.NewClientBuilder().WithObjects(configMap).Build()
+		baseReconcileLoop := New(configuration.Configuration{
+			Client:  fakeClient,
+			Jenkins: jenkins,
+		}, client.JenkinsAPIConnectionSettings{})
+
+		got, err := baseReconcileLoop.validateTrustedCABundle()
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"ConfigMap 'ca-bundle' key 'ca-bundle.crt' doesn't contain any PEM encoded certificate"}, got)
+	})
+}
+
+func TestValidateJVM(t *testing.T) {
+	newJenkins := func(memoryLimit string, javaOpts string, jvm *v1alpha2.JVM) *v1alpha2.Jenkins {
+		container := v1alpha2.Container{
+			Env: []corev1.EnvVar{{Name: constants.JavaOpsVariableName, Value: javaOpts}},
+		}
+		if len(memoryLimit) > 0 {
+			container.Resources = corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse(memoryLimit)},
+			}
+		}
+		return &v1alpha2.Jenkins{Spec: v1alpha2.JenkinsSpec{Master: v1alpha2.JenkinsMaster{
+			Containers: []v1alpha2.Container{container},
+			JVM:        jvm,
+		}}}
+	}
+	quantity := func(value string) *resource.Quantity {
+		q := resource.MustParse(value)
+		return &q
+	}
+
+	t.Run("not set", func(t *testing.T) {
+		baseReconcileLoop := New(configuration.Configuration{
+			Jenkins: newJenkins("", "", nil),
+		}, client.JenkinsAPIConnectionSettings{})
+
+		assert.Nil(t, baseReconcileLoop.validateJVM())
+	})
+	t.Run("happy", func(t *testing.T) {
+		baseReconcileLoop := New(configuration.Configuration{
+			Jenkins: newJenkins("4Gi", "-Djava.awt.headless=true", &v1alpha2.JVM{
+				InitialHeap:      quantity("1Gi"),
+				MaxHeap:          quantity("3Gi"),
+				MaxMetaspace:     quantity("512Mi"),
+				GarbageCollector: v1alpha2.G1GarbageCollector,
+				ExtraFlags:       []string{"-XX:+HeapDumpOnOutOfMemoryError"},
+			}),
+		}, client.JenkinsAPIConnectionSettings{})
+
+		assert.Nil(t, baseReconcileLoop.validateJVM())
+	})
+	t.Run("max heap without memory limit", func(t *testing.T) {
+		baseReconcileLoop := New(configuration.Configuration{
+			Jenkins: newJenkins("", "", &v1alpha2.JVM{MaxHeap: quantity("1Gi")}),
+		}, client.JenkinsAPIConnectionSettings{})
+
+		assert.Equal(t, []string{"spec.master.jvm.maxHeap requires memory limit of Jenkins Master container"}, baseReconcileLoop.validateJVM())
+	})
+	t.Run("too big heap and metaspace", func(t *testing.T) {
+		baseReconcileLoop := New(configuration.Configuration{
+			Jenkins: newJenkins("2Gi", "", &v1alpha2.JVM{
+				InitialHeap:  quantity("2Gi"),
+				MaxHeap:      quantity("1800Mi"),
+				MaxMetaspace: quantity("512Mi"),
+			}),
+		}, client.JenkinsAPIConnectionSettings{})
+
+		assert.Equal(t, []string{
+			"spec.master.jvm.maxHeap '1800Mi' can't be greater than 80% of Jenkins Master container memory limit '2Gi'",
+			"spec.master.jvm.initialHeap '2Gi' can't be greater than spec.master.jvm.maxHeap '1800Mi'",
+			"spec.master.jvm.maxHeap and spec.master.jvm.maxMetaspace together can't be greater than Jenkins Master container memory limit '2Gi'",
+		}, baseReconcileLoop.validateJVM())
+	})
+	t.Run("invalid and conflicting flags", func(t *testing.T) {
+		baseReconcileLoop := New(configuration.Configuration{
+			Jenkins: newJenkins("4Gi", "-Xmx2g -XX:+UseParallelGC", &v1alpha2.JVM{
+				MaxHeap:          quantity("1Gi"),
+				GarbageCollector: v1alpha2.G1GarbageCollector,
+				ExtraFlags:       []string{"XX:+HeapDumpOnOutOfMemoryError"},
+			}),
+		}, client.JenkinsAPIConnectionSettings{})
+
+		assert.Equal(t, []string{
+			"spec.master.jvm.extraFlags flag 'XX:+HeapDumpOnOutOfMemoryError' is invalid",
+			"JVM flag '-Xmx2g' conflicts with spec.master.jvm.maxHeap",
+			"JVM flag '-XX:+UseParallelGC' conflicts with spec.master.jvm.garbageCollector",
+		}, baseReconcileLoop.validateJVM())
+	})
+}
+
+func TestValidateBuildLogArchival(t *testing.T) {
+	t.Run("happy", func(t *testing.T) {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: defaultNamespace, Name: "s3-credentials"},
+			Data:       map[string][]byte{"AWS_ACCESS_KEY_ID": []byte("id"), "AWS_SECRET_ACCESS_KEY": []byte("secret")},
+		}
+		jenkins := &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Namespace: defaultNamespace},
+			Spec: v1alpha2.JenkinsSpec{BuildLogArchival: &v1alpha2.BuildLogArchival{
+				Provider:          v1alpha2.S3BuildLogArchivalProvider,
+				Bucket:            "build-logs",
+				Region:            "eu-west-1",
+				CredentialsSecret: &v1alpha2.SecretRef{Name: "s3-credentials"},
+			}},
+		}
+		baseReconcileLoop := New(configuration.Configuration{
+			Client:  fake.NewClientBuilder().WithObjects(secret).Build(),
+			Jenkins: jenkins,
+		}, client.JenkinsAPIConnectionSettings{})
+
+		got, err := baseReconcileLoop.validateBuildLogArchival()
+
+		assert.NoError(t, err)
+		assert.Nil(t, got)
+	})
+	t.Run("invalid configuration", func(t *testing.T) {
+		jenkins := &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Namespace: defaultNamespace},
+			Spec: v1alpha2.JenkinsSpec{
+				Master: v1alpha2.JenkinsMaster{
+					Containers: []v1alpha2.Container{{Name: resources.JenkinsMasterContainerName}, {Name: resources.BuildLogArchivalContainerName}},
+				},
+				BuildLogArchival: &v1alpha2.BuildLogArchival{
+					Provider:      v1alpha2.GCSBuildLogArchivalProvider,
+					Region:        "eu-west-1",
+					RetentionDays: -1,
+				},
+			},
+		}
+		baseReconcileLoop := New(configuration.Configuration{
+			Client:  fake.NewClientBuilder().Build(),
+			Jenkins: jenkins,
+		}, client.JenkinsAPIConnectionSettings{})
+
+		got, err := baseReconcileLoop.validateBuildLogArchival()
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			"spec.buildLogArchival.bucket is not set",
+			"spec.buildLogArchival.region and spec.buildLogArchival.endpoint are supported only by S3 provider",
+			"spec.buildLogArchival.retentionDays can't be negative",
+			"Jenkins Master container name 'build-log-archival' is reserved by spec.buildLogArchival please choose different one",
+		}, got)
+	})
+	t.Run("missing secret", func(t *testing.T) {
+		jenkins := &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Namespace: defaultNamespace},
+			Spec: v1alpha2.JenkinsSpec{BuildLogArchival: &v1alpha2.BuildLogArchival{
+				Provider:          v1alpha2.GCSBuildLogArchivalProvider,
+				Bucket:            "build-logs",
+				CredentialsSecret: &v1alpha2.SecretRef{Name: "gcs-credentials"},
+			}},
+		}
+		baseReconcileLoop := New(configuration.Configuration{
+			Client:  fake.NewClientBuilder().Build(),
+			Jenkins: jenkins,
+		}, client.JenkinsAPIConnectionSettings{})
+
+		got, err := baseReconcileLoop.validateBuildLogArchival()
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Secret 'gcs-credentials' not found for spec.buildLogArchival.credentialsSecret"}, got)
+	})
+	t.Run("missing secret key", func(t *testing.T) {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: defaultNamespace, Name: "gcs-credentials"},
+			Data:       map[string][]byte{"key.json": []byte("{}")},
+		}
+		jenkins := &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Namespace: defaultNamespace},
+			Spec: v1alpha2.JenkinsSpec{BuildLogArchival: &v1alpha2.BuildLogArchival{
+				Provider:          v1alpha2.GCSBuildLogArchivalProvider,
+				Bucket:            "build-logs",
+				CredentialsSecret: &v1alpha2.SecretRef{Name: "gcs-credentials"},
+			}},
+		}
+		baseReconcileLoop := New(configuration.Configuration{
+			Client:  fake.NewClientBuilder().WithObjects(secret).Build(),
+			Jenkins: jenkins,
+		}, client.JenkinsAPIConnectionSettings{})
+
+		got, err := baseReconcileLoop.validateBuildLogArchival()
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Secret 'gcs-credentials' doesn't have key 'service-account.json' required by spec.buildLogArchival"}, got)
+	})
+}
+
+func TestValidateInitContainers(t *testing.T) {
+	newJenkins := func(initContainers ...v1alpha2.Container) *v1alpha2.Jenkins {
+		return &v1alpha2.Jenkins{Spec: v1alpha2.JenkinsSpec{Master: v1alpha2.JenkinsMaster{
+			Containers:     []v1alpha2.Container{{Name: resources.JenkinsMasterContainerName, Image: "jenkins/jenkins:lts", ImagePullPolicy: corev1.PullAlways}},
+			InitContainers: initContainers,
+		}}}
+	}
+	t.Run("happy", func(t *testing.T) {
+		jenkins := newJenkins(v1alpha2.Container{Name: "seed", Image: "busybox:1.33", ImagePullPolicy: corev1.PullIfNotPresent})
+		baseReconcileLoop := New(configuration.Configuration{Jenkins: jenkins}, client.JenkinsAPIConnectionSettings{})
+
+		assert.Nil(t, baseReconcileLoop.validateInitContainers())
+	})
+	t.Run("invalid", func(t *testing.T) {
+		jenkins := newJenkins(
+			v1alpha2.Container{Name: resources.JenkinsMasterContainerName, Image: "busybox:1.33", ImagePullPolicy: corev1.PullIfNotPresent},
+			v1alpha2.Container{Name: "seed", Image: "busybox:1.33", ImagePullPolicy: corev1.PullIfNotPresent, ReadinessProbe: &corev1.Probe{}},
+			v1alpha2.Container{Image: "busybox:1.33"},
+		)
+		baseReconcileLoop := New(configuration.Configuration{Jenkins: jenkins}, client.JenkinsAPIConnectionSettings{})
+
+		assert.Equal(t, []string{
+			"InitContainer `jenkins-master` - name is already used by another container",
+			"InitContainer `seed` - readinessProbe, livenessProbe and lifecycle can't be set for init container",
+			"spec.master.initContainers[].name is not set",
+		}, baseReconcileLoop.validateInitContainers())
+	})
+}
+
+func TestValidateOpenShift(t *testing.T) {
+	newJenkins := func(openShift *v1alpha2.OpenShift) *v1alpha2.Jenkins {
+		return &v1alpha2.Jenkins{Spec: v1alpha2.JenkinsSpec{OpenShift: openShift}}
+	}
+
+	t.Run("not set", func(t *testing.T) {
+		baseReconcileLoop := New(configuration.Configuration{Jenkins: newJenkins(nil)}, client.JenkinsAPIConnectionSettings{})
+
+		assert.Nil(t, baseReconcileLoop.validateOpenShift())
+	})
+	t.Run("route", func(t *testing.T) {
+		baseReconcileLoop := New(configuration.Configuration{
+			Jenkins: newJenkins(&v1alpha2.OpenShift{Route: v1alpha2.Route{Host: "jenkins.apps.example.com"}}),
+		}, client.JenkinsAPIConnectionSettings{})
+
+		assert.Nil(t, baseReconcileLoop.validateOpenShift())
+	})
+	t.Run("invalid names", func(t *testing.T) {
+		baseReconcileLoop := New(configuration.Configuration{
+			Jenkins: newJenkins(&v1alpha2.OpenShift{
+				SecurityContextConstraints: []string{"Any_UID"},
+				Route:                      v1alpha2.Route{Host: "https://jenkins.apps.example.com"},
+			}),
+		}, client.JenkinsAPIConnectionSettings{})
+
+		messages := baseReconcileLoop.validateOpenShift()
+
+		require.Len(t, messages, 2)
+		assert.Contains(t, messages[0], "spec.openShift.securityContextConstraints name 'Any_UID' is invalid")
+		assert.Contains(t, messages[1], "spec.openShift.route.host 'https://jenkins.apps.example.com' is invalid")
+	})
+	t.Run("allowed security context constraints", func(t *testing.T) {
+		resources.AssumeOpenShift(true)
+		resources.AllowSecurityContextConstraints([]string{"nonroot"})
+		defer resources.AssumeOpenShift(false)
+		defer resources.AllowSecurityContextConstraints(nil)
+		baseReconcileLoop := New(configuration.Configuration{
+			Jenkins: newJenkins(&v1alpha2.OpenShift{SecurityContextConstraints: []string{"nonroot", "privileged"}}),
+		}, client.JenkinsAPIConnectionSettings{})
+
+		assert.Equal(t, []string{
+			"spec.openShift.securityContextConstraints 'privileged' isn't allowed by --allowed-security-context-constraints operator flag",
+		}, baseReconcileLoop.validateOpenShift())
+	})
+}
+
+func TestValidateServices(t *testing.T) {
+	singleStack := corev1.IPFamilyPolicySingleStack
+	invalidPolicy := corev1.IPFamilyPolicyType("DualStack")
+
+	t.Run("not set", func(t *testing.T) {
+		baseReconcileLoop := New(configuration.Configuration{Jenkins: &v1alpha2.Jenkins{}}, client.JenkinsAPIConnectionSettings{})
+
+		assert.Nil(t, baseReconcileLoop.validateServices())
+	})
+	t.Run("dual-stack", func(t *testing.T) {
+		requireDualStack := corev1.IPFamilyPolicyRequireDualStack
+		baseReconcileLoop := New(configuration.Configuration{Jenkins: &v1alpha2.Jenkins{Spec: v1alpha2.JenkinsSpec{
+			Service:      v1alpha2.Service{IPFamilyPolicy: &requireDualStack, IPFamilies: []corev1.IPFamily{corev1.IPv6Protocol, corev1.IPv4Protocol}},
+			SlaveService: v1alpha2.Service{IPFamilyPolicy: &singleStack, IPFamilies: []corev1.IPFamily{corev1.IPv6Protocol}},
+		}}}, client.JenkinsAPIConnectionSettings{})
+
+		assert.Nil(t, baseReconcileLoop.validateServices())
+	})
+	t.Run("invalid", func(t *testing.T) {
+		baseReconcileLoop := New(configuration.Configuration{Jenkins: &v1alpha2.Jenkins{Spec: v1alpha2.JenkinsSpec{
+			Service:      v1alpha2.Service{IPFamilyPolicy: &invalidPolicy, IPFamilies: []corev1.IPFamily{"IPv5", corev1.IPv6Protocol, corev1.IPv6Protocol}},
+			SlaveService: v1alpha2.Service{IPFamilyPolicy: &singleStack, IPFamilies: []corev1.IPFamily{corev1.IPv4Protocol, corev1.IPv6Protocol}},
+		}}}, client.JenkinsAPIConnectionSettings{})
+
+		assert.Equal(t, []string{
+			"spec.service.ipFamilyPolicy 'DualStack' is invalid, must be one of SingleStack, PreferDualStack or RequireDualStack",
+			"spec.service.ipFamilies 'IPv5' is invalid, must be IPv4 or IPv6",
+			"spec.service.ipFamilies 'IPv6' is duplicated",
+			"spec.service.ipFamilies can contain at most 2 families",
+			"spec.slaveService.ipFamilies with 2 families requires PreferDualStack or RequireDualStack spec.slaveService.ipFamilyPolicy",
+		}, baseReconcileLoop.validateServices())
+	})
+}
+
+func TestValidateInboundAgents(t *testing.T) {
+	newJenkins := func(inboundAgents *v1alpha2.InboundAgents) *v1alpha2.Jenkins {
+		return &v1alpha2.Jenkins{Spec: v1alpha2.JenkinsSpec{InboundAgents: inboundAgents}}
+	}
+	port := int32(50001)
+	httpPort := int32(8080)
+
+	t.Run("not set", func(t *testing.T) {
+		baseReconcileLoop := New(configuration.Configuration{Jenkins: newJenkins(nil)}, client.JenkinsAPIConnectionSettings{})
+
+		assert.Nil(t, baseReconcileLoop.validateInboundAgents())
+	})
+	t.Run("WebSocket only", func(t *testing.T) {
+		baseReconcileLoop := New(configuration.Configuration{
+			Jenkins: newJenkins(&v1alpha2.InboundAgents{DisableTCP: true, WebSocket: true}),
+		}, client.JenkinsAPIConnectionSettings{})
+
+		assert.Nil(t, baseReconcileLoop.validateInboundAgents())
+	})
+	t.Run("pinned port", func(t *testing.T) {
+		baseReconcileLoop := New(configuration.Configuration{
+			Jenkins: newJenkins(&v1alpha2.InboundAgents{TCPPort: &port}),
+		}, client.JenkinsAPIConnectionSettings{})
+
+		assert.Nil(t, baseReconcileLoop.validateInboundAgents())
+	})
+	t.Run("invalid", func(t *testing.T) {
+		baseReconcileLoop := New(configuration.Configuration{
+			Jenkins: newJenkins(&v1alpha2.InboundAgents{DisableTCP: true, TCPPort: &httpPort}),
+		}, client.JenkinsAPIConnectionSettings{})
+
+		assert.Equal(t, []string{
+			"spec.inboundAgents.disableTCP requires spec.inboundAgents.webSocket, agents can't connect to Jenkins otherwise",
+			"spec.inboundAgents.tcpPort can't be set when spec.inboundAgents.disableTCP is true",
+			"spec.inboundAgents.tcpPort can't be 8080, it's Jenkins HTTP port",
+		}, baseReconcileLoop.validateInboundAgents())
+	})
+}
@@ -0,0 +1,387 @@
+package canary
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	jenkinsclient "github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
+	k8sevent "github.com/jenkinsci/kubernetes-operator/pkg/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/failure"
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	defaultStartupTimeout = uint64(900)
+	// checkInterval is the time between checks of the starting canary Jenkins pod
+	checkInterval = 15 * time.Second
+)
+
+// verifyPluginsGroovyScript fails when the canary Jenkins couldn't load any plugin
+const verifyPluginsGroovyScript = `
+def failedPlugins = Jenkins.instance.pluginManager.failedPlugins
+failedPlugins.each { println "Plugin '${it.name}' failed to load: ${it.cause?.message}" }
+if (!failedPlugins.isEmpty()) {
+    throw new Exception("${failedPlugins.size()} plugins failed to load")
+}
+`
+
+// Verifier applies the configuration to the canary Jenkins
+type Verifier func(jenkinsClient jenkinsclient.Jenkins) error
+
+// Canary manages the canary Jenkins pod of Jenkins CR
+type Canary struct {
+	configuration.Configuration
+	logger logr.Logger
+}
+
+// New creates new instance of Canary
+func New(configuration configuration.Configuration) *Canary {
+	return &Canary{
+		Configuration: configuration,
+		logger:        log.ForJenkins(configuration.Jenkins),
+	}
+}
+
+// IsEnabled returns true when spec.canary.enabled is set
+func IsEnabled(jenkins *v1alpha2.Jenkins) bool {
+	return jenkins.Spec.Canary != nil && jenkins.Spec.Canary.Enabled
+}
+
+// GetStartupTimeout returns the time for the canary Jenkins to become ready
+func GetStartupTimeout(canary v1alpha2.Canary) time.Duration {
+	timeout := canary.StartupTimeout
+	if timeout == 0 {
+		timeout = defaultStartupTimeout
+	}
+	return time.Duration(timeout) * time.Second
+}
+
+// ConfigurationHash returns the hash of Configuration as Code and groovy scripts with their secrets and Jenkins master
+// definition, the verification starts again when it changes
+func ConfigurationHash(k8sClient client.Client, jenkins *v1alpha2.Jenkins) (string, error) {
+	hash := sha256.New()
+	master, err := json.Marshal(jenkins.Spec.Master)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	_, _ = hash.Write(master)
+
+	for _, customization := range []v1alpha2.Customization{jenkins.Spec.ConfigurationAsCode.Customization, jenkins.Spec.GroovyScripts.Customization} {
+		if len(customization.Secret.Name) > 0 {
+			secret := &corev1.Secret{}
+			if err := k8sClient.Get(context.TODO(), types.NamespacedName{Name: customization.Secret.Name, Namespace: jenkins.Namespace}, secret); err != nil {
+				return "", errors.WithStack(err)
+			}
+			data := map[string]string{}
+			for key, value := range secret.Data {
+				data[key] = string(value)
+			}
+			writeData(hash.Write, "secret/"+secret.Name, data)
+		}
+		for _, configMapRef := range customization.Configurations {
+			configMap := &corev1.ConfigMap{}
+			if err := k8sClient.Get(context.TODO(), types.NamespacedName{Name: configMapRef.Name, Namespace: jenkins.Namespace}, configMap); err != nil {
+				return "", errors.WithStack(err)
+			}
+			writeData(hash.Write, "configmap/"+configMap.Name, configMap.Data)
+		}
+	}
+	return base64.StdEncoding.EncodeToString(hash.Sum(nil)), nil
+}
+
+func writeData(write func([]byte) (int, error), name string, data map[string]string) {
+	var keys []string
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	_, _ = write([]byte(name))
+	for _, key := range keys {
+		_, _ = write([]byte(key))
+		_, _ = write([]byte(data[key]))
+	}
+}
+
+// IsFailedConfiguration tells if the current configuration has failed in the canary Jenkins pod
+func (c *Canary) IsFailedConfiguration() (bool, error) {
+	jenkins := c.Configuration.Jenkins
+	if len(jenkins.Status.FailedCanaryConfigurationHash) == 0 {
+		return false, nil
+	}
+	hash, err := ConfigurationHash(c.Client, jenkins)
+	if err != nil {
+		return false, err
+	}
+	if hash != jenkins.Status.FailedCanaryConfigurationHash {
+		return false, nil
+	}
+	c.logger.V(log.VWarn).Info("Configuration changes won't be applied to Jenkins, they have failed in the canary Jenkins pod")
+	return true, nil
+}
+
+// Verify applies the configuration to the canary Jenkins pod, it returns true when the configuration has passed
+// the verification and can be applied to Jenkins. The failed configuration isn't verified again until it changes.
+func (c *Canary) Verify(ctx context.Context, verify Verifier) (bool, reconcile.Result, error) {
+	jenkins := c.Configuration.Jenkins
+	hash, err := ConfigurationHash(c.Client, jenkins)
+	if err != nil {
+		return false, reconcile.Result{}, err
+	}
+
+	// the result of the verification is cleared when Jenkins master pod is recreated
+	if jenkins.Status.FailedCanaryConfigurationHash == hash {
+		return false, reconcile.Result{}, c.deleteCanaryResources()
+	}
+	status := jenkins.Status.Canary
+	if status != nil && status.ConfigurationHash == hash && status.Phase != v1alpha2.CanaryPhaseRunning {
+		return status.Phase == v1alpha2.CanaryPhasePassed, reconcile.Result{}, c.deleteCanaryResources()
+	}
+	if status == nil || status.ConfigurationHash != hash {
+		now := metav1.Now()
+		jenkins.Status.Canary = &v1alpha2.CanaryStatus{ConfigurationHash: hash, Phase: v1alpha2.CanaryPhaseRunning, StartTime: &now}
+		if err = c.Client.Status().Update(context.TODO(), jenkins); err != nil {
+			return false, reconcile.Result{}, errors.WithStack(err)
+		}
+		c.logger.Info(fmt.Sprintf("Verifying configuration changes in the canary Jenkins pod %s", resources.GetCanaryPodName(jenkins)))
+		c.Emitf(k8sevent.TypeNormal, k8sevent.ReasonCanaryStarted, "Verifying configuration changes in the canary Jenkins pod %s", resources.GetCanaryPodName(jenkins))
+	}
+
+	pod, err := c.ensureCanaryResources(hash)
+	if err != nil {
+		return false, reconcile.Result{}, err
+	}
+	if pod == nil {
+		return false, reconcile.Result{RequeueAfter: checkInterval}, nil
+	}
+	if pod.Status.Phase == corev1.PodFailed || pod.Status.Phase == corev1.PodSucceeded {
+		return c.fail(fmt.Sprintf("The canary Jenkins pod has terminated in phase %s", pod.Status.Phase))
+	}
+	if !isPodReady(*pod) {
+		timeout := GetStartupTimeout(*jenkins.Spec.Canary)
+		if time.Since(jenkins.Status.Canary.StartTime.Time) > timeout {
+			return c.fail(fmt.Sprintf("The canary Jenkins pod hasn't become ready in %s", timeout))
+		}
+		return false, reconcile.Result{RequeueAfter: checkInterval}, nil
+	}
+
+	jenkinsClient, err := c.getJenkinsClient(ctx)
+	if err != nil {
+		return false, reconcile.Result{}, err
+	}
+	if logs, err := jenkinsClient.ExecuteScript(verifyPluginsGroovyScript); err != nil {
+		if isGroovyScriptExecutionFailed(err) {
+			return c.fail(fmt.Sprintf("Plugins have failed to load in the canary Jenkins, logs:\n%s", logs))
+		}
+		return false, reconcile.Result{}, err
+	}
+	if err = verify(jenkinsClient); err != nil {
+		groovyErr := &jenkinsclient.GroovyScriptExecutionFailed{}
+		if errors.As(err, &groovyErr) {
+			return c.fail(fmt.Sprintf("%s ConfigMap '%s' name '%s' has failed in the canary Jenkins, logs:\n%s",
+				groovyErr.ConfigurationType, groovyErr.Source, groovyErr.Name, groovyErr.Logs))
+		}
+		return false, reconcile.Result{}, err
+	}
+	return c.pass()
+}
+
+// Stop deletes the canary Jenkins pod and clears the result of the verification
+func (c *Canary) Stop() error {
+	if err := c.deleteCanaryResources(); err != nil {
+		return err
+	}
+	if c.Configuration.Jenkins.Status.Canary == nil && len(c.Configuration.Jenkins.Status.FailedCanaryConfigurationHash) == 0 {
+		return nil
+	}
+	c.Configuration.Jenkins.Status.Canary = nil
+	c.Configuration.Jenkins.Status.FailedCanaryConfigurationHash = ""
+	return errors.WithStack(c.Client.Status().Update(context.TODO(), c.Configuration.Jenkins))
+}
+
+func (c *Canary) pass() (bool, reconcile.Result, error) {
+	now := metav1.Now()
+	status := c.Configuration.Jenkins.Status.Canary
+	status.Phase = v1alpha2.CanaryPhasePassed
+	status.CompletionTime = &now
+	status.Message = ""
+	c.Configuration.Jenkins.Status.FailedCanaryConfigurationHash = ""
+	if err := c.Client.Status().Update(context.TODO(), c.Configuration.Jenkins); err != nil {
+		return false, reconcile.Result{}, errors.WithStack(err)
+	}
+	message := fmt.Sprintf("Configuration changes have passed the verification in the canary Jenkins, took %s", now.Sub(status.StartTime.Time))
+	c.logger.Info(message)
+	c.Emit(k8sevent.TypeNormal, k8sevent.ReasonCanaryPassed, message)
+	return true, reconcile.Result{}, c.deleteCanaryResources()
+}
+
+func (c *Canary) fail(message string) (bool, reconcile.Result, error) {
+	now := metav1.Now()
+	status := c.Configuration.Jenkins.Status.Canary
+	status.Phase = v1alpha2.CanaryPhaseFailed
+	status.CompletionTime = &now
+	status.Message = message
+	c.Configuration.Jenkins.Status.FailedCanaryConfigurationHash = status.ConfigurationHash
+	if err := c.Client.Status().Update(context.TODO(), c.Configuration.Jenkins); err != nil {
+		return false, reconcile.Result{}, errors.WithStack(err)
+	}
+	c.logger.V(log.VWarn).Info(fmt.Sprintf("Configuration changes won't be applied to Jenkins: %s", message))
+	c.Emit(k8sevent.TypeWarning, k8sevent.ReasonCanaryFailed, message)
+	return false, reconcile.Result{}, c.deleteCanaryResources()
+}
+
+// ensureCanaryResources creates the canary Jenkins pod and service, it returns the pod when it runs the configuration
+func (c *Canary) ensureCanaryResources(hash string) (*corev1.Pod, error) {
+	jenkins := c.Configuration.Jenkins
+	meta := resources.NewResourceObjectMeta(jenkins)
+
+	service := &corev1.Service{}
+	err := c.Client.Get(context.TODO(), types.NamespacedName{Name: resources.GetCanaryServiceName(jenkins), Namespace: jenkins.Namespace}, service)
+	if apierrors.IsNotFound(err) {
+		if err = c.CreateResource(resources.NewCanaryService(meta, jenkins, c.JenkinsAPIConnectionSettings.UseNodePort)); err != nil {
+			return nil, errors.WithStack(err)
+		}
+	} else if err != nil {
+		return nil, errors.WithStack(err)
+	} else if c.JenkinsAPIConnectionSettings.UseNodePort != (service.Spec.Type == corev1.ServiceTypeNodePort) {
+		c.logger.Info(fmt.Sprintf("Recreating the canary service %s, Jenkins API connection settings have changed", service.Name))
+		return nil, errors.WithStack(client.IgnoreNotFound(c.Client.Delete(context.TODO(), service)))
+	} else if resources.IsPrimaryIPFamilyChanged(*service, jenkins.Spec.Service.IPFamilies) {
+		c.logger.Info(fmt.Sprintf("Recreating the canary service %s, its primary IP family has changed", service.Name))
+		return nil, errors.WithStack(client.IgnoreNotFound(c.Client.Delete(context.TODO(), service)))
+	}
+
+	pod := &corev1.Pod{}
+	err = c.Client.Get(context.TODO(), types.NamespacedName{Name: resources.GetCanaryPodName(jenkins), Namespace: jenkins.Namespace}, pod)
+	if apierrors.IsNotFound(err) {
+		return nil, errors.WithStack(c.CreateResource(resources.NewCanaryPod(meta, jenkins, hash)))
+	} else if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if c.IsJenkinsTerminating(*pod) {
+		return nil, nil
+	}
+	if pod.Annotations[resources.CanaryConfigurationHashAnnotation] != hash {
+		c.logger.Info(fmt.Sprintf("Recreating the canary Jenkins pod %s, configuration has changed", pod.Name))
+		return nil, errors.WithStack(client.IgnoreNotFound(c.Client.Delete(context.TODO(), pod)))
+	}
+	// the canary still verifies the configuration of the current generation, so the orphan garbage collector keeps it
+	generation := strconv.FormatInt(jenkins.Generation, 10)
+	if pod.Annotations[constants.AnnotationJenkinsGenerationKey] != generation {
+		if pod.Annotations == nil {
+			pod.Annotations = map[string]string{}
+		}
+		pod.Annotations[constants.AnnotationJenkinsGenerationKey] = generation
+		if err = c.Client.Update(context.TODO(), pod); err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+	return pod, nil
+}
+
+func (c *Canary) deleteCanaryResources() error {
+	jenkins := c.Configuration.Jenkins
+	for _, object := range []client.Object{
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: resources.GetCanaryPodName(jenkins), Namespace: jenkins.Namespace}},
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: resources.GetCanaryServiceName(jenkins), Namespace: jenkins.Namespace}},
+	} {
+		// the cached lookup avoids the delete request in every reconcile loop
+		err := c.Client.Get(context.TODO(), types.NamespacedName{Name: object.GetName(), Namespace: object.GetNamespace()}, object)
+		if apierrors.IsNotFound(err) || (err == nil && object.GetDeletionTimestamp() != nil) {
+			continue
+		} else if err != nil {
+			return errors.WithStack(err)
+		}
+		if err = c.Client.Delete(context.TODO(), object); err != nil && !apierrors.IsNotFound(err) {
+			return errors.WithStack(err)
+		}
+	}
+	return nil
+}
+
+// getJenkinsAPIUrl returns URL of the canary Jenkins, it's reached through the canary service in the same way
+// as Jenkins is reached according to Jenkins API connection settings of the operator
+func (c *Canary) getJenkinsAPIUrl() (string, error) {
+	jenkins := c.Configuration.Jenkins
+	settings := c.JenkinsAPIConnectionSettings
+	if len(settings.Hostname) > 0 && !settings.UseNodePort {
+		return "", failure.ConfigurationInvalid(errors.New("the canary Jenkins pod can't be reached on the port of Jenkins API connection settings, use the nodePort"))
+	}
+
+	service := &corev1.Service{}
+	err := c.Client.Get(context.TODO(), types.NamespacedName{Name: resources.GetCanaryServiceName(jenkins), Namespace: jenkins.Namespace}, service)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	var jenkinsURL string
+	if len(settings.Hostname) == 0 && len(c.KubernetesClusterDomain) > 0 {
+		jenkinsURL = fmt.Sprintf("http://%s.%s.svc.%s:%d", service.Name, service.Namespace, c.KubernetesClusterDomain, service.Spec.Ports[0].Port)
+	} else {
+		jenkinsURL = settings.BuildJenkinsAPIUrl(service.Name, service.Namespace, service.Spec.Ports[0].Port, service.Spec.Ports[0].NodePort)
+	}
+	if prefix, ok := resources.GetJenkinsOpts(*jenkins)["prefix"]; ok {
+		jenkinsURL += prefix
+	}
+	return jenkinsURL, nil
+}
+
+// getJenkinsClient returns Jenkins client of the canary Jenkins with the operator credentials of Jenkins
+func (c *Canary) getJenkinsClient(ctx context.Context) (jenkinsclient.Jenkins, error) {
+	jenkins := c.Configuration.Jenkins
+	jenkinsURL, err := c.getJenkinsAPIUrl()
+	if err != nil {
+		return nil, err
+	}
+
+	if jenkins.Spec.JenkinsAPISettings.AuthorizationStrategy == v1alpha2.ServiceAccountAuthorizationStrategy {
+		token, _, err := c.Exec(resources.GetCanaryPodName(jenkins), resources.JenkinsMasterContainerName, []string{"cat", "/var/run/secrets/kubernetes.io/serviceaccount/token"})
+		if err != nil {
+			return nil, err
+		}
+		return jenkinsclient.NewBearerTokenAuthorization(ctx, jenkinsURL, token.String())
+	}
+
+	credentialsSecret := &corev1.Secret{}
+	err = c.Client.Get(context.TODO(), types.NamespacedName{Name: resources.GetOperatorCredentialsSecretName(jenkins), Namespace: jenkins.Namespace}, credentialsSecret)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return jenkinsclient.NewUserAndPasswordAuthorization(ctx, jenkinsURL,
+		string(credentialsSecret.Data[resources.OperatorCredentialsSecretUserNameKey]),
+		string(credentialsSecret.Data[resources.OperatorCredentialsSecretPasswordKey]))
+}
+
+func isGroovyScriptExecutionFailed(err error) bool {
+	groovyErr := &jenkinsclient.GroovyScriptExecutionFailed{}
+	return errors.As(err, &groovyErr)
+}
+
+func isPodReady(pod corev1.Pod) bool {
+	if pod.Status.Phase != corev1.PodRunning {
+		return false
+	}
+	for _, containerStatus := range pod.Status.ContainerStatuses {
+		if !containerStatus.Ready {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,240 @@
+package canary
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	jenkinsclient "github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const namespace = "default"
+
+func newJenkins() *v1alpha2.Jenkins {
+	return &v1alpha2.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: namespace},
+		Spec: v1alpha2.JenkinsSpec{
+			Master: v1alpha2.JenkinsMaster{
+				Containers: []v1alpha2.Container{{
+					Name:           resources.JenkinsMasterContainerName,
+					Image:          "jenkins/jenkins:lts",
+					ReadinessProbe: &corev1.Probe{Handler: corev1.Handler{HTTPGet: &corev1.HTTPGetAction{Path: "/login"}}},
+					LivenessProbe:  &corev1.Probe{Handler: corev1.Handler{HTTPGet: &corev1.HTTPGetAction{Path: "/login"}}},
+				}},
+			},
+			ConfigurationAsCode: v1alpha2.ConfigurationAsCode{
+				Customization: v1alpha2.Customization{Configurations: []v1alpha2.ConfigMapRef{{Name: "casc"}}},
+			},
+			Canary: &v1alpha2.Canary{Enabled: true},
+		},
+	}
+}
+
+func TestConfigurationHash(t *testing.T) {
+	jenkins := newJenkins()
+	configMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "casc", Namespace: namespace}, Data: map[string]string{"jenkins.yaml": "jenkins: {}"}}
+	fakeClient := fake.NewClientBuilder().WithObjects(configMap).Build()
+
+	hash, err := ConfigurationHash(fakeClient, jenkins)
+	require.NoError(t, err)
+
+	t.Run("same configuration", func(t *testing.T) {
+		actual, err := ConfigurationHash(fakeClient, jenkins)
+
+		require.NoError(t, err)
+		assert.Equal(t, hash, actual)
+	})
+	t.Run("ConfigMap changed", func(t *testing.T) {
+		changed := configMap.DeepCopy()
+		changed.Data["jenkins.yaml"] = "jenkins: {systemMessage: test}"
+		actual, err := ConfigurationHash(fake.NewClientBuilder().WithObjects(changed).Build(), jenkins)
+
+		require.NoError(t, err)
+		assert.NotEqual(t, hash, actual)
+	})
+	t.Run("Jenkins master changed", func(t *testing.T) {
+		changed := jenkins.DeepCopy()
+		changed.Spec.Master.Plugins = []v1alpha2.Plugin{{Name: "simple-theme-plugin", Version: "0.7"}}
+		actual, err := ConfigurationHash(fakeClient, changed)
+
+		require.NoError(t, err)
+		assert.NotEqual(t, hash, actual)
+	})
+	t.Run("missing ConfigMap", func(t *testing.T) {
+		_, err := ConfigurationHash(fake.NewClientBuilder().Build(), jenkins)
+
+		assert.True(t, apierrors.IsNotFound(err))
+	})
+}
+
+func TestCanary_Verify(t *testing.T) {
+	require.NoError(t, v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme))
+	ctx := context.TODO()
+	configMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "casc", Namespace: namespace}, Data: map[string]string{"jenkins.yaml": "jenkins: {}"}}
+	verify := func(jenkinsClient jenkinsclient.Jenkins) error {
+		t.Fatal("configuration mustn't be verified until the canary Jenkins is ready")
+		return nil
+	}
+	newCanary := func(jenkins *v1alpha2.Jenkins, objects ...client.Object) (*Canary, client.Client) {
+		fakeClient := fake.NewClientBuilder().WithObjects(append(objects, jenkins, configMap)...).Build()
+		return New(configuration.Configuration{Client: fakeClient, Jenkins: jenkins, Scheme: scheme.Scheme}), fakeClient
+	}
+	getPod := func(fakeClient client.Client, jenkins *v1alpha2.Jenkins) (*corev1.Pod, error) {
+		pod := &corev1.Pod{}
+		err := fakeClient.Get(ctx, types.NamespacedName{Name: resources.GetCanaryPodName(jenkins), Namespace: namespace}, pod)
+		return pod, err
+	}
+
+	t.Run("create canary", func(t *testing.T) {
+		jenkins := newJenkins()
+		canary, fakeClient := newCanary(jenkins)
+
+		verified, result, err := canary.Verify(ctx, verify)
+
+		require.NoError(t, err)
+		assert.False(t, verified)
+		assert.Equal(t, checkInterval, result.RequeueAfter)
+		require.NotNil(t, jenkins.Status.Canary)
+		assert.Equal(t, v1alpha2.CanaryPhaseRunning, jenkins.Status.Canary.Phase)
+		pod, err := getPod(fakeClient, jenkins)
+		require.NoError(t, err)
+		assert.Equal(t, jenkins.Status.Canary.ConfigurationHash, pod.Annotations[resources.CanaryConfigurationHashAnnotation])
+		assert.NoError(t, fakeClient.Get(ctx, types.NamespacedName{Name: resources.GetCanaryServiceName(jenkins), Namespace: namespace}, &corev1.Service{}))
+	})
+	t.Run("recreate canary when configuration changes", func(t *testing.T) {
+		jenkins := newJenkins()
+		now := metav1.Now()
+		jenkins.Status.Canary = &v1alpha2.CanaryStatus{ConfigurationHash: "old", Phase: v1alpha2.CanaryPhaseFailed, StartTime: &now}
+		pod := resources.NewCanaryPod(resources.NewResourceObjectMeta(jenkins), jenkins, "old")
+		canary, fakeClient := newCanary(jenkins, pod)
+
+		verified, _, err := canary.Verify(ctx, verify)
+
+		require.NoError(t, err)
+		assert.False(t, verified)
+		assert.Equal(t, v1alpha2.CanaryPhaseRunning, jenkins.Status.Canary.Phase)
+		assert.NotEqual(t, "old", jenkins.Status.Canary.ConfigurationHash)
+		_, err = getPod(fakeClient, jenkins)
+		assert.True(t, apierrors.IsNotFound(err))
+	})
+	t.Run("canary isn't ready in time", func(t *testing.T) {
+		jenkins := newJenkins()
+		canary, fakeClient := newCanary(jenkins)
+		_, _, err := canary.Verify(ctx, verify)
+		require.NoError(t, err)
+		jenkins.Status.Canary.StartTime = &metav1.Time{Time: time.Now().Add(-GetStartupTimeout(*jenkins.Spec.Canary) - time.Minute)}
+
+		verified, result, err := canary.Verify(ctx, verify)
+
+		require.NoError(t, err)
+		assert.False(t, verified)
+		assert.Equal(t, time.Duration(0), result.RequeueAfter)
+		assert.Equal(t, v1alpha2.CanaryPhaseFailed, jenkins.Status.Canary.Phase)
+		assert.Equal(t, "The canary Jenkins pod hasn't become ready in 15m0s", jenkins.Status.Canary.Message)
+		assert.Equal(t, jenkins.Status.Canary.ConfigurationHash, jenkins.Status.FailedCanaryConfigurationHash)
+		_, err = getPod(fakeClient, jenkins)
+		assert.True(t, apierrors.IsNotFound(err))
+
+		t.Run("failed configuration isn't verified again", func(t *testing.T) {
+			verified, _, err := canary.Verify(ctx, verify)
+
+			require.NoError(t, err)
+			assert.False(t, verified)
+			_, err = getPod(fakeClient, jenkins)
+			assert.True(t, apierrors.IsNotFound(err))
+		})
+	})
+	t.Run("failed configuration after Jenkins master pod restart", func(t *testing.T) {
+		jenkins := newJenkins()
+		canary, fakeClient := newCanary(jenkins)
+		hash, err := ConfigurationHash(fakeClient, jenkins)
+		require.NoError(t, err)
+		jenkins.Status.FailedCanaryConfigurationHash = hash
+
+		failed, err := canary.IsFailedConfiguration()
+		require.NoError(t, err)
+		assert.True(t, failed)
+
+		verified, _, err := canary.Verify(ctx, verify)
+
+		require.NoError(t, err)
+		assert.False(t, verified)
+		assert.Nil(t, jenkins.Status.Canary)
+		_, err = getPod(fakeClient, jenkins)
+		assert.True(t, apierrors.IsNotFound(err))
+
+		t.Run("changed configuration", func(t *testing.T) {
+			jenkins.Status.FailedCanaryConfigurationHash = "old"
+
+			failed, err := canary.IsFailedConfiguration()
+
+			require.NoError(t, err)
+			assert.False(t, failed)
+		})
+	})
+	t.Run("passed configuration", func(t *testing.T) {
+		jenkins := newJenkins()
+		canary, fakeClient := newCanary(jenkins)
+		hash, err := ConfigurationHash(fakeClient, jenkins)
+		require.NoError(t, err)
+		jenkins.Status.Canary = &v1alpha2.CanaryStatus{ConfigurationHash: hash, Phase: v1alpha2.CanaryPhasePassed}
+
+		verified, _, err := canary.Verify(ctx, verify)
+
+		require.NoError(t, err)
+		assert.True(t, verified)
+	})
+	t.Run("stop", func(t *testing.T) {
+		jenkins := newJenkins()
+		canary, fakeClient := newCanary(jenkins)
+		_, _, err := canary.Verify(ctx, verify)
+		require.NoError(t, err)
+
+		require.NoError(t, canary.Stop())
+
+		assert.Nil(t, jenkins.Status.Canary)
+		_, err = getPod(fakeClient, jenkins)
+		assert.True(t, apierrors.IsNotFound(err))
+	})
+}
+
+func TestCanary_getJenkinsAPIUrl(t *testing.T) {
+	jenkins := newJenkins()
+	service := resources.NewCanaryService(resources.NewResourceObjectMeta(jenkins), jenkins, true)
+	service.Spec.Ports[0].NodePort = 30080
+	fakeClient := fake.NewClientBuilder().WithObjects(service).Build()
+	newCanary := func(settings jenkinsclient.JenkinsAPIConnectionSettings, clusterDomain string) *Canary {
+		return New(configuration.Configuration{Client: fakeClient, Jenkins: jenkins, JenkinsAPIConnectionSettings: settings, KubernetesClusterDomain: clusterDomain})
+	}
+
+	t.Run("cluster domain", func(t *testing.T) {
+		url, err := newCanary(jenkinsclient.JenkinsAPIConnectionSettings{}, "cluster.local").getJenkinsAPIUrl()
+
+		require.NoError(t, err)
+		assert.Equal(t, "http://jenkins-operator-canary-jenkins.default.svc.cluster.local:8080", url)
+	})
+	t.Run("node port", func(t *testing.T) {
+		url, err := newCanary(jenkinsclient.JenkinsAPIConnectionSettings{Hostname: "10.0.0.1", UseNodePort: true}, "cluster.local").getJenkinsAPIUrl()
+
+		require.NoError(t, err)
+		assert.Equal(t, "http://10.0.0.1:30080", url)
+	})
+	t.Run("port of Jenkins", func(t *testing.T) {
+		_, err := newCanary(jenkinsclient.JenkinsAPIConnectionSettings{Hostname: "localhost", Port: 8080}, "cluster.local").getJenkinsAPIUrl()
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,2 @@
+// Package canary verifies Configuration as Code and groovy script changes in a temporary Jenkins pod before they're applied to Jenkins
+package canary
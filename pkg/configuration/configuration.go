@@ -3,13 +3,20 @@ package configuration
 import (
 	"bytes"
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
 	jenkinsclient "github.com/jenkinsci/kubernetes-operator/pkg/client"
 	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/maintenance"
+	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
+	k8sevent "github.com/jenkinsci/kubernetes-operator/pkg/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/failure"
 	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
 	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/reason"
+	"github.com/jenkinsci/kubernetes-operator/pkg/plugins"
 
 	stackerr "github.com/pkg/errors"
 	appsv1 "k8s.io/api/apps/v1"
@@ -23,6 +30,7 @@ import (
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/remotecommand"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
@@ -31,14 +39,22 @@ type Configuration struct {
 	Client                       client.Client
 	ClientSet                    kubernetes.Clientset
 	Notifications                *chan event.Event
+	Events                       k8sevent.Recorder
 	Jenkins                      *v1alpha2.Jenkins
 	Scheme                       *runtime.Scheme
 	Config                       *rest.Config
 	JenkinsAPIConnectionSettings jenkinsclient.JenkinsAPIConnectionSettings
 	KubernetesClusterDomain      string
+	// BasePluginManifests are the base plugin manifests with the overrides, the embedded manifests are used when it's empty
+	BasePluginManifests plugins.BasePluginManifests
+	// ServerSideApply enables server-side apply of the objects managed by the operator
+	ServerSideApply bool
 }
 
-// RestartJenkinsMasterPod terminate Jenkins master pod and notifies about it.
+// FieldManager is the field manager of the objects created, updated and applied by the operator
+const FieldManager = constants.OperatorName
+
+// RestartJenkinsMasterPod terminate Jenkins master pod, notifies about it and emits the PodRestart event.
 func (c *Configuration) RestartJenkinsMasterPod(reason reason.Reason) error {
 	currentJenkinsMasterPod, err := c.GetJenkinsMasterPod()
 	if err != nil {
@@ -55,10 +71,109 @@ func (c *Configuration) RestartJenkinsMasterPod(reason reason.Reason) error {
 		Level:   v1alpha2.NotificationLevelInfo,
 		Reason:  reason,
 	}
+	c.Emit(k8sevent.TypeNormal, k8sevent.ReasonPodRestart, strings.Join(reason.Short(), "; "))
 
 	return stackerr.WithStack(c.Client.Delete(context.TODO(), currentJenkinsMasterPod))
 }
 
+// Emit records Kubernetes event attached to Jenkins CR, it does nothing if the event recorder isn't set.
+func (c *Configuration) Emit(eventType k8sevent.Type, reason k8sevent.Reason, message string) {
+	if c.Events == nil {
+		return
+	}
+	c.Events.Emit(c.Jenkins, eventType, reason, message)
+}
+
+// Emitf records Kubernetes event attached to Jenkins CR with formatted message, it does nothing if the event recorder isn't set.
+func (c *Configuration) Emitf(eventType k8sevent.Type, reason k8sevent.Reason, format string, args ...interface{}) {
+	if c.Events == nil {
+		return
+	}
+	c.Events.Emitf(c.Jenkins, eventType, reason, format, args...)
+}
+
+// RemoveJenkinsAnnotation removes the annotation from Jenkins CR, the spec rendered from Jenkins templates is kept
+func (c *Configuration) RemoveJenkinsAnnotation(key string) error {
+	if _, found := c.Jenkins.Annotations[key]; !found {
+		return nil
+	}
+	jenkins := c.Jenkins.DeepCopy()
+	patch := client.MergeFrom(jenkins.DeepCopy())
+	delete(jenkins.Annotations, key)
+	if err := c.Client.Patch(context.TODO(), jenkins, patch); err != nil {
+		return stackerr.WithStack(err)
+	}
+	delete(c.Jenkins.Annotations, key)
+	c.Jenkins.ResourceVersion = jenkins.ResourceVersion
+	return nil
+}
+
+// DeferDisruptiveAction returns true when the disruptive action must wait for the next window of spec.maintenanceWindows,
+// the deferred action is reported in status.deferredActions until it runs
+func (c *Configuration) DeferDisruptiveAction(action v1alpha2.DisruptiveAction, message string) (bool, error) {
+	now := time.Now()
+	active, next, err := maintenance.Check(c.Jenkins.Spec.MaintenanceWindows, now)
+	if err != nil {
+		return false, err
+	}
+	if active {
+		return false, c.ResolveDeferredAction(action)
+	}
+
+	nextWindow := metav1.NewTime(next.UTC())
+	for _, deferred := range c.Jenkins.Status.DeferredActions {
+		if deferred.Action == action && deferred.Message == message && deferred.NextWindow.Equal(&nextWindow) {
+			return true, nil
+		}
+	}
+	actions, added := SetDeferredAction(c.Jenkins.Status.DeferredActions, v1alpha2.DeferredAction{
+		Action:        action,
+		Message:       message,
+		DeferredSince: metav1.NewTime(now),
+		NextWindow:    &nextWindow,
+	})
+	c.Jenkins.Status.DeferredActions = actions
+	if err = c.Client.Status().Update(context.TODO(), c.Jenkins); err != nil {
+		return true, stackerr.WithStack(err)
+	}
+	if added {
+		c.Emitf(k8sevent.TypeNormal, k8sevent.ReasonDisruptiveActionDeferred, "%s has been deferred until the maintenance window at %s: %s",
+			action, next.Format(time.RFC3339), message)
+	}
+	return true, nil
+}
+
+// ResolveDeferredAction removes the action from status.deferredActions when it runs or it isn't needed anymore
+func (c *Configuration) ResolveDeferredAction(action v1alpha2.DisruptiveAction) error {
+	var actions []v1alpha2.DeferredAction
+	for _, deferred := range c.Jenkins.Status.DeferredActions {
+		if deferred.Action != action {
+			actions = append(actions, deferred)
+		}
+	}
+	if len(actions) == len(c.Jenkins.Status.DeferredActions) {
+		return nil
+	}
+	c.Jenkins.Status.DeferredActions = actions
+	return stackerr.WithStack(c.Client.Status().Update(context.TODO(), c.Jenkins))
+}
+
+// SetDeferredAction replaces the deferred action of the same type keeping the time since it's deferred, it returns true
+// when the action hasn't been deferred before
+func SetDeferredAction(actions []v1alpha2.DeferredAction, action v1alpha2.DeferredAction) ([]v1alpha2.DeferredAction, bool) {
+	result := make([]v1alpha2.DeferredAction, 0, len(actions)+1)
+	added := true
+	for _, deferred := range actions {
+		if deferred.Action == action.Action {
+			action.DeferredSince = deferred.DeferredSince
+			added = false
+			continue
+		}
+		result = append(result, deferred)
+	}
+	return append(result, action), added
+}
+
 // GetJenkinsMasterPod gets the jenkins master pod.
 func (c *Configuration) GetJenkinsMasterPod() (*corev1.Pod, error) {
 	jenkinsMasterPodName := resources.GetJenkinsMasterPodName(c.Jenkins)
@@ -98,7 +213,7 @@ func (c *Configuration) CreateResource(obj metav1.Object) error {
 		return stackerr.WithStack(err)
 	}
 
-	return c.Client.Create(context.TODO(), clientObj) // don't wrap error
+	return c.Client.Create(context.TODO(), clientObj, client.FieldOwner(FieldManager)) // don't wrap error
 }
 
 // UpdateResource is updating kubernetes resource and references it to Jenkins CR.
@@ -111,7 +226,7 @@ func (c *Configuration) UpdateResource(obj metav1.Object) error {
 	// set Jenkins instance as the owner and controller, don't check errors(can be already set)
 	_ = controllerutil.SetControllerReference(c.Jenkins, obj, c.Scheme)
 
-	return c.Client.Update(context.TODO(), clientObj) // don't wrap error
+	return c.Client.Update(context.TODO(), clientObj, client.FieldOwner(FieldManager)) // don't wrap error
 }
 
 // CreateOrUpdateResource is creating or updating kubernetes resource and references it to Jenkins CR.
@@ -121,10 +236,14 @@ func (c *Configuration) CreateOrUpdateResource(obj metav1.Object) error {
 		return stackerr.Errorf("is not a %T a runtime.Object", obj)
 	}
 
+	if c.ServerSideApply {
+		return c.ApplyResource(obj)
+	}
+
 	// set Jenkins instance as the owner and controller, don't check error(can be already set)
 	_ = controllerutil.SetControllerReference(c.Jenkins, obj, c.Scheme)
 
-	err := c.Client.Create(context.TODO(), clientObj)
+	err := c.Client.Create(context.TODO(), clientObj, client.FieldOwner(FieldManager))
 	if err != nil && errors.IsAlreadyExists(err) {
 		return c.UpdateResource(obj)
 	} else if err != nil && !errors.IsAlreadyExists(err) {
@@ -134,6 +253,101 @@ func (c *Configuration) CreateOrUpdateResource(obj metav1.Object) error {
 	return nil
 }
 
+// ApplyResource is applying kubernetes resource with server-side apply and references it to Jenkins CR. The operator owns
+// only the fields set in the object, so the fields set by other field managers are kept. The conflicting fields aren't
+// forced, so the conflict rejects the whole apply, the object is left unchanged and the conflict is recorded in Jenkins CR
+// status until the apply succeeds.
+func (c *Configuration) ApplyResource(obj metav1.Object) error {
+	clientObj, ok := obj.(client.Object)
+	if !ok {
+		return stackerr.Errorf("is not a %T a runtime.Object", obj)
+	}
+
+	// set Jenkins instance as the owner and controller, don't check error(can be already set)
+	_ = controllerutil.SetControllerReference(c.Jenkins, obj, c.Scheme)
+	gvk, err := apiutil.GVKForObject(clientObj, c.Scheme)
+	if err != nil {
+		return stackerr.WithStack(err)
+	}
+	clientObj.GetObjectKind().SetGroupVersionKind(gvk)
+	clientObj.SetResourceVersion("")
+	clientObj.SetManagedFields(nil)
+
+	err = c.Client.Patch(context.TODO(), clientObj, client.Apply, client.FieldOwner(FieldManager))
+	if err == nil {
+		conflicts, removed := RemoveApplyConflict(c.Jenkins.Status.ApplyConflicts, gvk.Kind, clientObj.GetName())
+		if !removed {
+			return nil
+		}
+		c.Jenkins.Status.ApplyConflicts = conflicts
+		return stackerr.WithStack(c.Client.Status().Update(context.TODO(), c.Jenkins))
+	} else if !errors.IsConflict(err) {
+		return stackerr.WithStack(err)
+	}
+
+	conflict := NewApplyConflict(gvk.Kind, clientObj.GetName(), err)
+	if !HasApplyConflict(c.Jenkins.Status.ApplyConflicts, conflict) {
+		c.Emitf(k8sevent.TypeWarning, k8sevent.ReasonApplyConflict, "%s %s: %s", conflict.Kind, conflict.Name, conflict.Message)
+		c.Jenkins.Status.ApplyConflicts = SetApplyConflict(c.Jenkins.Status.ApplyConflicts, conflict)
+		if err := c.Client.Status().Update(context.TODO(), c.Jenkins); err != nil {
+			return stackerr.WithStack(err)
+		}
+	}
+	// the conflict isn't returned, the controller would requeue it immediately as a conflict of the update
+	return failure.Transient(stackerr.Errorf("server-side apply of %s %s conflicts with other field managers: %s",
+		conflict.Kind, conflict.Name, conflict.Message))
+}
+
+// NewApplyConflict returns the conflict of server-side apply with the conflicting fields and their field managers
+func NewApplyConflict(kind, name string, err error) v1alpha2.ApplyConflict {
+	conflict := v1alpha2.ApplyConflict{Kind: kind, Name: name, Message: err.Error(), Time: metav1.Now()}
+	status, ok := err.(errors.APIStatus)
+	if !ok || status.Status().Details == nil {
+		return conflict
+	}
+	var fields []string
+	for _, cause := range status.Status().Details.Causes {
+		if cause.Type == metav1.CauseTypeFieldManagerConflict {
+			fields = append(fields, fmt.Sprintf("%s %s", cause.Field, cause.Message))
+		}
+	}
+	if len(fields) > 0 {
+		conflict.Message = strings.Join(fields, "; ")
+	}
+	return conflict
+}
+
+// HasApplyConflict returns true when the same conflict of the object has been already recorded
+func HasApplyConflict(conflicts []v1alpha2.ApplyConflict, conflict v1alpha2.ApplyConflict) bool {
+	for _, current := range conflicts {
+		if current.Kind == conflict.Kind && current.Name == conflict.Name && current.Message == conflict.Message {
+			return true
+		}
+	}
+	return false
+}
+
+// SetApplyConflict replaces the last conflict of the object in the list or appends it
+func SetApplyConflict(conflicts []v1alpha2.ApplyConflict, conflict v1alpha2.ApplyConflict) []v1alpha2.ApplyConflict {
+	for i, current := range conflicts {
+		if current.Kind == conflict.Kind && current.Name == conflict.Name {
+			conflicts[i] = conflict
+			return conflicts
+		}
+	}
+	return append(conflicts, conflict)
+}
+
+// RemoveApplyConflict removes the conflict of the object from the list, returns true when it has been removed
+func RemoveApplyConflict(conflicts []v1alpha2.ApplyConflict, kind, name string) ([]v1alpha2.ApplyConflict, bool) {
+	for i, current := range conflicts {
+		if current.Kind == kind && current.Name == name {
+			return append(conflicts[:i:i], conflicts[i+1:]...), true
+		}
+	}
+	return conflicts, false
+}
+
 // Exec executes command in the given pod and it's container.
 func (c *Configuration) Exec(podName, containerName string, command []string) (stdout, stderr bytes.Buffer, err error) {
 	req := c.ClientSet.CoreV1().RESTClient().Post().
@@ -177,15 +391,15 @@ func (c *Configuration) GetJenkinsMasterContainer() *v1alpha2.Container {
 	return nil
 }
 
-// GetJenkinsClient gets jenkins client from a configuration.
-func (c *Configuration) GetJenkinsClient() (jenkinsclient.Jenkins, error) {
+// GetJenkinsClient gets jenkins client from a configuration, the span in ctx is the parent of Jenkins API calls spans.
+func (c *Configuration) GetJenkinsClient(ctx context.Context) (jenkinsclient.Jenkins, error) {
 	switch c.Jenkins.Spec.JenkinsAPISettings.AuthorizationStrategy {
 	case v1alpha2.ServiceAccountAuthorizationStrategy:
-		return c.GetJenkinsClientFromServiceAccount()
+		return c.GetJenkinsClientFromServiceAccount(ctx)
 	case v1alpha2.CreateUserAuthorizationStrategy:
-		return c.GetJenkinsClientFromSecret()
+		return c.GetJenkinsClientFromSecret(ctx)
 	default:
-		return nil, stackerr.Errorf("unrecognized '%s' spec.jenkinsAPISettings.authorizationStrategy", c.Jenkins.Spec.JenkinsAPISettings.AuthorizationStrategy)
+		return nil, failure.ConfigurationInvalid(stackerr.Errorf("unrecognized '%s' spec.jenkinsAPISettings.authorizationStrategy", c.Jenkins.Spec.JenkinsAPISettings.AuthorizationStrategy))
 	}
 }
 
@@ -208,7 +422,7 @@ func (c *Configuration) getJenkinsAPIUrl() (string, error) {
 }
 
 // GetJenkinsClientFromServiceAccount gets jenkins client from a serviceAccount.
-func (c *Configuration) GetJenkinsClientFromServiceAccount() (jenkinsclient.Jenkins, error) {
+func (c *Configuration) GetJenkinsClientFromServiceAccount(ctx context.Context) (jenkinsclient.Jenkins, error) {
 	jenkinsAPIUrl, err := c.getJenkinsAPIUrl()
 	if err != nil {
 		return nil, err
@@ -220,11 +434,11 @@ func (c *Configuration) GetJenkinsClientFromServiceAccount() (jenkinsclient.Jenk
 		return nil, err
 	}
 
-	return jenkinsclient.NewBearerTokenAuthorization(jenkinsAPIUrl, token.String())
+	return jenkinsclient.NewBearerTokenAuthorization(ctx, jenkinsAPIUrl, token.String())
 }
 
 // GetJenkinsClientFromSecret gets jenkins client from a secret.
-func (c *Configuration) GetJenkinsClientFromSecret() (jenkinsclient.Jenkins, error) {
+func (c *Configuration) GetJenkinsClientFromSecret(ctx context.Context) (jenkinsclient.Jenkins, error) {
 	jenkinsURL, err := c.getJenkinsAPIUrl()
 	if err != nil {
 		return nil, err
@@ -252,6 +466,7 @@ func (c *Configuration) GetJenkinsClientFromSecret() (jenkinsclient.Jenkins, err
 		currentJenkinsMasterPod.ObjectMeta.CreationTimestamp.Time.UTC().After(tokenCreationTime.UTC()) {
 		userName := string(credentialsSecret.Data[resources.OperatorCredentialsSecretUserNameKey])
 		jenkinsClient, err := jenkinsclient.NewUserAndPasswordAuthorization(
+			ctx,
 			jenkinsURL,
 			userName,
 			string(credentialsSecret.Data[resources.OperatorCredentialsSecretPasswordKey]))
@@ -273,6 +488,7 @@ func (c *Configuration) GetJenkinsClientFromSecret() (jenkinsclient.Jenkins, err
 		}
 	}
 	return jenkinsclient.NewUserAndPasswordAuthorization(
+		ctx,
 		jenkinsURL,
 		string(credentialsSecret.Data[resources.OperatorCredentialsSecretUserNameKey]),
 		string(credentialsSecret.Data[resources.OperatorCredentialsSecretTokenKey]))
@@ -0,0 +1,89 @@
+package configuration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestNewApplyConflict(t *testing.T) {
+	t.Run("field manager conflicts", func(t *testing.T) {
+		err := apierrors.NewApplyConflict([]metav1.StatusCause{
+			{Type: metav1.CauseTypeFieldManagerConflict, Field: ".metadata.annotations.team", Message: `conflict with "kubectl" using v1`},
+			{Type: metav1.CauseTypeFieldManagerConflict, Field: ".spec.type", Message: `conflict with "cloud-controller" using v1`},
+		}, "Apply failed with 2 conflicts")
+
+		conflict := NewApplyConflict("Service", "jenkins-operator-http-example", err)
+
+		assert.Equal(t, "Service", conflict.Kind)
+		assert.Equal(t, "jenkins-operator-http-example", conflict.Name)
+		assert.Equal(t, `.metadata.annotations.team conflict with "kubectl" using v1; .spec.type conflict with "cloud-controller" using v1`, conflict.Message)
+	})
+	t.Run("conflict without causes", func(t *testing.T) {
+		err := apierrors.NewConflict(schema.GroupResource{Resource: "configmaps"}, "example", assert.AnError)
+
+		conflict := NewApplyConflict("ConfigMap", "example", err)
+
+		assert.Equal(t, err.Error(), conflict.Message)
+	})
+}
+
+func TestSetApplyConflict(t *testing.T) {
+	first := v1alpha2.ApplyConflict{Kind: "Service", Name: "http", Message: "first"}
+	other := v1alpha2.ApplyConflict{Kind: "ConfigMap", Name: "http", Message: "other"}
+	second := v1alpha2.ApplyConflict{Kind: "Service", Name: "http", Message: "second"}
+
+	conflicts := SetApplyConflict(nil, first)
+	conflicts = SetApplyConflict(conflicts, other)
+	conflicts = SetApplyConflict(conflicts, second)
+
+	assert.Equal(t, []v1alpha2.ApplyConflict{second, other}, conflicts)
+}
+
+func TestSetDeferredAction(t *testing.T) {
+	since := metav1.NewTime(time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC))
+	later := metav1.NewTime(since.Add(time.Hour))
+	plugins := v1alpha2.DeferredAction{Action: v1alpha2.DisruptiveActionPluginChanges, Message: "plugins", DeferredSince: since}
+	restart := v1alpha2.DeferredAction{Action: v1alpha2.DisruptiveActionPodRestart, Message: "labels", DeferredSince: since}
+
+	actions, added := SetDeferredAction(nil, restart)
+	assert.True(t, added)
+	actions, added = SetDeferredAction(actions, plugins)
+	assert.True(t, added)
+	actions, added = SetDeferredAction(actions, v1alpha2.DeferredAction{Action: v1alpha2.DisruptiveActionPodRestart, Message: "labels; annotations", DeferredSince: later})
+	assert.False(t, added)
+
+	assert.Equal(t, []v1alpha2.DeferredAction{
+		plugins,
+		{Action: v1alpha2.DisruptiveActionPodRestart, Message: "labels; annotations", DeferredSince: since},
+	}, actions)
+}
+
+func TestRemoveApplyConflict(t *testing.T) {
+	service := v1alpha2.ApplyConflict{Kind: "Service", Name: "http", Message: "first"}
+	configMap := v1alpha2.ApplyConflict{Kind: "ConfigMap", Name: "http", Message: "other"}
+	conflicts := []v1alpha2.ApplyConflict{service, configMap}
+
+	remaining, removed := RemoveApplyConflict(conflicts, "Service", "http")
+	assert.True(t, removed)
+	assert.Equal(t, []v1alpha2.ApplyConflict{configMap}, remaining)
+	assert.Equal(t, []v1alpha2.ApplyConflict{service, configMap}, conflicts)
+
+	remaining, removed = RemoveApplyConflict(remaining, "Service", "http")
+	assert.False(t, removed)
+	assert.Equal(t, []v1alpha2.ApplyConflict{configMap}, remaining)
+}
+
+func TestHasApplyConflict(t *testing.T) {
+	conflicts := []v1alpha2.ApplyConflict{{Kind: "Service", Name: "http", Message: "first"}}
+
+	assert.True(t, HasApplyConflict(conflicts, v1alpha2.ApplyConflict{Kind: "Service", Name: "http", Message: "first"}))
+	assert.False(t, HasApplyConflict(conflicts, v1alpha2.ApplyConflict{Kind: "Service", Name: "http", Message: "second"}))
+	assert.False(t, HasApplyConflict(conflicts, v1alpha2.ApplyConflict{Kind: "ConfigMap", Name: "http", Message: "first"}))
+}
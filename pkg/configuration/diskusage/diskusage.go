@@ -0,0 +1,138 @@
+package diskusage
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultCheckInterval    = uint64(300)
+	defaultWarningThreshold = int32(80)
+	dfBlockSize             = int64(1024)
+)
+
+// GetCheckInterval returns the interval of disk usage checks
+func GetCheckInterval(settings v1alpha2.DiskUsage) time.Duration {
+	interval := settings.CheckInterval
+	if interval == 0 {
+		interval = defaultCheckInterval
+	}
+	return time.Duration(interval) * time.Second
+}
+
+// GetWarningThreshold returns the percentage of used disk space when the warning is emitted
+func GetWarningThreshold(settings v1alpha2.DiskUsage) int32 {
+	if settings.WarningThreshold == 0 {
+		return defaultWarningThreshold
+	}
+	return settings.WarningThreshold
+}
+
+// Command returns the command executed in Jenkins master container which prints Jenkins home disk usage
+func Command(jenkins *v1alpha2.Jenkins) []string {
+	return []string{"df", "-Pk", resources.GetJenkinsHomePath(jenkins)}
+}
+
+// ParseDiskUsage parses the POSIX output of df command
+func ParseDiskUsage(output string) (v1alpha2.DiskUsageStatus, error) {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	if len(lines) < 2 {
+		return v1alpha2.DiskUsageStatus{}, errors.Errorf("unexpected df output '%s'", output)
+	}
+	// Filesystem 1024-blocks Used Available Capacity Mounted on
+	fields := strings.Fields(lines[len(lines)-1])
+	if len(fields) < 6 {
+		return v1alpha2.DiskUsageStatus{}, errors.Errorf("unexpected df output '%s'", output)
+	}
+	used, err := strconv.ParseInt(fields[2], 10, 64)
+	if err != nil {
+		return v1alpha2.DiskUsageStatus{}, errors.Wrapf(err, "unexpected df output '%s'", output)
+	}
+	available, err := strconv.ParseInt(fields[3], 10, 64)
+	if err != nil {
+		return v1alpha2.DiskUsageStatus{}, errors.Wrapf(err, "unexpected df output '%s'", output)
+	}
+
+	status := v1alpha2.DiskUsageStatus{
+		UsedBytes:     used * dfBlockSize,
+		CapacityBytes: (used + available) * dfBlockSize,
+	}
+	if status.CapacityBytes > 0 {
+		// rounded up like the capacity reported by df
+		status.UsedPercent = int32((status.UsedBytes*100 + status.CapacityBytes - 1) / status.CapacityBytes)
+	}
+	return status, nil
+}
+
+// SelectPolicies returns cleanup policies which threshold has been reached
+func SelectPolicies(settings v1alpha2.DiskUsage, usedPercent int32) []v1alpha2.DiskCleanupPolicy {
+	var selected []v1alpha2.DiskCleanupPolicy
+	for _, policy := range settings.CleanupPolicies {
+		if usedPercent >= policy.Threshold {
+			selected = append(selected, policy)
+		}
+	}
+	return selected
+}
+
+// RenderCleanupScript renders groovy script which runs the cleanup policy in Jenkins
+func RenderCleanupScript(policy v1alpha2.DiskCleanupPolicy) (string, error) {
+	policyJSON, err := json.Marshal(policy)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	return fmt.Sprintf(cleanupGroovyScriptFmt, base64.StdEncoding.EncodeToString(policyJSON)), nil
+}
+
+const cleanupGroovyScriptFmt = `
+import groovy.json.JsonSlurper
+import hudson.FilePath
+import hudson.model.Job
+import jenkins.model.Jenkins
+
+def policy = new JsonSlurper().parseText(new String('%s'.decodeBase64(), 'UTF-8'))
+def jenkins = Jenkins.get()
+
+if (policy.discardOldBuilds) {
+  def keepBuilds = policy.discardOldBuilds.keepBuilds ?: 0
+  def keepDays = policy.discardOldBuilds.keepDays ?: 0
+  def cutoff = System.currentTimeMillis() - keepDays * 24L * 60 * 60 * 1000
+  jenkins.getAllItems(Job).each { job ->
+    // builds are sorted from the newest one
+    job.getBuilds().toList().eachWithIndex { build, index ->
+      if (index < keepBuilds || build.isBuilding() || build.isKeepLog()) {
+        return
+      }
+      if (keepDays > 0 && build.getTimeInMillis() >= cutoff) {
+        return
+      }
+      build.delete()
+    }
+  }
+}
+
+if (policy.wipeWorkspaces) {
+  jenkins.getAllItems(Job).findAll { it instanceof hudson.model.TopLevelItem && !it.isBuilding() }.each { job ->
+    def workspace = jenkins.getWorkspaceFor(job)
+    if (workspace != null && workspace.exists()) {
+      workspace.deleteRecursive()
+    }
+  }
+}
+
+(policy.cachePaths ?: []).each { path ->
+  def cache = new FilePath(jenkins.getRootPath(), path)
+  if (cache.exists()) {
+    cache.deleteContents()
+  }
+}
+`
@@ -0,0 +1,62 @@
+package diskusage
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDiskUsage(t *testing.T) {
+	t.Run("happy", func(t *testing.T) {
+		output := "Filesystem     1024-blocks    Used Available Capacity Mounted on\n" +
+			"/dev/sdb          10255636 8204508   2051128      80% /var/lib/jenkins\n"
+
+		got, err := ParseDiskUsage(output)
+
+		require.NoError(t, err)
+		assert.Equal(t, v1alpha2.DiskUsageStatus{
+			UsedBytes:     8204508 * 1024,
+			CapacityBytes: 10255636 * 1024,
+			UsedPercent:   80,
+		}, got)
+	})
+	t.Run("long filesystem name", func(t *testing.T) {
+		output := "Filesystem 1024-blocks Used Available Capacity Mounted on\n" +
+			"overlay 1000 250 750 25% /var/lib/jenkins\n"
+
+		got, err := ParseDiskUsage(output)
+
+		require.NoError(t, err)
+		assert.Equal(t, int32(25), got.UsedPercent)
+	})
+	t.Run("invalid output", func(t *testing.T) {
+		_, err := ParseDiskUsage("df: /var/lib/jenkins: No such file or directory")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestSelectPolicies(t *testing.T) {
+	builds := v1alpha2.DiskCleanupPolicy{Name: "builds", Threshold: 85, DiscardOldBuilds: &v1alpha2.DiscardOldBuilds{KeepBuilds: 10}}
+	workspaces := v1alpha2.DiskCleanupPolicy{Name: "workspaces", Threshold: 90, WipeWorkspaces: true}
+	settings := v1alpha2.DiskUsage{CleanupPolicies: []v1alpha2.DiskCleanupPolicy{builds, workspaces}}
+
+	assert.Empty(t, SelectPolicies(settings, 84))
+	assert.Equal(t, []v1alpha2.DiskCleanupPolicy{builds}, SelectPolicies(settings, 85))
+	assert.Equal(t, []v1alpha2.DiskCleanupPolicy{builds, workspaces}, SelectPolicies(settings, 95))
+}
+
+func TestRenderCleanupScript(t *testing.T) {
+	policy := v1alpha2.DiskCleanupPolicy{Name: "caches", Threshold: 90, CachePaths: []string{"caches"}}
+
+	script, err := RenderCleanupScript(policy)
+
+	require.NoError(t, err)
+	encoded := base64.StdEncoding.EncodeToString([]byte(`{"name":"caches","threshold":90,"cachePaths":["caches"]}`))
+	assert.True(t, strings.Contains(script, encoded))
+}
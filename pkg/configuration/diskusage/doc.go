@@ -0,0 +1,2 @@
+// Package diskusage is responsible for monitoring of Jenkins home disk usage and running cleanup policies
+package diskusage
@@ -0,0 +1,2 @@
+// Package health is responsible for assessing if Jenkins is ready to use
+package health
@@ -0,0 +1,147 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	jenkinsclient "github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/user/casc"
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+
+	"github.com/bndr/gojenkins"
+	"github.com/go-logr/logr"
+	stackerr "github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// ReasonHealthy means that all health checks passed
+	ReasonHealthy = "HealthChecksPassed"
+	// ReasonAPIUnreachable means that Jenkins API is not reachable with the operator credentials
+	ReasonAPIUnreachable = "APIUnreachable"
+	// ReasonPluginsNotActive means that some of required plugins are missing, failed to load or are disabled
+	ReasonPluginsNotActive = "PluginsNotActive"
+	// ReasonConfigurationAsCodeNotApplied means that some of Configuration as Code files haven't been applied yet
+	ReasonConfigurationAsCodeNotApplied = "ConfigurationAsCodeNotApplied"
+	// ReasonGroovyScriptExecutionFailed means that Configuration as Code or groovy script execution failed
+	ReasonGroovyScriptExecutionFailed = "GroovyScriptExecutionFailed"
+
+	fetchAllPlugins = 1
+)
+
+// Assessment is the result of Jenkins health check
+type Assessment struct {
+	Healthy  bool
+	Reason   string
+	Messages []string
+}
+
+// Checker defines API client for assessing Jenkins health
+type Checker interface {
+	Check() (Assessment, error)
+}
+
+type checker struct {
+	configuration.Configuration
+	jenkinsClient jenkinsclient.Jenkins
+	logger        logr.Logger
+}
+
+// New creates Jenkins health checker
+func New(config configuration.Configuration, jenkinsClient jenkinsclient.Jenkins) Checker {
+	return &checker{
+		Configuration: config,
+		jenkinsClient: jenkinsClient,
+		logger:        log.ForJenkins(config.Jenkins),
+	}
+}
+
+// Check verifies that Jenkins API is reachable with the operator credentials, all required plugins are active
+// and Configuration as Code has been applied
+func (c *checker) Check() (Assessment, error) {
+	allPluginsInJenkins, err := c.jenkinsClient.GetPlugins(fetchAllPlugins)
+	if err != nil {
+		return Assessment{
+			Reason:   ReasonAPIUnreachable,
+			Messages: []string{fmt.Sprintf("Jenkins API is not reachable with the operator credentials: %s", err)},
+		}, nil
+	}
+
+	if messages := c.checkPlugins(allPluginsInJenkins); len(messages) > 0 {
+		return Assessment{Reason: ReasonPluginsNotActive, Messages: messages}, nil
+	}
+
+	messages, err := c.checkConfigurationAsCode()
+	if err != nil {
+		return Assessment{}, err
+	}
+	if len(messages) > 0 {
+		return Assessment{Reason: ReasonConfigurationAsCodeNotApplied, Messages: messages}, nil
+	}
+
+	return Assessment{Healthy: true, Reason: ReasonHealthy, Messages: []string{"Jenkins is ready"}}, nil
+}
+
+func (c *checker) checkPlugins(allPluginsInJenkins *gojenkins.Plugins) []string {
+	var messages []string
+	allRequiredPlugins := [][]v1alpha2.Plugin{c.Configuration.Jenkins.Spec.Master.BasePlugins, c.Configuration.Jenkins.Spec.Master.Plugins}
+	for _, requiredPlugins := range allRequiredPlugins {
+		for _, plugin := range requiredPlugins {
+			p := allPluginsInJenkins.Contains(plugin.Name)
+			switch {
+			case p == nil:
+				messages = append(messages, fmt.Sprintf("Plugin '%s' is not installed", plugin.Name))
+			case p.Deleted:
+				messages = append(messages, fmt.Sprintf("Plugin '%s' is deleted", plugin.Name))
+			case !p.Enabled:
+				messages = append(messages, fmt.Sprintf("Plugin '%s' is disabled", plugin.Name))
+			case !p.Active:
+				messages = append(messages, fmt.Sprintf("Plugin '%s' is not active, it probably failed to load", plugin.Name))
+			}
+		}
+	}
+
+	return messages
+}
+
+func (c *checker) checkConfigurationAsCode() ([]string, error) {
+	var messages []string
+	for _, configMapRef := range c.Configuration.Jenkins.Spec.ConfigurationAsCode.Configurations {
+		configMap := &corev1.ConfigMap{}
+		err := c.Client.Get(context.TODO(), types.NamespacedName{Name: configMapRef.Name, Namespace: c.Configuration.Jenkins.Namespace}, configMap)
+		if err != nil {
+			return nil, stackerr.WithStack(err)
+		}
+
+		var names []string
+		for name := range configMap.Data {
+			if casc.IsConfigurationAsCodeFile(name) {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			if !c.isApplied(configMap.Name, name) {
+				messages = append(messages, fmt.Sprintf("Configuration as Code ConfigMap '%s' name '%s' hasn't been applied", configMap.Name, name))
+			}
+		}
+	}
+
+	return messages, nil
+}
+
+func (c *checker) isApplied(source, name string) bool {
+	for _, appliedGroovyScript := range c.Configuration.Jenkins.Status.AppliedGroovyScripts {
+		if appliedGroovyScript.ConfigurationType == casc.ConfigurationType &&
+			appliedGroovyScript.Source == source && appliedGroovyScript.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,126 @@
+package health
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/user/casc"
+
+	"github.com/bndr/gojenkins"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestChecker_Check(t *testing.T) {
+	const namespace = "default"
+	newJenkins := func() *v1alpha2.Jenkins {
+		return &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: namespace},
+			Spec: v1alpha2.JenkinsSpec{
+				Master: v1alpha2.JenkinsMaster{
+					BasePlugins: []v1alpha2.Plugin{{Name: "kubernetes", Version: "1.0.0"}},
+					Plugins:     []v1alpha2.Plugin{{Name: "git", Version: "1.0.0"}},
+				},
+				ConfigurationAsCode: v1alpha2.ConfigurationAsCode{Customization: v1alpha2.Customization{
+					Configurations: []v1alpha2.ConfigMapRef{{Name: "casc"}},
+				}},
+			},
+		}
+	}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "casc", Namespace: namespace},
+		Data: map[string]string{
+			"1-system-message.yaml": "jenkins:\n  systemMessage: hello",
+			"README.md":             "not a casc file",
+		},
+	}
+	activePlugins := &gojenkins.Plugins{Raw: &gojenkins.PluginResponse{Plugins: []gojenkins.Plugin{
+		{ShortName: "kubernetes", Version: "1.0.0", Active: true, Enabled: true},
+		{ShortName: "git", Version: "1.0.0", Active: true, Enabled: true},
+	}}}
+
+	t.Run("healthy", func(t *testing.T) {
+		jenkins := newJenkins()
+		jenkins.Status.AppliedGroovyScripts = []v1alpha2.AppliedGroovyScript{
+			{ConfigurationType: casc.ConfigurationType, Source: "casc", Name: "1-system-message.yaml", Hash: "hash"},
+		}
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		jenkinsClient := client.NewMockJenkins(ctrl)
+		jenkinsClient.EXPECT().GetPlugins(fetchAllPlugins).Return(activePlugins, nil)
+		checker := New(configuration.Configuration{
+			Client:  fake.NewClientBuilder().WithObjects(configMap).Build(),
+			Jenkins: jenkins,
+		}, jenkinsClient)
+
+		got, err := checker.Check()
+
+		assert.NoError(t, err)
+		assert.Equal(t, Assessment{Healthy: true, Reason: ReasonHealthy, Messages: []string{"Jenkins is ready"}}, got)
+	})
+	t.Run("API unreachable", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		jenkinsClient := client.NewMockJenkins(ctrl)
+		jenkinsClient.EXPECT().GetPlugins(fetchAllPlugins).Return(nil, errors.New("401 Unauthorized"))
+		checker := New(configuration.Configuration{
+			Client:  fake.NewClientBuilder().Build(),
+			Jenkins: newJenkins(),
+		}, jenkinsClient)
+
+		got, err := checker.Check()
+
+		assert.NoError(t, err)
+		assert.Equal(t, Assessment{
+			Reason:   ReasonAPIUnreachable,
+			Messages: []string{"Jenkins API is not reachable with the operator credentials: 401 Unauthorized"},
+		}, got)
+	})
+	t.Run("plugins not active", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		jenkinsClient := client.NewMockJenkins(ctrl)
+		jenkinsClient.EXPECT().GetPlugins(fetchAllPlugins).Return(&gojenkins.Plugins{Raw: &gojenkins.PluginResponse{Plugins: []gojenkins.Plugin{
+			{ShortName: "kubernetes", Version: "1.0.0", Active: false, Enabled: true},
+		}}}, nil)
+		checker := New(configuration.Configuration{
+			Client:  fake.NewClientBuilder().Build(),
+			Jenkins: newJenkins(),
+		}, jenkinsClient)
+
+		got, err := checker.Check()
+
+		assert.NoError(t, err)
+		assert.Equal(t, Assessment{
+			Reason: ReasonPluginsNotActive,
+			Messages: []string{
+				"Plugin 'kubernetes' is not active, it probably failed to load",
+				"Plugin 'git' is not installed",
+			},
+		}, got)
+	})
+	t.Run("configuration as code not applied", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		jenkinsClient := client.NewMockJenkins(ctrl)
+		jenkinsClient.EXPECT().GetPlugins(fetchAllPlugins).Return(activePlugins, nil)
+		checker := New(configuration.Configuration{
+			Client:  fake.NewClientBuilder().WithObjects(configMap).Build(),
+			Jenkins: newJenkins(),
+		}, jenkinsClient)
+
+		got, err := checker.Check()
+
+		assert.NoError(t, err)
+		assert.Equal(t, Assessment{
+			Reason:   ReasonConfigurationAsCodeNotApplied,
+			Messages: []string{"Configuration as Code ConfigMap 'casc' name '1-system-message.yaml' hasn't been applied"},
+		}, got)
+	})
+}
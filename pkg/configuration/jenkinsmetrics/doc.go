@@ -0,0 +1,2 @@
+// Package jenkinsmetrics scrapes queue, executor, node and build metrics of Jenkins according to spec.monitoring.exporter
+package jenkinsmetrics
@@ -0,0 +1,127 @@
+package jenkinsmetrics
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultScrapeInterval = uint64(60)
+	scrapePrefix          = "jenkins-metrics:"
+)
+
+// Build is the build completed since the previous scrape
+type Build struct {
+	// Result is the result of the build, e.g. SUCCESS or FAILURE
+	Result string `json:"result"`
+	// DurationMillis is the duration of the build in milliseconds
+	DurationMillis int64 `json:"duration"`
+}
+
+// Snapshot contains metrics scraped from Jenkins
+type Snapshot struct {
+	// TimeMillis is the time of the scrape by Jenkins clock, the next scrape reports builds completed after it
+	TimeMillis     int64   `json:"time"`
+	QueueLength    int     `json:"queueLength"`
+	QueueBuildable int     `json:"queueBuildable"`
+	QueueStuck     int     `json:"queueStuck"`
+	Executors      int     `json:"executors"`
+	BusyExecutors  int     `json:"busyExecutors"`
+	NodesOnline    int     `json:"nodesOnline"`
+	NodesOffline   int     `json:"nodesOffline"`
+	Builds         []Build `json:"builds"`
+}
+
+// GetScrapeInterval returns the interval of metrics scrapes
+func GetScrapeInterval(settings v1alpha2.MetricsExporter) time.Duration {
+	interval := settings.ScrapeInterval
+	if interval == 0 {
+		interval = defaultScrapeInterval
+	}
+	return time.Duration(interval) * time.Second
+}
+
+// RenderScrapeScript renders groovy script which prints metrics of Jenkins, builds completed after sinceMillis
+// are reported, zero skips the builds
+func RenderScrapeScript(sinceMillis int64) string {
+	return fmt.Sprintf(scrapeGroovyScriptFmt, sinceMillis, scrapePrefix)
+}
+
+// ParseScrape parses the output of the scrape script
+func ParseScrape(logs string) (Snapshot, error) {
+	for _, line := range strings.Split(logs, "\n") {
+		if !strings.HasPrefix(line, scrapePrefix) {
+			continue
+		}
+		snapshot := Snapshot{}
+		if err := json.Unmarshal([]byte(strings.TrimPrefix(line, scrapePrefix)), &snapshot); err != nil {
+			return Snapshot{}, errors.Wrapf(err, "unexpected metrics scrape output '%s'", line)
+		}
+		return snapshot, nil
+	}
+	return Snapshot{}, errors.Errorf("metrics not found in the scrape output '%s'", logs)
+}
+
+// builds are sorted from the newest one, older builds than the scrape window extended by a day aren't checked
+// so long running builds are reported too
+const scrapeGroovyScriptFmt = `
+import groovy.json.JsonOutput
+import hudson.model.Job
+import jenkins.model.Jenkins
+
+def jenkins = Jenkins.get()
+def since = %dL
+def now = System.currentTimeMillis()
+
+def queue = jenkins.getQueue().getItems()
+def executors = 0
+def busyExecutors = 0
+def nodesOnline = 0
+def nodesOffline = 0
+jenkins.getComputers().each { computer ->
+  if (computer.isOffline()) {
+    nodesOffline++
+    return
+  }
+  nodesOnline++
+  executors += computer.countExecutors()
+  busyExecutors += computer.countBusy()
+}
+
+def builds = []
+if (since > 0) {
+  def oldest = since - 24L * 60 * 60 * 1000
+  jenkins.getAllItems(Job).each { job ->
+    for (build in job.getBuilds()) {
+      if (build.getStartTimeInMillis() < oldest) {
+        break
+      }
+      if (build.isBuilding()) {
+        continue
+      }
+      def finished = build.getStartTimeInMillis() + build.getDuration()
+      if (finished > since && finished <= now) {
+        builds << [result: build.getResult()?.toString() ?: 'NOT_BUILT', duration: build.getDuration()]
+      }
+    }
+  }
+}
+
+println('%s' + JsonOutput.toJson([
+  time          : now,
+  queueLength   : queue.length,
+  queueBuildable: queue.count { it.isBuildable() },
+  queueStuck    : queue.count { it.isStuck() },
+  executors     : executors,
+  busyExecutors : busyExecutors,
+  nodesOnline   : nodesOnline,
+  nodesOffline  : nodesOffline,
+  builds        : builds,
+]))
+`
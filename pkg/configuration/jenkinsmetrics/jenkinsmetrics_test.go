@@ -0,0 +1,49 @@
+package jenkinsmetrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseScrape(t *testing.T) {
+	t.Run("happy", func(t *testing.T) {
+		logs := "Started\n" +
+			`jenkins-metrics:{"time":1600000000000,"queueLength":5,"queueBuildable":3,"queueStuck":1,"executors":8,` +
+			`"busyExecutors":6,"nodesOnline":3,"nodesOffline":1,"builds":[{"result":"SUCCESS","duration":120000}]}` + "\n"
+
+		got, err := ParseScrape(logs)
+
+		require.NoError(t, err)
+		assert.Equal(t, Snapshot{
+			TimeMillis:     1600000000000,
+			QueueLength:    5,
+			QueueBuildable: 3,
+			QueueStuck:     1,
+			Executors:      8,
+			BusyExecutors:  6,
+			NodesOnline:    3,
+			NodesOffline:   1,
+			Builds:         []Build{{Result: "SUCCESS", DurationMillis: 120000}},
+		}, got)
+	})
+	t.Run("metrics not found", func(t *testing.T) {
+		_, err := ParseScrape("java.lang.NullPointerException")
+
+		assert.Error(t, err)
+	})
+	t.Run("invalid json", func(t *testing.T) {
+		_, err := ParseScrape("jenkins-metrics:{")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestGetScrapeInterval(t *testing.T) {
+	assert.Equal(t, time.Minute, GetScrapeInterval(v1alpha2.MetricsExporter{}))
+	assert.Equal(t, 15*time.Second, GetScrapeInterval(v1alpha2.MetricsExporter{ScrapeInterval: 15}))
+}
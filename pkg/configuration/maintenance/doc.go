@@ -0,0 +1,2 @@
+// Package maintenance decides if disruptive actions can run now from the maintenance windows of Jenkins CR
+package maintenance
@@ -0,0 +1,82 @@
+package maintenance
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	"github.com/pkg/errors"
+	"github.com/robfig/cron"
+)
+
+// Window is the parsed maintenance window.
+type Window struct {
+	schedule cron.Schedule
+	duration time.Duration
+	location *time.Location
+}
+
+// Parse parses the maintenance window, the schedule is evaluated in its time zone
+func Parse(window v1alpha2.MaintenanceWindow) (Window, error) {
+	schedule, err := cron.ParseStandard(window.Schedule)
+	if err != nil {
+		return Window{}, errors.Wrapf(err, "invalid schedule '%s'", window.Schedule)
+	}
+	location := time.UTC
+	if len(window.TimeZone) > 0 {
+		if location, err = time.LoadLocation(window.TimeZone); err != nil {
+			return Window{}, errors.Wrapf(err, "invalid time zone '%s'", window.TimeZone)
+		}
+	}
+	if window.Duration.Duration <= 0 {
+		return Window{}, errors.Errorf("duration must be positive, got '%s'", window.Duration.Duration)
+	}
+	return Window{schedule: schedule, duration: window.Duration.Duration, location: location}, nil
+}
+
+// Active returns true when now is between the start of the window and the end of its duration
+func (w Window) Active(now time.Time) bool {
+	// the first start after now minus duration is the start of the window now is in, if there's any
+	start := w.schedule.Next(now.In(w.location).Add(-w.duration))
+	return !start.After(now)
+}
+
+// Next returns the start of the next window after now
+func (w Window) Next(now time.Time) time.Time {
+	return w.schedule.Next(now.In(w.location))
+}
+
+// Check returns true when disruptive actions can run now, they can always run without windows. Otherwise the start
+// of the nearest next window is returned
+func Check(windows []v1alpha2.MaintenanceWindow, now time.Time) (bool, time.Time, error) {
+	if len(windows) == 0 {
+		return true, time.Time{}, nil
+	}
+
+	var next time.Time
+	for _, spec := range windows {
+		window, err := Parse(spec)
+		if err != nil {
+			return false, time.Time{}, err
+		}
+		if window.Active(now) {
+			return true, time.Time{}, nil
+		}
+		if start := window.Next(now); next.IsZero() || start.Before(next) {
+			next = start
+		}
+	}
+	return false, next, nil
+}
+
+// Validate validates spec.maintenanceWindows
+func Validate(windows []v1alpha2.MaintenanceWindow) []string {
+	var messages []string
+	for i, window := range windows {
+		if _, err := Parse(window); err != nil {
+			messages = append(messages, fmt.Sprintf("spec.maintenanceWindows[%d]: %s", i, err))
+		}
+	}
+	return messages
+}
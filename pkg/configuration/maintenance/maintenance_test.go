@@ -0,0 +1,95 @@
+package maintenance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func window(schedule string, duration time.Duration, timeZone string) v1alpha2.MaintenanceWindow {
+	return v1alpha2.MaintenanceWindow{Schedule: schedule, Duration: metav1.Duration{Duration: duration}, TimeZone: timeZone}
+}
+
+func TestCheck(t *testing.T) {
+	// Saturday
+	saturday := time.Date(2021, 3, 6, 0, 0, 0, 0, time.UTC)
+	windows := []v1alpha2.MaintenanceWindow{
+		window("0 2 * * 6", 2*time.Hour, ""),
+		window("0 22 * * 3", time.Hour, ""),
+	}
+
+	t.Run("without windows", func(t *testing.T) {
+		active, _, err := Check(nil, saturday)
+
+		require.NoError(t, err)
+		assert.True(t, active)
+	})
+	t.Run("in window", func(t *testing.T) {
+		for _, now := range []time.Time{saturday.Add(2 * time.Hour), saturday.Add(3*time.Hour + 59*time.Minute)} {
+			active, _, err := Check(windows, now)
+
+			require.NoError(t, err)
+			assert.True(t, active, now.String())
+		}
+	})
+	t.Run("before window", func(t *testing.T) {
+		active, next, err := Check(windows, saturday.Add(time.Hour))
+
+		require.NoError(t, err)
+		assert.False(t, active)
+		assert.True(t, next.Equal(saturday.Add(2*time.Hour)), next.String())
+	})
+	t.Run("after window", func(t *testing.T) {
+		active, next, err := Check(windows, saturday.Add(4*time.Hour))
+
+		require.NoError(t, err)
+		assert.False(t, active)
+		// Wednesday 22:00
+		assert.True(t, next.Equal(time.Date(2021, 3, 10, 22, 0, 0, 0, time.UTC)), next.String())
+	})
+	t.Run("time zone", func(t *testing.T) {
+		windows := []v1alpha2.MaintenanceWindow{window("0 2 * * 6", time.Hour, "Europe/Warsaw")}
+
+		active, _, err := Check(windows, saturday.Add(time.Hour+30*time.Minute))
+		require.NoError(t, err)
+		assert.True(t, active)
+
+		active, next, err := Check(windows, saturday.Add(2*time.Hour+30*time.Minute))
+		require.NoError(t, err)
+		assert.False(t, active)
+		assert.True(t, next.Equal(time.Date(2021, 3, 13, 1, 0, 0, 0, time.UTC)), next.String())
+	})
+	t.Run("window longer than the period", func(t *testing.T) {
+		windows := []v1alpha2.MaintenanceWindow{window("0 0 * * *", 25*time.Hour, "")}
+
+		active, _, err := Check(windows, saturday.Add(-30*time.Minute))
+
+		require.NoError(t, err)
+		assert.True(t, active)
+	})
+}
+
+func TestValidate(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		messages := Validate([]v1alpha2.MaintenanceWindow{window("0 2 * * 6", time.Hour, "Europe/Warsaw")})
+
+		assert.Empty(t, messages)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		messages := Validate([]v1alpha2.MaintenanceWindow{
+			window("0 2 * *", time.Hour, ""),
+			window("0 2 * * 6", time.Hour, "Mars/Olympus"),
+			window("0 2 * * 6", 0, ""),
+		})
+
+		require.Len(t, messages, 3)
+		assert.Contains(t, messages[0], "spec.maintenanceWindows[0]: invalid schedule '0 2 * *'")
+		assert.Contains(t, messages[1], "spec.maintenanceWindows[1]: invalid time zone 'Mars/Olympus'")
+		assert.Equal(t, "spec.maintenanceWindows[2]: duration must be positive, got '0s'", messages[2])
+	})
+}
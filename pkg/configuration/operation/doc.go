@@ -0,0 +1,2 @@
+// Package operation performs one-shot actions defined by JenkinsOperation CRs in Jenkins
+package operation
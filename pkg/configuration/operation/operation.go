@@ -0,0 +1,116 @@
+package operation
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/backup"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/backuprestore"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultTimeout is the default time in seconds to wait until Jenkins is ready and the action is completed
+	DefaultTimeout = uint64(15 * 60)
+	// MaxOutputLength is the maximum length of groovy script output kept in status.output
+	MaxOutputLength = 4096
+
+	// JVMStartTimeScript prints the start time of Jenkins JVM in milliseconds, it changes when Jenkins has been restarted
+	JVMStartTimeScript = "println(java.lang.management.ManagementFactory.getRuntimeMXBean().getStartTime())"
+
+	// verifierPrefix is the prefix of the line appended to the output of groovy scripts by Jenkins client
+	verifierPrefix = "verifier-"
+)
+
+// GetTimeout returns how long the operator waits until Jenkins is ready and the action is completed
+func GetTimeout(operation *v1alpha2.JenkinsOperation) time.Duration {
+	timeout := operation.Spec.Timeout
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+	return time.Duration(timeout) * time.Second
+}
+
+// IsFinished returns true if the action has succeeded or failed
+func IsFinished(operation *v1alpha2.JenkinsOperation) bool {
+	return operation.Status.Phase == v1alpha2.OperationPhaseSucceeded || operation.Status.Phase == v1alpha2.OperationPhaseFailed
+}
+
+// IsTimedOut returns true if the action hasn't been completed within spec.timeout
+func IsTimedOut(operation *v1alpha2.JenkinsOperation, now time.Time) bool {
+	if operation.Status.StartTime == nil {
+		return false
+	}
+	return now.Sub(operation.Status.StartTime.Time) > GetTimeout(operation)
+}
+
+// IsExpired returns true if the finished JenkinsOperation CR should be deleted because of spec.ttlSecondsAfterFinished,
+// otherwise it returns the time after which it expires or zero if it never expires
+func IsExpired(operation *v1alpha2.JenkinsOperation, now time.Time) (bool, time.Duration) {
+	if operation.Spec.TTLSecondsAfterFinished == nil || operation.Status.CompletionTime == nil {
+		return false, 0
+	}
+	expiresAt := operation.Status.CompletionTime.Add(time.Duration(*operation.Spec.TTLSecondsAfterFinished) * time.Second)
+	if !now.Before(expiresAt) {
+		return true, 0
+	}
+	return false, expiresAt.Sub(now)
+}
+
+// Validate checks if the action can be performed in Jenkins
+func Validate(operation *v1alpha2.JenkinsOperation, jenkins *v1alpha2.Jenkins) []string {
+	var messages []string
+	switch operation.Spec.Action {
+	case v1alpha2.BackupOperationAction:
+		if !backup.IsBackupConfigured(jenkins) {
+			messages = append(messages, fmt.Sprintf("Backup is not configured in Jenkins CR '%s'", jenkins.Name))
+		} else if backuprestore.AreBackupsDisabled(jenkins) {
+			messages = append(messages, fmt.Sprintf("Backups are disabled by '%s' label in Jenkins CR '%s'", backuprestore.BackupsDisabledLabelKey, jenkins.Name))
+		}
+	case v1alpha2.ReloadCascOperationAction:
+		if len(jenkins.Spec.ConfigurationAsCode.Configurations) == 0 {
+			messages = append(messages, fmt.Sprintf("Jenkins CR '%s' has no spec.configurationAsCode.configurations", jenkins.Name))
+		}
+	case v1alpha2.RerunSeedJobsOperationAction:
+		if len(jenkins.Spec.SeedJobs) == 0 {
+			messages = append(messages, fmt.Sprintf("Jenkins CR '%s' has no seed jobs", jenkins.Name))
+		}
+	case v1alpha2.GroovyOperationAction:
+		if len(strings.TrimSpace(operation.Spec.Groovy)) == 0 {
+			messages = append(messages, "spec.groovy is required by Groovy action")
+		}
+	case v1alpha2.SafeRestartOperationAction:
+	default:
+		messages = append(messages, fmt.Sprintf("Unsupported spec.action '%s'", operation.Spec.Action))
+	}
+	if operation.Spec.Action != v1alpha2.GroovyOperationAction && len(operation.Spec.Groovy) > 0 {
+		messages = append(messages, fmt.Sprintf("spec.groovy is allowed only with Groovy action, got '%s'", operation.Spec.Action))
+	}
+	return messages
+}
+
+// ScriptOutput returns the output of groovy script without the verifier line, truncated to MaxOutputLength
+func ScriptOutput(output string) string {
+	// the verifier line is the last one
+	if index := strings.LastIndex(output, verifierPrefix); index >= 0 {
+		output = output[:index]
+	}
+	output = strings.TrimRight(output, "\n")
+	if len(output) > MaxOutputLength {
+		output = output[:MaxOutputLength]
+	}
+	return output
+}
+
+// ParseJVMStartTime parses the output of JVMStartTimeScript
+func ParseJVMStartTime(output string) (time.Time, error) {
+	milliseconds, err := strconv.ParseInt(strings.TrimSpace(ScriptOutput(output)), 10, 64)
+	if err != nil {
+		return time.Time{}, errors.Wrapf(err, "invalid JVM start time '%s'", output)
+	}
+	return time.Unix(0, milliseconds*int64(time.Millisecond)), nil
+}
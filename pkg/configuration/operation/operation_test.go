@@ -0,0 +1,127 @@
+package operation
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/backuprestore"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestIsExpired(t *testing.T) {
+	now := time.Now()
+	ttl := int32(60)
+
+	t.Run("without TTL", func(t *testing.T) {
+		completedAt := metav1.NewTime(now.Add(-time.Hour))
+		operation := &v1alpha2.JenkinsOperation{Status: v1alpha2.JenkinsOperationStatus{CompletionTime: &completedAt}}
+
+		expired, after := IsExpired(operation, now)
+
+		assert.False(t, expired)
+		assert.Equal(t, time.Duration(0), after)
+	})
+	t.Run("not finished", func(t *testing.T) {
+		operation := &v1alpha2.JenkinsOperation{Spec: v1alpha2.JenkinsOperationSpec{TTLSecondsAfterFinished: &ttl}}
+
+		expired, _ := IsExpired(operation, now)
+
+		assert.False(t, expired)
+	})
+	t.Run("expires later", func(t *testing.T) {
+		completedAt := metav1.NewTime(now.Add(-20 * time.Second))
+		operation := &v1alpha2.JenkinsOperation{
+			Spec:   v1alpha2.JenkinsOperationSpec{TTLSecondsAfterFinished: &ttl},
+			Status: v1alpha2.JenkinsOperationStatus{CompletionTime: &completedAt},
+		}
+
+		expired, after := IsExpired(operation, now)
+
+		assert.False(t, expired)
+		assert.Equal(t, 40*time.Second, after)
+	})
+	t.Run("expired", func(t *testing.T) {
+		completedAt := metav1.NewTime(now.Add(-time.Minute))
+		operation := &v1alpha2.JenkinsOperation{
+			Spec:   v1alpha2.JenkinsOperationSpec{TTLSecondsAfterFinished: &ttl},
+			Status: v1alpha2.JenkinsOperationStatus{CompletionTime: &completedAt},
+		}
+
+		expired, _ := IsExpired(operation, now)
+
+		assert.True(t, expired)
+	})
+}
+
+func TestIsTimedOut(t *testing.T) {
+	now := time.Now()
+	startedAt := metav1.NewTime(now.Add(-10 * time.Minute))
+
+	assert.False(t, IsTimedOut(&v1alpha2.JenkinsOperation{}, now))
+	assert.False(t, IsTimedOut(&v1alpha2.JenkinsOperation{Status: v1alpha2.JenkinsOperationStatus{StartTime: &startedAt}}, now))
+	assert.True(t, IsTimedOut(&v1alpha2.JenkinsOperation{
+		Spec:   v1alpha2.JenkinsOperationSpec{Timeout: 300},
+		Status: v1alpha2.JenkinsOperationStatus{StartTime: &startedAt},
+	}, now))
+}
+
+func TestValidate(t *testing.T) {
+	jenkins := &v1alpha2.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{Name: "jenkins"},
+		Spec: v1alpha2.JenkinsSpec{
+			Backup: v1alpha2.Backup{
+				ContainerName: "backup",
+				Action:        v1alpha2.Handler{Exec: &corev1.ExecAction{Command: []string{"backup.sh"}}},
+			},
+			SeedJobs: []v1alpha2.SeedJob{{ID: "jenkins-operator"}},
+		},
+	}
+	operation := func(action v1alpha2.OperationAction, groovy string) *v1alpha2.JenkinsOperation {
+		return &v1alpha2.JenkinsOperation{Spec: v1alpha2.JenkinsOperationSpec{Action: action, Groovy: groovy}}
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		assert.Empty(t, Validate(operation(v1alpha2.BackupOperationAction, ""), jenkins))
+		assert.Empty(t, Validate(operation(v1alpha2.SafeRestartOperationAction, ""), jenkins))
+		assert.Empty(t, Validate(operation(v1alpha2.RerunSeedJobsOperationAction, ""), jenkins))
+		assert.Empty(t, Validate(operation(v1alpha2.GroovyOperationAction, "println 'hello'"), jenkins))
+	})
+	t.Run("backups disabled", func(t *testing.T) {
+		disabled := jenkins.DeepCopy()
+		disabled.Labels = map[string]string{backuprestore.BackupsDisabledLabelKey: "true"}
+
+		assert.Equal(t, []string{"Backups are disabled by 'jenkins.io/backups-disabled' label in Jenkins CR 'jenkins'"},
+			Validate(operation(v1alpha2.BackupOperationAction, ""), disabled))
+	})
+	t.Run("no configuration as code", func(t *testing.T) {
+		assert.Equal(t, []string{"Jenkins CR 'jenkins' has no spec.configurationAsCode.configurations"},
+			Validate(operation(v1alpha2.ReloadCascOperationAction, ""), jenkins))
+	})
+	t.Run("groovy", func(t *testing.T) {
+		assert.Equal(t, []string{"spec.groovy is required by Groovy action"}, Validate(operation(v1alpha2.GroovyOperationAction, " "), jenkins))
+		assert.Equal(t, []string{"spec.groovy is allowed only with Groovy action, got 'SafeRestart'"},
+			Validate(operation(v1alpha2.SafeRestartOperationAction, "println 'hello'"), jenkins))
+	})
+}
+
+func TestScriptOutput(t *testing.T) {
+	assert.Equal(t, "hello\nworld", ScriptOutput("hello\nworld\nverifier-1639000000\nnull"))
+	assert.Equal(t, "", ScriptOutput("verifier-1639000000\n"))
+	assert.Len(t, ScriptOutput(strings.Repeat("a", MaxOutputLength+1)+"\nverifier-1639000000\n"), MaxOutputLength)
+}
+
+func TestParseJVMStartTime(t *testing.T) {
+	startTime, err := ParseJVMStartTime("1639000000123\nverifier-1639000100\n")
+
+	require.NoError(t, err)
+	assert.Equal(t, time.Unix(1639000000, 123*int64(time.Millisecond)), startTime)
+
+	_, err = ParseJVMStartTime("groovy.lang.MissingPropertyException")
+	assert.Error(t, err)
+}
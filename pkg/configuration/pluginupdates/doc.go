@@ -0,0 +1,2 @@
+// Package pluginupdates checks plugin updates in the update center and upgrades plugins automatically
+package pluginupdates
@@ -0,0 +1,149 @@
+package pluginupdates
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
+	"github.com/jenkinsci/kubernetes-operator/pkg/plugins"
+
+	"github.com/bndr/gojenkins"
+)
+
+const (
+	defaultCheckInterval   = uint64(86400)
+	defaultRollbackTimeout = uint64(900)
+)
+
+// GetUpdateCenterURL returns the URL of the update center JSON
+func GetUpdateCenterURL(settings v1alpha2.PluginUpdates) string {
+	if len(settings.UpdateCenterURL) > 0 {
+		return settings.UpdateCenterURL
+	}
+	return constants.DefaultUpdateCenterURL
+}
+
+// GetCheckInterval returns the interval of plugin update checks
+func GetCheckInterval(settings v1alpha2.PluginUpdates) time.Duration {
+	interval := settings.CheckInterval
+	if interval == 0 {
+		interval = defaultCheckInterval
+	}
+	return time.Duration(interval) * time.Second
+}
+
+// GetRollbackTimeout returns the time for Jenkins to become ready after the automatic upgrade
+func GetRollbackTimeout(settings v1alpha2.PluginUpdates) time.Duration {
+	timeout := settings.RollbackTimeout
+	if timeout == 0 {
+		timeout = defaultRollbackTimeout
+	}
+	return time.Duration(timeout) * time.Second
+}
+
+// AvailableUpgrades returns installed plugins which have newer version in the update center
+func AvailableUpgrades(installedPlugins *gojenkins.Plugins, updateCenter *plugins.UpdateCenter) []v1alpha2.PluginUpgrade {
+	var upgrades []v1alpha2.PluginUpgrade
+	for _, installed := range installedPlugins.Raw.Plugins {
+		if installed.Deleted {
+			continue
+		}
+		latest, ok := updateCenter.Plugins[installed.ShortName]
+		if !ok || plugins.CompareVersions(latest.Version, installed.Version) <= 0 {
+			continue
+		}
+
+		upgrades = append(upgrades, v1alpha2.PluginUpgrade{
+			Name:             installed.ShortName,
+			CurrentVersion:   installed.Version,
+			AvailableVersion: latest.Version,
+			Security: updateCenter.IsAffectedBySecurityWarning(installed.ShortName, installed.Version) &&
+				!updateCenter.IsAffectedBySecurityWarning(installed.ShortName, latest.Version),
+		})
+	}
+
+	sort.Slice(upgrades, func(i, j int) bool {
+		return upgrades[i].Name < upgrades[j].Name
+	})
+	return upgrades
+}
+
+// SelectUpgrades returns upgrades allowed by the policy, the upgrades rolled back by the last automatic upgrade are skipped
+func SelectUpgrades(policy v1alpha2.PluginUpgradePolicy, upgrades []v1alpha2.PluginUpgrade, lastUpgrade *v1alpha2.PluginAutoUpgrade) []v1alpha2.PluginUpgrade {
+	var selected []v1alpha2.PluginUpgrade
+	for _, upgrade := range upgrades {
+		if isRolledBack(upgrade, lastUpgrade) {
+			continue
+		}
+
+		switch policy {
+		case v1alpha2.PatchOnlyPluginUpgradePolicy:
+			if isPatchUpgrade(upgrade.CurrentVersion, upgrade.AvailableVersion) {
+				selected = append(selected, upgrade)
+			}
+		case v1alpha2.SecurityOnlyPluginUpgradePolicy:
+			if upgrade.Security {
+				selected = append(selected, upgrade)
+			}
+		}
+	}
+
+	return selected
+}
+
+func isRolledBack(upgrade v1alpha2.PluginUpgrade, lastUpgrade *v1alpha2.PluginAutoUpgrade) bool {
+	if lastUpgrade == nil || !lastUpgrade.RolledBack {
+		return false
+	}
+	for _, rolledBack := range lastUpgrade.Plugins {
+		if rolledBack.Name == upgrade.Name && rolledBack.AvailableVersion == upgrade.AvailableVersion {
+			return true
+		}
+	}
+	return false
+}
+
+// isPatchUpgrade returns true if major and minor versions are the same
+func isPatchUpgrade(currentVersion, availableVersion string) bool {
+	current := strings.Split(currentVersion, ".")
+	available := strings.Split(availableVersion, ".")
+	if len(current) < 2 || len(available) < 2 {
+		return false
+	}
+	return current[0] == available[0] && current[1] == available[1]
+}
+
+// Apply sets the available versions of upgraded plugins in spec.master.basePlugins and spec.master.plugins,
+// it returns applied upgrades, the plugins which aren't in the Jenkins CR are skipped
+func Apply(jenkins *v1alpha2.Jenkins, upgrades []v1alpha2.PluginUpgrade) []v1alpha2.PluginUpgrade {
+	var applied []v1alpha2.PluginUpgrade
+	for _, upgrade := range upgrades {
+		if setPluginVersion(jenkins, upgrade.Name, upgrade.CurrentVersion, upgrade.AvailableVersion) {
+			applied = append(applied, upgrade)
+		}
+	}
+	return applied
+}
+
+// Rollback sets the previous versions of upgraded plugins in spec.master.basePlugins and spec.master.plugins
+func Rollback(jenkins *v1alpha2.Jenkins, upgrades []v1alpha2.PluginUpgrade) {
+	for _, upgrade := range upgrades {
+		setPluginVersion(jenkins, upgrade.Name, upgrade.AvailableVersion, upgrade.CurrentVersion)
+	}
+}
+
+// setPluginVersion changes the plugin version if the plugin is in the Jenkins CR with the expected version
+func setPluginVersion(jenkins *v1alpha2.Jenkins, name, expectedVersion, version string) bool {
+	changed := false
+	for _, requiredPlugins := range [][]v1alpha2.Plugin{jenkins.Spec.Master.BasePlugins, jenkins.Spec.Master.Plugins} {
+		for i, plugin := range requiredPlugins {
+			if plugin.Name == name && plugin.Version == expectedVersion {
+				requiredPlugins[i].Version = version
+				changed = true
+			}
+		}
+	}
+	return changed
+}
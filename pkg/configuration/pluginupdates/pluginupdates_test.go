@@ -0,0 +1,103 @@
+package pluginupdates
+
+import (
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/plugins"
+
+	"github.com/bndr/gojenkins"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAvailableUpgrades(t *testing.T) {
+	installedPlugins := &gojenkins.Plugins{
+		Raw: &gojenkins.PluginResponse{
+			Plugins: []gojenkins.Plugin{
+				{ShortName: "kubernetes", Version: "1.30.1"},
+				{ShortName: "git", Version: "4.9.0"},
+				{ShortName: "up-to-date", Version: "1.0"},
+				{ShortName: "not-in-update-center", Version: "1.0"},
+				{ShortName: "deleted", Version: "1.0", Deleted: true},
+			},
+		},
+	}
+	updateCenter := &plugins.UpdateCenter{
+		Plugins: map[string]plugins.UpdateCenterPlugin{
+			"kubernetes": {Name: "kubernetes", Version: "1.30.5"},
+			"git":        {Name: "git", Version: "4.10.2"},
+			"up-to-date": {Name: "up-to-date", Version: "1.0"},
+			"deleted":    {Name: "deleted", Version: "2.0"},
+		},
+		Warnings: []plugins.UpdateCenterWarning{
+			{Type: "plugin", Name: "git", Versions: []plugins.UpdateCenterWarningVersion{{Pattern: `4[.]([0-9]|10[.]0)(|[.-].*)`}}},
+		},
+	}
+
+	got := AvailableUpgrades(installedPlugins, updateCenter)
+
+	assert.Equal(t, []v1alpha2.PluginUpgrade{
+		{Name: "git", CurrentVersion: "4.9.0", AvailableVersion: "4.10.2", Security: true},
+		{Name: "kubernetes", CurrentVersion: "1.30.1", AvailableVersion: "1.30.5"},
+	}, got)
+}
+
+func TestSelectUpgrades(t *testing.T) {
+	patch := v1alpha2.PluginUpgrade{Name: "kubernetes", CurrentVersion: "1.30.1", AvailableVersion: "1.30.5"}
+	minor := v1alpha2.PluginUpgrade{Name: "workflow-job", CurrentVersion: "2.41", AvailableVersion: "2.42"}
+	security := v1alpha2.PluginUpgrade{Name: "git", CurrentVersion: "4.9.0", AvailableVersion: "4.10.2", Security: true}
+	upgrades := []v1alpha2.PluginUpgrade{patch, minor, security}
+
+	t.Run("no policy", func(t *testing.T) {
+		assert.Empty(t, SelectUpgrades("", upgrades, nil))
+	})
+	t.Run("patch-only", func(t *testing.T) {
+		assert.Equal(t, []v1alpha2.PluginUpgrade{patch}, SelectUpgrades(v1alpha2.PatchOnlyPluginUpgradePolicy, upgrades, nil))
+	})
+	t.Run("security-only", func(t *testing.T) {
+		assert.Equal(t, []v1alpha2.PluginUpgrade{security}, SelectUpgrades(v1alpha2.SecurityOnlyPluginUpgradePolicy, upgrades, nil))
+	})
+	t.Run("skip rolled back upgrade", func(t *testing.T) {
+		lastUpgrade := &v1alpha2.PluginAutoUpgrade{Plugins: []v1alpha2.PluginUpgrade{security}, RolledBack: true}
+
+		assert.Empty(t, SelectUpgrades(v1alpha2.SecurityOnlyPluginUpgradePolicy, upgrades, lastUpgrade))
+	})
+}
+
+func TestIsPatchUpgrade(t *testing.T) {
+	assert.True(t, isPatchUpgrade("1.2.3", "1.2.4"))
+	assert.True(t, isPatchUpgrade("1.2", "1.2.1"))
+	assert.True(t, isPatchUpgrade("1.2.1", "1.2"))
+	assert.False(t, isPatchUpgrade("1.2.3", "1.3"))
+	assert.False(t, isPatchUpgrade("1.2.3", "2.2.3"))
+	assert.False(t, isPatchUpgrade("1", "1.0.1"))
+	assert.False(t, isPatchUpgrade("1.0.1", "1"))
+}
+
+func TestApplyAndRollback(t *testing.T) {
+	jenkins := &v1alpha2.Jenkins{
+		Spec: v1alpha2.JenkinsSpec{
+			Master: v1alpha2.JenkinsMaster{
+				BasePlugins: []v1alpha2.Plugin{{Name: "kubernetes", Version: "1.30.1"}},
+				Plugins:     []v1alpha2.Plugin{{Name: "git", Version: "4.9.0"}, {Name: "pinned", Version: "1.1"}},
+			},
+		},
+	}
+	upgrades := []v1alpha2.PluginUpgrade{
+		{Name: "kubernetes", CurrentVersion: "1.30.1", AvailableVersion: "1.30.5"},
+		{Name: "git", CurrentVersion: "4.9.0", AvailableVersion: "4.10.2"},
+		{Name: "pinned", CurrentVersion: "1.0", AvailableVersion: "1.2"},
+		{Name: "dependency", CurrentVersion: "1.0", AvailableVersion: "1.1"},
+	}
+
+	applied := Apply(jenkins, upgrades)
+
+	assert.Equal(t, upgrades[:2], applied)
+	assert.Equal(t, []v1alpha2.Plugin{{Name: "kubernetes", Version: "1.30.5"}}, jenkins.Spec.Master.BasePlugins)
+	assert.Equal(t, []v1alpha2.Plugin{{Name: "git", Version: "4.10.2"}, {Name: "pinned", Version: "1.1"}}, jenkins.Spec.Master.Plugins)
+
+	Rollback(jenkins, applied)
+
+	assert.Equal(t, []v1alpha2.Plugin{{Name: "kubernetes", Version: "1.30.1"}}, jenkins.Spec.Master.BasePlugins)
+	assert.Equal(t, []v1alpha2.Plugin{{Name: "git", Version: "4.9.0"}, {Name: "pinned", Version: "1.1"}}, jenkins.Spec.Master.Plugins)
+}
@@ -0,0 +1,2 @@
+// Package requeue contains timing of Jenkins reconcile loops set by the operator flags and overridden by Jenkins CR annotations
+package requeue
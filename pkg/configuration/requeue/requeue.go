@@ -0,0 +1,139 @@
+package requeue
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ProfileAnnotation is the Jenkins CR annotation which overrides the reconciliation profile
+	ProfileAnnotation = "jenkins.io/reconcile-profile"
+	// IntervalAnnotation is the Jenkins CR annotation which overrides the requeue interval, e.g. 30s
+	IntervalAnnotation = "jenkins.io/requeue-interval"
+	// MaxErrorBackoffAnnotation is the Jenkins CR annotation which overrides the cap of the error backoff, e.g. 5m
+	MaxErrorBackoffAnnotation = "jenkins.io/max-error-backoff"
+	// DriftCheckPeriodAnnotation is the Jenkins CR annotation which overrides the drift check period, 0 disables drift checks
+	DriftCheckPeriodAnnotation = "jenkins.io/drift-check-period"
+)
+
+// Profile is the set of reconciliation timings.
+type Profile string
+
+const (
+	// ProfileAggressive gives fast feedback, e.g. for development instances
+	ProfileAggressive Profile = "aggressive"
+	// ProfileBalanced is the default profile
+	ProfileBalanced Profile = "balanced"
+	// ProfileRelaxed reduces the load of large instances and the API server
+	ProfileRelaxed Profile = "relaxed"
+)
+
+// Settings define timing of Jenkins reconcile loops.
+type Settings struct {
+	// Interval is the time between checks while Jenkins isn't ready, it's also the first error backoff
+	Interval time.Duration
+	// MaxErrorBackoff caps the backoff doubled after every failed reconcile loop
+	MaxErrorBackoff time.Duration
+	// DriftCheckPeriod is the time after which ready Jenkins is reconciled again to revert manual changes, 0 disables it
+	DriftCheckPeriod time.Duration
+}
+
+var profiles = map[Profile]Settings{
+	ProfileAggressive: {Interval: 2 * time.Second, MaxErrorBackoff: 30 * time.Second, DriftCheckPeriod: time.Minute},
+	ProfileBalanced:   {Interval: 10 * time.Second, MaxErrorBackoff: 5 * time.Minute, DriftCheckPeriod: 10 * time.Minute},
+	ProfileRelaxed:    {Interval: 30 * time.Second, MaxErrorBackoff: 30 * time.Minute, DriftCheckPeriod: time.Hour},
+}
+
+// Default returns the settings of the balanced profile.
+func Default() Settings {
+	return profiles[ProfileBalanced]
+}
+
+// ForProfile returns the settings of the profile.
+func ForProfile(profile Profile) (Settings, error) {
+	settings, ok := profiles[profile]
+	if !ok {
+		return Settings{}, errors.Errorf("unrecognized reconciliation profile '%s', must be one of %s, %s or %s", profile, ProfileAggressive, ProfileBalanced, ProfileRelaxed)
+	}
+	return settings, nil
+}
+
+// Validate checks if the settings are valid.
+func (s Settings) Validate() error {
+	if s.Interval <= 0 {
+		return errors.Errorf("requeue interval must be positive, got '%s'", s.Interval)
+	}
+	if s.MaxErrorBackoff < s.Interval {
+		return errors.Errorf("max error backoff '%s' can't be shorter than requeue interval '%s'", s.MaxErrorBackoff, s.Interval)
+	}
+	if s.DriftCheckPeriod < 0 {
+		return errors.Errorf("drift check period can't be negative, got '%s'", s.DriftCheckPeriod)
+	}
+	return nil
+}
+
+// ForObject returns the settings overridden by the annotations of CR, invalid annotations are ignored.
+func (s Settings) ForObject(object metav1.Object) Settings {
+	settings, _ := s.override(object.GetAnnotations())
+	return settings
+}
+
+// ValidateAnnotations returns messages about invalid annotations of CR.
+func ValidateAnnotations(object metav1.Object) []string {
+	_, messages := Default().override(object.GetAnnotations())
+	return messages
+}
+
+func (s Settings) override(annotations map[string]string) (Settings, []string) {
+	var messages []string
+	if value, ok := annotations[ProfileAnnotation]; ok {
+		if profile, err := ForProfile(Profile(value)); err != nil {
+			messages = append(messages, fmt.Sprintf("%s annotation is invalid: %s", ProfileAnnotation, err))
+		} else {
+			s = profile
+		}
+	}
+
+	durations := []struct {
+		annotation string
+		value      *time.Duration
+	}{
+		{IntervalAnnotation, &s.Interval},
+		{MaxErrorBackoffAnnotation, &s.MaxErrorBackoff},
+		{DriftCheckPeriodAnnotation, &s.DriftCheckPeriod},
+	}
+	profileSettings := s
+	for _, duration := range durations {
+		value, ok := annotations[duration.annotation]
+		if !ok {
+			continue
+		}
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			messages = append(messages, fmt.Sprintf("unrecognized '%s' value of %s annotation, must be a duration, e.g. 30s", value, duration.annotation))
+			continue
+		}
+		*duration.value = parsed
+	}
+	if err := s.Validate(); err != nil {
+		messages = append(messages, fmt.Sprintf("reconciliation timing annotations are invalid: %s", err))
+		return profileSettings, messages
+	}
+	return s, messages
+}
+
+// ErrorBackoff returns the delay of the next reconcile loop after the number of consecutive failures,
+// it's doubled after every failure up to MaxErrorBackoff.
+func (s Settings) ErrorBackoff(failures uint64) time.Duration {
+	backoff := s.Interval
+	for i := uint64(1); i < failures && backoff < s.MaxErrorBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > s.MaxErrorBackoff {
+		return s.MaxErrorBackoff
+	}
+	return backoff
+}
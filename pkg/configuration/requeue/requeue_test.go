@@ -0,0 +1,112 @@
+package requeue
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func jenkins(annotations map[string]string) *metav1.ObjectMeta {
+	return &metav1.ObjectMeta{Name: "jenkins", Namespace: "default", Annotations: annotations}
+}
+
+func TestForProfile(t *testing.T) {
+	t.Run("known profiles are valid", func(t *testing.T) {
+		for _, profile := range []Profile{ProfileAggressive, ProfileBalanced, ProfileRelaxed} {
+			settings, err := ForProfile(profile)
+
+			require.NoError(t, err)
+			assert.NoError(t, settings.Validate(), profile)
+		}
+	})
+	t.Run("unknown profile", func(t *testing.T) {
+		_, err := ForProfile("fast")
+
+		assert.EqualError(t, err, "unrecognized reconciliation profile 'fast', must be one of aggressive, balanced or relaxed")
+	})
+}
+
+func TestSettings_Validate(t *testing.T) {
+	t.Run("zero interval", func(t *testing.T) {
+		assert.EqualError(t, Settings{MaxErrorBackoff: time.Minute}.Validate(), "requeue interval must be positive, got '0s'")
+	})
+	t.Run("backoff shorter than interval", func(t *testing.T) {
+		assert.EqualError(t, Settings{Interval: time.Minute, MaxErrorBackoff: time.Second}.Validate(), "max error backoff '1s' can't be shorter than requeue interval '1m0s'")
+	})
+	t.Run("negative drift check period", func(t *testing.T) {
+		assert.EqualError(t, Settings{Interval: time.Second, MaxErrorBackoff: time.Second, DriftCheckPeriod: -time.Second}.Validate(), "drift check period can't be negative, got '-1s'")
+	})
+	t.Run("disabled drift checks", func(t *testing.T) {
+		assert.NoError(t, Settings{Interval: time.Second, MaxErrorBackoff: time.Second}.Validate())
+	})
+}
+
+func TestSettings_ForObject(t *testing.T) {
+	t.Run("no annotations", func(t *testing.T) {
+		assert.Equal(t, Default(), Default().ForObject(jenkins(nil)))
+	})
+	t.Run("profile annotation", func(t *testing.T) {
+		relaxed, _ := ForProfile(ProfileRelaxed)
+
+		assert.Equal(t, relaxed, Default().ForObject(jenkins(map[string]string{ProfileAnnotation: "relaxed"})))
+	})
+	t.Run("duration annotations override the profile", func(t *testing.T) {
+		settings := Default().ForObject(jenkins(map[string]string{
+			ProfileAnnotation:          "aggressive",
+			IntervalAnnotation:         "5s",
+			DriftCheckPeriodAnnotation: "0",
+		}))
+
+		assert.Equal(t, Settings{Interval: 5 * time.Second, MaxErrorBackoff: 30 * time.Second}, settings)
+	})
+	t.Run("invalid annotations are ignored", func(t *testing.T) {
+		settings := Default().ForObject(jenkins(map[string]string{
+			ProfileAnnotation:         "fast",
+			MaxErrorBackoffAnnotation: "soon",
+		}))
+
+		assert.Equal(t, Default(), settings)
+	})
+	t.Run("invalid combination of annotations", func(t *testing.T) {
+		relaxed, _ := ForProfile(ProfileRelaxed)
+		settings := Default().ForObject(jenkins(map[string]string{
+			ProfileAnnotation:  "relaxed",
+			IntervalAnnotation: "1h",
+		}))
+
+		assert.Equal(t, relaxed, settings)
+	})
+}
+
+func TestValidateAnnotations(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		assert.Empty(t, ValidateAnnotations(jenkins(map[string]string{ProfileAnnotation: "aggressive", MaxErrorBackoffAnnotation: "1m"})))
+	})
+	t.Run("invalid", func(t *testing.T) {
+		messages := ValidateAnnotations(jenkins(map[string]string{
+			ProfileAnnotation:          "fast",
+			DriftCheckPeriodAnnotation: "hourly",
+			IntervalAnnotation:         "-1s",
+		}))
+
+		assert.Equal(t, []string{
+			"jenkins.io/reconcile-profile annotation is invalid: unrecognized reconciliation profile 'fast', must be one of aggressive, balanced or relaxed",
+			"unrecognized 'hourly' value of jenkins.io/drift-check-period annotation, must be a duration, e.g. 30s",
+			"reconciliation timing annotations are invalid: requeue interval must be positive, got '-1s'",
+		}, messages)
+	})
+}
+
+func TestSettings_ErrorBackoff(t *testing.T) {
+	settings := Settings{Interval: 10 * time.Second, MaxErrorBackoff: time.Minute}
+
+	assert.Equal(t, 10*time.Second, settings.ErrorBackoff(0))
+	assert.Equal(t, 10*time.Second, settings.ErrorBackoff(1))
+	assert.Equal(t, 20*time.Second, settings.ErrorBackoff(2))
+	assert.Equal(t, 40*time.Second, settings.ErrorBackoff(3))
+	assert.Equal(t, time.Minute, settings.ErrorBackoff(4))
+	assert.Equal(t, time.Minute, settings.ErrorBackoff(100))
+}
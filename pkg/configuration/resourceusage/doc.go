@@ -0,0 +1,2 @@
+// Package resourceusage is responsible for aggregating resource requests and usage of Jenkins master and agent pods
+package resourceusage
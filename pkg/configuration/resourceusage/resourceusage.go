@@ -0,0 +1,114 @@
+package resourceusage
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const (
+	// TeamLabelKey is the label of Jenkins CR with the default team of resource usage metrics
+	TeamLabelKey = "jenkins.io/team"
+
+	defaultCheckInterval = uint64(300)
+)
+
+// GetCheckInterval returns the interval of resource usage collection
+func GetCheckInterval(settings v1alpha2.ResourceUsage) time.Duration {
+	interval := settings.CheckInterval
+	if interval == 0 {
+		interval = defaultCheckInterval
+	}
+	return time.Duration(interval) * time.Second
+}
+
+// GetTeam returns the team charged for the resource usage of Jenkins
+func GetTeam(jenkins *v1alpha2.Jenkins) string {
+	if jenkins.Spec.ResourceUsage != nil && len(jenkins.Spec.ResourceUsage.Team) > 0 {
+		return jenkins.Spec.ResourceUsage.Team
+	}
+	return jenkins.Labels[TeamLabelKey]
+}
+
+// PodMetricsPath returns the path of metrics-server API with the usage of pods in the namespace
+func PodMetricsPath(namespace string) string {
+	return fmt.Sprintf("/apis/metrics.k8s.io/v1beta1/namespaces/%s/pods", namespace)
+}
+
+// podMetricsList is the subset of metrics.k8s.io/v1beta1 PodMetricsList used by the operator
+type podMetricsList struct {
+	Items []struct {
+		Metadata struct {
+			Name string `json:"name"`
+		} `json:"metadata"`
+		Containers []struct {
+			Usage corev1.ResourceList `json:"usage"`
+		} `json:"containers"`
+	} `json:"items"`
+}
+
+// ParsePodMetrics returns the usage of pods by their names from the metrics-server response
+func ParsePodMetrics(data []byte) (map[string]corev1.ResourceList, error) {
+	list := podMetricsList{}
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, errors.Wrap(err, "failed to parse pod metrics")
+	}
+	usage := map[string]corev1.ResourceList{}
+	for _, item := range list.Items {
+		total := corev1.ResourceList{}
+		for _, container := range item.Containers {
+			add(total, container.Usage)
+		}
+		usage[item.Metadata.Name] = total
+	}
+	return usage, nil
+}
+
+// Aggregate returns the sum of requests of pending and running pods, the actual usage is added when usage map
+// isn't nil, pods missing in metrics-server response, e.g. just started, count as zero usage
+func Aggregate(pods []corev1.Pod, usage map[string]corev1.ResourceList) v1alpha2.PodsResourceUsage {
+	result := v1alpha2.PodsResourceUsage{CPURequests: zeroCPU(), MemoryRequests: zeroMemory()}
+	total := corev1.ResourceList{corev1.ResourceCPU: zeroCPU(), corev1.ResourceMemory: zeroMemory()}
+	for _, pod := range pods {
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		result.Pods++
+		for _, container := range pod.Spec.Containers {
+			if cpu, ok := container.Resources.Requests[corev1.ResourceCPU]; ok {
+				result.CPURequests.Add(cpu)
+			}
+			if memory, ok := container.Resources.Requests[corev1.ResourceMemory]; ok {
+				result.MemoryRequests.Add(memory)
+			}
+		}
+		add(total, usage[pod.Name])
+	}
+	if usage != nil {
+		cpu, memory := total[corev1.ResourceCPU], total[corev1.ResourceMemory]
+		result.CPUUsage, result.MemoryUsage = &cpu, &memory
+	}
+	return result
+}
+
+func add(total, list corev1.ResourceList) {
+	for name, quantity := range list {
+		sum := total[name]
+		sum.Add(quantity)
+		total[name] = sum
+	}
+}
+
+func zeroCPU() resource.Quantity {
+	return *resource.NewMilliQuantity(0, resource.DecimalSI)
+}
+
+func zeroMemory() resource.Quantity {
+	return *resource.NewQuantity(0, resource.BinarySI)
+}
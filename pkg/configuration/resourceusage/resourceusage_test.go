@@ -0,0 +1,82 @@
+package resourceusage
+
+import (
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newPod(name string, phase corev1.PodPhase, requests ...corev1.ResourceList) corev1.Pod {
+	pod := corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name}, Status: corev1.PodStatus{Phase: phase}}
+	for _, request := range requests {
+		pod.Spec.Containers = append(pod.Spec.Containers, corev1.Container{Resources: corev1.ResourceRequirements{Requests: request}})
+	}
+	return pod
+}
+
+func TestParsePodMetrics(t *testing.T) {
+	data := []byte(`{"kind":"PodMetricsList","apiVersion":"metrics.k8s.io/v1beta1","items":[
+		{"metadata":{"name":"jenkins-example"},"containers":[
+			{"name":"jenkins-master","usage":{"cpu":"250m","memory":"1Gi"}},
+			{"name":"backup","usage":{"cpu":"5m","memory":"20Mi"}}]},
+		{"metadata":{"name":"agent-1"},"containers":[{"name":"jnlp","usage":{"cpu":"1","memory":"512Mi"}}]}]}`)
+
+	usage, err := ParsePodMetrics(data)
+
+	require.NoError(t, err)
+	require.Len(t, usage, 2)
+	cpu, memory := usage["jenkins-example"][corev1.ResourceCPU], usage["jenkins-example"][corev1.ResourceMemory]
+	assert.Equal(t, int64(255), cpu.MilliValue())
+	assert.Equal(t, int64(1044<<20), memory.Value())
+
+	_, err = ParsePodMetrics([]byte("not json"))
+	assert.Error(t, err)
+}
+
+func TestAggregate(t *testing.T) {
+	pods := []corev1.Pod{
+		newPod("agent-1", corev1.PodRunning,
+			corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m"), corev1.ResourceMemory: resource.MustParse("512Mi")},
+			corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")}),
+		newPod("agent-2", corev1.PodPending,
+			corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1"), corev1.ResourceMemory: resource.MustParse("1Gi")}),
+		newPod("agent-3", corev1.PodSucceeded,
+			corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4"), corev1.ResourceMemory: resource.MustParse("8Gi")}),
+	}
+	t.Run("requests only", func(t *testing.T) {
+		result := Aggregate(pods, nil)
+
+		assert.Equal(t, int32(2), result.Pods)
+		assert.Equal(t, int64(1600), result.CPURequests.MilliValue())
+		assert.Equal(t, int64(1536<<20), result.MemoryRequests.Value())
+		assert.Nil(t, result.CPUUsage)
+		assert.Nil(t, result.MemoryUsage)
+	})
+	t.Run("with usage", func(t *testing.T) {
+		usage := map[string]corev1.ResourceList{
+			"agent-1": {corev1.ResourceCPU: resource.MustParse("300m"), corev1.ResourceMemory: resource.MustParse("400Mi")},
+			"agent-3": {corev1.ResourceCPU: resource.MustParse("2"), corev1.ResourceMemory: resource.MustParse("2Gi")},
+		}
+
+		result := Aggregate(pods, usage)
+
+		require.NotNil(t, result.CPUUsage)
+		require.NotNil(t, result.MemoryUsage)
+		assert.Equal(t, int64(300), result.CPUUsage.MilliValue())
+		assert.Equal(t, int64(400<<20), result.MemoryUsage.Value())
+	})
+}
+
+func TestGetTeam(t *testing.T) {
+	jenkins := &v1alpha2.Jenkins{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{TeamLabelKey: "platform"}}}
+	assert.Equal(t, "platform", GetTeam(jenkins))
+
+	jenkins.Spec.ResourceUsage = &v1alpha2.ResourceUsage{Team: "payments"}
+	assert.Equal(t, "payments", GetTeam(jenkins))
+}
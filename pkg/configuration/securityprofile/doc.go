@@ -0,0 +1,2 @@
+// Package securityprofile renders security contexts of pods managed by the operator compliant with the Pod Security Standard from spec.securityProfile
+package securityprofile
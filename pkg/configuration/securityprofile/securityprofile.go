@@ -0,0 +1,238 @@
+package securityprofile
+
+import (
+	"fmt"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// TmpVolumeName is the name of emptyDir volume mounted at /tmp when the root filesystem is read-only
+	TmpVolumeName = "tmp"
+	// TmpVolumePath is the path where TmpVolumeName is mounted
+	TmpVolumePath = "/tmp"
+	// DefaultUserID is the user and group of pods with restricted profile if runAsUser isn't set, Jenkins images
+	// have non-numeric user, Kubernetes can't verify that it isn't root then
+	DefaultUserID = int64(1000)
+
+	capabilityAll            = corev1.Capability("ALL")
+	capabilityNetBindService = corev1.Capability("NET_BIND_SERVICE")
+)
+
+// baselineCapabilities are capabilities which can be added by containers with baseline profile
+var baselineCapabilities = map[corev1.Capability]bool{
+	"AUDIT_WRITE": true, "CHOWN": true, "DAC_OVERRIDE": true, "FOWNER": true, "FSETID": true, "KILL": true, "MKNOD": true,
+	"NET_BIND_SERVICE": true, "SETFCAP": true, "SETGID": true, "SETPCAP": true, "SETUID": true, "SYS_CHROOT": true,
+}
+
+// Get returns the security profile of Jenkins CR, it defaults to custom
+func Get(jenkins *v1alpha2.Jenkins) v1alpha2.SecurityProfile {
+	if len(jenkins.Spec.SecurityProfile) == 0 {
+		return v1alpha2.CustomSecurityProfile
+	}
+	return jenkins.Spec.SecurityProfile
+}
+
+// PodSecurityContext returns the pod security context complying with the profile, the fields set in Jenkins CR are kept
+func PodSecurityContext(profile v1alpha2.SecurityProfile, securityContext *corev1.PodSecurityContext) *corev1.PodSecurityContext {
+	if profile != v1alpha2.RestrictedSecurityProfile && profile != v1alpha2.BaselineSecurityProfile {
+		return securityContext
+	}
+
+	rendered := &corev1.PodSecurityContext{}
+	if securityContext != nil {
+		rendered = securityContext.DeepCopy()
+	}
+	if rendered.SeccompProfile == nil {
+		rendered.SeccompProfile = &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault}
+	}
+	if profile == v1alpha2.RestrictedSecurityProfile {
+		if rendered.RunAsNonRoot == nil {
+			rendered.RunAsNonRoot = boolPtr(true)
+		}
+		if rendered.RunAsUser == nil {
+			rendered.RunAsUser = int64Ptr(DefaultUserID)
+		}
+		if rendered.FSGroup == nil {
+			rendered.FSGroup = int64Ptr(DefaultUserID)
+		}
+	}
+	return rendered
+}
+
+// ContainerSecurityContext returns the container security context complying with the profile, the fields set
+// in Jenkins CR are kept
+func ContainerSecurityContext(profile v1alpha2.SecurityProfile, securityContext *corev1.SecurityContext) *corev1.SecurityContext {
+	if profile != v1alpha2.RestrictedSecurityProfile {
+		return securityContext
+	}
+
+	rendered := &corev1.SecurityContext{}
+	if securityContext != nil {
+		rendered = securityContext.DeepCopy()
+	}
+	if rendered.AllowPrivilegeEscalation == nil {
+		rendered.AllowPrivilegeEscalation = boolPtr(false)
+	}
+	if rendered.RunAsNonRoot == nil {
+		rendered.RunAsNonRoot = boolPtr(true)
+	}
+	if rendered.ReadOnlyRootFilesystem == nil {
+		rendered.ReadOnlyRootFilesystem = boolPtr(true)
+	}
+	if rendered.Capabilities == nil {
+		rendered.Capabilities = &corev1.Capabilities{}
+	}
+	if !hasCapability(rendered.Capabilities.Drop, capabilityAll) {
+		rendered.Capabilities.Drop = append(rendered.Capabilities.Drop, capabilityAll)
+	}
+	return rendered
+}
+
+// Container returns the container with the security context complying with the profile, /tmp is mounted
+// from TmpVolumeName when the root filesystem is read-only
+func Container(profile v1alpha2.SecurityProfile, container corev1.Container) corev1.Container {
+	container.SecurityContext = ContainerSecurityContext(profile, container.SecurityContext)
+	if profile != v1alpha2.RestrictedSecurityProfile || !isRootFilesystemReadOnly(container.SecurityContext) {
+		return container
+	}
+	for _, volumeMount := range container.VolumeMounts {
+		if volumeMount.MountPath == TmpVolumePath {
+			return container
+		}
+	}
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{Name: TmpVolumeName, MountPath: TmpVolumePath})
+	return container
+}
+
+// Volumes returns the volumes required by the profile
+func Volumes(profile v1alpha2.SecurityProfile) []corev1.Volume {
+	if profile != v1alpha2.RestrictedSecurityProfile {
+		return nil
+	}
+	return []corev1.Volume{{Name: TmpVolumeName, VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}}}
+}
+
+// Validate returns the settings of Jenkins CR which don't comply with spec.securityProfile
+func Validate(jenkins *v1alpha2.Jenkins) []string {
+	profile := Get(jenkins)
+	if profile == v1alpha2.CustomSecurityProfile {
+		return nil
+	}
+
+	var messages []string
+	messages = append(messages, validatePodSecurityContext(profile, "spec.master.securityContext", jenkins.Spec.Master.SecurityContext)...)
+	for _, container := range jenkins.Spec.Master.Containers {
+		field := fmt.Sprintf("spec.master.containers[%s].securityContext", container.Name)
+		messages = append(messages, validateContainerSecurityContext(profile, field, container.SecurityContext)...)
+	}
+	for _, container := range jenkins.Spec.Master.InitContainers {
+		field := fmt.Sprintf("spec.master.initContainers[%s].securityContext", container.Name)
+		messages = append(messages, validateContainerSecurityContext(profile, field, container.SecurityContext)...)
+	}
+	for _, volume := range jenkins.Spec.Master.Volumes {
+		if msg := validateVolume(profile, volume); len(msg) > 0 {
+			messages = append(messages, fmt.Sprintf("spec.master.volumes[%s] %s", volume.Name, msg))
+		}
+	}
+	if profile == v1alpha2.RestrictedSecurityProfile && jenkins.Spec.Agents != nil {
+		for _, template := range jenkins.Spec.Agents.PodTemplates {
+			if template.RunAsUser != nil && *template.RunAsUser == 0 {
+				messages = append(messages, fmt.Sprintf("spec.agents.podTemplates[%s].runAsUser can't be 0 with %s security profile", template.Name, profile))
+			}
+		}
+	}
+	return messages
+}
+
+func validatePodSecurityContext(profile v1alpha2.SecurityProfile, field string, securityContext *corev1.PodSecurityContext) []string {
+	if securityContext == nil {
+		return nil
+	}
+
+	var messages []string
+	if securityContext.SeccompProfile != nil && securityContext.SeccompProfile.Type == corev1.SeccompProfileTypeUnconfined {
+		messages = append(messages, fmt.Sprintf("%s.seccompProfile can't be %s with %s security profile", field, corev1.SeccompProfileTypeUnconfined, profile))
+	}
+	if profile == v1alpha2.RestrictedSecurityProfile {
+		if securityContext.RunAsNonRoot != nil && !*securityContext.RunAsNonRoot {
+			messages = append(messages, fmt.Sprintf("%s.runAsNonRoot can't be false with %s security profile", field, profile))
+		}
+		if securityContext.RunAsUser != nil && *securityContext.RunAsUser == 0 {
+			messages = append(messages, fmt.Sprintf("%s.runAsUser can't be 0 with %s security profile", field, profile))
+		}
+	}
+	return messages
+}
+
+func validateContainerSecurityContext(profile v1alpha2.SecurityProfile, field string, securityContext *corev1.SecurityContext) []string {
+	if securityContext == nil {
+		return nil
+	}
+
+	var messages []string
+	if securityContext.Privileged != nil && *securityContext.Privileged {
+		messages = append(messages, fmt.Sprintf("%s.privileged can't be true with %s security profile", field, profile))
+	}
+	if securityContext.SeccompProfile != nil && securityContext.SeccompProfile.Type == corev1.SeccompProfileTypeUnconfined {
+		messages = append(messages, fmt.Sprintf("%s.seccompProfile can't be %s with %s security profile", field, corev1.SeccompProfileTypeUnconfined, profile))
+	}
+	if securityContext.Capabilities != nil {
+		for _, capability := range securityContext.Capabilities.Add {
+			if !baselineCapabilities[capability] || (profile == v1alpha2.RestrictedSecurityProfile && capability != capabilityNetBindService) {
+				messages = append(messages, fmt.Sprintf("%s.capabilities can't add %s with %s security profile", field, capability, profile))
+			}
+		}
+	}
+	if profile == v1alpha2.RestrictedSecurityProfile {
+		if securityContext.AllowPrivilegeEscalation != nil && *securityContext.AllowPrivilegeEscalation {
+			messages = append(messages, fmt.Sprintf("%s.allowPrivilegeEscalation can't be true with %s security profile", field, profile))
+		}
+		if securityContext.RunAsNonRoot != nil && !*securityContext.RunAsNonRoot {
+			messages = append(messages, fmt.Sprintf("%s.runAsNonRoot can't be false with %s security profile", field, profile))
+		}
+		if securityContext.RunAsUser != nil && *securityContext.RunAsUser == 0 {
+			messages = append(messages, fmt.Sprintf("%s.runAsUser can't be 0 with %s security profile", field, profile))
+		}
+	}
+	return messages
+}
+
+func validateVolume(profile v1alpha2.SecurityProfile, volume corev1.Volume) string {
+	if volume.HostPath != nil {
+		return fmt.Sprintf("can't be hostPath volume with %s security profile", profile)
+	}
+	if profile != v1alpha2.RestrictedSecurityProfile {
+		return ""
+	}
+	// the volume types allowed by the restricted Pod Security Standard
+	source := volume.VolumeSource
+	if source.ConfigMap != nil || source.CSI != nil || source.DownwardAPI != nil || source.EmptyDir != nil ||
+		source.Ephemeral != nil || source.PersistentVolumeClaim != nil || source.Projected != nil || source.Secret != nil {
+		return ""
+	}
+	return fmt.Sprintf("must be configMap, csi, downwardAPI, emptyDir, ephemeral, persistentVolumeClaim, projected or secret volume with %s security profile", profile)
+}
+
+func isRootFilesystemReadOnly(securityContext *corev1.SecurityContext) bool {
+	return securityContext != nil && securityContext.ReadOnlyRootFilesystem != nil && *securityContext.ReadOnlyRootFilesystem
+}
+
+func hasCapability(capabilities []corev1.Capability, capability corev1.Capability) bool {
+	for _, c := range capabilities {
+		if c == capability {
+			return true
+		}
+	}
+	return false
+}
+
+func boolPtr(value bool) *bool {
+	return &value
+}
+
+func int64Ptr(value int64) *int64 {
+	return &value
+}
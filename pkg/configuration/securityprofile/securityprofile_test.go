@@ -0,0 +1,116 @@
+package securityprofile
+
+import (
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestPodSecurityContext(t *testing.T) {
+	uid := int64(2000)
+
+	t.Run("custom", func(t *testing.T) {
+		assert.Nil(t, PodSecurityContext(v1alpha2.CustomSecurityProfile, nil))
+	})
+	t.Run("baseline", func(t *testing.T) {
+		securityContext := PodSecurityContext(v1alpha2.BaselineSecurityProfile, &corev1.PodSecurityContext{RunAsUser: &uid})
+
+		assert.Equal(t, &corev1.PodSecurityContext{
+			RunAsUser:      &uid,
+			SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+		}, securityContext)
+	})
+	t.Run("restricted keeps user", func(t *testing.T) {
+		securityContext := PodSecurityContext(v1alpha2.RestrictedSecurityProfile, &corev1.PodSecurityContext{RunAsUser: &uid})
+
+		assert.Equal(t, &uid, securityContext.RunAsUser)
+		assert.Equal(t, DefaultUserID, *securityContext.FSGroup)
+		assert.True(t, *securityContext.RunAsNonRoot)
+		assert.Equal(t, corev1.SeccompProfileTypeRuntimeDefault, securityContext.SeccompProfile.Type)
+	})
+}
+
+func TestContainer(t *testing.T) {
+	t.Run("custom", func(t *testing.T) {
+		container := Container(v1alpha2.CustomSecurityProfile, corev1.Container{Name: "jenkins-master"})
+
+		assert.Equal(t, corev1.Container{Name: "jenkins-master"}, container)
+	})
+	t.Run("restricted", func(t *testing.T) {
+		container := Container(v1alpha2.RestrictedSecurityProfile, corev1.Container{
+			Name:            "backup",
+			SecurityContext: &corev1.SecurityContext{Capabilities: &corev1.Capabilities{Add: []corev1.Capability{"NET_BIND_SERVICE"}}},
+		})
+
+		require.NotNil(t, container.SecurityContext)
+		assert.False(t, *container.SecurityContext.AllowPrivilegeEscalation)
+		assert.True(t, *container.SecurityContext.RunAsNonRoot)
+		assert.True(t, *container.SecurityContext.ReadOnlyRootFilesystem)
+		assert.Equal(t, &corev1.Capabilities{Add: []corev1.Capability{"NET_BIND_SERVICE"}, Drop: []corev1.Capability{"ALL"}}, container.SecurityContext.Capabilities)
+		assert.Equal(t, []corev1.VolumeMount{{Name: TmpVolumeName, MountPath: TmpVolumePath}}, container.VolumeMounts)
+	})
+	t.Run("restricted with writable root filesystem", func(t *testing.T) {
+		readOnly := false
+		container := Container(v1alpha2.RestrictedSecurityProfile, corev1.Container{
+			Name:            "backup",
+			SecurityContext: &corev1.SecurityContext{ReadOnlyRootFilesystem: &readOnly},
+		})
+
+		assert.False(t, *container.SecurityContext.ReadOnlyRootFilesystem)
+		assert.Empty(t, container.VolumeMounts)
+	})
+}
+
+func TestValidate(t *testing.T) {
+	privileged := true
+	root := int64(0)
+	jenkins := &v1alpha2.Jenkins{
+		Spec: v1alpha2.JenkinsSpec{
+			Master: v1alpha2.JenkinsMaster{
+				SecurityContext: &corev1.PodSecurityContext{RunAsUser: &root},
+				Containers: []v1alpha2.Container{
+					{Name: "jenkins-master"},
+					{Name: "backup", SecurityContext: &corev1.SecurityContext{
+						Privileged:   &privileged,
+						Capabilities: &corev1.Capabilities{Add: []corev1.Capability{"CHOWN", "SYS_ADMIN"}},
+					}},
+				},
+				Volumes: []corev1.Volume{
+					{Name: "docker", VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/var/run/docker.sock"}}},
+					{Name: "nfs", VolumeSource: corev1.VolumeSource{NFS: &corev1.NFSVolumeSource{Server: "nfs", Path: "/"}}},
+				},
+			},
+		},
+	}
+
+	t.Run("custom", func(t *testing.T) {
+		assert.Nil(t, Validate(jenkins))
+	})
+	t.Run("baseline", func(t *testing.T) {
+		baseline := jenkins.DeepCopy()
+		baseline.Spec.SecurityProfile = v1alpha2.BaselineSecurityProfile
+
+		assert.Equal(t, []string{
+			"spec.master.containers[backup].securityContext.privileged can't be true with baseline security profile",
+			"spec.master.containers[backup].securityContext.capabilities can't add SYS_ADMIN with baseline security profile",
+			"spec.master.volumes[docker] can't be hostPath volume with baseline security profile",
+		}, Validate(baseline))
+	})
+	t.Run("restricted", func(t *testing.T) {
+		restricted := jenkins.DeepCopy()
+		restricted.Spec.SecurityProfile = v1alpha2.RestrictedSecurityProfile
+
+		assert.Equal(t, []string{
+			"spec.master.securityContext.runAsUser can't be 0 with restricted security profile",
+			"spec.master.containers[backup].securityContext.privileged can't be true with restricted security profile",
+			"spec.master.containers[backup].securityContext.capabilities can't add CHOWN with restricted security profile",
+			"spec.master.containers[backup].securityContext.capabilities can't add SYS_ADMIN with restricted security profile",
+			"spec.master.volumes[docker] can't be hostPath volume with restricted security profile",
+			"spec.master.volumes[nfs] must be configMap, csi, downwardAPI, emptyDir, ephemeral, persistentVolumeClaim, projected or secret volume with restricted security profile",
+		}, Validate(restricted))
+	})
+}
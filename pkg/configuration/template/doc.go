@@ -0,0 +1,2 @@
+// Package template merges spec of JenkinsTemplate referenced by spec.from with spec of Jenkins CR
+package template
@@ -0,0 +1,242 @@
+package template
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// nameKey identifies elements of lists merged by name, e.g. plugins, containers or seed jobs
+const nameKey = "name"
+
+// IndexField is the field index of Jenkins CRs by the name of the referenced JenkinsTemplate
+const IndexField = "spec.from.name"
+
+// IndexJenkinsTemplate returns the name of JenkinsTemplate referenced by Jenkins CR for IndexField
+func IndexJenkinsTemplate(object client.Object) []string {
+	jenkins, ok := object.(*v1alpha2.Jenkins)
+	if !ok || !IsTemplated(jenkins) {
+		return nil
+	}
+	return []string{jenkins.Spec.From.Name}
+}
+
+// IsTemplated returns true if Jenkins CR references JenkinsTemplate.
+func IsTemplated(jenkins *v1alpha2.Jenkins) bool {
+	return jenkins.Spec.From != nil && len(jenkins.Spec.From.Name) > 0
+}
+
+// Apply replaces spec of Jenkins CR with spec of the referenced JenkinsTemplate merged with the Jenkins CR spec,
+// it does nothing if Jenkins CR doesn't reference the template. The merged spec mustn't be written back to the Jenkins CR.
+func Apply(ctx context.Context, k8sClient client.Client, jenkins *v1alpha2.Jenkins) error {
+	if !IsTemplated(jenkins) {
+		return nil
+	}
+
+	jenkinsTemplate := &v1alpha2.JenkinsTemplate{}
+	if err := k8sClient.Get(ctx, types.NamespacedName{Name: jenkins.Spec.From.Name}, jenkinsTemplate); err != nil {
+		if apierrors.IsNotFound(err) {
+			return errors.Errorf("JenkinsTemplate '%s' referenced by spec.from not found", jenkins.Spec.From.Name)
+		}
+		return errors.WithStack(err)
+	}
+
+	merged, err := merge(jenkinsTemplate.Spec.Template, jenkins.Spec, setSpecFields(jenkins.ManagedFields))
+	if err != nil {
+		return errors.Wrapf(err, "couldn't merge JenkinsTemplate '%s'", jenkins.Spec.From.Name)
+	}
+	jenkins.Spec = merged
+	return nil
+}
+
+// Merge returns the template spec overridden by values set in the Jenkins CR spec. Objects are merged recursively,
+// lists of objects with names are merged by name and other lists are replaced. Empty values, e.g. false or 0,
+// don't override the template, Apply overrides it also with empty values set in the Jenkins CR.
+func Merge(template, overrides v1alpha2.JenkinsSpec) (v1alpha2.JenkinsSpec, error) {
+	return merge(template, overrides, nil)
+}
+
+// merge merges the specs like Merge does, the empty values of fields in setFields override the template
+func merge(template, overrides v1alpha2.JenkinsSpec, setFields map[string]interface{}) (v1alpha2.JenkinsSpec, error) {
+	from := overrides.From
+	template.From = nil
+	overrides.From = nil
+
+	base, err := toMap(template)
+	if err != nil {
+		return v1alpha2.JenkinsSpec{}, err
+	}
+	patch, err := toMap(overrides)
+	if err != nil {
+		return v1alpha2.JenkinsSpec{}, err
+	}
+
+	merged := interface{}(base)
+	if pruned := prune(patch, setFields); pruned != nil {
+		merged = mergeValues(base, pruned)
+	}
+	data, err := json.Marshal(merged)
+	if err != nil {
+		return v1alpha2.JenkinsSpec{}, errors.WithStack(err)
+	}
+	spec := v1alpha2.JenkinsSpec{}
+	if err = json.Unmarshal(data, &spec); err != nil {
+		return v1alpha2.JenkinsSpec{}, errors.WithStack(err)
+	}
+	spec.From = from
+	return spec, nil
+}
+
+func toMap(spec v1alpha2.JenkinsSpec) (map[string]interface{}, error) {
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	value := map[string]interface{}{}
+	if err = json.Unmarshal(data, &value); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return value, nil
+}
+
+// setSpecFields returns the fields of spec set in the Jenkins CR, in the FieldsV1 format of managed fields,
+// the operator doesn't update spec of Jenkins CR created from the template, so all managers are users
+func setSpecFields(managedFields []metav1.ManagedFieldsEntry) map[string]interface{} {
+	set := map[string]interface{}{}
+	for _, entry := range managedFields {
+		if entry.FieldsType != "FieldsV1" || entry.FieldsV1 == nil {
+			continue
+		}
+		fields := map[string]interface{}{}
+		if err := json.Unmarshal(entry.FieldsV1.Raw, &fields); err != nil {
+			continue
+		}
+		if spec, ok := fields["f:spec"].(map[string]interface{}); ok {
+			mergeFields(set, spec)
+		}
+	}
+	return set
+}
+
+func mergeFields(set, fields map[string]interface{}) {
+	for key, value := range fields {
+		child, ok := value.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if _, ok := set[key].(map[string]interface{}); !ok {
+			set[key] = map[string]interface{}{}
+		}
+		mergeFields(set[key].(map[string]interface{}), child)
+	}
+}
+
+// prune removes empty values which are written by JSON encoding of fields without omitempty,
+// the empty values of fields in setFields are kept
+func prune(value interface{}, setFields map[string]interface{}) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		pruned := map[string]interface{}{}
+		for key, item := range typed {
+			fields, set := setFields["f:"+key].(map[string]interface{})
+			if prunedItem := prune(item, fields); prunedItem != nil {
+				pruned[key] = prunedItem
+			} else if set {
+				pruned[key] = item
+			}
+		}
+		if len(pruned) == 0 {
+			return nil
+		}
+		return pruned
+	case []interface{}:
+		if len(typed) == 0 {
+			return nil
+		}
+		pruned := make([]interface{}, 0, len(typed))
+		for _, item := range typed {
+			// elements of lists aren't tracked in managed fields of Jenkins CR
+			if item = prune(item, nil); item != nil {
+				pruned = append(pruned, item)
+			}
+		}
+		return pruned
+	case string:
+		if len(typed) == 0 {
+			return nil
+		}
+	case bool:
+		if !typed {
+			return nil
+		}
+	case float64:
+		if typed == 0 {
+			return nil
+		}
+	}
+	return value
+}
+
+func mergeValues(base, patch interface{}) interface{} {
+	switch typed := patch.(type) {
+	case map[string]interface{}:
+		baseMap, ok := base.(map[string]interface{})
+		if !ok {
+			return typed
+		}
+		merged := make(map[string]interface{}, len(baseMap))
+		for key, item := range baseMap {
+			merged[key] = item
+		}
+		for key, item := range typed {
+			merged[key] = mergeValues(baseMap[key], item)
+		}
+		return merged
+	case []interface{}:
+		baseList, ok := base.([]interface{})
+		if !ok || !isNamedList(baseList) || !isNamedList(typed) {
+			return typed
+		}
+		return mergeNamedLists(baseList, typed)
+	}
+	return patch
+}
+
+// mergeNamedLists merges elements with the same name and appends new elements, the order of the template is kept
+func mergeNamedLists(base, patch []interface{}) []interface{} {
+	merged := append([]interface{}{}, base...)
+	indexes := map[string]int{}
+	for i, item := range merged {
+		indexes[item.(map[string]interface{})[nameKey].(string)] = i
+	}
+	for _, item := range patch {
+		name := item.(map[string]interface{})[nameKey].(string)
+		if i, ok := indexes[name]; ok {
+			merged[i] = mergeValues(merged[i], item)
+			continue
+		}
+		indexes[name] = len(merged)
+		merged = append(merged, item)
+	}
+	return merged
+}
+
+func isNamedList(list []interface{}) bool {
+	for _, item := range list {
+		object, ok := item.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		if name, ok := object[nameKey].(string); !ok || len(name) == 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,158 @@
+package template
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestMerge(t *testing.T) {
+	template := v1alpha2.JenkinsSpec{
+		Master: v1alpha2.JenkinsMaster{
+			DisableCSRFProtection: true,
+			NodeSelector:          map[string]string{"pool": "jenkins"},
+			Containers: []v1alpha2.Container{
+				{Name: "jenkins-master", Image: "jenkins/jenkins:lts", ImagePullPolicy: "Always"},
+				{Name: "backup", Image: "backup:1"},
+			},
+			Plugins: []v1alpha2.Plugin{{Name: "git", Version: "4.5.0"}},
+		},
+		JenkinsAPISettings: v1alpha2.JenkinsAPISettings{AuthorizationStrategy: v1alpha2.CreateUserAuthorizationStrategy},
+	}
+	t.Run("empty overrides", func(t *testing.T) {
+		merged, err := Merge(template, v1alpha2.JenkinsSpec{})
+
+		require.NoError(t, err)
+		assert.Equal(t, template, merged)
+	})
+	t.Run("overrides", func(t *testing.T) {
+		overrides := v1alpha2.JenkinsSpec{
+			From: &v1alpha2.JenkinsTemplateRef{Name: "golden"},
+			Master: v1alpha2.JenkinsMaster{
+				NodeSelector: map[string]string{"zone": "a"},
+				Containers: []v1alpha2.Container{
+					{Name: "jenkins-master", Image: "jenkins/jenkins:2.263.1"},
+					{Name: "sidecar", Image: "sidecar:1"},
+				},
+				Plugins: []v1alpha2.Plugin{{Name: "git", Version: "4.6.0"}, {Name: "job-dsl", Version: "1.77"}},
+			},
+		}
+
+		merged, err := Merge(template, overrides)
+
+		require.NoError(t, err)
+		assert.Equal(t, overrides.From, merged.From)
+		assert.True(t, merged.Master.DisableCSRFProtection)
+		assert.Equal(t, map[string]string{"pool": "jenkins", "zone": "a"}, merged.Master.NodeSelector)
+		assert.Equal(t, []v1alpha2.Container{
+			{Name: "jenkins-master", Image: "jenkins/jenkins:2.263.1", ImagePullPolicy: "Always"},
+			{Name: "backup", Image: "backup:1"},
+			{Name: "sidecar", Image: "sidecar:1"},
+		}, merged.Master.Containers)
+		assert.Equal(t, []v1alpha2.Plugin{{Name: "git", Version: "4.6.0"}, {Name: "job-dsl", Version: "1.77"}}, merged.Master.Plugins)
+		assert.Equal(t, v1alpha2.CreateUserAuthorizationStrategy, merged.JenkinsAPISettings.AuthorizationStrategy)
+	})
+	t.Run("lists without names are replaced", func(t *testing.T) {
+		template := v1alpha2.JenkinsSpec{Master: v1alpha2.JenkinsMaster{
+			Containers: []v1alpha2.Container{{Name: "jenkins-master", Command: []string{"a", "b"}}},
+		}}
+		overrides := v1alpha2.JenkinsSpec{Master: v1alpha2.JenkinsMaster{
+			Containers: []v1alpha2.Container{{Name: "jenkins-master", Command: []string{"c"}}},
+		}}
+
+		merged, err := Merge(template, overrides)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"c"}, merged.Master.Containers[0].Command)
+	})
+	t.Run("template reference of template is ignored", func(t *testing.T) {
+		template := v1alpha2.JenkinsSpec{From: &v1alpha2.JenkinsTemplateRef{Name: "other"}}
+
+		merged, err := Merge(template, v1alpha2.JenkinsSpec{})
+
+		require.NoError(t, err)
+		assert.Nil(t, merged.From)
+	})
+}
+
+func TestApply(t *testing.T) {
+	require.NoError(t, v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme))
+	jenkinsTemplate := &v1alpha2.JenkinsTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "golden"},
+		Spec: v1alpha2.JenkinsTemplateSpec{
+			Template: v1alpha2.JenkinsSpec{
+				Master: v1alpha2.JenkinsMaster{
+					Containers: []v1alpha2.Container{{Name: "jenkins-master", Image: "jenkins/jenkins:lts"}},
+				},
+			},
+		},
+	}
+	t.Run("not templated", func(t *testing.T) {
+		jenkins := &v1alpha2.Jenkins{Spec: v1alpha2.JenkinsSpec{Master: v1alpha2.JenkinsMaster{DisableCSRFProtection: true}}}
+		expected := jenkins.DeepCopy()
+
+		err := Apply(context.TODO(), fake.NewClientBuilder().Build(), jenkins)
+
+		require.NoError(t, err)
+		assert.Equal(t, expected, jenkins)
+	})
+	t.Run("templated", func(t *testing.T) {
+		jenkins := &v1alpha2.Jenkins{Spec: v1alpha2.JenkinsSpec{
+			From:   &v1alpha2.JenkinsTemplateRef{Name: "golden"},
+			Master: v1alpha2.JenkinsMaster{DisableCSRFProtection: true},
+		}}
+
+		err := Apply(context.TODO(), fake.NewClientBuilder().WithObjects(jenkinsTemplate).Build(), jenkins)
+
+		require.NoError(t, err)
+		assert.Equal(t, "golden", jenkins.Spec.From.Name)
+		assert.True(t, jenkins.Spec.Master.DisableCSRFProtection)
+		assert.Equal(t, jenkinsTemplate.Spec.Template.Master.Containers, jenkins.Spec.Master.Containers)
+	})
+	t.Run("empty values set in Jenkins CR override template", func(t *testing.T) {
+		jenkinsTemplate := &v1alpha2.JenkinsTemplate{
+			ObjectMeta: metav1.ObjectMeta{Name: "csrf-disabled"},
+			Spec: v1alpha2.JenkinsTemplateSpec{
+				Template: v1alpha2.JenkinsSpec{
+					Master: v1alpha2.JenkinsMaster{
+						DisableCSRFProtection: true,
+						Containers:            []v1alpha2.Container{{Name: "jenkins-master", Image: "jenkins/jenkins:lts"}},
+					},
+				},
+			},
+		}
+		fields := `{"f:spec":{"f:from":{".":{},"f:name":{}},"f:master":{"f:disableCSRFProtection":{}}}}`
+		jenkins := &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{ManagedFields: []metav1.ManagedFieldsEntry{
+				{Manager: "kubectl", Operation: metav1.ManagedFieldsOperationApply, FieldsType: "FieldsV1", FieldsV1: &metav1.FieldsV1{Raw: []byte(fields)}},
+			}},
+			Spec: v1alpha2.JenkinsSpec{From: &v1alpha2.JenkinsTemplateRef{Name: "csrf-disabled"}},
+		}
+
+		err := Apply(context.TODO(), fake.NewClientBuilder().WithObjects(jenkinsTemplate).Build(), jenkins)
+
+		require.NoError(t, err)
+		assert.False(t, jenkins.Spec.Master.DisableCSRFProtection)
+		assert.Equal(t, jenkinsTemplate.Spec.Template.Master.Containers, jenkins.Spec.Master.Containers)
+	})
+	t.Run("template not found", func(t *testing.T) {
+		jenkins := &v1alpha2.Jenkins{Spec: v1alpha2.JenkinsSpec{From: &v1alpha2.JenkinsTemplateRef{Name: "missing"}}}
+
+		err := Apply(context.TODO(), fake.NewClientBuilder().Build(), jenkins)
+
+		assert.EqualError(t, err, "JenkinsTemplate 'missing' referenced by spec.from not found")
+	})
+}
+
+func TestIndexJenkinsTemplate(t *testing.T) {
+	assert.Equal(t, []string{"golden"}, IndexJenkinsTemplate(&v1alpha2.Jenkins{Spec: v1alpha2.JenkinsSpec{From: &v1alpha2.JenkinsTemplateRef{Name: "golden"}}}))
+	assert.Nil(t, IndexJenkinsTemplate(&v1alpha2.Jenkins{}))
+	assert.Nil(t, IndexJenkinsTemplate(&v1alpha2.JenkinsTemplate{}))
+}
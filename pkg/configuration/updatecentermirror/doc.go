@@ -0,0 +1,2 @@
+// Package updatecentermirror generates the signed update center JSON with only approved plugins
+package updatecentermirror
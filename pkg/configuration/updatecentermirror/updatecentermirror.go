@@ -0,0 +1,176 @@
+package updatecentermirror
+
+import (
+	"path"
+	"sort"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
+	"github.com/jenkinsci/kubernetes-operator/pkg/plugins"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultRefreshInterval = uint64(86400)
+	// maxUpdateCenterSize is the size limit of the generated JSON, it has to fit in the config map
+	maxUpdateCenterSize = 1000 * 1024
+
+	pluginsKey      = "plugins"
+	warningsKey     = "warnings"
+	deprecationsKey = "deprecations"
+	coreWarningType = "core"
+)
+
+// GetUpstreamURL returns the URL of the update center JSON which the mirror is generated from
+func GetUpstreamURL(settings v1alpha2.UpdateCenterMirror) string {
+	if len(settings.UpstreamURL) > 0 {
+		return settings.UpstreamURL
+	}
+	return constants.DefaultUpdateCenterURL
+}
+
+// GetRefreshInterval returns the interval of the update center mirror generation
+func GetRefreshInterval(settings v1alpha2.UpdateCenterMirror) time.Duration {
+	interval := settings.RefreshInterval
+	if interval == 0 {
+		interval = defaultRefreshInterval
+	}
+	return time.Duration(interval) * time.Second
+}
+
+// ApprovedPlugins returns sorted names of plugins from the upstream update center which are in spec.master.basePlugins,
+// spec.master.plugins or match spec.updateCenterMirror.plugins, and names of their required dependencies.
+// Plugins which aren't allowed by spec.plugins.policy are skipped.
+func ApprovedPlugins(jenkins *v1alpha2.Jenkins, upstream map[string]interface{}) []string {
+	upstreamPlugins, _ := upstream[pluginsKey].(map[string]interface{})
+
+	var queue []string
+	for _, plugin := range append(append([]v1alpha2.Plugin{}, jenkins.Spec.Master.BasePlugins...), jenkins.Spec.Master.Plugins...) {
+		queue = append(queue, plugin.Name)
+	}
+	if jenkins.Spec.UpdateCenterMirror != nil {
+		for name := range upstreamPlugins {
+			for _, pattern := range jenkins.Spec.UpdateCenterMirror.Plugins {
+				if matched, _ := path.Match(pattern, name); matched {
+					queue = append(queue, name)
+					break
+				}
+			}
+		}
+	}
+
+	var policy *plugins.Policy
+	if jenkins.Spec.Plugins != nil && jenkins.Spec.Plugins.Policy != nil {
+		converted := jenkins.Spec.Plugins.Policy.ToPolicy()
+		policy = &converted
+	}
+
+	approved := map[string]bool{}
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		if approved[name] {
+			continue
+		}
+		plugin, ok := upstreamPlugins[name].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		version, _ := plugin["version"].(string)
+		if policy != nil && len(policy.Verify([]plugins.Plugin{{Name: name, Version: version}})) > 0 {
+			continue
+		}
+
+		approved[name] = true
+		queue = append(queue, requiredDependencies(plugin)...)
+	}
+
+	names := make([]string, 0, len(approved))
+	for name := range approved {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func requiredDependencies(plugin map[string]interface{}) []string {
+	var names []string
+	dependencies, _ := plugin["dependencies"].([]interface{})
+	for _, value := range dependencies {
+		dependency, ok := value.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if optional, _ := dependency["optional"].(bool); optional {
+			continue
+		}
+		if name, ok := dependency["name"].(string); ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Strip returns the copy of the upstream update center with only approved plugins, their warnings and deprecations,
+// the other fields, e.g. core, are kept unchanged
+func Strip(upstream map[string]interface{}, approved []string) map[string]interface{} {
+	isApproved := map[string]bool{}
+	for _, name := range approved {
+		isApproved[name] = true
+	}
+
+	stripped := make(map[string]interface{}, len(upstream))
+	for key, value := range upstream {
+		stripped[key] = value
+	}
+
+	upstreamPlugins, _ := upstream[pluginsKey].(map[string]interface{})
+	strippedPlugins := map[string]interface{}{}
+	for name, plugin := range upstreamPlugins {
+		if isApproved[name] {
+			strippedPlugins[name] = plugin
+		}
+	}
+	stripped[pluginsKey] = strippedPlugins
+
+	if warnings, ok := upstream[warningsKey].([]interface{}); ok {
+		strippedWarnings := []interface{}{}
+		for _, value := range warnings {
+			warning, _ := value.(map[string]interface{})
+			warningType, _ := warning["type"].(string)
+			name, _ := warning["name"].(string)
+			if warningType == coreWarningType || isApproved[name] {
+				strippedWarnings = append(strippedWarnings, value)
+			}
+		}
+		stripped[warningsKey] = strippedWarnings
+	}
+
+	if deprecations, ok := upstream[deprecationsKey].(map[string]interface{}); ok {
+		strippedDeprecations := map[string]interface{}{}
+		for name, deprecation := range deprecations {
+			if isApproved[name] {
+				strippedDeprecations[name] = deprecation
+			}
+		}
+		stripped[deprecationsKey] = strippedDeprecations
+	}
+
+	return stripped
+}
+
+// Generate returns the signed update center JSON with approved plugins and the number of these plugins
+func Generate(jenkins *v1alpha2.Jenkins, upstream map[string]interface{}, keyPEM, certPEM []byte) ([]byte, int, error) {
+	approved := ApprovedPlugins(jenkins, upstream)
+	updateCenter, err := plugins.SignUpdateCenter(Strip(upstream, approved), keyPEM, certPEM)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(updateCenter) > maxUpdateCenterSize {
+		return nil, 0, errors.Errorf("generated update center with %d plugins has %d bytes, it exceeds the limit of %d bytes",
+			len(approved), len(updateCenter), maxUpdateCenterSize)
+	}
+	return updateCenter, len(approved), nil
+}
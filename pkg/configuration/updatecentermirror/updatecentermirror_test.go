@@ -0,0 +1,94 @@
+package updatecentermirror
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/plugins"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const upstreamJSON = `{
+  "id": "default",
+  "core": {"name": "core", "version": "2.319"},
+  "plugins": {
+    "git": {"name": "git", "version": "4.10.0", "dependencies": [
+      {"name": "git-client", "optional": false, "version": "3.10.0"},
+      {"name": "promoted-builds", "optional": true, "version": "3.10"}
+    ]},
+    "git-client": {"name": "git-client", "version": "3.10.0", "dependencies": []},
+    "promoted-builds": {"name": "promoted-builds", "version": "3.10", "dependencies": []},
+    "blueocean": {"name": "blueocean", "version": "1.25.2", "dependencies": []},
+    "blueocean-pipeline-editor": {"name": "blueocean-pipeline-editor", "version": "1.25.2", "dependencies": []},
+    "workflow-job": {"name": "workflow-job", "version": "2.42", "dependencies": []}
+  },
+  "warnings": [
+    {"type": "core", "name": "core", "id": "SECURITY-1"},
+    {"type": "plugin", "name": "git", "id": "SECURITY-2"},
+    {"type": "plugin", "name": "blueocean", "id": "SECURITY-3"}
+  ],
+  "deprecations": {"git-client": {"url": "https://example.com"}, "blueocean": {"url": "https://example.com"}},
+  "signature": {"correct_digest": "upstream"}
+}`
+
+func upstreamDocument(t *testing.T) map[string]interface{} {
+	document := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal([]byte(upstreamJSON), &document))
+	return document
+}
+
+func TestApprovedPlugins(t *testing.T) {
+	t.Run("plugins from Jenkins CR and their required dependencies", func(t *testing.T) {
+		jenkins := &v1alpha2.Jenkins{Spec: v1alpha2.JenkinsSpec{
+			Master:             v1alpha2.JenkinsMaster{Plugins: []v1alpha2.Plugin{{Name: "git", Version: "4.10.0"}, {Name: "not-in-update-center", Version: "1.0"}}},
+			UpdateCenterMirror: &v1alpha2.UpdateCenterMirror{},
+		}}
+
+		assert.Equal(t, []string{"git", "git-client"}, ApprovedPlugins(jenkins, upstreamDocument(t)))
+	})
+	t.Run("patterns and plugin policy", func(t *testing.T) {
+		jenkins := &v1alpha2.Jenkins{Spec: v1alpha2.JenkinsSpec{
+			Master:             v1alpha2.JenkinsMaster{BasePlugins: []v1alpha2.Plugin{{Name: "workflow-job", Version: "2.42"}}},
+			Plugins:            &v1alpha2.Plugins{Policy: &v1alpha2.PluginPolicy{Deny: []string{"blueocean-pipeline-*"}}},
+			UpdateCenterMirror: &v1alpha2.UpdateCenterMirror{Plugins: []string{"blueocean*"}},
+		}}
+
+		assert.Equal(t, []string{"blueocean", "workflow-job"}, ApprovedPlugins(jenkins, upstreamDocument(t)))
+	})
+}
+
+func TestStrip(t *testing.T) {
+	got := Strip(upstreamDocument(t), []string{"git", "git-client"})
+
+	assert.Equal(t, upstreamDocument(t)["core"], got["core"])
+	assert.Len(t, got["plugins"], 2)
+	assert.Contains(t, got["plugins"], "git")
+	assert.Contains(t, got["plugins"], "git-client")
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"type": "core", "name": "core", "id": "SECURITY-1"},
+		map[string]interface{}{"type": "plugin", "name": "git", "id": "SECURITY-2"},
+	}, got["warnings"])
+	assert.Equal(t, map[string]interface{}{"git-client": map[string]interface{}{"url": "https://example.com"}}, got["deprecations"])
+}
+
+func TestGenerate(t *testing.T) {
+	keyPEM, certPEM, err := plugins.NewSigningCertificate("test", time.Hour)
+	require.NoError(t, err)
+	jenkins := &v1alpha2.Jenkins{Spec: v1alpha2.JenkinsSpec{
+		Master:             v1alpha2.JenkinsMaster{Plugins: []v1alpha2.Plugin{{Name: "git", Version: "4.10.0"}}},
+		UpdateCenterMirror: &v1alpha2.UpdateCenterMirror{},
+	}}
+
+	updateCenter, approvedPlugins, err := Generate(jenkins, upstreamDocument(t), keyPEM, certPEM)
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, approvedPlugins)
+	generated := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(updateCenter, &generated))
+	assert.Len(t, generated["plugins"], 2)
+	assert.NotEqual(t, "upstream", generated["signature"].(map[string]interface{})["correct_digest"])
+}
@@ -0,0 +1,295 @@
+package agents
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	jenkinsclient "github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/securityprofile"
+	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
+	"github.com/jenkinsci/kubernetes-operator/pkg/groovy"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8s "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// ConfigurationType is the configuration type of agent pod templates stored in status.appliedGroovyScripts
+	ConfigurationType = "user-agents"
+
+	agentsSource = "spec.agents"
+	agentsName   = "agents.groovy"
+
+	// jnlpContainerName is the name of the container which is configured by the Kubernetes plugin to connect to Jenkins
+	jnlpContainerName = "jnlp"
+
+	linuxWorkingDir   = "/home/jenkins/agent"
+	windowsWorkingDir = `C:\home\jenkins\agent`
+)
+
+// Agents defines client for agent pod templates
+type Agents interface {
+	Ensure() (requeue bool, err error)
+	Plan() ([]v1alpha2.PlannedConfigurationChange, error)
+}
+
+type agents struct {
+	groovyClient *groovy.Groovy
+	jenkins      *v1alpha2.Jenkins
+}
+
+// New creates new instance of Agents
+func New(jenkinsClient jenkinsclient.Jenkins, k8sClient k8s.Client, jenkins *v1alpha2.Jenkins) Agents {
+	return &agents{
+		groovyClient: groovy.New(jenkinsClient, k8sClient, jenkins, ConfigurationType, v1alpha2.Customization{}),
+		jenkins:      jenkins,
+	}
+}
+
+// Ensure synchronizes pod templates of the kubernetes cloud with spec.agents.podTemplates
+func (a *agents) Ensure() (requeue bool, err error) {
+	if !a.isManaged() {
+		return false, nil
+	}
+
+	groovyScript, err := RenderGroovyScript(a.podTemplates(), BuildPodLabels(a.jenkins), a.maxConcurrent(), securityprofile.Get(a.jenkins))
+	if err != nil {
+		return true, err
+	}
+	return a.groovyClient.EnsureGenerated(agentsSource, agentsName, groovyScript)
+}
+
+// Plan returns the agents groovy script if it would be run by Ensure
+func (a *agents) Plan() ([]v1alpha2.PlannedConfigurationChange, error) {
+	if !a.isManaged() {
+		return nil, nil
+	}
+
+	groovyScript, err := RenderGroovyScript(a.podTemplates(), BuildPodLabels(a.jenkins), a.maxConcurrent(), securityprofile.Get(a.jenkins))
+	if err != nil {
+		return nil, err
+	}
+	return a.groovyClient.PlanGenerated(agentsSource, agentsName, groovyScript)
+}
+
+func (a *agents) podTemplates() []v1alpha2.AgentPodTemplate {
+	if a.jenkins.Spec.Agents == nil {
+		return nil
+	}
+	return a.jenkins.Spec.Agents.PodTemplates
+}
+
+func (a *agents) maxConcurrent() *int32 {
+	if a.jenkins.Spec.Agents == nil {
+		return nil
+	}
+	return a.jenkins.Spec.Agents.MaxConcurrent
+}
+
+// BuildPodLabels returns labels of agent pods created from spec.agents.podTemplates
+func BuildPodLabels(jenkins *v1alpha2.Jenkins) map[string]string {
+	return map[string]string{constants.LabelAgentOfKey: jenkins.Name}
+}
+
+// isManaged returns true if spec.agents is set or the pod templates from it were created before,
+// so the pod templates are removed from Jenkins when spec.agents.podTemplates is cleared
+func (a *agents) isManaged() bool {
+	if len(a.podTemplates()) > 0 || a.maxConcurrent() != nil {
+		return true
+	}
+	for _, applied := range a.jenkins.Status.AppliedGroovyScripts {
+		if applied.ConfigurationType == ConfigurationType {
+			return true
+		}
+	}
+	return false
+}
+
+type podTemplate struct {
+	Name        string `json:"name"`
+	Label       string `json:"label"`
+	IdleMinutes int32  `json:"idleMinutes"`
+	YAML        string `json:"yaml"`
+}
+
+// RenderGroovyScript returns groovy script which replaces pod templates created by the operator and sets the container cap
+// of the kubernetes cloud, the pod templates are passed to the script as base64 encoded JSON so it doesn't need any escaping
+func RenderGroovyScript(templates []v1alpha2.AgentPodTemplate, podLabels map[string]string, maxConcurrent *int32,
+	profile v1alpha2.SecurityProfile) (string, error) {
+	rendered := []podTemplate{}
+	for _, template := range templates {
+		podYAML, err := RenderPodYAML(template, podLabels, profile)
+		if err != nil {
+			return "", err
+		}
+
+		labels := template.Labels
+		if len(labels) == 0 {
+			labels = []string{template.Name}
+		}
+		rendered = append(rendered, podTemplate{
+			Name:        template.Name,
+			Label:       strings.Join(labels, " "),
+			IdleMinutes: template.IdleMinutes,
+			YAML:        podYAML,
+		})
+	}
+
+	data, err := json.Marshal(rendered)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	containerCap := ""
+	if maxConcurrent != nil {
+		containerCap = fmt.Sprintf("%d", *maxConcurrent)
+	}
+	return fmt.Sprintf(podTemplatesGroovyScriptFmt, base64.StdEncoding.EncodeToString(data), containerCap), nil
+}
+
+// RenderPodYAML returns the raw pod YAML of the pod template, the Kubernetes plugin adds the agent connection
+// settings to the jnlp container, security contexts of Linux pods comply with the security profile
+func RenderPodYAML(template v1alpha2.AgentPodTemplate, podLabels map[string]string, profile v1alpha2.SecurityProfile) (string, error) {
+	windows := template.OS == v1alpha2.WindowsAgentOS
+	image, workingDir, os := constants.DefaultLinuxAgentImage, linuxWorkingDir, v1alpha2.LinuxAgentOS
+	if windows {
+		image, workingDir, os = constants.DefaultWindowsAgentImage, windowsWorkingDir, v1alpha2.WindowsAgentOS
+	}
+	if len(template.Image) > 0 {
+		image = template.Image
+	}
+
+	nodeSelector := map[string]string{corev1.LabelOSStable: string(os)}
+	for key, value := range template.NodeSelector {
+		nodeSelector[key] = value
+	}
+
+	containers := []corev1.Container{
+		{
+			Name:       jnlpContainerName,
+			Image:      image,
+			WorkingDir: workingDir,
+			Resources:  template.Resources,
+		},
+	}
+	for _, container := range template.Containers {
+		command, args := container.Command, container.Args
+		if len(command) == 0 {
+			command, args = keepRunningCommand(windows)
+		}
+		containers = append(containers, corev1.Container{
+			Name:       container.Name,
+			Image:      container.Image,
+			Command:    command,
+			Args:       args,
+			Env:        container.Env,
+			WorkingDir: workingDir,
+			Resources:  container.Resources,
+		})
+	}
+
+	pod := corev1.Pod{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+		ObjectMeta: metav1.ObjectMeta{Labels: podLabels},
+		Spec: corev1.PodSpec{
+			NodeSelector:    nodeSelector,
+			Tolerations:     template.Tolerations,
+			SecurityContext: podSecurityContext(template, windows),
+			Containers:      containers,
+		},
+	}
+	// Pod Security Standards don't restrict security contexts of Windows pods
+	if !windows {
+		pod.Spec.SecurityContext = securityprofile.PodSecurityContext(profile, pod.Spec.SecurityContext)
+		for i := range pod.Spec.Containers {
+			pod.Spec.Containers[i] = securityprofile.Container(profile, pod.Spec.Containers[i])
+		}
+		pod.Spec.Volumes = securityprofile.Volumes(profile)
+	}
+	podYAML, err := yaml.Marshal(pod)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	return string(podYAML), nil
+}
+
+func keepRunningCommand(windows bool) (command []string, args []string) {
+	if windows {
+		return []string{"powershell"}, []string{"Start-Sleep", "2147483"}
+	}
+	return []string{"sleep"}, []string{"infinity"}
+}
+
+// podSecurityContext returns the user of agent containers, Windows containers don't support UIDs
+func podSecurityContext(template v1alpha2.AgentPodTemplate, windows bool) *corev1.PodSecurityContext {
+	if windows {
+		if len(template.RunAsUserName) == 0 {
+			return nil
+		}
+		runAsUserName := template.RunAsUserName
+		return &corev1.PodSecurityContext{
+			WindowsOptions: &corev1.WindowsSecurityContextOptions{RunAsUserName: &runAsUserName},
+		}
+	}
+	if template.RunAsUser == nil && template.RunAsGroup == nil {
+		return nil
+	}
+	return &corev1.PodSecurityContext{
+		RunAsUser:  template.RunAsUser,
+		RunAsGroup: template.RunAsGroup,
+		FSGroup:    template.RunAsGroup,
+	}
+}
+
+const podTemplatesGroovyScriptFmt = `
+import groovy.json.JsonSlurper
+import jenkins.model.Jenkins
+import org.csanchez.jenkins.plugins.kubernetes.PodAnnotation
+import org.csanchez.jenkins.plugins.kubernetes.PodTemplate
+
+def templates = new JsonSlurper().parseText(new String('%s'.decodeBase64(), 'UTF-8'))
+def containerCap = '%s'
+def jenkins = Jenkins.get()
+def kubernetes = jenkins.clouds.getByName('kubernetes')
+if (kubernetes == null) {
+    throw new IllegalStateException("Cloud 'kubernetes' not found")
+}
+
+def managedBy = new PodAnnotation('jenkins.io/managed-by', 'jenkins-operator')
+kubernetes.getTemplates().findAll { it.getAnnotations().contains(managedBy) }.each { template ->
+    println "Removing pod template '${template.getName()}'"
+    kubernetes.removeTemplate(template)
+}
+
+for (template in templates) {
+    println "Adding pod template '${template.name}'"
+    def podTemplate = new PodTemplate()
+    podTemplate.setName(template.name)
+    podTemplate.setLabel(template.label)
+    podTemplate.setIdleMinutes(template.idleMinutes)
+    podTemplate.setYaml(template.yaml)
+    podTemplate.setAnnotations([managedBy])
+    kubernetes.addTemplate(podTemplate)
+}
+
+// the container cap set by the operator is kept in Jenkins home, so it's reset only if it hasn't been changed since
+def operatorContainerCap = new File(jenkins.getRootDir(), 'jenkins-operator-container-cap')
+if (containerCap) {
+    println "Setting container cap to ${containerCap}"
+    kubernetes.setContainerCapStr(containerCap)
+    operatorContainerCap.text = containerCap
+} else if (operatorContainerCap.exists()) {
+    if (kubernetes.getContainerCapStr() == operatorContainerCap.text) {
+        println "Resetting container cap"
+        kubernetes.setContainerCapStr('')
+    }
+    operatorContainerCap.delete()
+}
+
+jenkins.save()
+`
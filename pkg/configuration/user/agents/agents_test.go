@@ -0,0 +1,113 @@
+package agents
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"regexp"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+func renderPod(t *testing.T, template v1alpha2.AgentPodTemplate) corev1.Pod {
+	podYAML, err := RenderPodYAML(template, map[string]string{constants.LabelAgentOfKey: "jenkins"}, v1alpha2.CustomSecurityProfile)
+	require.NoError(t, err)
+	pod := corev1.Pod{}
+	require.NoError(t, yaml.Unmarshal([]byte(podYAML), &pod))
+	return pod
+}
+
+func TestRenderPodYAML(t *testing.T) {
+	t.Run("linux", func(t *testing.T) {
+		uid := int64(1000)
+		pod := renderPod(t, v1alpha2.AgentPodTemplate{
+			Name:       "maven",
+			RunAsUser:  &uid,
+			RunAsGroup: &uid,
+			Containers: []v1alpha2.AgentContainer{{Name: "maven", Image: "maven:3.8-openjdk-11"}},
+		})
+
+		assert.Equal(t, map[string]string{constants.LabelAgentOfKey: "jenkins"}, pod.Labels)
+		assert.Equal(t, map[string]string{"kubernetes.io/os": "linux"}, pod.Spec.NodeSelector)
+		assert.Equal(t, &corev1.PodSecurityContext{RunAsUser: &uid, RunAsGroup: &uid, FSGroup: &uid}, pod.Spec.SecurityContext)
+		require.Len(t, pod.Spec.Containers, 2)
+		assert.Equal(t, "jnlp", pod.Spec.Containers[0].Name)
+		assert.Equal(t, constants.DefaultLinuxAgentImage, pod.Spec.Containers[0].Image)
+		assert.Equal(t, "/home/jenkins/agent", pod.Spec.Containers[0].WorkingDir)
+		assert.Equal(t, []string{"sleep"}, pod.Spec.Containers[1].Command)
+		assert.Equal(t, []string{"infinity"}, pod.Spec.Containers[1].Args)
+	})
+	t.Run("windows", func(t *testing.T) {
+		pod := renderPod(t, v1alpha2.AgentPodTemplate{
+			Name:          "dotnet",
+			OS:            v1alpha2.WindowsAgentOS,
+			RunAsUserName: "ContainerUser",
+			NodeSelector:  map[string]string{"node.kubernetes.io/windows-build": "ltsc2019"},
+			Tolerations:   []corev1.Toleration{{Key: "os", Value: "windows", Effect: corev1.TaintEffectNoSchedule}},
+			Containers:    []v1alpha2.AgentContainer{{Name: "dotnet", Image: "mcr.microsoft.com/dotnet/sdk:5.0-windowsservercore-ltsc2019"}},
+		})
+
+		assert.Equal(t, map[string]string{"kubernetes.io/os": "windows", "node.kubernetes.io/windows-build": "ltsc2019"}, pod.Spec.NodeSelector)
+		assert.Equal(t, []corev1.Toleration{{Key: "os", Value: "windows", Effect: corev1.TaintEffectNoSchedule}}, pod.Spec.Tolerations)
+		require.NotNil(t, pod.Spec.SecurityContext)
+		assert.Nil(t, pod.Spec.SecurityContext.RunAsUser)
+		assert.Equal(t, "ContainerUser", *pod.Spec.SecurityContext.WindowsOptions.RunAsUserName)
+		assert.Equal(t, constants.DefaultWindowsAgentImage, pod.Spec.Containers[0].Image)
+		assert.Equal(t, `C:\home\jenkins\agent`, pod.Spec.Containers[0].WorkingDir)
+		assert.Equal(t, []string{"powershell"}, pod.Spec.Containers[1].Command)
+	})
+}
+
+func TestRenderPodYAML_RestrictedSecurityProfile(t *testing.T) {
+	podYAML, err := RenderPodYAML(v1alpha2.AgentPodTemplate{
+		Name:       "maven",
+		Containers: []v1alpha2.AgentContainer{{Name: "maven", Image: "maven:3.8-openjdk-11"}},
+	}, nil, v1alpha2.RestrictedSecurityProfile)
+	require.NoError(t, err)
+	pod := corev1.Pod{}
+	require.NoError(t, yaml.Unmarshal([]byte(podYAML), &pod))
+
+	uid := int64(1000)
+	assert.Equal(t, &uid, pod.Spec.SecurityContext.RunAsUser)
+	assert.Equal(t, corev1.SeccompProfileTypeRuntimeDefault, pod.Spec.SecurityContext.SeccompProfile.Type)
+	require.Len(t, pod.Spec.Containers, 2)
+	for _, container := range pod.Spec.Containers {
+		assert.True(t, *container.SecurityContext.ReadOnlyRootFilesystem, container.Name)
+		assert.Equal(t, []corev1.Capability{"ALL"}, container.SecurityContext.Capabilities.Drop, container.Name)
+		assert.Equal(t, []corev1.VolumeMount{{Name: "tmp", MountPath: "/tmp"}}, container.VolumeMounts, container.Name)
+	}
+	require.Len(t, pod.Spec.Volumes, 1)
+	assert.Equal(t, "tmp", pod.Spec.Volumes[0].Name)
+}
+
+func TestRenderGroovyScript(t *testing.T) {
+	templatesPattern := regexp.MustCompile(`new String\('([^']+)'\.decodeBase64\(\), 'UTF-8'\)`)
+	templates := []v1alpha2.AgentPodTemplate{
+		{Name: "maven", Labels: []string{"maven", "java"}, IdleMinutes: 5},
+		{Name: "windows", OS: v1alpha2.WindowsAgentOS},
+	}
+
+	maxConcurrent := int32(10)
+
+	got, err := RenderGroovyScript(templates, nil, &maxConcurrent, v1alpha2.CustomSecurityProfile)
+
+	require.NoError(t, err)
+	assert.Contains(t, got, "def containerCap = '10'")
+	match := templatesPattern.FindStringSubmatch(got)
+	require.Len(t, match, 2)
+	data, err := base64.StdEncoding.DecodeString(match[1])
+	require.NoError(t, err)
+	var decoded []podTemplate
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	require.Len(t, decoded, 2)
+	assert.Equal(t, "maven java", decoded[0].Label)
+	assert.Equal(t, int32(5), decoded[0].IdleMinutes)
+	assert.Equal(t, "windows", decoded[1].Label)
+	assert.Contains(t, decoded[1].YAML, "kubernetes.io/os: windows")
+}
@@ -0,0 +1,2 @@
+// Package agents adds pod templates from spec.agents to the kubernetes cloud of Jenkins
+package agents
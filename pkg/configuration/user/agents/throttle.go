@@ -0,0 +1,28 @@
+package agents
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Usage is the number of active agent pods of Jenkins
+type Usage struct {
+	Running int
+	Pending int
+}
+
+// GetUsage returns the usage of active agent pods, the terminating pods aren't counted
+func GetUsage(pods []corev1.Pod) Usage {
+	usage := Usage{}
+	for _, pod := range pods {
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+		switch pod.Status.Phase {
+		case corev1.PodRunning:
+			usage.Running++
+		case corev1.PodPending, "":
+			usage.Pending++
+		}
+	}
+	return usage
+}
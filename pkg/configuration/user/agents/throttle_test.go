@@ -0,0 +1,29 @@
+package agents
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetUsage(t *testing.T) {
+	newPod := func(name string, phase corev1.PodPhase) corev1.Pod {
+		return corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name}, Status: corev1.PodStatus{Phase: phase}}
+	}
+	deleted := metav1.Now()
+	terminating := newPod("terminating", corev1.PodRunning)
+	terminating.DeletionTimestamp = &deleted
+	pods := []corev1.Pod{
+		newPod("running-1", corev1.PodRunning),
+		newPod("running-2", corev1.PodRunning),
+		newPod("pending", corev1.PodPending),
+		newPod("scheduled", ""),
+		newPod("succeeded", corev1.PodSucceeded),
+		terminating,
+	}
+
+	assert.Equal(t, Usage{Running: 2, Pending: 2}, GetUsage(pods))
+	assert.Equal(t, Usage{}, GetUsage(nil))
+}
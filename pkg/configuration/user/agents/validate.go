@@ -0,0 +1,67 @@
+package agents
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// Validate validates spec.agents
+func Validate(agents *v1alpha2.Agents) []string {
+	if agents == nil {
+		return nil
+	}
+
+	var messages []string
+	if agents.MaxConcurrent != nil && *agents.MaxConcurrent < 1 {
+		messages = append(messages, "spec.agents.maxConcurrent must be at least 1")
+	}
+
+	names := map[string]bool{}
+	for i, template := range agents.PodTemplates {
+		field := fmt.Sprintf("spec.agents.podTemplates[%d]", i)
+		if len(template.Name) == 0 {
+			messages = append(messages, fmt.Sprintf("%s.name is not set", field))
+		} else if names[template.Name] {
+			messages = append(messages, fmt.Sprintf("%s.name '%s' is duplicated", field, template.Name))
+		}
+		names[template.Name] = true
+
+		for _, label := range template.Labels {
+			if len(label) == 0 || strings.ContainsAny(label, " \t\n") {
+				messages = append(messages, fmt.Sprintf("%s.labels '%s' must be a non empty label without whitespaces", field, label))
+			}
+		}
+		if template.IdleMinutes < 0 {
+			messages = append(messages, fmt.Sprintf("%s.idleMinutes can't be negative", field))
+		}
+
+		if template.OS == v1alpha2.WindowsAgentOS {
+			if template.RunAsUser != nil || template.RunAsGroup != nil {
+				messages = append(messages, fmt.Sprintf("%s runAsUser and runAsGroup aren't supported on Windows, use runAsUserName", field))
+			}
+		} else if len(template.RunAsUserName) > 0 {
+			messages = append(messages, fmt.Sprintf("%s.runAsUserName is supported only on Windows, use runAsUser", field))
+		}
+
+		containerNames := map[string]bool{jnlpContainerName: true}
+		for j, container := range template.Containers {
+			containerField := fmt.Sprintf("%s.containers[%d]", field, j)
+			if errs := validation.IsDNS1123Label(container.Name); len(errs) > 0 {
+				messages = append(messages, fmt.Sprintf("%s.name '%s' is invalid: %s", containerField, container.Name, strings.Join(errs, ", ")))
+			} else if containerNames[container.Name] {
+				messages = append(messages, fmt.Sprintf("%s.name '%s' is duplicated or reserved", containerField, container.Name))
+			}
+			containerNames[container.Name] = true
+
+			if len(container.Image) == 0 {
+				messages = append(messages, fmt.Sprintf("%s.image is not set", containerField))
+			}
+		}
+	}
+
+	return messages
+}
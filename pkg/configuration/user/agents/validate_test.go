@@ -0,0 +1,49 @@
+package agents
+
+import (
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	t.Run("not set", func(t *testing.T) {
+		assert.Nil(t, Validate(nil))
+	})
+	t.Run("happy", func(t *testing.T) {
+		uid := int64(1000)
+		agents := &v1alpha2.Agents{PodTemplates: []v1alpha2.AgentPodTemplate{
+			{Name: "maven", Labels: []string{"maven"}, RunAsUser: &uid, Containers: []v1alpha2.AgentContainer{{Name: "maven", Image: "maven:3.8"}}},
+			{Name: "windows", OS: v1alpha2.WindowsAgentOS, RunAsUserName: "ContainerUser"},
+		}}
+
+		assert.Nil(t, Validate(agents))
+	})
+	t.Run("invalid max concurrent", func(t *testing.T) {
+		maxConcurrent := int32(0)
+
+		assert.Equal(t, []string{"spec.agents.maxConcurrent must be at least 1"}, Validate(&v1alpha2.Agents{MaxConcurrent: &maxConcurrent}))
+	})
+	t.Run("invalid pod templates", func(t *testing.T) {
+		uid := int64(1000)
+		agents := &v1alpha2.Agents{PodTemplates: []v1alpha2.AgentPodTemplate{
+			{Name: "maven", Labels: []string{"maven java"}, RunAsUserName: "ContainerUser"},
+			{Name: "maven", OS: v1alpha2.WindowsAgentOS, RunAsUser: &uid, Containers: []v1alpha2.AgentContainer{
+				{Name: "jnlp", Image: "custom"},
+				{Name: "Build"},
+			}},
+		}}
+
+		assert.Equal(t, []string{
+			"spec.agents.podTemplates[0].labels 'maven java' must be a non empty label without whitespaces",
+			"spec.agents.podTemplates[0].runAsUserName is supported only on Windows, use runAsUser",
+			"spec.agents.podTemplates[1].name 'maven' is duplicated",
+			"spec.agents.podTemplates[1] runAsUser and runAsGroup aren't supported on Windows, use runAsUserName",
+			"spec.agents.podTemplates[1].containers[0].name 'jnlp' is duplicated or reserved",
+			"spec.agents.podTemplates[1].containers[1].name 'Build' is invalid: a lowercase RFC 1123 label must consist of lower case alphanumeric characters or '-', and must start and end with an alphanumeric character (e.g. 'my-name',  or '123-abc', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?')",
+			"spec.agents.podTemplates[1].containers[1].image is not set",
+		}, Validate(agents))
+	})
+}
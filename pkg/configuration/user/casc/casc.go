@@ -14,9 +14,14 @@ import (
 
 const groovyUtf8MaxStringLength = 65535
 
+// ConfigurationType is the configuration type of Configuration as Code scripts stored in status.appliedGroovyScripts
+const ConfigurationType = "user-casc"
+
 // ConfigurationAsCode defines client for configurationAsCode
 type ConfigurationAsCode interface {
 	Ensure(jenkins *v1alpha2.Jenkins) (requeue bool, err error)
+	Plan() ([]v1alpha2.PlannedConfigurationChange, error)
+	Reload() error
 }
 
 type configurationAsCode struct {
@@ -26,7 +31,7 @@ type configurationAsCode struct {
 // New creates new instance of ConfigurationAsCode
 func New(jenkinsClient jenkinsclient.Jenkins, k8sClient k8s.Client, jenkins *v1alpha2.Jenkins) ConfigurationAsCode {
 	return &configurationAsCode{
-		groovyClient: groovy.New(jenkinsClient, k8sClient, jenkins, "user-casc", jenkins.Spec.ConfigurationAsCode.Customization),
+		groovyClient: groovy.New(jenkinsClient, k8sClient, jenkins, ConfigurationType, jenkins.Spec.ConfigurationAsCode.Customization),
 	}
 }
 
@@ -37,11 +42,22 @@ func (c *configurationAsCode) Ensure(_ *v1alpha2.Jenkins) (requeue bool, err err
 		return requeue, err
 	}
 
-	return c.groovyClient.Ensure(func(name string) bool {
-		return strings.HasSuffix(name, ".yaml") || strings.HasSuffix(name, ".yml")
-	}, func(groovyScript string) string {
-		return fmt.Sprintf(applyConfigurationAsCodeGroovyScriptFmt, prepareScript(groovyScript))
-	})
+	return c.groovyClient.Ensure(IsConfigurationAsCodeFile, UpdateGroovyScript)
+}
+
+// Plan returns Configuration as Code files which would be applied by Ensure
+func (c *configurationAsCode) Plan() ([]v1alpha2.PlannedConfigurationChange, error) {
+	return c.groovyClient.Plan(IsConfigurationAsCodeFile, UpdateGroovyScript)
+}
+
+// Reload applies all Configuration as Code files again, including the ones which have been already applied
+func (c *configurationAsCode) Reload() error {
+	return c.groovyClient.Run(IsConfigurationAsCodeFile, UpdateGroovyScript)
+}
+
+// UpdateGroovyScript wraps Configuration as Code YAML with groovy script which applies it
+func UpdateGroovyScript(groovyScript string) string {
+	return fmt.Sprintf(applyConfigurationAsCodeGroovyScriptFmt, prepareScript(groovyScript))
 }
 
 const applyConfigurationAsCodeGroovyScriptFmt = `
@@ -58,6 +74,11 @@ def source = io.jenkins.plugins.casc.yaml.YamlSource.of(stream)
 io.jenkins.plugins.casc.ConfigurationAsCode.get().configureWith(source)
 `
 
+// IsConfigurationAsCodeFile returns true if the ConfigMap key is Configuration as Code YAML file
+func IsConfigurationAsCodeFile(name string) bool {
+	return strings.HasSuffix(name, ".yaml") || strings.HasSuffix(name, ".yml")
+}
+
 func prepareScript(script string) string {
 	var slicedScript []string
 	if len(script) > groovyUtf8MaxStringLength {
@@ -0,0 +1,93 @@
+package casc
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// variablePattern matches the variables of Configuration as Code files, e.g. ${GITHUB_TOKEN} or ${PORT:-8080}, the
+// variables escaped with ^ aren't resolved by the plugin
+var variablePattern = regexp.MustCompile(`(\^?)\$\{([^{}:]+)(:-[^}]*)?\}`)
+
+// rootElements are the root elements of Configuration as Code, the elements prefixed with x- hold YAML anchors and
+// they're ignored by the plugin
+var rootElements = []string{"jenkins", "credentials", "unclassified", "tool", "security", "jobs", "appearance", "configuration-as-code"}
+
+// Validate validates Configuration as Code files from the data of ConfigMap, every file must be the YAML document
+// with the mapping of known root elements, e.g. jenkins or unclassified
+func Validate(configMapName string, data map[string]string) []string {
+	var messages []string
+	for _, name := range sortedConfigurationAsCodeFiles(data) {
+		var document interface{}
+		if err := yaml.Unmarshal([]byte(data[name]), &document); err != nil {
+			messages = append(messages, fmt.Sprintf("ConfigMap '%s' file '%s' isn't valid YAML: %s", configMapName, name, err))
+			continue
+		}
+		elements, ok := document.(map[string]interface{})
+		if document != nil && !ok {
+			messages = append(messages, fmt.Sprintf("ConfigMap '%s' file '%s' must be the mapping of Configuration as Code root elements", configMapName, name))
+			continue
+		}
+		for _, element := range unknownRootElements(elements) {
+			messages = append(messages, fmt.Sprintf("ConfigMap '%s' file '%s' has unknown Configuration as Code root element '%s', the root elements are %s",
+				configMapName, name, element, strings.Join(rootElements, ", ")))
+		}
+	}
+	return messages
+}
+
+func unknownRootElements(elements map[string]interface{}) []string {
+	var unknown []string
+	for element := range elements {
+		if !isRootElement(element) && !strings.HasPrefix(element, "x-") {
+			unknown = append(unknown, element)
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
+}
+
+func isRootElement(element string) bool {
+	for _, rootElement := range rootElements {
+		if rootElement == element {
+			return true
+		}
+	}
+	return false
+}
+
+// Variables returns the sorted names of variables without defaults used by Configuration as Code files from the data
+// of ConfigMap, the plugin resolves them from the files of spec.configurationAsCode.secret and environment variables
+func Variables(data map[string]string) []string {
+	found := map[string]bool{}
+	for _, name := range sortedConfigurationAsCodeFiles(data) {
+		for _, match := range variablePattern.FindAllStringSubmatch(data[name], -1) {
+			if len(match[1]) > 0 || len(match[3]) > 0 {
+				continue
+			}
+			found[match[2]] = true
+		}
+	}
+
+	var variables []string
+	for variable := range found {
+		variables = append(variables, variable)
+	}
+	sort.Strings(variables)
+	return variables
+}
+
+func sortedConfigurationAsCodeFiles(data map[string]string) []string {
+	var names []string
+	for name := range data {
+		if IsConfigurationAsCodeFile(name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,46 @@
+package casc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		data := map[string]string{
+			"1-jenkins.yaml": "jenkins:\n  systemMessage: hello\nunclassified:\n  location:\n    url: https://jenkins.example.com/\n",
+			"3-anchors.yaml": "x-credentials: &token\n  scope: GLOBAL\ncredentials:\n  system:\n    domainCredentials: []\n",
+			"2-empty.yaml":   "",
+			"README.md":      "not: [yaml",
+		}
+
+		assert.Empty(t, Validate("casc", data))
+	})
+	t.Run("invalid YAML and root element", func(t *testing.T) {
+		data := map[string]string{
+			"1-broken.yaml":  "jenkins: [systemMessage",
+			"2-list.yml":     "- jenkins\n",
+			"3-unknown.yaml": "jenkins:\n  systemMessage: hello\nsystemMessage: hello\nJenkins: {}\n",
+		}
+
+		messages := Validate("casc", data)
+
+		assert.Len(t, messages, 4)
+		assert.Contains(t, messages[0], "ConfigMap 'casc' file '1-broken.yaml' isn't valid YAML")
+		assert.Equal(t, "ConfigMap 'casc' file '2-list.yml' must be the mapping of Configuration as Code root elements", messages[1])
+		assert.Equal(t, "ConfigMap 'casc' file '3-unknown.yaml' has unknown Configuration as Code root element 'Jenkins', "+
+			"the root elements are jenkins, credentials, unclassified, tool, security, jobs, appearance, configuration-as-code", messages[2])
+		assert.Contains(t, messages[3], "unknown Configuration as Code root element 'systemMessage'")
+	})
+}
+
+func TestVariables(t *testing.T) {
+	data := map[string]string{
+		"1-jenkins.yaml": "jenkins:\n  systemMessage: ${MESSAGE}\n  numExecutors: ${EXECUTORS:-2}\n",
+		"2-creds.yaml":   "credentials:\n  token: ${GITHUB_TOKEN}\n  escaped: ^${NOT_RESOLVED}\n  file: ${readFile:${CERT_PATH}}\n  again: ${MESSAGE}\n",
+		"notes.txt":      "${IGNORED}",
+	}
+
+	assert.Equal(t, []string{"CERT_PATH", "GITHUB_TOKEN", "MESSAGE"}, Variables(data))
+}
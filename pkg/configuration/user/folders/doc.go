@@ -0,0 +1,2 @@
+// Package folders creates folders and views from spec.folders and spec.views
+package folders
@@ -0,0 +1,186 @@
+package folders
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	jenkinsclient "github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/groovy"
+
+	"github.com/pkg/errors"
+	k8s "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// ConfigurationType is the configuration type of folders and views stored in status.appliedGroovyScripts
+	ConfigurationType = "user-folders"
+
+	foldersSource = "spec.folders"
+	foldersName   = "folders.groovy"
+)
+
+// Folders defines client for folders and views
+type Folders interface {
+	Ensure() (requeue bool, err error)
+	Plan() ([]v1alpha2.PlannedConfigurationChange, error)
+}
+
+type folders struct {
+	groovyClient *groovy.Groovy
+	jenkins      *v1alpha2.Jenkins
+}
+
+// New creates new instance of Folders
+func New(jenkinsClient jenkinsclient.Jenkins, k8sClient k8s.Client, jenkins *v1alpha2.Jenkins) Folders {
+	return &folders{
+		groovyClient: groovy.New(jenkinsClient, k8sClient, jenkins, ConfigurationType, v1alpha2.Customization{}),
+		jenkins:      jenkins,
+	}
+}
+
+// Ensure creates folders and views, the script runs again only when spec.folders or spec.views change
+func (f *folders) Ensure() (requeue bool, err error) {
+	if len(f.jenkins.Spec.Folders) == 0 && len(f.jenkins.Spec.Views) == 0 {
+		return false, nil
+	}
+
+	groovyScript, err := RenderGroovyScript(f.jenkins.Spec.Folders, f.jenkins.Spec.Views)
+	if err != nil {
+		return true, err
+	}
+	return f.groovyClient.EnsureGenerated(foldersSource, foldersName, groovyScript)
+}
+
+// Plan returns the folders groovy script if it would be run by Ensure
+func (f *folders) Plan() ([]v1alpha2.PlannedConfigurationChange, error) {
+	if len(f.jenkins.Spec.Folders) == 0 && len(f.jenkins.Spec.Views) == 0 {
+		return nil, nil
+	}
+
+	groovyScript, err := RenderGroovyScript(f.jenkins.Spec.Folders, f.jenkins.Spec.Views)
+	if err != nil {
+		return nil, err
+	}
+	return f.groovyClient.PlanGenerated(foldersSource, foldersName, groovyScript)
+}
+
+type layout struct {
+	Folders []v1alpha2.Folder `json:"folders"`
+	Views   []v1alpha2.View   `json:"views"`
+}
+
+// RenderGroovyScript returns groovy script which creates folders, views and folder roles,
+// the layout is passed to the script as base64 encoded JSON so it doesn't need any escaping
+func RenderGroovyScript(folders []v1alpha2.Folder, views []v1alpha2.View) (string, error) {
+	data, err := json.Marshal(layout{Folders: folders, Views: views})
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	groovyScript := fmt.Sprintf(createFoldersAndViewsGroovyScriptFmt, base64.StdEncoding.EncodeToString(data))
+	if hasRoles(folders) {
+		groovyScript = roleStrategyImports + groovyScript + createFolderRolesGroovyScript
+	}
+	return groovyScript, nil
+}
+
+func hasRoles(folders []v1alpha2.Folder) bool {
+	for _, folder := range folders {
+		if len(folder.Roles) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+const createFoldersAndViewsGroovyScriptFmt = `
+import com.cloudbees.hudson.plugins.folder.Folder
+import groovy.json.JsonSlurper
+import hudson.model.ListView
+import jenkins.model.Jenkins
+
+def layout = new JsonSlurper().parseText(new String('%s'.decodeBase64(), 'UTF-8'))
+def jenkins = Jenkins.get()
+
+for (folder in layout.folders) {
+    def parent = jenkins
+    for (segment in folder.name.split('/')) {
+        def item = parent.getItem(segment)
+        if (item == null) {
+            println "Creating folder '${segment}' in '${parent.getFullName()}'"
+            item = parent.createProject(Folder, segment)
+        } else if (!(item instanceof Folder)) {
+            throw new IllegalStateException("Item '${item.getFullName()}' isn't a folder")
+        }
+        parent = item
+    }
+    if (folder.displayName) {
+        parent.setDisplayName(folder.displayName)
+    }
+    if (folder.description != null) {
+        parent.setDescription(folder.description)
+    }
+}
+
+for (view in layout.views) {
+    def owner = jenkins
+    if (view.folder) {
+        owner = jenkins.getItemByFullName(view.folder)
+        if (!(owner instanceof Folder)) {
+            throw new IllegalStateException("Folder '${view.folder}' of view '${view.name}' doesn't exist")
+        }
+    }
+    def listView = owner.getView(view.name)
+    if (listView == null) {
+        println "Creating view '${view.name}' in '${owner.getFullName()}'"
+        listView = new ListView(view.name, owner)
+        owner.addView(listView)
+    } else if (!(listView instanceof ListView)) {
+        throw new IllegalStateException("View '${view.name}' in '${owner.getFullName()}' isn't a list view")
+    }
+    listView.setDescription(view.description)
+    listView.setIncludeRegex(view.includeRegex ?: null)
+    listView.setRecurse(view.recurse == true)
+    listView.save()
+}
+`
+
+const roleStrategyImports = `
+import com.michelin.cio.hudson.plugins.rolestrategy.Role
+import com.michelin.cio.hudson.plugins.rolestrategy.RoleBasedAuthorizationStrategy
+import com.synopsys.arc.jenkins.plugins.rolestrategy.RoleType
+import hudson.security.Permission
+import java.util.regex.Pattern
+`
+
+const createFolderRolesGroovyScript = `
+def strategy = jenkins.getAuthorizationStrategy()
+if (!(strategy instanceof RoleBasedAuthorizationStrategy)) {
+    throw new IllegalStateException('Folder roles require the role-based authorization strategy of role-strategy plugin')
+}
+def roleMap = strategy.getRoleMap(RoleType.Project)
+for (folder in layout.folders) {
+    for (folderRole in folder.roles ?: []) {
+        def permissions = folderRole.permissions.collect { id ->
+            def permission = Permission.fromId(id)
+            if (permission == null) {
+                throw new IllegalStateException("Unknown permission '${id}' of role '${folderRole.name}'")
+            }
+            return permission
+        } as Set
+        def existing = roleMap.getRole(folderRole.name)
+        if (existing != null) {
+            roleMap.removeRole(existing)
+        }
+        def role = new Role(folderRole.name, '^' + Pattern.quote(folder.name) + '(/.*)?$', permissions)
+        println "Configuring role '${folderRole.name}' of folder '${folder.name}'"
+        strategy.addRole(RoleType.Project, role)
+        for (sid in folderRole.sids ?: []) {
+            strategy.assignRole(RoleType.Project, role, sid)
+        }
+    }
+}
+jenkins.save()
+`
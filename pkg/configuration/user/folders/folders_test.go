@@ -0,0 +1,50 @@
+package folders
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"regexp"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderGroovyScript(t *testing.T) {
+	layoutPattern := regexp.MustCompile(`new String\('([^']+)'\.decodeBase64\(\), 'UTF-8'\)`)
+
+	t.Run("folders and views", func(t *testing.T) {
+		folders := []v1alpha2.Folder{{Name: "team-a/services", Description: "Team's 'services'"}}
+		views := []v1alpha2.View{{Name: "All services", Folder: "team-a", IncludeRegex: ".*-service", Recurse: true}}
+
+		got, err := RenderGroovyScript(folders, views)
+
+		require.NoError(t, err)
+		assert.NotContains(t, got, "rolestrategy")
+		match := layoutPattern.FindStringSubmatch(got)
+		require.Len(t, match, 2)
+		data, err := base64.StdEncoding.DecodeString(match[1])
+		require.NoError(t, err)
+		decoded := layout{}
+		require.NoError(t, json.Unmarshal(data, &decoded))
+		assert.Equal(t, layout{Folders: folders, Views: views}, decoded)
+	})
+	t.Run("folder roles", func(t *testing.T) {
+		folders := []v1alpha2.Folder{
+			{
+				Name: "team-a",
+				Roles: []v1alpha2.FolderRole{
+					{Name: "team-a-developers", Permissions: []string{"hudson.model.Item.Read", "hudson.model.Item.Build"}, Sids: []string{"team-a"}},
+				},
+			},
+		}
+
+		got, err := RenderGroovyScript(folders, nil)
+
+		require.NoError(t, err)
+		assert.Contains(t, got, "import com.michelin.cio.hudson.plugins.rolestrategy.RoleBasedAuthorizationStrategy")
+		assert.Contains(t, got, "strategy.assignRole(RoleType.Project, role, sid)")
+	})
+}
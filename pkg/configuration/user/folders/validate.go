@@ -0,0 +1,101 @@
+package folders
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/plugins"
+)
+
+const roleStrategyPlugin = "role-strategy"
+
+var (
+	// folderNamePattern matches the folder name segment, the characters are the ones allowed by Jenkins
+	folderNamePattern = regexp.MustCompile(`^[^?*/\\%!@#$^&|<>\[\]:;]+$`)
+	// permissionPattern matches Jenkins permission IDs, e.g. hudson.model.Item.Build
+	permissionPattern = regexp.MustCompile(`^[\w$]+(\.[\w$]+)+$`)
+)
+
+// Validate validates spec.folders and spec.views
+func Validate(jenkins *v1alpha2.Jenkins) []string {
+	var messages []string
+
+	declaredFolders := map[string]bool{}
+	folderNames := map[string]bool{}
+	roleNames := map[string]bool{}
+	for i, folder := range jenkins.Spec.Folders {
+		field := fmt.Sprintf("spec.folders[%d]", i)
+		if msg := validateFolderName(folder.Name); len(msg) > 0 {
+			messages = append(messages, fmt.Sprintf("%s.name %s", field, msg))
+		} else if declaredFolders[folder.Name] {
+			messages = append(messages, fmt.Sprintf("%s.name '%s' is duplicated", field, folder.Name))
+		}
+		declaredFolders[folder.Name] = true
+		for _, parent := range parentFolders(folder.Name) {
+			folderNames[parent] = true
+		}
+
+		for j, role := range folder.Roles {
+			roleField := fmt.Sprintf("%s.roles[%d]", field, j)
+			if len(role.Name) == 0 {
+				messages = append(messages, fmt.Sprintf("%s.name is not set", roleField))
+			} else if roleNames[role.Name] {
+				messages = append(messages, fmt.Sprintf("%s.name '%s' is duplicated", roleField, role.Name))
+			}
+			roleNames[role.Name] = true
+
+			if len(role.Permissions) == 0 {
+				messages = append(messages, fmt.Sprintf("%s.permissions can't be empty", roleField))
+			}
+			for _, permission := range role.Permissions {
+				if !permissionPattern.MatchString(permission) {
+					messages = append(messages, fmt.Sprintf("%s.permissions '%s' is invalid, it must be a permission ID, e.g. hudson.model.Item.Read", roleField, permission))
+				}
+			}
+		}
+	}
+	if len(roleNames) > 0 && !plugins.IsRequired(roleStrategyPlugin, jenkins.RequiredPlugins()) {
+		messages = append(messages, fmt.Sprintf("spec.folders roles require '%s' plugin in spec.master.plugins", roleStrategyPlugin))
+	}
+
+	viewNames := map[string]bool{}
+	for i, view := range jenkins.Spec.Views {
+		field := fmt.Sprintf("spec.views[%d]", i)
+		if len(view.Name) == 0 || strings.ContainsAny(view.Name, "/\\") {
+			messages = append(messages, fmt.Sprintf("%s.name '%s' is invalid", field, view.Name))
+		} else if viewNames[view.Folder+"/"+view.Name] {
+			messages = append(messages, fmt.Sprintf("%s.name '%s' is duplicated", field, view.Name))
+		}
+		viewNames[view.Folder+"/"+view.Name] = true
+
+		if len(view.Folder) > 0 && !folderNames[view.Folder] {
+			messages = append(messages, fmt.Sprintf("%s.folder '%s' isn't defined in spec.folders", field, view.Folder))
+		}
+	}
+
+	return messages
+}
+
+func validateFolderName(name string) string {
+	if len(name) == 0 {
+		return "is not set"
+	}
+	for _, segment := range strings.Split(name, "/") {
+		if segment == "." || segment == ".." || !folderNamePattern.MatchString(segment) {
+			return fmt.Sprintf("'%s' is invalid", name)
+		}
+	}
+	return ""
+}
+
+// parentFolders returns the folder and all its parent folders, e.g. a/b/c, a/b and a
+func parentFolders(name string) []string {
+	var parents []string
+	segments := strings.Split(name, "/")
+	for i := len(segments); i > 0; i-- {
+		parents = append(parents, strings.Join(segments[:i], "/"))
+	}
+	return parents
+}
@@ -0,0 +1,73 @@
+package folders
+
+import (
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		jenkins := &v1alpha2.Jenkins{
+			Spec: v1alpha2.JenkinsSpec{
+				Master: v1alpha2.JenkinsMaster{
+					Plugins: []v1alpha2.Plugin{{Name: roleStrategyPlugin, Version: "3.2.0"}},
+				},
+				Folders: []v1alpha2.Folder{
+					{Name: "team-a/services", Roles: []v1alpha2.FolderRole{{Name: "team-a", Permissions: []string{"hudson.model.Item.Read"}, Sids: []string{"team-a"}}}},
+					{Name: "team-a", DisplayName: "Team A"},
+				},
+				Views: []v1alpha2.View{
+					{Name: "services", Folder: "team-a"},
+					{Name: "services", Folder: "team-a/services"},
+					{Name: "services"},
+				},
+			},
+		}
+
+		assert.Empty(t, Validate(jenkins))
+	})
+	t.Run("invalid folders", func(t *testing.T) {
+		jenkins := &v1alpha2.Jenkins{
+			Spec: v1alpha2.JenkinsSpec{
+				Folders: []v1alpha2.Folder{
+					{},
+					{Name: "team-a//services"},
+					{Name: "team-b"},
+					{Name: "team-b", Roles: []v1alpha2.FolderRole{{Name: "developers", Permissions: []string{"Item"}}, {Name: "developers"}}},
+				},
+			},
+		}
+
+		assert.Equal(t, []string{
+			"spec.folders[0].name is not set",
+			"spec.folders[1].name 'team-a//services' is invalid",
+			"spec.folders[3].name 'team-b' is duplicated",
+			"spec.folders[3].roles[0].permissions 'Item' is invalid, it must be a permission ID, e.g. hudson.model.Item.Read",
+			"spec.folders[3].roles[1].name 'developers' is duplicated",
+			"spec.folders[3].roles[1].permissions can't be empty",
+			"spec.folders roles require 'role-strategy' plugin in spec.master.plugins",
+		}, Validate(jenkins))
+	})
+	t.Run("invalid views", func(t *testing.T) {
+		jenkins := &v1alpha2.Jenkins{
+			Spec: v1alpha2.JenkinsSpec{
+				Folders: []v1alpha2.Folder{{Name: "team-a"}},
+				Views: []v1alpha2.View{
+					{Name: "all", Folder: "team-a"},
+					{Name: "all", Folder: "team-a"},
+					{Name: "a/b"},
+					{Name: "missing", Folder: "team-b"},
+				},
+			},
+		}
+
+		assert.Equal(t, []string{
+			"spec.views[1].name 'all' is duplicated",
+			"spec.views[2].name 'a/b' is invalid",
+			"spec.views[3].folder 'team-b' isn't defined in spec.folders",
+		}, Validate(jenkins))
+	})
+}
@@ -0,0 +1,52 @@
+package user
+
+import (
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/user/agents"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/user/casc"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/user/folders"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/user/sharedlibraries"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/user/tools"
+	"github.com/jenkinsci/kubernetes-operator/pkg/groovy"
+)
+
+// Plan returns Configuration as Code, tool installations, shared libraries, folders and groovy scripts which would be applied by ReconcileCasc
+func (r *reconcileUserConfiguration) Plan() ([]v1alpha2.PlannedConfigurationChange, error) {
+	changes, err := casc.New(r.jenkinsClient, r.Client, r.Configuration.Jenkins).Plan()
+	if err != nil {
+		return nil, err
+	}
+
+	toolChanges, err := tools.New(r.jenkinsClient, r.Client, r.Configuration.Jenkins).Plan()
+	if err != nil {
+		return nil, err
+	}
+	changes = append(changes, toolChanges...)
+
+	sharedLibraryChanges, err := sharedlibraries.New(r.jenkinsClient, r.Client, r.Configuration.Jenkins).Plan()
+	if err != nil {
+		return nil, err
+	}
+	changes = append(changes, sharedLibraryChanges...)
+
+	folderChanges, err := folders.New(r.jenkinsClient, r.Client, r.Configuration.Jenkins).Plan()
+	if err != nil {
+		return nil, err
+	}
+	changes = append(changes, folderChanges...)
+
+	agentChanges, err := agents.New(r.jenkinsClient, r.Client, r.Configuration.Jenkins).Plan()
+	if err != nil {
+		return nil, err
+	}
+	changes = append(changes, agentChanges...)
+
+	groovyClient := groovy.New(r.jenkinsClient, r.Client, r.Configuration.Jenkins, groovyConfigurationType, r.Configuration.Jenkins.Spec.GroovyScripts.Customization)
+	groovyChanges, err := groovyClient.Plan(isGroovyScriptFile, groovy.AddSecretsLoaderToGroovyScript(resources.GroovyScriptsSecretVolumePath))
+	if err != nil {
+		return nil, err
+	}
+
+	return append(changes, groovyChanges...), nil
+}
@@ -8,8 +8,13 @@ import (
 	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
 	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/backuprestore"
 	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/user/agents"
 	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/user/casc"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/user/folders"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/user/scriptapproval"
 	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/user/seedjobs"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/user/sharedlibraries"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/user/tools"
 	"github.com/jenkinsci/kubernetes-operator/pkg/groovy"
 	"github.com/jenkinsci/kubernetes-operator/pkg/log"
 
@@ -17,11 +22,16 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
+// groovyConfigurationType is the configuration type of user groovy scripts stored in status.appliedGroovyScripts
+const groovyConfigurationType = "user-groovy"
+
 // ReconcileUserConfiguration defines API client for reconcile User Configuration
 type ReconcileUserConfiguration interface {
 	ReconcileCasc() (reconcile.Result, error)
 	ReconcileOthers() (reconcile.Result, error)
 	Validate(jenkins *v1alpha2.Jenkins) ([]string, error)
+	Plan() ([]v1alpha2.PlannedConfigurationChange, error)
+	Verify(jenkinsClient jenkinsclient.Jenkins) error
 }
 
 type reconcileUserConfiguration struct {
@@ -35,7 +45,7 @@ func New(configuration configuration.Configuration, jenkinsClient jenkinsclient.
 	return &reconcileUserConfiguration{
 		Configuration: configuration,
 		jenkinsClient: jenkinsClient,
-		logger:        log.Log.WithValues("cr", configuration.Jenkins.Name),
+		logger:        log.ForJenkinsPhase(configuration.Jenkins, log.PhaseUser),
 	}
 }
 
@@ -64,10 +74,18 @@ func (r *reconcileUserConfiguration) ReconcileOthers() (reconcile.Result, error)
 		return result, nil
 	}
 
+	if err := scriptapproval.New(r.jenkinsClient, r.Configuration).Ensure(); err != nil {
+		return reconcile.Result{}, err
+	}
+
 	if err := backupAndRestore.Restore(r.jenkinsClient); err != nil {
 		return reconcile.Result{}, err
 	}
 
+	if err := backupAndRestore.ScheduleRequestedBackup(); err != nil {
+		return reconcile.Result{}, err
+	}
+
 	if err := backupAndRestore.Backup(false); err != nil {
 		return reconcile.Result{}, err
 	}
@@ -100,7 +118,39 @@ func (r *reconcileUserConfiguration) ensureCasc(jenkinsClient jenkinsclient.Jenk
 		return reconcile.Result{Requeue: true}, nil
 	}
 
-	groovyClient := groovy.New(jenkinsClient, r.Client, r.Configuration.Jenkins, "user-groovy", r.Configuration.Jenkins.Spec.GroovyScripts.Customization)
+	requeue, err = tools.New(jenkinsClient, r.Client, r.Configuration.Jenkins).Ensure()
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if requeue {
+		return reconcile.Result{Requeue: true}, nil
+	}
+
+	requeue, err = sharedlibraries.New(jenkinsClient, r.Client, r.Configuration.Jenkins).Ensure()
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if requeue {
+		return reconcile.Result{Requeue: true}, nil
+	}
+
+	requeue, err = folders.New(jenkinsClient, r.Client, r.Configuration.Jenkins).Ensure()
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if requeue {
+		return reconcile.Result{Requeue: true}, nil
+	}
+
+	requeue, err = agents.New(jenkinsClient, r.Client, r.Configuration.Jenkins).Ensure()
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if requeue {
+		return reconcile.Result{Requeue: true}, nil
+	}
+
+	groovyClient := groovy.New(jenkinsClient, r.Client, r.Configuration.Jenkins, groovyConfigurationType, r.Configuration.Jenkins.Spec.GroovyScripts.Customization)
 	requeue, err = groovyClient.WaitForSecretSynchronization(resources.GroovyScriptsSecretVolumePath)
 	if err != nil {
 		return reconcile.Result{}, err
@@ -108,9 +158,7 @@ func (r *reconcileUserConfiguration) ensureCasc(jenkinsClient jenkinsclient.Jenk
 	if requeue {
 		return reconcile.Result{Requeue: true}, nil
 	}
-	requeue, err = groovyClient.Ensure(func(name string) bool {
-		return strings.HasSuffix(name, ".groovy")
-	}, groovy.AddSecretsLoaderToGroovyScript(resources.GroovyScriptsSecretVolumePath))
+	requeue, err = groovyClient.Ensure(isGroovyScriptFile, groovy.AddSecretsLoaderToGroovyScript(resources.GroovyScriptsSecretVolumePath))
 	if err != nil {
 		return reconcile.Result{}, err
 	}
@@ -120,3 +168,23 @@ func (r *reconcileUserConfiguration) ensureCasc(jenkinsClient jenkinsclient.Jenk
 
 	return reconcile.Result{}, nil
 }
+
+// Verify applies Configuration as Code and groovy scripts to the given Jenkins without recording them in status,
+// it's used to verify the configuration in the canary Jenkins
+func (r *reconcileUserConfiguration) Verify(jenkinsClient jenkinsclient.Jenkins) error {
+	cascClient := groovy.New(jenkinsClient, r.Client, r.Configuration.Jenkins, casc.ConfigurationType, r.Configuration.Jenkins.Spec.ConfigurationAsCode.Customization)
+	if err := cascClient.Run(casc.IsConfigurationAsCodeFile, casc.UpdateGroovyScript); err != nil {
+		return err
+	}
+	groovyClient := groovy.New(jenkinsClient, r.Client, r.Configuration.Jenkins, groovyConfigurationType, r.Configuration.Jenkins.Spec.GroovyScripts.Customization)
+	return groovyClient.Run(isGroovyScriptFile, groovy.AddSecretsLoaderToGroovyScript(resources.GroovyScriptsSecretVolumePath))
+}
+
+// IsVerifiedByCanary returns true when the planned change is Configuration as Code or groovy script verified by the canary Jenkins
+func IsVerifiedByCanary(change v1alpha2.PlannedConfigurationChange) bool {
+	return change.ConfigurationType == casc.ConfigurationType || change.ConfigurationType == groovyConfigurationType
+}
+
+func isGroovyScriptFile(name string) bool {
+	return strings.HasSuffix(name, ".groovy")
+}
@@ -0,0 +1,3 @@
+// Package scriptapproval approves pending script approvals of script-security plugin listed in spec.scriptApproval
+// and reports the other ones in status
+package scriptapproval
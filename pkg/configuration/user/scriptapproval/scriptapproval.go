@@ -0,0 +1,207 @@
+package scriptapproval
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	jenkinsclient "github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
+	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
+	k8sevent "github.com/jenkinsci/kubernetes-operator/pkg/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// checkInterval is the interval of checks of pending script approvals when spec.scriptApproval and seed jobs don't change
+const checkInterval = 5 * time.Minute
+
+var (
+	signaturePattern  = regexp.MustCompile(`^(method|staticMethod|new|field|staticField) \S+`)
+	scriptHashPattern = regexp.MustCompile(`^(SHA512:[0-9a-f]{128}|[0-9a-f]{40})$`)
+)
+
+// ScriptApproval defines client for script approvals of script-security plugin
+type ScriptApproval interface {
+	Ensure() error
+}
+
+type scriptApproval struct {
+	configuration.Configuration
+	jenkinsClient jenkinsclient.Jenkins
+	logger        logr.Logger
+}
+
+// New creates new instance of ScriptApproval
+func New(jenkinsClient jenkinsclient.Jenkins, config configuration.Configuration) ScriptApproval {
+	return &scriptApproval{
+		Configuration: config,
+		jenkinsClient: jenkinsClient,
+		logger:        log.ForJenkinsPhase(config.Jenkins, log.PhaseUser),
+	}
+}
+
+// Ensure approves pending script approvals listed in spec.scriptApproval, queues the failed seed jobs again
+// when any of them has been approved and updates status.pendingScriptApprovals with the other ones. Jenkins is
+// checked when spec.scriptApproval or the seed jobs have changed since the last check or after checkInterval
+func (s *scriptApproval) Ensure() error {
+	script := approveGroovyScript(*s.Jenkins)
+	hash := calculateHash(script)
+	if check := s.Jenkins.Status.ScriptApprovalCheck; check != nil && check.Hash == hash && time.Since(check.LastCheckTime.Time) < checkInterval {
+		return nil
+	}
+
+	logs, err := s.jenkinsClient.ExecuteScript(script)
+	if err != nil {
+		return errors.Wrapf(err, "couldn't approve pending scripts, logs '%s'", logs)
+	}
+
+	approved, pending, rerun := parseOutput(logs)
+	for _, approval := range approved {
+		message := fmt.Sprintf("Pending %s '%s' has been approved", approval.Type, approval.Value)
+		s.logger.Info(message)
+		s.Emit(k8sevent.TypeNormal, k8sevent.ReasonScriptApproved, message)
+	}
+	if len(rerun) > 0 {
+		message := fmt.Sprintf("Builds of failed seed jobs '%s' have been queued after script approval", strings.Join(rerun, "', '"))
+		s.logger.Info(message)
+		s.Emit(k8sevent.TypeNormal, k8sevent.ReasonSeedJobsRerun, message)
+	}
+
+	for _, approval := range pending {
+		if !contains(s.Jenkins.Status.PendingScriptApprovals, approval) {
+			message := fmt.Sprintf("Pending %s '%s' isn't listed in spec.scriptApproval", approval.Type, approval.Value)
+			s.logger.Info(message)
+			s.Emit(k8sevent.TypeWarning, k8sevent.ReasonScriptApprovalPending, message)
+		}
+	}
+	s.Jenkins.Status.PendingScriptApprovals = pending
+	s.Jenkins.Status.ScriptApprovalCheck = &v1alpha2.ScriptApprovalCheck{Hash: hash, LastCheckTime: metav1.Now()}
+	return errors.WithStack(s.Client.Status().Update(context.TODO(), s.Jenkins))
+}
+
+func calculateHash(script string) string {
+	hash := sha256.Sum256([]byte(script))
+	return hex.EncodeToString(hash[:])
+}
+
+// Validate verifies signatures and script hashes of spec.scriptApproval
+func Validate(scriptApproval *v1alpha2.ScriptApproval) []string {
+	if scriptApproval == nil {
+		return nil
+	}
+
+	var messages []string
+	for _, signature := range scriptApproval.Signatures {
+		if !signaturePattern.MatchString(signature) {
+			messages = append(messages, fmt.Sprintf("spec.scriptApproval.signatures '%s' must start with method, staticMethod, new, field or staticField followed by the class name", signature))
+		}
+	}
+	for _, hash := range scriptApproval.ScriptHashes {
+		if !scriptHashPattern.MatchString(hash) {
+			messages = append(messages, fmt.Sprintf("spec.scriptApproval.scriptHashes '%s' must be SHA512:<hex> or SHA-1 hex", hash))
+		}
+	}
+	return messages
+}
+
+// parseOutput returns approvals approved by the groovy script, the pending ones which haven't been approved
+// and the seed jobs queued again
+func parseOutput(logs string) (approved, pending []v1alpha2.PendingScriptApproval, rerun []string) {
+	for _, line := range strings.Split(logs, "\n") {
+		fields := strings.Split(strings.TrimRight(line, "\r"), "\t")
+		if len(fields) < 3 {
+			continue
+		}
+		approval := v1alpha2.PendingScriptApproval{Type: v1alpha2.ScriptApprovalType(fields[1]), Value: fields[2]}
+		switch fields[0] {
+		case "approved":
+			approved = append(approved, approval)
+		case "pending":
+			if len(fields) == 5 {
+				approval.Language = fields[3]
+				approval.Dangerous = fields[4] == "true"
+			}
+			pending = append(pending, approval)
+		case "rerun":
+			rerun = append(rerun, approval.Value)
+		}
+	}
+	return approved, pending, rerun
+}
+
+func contains(approvals []v1alpha2.PendingScriptApproval, approval v1alpha2.PendingScriptApproval) bool {
+	for _, item := range approvals {
+		if item.Type == approval.Type && item.Value == approval.Value {
+			return true
+		}
+	}
+	return false
+}
+
+func approveGroovyScript(jenkins v1alpha2.Jenkins) string {
+	scriptApproval := v1alpha2.ScriptApproval{}
+	if jenkins.Spec.ScriptApproval != nil {
+		scriptApproval = *jenkins.Spec.ScriptApproval
+	}
+	var seedJobs []string
+	for _, seedJob := range jenkins.Spec.SeedJobs {
+		seedJobs = append(seedJobs, fmt.Sprintf("%s-%s", seedJob.ID, constants.SeedJobSuffix))
+	}
+	return fmt.Sprintf(approveGroovyScriptFmt,
+		base64.StdEncoding.EncodeToString([]byte(strings.Join(scriptApproval.Signatures, "\n"))),
+		base64.StdEncoding.EncodeToString([]byte(strings.Join(scriptApproval.ScriptHashes, "\n"))),
+		base64.StdEncoding.EncodeToString([]byte(strings.Join(seedJobs, "\n"))))
+}
+
+// approveGroovyScriptFmt prints one tab separated line per pending approval, approved or pending, and per seed job
+// queued again, the seed jobs whose last build has failed are queued when anything has been approved
+const approveGroovyScriptFmt = `
+import hudson.model.Result
+import jenkins.model.Jenkins
+import org.jenkinsci.plugins.scriptsecurity.scripts.ScriptApproval
+
+def decode = { String encoded -> new String(encoded.decodeBase64(), 'UTF-8').readLines().findAll { it } as Set }
+def signatures = decode('%s')
+def scriptHashes = decode('%s')
+def seedJobs = decode('%s')
+def approval = ScriptApproval.get()
+def approved = false
+
+new ArrayList(approval.getPendingSignatures()).each { pending ->
+    if (signatures.contains(pending.signature)) {
+        approval.approveSignature(pending.signature)
+        approved = true
+        println("approved\tsignature\t" + pending.signature)
+    } else {
+        println("pending\tsignature\t" + pending.signature + "\t\t" + pending.isDangerous())
+    }
+}
+new ArrayList(approval.getPendingScripts()).each { pending ->
+    if (scriptHashes.contains(pending.getHash())) {
+        approval.approveScript(pending.getHash())
+        approved = true
+        println("approved\tscript\t" + pending.getHash())
+    } else {
+        println("pending\tscript\t" + pending.getHash() + "\t" + (pending.getLanguage()?.getName() ?: "") + "\tfalse")
+    }
+}
+if (approved) {
+    seedJobs.each { name ->
+        def job = Jenkins.get().getItemByFullName(name)
+        if (job != null && !job.isBuilding() && !job.isInQueue() && job.getLastCompletedBuild()?.getResult() == Result.FAILURE) {
+            job.scheduleBuild2(0)
+            println("rerun\tjob\t" + name)
+        }
+    }
+}
+`
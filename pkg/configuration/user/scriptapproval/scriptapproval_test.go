@@ -0,0 +1,173 @@
+package scriptapproval
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	jenkinsclient "github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
+	k8sevent "github.com/jenkinsci/kubernetes-operator/pkg/event"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var scriptHash = "SHA512:" + strings.Repeat("ab", 64)
+
+type fakeEvents struct {
+	reasons []k8sevent.Reason
+}
+
+func (f *fakeEvents) Emit(_ runtime.Object, _ k8sevent.Type, reason k8sevent.Reason, _ string) {
+	f.reasons = append(f.reasons, reason)
+}
+
+func (f *fakeEvents) Emitf(_ runtime.Object, _ k8sevent.Type, reason k8sevent.Reason, _ string, _ ...interface{}) {
+	f.reasons = append(f.reasons, reason)
+}
+
+func TestEnsure(t *testing.T) {
+	t.Run("approve listed and report other pending approvals", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		require.NoError(t, v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme))
+
+		jenkins := &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: "default"},
+			Spec: v1alpha2.JenkinsSpec{
+				ScriptApproval: &v1alpha2.ScriptApproval{
+					Signatures:   []string{"method java.lang.String trim"},
+					ScriptHashes: []string{scriptHash},
+				},
+				SeedJobs: []v1alpha2.SeedJob{{ID: "jobs"}},
+			},
+		}
+		fakeClient := fake.NewClientBuilder().WithObjects(jenkins).Build()
+		jenkinsClient := jenkinsclient.NewMockJenkins(ctrl)
+		jenkinsClient.EXPECT().ExecuteScript(approveGroovyScript(*jenkins)).Return(
+			"approved\tsignature\tmethod java.lang.String trim\n"+
+				"approved\tscript\t"+scriptHash+"\n"+
+				"pending\tsignature\tstaticMethod java.lang.System exit int\t\ttrue\n"+
+				"pending\tscript\tSHA512:cd\tgroovy\tfalse\n"+
+				"rerun\tjob\tjobs-job-dsl-seed\n"+
+				"verifier-abc\n", nil)
+		events := &fakeEvents{}
+
+		err := New(jenkinsClient, configuration.Configuration{Client: fakeClient, Jenkins: jenkins, Events: events}).Ensure()
+
+		require.NoError(t, err)
+		assert.Equal(t, []k8sevent.Reason{
+			k8sevent.ReasonScriptApproved,
+			k8sevent.ReasonScriptApproved,
+			k8sevent.ReasonSeedJobsRerun,
+			k8sevent.ReasonScriptApprovalPending,
+			k8sevent.ReasonScriptApprovalPending,
+		}, events.reasons)
+		actual := &v1alpha2.Jenkins{}
+		require.NoError(t, fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "jenkins"}, actual))
+		assert.Equal(t, []v1alpha2.PendingScriptApproval{
+			{Type: v1alpha2.ScriptApprovalTypeSignature, Value: "staticMethod java.lang.System exit int", Dangerous: true},
+			{Type: v1alpha2.ScriptApprovalTypeScript, Value: "SHA512:cd", Language: "groovy"},
+		}, actual.Status.PendingScriptApprovals)
+	})
+	t.Run("report pending approvals when script approval isn't set", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		require.NoError(t, v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme))
+
+		jenkins := &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: "default"},
+		}
+		fakeClient := fake.NewClientBuilder().WithObjects(jenkins).Build()
+		jenkinsClient := jenkinsclient.NewMockJenkins(ctrl)
+		jenkinsClient.EXPECT().ExecuteScript(approveGroovyScript(*jenkins)).Return("pending\tscript\t"+scriptHash+"\t\tfalse\n", nil)
+
+		err := New(jenkinsClient, configuration.Configuration{Client: fakeClient, Jenkins: jenkins, Events: &fakeEvents{}}).Ensure()
+
+		require.NoError(t, err)
+		actual := &v1alpha2.Jenkins{}
+		require.NoError(t, fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "jenkins"}, actual))
+		assert.Equal(t, []v1alpha2.PendingScriptApproval{{Type: v1alpha2.ScriptApprovalTypeScript, Value: scriptHash}}, actual.Status.PendingScriptApprovals)
+	})
+	t.Run("clear status when nothing is pending", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		require.NoError(t, v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme))
+
+		jenkins := &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: "default"},
+			Status: v1alpha2.JenkinsStatus{PendingScriptApprovals: []v1alpha2.PendingScriptApproval{
+				{Type: v1alpha2.ScriptApprovalTypeScript, Value: scriptHash},
+			}},
+		}
+		fakeClient := fake.NewClientBuilder().WithObjects(jenkins).Build()
+		jenkinsClient := jenkinsclient.NewMockJenkins(ctrl)
+		jenkinsClient.EXPECT().ExecuteScript(approveGroovyScript(*jenkins)).Return("", nil)
+
+		err := New(jenkinsClient, configuration.Configuration{Client: fakeClient, Jenkins: jenkins}).Ensure()
+
+		require.NoError(t, err)
+		actual := &v1alpha2.Jenkins{}
+		require.NoError(t, fakeClient.Get(context.TODO(), types.NamespacedName{Namespace: "default", Name: "jenkins"}, actual))
+		assert.Empty(t, actual.Status.PendingScriptApprovals)
+		require.NotNil(t, actual.Status.ScriptApprovalCheck)
+		assert.Equal(t, calculateHash(approveGroovyScript(*jenkins)), actual.Status.ScriptApprovalCheck.Hash)
+	})
+	t.Run("skip check when nothing has changed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		jenkins := &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: "default"},
+			Spec:       v1alpha2.JenkinsSpec{ScriptApproval: &v1alpha2.ScriptApproval{ScriptHashes: []string{scriptHash}}},
+		}
+		jenkins.Status.ScriptApprovalCheck = &v1alpha2.ScriptApprovalCheck{Hash: calculateHash(approveGroovyScript(*jenkins)), LastCheckTime: metav1.Now()}
+		jenkinsClient := jenkinsclient.NewMockJenkins(ctrl)
+
+		err := New(jenkinsClient, configuration.Configuration{Client: fake.NewClientBuilder().WithObjects(jenkins).Build(), Jenkins: jenkins}).Ensure()
+
+		require.NoError(t, err)
+	})
+	t.Run("check again when script approval has changed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		require.NoError(t, v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme))
+
+		jenkins := &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: "default"},
+			Spec:       v1alpha2.JenkinsSpec{ScriptApproval: &v1alpha2.ScriptApproval{ScriptHashes: []string{scriptHash}}},
+			Status: v1alpha2.JenkinsStatus{
+				ScriptApprovalCheck: &v1alpha2.ScriptApprovalCheck{Hash: calculateHash(""), LastCheckTime: metav1.Now()},
+			},
+		}
+		jenkinsClient := jenkinsclient.NewMockJenkins(ctrl)
+		jenkinsClient.EXPECT().ExecuteScript(approveGroovyScript(*jenkins)).Return("", nil)
+
+		err := New(jenkinsClient, configuration.Configuration{Client: fake.NewClientBuilder().WithObjects(jenkins).Build(), Jenkins: jenkins}).Ensure()
+
+		require.NoError(t, err)
+	})
+}
+
+func TestValidate(t *testing.T) {
+	assert.Nil(t, Validate(nil))
+	assert.Nil(t, Validate(&v1alpha2.ScriptApproval{
+		Signatures:   []string{"method java.lang.String trim", "new java.io.File java.lang.String", "staticField java.lang.Integer MAX_VALUE"},
+		ScriptHashes: []string{scriptHash, strings.Repeat("a1", 20)},
+	}))
+	assert.Equal(t, []string{
+		"spec.scriptApproval.signatures 'java.lang.String trim' must start with method, staticMethod, new, field or staticField followed by the class name",
+		"spec.scriptApproval.scriptHashes 'SHA256:ab' must be SHA512:<hex> or SHA-1 hex",
+	}, Validate(&v1alpha2.ScriptApproval{
+		Signatures:   []string{"java.lang.String trim"},
+		ScriptHashes: []string{"SHA256:ab"},
+	}))
+}
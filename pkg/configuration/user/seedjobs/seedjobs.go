@@ -5,6 +5,7 @@ import (
 	"crypto/sha256"
 	"encoding/base64"
 	"fmt"
+	"math"
 	"reflect"
 	"text/template"
 
@@ -13,7 +14,9 @@ import (
 	jenkinsclient "github.com/jenkinsci/kubernetes-operator/pkg/client"
 	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
 	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/securityprofile"
 	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
+	k8sevent "github.com/jenkinsci/kubernetes-operator/pkg/event"
 	"github.com/jenkinsci/kubernetes-operator/pkg/groovy"
 	"github.com/jenkinsci/kubernetes-operator/pkg/log"
 	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/reason"
@@ -43,6 +46,14 @@ const (
 	// AgentName is the name of seed job agent
 	AgentName = "seed-job-agent"
 
+	// AgentImage is the default image of seed job agents
+	AgentImage = "jenkins/inbound-agent:4.9-1"
+
+	// DedicatedAgentLabelKey is the label of dedicated seed job agent deployments, its value is the Jenkins name
+	DedicatedAgentLabelKey = "jenkins.io/seed-job-agent-of"
+	// dedicatedAgentNodeAnnotation is the annotation of dedicated seed job agent deployments with the Jenkins node name
+	dedicatedAgentNodeAnnotation = "jenkins.io/seed-job-agent"
+
 	creatingGroovyScriptName = "seed-job-groovy-script.groovy"
 
 	homeVolumeName = "home"
@@ -74,6 +85,11 @@ import com.cloudbees.jenkins.GitHubPushTrigger;
 {{ if .BitbucketPushTrigger }}
 import com.cloudbees.jenkins.plugins.BitBucketTrigger;
 {{ end }}
+{{ if .TimeoutMinutes }}
+import hudson.plugins.build_timeout.BuildTimeoutWrapper;
+import hudson.plugins.build_timeout.impl.AbsoluteTimeOutStrategy;
+import hudson.plugins.build_timeout.operations.AbortOperation;
+{{ end }}
 import hudson.model.FreeStyleProject;
 import hudson.model.labels.LabelAtom;
 import hudson.plugins.git.BranchSpec;
@@ -144,6 +160,13 @@ jobRef.addTrigger(new BitBucketTrigger())
 jobRef.addTrigger(new TimerTrigger("{{ .BuildPeriodically }}"))
 {{ end}}
 jobRef.setAssignedLabel(new LabelAtom("{{ .AgentName }}"))
+
+jobRef.getBuildWrappersList().findAll { it.getClass().getName() == "hudson.plugins.build_timeout.BuildTimeoutWrapper" }.each {
+        jobRef.getBuildWrappersList().remove(it)
+}
+{{ if .TimeoutMinutes }}
+jobRef.getBuildWrappersList().add(new BuildTimeoutWrapper(new AbsoluteTimeOutStrategy("{{ .TimeoutMinutes }}"), [new AbortOperation()], ""))
+{{ end }}
 jenkins.getQueue().schedule(jobRef)
 `))
 
@@ -152,11 +175,13 @@ type SeedJobs interface {
 	EnsureSeedJobs(jenkins *v1alpha2.Jenkins) (done bool, err error)
 	waitForSeedJobAgent(agentName string) (requeue bool, err error)
 	createJobs(jenkins *v1alpha2.Jenkins) (requeue bool, err error)
+	rerunJobs(jenkins *v1alpha2.Jenkins) error
 	ensureLabelsForSecrets(jenkins v1alpha2.Jenkins) error
 	credentialValue(namespace string, seedJob v1alpha2.SeedJob) (string, error)
 	getAllSeedJobIDs(jenkins v1alpha2.Jenkins) []string
 	isRecreatePodNeeded(jenkins v1alpha2.Jenkins) bool
-	createAgent(jenkinsClient jenkinsclient.Jenkins, k8sClient client.Client, jenkinsManifest *v1alpha2.Jenkins, namespace string, agentName string) error
+	ensureAgents(jenkins *v1alpha2.Jenkins) (requeue bool, err error)
+	createAgent(jenkinsClient jenkinsclient.Jenkins, k8sClient client.Client, jenkinsManifest *v1alpha2.Jenkins, namespace string, agentName string, agent *v1alpha2.SeedJobAgent) error
 	ValidateSeedJobs(jenkins v1alpha2.Jenkins) ([]string, error)
 	validateSchedule(job v1alpha2.SeedJob, str string, key string) []string
 	validateGitHubPushTrigger(jenkins v1alpha2.Jenkins) []string
@@ -175,54 +200,45 @@ func New(jenkinsClient jenkinsclient.Jenkins, config configuration.Configuration
 	return &seedJobs{
 		Configuration: config,
 		jenkinsClient: jenkinsClient,
-		logger:        log.Log.WithValues("cr", config.Jenkins.Name),
+		logger:        log.ForJenkinsPhase(config.Jenkins, log.PhaseUser),
 	}
 }
 
 // EnsureSeedJobs configures seed job and runs it for every entry from Jenkins.Spec.SeedJobs
 func (s *seedJobs) EnsureSeedJobs(jenkins *v1alpha2.Jenkins) (done bool, err error) {
+	deferred := false
 	if s.isRecreatePodNeeded(*jenkins) {
-		message := "Some seed job has been deleted, recreating pod"
-		s.logger.Info(message)
-
-		restartReason := reason.NewPodRestart(
-			reason.OperatorSource,
-			[]string{message},
-		)
-		return false, s.RestartJenkinsMasterPod(restartReason)
-	}
-
-	if len(jenkins.Spec.SeedJobs) > 0 {
-		err := s.createAgent(s.jenkinsClient, s.Client, jenkins, jenkins.Namespace, AgentName)
+		deferred, err = s.DeferDisruptiveAction(v1alpha2.DisruptiveActionSeedJobRemoval, "Some seed job has been deleted")
 		if err != nil {
 			return false, err
 		}
-
-		requeue, err := s.waitForSeedJobAgent(AgentName)
-		if err != nil {
-			return false, err
+		if !deferred {
+			message := "Some seed job has been deleted, recreating pod"
+			s.logger.Info(message)
+
+			restartReason := reason.NewPodRestart(
+				reason.OperatorSource,
+				[]string{message},
+			)
+			return false, s.RestartJenkinsMasterPod(restartReason)
 		}
-		if requeue {
-			return false, nil
-		}
-	} else if len(jenkins.Spec.SeedJobs) == 0 {
-		err := s.Client.Delete(context.TODO(), &appsv1.Deployment{
-			ObjectMeta: metav1.ObjectMeta{
-				Namespace: jenkins.Namespace,
-				Name:      agentDeploymentName(*jenkins, AgentName),
-			},
-		})
+	} else if err = s.ResolveDeferredAction(v1alpha2.DisruptiveActionSeedJobRemoval); err != nil {
+		return false, err
+	}
 
-		if err != nil && !apierrors.IsNotFound(err) {
-			return false, stackerr.WithStack(err)
-		}
+	requeue, err := s.ensureAgents(jenkins)
+	if err != nil {
+		return false, err
+	}
+	if requeue {
+		return false, nil
 	}
 
 	if err = s.ensureLabelsForSecrets(*jenkins); err != nil {
 		return false, err
 	}
 
-	requeue, err := s.createJobs(jenkins)
+	requeue, err = s.createJobs(jenkins)
 	if err != nil {
 		return false, err
 	}
@@ -231,14 +247,102 @@ func (s *seedJobs) EnsureSeedJobs(jenkins *v1alpha2.Jenkins) (done bool, err err
 	}
 
 	seedJobIDs := s.getAllSeedJobIDs(*jenkins)
+	if deferred {
+		// the deleted seed jobs stay in Jenkins until the deferred restart
+		seedJobIDs = append(seedJobIDs, deletedSeedJobIDs(*jenkins)...)
+	}
 	if !reflect.DeepEqual(seedJobIDs, jenkins.Status.CreatedSeedJobs) {
 		jenkins.Status.CreatedSeedJobs = seedJobIDs
 		return false, stackerr.WithStack(s.Client.Status().Update(context.TODO(), jenkins))
 	}
 
+	if err = s.rerunJobs(jenkins); err != nil {
+		return false, err
+	}
+
 	return true, nil
 }
 
+// ensureAgents creates the shared seed job agent and the dedicated agents of seed jobs, waits for them and
+// deletes the agents which aren't used by any seed job
+func (s *seedJobs) ensureAgents(jenkins *v1alpha2.Jenkins) (requeue bool, err error) {
+	agents := map[string]*v1alpha2.SeedJobAgent{}
+	var agentNames []string
+	for _, seedJob := range jenkins.Spec.SeedJobs {
+		agentName := seedJobAgentName(seedJob)
+		if _, found := agents[agentName]; !found {
+			agentNames = append(agentNames, agentName)
+		}
+		agents[agentName] = seedJob.Agent
+	}
+
+	if _, shared := agents[AgentName]; !shared {
+		err := s.Client.Delete(context.TODO(), &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: jenkins.Namespace,
+				Name:      agentDeploymentName(*jenkins, AgentName),
+			},
+		})
+
+		if err != nil && !apierrors.IsNotFound(err) {
+			return false, stackerr.WithStack(err)
+		}
+	}
+
+	if err = s.deleteStaleAgents(jenkins, agents); err != nil {
+		return false, err
+	}
+
+	for _, agentName := range agentNames {
+		err := s.createAgent(s.jenkinsClient, s.Client, jenkins, jenkins.Namespace, agentName, agents[agentName])
+		if err != nil {
+			return false, err
+		}
+
+		requeue, err := s.waitForSeedJobAgent(agentName)
+		if err != nil || requeue {
+			return requeue, err
+		}
+	}
+
+	return false, nil
+}
+
+// deleteStaleAgents deletes dedicated seed job agents and their Jenkins nodes which aren't used by any seed job
+func (s *seedJobs) deleteStaleAgents(jenkins *v1alpha2.Jenkins, agents map[string]*v1alpha2.SeedJobAgent) error {
+	deployments := &appsv1.DeploymentList{}
+	err := s.Client.List(context.TODO(), deployments, client.InNamespace(jenkins.Namespace), client.MatchingLabels{DedicatedAgentLabelKey: jenkins.Name})
+	if err != nil {
+		return stackerr.WithStack(err)
+	}
+
+	for _, deployment := range deployments.Items {
+		agentName := deployment.Annotations[dedicatedAgentNodeAnnotation]
+		if _, used := agents[agentName]; used {
+			continue
+		}
+
+		s.logger.Info(fmt.Sprintf("Deleting unused seed job agent `%s`", agentName))
+		if _, err := s.jenkinsClient.DeleteNode(agentName); err != nil {
+			s.logger.V(log.VWarn).Info(fmt.Sprintf("Couldn't delete Jenkins node `%s`: %s", agentName, err))
+		}
+		deployment := deployment
+		if err := s.Client.Delete(context.TODO(), &deployment); err != nil && !apierrors.IsNotFound(err) {
+			return stackerr.WithStack(err)
+		}
+	}
+
+	return nil
+}
+
+// seedJobAgentName returns the name of Jenkins node the seed job runs on
+func seedJobAgentName(seedJob v1alpha2.SeedJob) string {
+	if seedJob.Agent == nil {
+		return AgentName
+	}
+	return fmt.Sprintf("%s-%s", AgentName, seedJob.ID)
+}
+
 func (s *seedJobs) waitForSeedJobAgent(agentName string) (requeue bool, err error) {
 	agent := appsv1.Deployment{}
 	err = s.Client.Get(context.TODO(), types.NamespacedName{Name: agentDeploymentName(*s.Jenkins, agentName), Namespace: s.Jenkins.Namespace}, &agent)
@@ -294,6 +398,31 @@ func (s *seedJobs) createJobs(jenkins *v1alpha2.Jenkins) (requeue bool, err erro
 	return false, nil
 }
 
+// BuildSeedJobs queues builds of all seed jobs from spec.seedJobs
+func BuildSeedJobs(jenkinsClient jenkinsclient.Jenkins, jenkins *v1alpha2.Jenkins) error {
+	for _, seedJob := range jenkins.Spec.SeedJobs {
+		jobName := fmt.Sprintf("%s-%s", seedJob.ID, constants.SeedJobSuffix)
+		if _, err := jenkinsClient.BuildJob(jobName, map[string]string{}); err != nil {
+			return stackerr.Wrapf(err, "couldn't build seed job '%s'", jobName)
+		}
+	}
+	return nil
+}
+
+// rerunJobs queues builds of all seed jobs when jenkins.io/rerun-seed-jobs annotation is set and removes the annotation
+func (s *seedJobs) rerunJobs(jenkins *v1alpha2.Jenkins) error {
+	if _, requested := jenkins.Annotations[v1alpha2.RerunSeedJobsAnnotation]; !requested {
+		return nil
+	}
+
+	if err := BuildSeedJobs(s.jenkinsClient, jenkins); err != nil {
+		return err
+	}
+	s.logger.Info(fmt.Sprintf("Builds of %d seed jobs have been queued", len(jenkins.Spec.SeedJobs)))
+	s.Emitf(k8sevent.TypeNormal, k8sevent.ReasonSeedJobsRerun, "Builds of %d seed jobs have been queued", len(jenkins.Spec.SeedJobs))
+	return s.RemoveJenkinsAnnotation(v1alpha2.RerunSeedJobsAnnotation)
+}
+
 // ensureLabelsForSecrets adds labels to Kubernetes secrets where are Jenkins credentials used for seed jobs,
 // thanks to them kubernetes-credentials-provider-plugin will create Jenkins credentials in Jenkins and
 // Operator will able to watch any changes made to them
@@ -348,6 +477,12 @@ func (s *seedJobs) getAllSeedJobIDs(jenkins v1alpha2.Jenkins) []string {
 }
 
 func (s *seedJobs) isRecreatePodNeeded(jenkins v1alpha2.Jenkins) bool {
+	return len(deletedSeedJobIDs(jenkins)) > 0
+}
+
+// deletedSeedJobIDs returns the IDs of seed jobs created in Jenkins which have been removed from spec.seedJobs
+func deletedSeedJobIDs(jenkins v1alpha2.Jenkins) []string {
+	var deleted []string
 	for _, createdSeedJob := range jenkins.Status.CreatedSeedJobs {
 		found := false
 		for _, seedJob := range jenkins.Spec.SeedJobs {
@@ -357,19 +492,24 @@ func (s *seedJobs) isRecreatePodNeeded(jenkins v1alpha2.Jenkins) bool {
 			}
 		}
 		if !found {
-			return true
+			deleted = append(deleted, createdSeedJob)
 		}
 	}
-	return false
+	return deleted
 }
 
-// createAgent deploys Jenkins agent to Kubernetes cluster
-func (s *seedJobs) createAgent(jenkinsClient jenkinsclient.Jenkins, k8sClient client.Client, jenkinsManifest *v1alpha2.Jenkins, namespace string, agentName string) error {
+// createAgent deploys Jenkins agent to Kubernetes cluster, the agent is the dedicated one of a single seed job
+// if agent is set
+func (s *seedJobs) createAgent(jenkinsClient jenkinsclient.Jenkins, k8sClient client.Client, jenkinsManifest *v1alpha2.Jenkins, namespace string, agentName string, agent *v1alpha2.SeedJobAgent) error {
 	_, err := jenkinsClient.GetNode(agentName)
 
 	// Create node if not exists
 	if err != nil && err.Error() == "No node found" {
-		_, err = jenkinsClient.CreateNode(agentName, 5, "The jenkins-operator generated agent", "/home/jenkins", agentName)
+		executors := 5
+		if agent != nil {
+			executors = 1
+		}
+		_, err = jenkinsClient.CreateNode(agentName, executors, "The jenkins-operator generated agent", "/home/jenkins", agentName)
 		if err != nil {
 			return stackerr.WithStack(err)
 		}
@@ -382,7 +522,7 @@ func (s *seedJobs) createAgent(jenkinsClient jenkinsclient.Jenkins, k8sClient cl
 		return err
 	}
 
-	deployment, err := agentDeployment(jenkinsManifest, namespace, agentName, secret, s.KubernetesClusterDomain)
+	deployment, err := agentDeployment(jenkinsManifest, namespace, agentName, agent, secret, s.KubernetesClusterDomain)
 	if err != nil {
 		return err
 	}
@@ -404,7 +544,12 @@ func agentDeploymentName(jenkins v1alpha2.Jenkins, agentName string) string {
 	return fmt.Sprintf("%s-%s", agentName, jenkins.Name)
 }
 
-func agentDeployment(jenkins *v1alpha2.Jenkins, namespace string, agentName string, secret string, kubernetesDomainName string) (*appsv1.Deployment, error) {
+// IsAgentDeployment tells if the Deployment is the shared or a dedicated seed job agent of Jenkins
+func IsAgentDeployment(jenkins v1alpha2.Jenkins, deployment appsv1.Deployment) bool {
+	return deployment.Name == agentDeploymentName(jenkins, AgentName) || deployment.Labels[DedicatedAgentLabelKey] == jenkins.Name
+}
+
+func agentDeployment(jenkins *v1alpha2.Jenkins, namespace string, agentName string, agent *v1alpha2.SeedJobAgent, secret string, kubernetesDomainName string) (*appsv1.Deployment, error) {
 	jenkinsSlavesServiceFQDN, err := resources.GetJenkinsSlavesServiceFQDN(jenkins, kubernetesDomainName)
 	if err != nil {
 		return nil, err
@@ -418,10 +563,78 @@ func agentDeployment(jenkins *v1alpha2.Jenkins, namespace string, agentName stri
 	if prefix, ok := resources.GetJenkinsOpts(*jenkins)["prefix"]; ok {
 		suffix = prefix
 	}
+	envs := []corev1.EnvVar{
+		{
+			Name:  "JENKINS_SECRET",
+			Value: secret,
+		},
+		{
+			Name:  "JENKINS_AGENT_NAME",
+			Value: agentName,
+		},
+		{
+			Name:  "JENKINS_URL",
+			Value: resources.GetJenkinsURL(jenkinsHTTPServiceFQDN, jenkins.Spec.Service.Port, suffix),
+		},
+		{
+			Name:  "JENKINS_AGENT_WORKDIR",
+			Value: homeVolumePath,
+		},
+	}
+	if resources.IsWebSocketAgentsEnabled(jenkins) {
+		// the agent connects to JENKINS_URL, the tunnel isn't used
+		envs = append(envs, corev1.EnvVar{Name: "JENKINS_WEB_SOCKET", Value: "true"})
+	} else {
+		envs = append([]corev1.EnvVar{{
+			Name:  "JENKINS_TUNNEL",
+			Value: resources.GetJenkinsTunnel(jenkinsSlavesServiceFQDN, jenkins.Spec.SlaveService.Port),
+		}}, envs...)
+	}
+
+	image := AgentImage
+	nodeSelector := jenkins.Spec.Master.NodeSelector
+	tolerations := jenkins.Spec.Master.Tolerations
+	var resourceRequirements corev1.ResourceRequirements
+	var labels, annotations map[string]string
+	if agent != nil {
+		if len(agent.Image) > 0 {
+			image = agent.Image
+		}
+		if len(agent.NodeSelector) > 0 {
+			nodeSelector = agent.NodeSelector
+		}
+		if len(agent.Tolerations) > 0 {
+			tolerations = agent.Tolerations
+		}
+		resourceRequirements = agent.Resources
+		labels = map[string]string{DedicatedAgentLabelKey: jenkins.Name}
+		annotations = map[string]string{dedicatedAgentNodeAnnotation: agentName}
+	}
+
+	profile := securityprofile.Get(jenkins)
+	container := securityprofile.Container(profile, corev1.Container{
+		Name:      "jnlp",
+		Image:     image,
+		Env:       append(envs, resources.GetProxyEnvs(jenkins)...),
+		Resources: resourceRequirements,
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      homeVolumeName,
+				MountPath: homeVolumePath,
+			},
+			{
+				Name:      workspaceVolumeName,
+				MountPath: workspaceVolumePath,
+			},
+		},
+	})
+
 	return &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      agentDeploymentName(*jenkins, agentName),
-			Namespace: namespace,
+			Name:        agentDeploymentName(*jenkins, agentName),
+			Namespace:   namespace,
+			Labels:      labels,
+			Annotations: annotations,
 			OwnerReferences: []metav1.OwnerReference{
 				{
 					BlockOwnerDeletion: &[]bool{true}[0],
@@ -436,54 +649,13 @@ func agentDeployment(jenkins *v1alpha2.Jenkins, namespace string, agentName stri
 		Spec: appsv1.DeploymentSpec{
 			Template: corev1.PodTemplateSpec{
 				Spec: corev1.PodSpec{
-					NodeSelector:     jenkins.Spec.Master.NodeSelector,
-					Tolerations:      jenkins.Spec.Master.Tolerations,
+					NodeSelector:     nodeSelector,
+					Tolerations:      tolerations,
 					ImagePullSecrets: jenkins.Spec.Master.ImagePullSecrets,
 					HostAliases:      jenkins.Spec.Master.HostAliases,
-					Containers: []corev1.Container{
-						{
-							Name:  "jnlp",
-							Image: "jenkins/inbound-agent:4.9-1",
-							Env: []corev1.EnvVar{
-								{
-									Name: "JENKINS_TUNNEL",
-									Value: fmt.Sprintf("%s:%d",
-										jenkinsSlavesServiceFQDN,
-										jenkins.Spec.SlaveService.Port),
-								},
-								{
-									Name:  "JENKINS_SECRET",
-									Value: secret,
-								},
-								{
-									Name:  "JENKINS_AGENT_NAME",
-									Value: agentName,
-								},
-								{
-									Name: "JENKINS_URL",
-									Value: fmt.Sprintf("http://%s:%d%s",
-										jenkinsHTTPServiceFQDN,
-										jenkins.Spec.Service.Port,
-										suffix),
-								},
-								{
-									Name:  "JENKINS_AGENT_WORKDIR",
-									Value: homeVolumePath,
-								},
-							},
-							VolumeMounts: []corev1.VolumeMount{
-								{
-									Name:      homeVolumeName,
-									MountPath: homeVolumePath,
-								},
-								{
-									Name:      workspaceVolumeName,
-									MountPath: workspaceVolumePath,
-								},
-							},
-						},
-					},
-					Volumes: []corev1.Volume{
+					SecurityContext:  securityprofile.PodSecurityContext(profile, nil),
+					Containers:       []corev1.Container{container},
+					Volumes: append([]corev1.Volume{
 						{
 							Name: homeVolumeName,
 							VolumeSource: corev1.VolumeSource{
@@ -496,7 +668,7 @@ func agentDeployment(jenkins *v1alpha2.Jenkins, namespace string, agentName stri
 								EmptyDir: &corev1.EmptyDirVolumeSource{},
 							},
 						},
-					},
+					}, securityprofile.Volumes(profile)...),
 				},
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: map[string]string{
@@ -530,6 +702,7 @@ func seedJobCreatingGroovyScript(seedJob v1alpha2.SeedJob) (string, error) {
 		UnstableOnDeprecation bool
 		SeedJobSuffix         string
 		AgentName             string
+		TimeoutMinutes        int
 	}{
 		ID:                    seedJob.ID,
 		CredentialID:          seedJob.CredentialID,
@@ -545,7 +718,10 @@ func seedJobCreatingGroovyScript(seedJob v1alpha2.SeedJob) (string, error) {
 		FailOnMissingPlugin:   seedJob.FailOnMissingPlugin,
 		UnstableOnDeprecation: seedJob.UnstableOnDeprecation,
 		SeedJobSuffix:         constants.SeedJobSuffix,
-		AgentName:             AgentName,
+		AgentName:             seedJobAgentName(seedJob),
+	}
+	if seedJob.Timeout != nil {
+		data.TimeoutMinutes = int(math.Ceil(seedJob.Timeout.Duration.Minutes()))
 	}
 
 	output, err := render.Render(seedJobGroovyScriptTemplate, data)
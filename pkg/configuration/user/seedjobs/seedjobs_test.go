@@ -3,15 +3,18 @@ package seedjobs
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
 	jenkinsclient "github.com/jenkinsci/kubernetes-operator/pkg/client"
 	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
 	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/securityprofile"
 
 	"github.com/bndr/gojenkins"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -161,6 +164,122 @@ func TestEnsureSeedJobs(t *testing.T) {
 
 		assert.True(t, errors.IsNotFound(err), "Agent deployment hasn't been deleted")
 	})
+
+	t.Run("dedicated agent", func(t *testing.T) {
+		// given
+		ctrl := gomock.NewController(t)
+		ctx := context.TODO()
+		defer ctrl.Finish()
+
+		jenkins := jenkinsCustomResource()
+		resourceRequirements := corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+		}
+		jenkins.Spec.SeedJobs[0].Agent = &v1alpha2.SeedJobAgent{Image: "jenkins/inbound-agent:custom", Resources: resourceRequirements}
+		agentName := seedJobAgentName(jenkins.Spec.SeedJobs[0])
+		staleAgentName := AgentName + "-removed"
+
+		jenkinsClient := jenkinsclient.NewMockJenkins(ctrl)
+		fakeClient := fake.NewClientBuilder().Build()
+		err := v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme)
+		assert.NoError(t, err)
+
+		config := configuration.Configuration{
+			Client:        fakeClient,
+			ClientSet:     kubernetes.Clientset{},
+			Notifications: nil,
+			Jenkins:       jenkins,
+		}
+
+		jenkinsClient.EXPECT().GetNode(agentName).Return(nil, nil)
+		jenkinsClient.EXPECT().GetNodeSecret(agentName).Return(agentSecret, nil)
+		jenkinsClient.EXPECT().DeleteNode(staleAgentName).Return(true, nil)
+
+		for _, deployment := range []*appsv1.Deployment{
+			{ObjectMeta: metav1.ObjectMeta{Name: agentDeploymentName(*jenkins, AgentName), Namespace: jenkins.Namespace}},
+			{ObjectMeta: metav1.ObjectMeta{
+				Name:        agentDeploymentName(*jenkins, staleAgentName),
+				Namespace:   jenkins.Namespace,
+				Labels:      map[string]string{DedicatedAgentLabelKey: jenkins.Name},
+				Annotations: map[string]string{dedicatedAgentNodeAnnotation: staleAgentName},
+			}},
+		} {
+			assert.NoError(t, fakeClient.Create(ctx, deployment))
+		}
+
+		seedJobsClient := New(jenkinsClient, config)
+
+		// when
+		_, err = seedJobsClient.EnsureSeedJobs(jenkins)
+
+		// then
+		assert.NoError(t, err)
+
+		var deployment appsv1.Deployment
+		err = fakeClient.Get(ctx, types.NamespacedName{Namespace: jenkins.Namespace, Name: agentDeploymentName(*jenkins, agentName)}, &deployment)
+		assert.NoError(t, err)
+		assert.Equal(t, "jenkins/inbound-agent:custom", deployment.Spec.Template.Spec.Containers[0].Image)
+		assert.Equal(t, resourceRequirements, deployment.Spec.Template.Spec.Containers[0].Resources)
+		assert.Equal(t, agentName, deployment.Annotations[dedicatedAgentNodeAnnotation])
+
+		err = fakeClient.Get(ctx, types.NamespacedName{Namespace: jenkins.Namespace, Name: agentDeploymentName(*jenkins, AgentName)}, &deployment)
+		assert.True(t, errors.IsNotFound(err), "Shared agent deployment hasn't been deleted")
+		err = fakeClient.Get(ctx, types.NamespacedName{Namespace: jenkins.Namespace, Name: agentDeploymentName(*jenkins, staleAgentName)}, &deployment)
+		assert.True(t, errors.IsNotFound(err), "Stale agent deployment hasn't been deleted")
+	})
+
+	t.Run("defer pod restart after seed job deletion", func(t *testing.T) {
+		// given
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		jenkins := jenkinsCustomResource()
+		jenkins.Spec.SeedJobs = nil
+		jenkins.Spec.MaintenanceWindows = []v1alpha2.MaintenanceWindow{{Schedule: "0 0 1 1 *", Duration: metav1.Duration{Duration: time.Minute}}}
+		jenkins.Status.CreatedSeedJobs = []string{"removed"}
+
+		err := v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme)
+		assert.NoError(t, err)
+		fakeClient := fake.NewClientBuilder().WithObjects(jenkins).Build()
+		config := configuration.Configuration{
+			Client:    fakeClient,
+			ClientSet: kubernetes.Clientset{},
+			Jenkins:   jenkins,
+		}
+
+		seedJobsClient := New(jenkinsclient.NewMockJenkins(ctrl), config)
+
+		// when
+		done, err := seedJobsClient.EnsureSeedJobs(jenkins)
+
+		// then
+		require.NoError(t, err)
+		assert.True(t, done)
+		require.Len(t, jenkins.Status.DeferredActions, 1)
+		assert.Equal(t, v1alpha2.DisruptiveActionSeedJobRemoval, jenkins.Status.DeferredActions[0].Action)
+		assert.Equal(t, []string{"removed"}, jenkins.Status.CreatedSeedJobs)
+	})
+}
+
+func TestSeedJobCreatingGroovyScript(t *testing.T) {
+	t.Run("shared agent without timeout", func(t *testing.T) {
+		script, err := seedJobCreatingGroovyScript(v1alpha2.SeedJob{ID: "jobs"})
+
+		assert.NoError(t, err)
+		assert.Contains(t, script, `jobRef.setAssignedLabel(new LabelAtom("seed-job-agent"))`)
+		assert.NotContains(t, script, "new BuildTimeoutWrapper")
+	})
+	t.Run("dedicated agent with timeout", func(t *testing.T) {
+		script, err := seedJobCreatingGroovyScript(v1alpha2.SeedJob{
+			ID:      "jobs",
+			Agent:   &v1alpha2.SeedJobAgent{},
+			Timeout: &metav1.Duration{Duration: 150 * time.Second},
+		})
+
+		assert.NoError(t, err)
+		assert.Contains(t, script, `jobRef.setAssignedLabel(new LabelAtom("seed-job-agent-jobs"))`)
+		assert.Contains(t, script, `new BuildTimeoutWrapper(new AbsoluteTimeOutStrategy("3"), [new AbortOperation()], "")`)
+	})
 }
 
 func TestCreateAgent(t *testing.T) {
@@ -199,13 +318,40 @@ func TestCreateAgent(t *testing.T) {
 		assert.NoError(t, err)
 
 		// when
-		err = seedJobsClient.createAgent(jenkinsClient, fakeClient, jenkinsCustomResource(), jenkins.Namespace, AgentName)
+		err = seedJobsClient.createAgent(jenkinsClient, fakeClient, jenkinsCustomResource(), jenkins.Namespace, AgentName, nil)
 
 		// then
 		assert.NoError(t, err)
 	})
 }
 
+func TestAgentDeployment(t *testing.T) {
+	t.Run("custom security profile", func(t *testing.T) {
+		deployment, err := agentDeployment(jenkinsCustomResource(), "default", AgentName, nil, agentSecret, "cluster.local")
+
+		require.NoError(t, err)
+		podSpec := deployment.Spec.Template.Spec
+		assert.Nil(t, podSpec.SecurityContext)
+		assert.Nil(t, podSpec.Containers[0].SecurityContext)
+		assert.Len(t, podSpec.Volumes, 2)
+	})
+	t.Run("restricted security profile of dedicated agent", func(t *testing.T) {
+		jenkins := jenkinsCustomResource()
+		jenkins.Spec.SecurityProfile = v1alpha2.RestrictedSecurityProfile
+
+		deployment, err := agentDeployment(jenkins, "default", "seed-job-agent", &v1alpha2.SeedJobAgent{}, agentSecret, "cluster.local")
+
+		require.NoError(t, err)
+		podSpec := deployment.Spec.Template.Spec
+		require.NotNil(t, podSpec.SecurityContext)
+		assert.Equal(t, true, *podSpec.SecurityContext.RunAsNonRoot)
+		require.NotNil(t, podSpec.Containers[0].SecurityContext)
+		assert.Equal(t, false, *podSpec.Containers[0].SecurityContext.AllowPrivilegeEscalation)
+		assert.Contains(t, podSpec.Containers[0].VolumeMounts, corev1.VolumeMount{Name: securityprofile.TmpVolumeName, MountPath: securityprofile.TmpVolumePath})
+		assert.Equal(t, securityprofile.TmpVolumeName, podSpec.Volumes[len(podSpec.Volumes)-1].Name)
+	})
+}
+
 func TestSeedJobs_isRecreatePodNeeded(t *testing.T) {
 	config := configuration.Configuration{
 		Client:        nil,
@@ -279,3 +425,47 @@ func TestSeedJobs_isRecreatePodNeeded(t *testing.T) {
 		assert.True(t, got)
 	})
 }
+
+func TestSeedJobs_rerunJobs(t *testing.T) {
+	t.Run("not requested", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		jenkins := jenkinsCustomResource()
+		jenkinsClient := jenkinsclient.NewMockJenkins(ctrl)
+		seedJobsClient := New(jenkinsClient, configuration.Configuration{Jenkins: jenkins})
+
+		err := seedJobsClient.rerunJobs(jenkins)
+
+		assert.NoError(t, err)
+	})
+	t.Run("requested", func(t *testing.T) {
+		// given
+		ctrl := gomock.NewController(t)
+		ctx := context.TODO()
+		defer ctrl.Finish()
+
+		err := v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme)
+		assert.NoError(t, err)
+		fakeClient := fake.NewClientBuilder().Build()
+		jenkins := jenkinsCustomResource()
+		jenkins.Annotations = map[string]string{v1alpha2.RerunSeedJobsAnnotation: "2021-12-08T10:00:00Z"}
+		err = fakeClient.Create(ctx, jenkins)
+		assert.NoError(t, err)
+
+		jenkinsClient := jenkinsclient.NewMockJenkins(ctrl)
+		jenkinsClient.EXPECT().BuildJob("jenkins-operator-e2e-job-dsl-seed", map[string]string{}).Return(int64(1), nil)
+		seedJobsClient := New(jenkinsClient, configuration.Configuration{Client: fakeClient, Jenkins: jenkins})
+
+		// when
+		err = seedJobsClient.rerunJobs(jenkins)
+
+		// then
+		assert.NoError(t, err)
+		assert.NotContains(t, jenkins.Annotations, v1alpha2.RerunSeedJobsAnnotation)
+		current := &v1alpha2.Jenkins{}
+		err = fakeClient.Get(ctx, types.NamespacedName{Namespace: jenkins.Namespace, Name: jenkins.Name}, current)
+		assert.NoError(t, err)
+		assert.NotContains(t, current.Annotations, v1alpha2.RerunSeedJobsAnnotation)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
 
@@ -12,9 +13,14 @@ import (
 	"golang.org/x/crypto/ssh"
 	v1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
+// minTimeout is the minimum timeout of build-timeout plugin absolute strategy
+const minTimeout = 3 * time.Minute
+
 // ValidateSeedJobs verify seed jobs configuration
 func (s *seedJobs) ValidateSeedJobs(jenkins v1alpha2.Jenkins) ([]string, error) {
 	var messages []string
@@ -112,6 +118,18 @@ func (s *seedJobs) ValidateSeedJobs(jenkins v1alpha2.Jenkins) ([]string, error)
 				}
 			}
 		}
+
+		if seedJob.Timeout != nil {
+			for _, m := range validateTimeout(jenkins, *seedJob.Timeout) {
+				messages = append(messages, fmt.Sprintf("seedJob `%s` %s", seedJob.ID, m))
+			}
+		}
+
+		if seedJob.Agent != nil && len(seedJob.ID) > 0 {
+			for _, m := range validateAgent(jenkins, seedJob) {
+				messages = append(messages, fmt.Sprintf("seedJob `%s` %s", seedJob.ID, m))
+			}
+		}
 	}
 
 	return messages, nil
@@ -142,6 +160,30 @@ func (s *seedJobs) validateBitbucketPushTrigger(jenkins v1alpha2.Jenkins) []stri
 	return messages
 }
 
+func validateTimeout(jenkins v1alpha2.Jenkins, timeout metav1.Duration) []string {
+	var messages []string
+	if timeout.Duration < minTimeout {
+		messages = append(messages, fmt.Sprintf("timeout '%s' must be at least %s", timeout.Duration, minTimeout))
+	}
+	if err := checkPluginExists(jenkins, "build-timeout"); err != nil {
+		messages = append(messages, fmt.Sprintf("timeout cannot be set: %s", err))
+	}
+	return messages
+}
+
+func validateAgent(jenkins v1alpha2.Jenkins, seedJob v1alpha2.SeedJob) []string {
+	var messages []string
+	agentName := seedJobAgentName(seedJob)
+	// the agent name is used in the deployment name and in the pod selector label
+	for _, m := range validation.IsDNS1123Subdomain(agentDeploymentName(jenkins, agentName)) {
+		messages = append(messages, fmt.Sprintf("agent deployment name '%s' is invalid: %s", agentDeploymentName(jenkins, agentName), m))
+	}
+	for _, m := range validation.IsValidLabelValue(fmt.Sprintf("%s-selector", agentName)) {
+		messages = append(messages, fmt.Sprintf("agent name '%s' is invalid: %s", agentName, m))
+	}
+	return messages
+}
+
 func checkPluginExists(jenkins v1alpha2.Jenkins, name string) error {
 	exists := false
 	for _, plugin := range jenkins.Spec.Master.BasePlugins {
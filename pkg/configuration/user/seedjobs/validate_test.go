@@ -2,7 +2,9 @@ package seedjobs
 
 import (
 	"context"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
 	"github.com/jenkinsci/kubernetes-operator/pkg/configuration"
@@ -882,6 +884,106 @@ func TestValidateSeedJobs(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Nil(t, result)
 	})
+	t.Run("Invalid with too short timeout and not installed build-timeout plugin", func(t *testing.T) {
+		jenkins := v1alpha2.Jenkins{
+			Spec: v1alpha2.JenkinsSpec{
+				SeedJobs: []v1alpha2.SeedJob{
+					{
+						ID:                    "example",
+						JenkinsCredentialType: v1alpha2.NoJenkinsCredentialCredentialType,
+						Targets:               "cicd/jobs/*.jenkins",
+						RepositoryBranch:      "master",
+						RepositoryURL:         "https://github.com/jenkinsci/kubernetes-operator.git",
+						Timeout:               &metav1.Duration{Duration: time.Minute},
+					},
+				},
+			},
+		}
+
+		config := configuration.Configuration{
+			Client:        fake.NewClientBuilder().Build(),
+			ClientSet:     kubernetes.Clientset{},
+			Notifications: nil,
+			Jenkins:       &v1alpha2.Jenkins{},
+		}
+
+		seedJobs := New(nil, config)
+		result, err := seedJobs.ValidateSeedJobs(jenkins)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			"seedJob `example` timeout '1m0s' must be at least 3m0s",
+			"seedJob `example` timeout cannot be set: `build-timeout` plugin not installed",
+		}, result)
+	})
+	t.Run("Valid with timeout and dedicated agent", func(t *testing.T) {
+		jenkins := v1alpha2.Jenkins{
+			ObjectMeta: jenkinsObjectMeta,
+			Spec: v1alpha2.JenkinsSpec{
+				SeedJobs: []v1alpha2.SeedJob{
+					{
+						ID:                    "example",
+						JenkinsCredentialType: v1alpha2.NoJenkinsCredentialCredentialType,
+						Targets:               "cicd/jobs/*.jenkins",
+						RepositoryBranch:      "master",
+						RepositoryURL:         "https://github.com/jenkinsci/kubernetes-operator.git",
+						Timeout:               &metav1.Duration{Duration: 10 * time.Minute},
+						Agent:                 &v1alpha2.SeedJobAgent{},
+					},
+				},
+				Master: v1alpha2.JenkinsMaster{
+					Plugins: []v1alpha2.Plugin{
+						{Name: "build-timeout", Version: "1.20"},
+					},
+				},
+			},
+		}
+
+		config := configuration.Configuration{
+			Client:        fake.NewClientBuilder().Build(),
+			ClientSet:     kubernetes.Clientset{},
+			Notifications: nil,
+			Jenkins:       &v1alpha2.Jenkins{},
+		}
+
+		seedJobs := New(nil, config)
+		result, err := seedJobs.ValidateSeedJobs(jenkins)
+
+		assert.NoError(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("Invalid with dedicated agent and too long id", func(t *testing.T) {
+		id := strings.Repeat("a", 50)
+		jenkins := v1alpha2.Jenkins{
+			ObjectMeta: jenkinsObjectMeta,
+			Spec: v1alpha2.JenkinsSpec{
+				SeedJobs: []v1alpha2.SeedJob{
+					{
+						ID:                    id,
+						JenkinsCredentialType: v1alpha2.NoJenkinsCredentialCredentialType,
+						Targets:               "cicd/jobs/*.jenkins",
+						RepositoryBranch:      "master",
+						RepositoryURL:         "https://github.com/jenkinsci/kubernetes-operator.git",
+						Agent:                 &v1alpha2.SeedJobAgent{},
+					},
+				},
+			},
+		}
+
+		config := configuration.Configuration{
+			Client:        fake.NewClientBuilder().Build(),
+			ClientSet:     kubernetes.Clientset{},
+			Notifications: nil,
+			Jenkins:       &v1alpha2.Jenkins{},
+		}
+
+		seedJobs := New(nil, config)
+		result, err := seedJobs.ValidateSeedJobs(jenkins)
+
+		assert.NoError(t, err)
+		assert.Len(t, result, 1)
+		assert.Contains(t, result[0], "seedJob `"+id+"` agent name 'seed-job-agent-"+id+"' is invalid")
+	})
 }
 
 func TestValidateIfIDIsUnique(t *testing.T) {
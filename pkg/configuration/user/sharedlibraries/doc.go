@@ -0,0 +1,2 @@
+// Package sharedlibraries configures global pipeline libraries from spec.sharedLibraries with help Configuration as a code plugin
+package sharedlibraries
@@ -0,0 +1,152 @@
+package sharedlibraries
+
+import (
+	"fmt"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	jenkinsclient "github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/user/casc"
+	"github.com/jenkinsci/kubernetes-operator/pkg/groovy"
+
+	"github.com/pkg/errors"
+	k8s "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// ConfigurationType is the configuration type of shared libraries stored in status.appliedGroovyScripts
+	ConfigurationType = "user-shared-libraries"
+
+	sharedLibrariesSource = "spec.sharedLibraries"
+	sharedLibrariesName   = "shared-libraries.yaml"
+)
+
+// SharedLibraries defines client for global pipeline libraries
+type SharedLibraries interface {
+	Ensure() (requeue bool, err error)
+	Plan() ([]v1alpha2.PlannedConfigurationChange, error)
+}
+
+type sharedLibraries struct {
+	groovyClient *groovy.Groovy
+	jenkins      *v1alpha2.Jenkins
+}
+
+// New creates new instance of SharedLibraries
+func New(jenkinsClient jenkinsclient.Jenkins, k8sClient k8s.Client, jenkins *v1alpha2.Jenkins) SharedLibraries {
+	return &sharedLibraries{
+		groovyClient: groovy.New(jenkinsClient, k8sClient, jenkins, ConfigurationType, v1alpha2.Customization{}),
+		jenkins:      jenkins,
+	}
+}
+
+// Ensure synchronizes global pipeline libraries with spec.sharedLibraries
+func (s *sharedLibraries) Ensure() (requeue bool, err error) {
+	if !s.isManaged() {
+		return false, nil
+	}
+
+	configurationAsCode, err := RenderConfigurationAsCode(s.jenkins.Spec.SharedLibraries)
+	if err != nil {
+		return true, err
+	}
+	return s.groovyClient.EnsureGenerated(sharedLibrariesSource, sharedLibrariesName, casc.UpdateGroovyScript(configurationAsCode))
+}
+
+// Plan returns the shared libraries Configuration as Code file if it would be applied by Ensure
+func (s *sharedLibraries) Plan() ([]v1alpha2.PlannedConfigurationChange, error) {
+	if !s.isManaged() {
+		return nil, nil
+	}
+
+	configurationAsCode, err := RenderConfigurationAsCode(s.jenkins.Spec.SharedLibraries)
+	if err != nil {
+		return nil, err
+	}
+	return s.groovyClient.PlanGenerated(sharedLibrariesSource, sharedLibrariesName, casc.UpdateGroovyScript(configurationAsCode))
+}
+
+// isManaged returns true if spec.sharedLibraries is set or the libraries from it were applied before,
+// so the libraries are removed from Jenkins when spec.sharedLibraries is cleared
+func (s *sharedLibraries) isManaged() bool {
+	if len(s.jenkins.Spec.SharedLibraries) > 0 {
+		return true
+	}
+	for _, applied := range s.jenkins.Status.AppliedGroovyScripts {
+		if applied.ConfigurationType == ConfigurationType {
+			return true
+		}
+	}
+	return false
+}
+
+// RenderConfigurationAsCode returns Configuration as Code YAML with global pipeline libraries
+func RenderConfigurationAsCode(libraries []v1alpha2.SharedLibrary) (string, error) {
+	rendered := []interface{}{}
+	for _, library := range libraries {
+		git := map[string]interface{}{"remote": library.RepositoryURL}
+		if len(library.CredentialID) > 0 {
+			git["credentialsId"] = library.CredentialID
+		}
+
+		config := map[string]interface{}{
+			"name":     library.Name,
+			"implicit": library.Implicit,
+			"retriever": map[string]interface{}{
+				"modernSCM": map[string]interface{}{
+					"scm": map[string]interface{}{"git": git},
+				},
+			},
+		}
+		if len(library.DefaultVersion) > 0 {
+			config["defaultVersion"] = library.DefaultVersion
+		}
+		if library.Cache != nil {
+			config["cachingConfiguration"] = map[string]interface{}{
+				"refreshTimeMinutes": library.Cache.RefreshTimeMinutes,
+			}
+		}
+		rendered = append(rendered, config)
+	}
+
+	configurationAsCode, err := yaml.Marshal(map[string]interface{}{
+		"unclassified": map[string]interface{}{
+			"globalLibraries": map[string]interface{}{"libraries": rendered},
+		},
+	})
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	return string(configurationAsCode), nil
+}
+
+// ClearCache removes all cached versions of the library from Jenkins master
+func ClearCache(jenkinsClient jenkinsclient.Jenkins, name string) error {
+	if !namePattern.MatchString(name) {
+		return errors.Errorf("invalid shared library name '%s'", name)
+	}
+
+	logs, err := jenkinsClient.ExecuteScript(fmt.Sprintf(clearCacheGroovyScriptFmt, name))
+	if err != nil {
+		return errors.Wrapf(err, "failed to clear '%s' shared library cache, logs '%s'", name, logs)
+	}
+	return nil
+}
+
+const clearCacheGroovyScriptFmt = `
+import org.jenkinsci.plugins.workflow.libs.LibraryCachingConfiguration
+
+def libraryName = '%s'
+def cacheDir = LibraryCachingConfiguration.getGlobalLibrariesCacheDir()
+if (!cacheDir.exists()) {
+    return
+}
+for (def namePath : cacheDir.list('*-name.txt')) {
+    def cachedVersion = namePath.readToString()
+    if (cachedVersion.startsWith(libraryName + '@')) {
+        cacheDir.child(namePath.getName().replace('-name.txt', '')).deleteRecursive()
+        namePath.delete()
+        println "Cleared cache of ${cachedVersion}"
+    }
+}
+`
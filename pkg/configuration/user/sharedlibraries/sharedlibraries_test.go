@@ -0,0 +1,152 @@
+package sharedlibraries
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	jenkinsclient "github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestRenderConfigurationAsCode(t *testing.T) {
+	t.Run("libraries", func(t *testing.T) {
+		libraries := []v1alpha2.SharedLibrary{
+			{
+				Name:           "pipeline-library",
+				RepositoryURL:  "https://github.com/jenkinsci/pipeline-library.git",
+				DefaultVersion: "master",
+				Implicit:       true,
+				Cache:          &v1alpha2.SharedLibraryCache{RefreshTimeMinutes: 60},
+			},
+			{
+				Name:          "private",
+				RepositoryURL: "git@github.com:example/private.git",
+				CredentialID:  "private-ssh",
+			},
+		}
+
+		got, err := RenderConfigurationAsCode(libraries)
+
+		require.NoError(t, err)
+		assert.Equal(t, `unclassified:
+  globalLibraries:
+    libraries:
+    - cachingConfiguration:
+        refreshTimeMinutes: 60
+      defaultVersion: master
+      implicit: true
+      name: pipeline-library
+      retriever:
+        modernSCM:
+          scm:
+            git:
+              remote: https://github.com/jenkinsci/pipeline-library.git
+    - implicit: false
+      name: private
+      retriever:
+        modernSCM:
+          scm:
+            git:
+              credentialsId: private-ssh
+              remote: git@github.com:example/private.git
+`, got)
+	})
+	t.Run("no libraries", func(t *testing.T) {
+		got, err := RenderConfigurationAsCode(nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, `unclassified:
+  globalLibraries:
+    libraries: []
+`, got)
+	})
+}
+
+func TestSharedLibraries_Ensure(t *testing.T) {
+	log.SetupLogger(true)
+	require.NoError(t, v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme))
+
+	t.Run("not managed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		jenkins := &v1alpha2.Jenkins{ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: "default"}}
+
+		requeue, err := New(jenkinsclient.NewMockJenkins(ctrl), fake.NewClientBuilder().Build(), jenkins).Ensure()
+
+		require.NoError(t, err)
+		assert.False(t, requeue)
+	})
+	t.Run("apply libraries once", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		jenkins := &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: "default"},
+			Spec: v1alpha2.JenkinsSpec{
+				SharedLibraries: []v1alpha2.SharedLibrary{{Name: "library", RepositoryURL: "https://example.com/library.git"}},
+			},
+		}
+		fakeClient := fake.NewClientBuilder().Build()
+		require.NoError(t, fakeClient.Create(context.TODO(), jenkins))
+		jenkinsClient := jenkinsclient.NewMockJenkins(ctrl)
+		jenkinsClient.EXPECT().ExecuteScript(gomock.Any()).Return("", nil).Times(1)
+		sharedLibraries := New(jenkinsClient, fakeClient, jenkins)
+
+		requeue, err := sharedLibraries.Ensure()
+		require.NoError(t, err)
+		assert.True(t, requeue)
+
+		requeue, err = sharedLibraries.Ensure()
+		require.NoError(t, err)
+		assert.False(t, requeue)
+		changes, err := sharedLibraries.Plan()
+		require.NoError(t, err)
+		assert.Empty(t, changes)
+	})
+	t.Run("remove libraries", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		jenkins := &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: "default"},
+			Status: v1alpha2.JenkinsStatus{
+				AppliedGroovyScripts: []v1alpha2.AppliedGroovyScript{
+					{ConfigurationType: ConfigurationType, Source: sharedLibrariesSource, Name: sharedLibrariesName, Hash: "old"},
+				},
+			},
+		}
+
+		changes, err := New(jenkinsclient.NewMockJenkins(ctrl), fake.NewClientBuilder().Build(), jenkins).Plan()
+
+		require.NoError(t, err)
+		assert.Equal(t, []v1alpha2.PlannedConfigurationChange{
+			{ConfigurationType: ConfigurationType, Source: sharedLibrariesSource, Name: sharedLibrariesName},
+		}, changes)
+	})
+}
+
+func TestClearCache(t *testing.T) {
+	t.Run("valid name", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		jenkinsClient := jenkinsclient.NewMockJenkins(ctrl)
+		jenkinsClient.EXPECT().ExecuteScript(gomock.Any()).DoAndReturn(func(script string) (string, error) {
+			assert.Contains(t, script, "def libraryName = 'pipeline-library'")
+			return "", nil
+		})
+
+		assert.NoError(t, ClearCache(jenkinsClient, "pipeline-library"))
+	})
+	t.Run("invalid name", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		assert.Error(t, ClearCache(jenkinsclient.NewMockJenkins(ctrl), "library'; println 'injected"))
+	})
+}
@@ -0,0 +1,42 @@
+package sharedlibraries
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+)
+
+// namePattern matches the library names, they're used in groovy scripts and webhook URLs
+var namePattern = regexp.MustCompile(`^[0-9a-zA-Z._-]+$`)
+
+// Validate validates global pipeline libraries from spec.sharedLibraries
+func Validate(libraries []v1alpha2.SharedLibrary) []string {
+	var messages []string
+	names := map[string]bool{}
+	for i, library := range libraries {
+		field := fmt.Sprintf("spec.sharedLibraries[%d]", i)
+
+		if len(library.Name) == 0 {
+			messages = append(messages, fmt.Sprintf("%s.name is not set", field))
+		} else if !namePattern.MatchString(library.Name) {
+			messages = append(messages, fmt.Sprintf("%s.name '%s' is invalid, it must match '%s'", field, library.Name, namePattern))
+		} else if names[library.Name] {
+			messages = append(messages, fmt.Sprintf("%s.name '%s' is duplicated", field, library.Name))
+		}
+		names[library.Name] = true
+
+		if len(library.RepositoryURL) == 0 {
+			messages = append(messages, fmt.Sprintf("%s.repositoryUrl is not set", field))
+		}
+
+		if library.Cache != nil && library.Cache.WebhookSecretKeySelector != nil {
+			selector := library.Cache.WebhookSecretKeySelector
+			if len(selector.Name) == 0 || len(selector.Key) == 0 {
+				messages = append(messages, fmt.Sprintf("%s.cache.webhookSecretKeySelector secret name and key must be set", field))
+			}
+		}
+	}
+
+	return messages
+}
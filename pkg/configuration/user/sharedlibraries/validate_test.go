@@ -0,0 +1,45 @@
+package sharedlibraries
+
+import (
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestValidate(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		libraries := []v1alpha2.SharedLibrary{
+			{
+				Name:          "pipeline-library",
+				RepositoryURL: "https://github.com/jenkinsci/pipeline-library.git",
+				Cache: &v1alpha2.SharedLibraryCache{
+					WebhookSecretKeySelector: &v1alpha2.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "webhook"},
+						Key:                  "token",
+					},
+				},
+			},
+		}
+
+		assert.Empty(t, Validate(libraries))
+	})
+	t.Run("invalid", func(t *testing.T) {
+		libraries := []v1alpha2.SharedLibrary{
+			{RepositoryURL: "https://example.com/library.git"},
+			{Name: "library", RepositoryURL: "https://example.com/library.git"},
+			{Name: "library", Cache: &v1alpha2.SharedLibraryCache{WebhookSecretKeySelector: &v1alpha2.SecretKeySelector{}}},
+			{Name: "my library", RepositoryURL: "https://example.com/library.git"},
+		}
+
+		assert.Equal(t, []string{
+			"spec.sharedLibraries[0].name is not set",
+			"spec.sharedLibraries[2].name 'library' is duplicated",
+			"spec.sharedLibraries[2].repositoryUrl is not set",
+			"spec.sharedLibraries[2].cache.webhookSecretKeySelector secret name and key must be set",
+			"spec.sharedLibraries[3].name 'my library' is invalid, it must match '^[0-9a-zA-Z._-]+$'",
+		}, Validate(libraries))
+	})
+}
@@ -0,0 +1,42 @@
+package sharedlibraries
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"net/http"
+	"strings"
+)
+
+const (
+	// gitLabTokenHeader is the header with the secret token sent by GitLab webhooks
+	gitLabTokenHeader = "X-Gitlab-Token"
+	// gitHubSignatureHeader is the header with HMAC SHA256 signature of the payload sent by GitHub webhooks
+	gitHubSignatureHeader = "X-Hub-Signature-256"
+	gitHubSignaturePrefix = "sha256="
+)
+
+// VerifyWebhook returns true if the webhook request is authorized by the secret token,
+// either with GitLab token header or GitHub payload signature
+func VerifyWebhook(header http.Header, payload []byte, token string) bool {
+	if len(token) == 0 {
+		return false
+	}
+
+	if gitLabToken := header.Get(gitLabTokenHeader); len(gitLabToken) > 0 {
+		return subtle.ConstantTimeCompare([]byte(gitLabToken), []byte(token)) == 1
+	}
+
+	signature := header.Get(gitHubSignatureHeader)
+	if !strings.HasPrefix(signature, gitHubSignaturePrefix) {
+		return false
+	}
+	received, err := hex.DecodeString(strings.TrimPrefix(signature, gitHubSignaturePrefix))
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(token))
+	_, _ = mac.Write(payload)
+	return hmac.Equal(received, mac.Sum(nil))
+}
@@ -0,0 +1,33 @@
+package sharedlibraries
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyWebhook(t *testing.T) {
+	token := "secret-token"
+	payload := []byte(`{"ref":"refs/heads/master"}`)
+	mac := hmac.New(sha256.New, []byte(token))
+	_, _ = mac.Write(payload)
+	signature := gitHubSignaturePrefix + hex.EncodeToString(mac.Sum(nil))
+
+	t.Run("GitLab token", func(t *testing.T) {
+		assert.True(t, VerifyWebhook(http.Header{gitLabTokenHeader: {token}}, payload, token))
+		assert.False(t, VerifyWebhook(http.Header{gitLabTokenHeader: {"wrong"}}, payload, token))
+	})
+	t.Run("GitHub signature", func(t *testing.T) {
+		assert.True(t, VerifyWebhook(http.Header{gitHubSignatureHeader: {signature}}, payload, token))
+		assert.False(t, VerifyWebhook(http.Header{gitHubSignatureHeader: {signature}}, []byte("tampered"), token))
+		assert.False(t, VerifyWebhook(http.Header{gitHubSignatureHeader: {"sha256=not-hex"}}, payload, token))
+	})
+	t.Run("missing token", func(t *testing.T) {
+		assert.False(t, VerifyWebhook(http.Header{}, payload, token))
+		assert.False(t, VerifyWebhook(http.Header{gitLabTokenHeader: {""}}, payload, ""))
+	})
+}
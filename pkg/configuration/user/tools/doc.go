@@ -0,0 +1,2 @@
+// Package tools configures global tool installations from spec.tools with help Configuration as a code plugin
+package tools
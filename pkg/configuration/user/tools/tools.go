@@ -0,0 +1,156 @@
+package tools
+
+import (
+	"strings"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	jenkinsclient "github.com/jenkinsci/kubernetes-operator/pkg/client"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/user/casc"
+	"github.com/jenkinsci/kubernetes-operator/pkg/groovy"
+	"github.com/jenkinsci/kubernetes-operator/pkg/plugins"
+
+	"github.com/pkg/errors"
+	k8s "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// ConfigurationType is the configuration type of tool installations stored in status.appliedGroovyScripts
+	ConfigurationType = "user-tools"
+
+	toolsSource = "spec.tools"
+	toolsName   = "tools.yaml"
+
+	npmPackagesRefreshHours = 72
+)
+
+// Tools defines client for global tool installations
+type Tools interface {
+	Ensure() (requeue bool, err error)
+	Plan() ([]v1alpha2.PlannedConfigurationChange, error)
+}
+
+type tools struct {
+	groovyClient *groovy.Groovy
+	jenkins      *v1alpha2.Jenkins
+}
+
+// New creates new instance of Tools
+func New(jenkinsClient jenkinsclient.Jenkins, k8sClient k8s.Client, jenkins *v1alpha2.Jenkins) Tools {
+	return &tools{
+		groovyClient: groovy.New(jenkinsClient, k8sClient, jenkins, ConfigurationType, v1alpha2.Customization{}),
+		jenkins:      jenkins,
+	}
+}
+
+// Ensure configures tool installations from spec.tools, the tools which are no longer declared aren't removed
+func (t *tools) Ensure() (requeue bool, err error) {
+	if t.jenkins.Spec.Tools == nil {
+		return false, nil
+	}
+
+	configurationAsCode, err := RenderConfigurationAsCode(*t.jenkins.Spec.Tools)
+	if err != nil {
+		return true, err
+	}
+	return t.groovyClient.EnsureGenerated(toolsSource, toolsName, casc.UpdateGroovyScript(configurationAsCode))
+}
+
+// Plan returns the tool installations Configuration as Code file if it would be applied by Ensure
+func (t *tools) Plan() ([]v1alpha2.PlannedConfigurationChange, error) {
+	if t.jenkins.Spec.Tools == nil {
+		return nil, nil
+	}
+
+	configurationAsCode, err := RenderConfigurationAsCode(*t.jenkins.Spec.Tools)
+	if err != nil {
+		return nil, err
+	}
+	return t.groovyClient.PlanGenerated(toolsSource, toolsName, casc.UpdateGroovyScript(configurationAsCode))
+}
+
+// RenderConfigurationAsCode returns Configuration as Code YAML with tool installations,
+// only the tool types which have any installation declared are configured
+func RenderConfigurationAsCode(tools v1alpha2.Tools) (string, error) {
+	toolConfiguration := map[string]interface{}{}
+
+	if len(tools.JDK) > 0 {
+		var installations []interface{}
+		for _, jdk := range tools.JDK {
+			installations = append(installations, renderInstallation(jdk.ToolInstallation, "adoptOpenJdkInstaller", map[string]interface{}{
+				"id": jdk.Version,
+			}))
+		}
+		toolConfiguration["jdk"] = map[string]interface{}{"installations": installations}
+	}
+
+	if len(tools.Maven) > 0 {
+		var installations []interface{}
+		for _, maven := range tools.Maven {
+			installations = append(installations, renderInstallation(maven.ToolInstallation, "maven", map[string]interface{}{
+				"id": maven.Version,
+			}))
+		}
+		toolConfiguration["maven"] = map[string]interface{}{"installations": installations}
+	}
+
+	if len(tools.NodeJS) > 0 {
+		var installations []interface{}
+		for _, nodeJS := range tools.NodeJS {
+			installer := map[string]interface{}{"id": nodeJS.Version}
+			if len(nodeJS.GlobalPackages) > 0 {
+				installer["npmPackages"] = strings.Join(nodeJS.GlobalPackages, " ")
+				installer["npmPackagesRefreshHours"] = npmPackagesRefreshHours
+			}
+			installations = append(installations, renderInstallation(nodeJS.ToolInstallation, "nodeJSInstaller", installer))
+		}
+		toolConfiguration["nodejs"] = map[string]interface{}{"installations": installations}
+	}
+
+	configurationAsCode, err := yaml.Marshal(map[string]interface{}{"tool": toolConfiguration})
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	return string(configurationAsCode), nil
+}
+
+func renderInstallation(tool v1alpha2.ToolInstallation, installerType string, installer map[string]interface{}) map[string]interface{} {
+	if len(tool.Home) > 0 {
+		return map[string]interface{}{
+			"name": tool.Name,
+			"home": tool.Home,
+		}
+	}
+
+	return map[string]interface{}{
+		"name": tool.Name,
+		"properties": []interface{}{
+			map[string]interface{}{
+				"installSource": map[string]interface{}{
+					"installers": []interface{}{
+						map[string]interface{}{installerType: installer},
+					},
+				},
+			},
+		},
+	}
+}
+
+// RequiredPlugins returns plugins required by the tool installations
+func RequiredPlugins(tools *v1alpha2.Tools) []plugins.Plugin {
+	if tools == nil {
+		return nil
+	}
+
+	var requiredPlugins []plugins.Plugin
+	for _, jdk := range tools.JDK {
+		if len(jdk.Version) > 0 {
+			requiredPlugins = append(requiredPlugins, plugins.AdoptOpenJDKPlugin)
+			break
+		}
+	}
+	if len(tools.NodeJS) > 0 {
+		requiredPlugins = append(requiredPlugins, plugins.NodeJSPlugin)
+	}
+	return requiredPlugins
+}
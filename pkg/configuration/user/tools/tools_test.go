@@ -0,0 +1,104 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/plugins"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderConfigurationAsCode(t *testing.T) {
+	t.Run("all tool types", func(t *testing.T) {
+		tools := v1alpha2.Tools{
+			JDK: []v1alpha2.JDKTool{
+				{ToolInstallation: v1alpha2.ToolInstallation{Name: "jdk11", Version: "jdk-11.0.13+8"}},
+				{ToolInstallation: v1alpha2.ToolInstallation{Name: "system", Home: "/opt/java/openjdk"}},
+			},
+			Maven: []v1alpha2.MavenTool{
+				{ToolInstallation: v1alpha2.ToolInstallation{Name: "maven3", Version: "3.8.4"}},
+			},
+			NodeJS: []v1alpha2.NodeJSTool{
+				{ToolInstallation: v1alpha2.ToolInstallation{Name: "node16", Version: "16.13.1"}, GlobalPackages: []string{"yarn@1.22.17", "npm@8"}},
+			},
+		}
+
+		got, err := RenderConfigurationAsCode(tools)
+
+		require.NoError(t, err)
+		assert.Equal(t, `tool:
+  jdk:
+    installations:
+    - name: jdk11
+      properties:
+      - installSource:
+          installers:
+          - adoptOpenJdkInstaller:
+              id: jdk-11.0.13+8
+    - home: /opt/java/openjdk
+      name: system
+  maven:
+    installations:
+    - name: maven3
+      properties:
+      - installSource:
+          installers:
+          - maven:
+              id: 3.8.4
+  nodejs:
+    installations:
+    - name: node16
+      properties:
+      - installSource:
+          installers:
+          - nodeJSInstaller:
+              id: 16.13.1
+              npmPackages: yarn@1.22.17 npm@8
+              npmPackagesRefreshHours: 72
+`, got)
+	})
+	t.Run("only declared tool types", func(t *testing.T) {
+		tools := v1alpha2.Tools{
+			Maven: []v1alpha2.MavenTool{
+				{ToolInstallation: v1alpha2.ToolInstallation{Name: "maven3", Home: "/usr/share/maven"}},
+			},
+		}
+
+		got, err := RenderConfigurationAsCode(tools)
+
+		require.NoError(t, err)
+		assert.Equal(t, `tool:
+  maven:
+    installations:
+    - home: /usr/share/maven
+      name: maven3
+`, got)
+	})
+}
+
+func TestRequiredPlugins(t *testing.T) {
+	t.Run("no tools", func(t *testing.T) {
+		assert.Empty(t, RequiredPlugins(nil))
+	})
+	t.Run("JDK from home and Maven", func(t *testing.T) {
+		tools := &v1alpha2.Tools{
+			JDK:   []v1alpha2.JDKTool{{ToolInstallation: v1alpha2.ToolInstallation{Name: "system", Home: "/opt/java/openjdk"}}},
+			Maven: []v1alpha2.MavenTool{{ToolInstallation: v1alpha2.ToolInstallation{Name: "maven3", Version: "3.8.4"}}},
+		}
+
+		assert.Empty(t, RequiredPlugins(tools))
+	})
+	t.Run("JDK installer and NodeJS", func(t *testing.T) {
+		tools := &v1alpha2.Tools{
+			JDK: []v1alpha2.JDKTool{
+				{ToolInstallation: v1alpha2.ToolInstallation{Name: "jdk11", Version: "jdk-11.0.13+8"}},
+				{ToolInstallation: v1alpha2.ToolInstallation{Name: "jdk8", Version: "jdk8u312-b07"}},
+			},
+			NodeJS: []v1alpha2.NodeJSTool{{ToolInstallation: v1alpha2.ToolInstallation{Name: "node", Home: "/usr/local"}}},
+		}
+
+		assert.Equal(t, []plugins.Plugin{plugins.AdoptOpenJDKPlugin, plugins.NodeJSPlugin}, RequiredPlugins(tools))
+	})
+}
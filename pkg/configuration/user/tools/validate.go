@@ -0,0 +1,71 @@
+package tools
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+)
+
+var (
+	// jdkVersionPattern matches AdoptOpenJDK release names, e.g. jdk-11.0.13+8 or jdk8u312-b07
+	jdkVersionPattern = regexp.MustCompile(`^jdk(-\d+(\.\d+)*\+\d+|8u\d+-b\d+)$`)
+	// releaseVersionPattern matches Maven and NodeJS release versions, e.g. 3.8.4
+	releaseVersionPattern = regexp.MustCompile(`^\d+\.\d+\.\d+$`)
+)
+
+// Validate validates tool installations from spec.tools
+func Validate(tools *v1alpha2.Tools) []string {
+	if tools == nil {
+		return nil
+	}
+
+	var messages []string
+	names := map[string]bool{}
+	for i, jdk := range tools.JDK {
+		messages = append(messages, validateInstallation(fmt.Sprintf("spec.tools.jdk[%d]", i), jdk.ToolInstallation, jdkVersionPattern, names)...)
+	}
+
+	names = map[string]bool{}
+	for i, maven := range tools.Maven {
+		messages = append(messages, validateInstallation(fmt.Sprintf("spec.tools.maven[%d]", i), maven.ToolInstallation, releaseVersionPattern, names)...)
+	}
+
+	names = map[string]bool{}
+	for i, nodeJS := range tools.NodeJS {
+		field := fmt.Sprintf("spec.tools.nodejs[%d]", i)
+		messages = append(messages, validateInstallation(field, nodeJS.ToolInstallation, releaseVersionPattern, names)...)
+		if len(nodeJS.GlobalPackages) > 0 && len(nodeJS.Home) > 0 {
+			messages = append(messages, fmt.Sprintf("%s.globalPackages can't be used with %s.home", field, field))
+		}
+		for _, npmPackage := range nodeJS.GlobalPackages {
+			if len(npmPackage) == 0 || strings.ContainsAny(npmPackage, " \t'") {
+				messages = append(messages, fmt.Sprintf("%s.globalPackages package '%s' is invalid", field, npmPackage))
+			}
+		}
+	}
+
+	return messages
+}
+
+func validateInstallation(field string, tool v1alpha2.ToolInstallation, versionPattern *regexp.Regexp, names map[string]bool) []string {
+	var messages []string
+
+	if len(tool.Name) == 0 {
+		messages = append(messages, fmt.Sprintf("%s.name is not set", field))
+	} else if names[tool.Name] {
+		messages = append(messages, fmt.Sprintf("%s.name '%s' is duplicated", field, tool.Name))
+	}
+	names[tool.Name] = true
+
+	if len(tool.Version) > 0 && len(tool.Home) > 0 {
+		messages = append(messages, fmt.Sprintf("%s.version and %s.home are mutually exclusive", field, field))
+	} else if len(tool.Version) == 0 && len(tool.Home) == 0 {
+		messages = append(messages, fmt.Sprintf("%s.version or %s.home must be set", field, field))
+	} else if len(tool.Version) > 0 && !versionPattern.MatchString(tool.Version) {
+		messages = append(messages, fmt.Sprintf("%s.version '%s' is invalid, it must match '%s'", field, tool.Version, versionPattern))
+	}
+
+	return messages
+}
@@ -0,0 +1,57 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	t.Run("no tools", func(t *testing.T) {
+		assert.Empty(t, Validate(nil))
+	})
+	t.Run("valid", func(t *testing.T) {
+		tools := &v1alpha2.Tools{
+			JDK: []v1alpha2.JDKTool{
+				{ToolInstallation: v1alpha2.ToolInstallation{Name: "jdk11", Version: "jdk-11.0.13+8"}},
+				{ToolInstallation: v1alpha2.ToolInstallation{Name: "jdk8", Version: "jdk8u312-b07"}},
+				{ToolInstallation: v1alpha2.ToolInstallation{Name: "system", Home: "/opt/java/openjdk"}},
+			},
+			Maven: []v1alpha2.MavenTool{
+				{ToolInstallation: v1alpha2.ToolInstallation{Name: "jdk11", Version: "3.8.4"}},
+			},
+			NodeJS: []v1alpha2.NodeJSTool{
+				{ToolInstallation: v1alpha2.ToolInstallation{Name: "node16", Version: "16.13.1"}, GlobalPackages: []string{"yarn@1.22.17"}},
+			},
+		}
+
+		assert.Empty(t, Validate(tools))
+	})
+	t.Run("invalid installations", func(t *testing.T) {
+		tools := &v1alpha2.Tools{
+			JDK: []v1alpha2.JDKTool{
+				{ToolInstallation: v1alpha2.ToolInstallation{Version: "jdk-11.0.13+8"}},
+				{ToolInstallation: v1alpha2.ToolInstallation{Name: "jdk11", Version: "11"}},
+				{ToolInstallation: v1alpha2.ToolInstallation{Name: "jdk11", Version: "jdk-11.0.13+8", Home: "/opt/java"}},
+			},
+			Maven: []v1alpha2.MavenTool{
+				{ToolInstallation: v1alpha2.ToolInstallation{Name: "maven3"}},
+			},
+			NodeJS: []v1alpha2.NodeJSTool{
+				{ToolInstallation: v1alpha2.ToolInstallation{Name: "node", Home: "/usr/local"}, GlobalPackages: []string{"yarn gulp"}},
+			},
+		}
+
+		assert.Equal(t, []string{
+			"spec.tools.jdk[0].name is not set",
+			"spec.tools.jdk[1].version '11' is invalid, it must match '^jdk(-\\d+(\\.\\d+)*\\+\\d+|8u\\d+-b\\d+)$'",
+			"spec.tools.jdk[2].name 'jdk11' is duplicated",
+			"spec.tools.jdk[2].version and spec.tools.jdk[2].home are mutually exclusive",
+			"spec.tools.maven[0].version or spec.tools.maven[0].home must be set",
+			"spec.tools.nodejs[0].globalPackages can't be used with spec.tools.nodejs[0].home",
+			"spec.tools.nodejs[0].globalPackages package 'yarn gulp' is invalid",
+		}, Validate(tools))
+	})
+}
@@ -3,7 +3,12 @@ package user
 import (
 	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
 	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/backuprestore"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/user/agents"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/user/folders"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/user/scriptapproval"
 	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/user/seedjobs"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/user/sharedlibraries"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/user/tools"
 )
 
 // Validate validates Jenkins CR Spec section
@@ -13,6 +18,26 @@ func (r *reconcileUserConfiguration) Validate(jenkins *v1alpha2.Jenkins) ([]stri
 		return msg, nil
 	}
 
+	if msg := tools.Validate(jenkins.Spec.Tools); msg != nil {
+		return msg, nil
+	}
+
+	if msg := sharedlibraries.Validate(jenkins.Spec.SharedLibraries); msg != nil {
+		return msg, nil
+	}
+
+	if msg := folders.Validate(jenkins); msg != nil {
+		return msg, nil
+	}
+
+	if msg := agents.Validate(jenkins.Spec.Agents); msg != nil {
+		return msg, nil
+	}
+
+	if msg := scriptapproval.Validate(jenkins.Spec.ScriptApproval); msg != nil {
+		return msg, nil
+	}
+
 	seedJobs := seedjobs.New(r.jenkinsClient, r.Configuration)
 	return seedJobs.ValidateSeedJobs(*jenkins)
 }
@@ -15,4 +15,18 @@ const (
 	DefaultSlavePortInt32 = int32(50000)
 	// JavaOpsVariableName is the name of environment variable which consists Jenkins Java options
 	JavaOpsVariableName = "JAVA_OPTS"
+	// DefaultTrustedCABundleKey is the default ConfigMap key with trusted CA certificates
+	DefaultTrustedCABundleKey = "ca-bundle.crt"
+	// DefaultBuildLogArchivalS3Image is the default image of the sidecar uploading build logs to S3
+	DefaultBuildLogArchivalS3Image = "amazon/aws-cli:2.4.6"
+	// DefaultBuildLogArchivalGCSImage is the default image of the sidecar uploading build logs to GCS
+	DefaultBuildLogArchivalGCSImage = "google/cloud-sdk:367.0.0-alpine"
+	// DefaultUpdateCenterURL is the default URL of the update center JSON used by plugin update checks
+	DefaultUpdateCenterURL = "https://updates.jenkins.io/update-center.actual.json"
+	// DefaultJenkinsUpdateCenterURL is the URL of the update site configured in Jenkins by default
+	DefaultJenkinsUpdateCenterURL = "https://updates.jenkins.io/update-center.json"
+	// DefaultLinuxAgentImage is the default image of jnlp container of Linux agent pod templates
+	DefaultLinuxAgentImage = "jenkins/inbound-agent:4.9-1"
+	// DefaultWindowsAgentImage is the default image of jnlp container of Windows agent pod templates
+	DefaultWindowsAgentImage = "jenkins/inbound-agent:4.9-1-jdk11-windowsservercore-ltsc2019"
 )
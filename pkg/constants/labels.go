@@ -13,4 +13,16 @@ const (
 
 	// LabelJenkinsCRKey Kubernetes label name which contains Jenkins CR name
 	LabelJenkinsCRKey = "jenkins-cr"
+
+	// LabelAgentOfKey Kubernetes label name which contains Jenkins CR name of agent pods created from spec.agents.podTemplates
+	LabelAgentOfKey = "jenkins.io/agent-of"
+
+	// LabelJenkinsHomeActiveKey Kubernetes label of Jenkins home PersistentVolumeClaim mounted by Jenkins master pod,
+	// it survives removal of spec.persistence and recreation of Jenkins CR
+	LabelJenkinsHomeActiveKey = "jenkins.io/jenkins-home-active"
+	// LabelJenkinsHomeActiveValue Kubernetes label value of the active Jenkins home PersistentVolumeClaim
+	LabelJenkinsHomeActiveValue = "true"
+
+	// AnnotationJenkinsGenerationKey Kubernetes annotation which contains the generation of Jenkins CR the object has been created for
+	AnnotationJenkinsGenerationKey = "jenkins.io/jenkins-generation"
 )
@@ -7,7 +7,6 @@ import (
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes"
-	"k8s.io/client-go/kubernetes/scheme"
 	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/record"
@@ -36,9 +35,9 @@ type recorder struct {
 	recorder record.EventRecorder
 }
 
-// New returns recorder used to emit events
-func New(config *rest.Config, component string) (Recorder, error) {
-	eventRecorder, err := initializeEventRecorder(config, component)
+// New returns recorder used to emit events, the scheme must contain types of objects which events are attached to
+func New(config *rest.Config, scheme *runtime.Scheme, component string) (Recorder, error) {
+	eventRecorder, err := initializeEventRecorder(config, scheme, component)
 	if err != nil {
 		return nil, err
 	}
@@ -48,7 +47,7 @@ func New(config *rest.Config, component string) (Recorder, error) {
 	}, nil
 }
 
-func initializeEventRecorder(config *rest.Config, component string) (record.EventRecorder, error) {
+func initializeEventRecorder(config *rest.Config, scheme *runtime.Scheme, component string) (record.EventRecorder, error) {
 	client, err := kubernetes.NewForConfig(config)
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -58,7 +57,7 @@ func initializeEventRecorder(config *rest.Config, component string) (record.Even
 		&typedcorev1.EventSinkImpl{
 			Interface: client.CoreV1().Events("")})
 	eventRecorder := eventBroadcaster.NewRecorder(
-		scheme.Scheme,
+		scheme,
 		v1.EventSource{Component: component},
 	)
 	return eventRecorder, nil
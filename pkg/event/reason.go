@@ -0,0 +1,93 @@
+package event
+
+const (
+	// ReasonBaseConfigurationStarted is emitted when the operator starts provisioning of Jenkins master
+	ReasonBaseConfigurationStarted = Reason("BaseConfigurationStarted")
+	// ReasonUserConfigurationStarted is emitted when the operator starts applying user configuration
+	ReasonUserConfigurationStarted = Reason("UserConfigurationStarted")
+	// ReasonPluginVerificationFailed is emitted when plugins installed in Jenkins don't match the required plugins
+	ReasonPluginVerificationFailed = Reason("PluginVerificationFailed")
+	// ReasonPluginBatchStarted is emitted when the plugin changes are downloaded to Jenkins and the safe restart is scheduled
+	ReasonPluginBatchStarted = Reason("PluginBatchStarted")
+	// ReasonPluginBatchCompleted is emitted when Jenkins has been restarted with all plugin changes of the batch
+	ReasonPluginBatchCompleted = Reason("PluginBatchCompleted")
+	// ReasonPluginBatchFailed is emitted when the plugin batch can't be applied and Jenkins master pod is restarted
+	ReasonPluginBatchFailed = Reason("PluginBatchFailed")
+	// ReasonPodRestart is emitted when the operator deletes Jenkins master pod to recreate it
+	ReasonPodRestart = Reason("PodRestart")
+	// ReasonApplyConflict is emitted when server-side apply of the object managed by the operator conflicts with another field manager
+	ReasonApplyConflict = Reason("ApplyConflict")
+	// ReasonBackupTriggered is emitted when the operator starts the backup
+	ReasonBackupTriggered = Reason("BackupTriggered")
+	// ReasonBackupFailed is emitted when the backup action fails
+	ReasonBackupFailed = Reason("BackupFailed")
+	// ReasonRestoreStarted is emitted when the operator starts restoring the backup
+	ReasonRestoreStarted = Reason("RestoreStarted")
+	// ReasonRestoreCompleted is emitted when the backup has been restored
+	ReasonRestoreCompleted = Reason("RestoreCompleted")
+	// ReasonRestoreFailed is emitted when the restore action fails
+	ReasonRestoreFailed = Reason("RestoreFailed")
+	// ReasonPlanGenerated is emitted when the plan of actions has been published in the Jenkins CR status
+	ReasonPlanGenerated = Reason("PlanGenerated")
+	// ReasonPluginUpgradeStarted is emitted when the operator upgrades plugins in the Jenkins CR
+	ReasonPluginUpgradeStarted = Reason("PluginUpgradeStarted")
+	// ReasonPluginUpgradeCompleted is emitted when Jenkins becomes ready with the upgraded plugins
+	ReasonPluginUpgradeCompleted = Reason("PluginUpgradeCompleted")
+	// ReasonPluginUpgradeRolledBack is emitted when the upgraded plugins are rolled back because Jenkins didn't become ready
+	ReasonPluginUpgradeRolledBack = Reason("PluginUpgradeRolledBack")
+	// ReasonSharedLibraryCacheCleared is emitted when the shared library cache has been cleared by the Git push webhook
+	ReasonSharedLibraryCacheCleared = Reason("SharedLibraryCacheCleared")
+	// ReasonPersistentVolumeClaimExpanded is emitted when the operator requests the larger Jenkins home claim
+	ReasonPersistentVolumeClaimExpanded = Reason("PersistentVolumeClaimExpanded")
+	// ReasonPersistentVolumeClaimExpansionFailed is emitted when the expansion of Jenkins home claim has been rejected
+	ReasonPersistentVolumeClaimExpansionFailed = Reason("PersistentVolumeClaimExpansionFailed")
+	// ReasonPersistentVolumeClaimRetained is emitted when Jenkins home claim is kept after Jenkins CR deletion
+	ReasonPersistentVolumeClaimRetained = Reason("PersistentVolumeClaimRetained")
+	// ReasonPersistentVolumeClaimDeleted is emitted when Jenkins home claim is deleted together with Jenkins CR
+	ReasonPersistentVolumeClaimDeleted = Reason("PersistentVolumeClaimDeleted")
+	// ReasonPersistenceMigrationStarted is emitted when the operator starts copying Jenkins home to the claim with the new storage class
+	ReasonPersistenceMigrationStarted = Reason("PersistenceMigrationStarted")
+	// ReasonPersistenceMigrationCompleted is emitted when Jenkins home has been copied to the claim with the new storage class
+	ReasonPersistenceMigrationCompleted = Reason("PersistenceMigrationCompleted")
+	// ReasonPersistenceMigrationFailed is emitted when Jenkins home couldn't be copied to the claim with the new storage class
+	ReasonPersistenceMigrationFailed = Reason("PersistenceMigrationFailed")
+	// ReasonDiskUsageHigh is emitted when the used disk space of Jenkins home reaches spec.diskUsage.warningThreshold
+	ReasonDiskUsageHigh = Reason("DiskUsageHigh")
+	// ReasonDiskCleanupCompleted is emitted when the cleanup policies have freed Jenkins home disk space
+	ReasonDiskCleanupCompleted = Reason("DiskCleanupCompleted")
+	// ReasonDiskCleanupFailed is emitted when the cleanup policy fails
+	ReasonDiskCleanupFailed = Reason("DiskCleanupFailed")
+	// ReasonUpdateCenterMirrorGenerated is emitted when the update center mirror has been generated
+	ReasonUpdateCenterMirrorGenerated = Reason("UpdateCenterMirrorGenerated")
+	// ReasonUpdateCenterMirrorFailed is emitted when the update center mirror couldn't be generated
+	ReasonUpdateCenterMirrorFailed = Reason("UpdateCenterMirrorFailed")
+	// ReasonOperatorCredentialsRotated is emitted when the operator user password and API token have been rotated
+	ReasonOperatorCredentialsRotated = Reason("OperatorCredentialsRotated")
+	// ReasonContainerFailed is emitted when the init container or the sidecar of Jenkins master pod from spec.master fails
+	ReasonContainerFailed = Reason("ContainerFailed")
+	// ReasonCanaryStarted is emitted when the canary Jenkins pod is created to verify configuration changes
+	ReasonCanaryStarted = Reason("CanaryStarted")
+	// ReasonCanaryPassed is emitted when configuration changes have been applied to the canary Jenkins pod without errors
+	ReasonCanaryPassed = Reason("CanaryPassed")
+	// ReasonCanaryFailed is emitted when the canary Jenkins pod hasn't started or configuration changes have failed in it
+	ReasonCanaryFailed = Reason("CanaryFailed")
+	// ReasonSeedJobsRerun is emitted when builds of seed jobs have been queued on request of jenkins.io/rerun-seed-jobs annotation
+	// or after script approval
+	ReasonSeedJobsRerun = Reason("SeedJobsRerun")
+	// ReasonOperationStarted is emitted when the action of JenkinsOperation CR has been started in Jenkins
+	ReasonOperationStarted = Reason("OperationStarted")
+	// ReasonOperationSucceeded is emitted when the action of JenkinsOperation CR has been completed
+	ReasonOperationSucceeded = Reason("OperationSucceeded")
+	// ReasonOperationFailed is emitted when the action of JenkinsOperation CR has failed or hasn't been completed in time
+	ReasonOperationFailed = Reason("OperationFailed")
+	// ReasonScriptApproved is emitted when the pending script approval listed in spec.scriptApproval has been approved
+	ReasonScriptApproved = Reason("ScriptApproved")
+	// ReasonScriptApprovalPending is emitted when the script approval pending in Jenkins isn't listed in spec.scriptApproval
+	ReasonScriptApprovalPending = Reason("ScriptApprovalPending")
+	// ReasonDisruptiveActionDeferred is emitted when the disruptive action has been deferred until the next window of spec.maintenanceWindows
+	ReasonDisruptiveActionDeferred = Reason("DisruptiveActionDeferred")
+	// ReasonSpecChanged is emitted when the new generation of Jenkins CR spec has been recorded in the audit trail of spec.audit
+	ReasonSpecChanged = Reason("SpecChanged")
+	// ReasonGroovyScriptApplied is emitted when the groovy script or Configuration as Code has been applied in Jenkins with spec.audit
+	ReasonGroovyScriptApplied = Reason("GroovyScriptApplied")
+)
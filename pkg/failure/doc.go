@@ -0,0 +1,3 @@
+// Package failure categorizes errors of the Jenkins client and reconcile loops, so retryable failures are told apart
+// from the ones which require a change of Jenkins CR or the environment
+package failure
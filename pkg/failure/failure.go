@@ -0,0 +1,158 @@
+package failure
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// Category is the kind of failure which decides how the reconcile loop reacts to the error.
+type Category string
+
+const (
+	// CategoryUnknown is the category of errors which haven't been categorized
+	CategoryUnknown Category = "Unknown"
+	// CategoryTransient means the failure is temporary, e.g. Jenkins or the API server aren't reachable, and is retried
+	CategoryTransient Category = "Transient"
+	// CategoryConfigurationInvalid means Jenkins CR or resources referenced by it are invalid, retrying doesn't help
+	// until they are changed
+	CategoryConfigurationInvalid Category = "ConfigurationInvalid"
+	// CategoryAuthFailure means the operator isn't authenticated or authorized by Jenkins or the API server
+	CategoryAuthFailure Category = "AuthFailure"
+	// CategoryPluginFailure means Jenkins plugins couldn't be resolved, installed or loaded
+	CategoryPluginFailure Category = "PluginFailure"
+)
+
+// Categorized is implemented by errors which know their category.
+type Categorized interface {
+	error
+	Category() Category
+}
+
+// Error is the error with its category.
+type Error struct {
+	category Category
+	err      error
+}
+
+// Category returns the category of the error
+func (e *Error) Category() Category {
+	return e.category
+}
+
+// Error returns the message of the wrapped error
+func (e *Error) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the wrapped error
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+// Cause returns the wrapped error, it's used by errors.Cause of github.com/pkg/errors
+func (e *Error) Cause() error {
+	return e.err
+}
+
+// Format prints the wrapped error, so %+v keeps the stack trace
+func (e *Error) Format(s fmt.State, verb rune) {
+	if formatter, ok := e.err.(fmt.Formatter); ok {
+		formatter.Format(s, verb)
+		return
+	}
+	_, _ = fmt.Fprint(s, e.err.Error())
+}
+
+// New categorizes the error, nil error is kept nil
+func New(category Category, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{category: category, err: err}
+}
+
+// Transient marks the error as temporary
+func Transient(err error) error {
+	return New(CategoryTransient, err)
+}
+
+// ConfigurationInvalid marks the error as caused by invalid configuration
+func ConfigurationInvalid(err error) error {
+	return New(CategoryConfigurationInvalid, err)
+}
+
+// AuthFailure marks the error as authentication or authorization failure
+func AuthFailure(err error) error {
+	return New(CategoryAuthFailure, err)
+}
+
+// PluginFailure marks the error as Jenkins plugins failure
+func PluginFailure(err error) error {
+	return New(CategoryPluginFailure, err)
+}
+
+// ForHTTPStatus returns the category of failed HTTP request by its status code
+func ForHTTPStatus(statusCode int) Category {
+	switch {
+	case statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden:
+		return CategoryAuthFailure
+	case statusCode == http.StatusTooManyRequests || statusCode == http.StatusRequestTimeout || statusCode >= http.StatusInternalServerError:
+		return CategoryTransient
+	default:
+		return CategoryUnknown
+	}
+}
+
+// CategoryOf returns the category of the error, errors of Kubernetes API and network errors are categorized
+// even if they haven't been marked
+func CategoryOf(err error) Category {
+	if err == nil {
+		return CategoryUnknown
+	}
+	var categorized Categorized
+	if errors.As(err, &categorized) {
+		return categorized.Category()
+	}
+
+	switch {
+	case apierrors.IsUnauthorized(err) || apierrors.IsForbidden(err):
+		return CategoryAuthFailure
+	case apierrors.IsInvalid(err) || apierrors.IsBadRequest(err):
+		return CategoryConfigurationInvalid
+	case apierrors.IsServerTimeout(err) || apierrors.IsTimeout(err) || apierrors.IsTooManyRequests(err) ||
+		apierrors.IsServiceUnavailable(err) || apierrors.IsInternalError(err) || apierrors.IsConflict(err):
+		return CategoryTransient
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) || errors.Is(err, context.DeadlineExceeded) {
+		return CategoryTransient
+	}
+	return CategoryUnknown
+}
+
+// MaxMessageLength is the limit of the message of the condition
+const MaxMessageLength = 32768
+
+const truncatedSuffix = "... (truncated)"
+
+// Message returns the error message which fits into the message of the condition, e.g. Jenkins script logs
+// included in the error are truncated
+func Message(err error) string {
+	message := err.Error()
+	if len(message) <= MaxMessageLength {
+		return message
+	}
+	message = message[:MaxMessageLength-len(truncatedSuffix)]
+	// don't split the multi-byte character
+	for !utf8.ValidString(message) {
+		message = message[:len(message)-1]
+	}
+	return message + truncatedSuffix
+}
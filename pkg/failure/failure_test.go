@@ -0,0 +1,86 @@
+package failure
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+type categorizedError struct{}
+
+func (categorizedError) Error() string {
+	return "categorized"
+}
+
+func (categorizedError) Category() Category {
+	return CategoryPluginFailure
+}
+
+func TestCategoryOf(t *testing.T) {
+	resource := schema.GroupResource{Resource: "pods"}
+	tests := []struct {
+		name string
+		err  error
+		want Category
+	}{
+		{name: "nil", err: nil, want: CategoryUnknown},
+		{name: "plain error", err: errors.New("boom"), want: CategoryUnknown},
+		{name: "marked", err: AuthFailure(errors.New("401")), want: CategoryAuthFailure},
+		{name: "marked and wrapped", err: errors.Wrap(ConfigurationInvalid(errors.New("invalid")), "reconcile"), want: CategoryConfigurationInvalid},
+		{name: "typed error", err: errors.WithStack(&categorizedError{}), want: CategoryPluginFailure},
+		{name: "marked overrides typed error", err: Transient(&categorizedError{}), want: CategoryTransient},
+		{name: "api forbidden", err: apierrors.NewForbidden(resource, "pod", errors.New("denied")), want: CategoryAuthFailure},
+		{name: "api invalid", err: apierrors.NewInvalid(schema.GroupKind{Kind: "Pod"}, "pod", nil), want: CategoryConfigurationInvalid},
+		{name: "api timeout", err: apierrors.NewServerTimeout(resource, "get", 1), want: CategoryTransient},
+		{name: "api not found", err: apierrors.NewNotFound(resource, "pod"), want: CategoryUnknown},
+		{name: "network error", err: errors.WithStack(&net.OpError{Op: "dial", Err: errors.New("connection refused")}), want: CategoryTransient},
+		{name: "deadline exceeded", err: errors.Wrap(context.DeadlineExceeded, "wait"), want: CategoryTransient},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, CategoryOf(test.err))
+		})
+	}
+}
+
+func TestForHTTPStatus(t *testing.T) {
+	assert.Equal(t, CategoryAuthFailure, ForHTTPStatus(http.StatusUnauthorized))
+	assert.Equal(t, CategoryAuthFailure, ForHTTPStatus(http.StatusForbidden))
+	assert.Equal(t, CategoryTransient, ForHTTPStatus(http.StatusServiceUnavailable))
+	assert.Equal(t, CategoryTransient, ForHTTPStatus(http.StatusTooManyRequests))
+	assert.Equal(t, CategoryUnknown, ForHTTPStatus(http.StatusNotFound))
+}
+
+func TestError(t *testing.T) {
+	err := errors.New("boom")
+
+	assert.Nil(t, Transient(nil))
+	assert.EqualError(t, Transient(err), "boom")
+	assert.Equal(t, err, errors.Cause(Transient(err)))
+	assert.Contains(t, fmt.Sprintf("%+v", Transient(err)), "TestError")
+}
+
+func TestMessage(t *testing.T) {
+	t.Run("short", func(t *testing.T) {
+		assert.Equal(t, "boom", Message(errors.New("boom")))
+	})
+	t.Run("long", func(t *testing.T) {
+		message := Message(errors.New(strings.Repeat("a", MaxMessageLength+100)))
+		assert.Len(t, message, MaxMessageLength)
+		assert.True(t, strings.HasSuffix(message, truncatedSuffix))
+	})
+	t.Run("multi-byte characters", func(t *testing.T) {
+		message := Message(errors.New(strings.Repeat("ż", MaxMessageLength)))
+		assert.True(t, utf8.ValidString(message))
+		assert.LessOrEqual(t, len(message), MaxMessageLength)
+	})
+}
@@ -0,0 +1,2 @@
+// Package gc finds objects created by the operator for Jenkins CRs which don't exist anymore
+package gc
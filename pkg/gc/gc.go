@@ -0,0 +1,143 @@
+package gc
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/user/seedjobs"
+	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// DefaultMinAge is the age of the object below which it's never collected, the Jenkins CR created at the same time
+// may not be listed yet
+const DefaultMinAge = 5 * time.Minute
+
+// ownerLabels are the labels of objects created by the operator with the name of Jenkins CR as value
+var ownerLabels = []string{
+	constants.LabelJenkinsCRKey,
+	constants.LabelAgentOfKey,
+	resources.LabelCanaryKey,
+	seedjobs.DedicatedAgentLabelKey,
+}
+
+// Orphan is the object created by the operator for Jenkins CR which doesn't exist anymore
+type Orphan struct {
+	Kind      string
+	Namespace string
+	Name      string
+	Jenkins   string
+	Reason    string
+}
+
+func (o Orphan) String() string {
+	return fmt.Sprintf("%s %s/%s: %s", o.Kind, o.Namespace, o.Name, o.Reason)
+}
+
+// Owner returns the name of Jenkins CR the object has been created for from its labels or its controller owner
+// reference, false is returned when the object doesn't belong to any Jenkins CR
+func Owner(object metav1.Object) (string, bool) {
+	for _, key := range ownerLabels {
+		if name, ok := object.GetLabels()[key]; ok && len(name) > 0 {
+			return name, true
+		}
+	}
+	if owner := jenkinsOwnerReference(object); owner != nil {
+		return owner.Name, true
+	}
+	return "", false
+}
+
+// Jenkins is the existing Jenkins CR the objects are checked against
+type Jenkins struct {
+	UID        types.UID
+	Generation int64
+}
+
+// FindOrphans returns the objects of the kind whose Jenkins CR doesn't exist, whose controller owner reference points
+// to the Jenkins CR with the same name deleted before, e.g. after the CRD has been recreated, or which have been created
+// for an older generation of Jenkins CR. jenkinses maps existing Jenkins CRs to their UIDs and generations. The objects
+// being deleted, younger than minAge or watched by the operator, e.g. user secrets with Jenkins credentials, aren't returned.
+func FindOrphans(kind string, objects []metav1.Object, jenkinses map[types.NamespacedName]Jenkins, minAge time.Duration, now time.Time) []Orphan {
+	var orphans []Orphan
+	for _, object := range objects {
+		if object.GetDeletionTimestamp() != nil || now.Sub(object.GetCreationTimestamp().Time) < minAge {
+			continue
+		}
+		if object.GetLabels()[constants.LabelWatchKey] == constants.LabelWatchValue {
+			continue
+		}
+		name, ok := Owner(object)
+		if !ok {
+			continue
+		}
+
+		orphan := Orphan{Kind: kind, Namespace: object.GetNamespace(), Name: object.GetName(), Jenkins: name}
+		jenkins, exists := jenkinses[types.NamespacedName{Namespace: object.GetNamespace(), Name: name}]
+		owner := jenkinsOwnerReference(object)
+		generation, hasGeneration := createdForGeneration(object)
+		switch {
+		case !exists:
+			orphan.Reason = fmt.Sprintf("Jenkins CR '%s' doesn't exist", name)
+		case owner != nil && owner.Name == name && owner.UID != jenkins.UID:
+			orphan.Reason = fmt.Sprintf("owned by Jenkins CR '%s' with UID '%s', the current one has UID '%s'", name, owner.UID, jenkins.UID)
+		case hasGeneration && generation < jenkins.Generation:
+			orphan.Reason = fmt.Sprintf("created for generation %d of Jenkins CR '%s', the current one is %d", generation, name, jenkins.Generation)
+		default:
+			continue
+		}
+		orphans = append(orphans, orphan)
+	}
+	return orphans
+}
+
+// createdForGeneration returns the generation of Jenkins CR from the annotation of the object, false is returned
+// when the object isn't bound to a generation
+func createdForGeneration(object metav1.Object) (int64, bool) {
+	value, ok := object.GetAnnotations()[constants.AnnotationJenkinsGenerationKey]
+	if !ok {
+		return 0, false
+	}
+	generation, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return generation, true
+}
+
+func jenkinsOwnerReference(object metav1.Object) *metav1.OwnerReference {
+	for _, owner := range object.GetOwnerReferences() {
+		if owner.Kind == v1alpha2.Kind && owner.Controller != nil && *owner.Controller {
+			owner := owner
+			return &owner
+		}
+	}
+	return nil
+}
+
+// Mode defines what the garbage collector does with orphaned objects
+type Mode string
+
+const (
+	// ModeDisabled disables the garbage collector
+	ModeDisabled Mode = "disabled"
+	// ModeDryRun reports orphaned objects without deleting them
+	ModeDryRun Mode = "dry-run"
+	// ModeDelete deletes orphaned objects
+	ModeDelete Mode = "delete"
+)
+
+// ParseMode returns the mode of the garbage collector, an error is returned for unknown modes
+func ParseMode(value string) (Mode, error) {
+	switch mode := Mode(value); mode {
+	case ModeDisabled, ModeDryRun, ModeDelete:
+		return mode, nil
+	}
+	return "", errors.Errorf("unknown orphan garbage collector mode '%s', must be one of disabled, dry-run or delete", value)
+}
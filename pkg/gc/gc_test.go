@@ -0,0 +1,136 @@
+package gc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var now = time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func object(name string, labels map[string]string, owners ...metav1.OwnerReference) *corev1.Secret {
+	return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+		Namespace:         "default",
+		Name:              name,
+		Labels:            labels,
+		OwnerReferences:   owners,
+		CreationTimestamp: metav1.NewTime(now.Add(-time.Hour)),
+	}}
+}
+
+func controllerOf(name string, uid types.UID) metav1.OwnerReference {
+	controller := true
+	return metav1.OwnerReference{APIVersion: v1alpha2.GroupVersion.String(), Kind: v1alpha2.Kind, Name: name, UID: uid, Controller: &controller}
+}
+
+func TestOwner(t *testing.T) {
+	t.Run("from label", func(t *testing.T) {
+		name, ok := Owner(object("secret", map[string]string{constants.LabelJenkinsCRKey: "example"}))
+
+		assert.True(t, ok)
+		assert.Equal(t, "example", name)
+	})
+	t.Run("from agent label", func(t *testing.T) {
+		name, ok := Owner(object("pod", map[string]string{constants.LabelAgentOfKey: "example"}))
+
+		assert.True(t, ok)
+		assert.Equal(t, "example", name)
+	})
+	t.Run("from controller owner reference", func(t *testing.T) {
+		name, ok := Owner(object("secret", nil, controllerOf("example", "uid")))
+
+		assert.True(t, ok)
+		assert.Equal(t, "example", name)
+	})
+	t.Run("not owned", func(t *testing.T) {
+		_, ok := Owner(object("secret", map[string]string{constants.LabelAppKey: constants.LabelAppValue}))
+
+		assert.False(t, ok)
+	})
+}
+
+func TestFindOrphans(t *testing.T) {
+	jenkinses := map[types.NamespacedName]Jenkins{{Namespace: "default", Name: "example"}: {UID: "current", Generation: 3}}
+
+	t.Run("Jenkins CR doesn't exist", func(t *testing.T) {
+		objects := []metav1.Object{
+			object("owned", map[string]string{constants.LabelJenkinsCRKey: "example"}),
+			object("orphan", map[string]string{constants.LabelJenkinsCRKey: "deleted"}),
+		}
+
+		orphans := FindOrphans("Secret", objects, jenkinses, DefaultMinAge, now)
+
+		require.Len(t, orphans, 1)
+		assert.Equal(t, Orphan{Kind: "Secret", Namespace: "default", Name: "orphan", Jenkins: "deleted",
+			Reason: "Jenkins CR 'deleted' doesn't exist"}, orphans[0])
+	})
+	t.Run("Jenkins CR in other namespace", func(t *testing.T) {
+		orphan := object("orphan", map[string]string{constants.LabelJenkinsCRKey: "example"})
+		orphan.Namespace = "other"
+
+		orphans := FindOrphans("Secret", []metav1.Object{orphan}, jenkinses, DefaultMinAge, now)
+
+		assert.Len(t, orphans, 1)
+	})
+	t.Run("owned by recreated Jenkins CR", func(t *testing.T) {
+		objects := []metav1.Object{
+			object("owned", nil, controllerOf("example", "current")),
+			object("orphan", nil, controllerOf("example", "previous")),
+		}
+
+		orphans := FindOrphans("Service", objects, jenkinses, DefaultMinAge, now)
+
+		require.Len(t, orphans, 1)
+		assert.Equal(t, "orphan", orphans[0].Name)
+		assert.Equal(t, "owned by Jenkins CR 'example' with UID 'previous', the current one has UID 'current'", orphans[0].Reason)
+	})
+	t.Run("created for older generation", func(t *testing.T) {
+		current := object("current", map[string]string{resources.LabelCanaryKey: "example"})
+		current.Annotations = map[string]string{constants.AnnotationJenkinsGenerationKey: "3"}
+		stale := object("stale", map[string]string{resources.LabelCanaryKey: "example"})
+		stale.Annotations = map[string]string{constants.AnnotationJenkinsGenerationKey: "2"}
+		invalid := object("invalid", map[string]string{resources.LabelCanaryKey: "example"})
+		invalid.Annotations = map[string]string{constants.AnnotationJenkinsGenerationKey: "abc"}
+
+		orphans := FindOrphans("Pod", []metav1.Object{current, stale, invalid}, jenkinses, DefaultMinAge, now)
+
+		require.Len(t, orphans, 1)
+		assert.Equal(t, "stale", orphans[0].Name)
+		assert.Equal(t, "created for generation 2 of Jenkins CR 'example', the current one is 3", orphans[0].Reason)
+	})
+	t.Run("skipped objects", func(t *testing.T) {
+		young := object("young", map[string]string{constants.LabelJenkinsCRKey: "deleted"})
+		young.CreationTimestamp = metav1.NewTime(now.Add(-time.Minute))
+		deleting := object("deleting", map[string]string{constants.LabelJenkinsCRKey: "deleted"})
+		deletionTimestamp := metav1.NewTime(now)
+		deleting.DeletionTimestamp = &deletionTimestamp
+		watched := object("watched", map[string]string{constants.LabelJenkinsCRKey: "deleted", constants.LabelWatchKey: constants.LabelWatchValue})
+		notOwned := object("not-owned", map[string]string{constants.LabelAppKey: constants.LabelAppValue})
+
+		orphans := FindOrphans("Secret", []metav1.Object{young, deleting, watched, notOwned}, jenkinses, DefaultMinAge, now)
+
+		assert.Empty(t, orphans)
+	})
+}
+
+func TestParseMode(t *testing.T) {
+	for _, value := range []string{"disabled", "dry-run", "delete"} {
+		mode, err := ParseMode(value)
+
+		assert.NoError(t, err)
+		assert.Equal(t, Mode(value), mode)
+	}
+
+	_, err := ParseMode("enabled")
+
+	assert.EqualError(t, err, "unknown orphan garbage collector mode 'enabled', must be one of disabled, dry-run or delete")
+}
@@ -37,7 +37,7 @@ func New(jenkinsClient jenkinsclient.Jenkins, k8sClient k8s.Client, jenkins *v1a
 		jenkins:           jenkins,
 		configurationType: configurationType,
 		customization:     customization,
-		logger:            log.Log.WithValues("cr", jenkins.Name),
+		logger:            log.ForJenkins(jenkins),
 	}
 }
 
@@ -112,19 +112,117 @@ func (g *Groovy) WaitForSecretSynchronization(secretsPath string) (requeue bool,
 
 // Ensure runs all groovy scripts configured in customization structure
 func (g *Groovy) Ensure(filter func(name string) bool, updateGroovyScript func(groovyScript string) string) (requeue bool, err error) {
+	scripts, err := g.getNotAppliedScripts(filter, updateGroovyScript)
+	if err != nil {
+		return true, err
+	}
+
+	for _, script := range scripts {
+		g.logger.Info(fmt.Sprintf("%s ConfigMap '%s' name '%s' running groovy script", g.configurationType, script.source, script.name))
+		requeue, err := g.EnsureSingle(script.source, script.name, script.hash, script.content)
+		if err != nil || requeue {
+			return requeue, err
+		}
+	}
+
+	return false, nil
+}
+
+// Run runs all groovy scripts configured in customization structure without recording them in status,
+// it's used to verify the scripts in Jenkins other than the one defined by Jenkins CR
+func (g *Groovy) Run(filter func(name string) bool, updateGroovyScript func(groovyScript string) string) error {
+	scripts, err := g.getScripts(filter, updateGroovyScript, false)
+	if err != nil {
+		return err
+	}
+
+	for _, script := range scripts {
+		g.logger.V(log.VDebug).Info(fmt.Sprintf("%s ConfigMap '%s' name '%s' running groovy script", g.configurationType, script.source, script.name))
+		logs, err := g.jenkinsClient.ExecuteScript(script.content)
+		if err != nil {
+			if groovyErr, ok := err.(*jenkinsclient.GroovyScriptExecutionFailed); ok {
+				groovyErr.ConfigurationType = g.configurationType
+				groovyErr.Name = script.name
+				groovyErr.Source = script.source
+				groovyErr.Logs = logs
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// Plan returns groovy scripts configured in customization structure which would be run by Ensure
+func (g *Groovy) Plan(filter func(name string) bool, updateGroovyScript func(groovyScript string) string) ([]v1alpha2.PlannedConfigurationChange, error) {
+	scripts, err := g.getNotAppliedScripts(filter, updateGroovyScript)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []v1alpha2.PlannedConfigurationChange
+	for _, script := range scripts {
+		changes = append(changes, v1alpha2.PlannedConfigurationChange{
+			ConfigurationType: g.configurationType,
+			Source:            script.source,
+			Name:              script.name,
+		})
+	}
+	return changes, nil
+}
+
+// EnsureGenerated runs groovy script generated by the operator, the script is run again only when its content changes
+func (g *Groovy) EnsureGenerated(source, name, groovyScript string) (requeue bool, err error) {
+	hash, err := g.calculateHash(map[string]string{name: groovyScript})
+	if err != nil {
+		return true, errors.WithStack(err)
+	}
+	if g.isGroovyScriptAlreadyApplied(source, name, hash) {
+		return false, nil
+	}
+
+	g.logger.Info(fmt.Sprintf("%s source '%s' name '%s' running groovy script", g.configurationType, source, name))
+	return g.EnsureSingle(source, name, hash, groovyScript)
+}
+
+// PlanGenerated returns groovy script generated by the operator if it would be run by EnsureGenerated
+func (g *Groovy) PlanGenerated(source, name, groovyScript string) ([]v1alpha2.PlannedConfigurationChange, error) {
+	hash, err := g.calculateHash(map[string]string{name: groovyScript})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if g.isGroovyScriptAlreadyApplied(source, name, hash) {
+		return nil, nil
+	}
+
+	return []v1alpha2.PlannedConfigurationChange{{ConfigurationType: g.configurationType, Source: source, Name: name}}, nil
+}
+
+type groovyScript struct {
+	source  string
+	name    string
+	hash    string
+	content string
+}
+
+func (g *Groovy) getNotAppliedScripts(filter func(name string) bool, updateGroovyScript func(groovyScript string) string) ([]groovyScript, error) {
+	return g.getScripts(filter, updateGroovyScript, true)
+}
+
+func (g *Groovy) getScripts(filter func(name string) bool, updateGroovyScript func(groovyScript string) string, skipApplied bool) ([]groovyScript, error) {
 	secret := &corev1.Secret{}
 	if len(g.customization.Secret.Name) > 0 {
 		err := g.k8sClient.Get(context.TODO(), types.NamespacedName{Name: g.customization.Secret.Name, Namespace: g.jenkins.ObjectMeta.Namespace}, secret)
 		if err != nil {
-			return true, err
+			return nil, err
 		}
 	}
 
+	var scripts []groovyScript
 	for _, configMapRef := range g.customization.Configurations {
 		configMap := &corev1.ConfigMap{}
 		err := g.k8sClient.Get(context.TODO(), types.NamespacedName{Name: configMapRef.Name, Namespace: g.jenkins.ObjectMeta.Namespace}, configMap)
 		if err != nil {
-			return true, errors.WithStack(err)
+			return nil, errors.WithStack(err)
 		}
 
 		var names []string
@@ -134,29 +232,25 @@ func (g *Groovy) Ensure(filter func(name string) bool, updateGroovyScript func(g
 		sort.Strings(names)
 
 		for _, name := range names {
-			groovyScript := updateGroovyScript(configMap.Data[name])
+			content := updateGroovyScript(configMap.Data[name])
 			if !filter(name) {
 				g.logger.V(log.VDebug).Info(fmt.Sprintf("Skipping %s ConfigMap '%s' name '%s'", g.configurationType, configMap.Name, name))
 				continue
 			}
 
-			hash, err := g.calculateCustomizationHash(*secret, name, groovyScript)
+			hash, err := g.calculateCustomizationHash(*secret, name, content)
 			if err != nil {
-				return true, errors.WithStack(err)
+				return nil, errors.WithStack(err)
 			}
-			if g.isGroovyScriptAlreadyApplied(configMap.Name, name, hash) {
+			if skipApplied && g.isGroovyScriptAlreadyApplied(configMap.Name, name, hash) {
 				continue
 			}
 
-			g.logger.Info(fmt.Sprintf("%s ConfigMap '%s' name '%s' running groovy script", g.configurationType, configMap.Name, name))
-			requeue, err := g.EnsureSingle(configMap.Name, name, hash, groovyScript)
-			if err != nil || requeue {
-				return requeue, err
-			}
+			scripts = append(scripts, groovyScript{source: configMap.Name, name: name, hash: hash, content: content})
 		}
 	}
 
-	return false, nil
+	return scripts, nil
 }
 
 func (g *Groovy) calculateCustomizationHash(secret corev1.Secret, key, groovyScript string) (string, error) {
@@ -611,6 +611,89 @@ func TestGroovy_Ensure(t *testing.T) {
 	})
 }
 
+func TestGroovy_Plan(t *testing.T) {
+	log.SetupLogger(true)
+	ctx := context.TODO()
+	namespace := "default"
+	configMapName := "config-map-name"
+	noUpdateGroovyScript := func(groovyScript string) string {
+		return groovyScript
+	}
+	onlyGroovyFilesFunc := func(name string) bool {
+		return strings.HasSuffix(name, ".groovy")
+	}
+
+	// given
+	customization := v1alpha2.Customization{
+		Configurations: []v1alpha2.ConfigMapRef{{Name: configMapName}},
+	}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      configMapName,
+			Namespace: namespace,
+		},
+		Data: map[string]string{
+			"applied.groovy": "applied",
+			"changed.groovy": "changed",
+			"to-omit":        "to-omit",
+		},
+	}
+	fakeClient := fake.NewClientBuilder().Build()
+	require.NoError(t, fakeClient.Create(ctx, configMap))
+
+	groovyClient := New(nil, fakeClient, &v1alpha2.Jenkins{ObjectMeta: metav1.ObjectMeta{Namespace: namespace}}, configurationType, customization)
+	appliedHash, err := groovyClient.calculateCustomizationHash(corev1.Secret{}, "applied.groovy", "applied")
+	require.NoError(t, err)
+	jenkins := &v1alpha2.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace},
+		Status: v1alpha2.JenkinsStatus{
+			AppliedGroovyScripts: []v1alpha2.AppliedGroovyScript{
+				{ConfigurationType: configurationType, Source: configMapName, Name: "applied.groovy", Hash: appliedHash},
+				{ConfigurationType: configurationType, Source: configMapName, Name: "changed.groovy", Hash: "old-hash"},
+			},
+		},
+	}
+	groovyClient = New(nil, fakeClient, jenkins, configurationType, customization)
+
+	// when
+	changes, err := groovyClient.Plan(onlyGroovyFilesFunc, noUpdateGroovyScript)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, []v1alpha2.PlannedConfigurationChange{
+		{ConfigurationType: configurationType, Source: configMapName, Name: "changed.groovy"},
+	}, changes)
+	assert.Len(t, jenkins.Status.AppliedGroovyScripts, 2)
+}
+
+func TestGroovy_PlanGenerated(t *testing.T) {
+	log.SetupLogger(true)
+	source := "spec.tools"
+	name := "tools.yaml"
+	jenkins := &v1alpha2.Jenkins{ObjectMeta: metav1.ObjectMeta{Namespace: "default"}}
+	groovyClient := New(nil, fake.NewClientBuilder().Build(), jenkins, configurationType, v1alpha2.Customization{})
+	hash, err := groovyClient.calculateHash(map[string]string{name: "applied"})
+	require.NoError(t, err)
+	jenkins.Status.AppliedGroovyScripts = []v1alpha2.AppliedGroovyScript{
+		{ConfigurationType: configurationType, Source: source, Name: name, Hash: hash},
+	}
+
+	t.Run("applied", func(t *testing.T) {
+		changes, err := groovyClient.PlanGenerated(source, name, "applied")
+
+		require.NoError(t, err)
+		assert.Empty(t, changes)
+	})
+	t.Run("changed", func(t *testing.T) {
+		changes, err := groovyClient.PlanGenerated(source, name, "changed")
+
+		require.NoError(t, err)
+		assert.Equal(t, []v1alpha2.PlannedConfigurationChange{
+			{ConfigurationType: configurationType, Source: source, Name: name},
+		}, changes)
+	})
+}
+
 func TestGroovy_isGroovyScriptAlreadyApplied(t *testing.T) {
 	log.SetupLogger(true)
 	emptyCustomization := v1alpha2.Customization{}
@@ -703,3 +786,52 @@ import com.foo.bar2`
 		assert.Equal(t, imports+"\n\n"+secretsLoader+"\n\n\n"+tail, got)
 	})
 }
+
+func TestGroovy_Run(t *testing.T) {
+	log.SetupLogger(true)
+	ctx := context.TODO()
+	require.NoError(t, v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme))
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "scripts", Namespace: "default"},
+		Data:       map[string]string{"test.groovy": "println 'test'", "skipped.txt": "skipped"},
+	}
+	customization := v1alpha2.Customization{Configurations: []v1alpha2.ConfigMapRef{{Name: configMap.Name}}}
+	filter := func(name string) bool { return strings.HasSuffix(name, ".groovy") }
+	updateGroovyScript := func(groovyScript string) string { return groovyScript }
+
+	t.Run("run applied script without changing status", func(t *testing.T) {
+		jenkins := &v1alpha2.Jenkins{
+			ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: "default"},
+			Status: v1alpha2.JenkinsStatus{
+				AppliedGroovyScripts: []v1alpha2.AppliedGroovyScript{{ConfigurationType: configurationType, Source: configMap.Name, Name: "test.groovy"}},
+			},
+		}
+		fakeClient := fake.NewClientBuilder().WithObjects(jenkins, configMap).Build()
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		jenkinsClient := jenkinsclient.NewMockJenkins(ctrl)
+		jenkinsClient.EXPECT().ExecuteScript("println 'test'").Return("test", nil)
+
+		err := New(jenkinsClient, fakeClient, jenkins, configurationType, customization).Run(filter, updateGroovyScript)
+
+		require.NoError(t, err)
+		actual := &v1alpha2.Jenkins{}
+		require.NoError(t, fakeClient.Get(ctx, types.NamespacedName{Name: jenkins.Name, Namespace: jenkins.Namespace}, actual))
+		assert.Equal(t, jenkins.Status.AppliedGroovyScripts, actual.Status.AppliedGroovyScripts)
+	})
+	t.Run("failed script", func(t *testing.T) {
+		jenkins := &v1alpha2.Jenkins{ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: "default"}}
+		fakeClient := fake.NewClientBuilder().WithObjects(jenkins, configMap).Build()
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		jenkinsClient := jenkinsclient.NewMockJenkins(ctrl)
+		jenkinsClient.EXPECT().ExecuteScript("println 'test'").Return("exception", &jenkinsclient.GroovyScriptExecutionFailed{})
+
+		err := New(jenkinsClient, fakeClient, jenkins, configurationType, customization).Run(filter, updateGroovyScript)
+
+		require.Error(t, err)
+		groovyErr, ok := err.(*jenkinsclient.GroovyScriptExecutionFailed)
+		require.True(t, ok)
+		assert.Equal(t, jenkinsclient.GroovyScriptExecutionFailed{ConfigurationType: configurationType, Source: configMap.Name, Name: "test.groovy", Logs: "exception"}, *groovyErr)
+	})
+}
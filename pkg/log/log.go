@@ -2,11 +2,15 @@ package log
 
 import (
 	"log"
+	"strings"
 
 	"github.com/go-logr/logr"
 	"github.com/go-logr/zapr"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	crzap "sigs.k8s.io/controller-runtime/pkg/log/zap"
 )
 
 // Log represents global logger.
@@ -22,16 +26,143 @@ const (
 	VDebug = 1
 )
 
-func zapLogger(debug bool) logr.Logger {
+const (
+	// NamespaceKey is the log key with namespace of the reconciled CR
+	NamespaceKey = "namespace"
+	// JenkinsKey is the log key with name of the reconciled Jenkins CR
+	JenkinsKey = "jenkins"
+	// PhaseKey is the log key with reconciliation phase: base or user
+	PhaseKey = "phase"
+
+	// PhaseBase is the base configuration phase
+	PhaseBase = "base"
+	// PhaseUser is the user configuration phase
+	PhaseUser = "user"
+
+	// LevelAnnotation is the CR annotation which overrides the log level for the single CR: debug, info or warn
+	LevelAnnotation = "jenkins.io/log-level"
+)
+
+var levels = map[string]int{
+	"debug":   VDebug,
+	"info":    0,
+	"warn":    VWarn,
+	"warning": VWarn,
+}
+
+// leveledLogger drops messages more verbose than the level, the level can be changed per logger
+type leveledLogger struct {
+	logger logr.Logger
+	level  int
+}
+
+// NewLeveled returns logger which drops messages more verbose than the level.
+// The underlying logger must accept all messages which may be enabled by LevelAnnotation.
+func NewLeveled(logger logr.Logger, level int) logr.Logger {
+	return &leveledLogger{logger: logger, level: level}
+}
+
+func (l *leveledLogger) Enabled() bool {
+	return l.level >= 0 && l.logger.Enabled()
+}
+
+func (l *leveledLogger) Info(msg string, keysAndValues ...interface{}) {
+	if l.level >= 0 {
+		l.logger.Info(msg, keysAndValues...)
+	}
+}
+
+func (l *leveledLogger) Error(err error, msg string, keysAndValues ...interface{}) {
+	l.logger.Error(err, msg, keysAndValues...)
+}
+
+func (l *leveledLogger) V(level int) logr.Logger {
+	if level > l.level {
+		return logr.Discard()
+	}
+	return &leveledLogger{logger: l.logger.V(level), level: l.level - level}
+}
+
+func (l *leveledLogger) WithValues(keysAndValues ...interface{}) logr.Logger {
+	return &leveledLogger{logger: l.logger.WithValues(keysAndValues...), level: l.level}
+}
+
+func (l *leveledLogger) WithName(name string) logr.Logger {
+	return &leveledLogger{logger: l.logger.WithName(name), level: l.level}
+}
+
+// GetLevel returns log level set by LevelAnnotation.
+func GetLevel(object metav1.Object) (int, bool) {
+	value, ok := object.GetAnnotations()[LevelAnnotation]
+	if !ok {
+		return 0, false
+	}
+	level, ok := levels[strings.ToLower(strings.TrimSpace(value))]
+	return level, ok
+}
+
+// IsValidLevel returns true if the value of LevelAnnotation is supported.
+func IsValidLevel(value string) bool {
+	_, ok := levels[strings.ToLower(strings.TrimSpace(value))]
+	return ok
+}
+
+// WithLevel returns logger which logs messages up to the level, it's no-op if the logger wasn't created by NewLeveled.
+func WithLevel(logger logr.Logger, level int) logr.Logger {
+	if leveled, ok := logger.(*leveledLogger); ok {
+		return &leveledLogger{logger: leveled.logger, level: level}
+	}
+	return logger
+}
+
+// ForObject returns logger with the namespace and name of CR, the log level can be overridden by LevelAnnotation.
+func ForObject(object metav1.Object, nameKey string) logr.Logger {
+	logger := Log.WithValues(NamespaceKey, object.GetNamespace(), nameKey, object.GetName())
+	if level, ok := GetLevel(object); ok {
+		logger = WithLevel(logger, level)
+	}
+	return logger
+}
+
+// ForJenkins returns logger with the namespace and name of Jenkins CR, the log level can be overridden by LevelAnnotation.
+func ForJenkins(jenkins metav1.Object) logr.Logger {
+	return ForObject(jenkins, JenkinsKey)
+}
+
+// ForJenkinsPhase returns logger for Jenkins CR in the reconciliation phase.
+func ForJenkinsPhase(jenkins metav1.Object, phase string) logr.Logger {
+	return ForJenkins(jenkins).WithValues(PhaseKey, phase)
+}
+
+// Setup setups global logger, the messages more verbose than VDebug in debug mode and info otherwise are dropped.
+func Setup(logger logr.Logger, debug bool) {
+	Debug = debug
+	level := 0
+	if debug {
+		level = VDebug
+	}
+	leveled := NewLeveled(logger, level)
+	logf.SetLogger(leveled)
+	Log = leveled.WithName("controller-jenkins")
+}
+
+// NewJSONEncoder returns zap encoder which writes structured JSON logs with ISO8601 timestamps.
+func NewJSONEncoder(options ...crzap.EncoderConfigOption) zapcore.Encoder {
+	encoderConfig := zap.NewProductionEncoderConfig()
+	encoderConfig.TimeKey = "time"
+	encoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+	for _, option := range options {
+		option(&encoderConfig)
+	}
+	return zapcore.NewJSONEncoder(encoderConfig)
+}
+
+func zapLogger() logr.Logger {
 	var zapLog *zap.Logger
 	var err error
 	zapLogCfg := zap.NewDevelopmentConfig()
-	if debug {
-		zapLogCfg.Level = zap.NewAtomicLevelAt(zap.DebugLevel)
-	} else {
-		zapLogCfg.Level = zap.NewAtomicLevelAt(zap.InfoLevel)
-	}
-	zapLog, err = zapLogCfg.Build(zap.AddStacktrace(zap.DPanicLevel), zap.AddCallerSkip(1))
+	zapLogCfg.Level = zap.NewAtomicLevelAt(zap.DebugLevel)
+	zapLog, err = zapLogCfg.Build(zap.AddStacktrace(zap.DPanicLevel), zap.AddCallerSkip(2))
 	// who watches the watchmen?
 	fatalIfErr(err, log.Fatalf)
 	return zapr.NewLogger(zapLog)
@@ -45,7 +176,5 @@ func fatalIfErr(err error, f func(format string, v ...interface{})) {
 
 // SetupLogger setups global logger.
 func SetupLogger(debug bool) {
-	Debug = debug
-	logf.SetLogger(zapLogger(debug))
-	Log = logf.Log.WithName("controller-jenkins")
+	Setup(zapLogger(), debug)
 }
@@ -0,0 +1,94 @@
+package log
+
+import (
+	"testing"
+
+	"github.com/go-logr/zapr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func setupObservedLogger(debug bool) *observer.ObservedLogs {
+	core, logs := observer.New(zapcore.DebugLevel)
+	Setup(zapr.NewLogger(zap.New(core)), debug)
+	return logs
+}
+
+func TestSetup(t *testing.T) {
+	t.Run("info level", func(t *testing.T) {
+		logs := setupObservedLogger(false)
+
+		Log.V(VWarn).Info("warning")
+		Log.Info("info")
+		Log.V(VDebug).Info("debug")
+
+		require.Equal(t, 2, logs.Len())
+		assert.Equal(t, "warning", logs.All()[0].Message)
+		assert.Equal(t, "info", logs.All()[1].Message)
+	})
+	t.Run("debug level", func(t *testing.T) {
+		logs := setupObservedLogger(true)
+
+		Log.V(VDebug).Info("debug")
+		Log.V(VDebug + 1).Info("trace")
+
+		require.Equal(t, 1, logs.Len())
+		assert.Equal(t, "debug", logs.All()[0].Message)
+	})
+}
+
+func TestForJenkins(t *testing.T) {
+	jenkins := func(annotations map[string]string) *metav1.ObjectMeta {
+		return &metav1.ObjectMeta{Name: "jenkins", Namespace: "default", Annotations: annotations}
+	}
+
+	t.Run("consistent keys", func(t *testing.T) {
+		logs := setupObservedLogger(false)
+
+		ForJenkinsPhase(jenkins(nil), PhaseBase).Info("info")
+
+		require.Equal(t, 1, logs.Len())
+		assert.Equal(t, map[string]interface{}{
+			NamespaceKey: "default",
+			JenkinsKey:   "jenkins",
+			PhaseKey:     PhaseBase,
+		}, logs.All()[0].ContextMap())
+	})
+	t.Run("debug level set by annotation", func(t *testing.T) {
+		logs := setupObservedLogger(false)
+
+		ForJenkins(jenkins(map[string]string{LevelAnnotation: "debug"})).V(VDebug).Info("debug")
+		ForJenkins(jenkins(nil)).V(VDebug).Info("skipped")
+
+		require.Equal(t, 1, logs.Len())
+		assert.Equal(t, "debug", logs.All()[0].Message)
+	})
+	t.Run("warn level set by annotation", func(t *testing.T) {
+		logs := setupObservedLogger(true)
+		logger := ForJenkins(jenkins(map[string]string{LevelAnnotation: "warn"}))
+
+		logger.Info("skipped")
+		logger.V(VDebug).Info("skipped")
+		logger.V(VWarn).Info("warning")
+
+		require.Equal(t, 1, logs.Len())
+		assert.Equal(t, "warning", logs.All()[0].Message)
+	})
+	t.Run("invalid annotation is ignored", func(t *testing.T) {
+		logs := setupObservedLogger(true)
+
+		ForJenkins(jenkins(map[string]string{LevelAnnotation: "verbose"})).V(VDebug).Info("debug")
+
+		require.Equal(t, 1, logs.Len())
+	})
+}
+
+func TestIsValidLevel(t *testing.T) {
+	assert.True(t, IsValidLevel("debug"))
+	assert.True(t, IsValidLevel(" Warn "))
+	assert.False(t, IsValidLevel("verbose"))
+}
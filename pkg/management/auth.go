@@ -0,0 +1,67 @@
+package management
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Authenticator returns the user of the bearer token, false is returned when the token isn't valid
+type Authenticator interface {
+	Authenticate(ctx context.Context, token string) (authenticationv1.UserInfo, bool, error)
+}
+
+// Authorizer tells if the user is allowed to perform the action on the resource
+type Authorizer interface {
+	Authorize(ctx context.Context, user authenticationv1.UserInfo, attributes authorizationv1.ResourceAttributes) (bool, error)
+}
+
+// KubernetesAuth authenticates by TokenReview and authorizes by SubjectAccessReview, the same tokens and RBAC rules
+// as for Kubernetes API apply
+type KubernetesAuth struct {
+	clientSet kubernetes.Interface
+}
+
+// NewKubernetesAuth returns the authenticator and authorizer using Kubernetes API
+func NewKubernetesAuth(clientSet kubernetes.Interface) *KubernetesAuth {
+	return &KubernetesAuth{clientSet: clientSet}
+}
+
+// Authenticate reviews the token by TokenReview
+func (k *KubernetesAuth) Authenticate(ctx context.Context, token string) (authenticationv1.UserInfo, bool, error) {
+	review := &authenticationv1.TokenReview{Spec: authenticationv1.TokenReviewSpec{Token: token}}
+	result, err := k.clientSet.AuthenticationV1().TokenReviews().Create(ctx, review, metav1.CreateOptions{})
+	if err != nil {
+		return authenticationv1.UserInfo{}, false, errors.WithStack(err)
+	}
+	if len(result.Status.Error) > 0 || !result.Status.Authenticated {
+		return authenticationv1.UserInfo{}, false, nil
+	}
+	return result.Status.User, true, nil
+}
+
+// Authorize checks the RBAC rules of the user by SubjectAccessReview
+func (k *KubernetesAuth) Authorize(ctx context.Context, user authenticationv1.UserInfo, attributes authorizationv1.ResourceAttributes) (bool, error) {
+	extra := map[string]authorizationv1.ExtraValue{}
+	for key, value := range user.Extra {
+		extra[key] = authorizationv1.ExtraValue(value)
+	}
+	review := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			ResourceAttributes: &attributes,
+			User:               user.Username,
+			Groups:             user.Groups,
+			UID:                user.UID,
+			Extra:              extra,
+		},
+	}
+	result, err := k.clientSet.AuthorizationV1().SubjectAccessReviews().Create(ctx, review, metav1.CreateOptions{})
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+	return result.Status.Allowed, nil
+}
@@ -0,0 +1,3 @@
+// Package management serves the REST API of Jenkins instances managed by the operator for developer portals,
+// callers are authenticated by TokenReview and authorized by SubjectAccessReview of Kubernetes API
+package management
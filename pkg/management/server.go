@@ -0,0 +1,280 @@
+package management
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/log"
+	"github.com/jenkinsci/kubernetes-operator/pkg/tracing"
+
+	"github.com/pkg/errors"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// BasePath is the path of Jenkins instances, the path of single instance is /api/v1/jenkins/<namespace>/<name>
+	BasePath = "/api/v1/jenkins"
+	// PlanSubresource is the subresource of Jenkins CR authorized when the plan mode is enabled or disabled
+	PlanSubresource = "plan"
+	// BackupSubresource is the subresource of Jenkins CR authorized when the backup is requested
+	BackupSubresource = "backup"
+
+	jenkinsResource = "jenkins"
+)
+
+// Instance is the Jenkins instance returned by the API
+type Instance struct {
+	Namespace                      string       `json:"namespace"`
+	Name                           string       `json:"name"`
+	Ready                          bool         `json:"ready"`
+	Reason                         string       `json:"reason,omitempty"`
+	Message                        string       `json:"message,omitempty"`
+	OperatorVersion                string       `json:"operatorVersion,omitempty"`
+	BaseConfigurationCompletedTime *metav1.Time `json:"baseConfigurationCompletedTime,omitempty"`
+	UserConfigurationCompletedTime *metav1.Time `json:"userConfigurationCompletedTime,omitempty"`
+	LastBackup                     uint64       `json:"lastBackup,omitempty"`
+	PlanMode                       bool         `json:"planMode"`
+	// Drift is returned only for single instance
+	Drift *Drift `json:"drift,omitempty"`
+}
+
+// Drift contains the pending changes of the Jenkins instance
+type Drift struct {
+	Plan                   *v1alpha2.JenkinsPlan            `json:"plan,omitempty"`
+	DeferredActions        []v1alpha2.DeferredAction        `json:"deferredActions,omitempty"`
+	ApplyConflicts         []v1alpha2.ApplyConflict         `json:"applyConflicts,omitempty"`
+	PendingScriptApprovals []v1alpha2.PendingScriptApproval `json:"pendingScriptApprovals,omitempty"`
+}
+
+// NewInstance returns the instance from Jenkins CR, the drift is included when withDrift is true
+func NewInstance(jenkins *v1alpha2.Jenkins, withDrift bool) Instance {
+	instance := Instance{
+		Namespace:                      jenkins.Namespace,
+		Name:                           jenkins.Name,
+		OperatorVersion:                jenkins.Status.OperatorVersion,
+		BaseConfigurationCompletedTime: jenkins.Status.BaseConfigurationCompletedTime,
+		UserConfigurationCompletedTime: jenkins.Status.UserConfigurationCompletedTime,
+		LastBackup:                     jenkins.Status.LastBackup,
+		PlanMode:                       jenkins.Annotations[v1alpha2.PlanAnnotation] == "true",
+	}
+	if condition := meta.FindStatusCondition(jenkins.Status.Conditions, v1alpha2.JenkinsReadyConditionType); condition != nil {
+		instance.Ready = condition.Status == metav1.ConditionTrue
+		instance.Reason = condition.Reason
+		instance.Message = condition.Message
+	}
+	if withDrift {
+		instance.Drift = &Drift{
+			Plan:                   jenkins.Status.Plan,
+			DeferredActions:        jenkins.Status.DeferredActions,
+			ApplyConflicts:         jenkins.Status.ApplyConflicts,
+			PendingScriptApprovals: jenkins.Status.PendingScriptApprovals,
+		}
+	}
+	return instance
+}
+
+// Handler serves the management API
+type Handler struct {
+	client        k8sclient.Client
+	authenticator Authenticator
+	authorizer    Authorizer
+}
+
+// New returns the handler of the management API
+func New(client k8sclient.Client, authenticator Authenticator, authorizer Authorizer) *Handler {
+	return &Handler{client: client, authenticator: authenticator, authorizer: authorizer}
+}
+
+// ServeHTTP authenticates the bearer token of the request and serves the API when the user is authorized.
+func (h *Handler) ServeHTTP(response http.ResponseWriter, request *http.Request) {
+	token := strings.TrimPrefix(request.Header.Get("Authorization"), "Bearer ")
+	if len(token) == 0 || token == request.Header.Get("Authorization") {
+		http.Error(response, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+	user, authenticated, err := h.authenticator.Authenticate(request.Context(), token)
+	if err != nil {
+		log.Log.Error(err, "Failed to authenticate management API request")
+		http.Error(response, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	if !authenticated {
+		http.Error(response, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+
+	if !strings.HasPrefix(request.URL.Path, BasePath) {
+		http.NotFound(response, request)
+		return
+	}
+	var parts []string
+	if path := strings.Trim(strings.TrimPrefix(request.URL.Path, BasePath), "/"); len(path) > 0 {
+		parts = strings.Split(path, "/")
+	}
+
+	switch {
+	case len(parts) == 0 && request.Method == http.MethodGet:
+		h.list(response, request, user)
+	case len(parts) == 2 && request.Method == http.MethodGet:
+		h.get(response, request, user, types.NamespacedName{Namespace: parts[0], Name: parts[1]})
+	case len(parts) == 3 && parts[2] == PlanSubresource && (request.Method == http.MethodPost || request.Method == http.MethodDelete):
+		h.setPlanMode(response, request, user, types.NamespacedName{Namespace: parts[0], Name: parts[1]})
+	case len(parts) == 3 && parts[2] == BackupSubresource && request.Method == http.MethodPost:
+		h.requestBackup(response, request, user, types.NamespacedName{Namespace: parts[0], Name: parts[1]})
+	case len(parts) == 0 || len(parts) == 2 || (len(parts) == 3 && (parts[2] == PlanSubresource || parts[2] == BackupSubresource)):
+		http.Error(response, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+	default:
+		http.NotFound(response, request)
+	}
+}
+
+func (h *Handler) list(response http.ResponseWriter, request *http.Request, user authenticationv1.UserInfo) {
+	ctx, span := tracing.Start(request.Context(), "ManagementAPI.List")
+	defer tracing.End(span, nil)
+
+	jenkinses := &v1alpha2.JenkinsList{}
+	if err := h.client.List(ctx, jenkinses); err != nil {
+		h.fail(response, errors.WithStack(err), "Failed to list Jenkins CRs")
+		return
+	}
+	instances := []Instance{}
+	allowed := map[string]bool{}
+	for i := range jenkinses.Items {
+		jenkins := &jenkinses.Items[i]
+		if _, checked := allowed[jenkins.Namespace]; !checked {
+			ok, err := h.authorizer.Authorize(ctx, user, authorizationv1.ResourceAttributes{
+				Namespace: jenkins.Namespace, Verb: "list", Group: v1alpha2.GroupVersion.Group, Resource: jenkinsResource,
+			})
+			if err != nil {
+				h.fail(response, err, "Failed to authorize management API request")
+				return
+			}
+			allowed[jenkins.Namespace] = ok
+		}
+		if allowed[jenkins.Namespace] {
+			instances = append(instances, NewInstance(jenkins, false))
+		}
+	}
+	writeJSON(response, http.StatusOK, instances)
+}
+
+func (h *Handler) get(response http.ResponseWriter, request *http.Request, user authenticationv1.UserInfo, name types.NamespacedName) {
+	ctx, span := tracing.Start(request.Context(), "ManagementAPI.Get", tracing.JenkinsAttributes(name.Namespace, name.Name)...)
+	defer tracing.End(span, nil)
+
+	jenkins, ok := h.authorizedJenkins(ctx, response, user, name, "get", "")
+	if !ok {
+		return
+	}
+	writeJSON(response, http.StatusOK, NewInstance(jenkins, true))
+}
+
+func (h *Handler) setPlanMode(response http.ResponseWriter, request *http.Request, user authenticationv1.UserInfo, name types.NamespacedName) {
+	ctx, span := tracing.Start(request.Context(), "ManagementAPI.Plan", tracing.JenkinsAttributes(name.Namespace, name.Name)...)
+	defer tracing.End(span, nil)
+
+	verb, value := "create", "true"
+	if request.Method == http.MethodDelete {
+		verb, value = "delete", ""
+	}
+	jenkins, ok := h.authorizedJenkins(ctx, response, user, name, verb, PlanSubresource)
+	if !ok {
+		return
+	}
+	if err := h.annotate(ctx, jenkins, v1alpha2.PlanAnnotation, value); err != nil {
+		h.fail(response, err, "Failed to set plan mode of Jenkins CR")
+		return
+	}
+	message := fmt.Sprintf("Plan mode has been enabled by '%s' through management API", user.Username)
+	if len(value) == 0 {
+		message = fmt.Sprintf("Plan mode has been disabled by '%s' through management API", user.Username)
+	}
+	log.ForJenkins(jenkins).Info(message)
+	writeJSON(response, http.StatusAccepted, NewInstance(jenkins, true))
+}
+
+func (h *Handler) requestBackup(response http.ResponseWriter, request *http.Request, user authenticationv1.UserInfo, name types.NamespacedName) {
+	ctx, span := tracing.Start(request.Context(), "ManagementAPI.Backup", tracing.JenkinsAttributes(name.Namespace, name.Name)...)
+	defer tracing.End(span, nil)
+
+	jenkins, ok := h.authorizedJenkins(ctx, response, user, name, "create", BackupSubresource)
+	if !ok {
+		return
+	}
+	if err := h.annotate(ctx, jenkins, v1alpha2.BackupNowAnnotation, time.Now().UTC().Format(time.RFC3339)); err != nil {
+		h.fail(response, err, "Failed to request backup of Jenkins CR")
+		return
+	}
+	log.ForJenkins(jenkins).Info(fmt.Sprintf("Backup has been requested by '%s' through management API", user.Username))
+	writeJSON(response, http.StatusAccepted, NewInstance(jenkins, true))
+}
+
+// authorizedJenkins returns Jenkins CR when the user is authorized, otherwise the error response is written
+func (h *Handler) authorizedJenkins(ctx context.Context, response http.ResponseWriter, user authenticationv1.UserInfo,
+	name types.NamespacedName, verb, subresource string) (*v1alpha2.Jenkins, bool) {
+	allowed, err := h.authorizer.Authorize(ctx, user, authorizationv1.ResourceAttributes{
+		Namespace:   name.Namespace,
+		Verb:        verb,
+		Group:       v1alpha2.GroupVersion.Group,
+		Resource:    jenkinsResource,
+		Subresource: subresource,
+		Name:        name.Name,
+	})
+	if err != nil {
+		h.fail(response, err, "Failed to authorize management API request")
+		return nil, false
+	}
+	if !allowed {
+		http.Error(response, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return nil, false
+	}
+
+	jenkins := &v1alpha2.Jenkins{}
+	if err := h.client.Get(ctx, name, jenkins); err != nil {
+		if apierrors.IsNotFound(err) {
+			http.Error(response, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			return nil, false
+		}
+		h.fail(response, errors.WithStack(err), "Failed to get Jenkins CR")
+		return nil, false
+	}
+	return jenkins, true
+}
+
+// annotate sets the annotation handled by the operator on Jenkins CR, the annotation is removed when value is empty
+func (h *Handler) annotate(ctx context.Context, jenkins *v1alpha2.Jenkins, annotation, value string) error {
+	patch := k8sclient.MergeFrom(jenkins.DeepCopy())
+	if len(value) == 0 {
+		delete(jenkins.Annotations, annotation)
+	} else {
+		if jenkins.Annotations == nil {
+			jenkins.Annotations = map[string]string{}
+		}
+		jenkins.Annotations[annotation] = value
+	}
+	return errors.WithStack(h.client.Patch(ctx, jenkins, patch))
+}
+
+func (h *Handler) fail(response http.ResponseWriter, err error, message string) {
+	log.Log.Error(err, message)
+	http.Error(response, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+}
+
+func writeJSON(response http.ResponseWriter, status int, value interface{}) {
+	response.Header().Set("Content-Type", "application/json")
+	response.WriteHeader(status)
+	if err := json.NewEncoder(response).Encode(value); err != nil {
+		log.Log.Error(err, "Failed to write management API response")
+	}
+}
@@ -0,0 +1,149 @@
+package management
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const token = "portal-token"
+
+type fakeAuth struct {
+	allowed map[authorizationv1.ResourceAttributes]bool
+}
+
+func (f *fakeAuth) Authenticate(_ context.Context, value string) (authenticationv1.UserInfo, bool, error) {
+	return authenticationv1.UserInfo{Username: "portal"}, value == token, nil
+}
+
+func (f *fakeAuth) Authorize(_ context.Context, _ authenticationv1.UserInfo, attributes authorizationv1.ResourceAttributes) (bool, error) {
+	return f.allowed[attributes], nil
+}
+
+func jenkinsAttributes(namespace, name, verb, subresource string) authorizationv1.ResourceAttributes {
+	return authorizationv1.ResourceAttributes{
+		Namespace: namespace, Name: name, Verb: verb, Group: "jenkins.io", Resource: "jenkins", Subresource: subresource,
+	}
+}
+
+func newJenkins(namespace, name string, ready bool) *v1alpha2.Jenkins {
+	status := metav1.ConditionFalse
+	if ready {
+		status = metav1.ConditionTrue
+	}
+	return &v1alpha2.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Status: v1alpha2.JenkinsStatus{
+			Conditions:      []metav1.Condition{{Type: v1alpha2.JenkinsReadyConditionType, Status: status, Reason: "Test"}},
+			DeferredActions: []v1alpha2.DeferredAction{{Action: v1alpha2.DisruptiveActionPodRestart, Message: "spec changed"}},
+		},
+	}
+}
+
+func serve(handler http.Handler, method, path, bearer string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(method, path, nil)
+	if len(bearer) > 0 {
+		request.Header.Set("Authorization", "Bearer "+bearer)
+	}
+	response := httptest.NewRecorder()
+	handler.ServeHTTP(response, request)
+	return response
+}
+
+func TestHandler(t *testing.T) {
+	require.NoError(t, v1alpha2.SchemeBuilder.AddToScheme(scheme.Scheme))
+	newHandler := func(allowed ...authorizationv1.ResourceAttributes) *Handler {
+		k8sClient := fake.NewClientBuilder().WithObjects(newJenkins("team-a", "jenkins", true), newJenkins("team-b", "jenkins", false)).Build()
+		auth := &fakeAuth{allowed: map[authorizationv1.ResourceAttributes]bool{}}
+		for _, attributes := range allowed {
+			auth.allowed[attributes] = true
+		}
+		return New(k8sClient, auth, auth)
+	}
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		handler := newHandler()
+
+		assert.Equal(t, http.StatusUnauthorized, serve(handler, http.MethodGet, BasePath, "").Code)
+		assert.Equal(t, http.StatusUnauthorized, serve(handler, http.MethodGet, BasePath, "invalid").Code)
+	})
+	t.Run("list only namespaces allowed", func(t *testing.T) {
+		handler := newHandler(jenkinsAttributes("team-a", "", "list", ""))
+
+		response := serve(handler, http.MethodGet, BasePath, token)
+
+		require.Equal(t, http.StatusOK, response.Code)
+		var instances []Instance
+		require.NoError(t, json.Unmarshal(response.Body.Bytes(), &instances))
+		require.Len(t, instances, 1)
+		assert.Equal(t, "team-a", instances[0].Namespace)
+		assert.True(t, instances[0].Ready)
+		assert.Nil(t, instances[0].Drift)
+	})
+	t.Run("get with drift", func(t *testing.T) {
+		handler := newHandler(jenkinsAttributes("team-b", "jenkins", "get", ""))
+
+		response := serve(handler, http.MethodGet, BasePath+"/team-b/jenkins", token)
+
+		require.Equal(t, http.StatusOK, response.Code)
+		var instance Instance
+		require.NoError(t, json.Unmarshal(response.Body.Bytes(), &instance))
+		assert.False(t, instance.Ready)
+		require.NotNil(t, instance.Drift)
+		assert.Len(t, instance.Drift.DeferredActions, 1)
+	})
+	t.Run("get forbidden", func(t *testing.T) {
+		handler := newHandler(jenkinsAttributes("team-a", "jenkins", "get", ""))
+
+		assert.Equal(t, http.StatusForbidden, serve(handler, http.MethodGet, BasePath+"/team-b/jenkins", token).Code)
+	})
+	t.Run("get not found", func(t *testing.T) {
+		handler := newHandler(jenkinsAttributes("team-a", "missing", "get", ""))
+
+		assert.Equal(t, http.StatusNotFound, serve(handler, http.MethodGet, BasePath+"/team-a/missing", token).Code)
+	})
+	t.Run("enable and disable plan mode", func(t *testing.T) {
+		handler := newHandler(jenkinsAttributes("team-a", "jenkins", "create", PlanSubresource), jenkinsAttributes("team-a", "jenkins", "delete", PlanSubresource))
+		jenkins := &v1alpha2.Jenkins{}
+
+		require.Equal(t, http.StatusAccepted, serve(handler, http.MethodPost, BasePath+"/team-a/jenkins/plan", token).Code)
+		require.NoError(t, handler.client.Get(context.TODO(), types.NamespacedName{Namespace: "team-a", Name: "jenkins"}, jenkins))
+		assert.Equal(t, "true", jenkins.Annotations[v1alpha2.PlanAnnotation])
+
+		require.Equal(t, http.StatusAccepted, serve(handler, http.MethodDelete, BasePath+"/team-a/jenkins/plan", token).Code)
+		jenkins = &v1alpha2.Jenkins{}
+		require.NoError(t, handler.client.Get(context.TODO(), types.NamespacedName{Namespace: "team-a", Name: "jenkins"}, jenkins))
+		assert.NotContains(t, jenkins.Annotations, v1alpha2.PlanAnnotation)
+	})
+	t.Run("request backup", func(t *testing.T) {
+		handler := newHandler(jenkinsAttributes("team-a", "jenkins", "create", BackupSubresource))
+		jenkins := &v1alpha2.Jenkins{}
+
+		require.Equal(t, http.StatusAccepted, serve(handler, http.MethodPost, BasePath+"/team-a/jenkins/backup", token).Code)
+		require.NoError(t, handler.client.Get(context.TODO(), types.NamespacedName{Namespace: "team-a", Name: "jenkins"}, jenkins))
+		assert.Contains(t, jenkins.Annotations, v1alpha2.BackupNowAnnotation)
+
+		assert.Equal(t, http.StatusForbidden, serve(handler, http.MethodPost, BasePath+"/team-b/jenkins/backup", token).Code)
+	})
+	t.Run("unknown paths and methods", func(t *testing.T) {
+		handler := newHandler()
+
+		assert.Equal(t, http.StatusNotFound, serve(handler, http.MethodGet, BasePath+"/team-a", token).Code)
+		assert.Equal(t, http.StatusNotFound, serve(handler, http.MethodGet, BasePath+"/team-a/jenkins/restart", token).Code)
+		assert.Equal(t, http.StatusMethodNotAllowed, serve(handler, http.MethodGet, BasePath+"/team-a/jenkins/backup", token).Code)
+		assert.Equal(t, http.StatusMethodNotAllowed, serve(handler, http.MethodDelete, BasePath, token).Code)
+	})
+}
@@ -0,0 +1,2 @@
+// Package metrics contains Prometheus metrics of Jenkins instances exposed by the operator metrics endpoint
+package metrics
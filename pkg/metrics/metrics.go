@@ -0,0 +1,312 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	namespaceLabel = "namespace"
+	jenkinsLabel   = "jenkins"
+	policyLabel    = "policy"
+	stateLabel     = "state"
+	resultLabel    = "result"
+	kindLabel      = "kind"
+	teamLabel      = "team"
+	componentLabel = "component"
+
+	onlineState  = "online"
+	offlineState = "offline"
+
+	masterComponent = "master"
+	agentsComponent = "agents"
+)
+
+// buildResults are the results of Jenkins builds
+var buildResults = []string{"SUCCESS", "UNSTABLE", "FAILURE", "NOT_BUILT", "ABORTED"}
+
+var (
+	// JenkinsHomeUsedBytes is the used disk space of Jenkins home volume
+	JenkinsHomeUsedBytes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "jenkins_operator_jenkins_home_used_bytes",
+		Help: "Used disk space of Jenkins home volume in bytes",
+	}, []string{namespaceLabel, jenkinsLabel})
+	// JenkinsHomeCapacityBytes is the size of Jenkins home volume
+	JenkinsHomeCapacityBytes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "jenkins_operator_jenkins_home_capacity_bytes",
+		Help: "Size of Jenkins home volume in bytes",
+	}, []string{namespaceLabel, jenkinsLabel})
+	// DiskCleanupsTotal is the number of runs of Jenkins home cleanup policies
+	DiskCleanupsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "jenkins_operator_disk_cleanups_total",
+		Help: "Number of runs of Jenkins home cleanup policies",
+	}, []string{namespaceLabel, jenkinsLabel, policyLabel})
+	// AgentPodsRunning is the number of running agent pods created from spec.agents.podTemplates
+	AgentPodsRunning = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "jenkins_operator_agent_pods_running",
+		Help: "Number of running agent pods of Jenkins",
+	}, []string{namespaceLabel, jenkinsLabel})
+	// AgentPodsPending is the number of pending agent pods created from spec.agents.podTemplates
+	AgentPodsPending = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "jenkins_operator_agent_pods_pending",
+		Help: "Number of pending agent pods of Jenkins",
+	}, []string{namespaceLabel, jenkinsLabel})
+	// AgentPodsMaxConcurrent is spec.agents.maxConcurrent
+	AgentPodsMaxConcurrent = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "jenkins_operator_agent_pods_max_concurrent",
+		Help: "Maximum number of agent pods of Jenkins running concurrently",
+	}, []string{namespaceLabel, jenkinsLabel})
+	// JenkinsReady is 1 when Jenkins has the Ready condition
+	JenkinsReady = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "jenkins_operator_jenkins_ready",
+		Help: "Whether Jenkins is ready, 1 when it's ready, 0 otherwise",
+	}, []string{namespaceLabel, jenkinsLabel})
+	// ReconcileErrorsTotal is the number of failed reconcile loops of Jenkins
+	ReconcileErrorsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "jenkins_operator_reconcile_errors_total",
+		Help: "Number of failed reconcile loops of Jenkins",
+	}, []string{namespaceLabel, jenkinsLabel})
+	// LastBackupTimestampSeconds is the time of the last successful backup of Jenkins
+	LastBackupTimestampSeconds = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "jenkins_operator_last_backup_timestamp_seconds",
+		Help: "Unix time of the last successful backup of Jenkins",
+	}, []string{namespaceLabel, jenkinsLabel})
+	// JenkinsQueueLength is the number of items in Jenkins queue
+	JenkinsQueueLength = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "jenkins_operator_jenkins_queue_length",
+		Help: "Number of items waiting in Jenkins queue",
+	}, []string{namespaceLabel, jenkinsLabel})
+	// JenkinsQueueBuildable is the number of items in Jenkins queue waiting only for a free executor
+	JenkinsQueueBuildable = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "jenkins_operator_jenkins_queue_buildable",
+		Help: "Number of items in Jenkins queue waiting for a free executor",
+	}, []string{namespaceLabel, jenkinsLabel})
+	// JenkinsQueueStuck is the number of items in Jenkins queue which can't be built
+	JenkinsQueueStuck = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "jenkins_operator_jenkins_queue_stuck",
+		Help: "Number of stuck items in Jenkins queue",
+	}, []string{namespaceLabel, jenkinsLabel})
+	// JenkinsExecutors is the number of executors of online Jenkins nodes
+	JenkinsExecutors = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "jenkins_operator_jenkins_executors",
+		Help: "Number of executors of online Jenkins nodes",
+	}, []string{namespaceLabel, jenkinsLabel})
+	// JenkinsExecutorsBusy is the number of executors running builds
+	JenkinsExecutorsBusy = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "jenkins_operator_jenkins_executors_busy",
+		Help: "Number of busy executors of Jenkins",
+	}, []string{namespaceLabel, jenkinsLabel})
+	// JenkinsNodes is the number of Jenkins nodes by their state
+	JenkinsNodes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "jenkins_operator_jenkins_nodes",
+		Help: "Number of online and offline Jenkins nodes",
+	}, []string{namespaceLabel, jenkinsLabel, stateLabel})
+	// JenkinsBuildDurationSeconds is the duration of completed Jenkins builds
+	JenkinsBuildDurationSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "jenkins_operator_jenkins_build_duration_seconds",
+		Help:    "Duration of completed Jenkins builds in seconds",
+		Buckets: prometheus.ExponentialBuckets(10, 2, 12),
+	}, []string{namespaceLabel, jenkinsLabel, resultLabel})
+	// OrphanedObjects is the number of objects found by the last run of the orphan garbage collector
+	OrphanedObjects = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "jenkins_operator_orphaned_objects",
+		Help: "Number of objects created by the operator for Jenkins CRs which don't exist anymore",
+	}, []string{namespaceLabel, kindLabel})
+	// OrphanedObjectsDeletedTotal is the number of orphaned objects deleted by the garbage collector
+	OrphanedObjectsDeletedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "jenkins_operator_orphaned_objects_deleted_total",
+		Help: "Number of orphaned objects deleted by the operator",
+	}, []string{namespaceLabel, kindLabel})
+	// CPURequestsCores is the sum of CPU requests of Jenkins master or agent pods
+	CPURequestsCores = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "jenkins_operator_cpu_requests_cores",
+		Help: "Sum of CPU requests of Jenkins master or agent pods in cores",
+	}, []string{namespaceLabel, jenkinsLabel, teamLabel, componentLabel})
+	// MemoryRequestsBytes is the sum of memory requests of Jenkins master or agent pods
+	MemoryRequestsBytes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "jenkins_operator_memory_requests_bytes",
+		Help: "Sum of memory requests of Jenkins master or agent pods in bytes",
+	}, []string{namespaceLabel, jenkinsLabel, teamLabel, componentLabel})
+	// CPUUsageCores is the sum of CPU usage of Jenkins master or agent pods reported by metrics-server
+	CPUUsageCores = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "jenkins_operator_cpu_usage_cores",
+		Help: "Sum of CPU usage of Jenkins master or agent pods in cores",
+	}, []string{namespaceLabel, jenkinsLabel, teamLabel, componentLabel})
+	// MemoryUsageBytes is the sum of memory working set of Jenkins master or agent pods reported by metrics-server
+	MemoryUsageBytes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "jenkins_operator_memory_usage_bytes",
+		Help: "Sum of memory working set of Jenkins master or agent pods in bytes",
+	}, []string{namespaceLabel, jenkinsLabel, teamLabel, componentLabel})
+	// AuditEntriesDroppedTotal is the number of audit records dropped because the audit queue was full
+	AuditEntriesDroppedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "jenkins_operator_audit_entries_dropped_total",
+		Help: "Number of audit records dropped because the audit queue was full",
+	}, []string{namespaceLabel, jenkinsLabel})
+)
+
+func init() {
+	metrics.Registry.MustRegister(JenkinsHomeUsedBytes, JenkinsHomeCapacityBytes, DiskCleanupsTotal,
+		AgentPodsRunning, AgentPodsPending, AgentPodsMaxConcurrent,
+		JenkinsReady, ReconcileErrorsTotal, LastBackupTimestampSeconds,
+		JenkinsQueueLength, JenkinsQueueBuildable, JenkinsQueueStuck, JenkinsExecutors, JenkinsExecutorsBusy,
+		JenkinsNodes, JenkinsBuildDurationSeconds, OrphanedObjects, OrphanedObjectsDeletedTotal,
+		CPURequestsCores, MemoryRequestsBytes, CPUUsageCores, MemoryUsageBytes,
+		AuditEntriesDroppedTotal)
+}
+
+// SetOrphanedObjects records the orphaned objects of the kind found in the namespace
+func SetOrphanedObjects(namespace, kind string, count int) {
+	OrphanedObjects.With(prometheus.Labels{namespaceLabel: namespace, kindLabel: kind}).Set(float64(count))
+}
+
+// AddOrphanedObjectsDeleted counts orphaned objects of the kind deleted in the namespace
+func AddOrphanedObjectsDeleted(namespace, kind string, count int) {
+	OrphanedObjectsDeletedTotal.With(prometheus.Labels{namespaceLabel: namespace, kindLabel: kind}).Add(float64(count))
+}
+
+// IncAuditEntriesDropped counts the audit record of Jenkins CR dropped because the audit queue was full
+func IncAuditEntriesDropped(namespace, jenkins string) {
+	AuditEntriesDroppedTotal.With(prometheus.Labels{namespaceLabel: namespace, jenkinsLabel: jenkins}).Inc()
+}
+
+// SetDiskUsage records the last Jenkins home disk usage check
+func SetDiskUsage(jenkins *v1alpha2.Jenkins, status v1alpha2.DiskUsageStatus) {
+	labels := prometheus.Labels{namespaceLabel: jenkins.Namespace, jenkinsLabel: jenkins.Name}
+	JenkinsHomeUsedBytes.With(labels).Set(float64(status.UsedBytes))
+	JenkinsHomeCapacityBytes.With(labels).Set(float64(status.CapacityBytes))
+}
+
+// IncDiskCleanups counts the run of Jenkins home cleanup policy
+func IncDiskCleanups(jenkins *v1alpha2.Jenkins, policy string) {
+	DiskCleanupsTotal.With(prometheus.Labels{namespaceLabel: jenkins.Namespace, jenkinsLabel: jenkins.Name, policyLabel: policy}).Inc()
+}
+
+// DeleteDiskUsage removes the disk usage metrics of Jenkins, e.g. when spec.diskUsage is unset
+func DeleteDiskUsage(jenkins *v1alpha2.Jenkins) {
+	labels := prometheus.Labels{namespaceLabel: jenkins.Namespace, jenkinsLabel: jenkins.Name}
+	JenkinsHomeUsedBytes.Delete(labels)
+	JenkinsHomeCapacityBytes.Delete(labels)
+}
+
+// SetAgentPods records the number of active agent pods and their limit
+func SetAgentPods(jenkins *v1alpha2.Jenkins, running, pending int, maxConcurrent int32) {
+	labels := prometheus.Labels{namespaceLabel: jenkins.Namespace, jenkinsLabel: jenkins.Name}
+	AgentPodsRunning.With(labels).Set(float64(running))
+	AgentPodsPending.With(labels).Set(float64(pending))
+	AgentPodsMaxConcurrent.With(labels).Set(float64(maxConcurrent))
+}
+
+// DeleteAgentPods removes the agent pods metrics of Jenkins, e.g. when spec.agents.maxConcurrent is unset
+// or Jenkins CR is deleted
+func DeleteAgentPods(jenkins *v1alpha2.Jenkins) {
+	labels := prometheus.Labels{namespaceLabel: jenkins.Namespace, jenkinsLabel: jenkins.Name}
+	AgentPodsRunning.Delete(labels)
+	AgentPodsPending.Delete(labels)
+	AgentPodsMaxConcurrent.Delete(labels)
+}
+
+// SetReady records the readiness of Jenkins
+func SetReady(jenkins *v1alpha2.Jenkins, ready bool) {
+	value := 0.0
+	if ready {
+		value = 1
+	}
+	JenkinsReady.With(prometheus.Labels{namespaceLabel: jenkins.Namespace, jenkinsLabel: jenkins.Name}).Set(value)
+}
+
+// IncReconcileErrors counts the failed reconcile loop of Jenkins
+func IncReconcileErrors(namespace, name string) {
+	ReconcileErrorsTotal.With(prometheus.Labels{namespaceLabel: namespace, jenkinsLabel: name}).Inc()
+}
+
+// SetLastBackupTime records the time of the last successful backup of Jenkins from status.lastBackupTime
+func SetLastBackupTime(jenkins *v1alpha2.Jenkins) {
+	labels := prometheus.Labels{namespaceLabel: jenkins.Namespace, jenkinsLabel: jenkins.Name}
+	if jenkins.Status.LastBackupTime == nil {
+		LastBackupTimestampSeconds.Delete(labels)
+		return
+	}
+	LastBackupTimestampSeconds.With(labels).Set(float64(jenkins.Status.LastBackupTime.Unix()))
+}
+
+// DeleteJenkins removes the readiness, reconcile errors and backup metrics of the deleted Jenkins
+func DeleteJenkins(namespace, name string) {
+	labels := prometheus.Labels{namespaceLabel: namespace, jenkinsLabel: name}
+	JenkinsReady.Delete(labels)
+	ReconcileErrorsTotal.Delete(labels)
+	LastBackupTimestampSeconds.Delete(labels)
+}
+
+// SetQueue records the number of items in Jenkins queue
+func SetQueue(jenkins *v1alpha2.Jenkins, length, buildable, stuck int) {
+	labels := prometheus.Labels{namespaceLabel: jenkins.Namespace, jenkinsLabel: jenkins.Name}
+	JenkinsQueueLength.With(labels).Set(float64(length))
+	JenkinsQueueBuildable.With(labels).Set(float64(buildable))
+	JenkinsQueueStuck.With(labels).Set(float64(stuck))
+}
+
+// SetExecutors records the number of all and busy executors of Jenkins
+func SetExecutors(jenkins *v1alpha2.Jenkins, executors, busy int) {
+	labels := prometheus.Labels{namespaceLabel: jenkins.Namespace, jenkinsLabel: jenkins.Name}
+	JenkinsExecutors.With(labels).Set(float64(executors))
+	JenkinsExecutorsBusy.With(labels).Set(float64(busy))
+}
+
+// SetNodes records the number of online and offline Jenkins nodes
+func SetNodes(jenkins *v1alpha2.Jenkins, online, offline int) {
+	JenkinsNodes.With(prometheus.Labels{namespaceLabel: jenkins.Namespace, jenkinsLabel: jenkins.Name, stateLabel: onlineState}).Set(float64(online))
+	JenkinsNodes.With(prometheus.Labels{namespaceLabel: jenkins.Namespace, jenkinsLabel: jenkins.Name, stateLabel: offlineState}).Set(float64(offline))
+}
+
+// ObserveBuildDuration records the duration of the completed Jenkins build
+func ObserveBuildDuration(jenkins *v1alpha2.Jenkins, result string, duration time.Duration) {
+	JenkinsBuildDurationSeconds.With(prometheus.Labels{namespaceLabel: jenkins.Namespace, jenkinsLabel: jenkins.Name, resultLabel: result}).Observe(duration.Seconds())
+}
+
+// DeleteJenkinsMetrics removes the metrics scraped from Jenkins, e.g. when spec.monitoring.exporter is unset
+func DeleteJenkinsMetrics(jenkins *v1alpha2.Jenkins) {
+	labels := prometheus.Labels{namespaceLabel: jenkins.Namespace, jenkinsLabel: jenkins.Name}
+	JenkinsQueueLength.Delete(labels)
+	JenkinsQueueBuildable.Delete(labels)
+	JenkinsQueueStuck.Delete(labels)
+	JenkinsExecutors.Delete(labels)
+	JenkinsExecutorsBusy.Delete(labels)
+	for _, state := range []string{onlineState, offlineState} {
+		JenkinsNodes.Delete(prometheus.Labels{namespaceLabel: jenkins.Namespace, jenkinsLabel: jenkins.Name, stateLabel: state})
+	}
+	for _, result := range buildResults {
+		JenkinsBuildDurationSeconds.Delete(prometheus.Labels{namespaceLabel: jenkins.Namespace, jenkinsLabel: jenkins.Name, resultLabel: result})
+	}
+}
+
+// SetResourceUsage records the resource requests and usage of Jenkins master and agent pods
+func SetResourceUsage(jenkins *v1alpha2.Jenkins, status v1alpha2.ResourceUsageStatus) {
+	for component, usage := range map[string]v1alpha2.PodsResourceUsage{masterComponent: status.Master, agentsComponent: status.Agents} {
+		labels := prometheus.Labels{namespaceLabel: jenkins.Namespace, jenkinsLabel: jenkins.Name, teamLabel: status.Team, componentLabel: component}
+		CPURequestsCores.With(labels).Set(float64(usage.CPURequests.MilliValue()) / 1000)
+		MemoryRequestsBytes.With(labels).Set(float64(usage.MemoryRequests.Value()))
+		if usage.CPUUsage != nil && usage.MemoryUsage != nil {
+			CPUUsageCores.With(labels).Set(float64(usage.CPUUsage.MilliValue()) / 1000)
+			MemoryUsageBytes.With(labels).Set(float64(usage.MemoryUsage.Value()))
+		} else {
+			CPUUsageCores.Delete(labels)
+			MemoryUsageBytes.Delete(labels)
+		}
+	}
+}
+
+// DeleteResourceUsage removes the resource usage metrics of Jenkins with the team label, e.g. when spec.resourceUsage
+// is unset or the team has changed
+func DeleteResourceUsage(jenkins *v1alpha2.Jenkins, team string) {
+	for _, component := range []string{masterComponent, agentsComponent} {
+		labels := prometheus.Labels{namespaceLabel: jenkins.Namespace, jenkinsLabel: jenkins.Name, teamLabel: team, componentLabel: component}
+		CPURequestsCores.Delete(labels)
+		MemoryRequestsBytes.Delete(labels)
+		CPUUsageCores.Delete(labels)
+		MemoryUsageBytes.Delete(labels)
+	}
+}
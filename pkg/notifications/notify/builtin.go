@@ -0,0 +1,36 @@
+package notify
+
+import (
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/mailgun"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/msteams"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/slack"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/smtp"
+)
+
+func init() {
+	register("slack", func(options Options, notification v1alpha2.Notification) (Sink, bool) {
+		if notification.Slack == nil {
+			return nil, false
+		}
+		return slack.New(options.Client, notification, options.HTTPClient), true
+	}, true)
+	register("teams", func(options Options, notification v1alpha2.Notification) (Sink, bool) {
+		if notification.Teams == nil {
+			return nil, false
+		}
+		return msteams.New(options.Client, notification, options.HTTPClient), true
+	}, true)
+	register("mailgun", func(options Options, notification v1alpha2.Notification) (Sink, bool) {
+		if notification.Mailgun == nil {
+			return nil, false
+		}
+		return mailgun.New(options.Client, notification), true
+	}, true)
+	register("smtp", func(options Options, notification v1alpha2.Notification) (Sink, bool) {
+		if notification.SMTP == nil {
+			return nil, false
+		}
+		return smtp.New(options.Client, notification), true
+	}, true)
+}
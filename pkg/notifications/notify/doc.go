@@ -0,0 +1,3 @@
+// Package notify contains the registry of sinks which send notifications from spec.notifications, the Slack,
+// Microsoft Teams, Mailgun and SMTP sinks are registered by default
+package notify
@@ -0,0 +1,140 @@
+package notify
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
+
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Sink sends notifications about Jenkins events to the communication service
+type Sink interface {
+	Send(event event.Event) error
+}
+
+// Options are passed to factories of sinks
+type Options struct {
+	Client     k8sclient.Client
+	HTTPClient http.Client
+}
+
+// Factory returns the sink configured by the notification, false is returned when the notification is for another
+// sink, e.g. the factory of custom sink returns false when spec.notifications[].custom isn't set
+type Factory func(options Options, notification v1alpha2.Notification) (Sink, bool)
+
+type registration struct {
+	name    string
+	factory Factory
+	// builtin sinks are configured by their own fields of spec.notifications[], not by custom.sink
+	builtin bool
+}
+
+var (
+	registrationsMutex sync.RWMutex
+	registrations      []registration
+)
+
+// Register makes the sink available for spec.notifications, it's meant to be called from init functions of packages
+// with custom sinks, e.g. PagerDuty. The notifications with spec.notifications[].custom.sink equal to the name are sent
+// by the sink. It panics if the name is registered twice.
+func Register(name string, factory Factory) {
+	register(name, factory, false)
+}
+
+func register(name string, factory Factory, builtin bool) {
+	registrationsMutex.Lock()
+	defer registrationsMutex.Unlock()
+	if factory == nil {
+		panic("notify: registered factory is nil")
+	}
+	for _, existing := range registrations {
+		if existing.name == name {
+			panic("notify: sink " + name + " registered twice")
+		}
+	}
+	registrations = append(registrations, registration{name: name, factory: factory, builtin: builtin})
+}
+
+// Names returns the names of registered sinks in the order of registration
+func Names() []string {
+	registrationsMutex.RLock()
+	defer registrationsMutex.RUnlock()
+	var names []string
+	for _, registration := range registrations {
+		names = append(names, registration.name)
+	}
+	return names
+}
+
+// customNames returns the names of registered custom sinks, which can be set in spec.notifications[].custom.sink
+func customNames() []string {
+	registrationsMutex.RLock()
+	defer registrationsMutex.RUnlock()
+	var names []string
+	for _, registration := range registrations {
+		if !registration.builtin {
+			names = append(names, registration.name)
+		}
+	}
+	return names
+}
+
+// New returns the sink of the notification with its name, false is returned when no registered sink handles it
+func New(options Options, notification v1alpha2.Notification) (Sink, string, bool) {
+	registrationsMutex.RLock()
+	defer registrationsMutex.RUnlock()
+	for _, registration := range registrations {
+		if notification.Custom != nil && notification.Custom.Sink != registration.name {
+			continue
+		}
+		if sink, ok := registration.factory(options, notification); ok {
+			return sink, registration.name, true
+		}
+	}
+	return nil, "", false
+}
+
+// Validate returns the problems with custom sinks of spec.notifications
+func Validate(notifications []v1alpha2.Notification) []string {
+	var messages []string
+	for _, notification := range notifications {
+		if notification.Custom == nil {
+			continue
+		}
+		if len(notification.Custom.Sink) == 0 {
+			messages = append(messages, fmt.Sprintf("spec.notifications '%s' custom.sink is not configured", notification.Name))
+		} else if isBuiltin(notification.Custom.Sink) {
+			messages = append(messages, fmt.Sprintf("spec.notifications '%s' custom sink '%s' is built-in, configure it with spec.notifications[].%s",
+				notification.Name, notification.Custom.Sink, notification.Custom.Sink))
+		} else if names := customNames(); !contains(names, notification.Custom.Sink) {
+			messages = append(messages, fmt.Sprintf("spec.notifications '%s' custom sink '%s' isn't registered, registered custom sinks are [%s]",
+				notification.Name, notification.Custom.Sink, strings.Join(names, ", ")))
+		}
+	}
+	return messages
+}
+
+func isBuiltin(name string) bool {
+	registrationsMutex.RLock()
+	defer registrationsMutex.RUnlock()
+	for _, registration := range registrations {
+		if registration.builtin && registration.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(names []string, name string) bool {
+	for _, registered := range names {
+		if registered == name {
+			return true
+		}
+	}
+	return false
+}
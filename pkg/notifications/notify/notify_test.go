@@ -0,0 +1,88 @@
+package notify
+
+import (
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/slack"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSink struct {
+	notification v1alpha2.Notification
+}
+
+func (f *fakeSink) Send(event.Event) error {
+	return nil
+}
+
+func registerFakeSink(t *testing.T) {
+	Register("pagerduty", func(options Options, notification v1alpha2.Notification) (Sink, bool) {
+		if notification.Custom == nil {
+			return nil, false
+		}
+		return &fakeSink{notification: notification}, true
+	})
+	t.Cleanup(func() {
+		registrationsMutex.Lock()
+		registrations = registrations[:len(registrations)-1]
+		registrationsMutex.Unlock()
+	})
+}
+
+func TestNew(t *testing.T) {
+	registerFakeSink(t)
+
+	t.Run("built-in sink", func(t *testing.T) {
+		sink, name, ok := New(Options{}, v1alpha2.Notification{Name: "slack", Slack: &v1alpha2.Slack{}})
+
+		require.True(t, ok)
+		assert.Equal(t, "slack", name)
+		assert.IsType(t, &slack.Slack{}, sink)
+	})
+	t.Run("custom sink", func(t *testing.T) {
+		notification := v1alpha2.Notification{Name: "on-call", Custom: &v1alpha2.CustomNotification{
+			Sink:       "pagerduty",
+			Parameters: map[string]string{"severity": "critical"},
+		}}
+
+		sink, name, ok := New(Options{}, notification)
+
+		require.True(t, ok)
+		assert.Equal(t, "pagerduty", name)
+		assert.Equal(t, &fakeSink{notification: notification}, sink)
+	})
+	t.Run("unknown", func(t *testing.T) {
+		_, _, ok := New(Options{}, v1alpha2.Notification{Name: "empty"})
+		assert.False(t, ok)
+
+		_, _, ok = New(Options{}, v1alpha2.Notification{Name: "opsgenie", Custom: &v1alpha2.CustomNotification{Sink: "opsgenie"}})
+		assert.False(t, ok)
+	})
+	t.Run("duplicate", func(t *testing.T) {
+		assert.Panics(t, func() {
+			Register("slack", func(Options, v1alpha2.Notification) (Sink, bool) { return nil, false })
+		})
+	})
+}
+
+func TestValidate(t *testing.T) {
+	registerFakeSink(t)
+
+	messages := Validate([]v1alpha2.Notification{
+		{Name: "slack", Slack: &v1alpha2.Slack{}},
+		{Name: "on-call", Custom: &v1alpha2.CustomNotification{Sink: "pagerduty"}},
+		{Name: "opsgenie", Custom: &v1alpha2.CustomNotification{Sink: "opsgenie"}},
+		{Name: "empty", Custom: &v1alpha2.CustomNotification{}},
+		{Name: "custom-slack", Custom: &v1alpha2.CustomNotification{Sink: "slack"}},
+	})
+
+	assert.Equal(t, []string{
+		"spec.notifications 'opsgenie' custom sink 'opsgenie' isn't registered, registered custom sinks are [pagerduty]",
+		"spec.notifications 'empty' custom.sink is not configured",
+		"spec.notifications 'custom-slack' custom sink 'slack' is built-in, configure it with spec.notifications[].slack",
+	}, messages)
+}
@@ -65,6 +65,36 @@ type UserConfigurationComplete struct {
 	Undefined
 }
 
+// JenkinsReady informs that Jenkins passed health checks and is ready to use.
+type JenkinsReady struct {
+	Undefined
+}
+
+// JenkinsNotReady defines the reason why Jenkins is not ready to use.
+type JenkinsNotReady struct {
+	Undefined
+}
+
+// BackupVerificationSucceeded informs that the backup has been restored and the restored Jenkins passed the checks.
+type BackupVerificationSucceeded struct {
+	Undefined
+}
+
+// BackupVerificationFailed defines the reason why the backup verification failed.
+type BackupVerificationFailed struct {
+	Undefined
+}
+
+// BackupRestoreSucceeded informs that the backup requested by JenkinsRestore CR has been restored.
+type BackupRestoreSucceeded struct {
+	Undefined
+}
+
+// BackupRestoreFailed defines the reason why the restore requested by JenkinsRestore CR failed.
+type BackupRestoreFailed struct {
+	Undefined
+}
+
 // NewUndefined returns new instance of Undefined.
 func NewUndefined(source Source, short []string, verbose ...string) *Undefined {
 	return &Undefined{source: source, short: short, verbose: checkIfVerboseEmpty(short, verbose)}
@@ -171,6 +201,72 @@ func NewUserConfigurationComplete(source Source, short []string, verbose ...stri
 	}
 }
 
+// NewJenkinsReady returns new instance of JenkinsReady.
+func NewJenkinsReady(source Source, short []string, verbose ...string) *JenkinsReady {
+	return &JenkinsReady{
+		Undefined{
+			source:  source,
+			short:   short,
+			verbose: checkIfVerboseEmpty(short, verbose),
+		},
+	}
+}
+
+// NewJenkinsNotReady returns new instance of JenkinsNotReady.
+func NewJenkinsNotReady(source Source, short []string, verbose ...string) *JenkinsNotReady {
+	return &JenkinsNotReady{
+		Undefined{
+			source:  source,
+			short:   short,
+			verbose: checkIfVerboseEmpty(short, verbose),
+		},
+	}
+}
+
+// NewBackupVerificationSucceeded returns new instance of BackupVerificationSucceeded.
+func NewBackupVerificationSucceeded(source Source, short []string, verbose ...string) *BackupVerificationSucceeded {
+	return &BackupVerificationSucceeded{
+		Undefined{
+			source:  source,
+			short:   short,
+			verbose: checkIfVerboseEmpty(short, verbose),
+		},
+	}
+}
+
+// NewBackupVerificationFailed returns new instance of BackupVerificationFailed.
+func NewBackupVerificationFailed(source Source, short []string, verbose ...string) *BackupVerificationFailed {
+	return &BackupVerificationFailed{
+		Undefined{
+			source:  source,
+			short:   short,
+			verbose: checkIfVerboseEmpty(short, verbose),
+		},
+	}
+}
+
+// NewBackupRestoreSucceeded returns new instance of BackupRestoreSucceeded.
+func NewBackupRestoreSucceeded(source Source, short []string, verbose ...string) *BackupRestoreSucceeded {
+	return &BackupRestoreSucceeded{
+		Undefined{
+			source:  source,
+			short:   short,
+			verbose: checkIfVerboseEmpty(short, verbose),
+		},
+	}
+}
+
+// NewBackupRestoreFailed returns new instance of BackupRestoreFailed.
+func NewBackupRestoreFailed(source Source, short []string, verbose ...string) *BackupRestoreFailed {
+	return &BackupRestoreFailed{
+		Undefined{
+			source:  source,
+			short:   short,
+			verbose: checkIfVerboseEmpty(short, verbose),
+		},
+	}
+}
+
 // Source is enum type that informs us what triggered notification.
 type Source string
 
@@ -10,25 +10,17 @@ import (
 	k8sevent "github.com/jenkinsci/kubernetes-operator/pkg/event"
 	"github.com/jenkinsci/kubernetes-operator/pkg/log"
 	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/event"
-	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/mailgun"
-	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/msteams"
-	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/slack"
-	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/smtp"
+	"github.com/jenkinsci/kubernetes-operator/pkg/notifications/notify"
 
 	"github.com/pkg/errors"
 	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-// Provider is the communication service handler.
-type Provider interface {
-	Send(event event.Event) error
-}
-
 // Listen listens for incoming events and send it as notifications.
 func Listen(events chan event.Event, k8sEvent k8sevent.Recorder, k8sClient k8sclient.Client) {
-	httpClient := http.Client{}
+	options := notify.Options{Client: k8sClient, HTTPClient: http.Client{}}
 	for e := range events {
-		logger := log.Log.WithValues("cr", e.Jenkins.Name)
+		logger := log.ForJenkins(&e.Jenkins)
 
 		if !e.Reason.HasMessages() {
 			logger.V(log.VWarn).Info("Reason has no messages, this should not happen")
@@ -42,18 +34,8 @@ func Listen(events chan event.Event, k8sEvent k8sevent.Recorder, k8sClient k8scl
 		)
 
 		for _, notificationConfig := range e.Jenkins.Spec.Notifications {
-			var err error
-			var provider Provider
-			switch {
-			case notificationConfig.Slack != nil:
-				provider = slack.New(k8sClient, notificationConfig, httpClient)
-			case notificationConfig.Teams != nil:
-				provider = msteams.New(k8sClient, notificationConfig, httpClient)
-			case notificationConfig.Mailgun != nil:
-				provider = mailgun.New(k8sClient, notificationConfig)
-			case notificationConfig.SMTP != nil:
-				provider = smtp.New(k8sClient, notificationConfig)
-			default:
+			sink, _, ok := notify.New(options, notificationConfig)
+			if !ok {
 				logger.V(log.VWarn).Info(fmt.Sprintf("Unknown notification service `%+v`", notificationConfig))
 				continue
 			}
@@ -64,9 +46,8 @@ func Listen(events chan event.Event, k8sEvent k8sevent.Recorder, k8sClient k8scl
 				continue // skip the event
 			}
 
-			go func(notificationConfig v1alpha2.Notification) {
-				err = provider.Send(e)
-				if err != nil {
+			go func(e event.Event, sink notify.Sink, notificationConfig v1alpha2.Notification) {
+				if err := sink.Send(e); err != nil {
 					wrapped := errors.WithMessage(err,
 						fmt.Sprintf("failed to send notification '%s'", notificationConfig.Name))
 					if log.Debug {
@@ -75,7 +56,7 @@ func Listen(events chan event.Event, k8sEvent k8sevent.Recorder, k8sClient k8scl
 						logger.Error(nil, fmt.Sprintf("%s", wrapped))
 					}
 				}
-			}(notificationConfig)
+			}(e, sink, notificationConfig)
 		}
 	}
 }
@@ -1,5 +1,26 @@
 package plugins
 
+import (
+	"bufio"
+	"context"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/failure"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// BasePluginsManifestAnnotation keeps MinJenkinsVersion of the manifest used to set spec.master.basePlugins,
+// the base plugins are switched to another manifest only when they haven't been changed by user
+const BasePluginsManifestAnnotation = "jenkins.io/base-plugins-manifest"
+
 const (
 	configurationAsCodePlugin           = "configuration-as-code:1.55"
 	gitPlugin                           = "git:4.10.0"
@@ -21,7 +42,216 @@ var basePluginsList = []Plugin{
 	Must(New(kubernetesCredentialsProviderPlugin)),
 }
 
+// BasePluginManifest contains plugins to install by operator on Jenkins versions starting from MinJenkinsVersion.
+type BasePluginManifest struct {
+	MinJenkinsVersion string
+	Plugins           []Plugin
+	// Dependencies are the dependencies of plugins declared in the manifest, they're used with BasePluginDependencies
+	Dependencies Dependencies
+}
+
+// BasePluginManifests contains base plugin manifests sorted by MinJenkinsVersion.
+type BasePluginManifests []BasePluginManifest
+
+// basePluginManifests contains the manifests embedded in operator, one per range of Jenkins LTS lines
+var basePluginManifests = BasePluginManifests{
+	{MinJenkinsVersion: "0", Plugins: []Plugin{
+		Must(New("kubernetes:1.29.7")),
+		Must(New("workflow-job:2.41")),
+		Must(New("workflow-aggregator:2.6")),
+		Must(New("git:4.7.2")),
+		Must(New("job-dsl:1.77")),
+		Must(New("configuration-as-code:1.51")),
+		Must(New("kubernetes-credentials-provider:0.18-1")),
+	}},
+	{MinJenkinsVersion: "2.289", Plugins: basePluginsList},
+	{MinJenkinsVersion: "2.319", Plugins: []Plugin{
+		Must(New("kubernetes:1.31.3")),
+		Must(New("workflow-job:2.42")),
+		Must(New("workflow-aggregator:2.6")),
+		Must(New("git:4.10.2")),
+		Must(New("job-dsl:1.78.3")),
+		Must(New("configuration-as-code:1.55.1")),
+		Must(New("kubernetes-credentials-provider:0.20")),
+	}},
+}
+
+// jenkinsVersionTagPattern matches the Jenkins version at the beginning of the image tag, for example 2.303.2-lts-alpine
+var jenkinsVersionTagPattern = regexp.MustCompile(`^([0-9]+\.[0-9]+(\.[0-9]+)?)`)
+
 // BasePlugins returns list of plugins to install by operator.
 func BasePlugins() []Plugin {
 	return basePluginsList
 }
+
+// DefaultBasePluginManifests returns the base plugin manifests embedded in operator.
+func DefaultBasePluginManifests() BasePluginManifests {
+	return basePluginManifests
+}
+
+// ParseBasePluginManifests parses base plugin manifests from ConfigMap data, the key is MinJenkinsVersion
+// and the value contains plugins in "name:version" format, one per line. The plugin can be followed
+// by names of plugins required by it, for example "kubernetes:1.31.3 credentials durable-task".
+func ParseBasePluginManifests(data map[string]string) (BasePluginManifests, error) {
+	var manifests BasePluginManifests
+	for minJenkinsVersion, value := range data {
+		if !isJenkinsVersion(minJenkinsVersion) {
+			return nil, errors.Errorf("invalid Jenkins version '%s' of base plugin manifest", minJenkinsVersion)
+		}
+		manifest := BasePluginManifest{MinJenkinsVersion: minJenkinsVersion}
+		scanner := bufio.NewScanner(strings.NewReader(value))
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if len(line) == 0 || strings.HasPrefix(line, "#") {
+				continue
+			}
+			fields := strings.Fields(line)
+			plugin, err := New(fields[0])
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid base plugin manifest '%s'", minJenkinsVersion)
+			}
+			manifest.Plugins = append(manifest.Plugins, *plugin)
+			if len(fields) > 1 {
+				if manifest.Dependencies == nil {
+					manifest.Dependencies = Dependencies{}
+				}
+				manifest.Dependencies[plugin.Name] = fields[1:]
+			}
+		}
+		if len(manifest.Plugins) == 0 {
+			return nil, errors.Errorf("base plugin manifest '%s' is empty", minJenkinsVersion)
+		}
+		manifests = append(manifests, manifest)
+	}
+	manifests.sort()
+	return manifests, nil
+}
+
+// LoadBasePluginManifests returns the embedded base plugin manifests merged with the manifests from ConfigMap
+// in the namespace, the embedded manifests are returned when the name is empty or ConfigMap doesn't exist. Both
+// the reconciler and the webhook use it so they select the same manifest.
+func LoadBasePluginManifests(reader client.Reader, configMapName, namespace string) (BasePluginManifests, error) {
+	manifests := DefaultBasePluginManifests()
+	if reader == nil || len(configMapName) == 0 {
+		return manifests, nil
+	}
+
+	configMap := &corev1.ConfigMap{}
+	err := reader.Get(context.TODO(), types.NamespacedName{Name: configMapName, Namespace: namespace}, configMap)
+	if apierrors.IsNotFound(err) {
+		return manifests, nil
+	} else if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	overrides, err := ParseBasePluginManifests(configMap.Data)
+	if err != nil {
+		return nil, failure.ConfigurationInvalid(errors.Wrapf(err, "invalid ConfigMap '%s'", configMapName))
+	}
+	return manifests.Merge(overrides), nil
+}
+
+// Merge returns manifests with the overrides, the override replaces the manifest with the same MinJenkinsVersion.
+func (m BasePluginManifests) Merge(overrides BasePluginManifests) BasePluginManifests {
+	merged := BasePluginManifests{}
+	for _, manifest := range m {
+		if _, found := overrides.Get(manifest.MinJenkinsVersion); !found {
+			merged = append(merged, manifest)
+		}
+	}
+	merged = append(merged, overrides...)
+	merged.sort()
+	return merged
+}
+
+// Get returns the manifest with the MinJenkinsVersion.
+func (m BasePluginManifests) Get(minJenkinsVersion string) (BasePluginManifest, bool) {
+	for _, manifest := range m {
+		if manifest.MinJenkinsVersion == minJenkinsVersion {
+			return manifest, true
+		}
+	}
+	return BasePluginManifest{}, false
+}
+
+// ForImage returns the manifest for Jenkins version of the image, the latest manifest is returned when the image tag
+// doesn't contain the version, for example jenkins/jenkins:lts.
+func (m BasePluginManifests) ForImage(image string) BasePluginManifest {
+	if len(m) == 0 {
+		return BasePluginManifest{}
+	}
+	version := JenkinsVersionFromImage(image)
+	if len(version) == 0 {
+		return m[len(m)-1]
+	}
+	selected := m[0]
+	for _, manifest := range m {
+		if compareJenkinsVersions(manifest.MinJenkinsVersion, version) <= 0 {
+			selected = manifest
+		}
+	}
+	return selected
+}
+
+// Dependencies returns BasePluginDependencies with the dependencies declared in the manifests,
+// the dependencies declared for the plugin replace the embedded ones.
+func (m BasePluginManifests) Dependencies() Dependencies {
+	dependencies := Dependencies{}
+	for name, required := range basePluginDependencies {
+		dependencies[name] = required
+	}
+	for _, manifest := range m {
+		for name, required := range manifest.Dependencies {
+			dependencies[name] = required
+		}
+	}
+	return dependencies
+}
+
+func (m BasePluginManifests) sort() {
+	sort.SliceStable(m, func(i, j int) bool {
+		return compareJenkinsVersions(m[i].MinJenkinsVersion, m[j].MinJenkinsVersion) < 0
+	})
+}
+
+// JenkinsVersionFromImage returns Jenkins version from the image tag, it's empty when the tag doesn't start with the version.
+func JenkinsVersionFromImage(image string) string {
+	if index := strings.Index(image, "@"); index >= 0 {
+		image = image[:index]
+	}
+	// the colon before the last slash separates the registry port
+	index := strings.LastIndex(image, ":")
+	if index < 0 || index < strings.LastIndex(image, "/") {
+		return ""
+	}
+	return jenkinsVersionTagPattern.FindString(image[index+1:])
+}
+
+func isJenkinsVersion(version string) bool {
+	for _, part := range strings.Split(version, ".") {
+		if _, err := strconv.Atoi(part); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// compareJenkinsVersions compares numeric parts of the versions, missing parts are zeros
+func compareJenkinsVersions(a, b string) int {
+	aParts, bParts := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(aParts) || i < len(bParts); i++ {
+		var aPart, bPart int
+		if i < len(aParts) {
+			aPart, _ = strconv.Atoi(aParts[i])
+		}
+		if i < len(bParts) {
+			bPart, _ = strconv.Atoi(bParts[i])
+		}
+		if aPart != bPart {
+			if aPart < bPart {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,161 @@
+package plugins
+
+import (
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/failure"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestJenkinsVersionFromImage(t *testing.T) {
+	tests := map[string]string{
+		"jenkins/jenkins:2.303.2-lts-alpine":      "2.303.2",
+		"jenkins/jenkins:2.332":                   "2.332",
+		"registry:5000/jenkins/jenkins:2.319.1":   "2.319.1",
+		"jenkins/jenkins:2.319.1@sha256:abcdef01": "2.319.1",
+		"jenkins/jenkins:lts":                     "",
+		"jenkins/jenkins":                         "",
+		"registry:5000/jenkins/jenkins":           "",
+	}
+	for image, expected := range tests {
+		t.Run(image, func(t *testing.T) {
+			assert.Equal(t, expected, JenkinsVersionFromImage(image))
+		})
+	}
+}
+
+func TestBasePluginManifests_ForImage(t *testing.T) {
+	manifests := DefaultBasePluginManifests()
+
+	t.Run("default image", func(t *testing.T) {
+		assert.Equal(t, BasePlugins(), manifests.ForImage("jenkins/jenkins:2.303.2-lts-alpine").Plugins)
+	})
+	t.Run("first version of the range", func(t *testing.T) {
+		assert.Equal(t, "2.319", manifests.ForImage("jenkins/jenkins:2.319.1-lts").MinJenkinsVersion)
+	})
+	t.Run("last version of the range", func(t *testing.T) {
+		assert.Equal(t, "2.289", manifests.ForImage("jenkins/jenkins:2.318").MinJenkinsVersion)
+	})
+	t.Run("old version", func(t *testing.T) {
+		assert.Equal(t, "0", manifests.ForImage("jenkins/jenkins:2.263.4").MinJenkinsVersion)
+	})
+	t.Run("tag without version", func(t *testing.T) {
+		assert.Equal(t, "2.319", manifests.ForImage("jenkins/jenkins:lts").MinJenkinsVersion)
+	})
+}
+
+func TestParseBasePluginManifests(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		manifests, err := ParseBasePluginManifests(map[string]string{
+			"2.346": "# plugins for 2.346.x\nkubernetes:3600.v144b_cd192ca_a_\n\nworkflow-job:1189.va_d37a_e9e4eda_\n",
+			"2.289": "kubernetes:1.30.11",
+		})
+
+		require.NoError(t, err)
+		require.Len(t, manifests, 2)
+		assert.Equal(t, "2.289", manifests[0].MinJenkinsVersion)
+		assert.Equal(t, "2.346", manifests[1].MinJenkinsVersion)
+		assert.Equal(t, []Plugin{
+			{Name: "kubernetes", Version: "3600.v144b_cd192ca_a_"},
+			{Name: "workflow-job", Version: "1189.va_d37a_e9e4eda_"},
+		}, manifests[1].Plugins)
+	})
+	t.Run("with dependencies", func(t *testing.T) {
+		manifests, err := ParseBasePluginManifests(map[string]string{
+			"2.346": "kubernetes:3600.v144b_cd192ca_a_ credentials jackson2-api\nworkflow-job:1189.va_d37a_e9e4eda_\n",
+		})
+
+		require.NoError(t, err)
+		require.Len(t, manifests, 1)
+		assert.Equal(t, []Plugin{
+			{Name: "kubernetes", Version: "3600.v144b_cd192ca_a_"},
+			{Name: "workflow-job", Version: "1189.va_d37a_e9e4eda_"},
+		}, manifests[0].Plugins)
+		assert.Equal(t, Dependencies{"kubernetes": {"credentials", "jackson2-api"}}, manifests[0].Dependencies)
+	})
+	t.Run("invalid version", func(t *testing.T) {
+		_, err := ParseBasePluginManifests(map[string]string{"lts": "kubernetes:1.30.11"})
+
+		assert.EqualError(t, err, "invalid Jenkins version 'lts' of base plugin manifest")
+	})
+	t.Run("invalid plugin", func(t *testing.T) {
+		_, err := ParseBasePluginManifests(map[string]string{"2.346": "kubernetes"})
+
+		assert.EqualError(t, err, "invalid base plugin manifest '2.346': invalid plugin format 'kubernetes'")
+	})
+	t.Run("empty manifest", func(t *testing.T) {
+		_, err := ParseBasePluginManifests(map[string]string{"2.346": "# nothing"})
+
+		assert.EqualError(t, err, "base plugin manifest '2.346' is empty")
+	})
+}
+
+func TestBasePluginManifests_Merge(t *testing.T) {
+	override := BasePluginManifest{MinJenkinsVersion: "2.289", Plugins: []Plugin{Must(New("kubernetes:1.30.1"))}}
+	added := BasePluginManifest{MinJenkinsVersion: "2.346", Plugins: []Plugin{Must(New("kubernetes:3600.v144b_cd192ca_a_"))}}
+
+	merged := DefaultBasePluginManifests().Merge(BasePluginManifests{added, override})
+
+	require.Len(t, merged, 4)
+	assert.Equal(t, override, merged.ForImage("jenkins/jenkins:2.303.2"))
+	assert.Equal(t, added, merged.ForImage("jenkins/jenkins:lts"))
+	assert.Equal(t, "2.319", merged.ForImage("jenkins/jenkins:2.319.1").MinJenkinsVersion)
+	assert.Equal(t, basePluginsList, DefaultBasePluginManifests().ForImage("jenkins/jenkins:2.303.2").Plugins)
+}
+
+func TestBasePluginManifests_Dependencies(t *testing.T) {
+	manifests := DefaultBasePluginManifests().Merge(BasePluginManifests{{
+		MinJenkinsVersion: "2.346",
+		Plugins:           []Plugin{Must(New("kubernetes:3600.v144b_cd192ca_a_")), Must(New("pipeline-model-definition:2.2097.v33db_b_de764b_e"))},
+		Dependencies:      Dependencies{"kubernetes": {"jackson2-api"}, "pipeline-model-definition": {"workflow-cps"}},
+	}})
+
+	dependencies := manifests.Dependencies()
+
+	assert.Equal(t, []string{"jackson2-api"}, dependencies["kubernetes"])
+	assert.Equal(t, []string{"workflow-cps"}, dependencies["pipeline-model-definition"])
+	assert.Equal(t, BasePluginDependencies()["git"], dependencies["git"])
+	assert.Equal(t, BasePluginDependencies(), BasePluginManifests{}.Dependencies())
+}
+
+func TestLoadBasePluginManifests(t *testing.T) {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "base-plugins", Namespace: "default"},
+		Data:       map[string]string{"2.999": "kubernetes:9.0"},
+	}
+	fakeClient := fake.NewClientBuilder().WithObjects(configMap).Build()
+
+	t.Run("no ConfigMap name", func(t *testing.T) {
+		manifests, err := LoadBasePluginManifests(fakeClient, "", "default")
+
+		require.NoError(t, err)
+		assert.Equal(t, DefaultBasePluginManifests(), manifests)
+	})
+	t.Run("missing ConfigMap", func(t *testing.T) {
+		manifests, err := LoadBasePluginManifests(fakeClient, "base-plugins", "other")
+
+		require.NoError(t, err)
+		assert.Equal(t, DefaultBasePluginManifests(), manifests)
+	})
+	t.Run("override", func(t *testing.T) {
+		manifests, err := LoadBasePluginManifests(fakeClient, "base-plugins", "default")
+
+		require.NoError(t, err)
+		assert.Equal(t, []Plugin{{Name: "kubernetes", Version: "9.0"}}, manifests.ForImage("jenkins/jenkins:2.999.1").Plugins)
+	})
+	t.Run("invalid ConfigMap", func(t *testing.T) {
+		invalid := configMap.DeepCopy()
+		invalid.Namespace = "invalid"
+		invalid.Data = map[string]string{"lts": "kubernetes:9.0"}
+
+		_, err := LoadBasePluginManifests(fake.NewClientBuilder().WithObjects(invalid).Build(), "base-plugins", "invalid")
+
+		assert.Error(t, err)
+		assert.Equal(t, failure.CategoryConfigurationInvalid, failure.CategoryOf(err))
+	})
+}
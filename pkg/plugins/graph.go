@@ -0,0 +1,199 @@
+package plugins
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Requirement is the version of the plugin required by the root plugin, the root plugin requires also its own version.
+type Requirement struct {
+	Version    string `json:"version"`
+	RequiredBy Plugin `json:"requiredBy"`
+}
+
+// Conflict is the plugin required in different versions.
+type Conflict struct {
+	Name         string        `json:"name"`
+	Requirements []Requirement `json:"requirements"`
+}
+
+// Messages describes the conflict for every pair of requirements with different versions.
+func (c Conflict) Messages() []string {
+	var messages []string
+	for _, first := range c.Requirements {
+		for _, second := range c.Requirements {
+			if first.Version != second.Version {
+				messages = append(messages, fmt.Sprintf("Plugin '%s' requires version '%s' but plugin '%s' requires '%s' for plugin '%s'",
+					first.RequiredBy, first.Version, second.RequiredBy, second.Version, c.Name))
+			}
+		}
+	}
+	return messages
+}
+
+// Graph is the dependency graph of plugin sets, every plugin set maps root plugins to plugins required by them.
+type Graph struct {
+	// requirements - plugin name, value versions required by root plugins
+	requirements map[string][]Requirement
+	// dependencies - root plugin name, value names of plugins required by it
+	dependencies map[string]map[string]bool
+	downloadURLs map[string]string
+}
+
+// NewGraph creates the empty dependency graph.
+func NewGraph() *Graph {
+	return &Graph{
+		requirements: map[string][]Requirement{},
+		dependencies: map[string]map[string]bool{},
+		downloadURLs: map[string]string{},
+	}
+}
+
+// Add adds the plugin set to the graph.
+func (g *Graph) Add(plugins map[Plugin][]Plugin) *Graph {
+	for rootPlugin, dependencies := range plugins {
+		g.require(rootPlugin, rootPlugin)
+		if len(rootPlugin.DownloadURL) > 0 {
+			g.downloadURLs[rootPlugin.Name] = rootPlugin.DownloadURL
+		}
+		if g.dependencies[rootPlugin.Name] == nil {
+			g.dependencies[rootPlugin.Name] = map[string]bool{}
+		}
+		for _, dependency := range dependencies {
+			g.require(dependency, rootPlugin)
+			g.dependencies[rootPlugin.Name][dependency.Name] = true
+		}
+	}
+	return g
+}
+
+func (g *Graph) require(plugin, rootPlugin Plugin) {
+	requirement := Requirement{Version: plugin.Version, RequiredBy: Plugin{Name: rootPlugin.Name, Version: rootPlugin.Version}}
+	for _, existing := range g.requirements[plugin.Name] {
+		if existing == requirement {
+			return
+		}
+	}
+	g.requirements[plugin.Name] = append(g.requirements[plugin.Name], requirement)
+}
+
+// Conflicts returns plugins required in different versions sorted by plugin name.
+func (g *Graph) Conflicts() []Conflict {
+	var conflicts []Conflict
+	for _, name := range g.names() {
+		requirements := g.sortedRequirements(name)
+		for _, requirement := range requirements[1:] {
+			if requirement.Version != requirements[0].Version {
+				conflicts = append(conflicts, Conflict{Name: name, Requirements: requirements})
+				break
+			}
+		}
+	}
+	return conflicts
+}
+
+// Resolved returns all plugins of the graph sorted by name, the plugin required in different versions
+// is resolved to the highest version.
+func (g *Graph) Resolved() []Plugin {
+	var resolved []Plugin
+	for _, name := range g.names() {
+		plugin := Plugin{Name: name, DownloadURL: g.downloadURLs[name]}
+		for _, requirement := range g.requirements[name] {
+			if len(plugin.Version) == 0 || CompareVersions(requirement.Version, plugin.Version) > 0 {
+				plugin.Version = requirement.Version
+			}
+		}
+		resolved = append(resolved, plugin)
+	}
+	return resolved
+}
+
+// Dependencies returns names of plugins required by the root plugins, it can be used to sort plugins in the install order.
+func (g *Graph) Dependencies() Dependencies {
+	dependencies := Dependencies{}
+	for name, required := range g.dependencies {
+		for dependency := range required {
+			dependencies[name] = append(dependencies[name], dependency)
+		}
+		sort.Strings(dependencies[name])
+	}
+	return dependencies
+}
+
+// DOT exports the graph in the Graphviz DOT format, the conflicting plugins are red.
+func (g *Graph) DOT() string {
+	conflicting := map[string]bool{}
+	for _, conflict := range g.Conflicts() {
+		conflicting[conflict.Name] = true
+	}
+	dependencies := g.Dependencies()
+
+	builder := &strings.Builder{}
+	builder.WriteString("digraph plugins {\n")
+	for _, plugin := range g.Resolved() {
+		if conflicting[plugin.Name] {
+			fmt.Fprintf(builder, "  %q [label=%q, color=red];\n", plugin.Name, plugin.Name+":"+strings.Join(g.versions(plugin.Name), "|"))
+		} else {
+			fmt.Fprintf(builder, "  %q [label=%q];\n", plugin.Name, plugin.String())
+		}
+		for _, dependency := range dependencies[plugin.Name] {
+			fmt.Fprintf(builder, "  %q -> %q;\n", plugin.Name, dependency)
+		}
+	}
+	builder.WriteString("}\n")
+	return builder.String()
+}
+
+type graphNode struct {
+	Plugin
+	Dependencies []string `json:"dependencies,omitempty"`
+}
+
+type graphJSON struct {
+	Plugins   []graphNode `json:"plugins"`
+	Conflicts []Conflict  `json:"conflicts,omitempty"`
+}
+
+// MarshalJSON exports the resolved plugins with their dependencies and the conflicts.
+func (g *Graph) MarshalJSON() ([]byte, error) {
+	dependencies := g.Dependencies()
+	exported := graphJSON{Plugins: []graphNode{}, Conflicts: g.Conflicts()}
+	for _, plugin := range g.Resolved() {
+		exported.Plugins = append(exported.Plugins, graphNode{Plugin: plugin, Dependencies: dependencies[plugin.Name]})
+	}
+	return json.Marshal(exported)
+}
+
+func (g *Graph) names() []string {
+	names := make([]string, 0, len(g.requirements))
+	for name := range g.requirements {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (g *Graph) sortedRequirements(name string) []Requirement {
+	requirements := append([]Requirement{}, g.requirements[name]...)
+	sort.Slice(requirements, func(i, j int) bool {
+		if requirements[i].RequiredBy != requirements[j].RequiredBy {
+			return requirements[i].RequiredBy.String() < requirements[j].RequiredBy.String()
+		}
+		return requirements[i].Version < requirements[j].Version
+	})
+	return requirements
+}
+
+func (g *Graph) versions(name string) []string {
+	var versions []string
+	seen := map[string]bool{}
+	for _, requirement := range g.sortedRequirements(name) {
+		if !seen[requirement.Version] {
+			seen[requirement.Version] = true
+			versions = append(versions, requirement.Version)
+		}
+	}
+	return versions
+}
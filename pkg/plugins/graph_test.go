@@ -0,0 +1,72 @@
+package plugins
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraph(t *testing.T) {
+	graph := NewGraph().
+		Add(map[Plugin][]Plugin{
+			Must(New("git:4.10.0")):         {Must(New("credentials:2.6.1")), Must(New("scm-api:2.6.5"))},
+			Must(New("kubernetes:1.30.11")): {Must(New("credentials:2.6.2"))},
+		}).
+		Add(map[Plugin][]Plugin{
+			Must(New("scm-api:2.6.5")): {},
+		})
+
+	t.Run("conflicts", func(t *testing.T) {
+		assert.Equal(t, []Conflict{
+			{Name: "credentials", Requirements: []Requirement{
+				{Version: "2.6.1", RequiredBy: Must(New("git:4.10.0"))},
+				{Version: "2.6.2", RequiredBy: Must(New("kubernetes:1.30.11"))},
+			}},
+		}, graph.Conflicts())
+	})
+	t.Run("resolved", func(t *testing.T) {
+		assert.Equal(t, []Plugin{
+			Must(New("credentials:2.6.2")),
+			Must(New("git:4.10.0")),
+			Must(New("kubernetes:1.30.11")),
+			Must(New("scm-api:2.6.5")),
+		}, graph.Resolved())
+	})
+	t.Run("dependencies", func(t *testing.T) {
+		assert.Equal(t, Dependencies{
+			"git":        {"credentials", "scm-api"},
+			"kubernetes": {"credentials"},
+		}, graph.Dependencies())
+	})
+	t.Run("DOT", func(t *testing.T) {
+		assert.Equal(t, `digraph plugins {
+  "credentials" [label="credentials:2.6.1|2.6.2", color=red];
+  "git" [label="git:4.10.0"];
+  "git" -> "credentials";
+  "git" -> "scm-api";
+  "kubernetes" [label="kubernetes:1.30.11"];
+  "kubernetes" -> "credentials";
+  "scm-api" [label="scm-api:2.6.5"];
+}
+`, graph.DOT())
+	})
+	t.Run("JSON", func(t *testing.T) {
+		got, err := graph.MarshalJSON()
+
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{
+  "plugins": [
+    {"name": "credentials", "version": "2.6.2", "downloadURL": ""},
+    {"name": "git", "version": "4.10.0", "downloadURL": "", "dependencies": ["credentials", "scm-api"]},
+    {"name": "kubernetes", "version": "1.30.11", "downloadURL": "", "dependencies": ["credentials"]},
+    {"name": "scm-api", "version": "2.6.5", "downloadURL": ""}
+  ],
+  "conflicts": [
+    {"name": "credentials", "requirements": [
+      {"version": "2.6.1", "requiredBy": {"name": "git", "version": "4.10.0", "downloadURL": ""}},
+      {"version": "2.6.2", "requiredBy": {"name": "kubernetes", "version": "1.30.11", "downloadURL": ""}}
+    ]}
+  ]
+}`, string(got))
+	})
+}
@@ -0,0 +1,117 @@
+package plugins
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Dependencies maps the plugin name to names of plugins required by it.
+type Dependencies map[string][]string
+
+// basePluginDependencies contains the required dependencies of plugins installed by operator,
+// it's used to install the plugins overridden by user before the base plugins which require them.
+var basePluginDependencies = Dependencies{
+	"configuration-as-code":           {"snakeyaml-api"},
+	"git":                             {"credentials", "git-client", "mailer", "scm-api", "script-security", "ssh-credentials", "structs", "workflow-scm-step", "workflow-step-api"},
+	"job-dsl":                         {"script-security", "structs"},
+	"kubernetes":                      {"credentials", "durable-task", "kubernetes-client-api", "kubernetes-credentials", "workflow-step-api"},
+	"kubernetes-credentials-provider": {"credentials", "kubernetes-client-api"},
+	"workflow-aggregator":             {"workflow-basic-steps", "workflow-cps", "workflow-durable-task-step", "workflow-job"},
+	"workflow-job":                    {"workflow-api", "workflow-step-api", "workflow-support"},
+}
+
+// BasePluginDependencies returns the required dependencies of plugins installed by operator.
+func BasePluginDependencies() Dependencies {
+	return basePluginDependencies
+}
+
+// Batch merges plugin lists into one installation request, the plugin which is already in the request is skipped.
+func Batch(pluginLists ...[]Plugin) []Plugin {
+	var batch []Plugin
+	names := map[string]bool{}
+	for _, plugins := range pluginLists {
+		for _, plugin := range plugins {
+			if names[plugin.Name] {
+				continue
+			}
+			names[plugin.Name] = true
+			batch = append(batch, plugin)
+		}
+	}
+	return batch
+}
+
+// InstallOrder sorts plugins topologically, every plugin is placed after the plugins required by it directly
+// or through plugins which aren't in the list. Plugins without dependencies between them keep the given order.
+func InstallOrder(plugins []Plugin, dependencies Dependencies) ([]Plugin, error) {
+	index := map[string]int{}
+	for i, plugin := range plugins {
+		index[plugin.Name] = i
+	}
+
+	// requiredBy - index of the plugin, value indexes of plugins which require it
+	requiredBy := make([][]int, len(plugins))
+	inDegree := make([]int, len(plugins))
+	for i, plugin := range plugins {
+		for _, name := range requiredPlugins(plugin.Name, dependencies, index) {
+			j := index[name]
+			requiredBy[j] = append(requiredBy[j], i)
+			inDegree[i]++
+		}
+	}
+
+	var ready []int
+	for i := range plugins {
+		if inDegree[i] == 0 {
+			ready = append(ready, i)
+		}
+	}
+
+	ordered := make([]Plugin, 0, len(plugins))
+	for len(ready) > 0 {
+		sort.Ints(ready)
+		i := ready[0]
+		ready = ready[1:]
+		ordered = append(ordered, plugins[i])
+		for _, j := range requiredBy[i] {
+			inDegree[j]--
+			if inDegree[j] == 0 {
+				ready = append(ready, j)
+			}
+		}
+	}
+
+	if len(ordered) != len(plugins) {
+		var cycle []string
+		for i, plugin := range plugins {
+			if inDegree[i] > 0 {
+				cycle = append(cycle, plugin.Name)
+			}
+		}
+		return nil, errors.Errorf("plugins %s have circular dependencies", strings.Join(cycle, ", "))
+	}
+	return ordered, nil
+}
+
+// requiredPlugins returns names of plugins from the index which are required by the plugin directly or transitively
+func requiredPlugins(name string, dependencies Dependencies, index map[string]int) []string {
+	var required []string
+	visited := map[string]bool{name: true}
+	queue := append([]string{}, dependencies[name]...)
+	for len(queue) > 0 {
+		dependency := queue[0]
+		queue = queue[1:]
+		if visited[dependency] {
+			continue
+		}
+		visited[dependency] = true
+		if _, ok := index[dependency]; ok {
+			required = append(required, dependency)
+			continue
+		}
+		queue = append(queue, dependencies[dependency]...)
+	}
+	return required
+}
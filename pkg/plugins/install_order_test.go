@@ -0,0 +1,74 @@
+package plugins
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatch(t *testing.T) {
+	basePlugins := []Plugin{Must(New("kubernetes:1.30.11")), Must(New("git:4.10.0"))}
+	userPlugins := []Plugin{Must(New("git:4.10.0")), Must(New("credentials:2.6.1"))}
+
+	got := Batch(basePlugins, userPlugins)
+
+	assert.Equal(t, []Plugin{Must(New("kubernetes:1.30.11")), Must(New("git:4.10.0")), Must(New("credentials:2.6.1"))}, got)
+}
+
+func TestInstallOrder(t *testing.T) {
+	t.Run("no dependencies keeps the order", func(t *testing.T) {
+		plugins := []Plugin{Must(New("b:1.0")), Must(New("a:1.0")), Must(New("c:1.0"))}
+
+		got, err := InstallOrder(plugins, Dependencies{})
+
+		require.NoError(t, err)
+		assert.Equal(t, plugins, got)
+	})
+	t.Run("dependencies are installed first", func(t *testing.T) {
+		plugins := []Plugin{Must(New("workflow-aggregator:2.6")), Must(New("git:4.10.0")), Must(New("workflow-job:2.42")), Must(New("credentials:2.6.1"))}
+		dependencies := Dependencies{
+			"workflow-aggregator": {"workflow-job"},
+			"git":                 {"credentials"},
+		}
+
+		got, err := InstallOrder(plugins, dependencies)
+
+		require.NoError(t, err)
+		assert.Equal(t, []Plugin{Must(New("workflow-job:2.42")), Must(New("workflow-aggregator:2.6")), Must(New("credentials:2.6.1")), Must(New("git:4.10.0"))}, got)
+	})
+	t.Run("transitive dependency through plugin which isn't in the list", func(t *testing.T) {
+		plugins := []Plugin{Must(New("a:1.0")), Must(New("c:1.0"))}
+		dependencies := Dependencies{
+			"a": {"b"},
+			"b": {"c"},
+		}
+
+		got, err := InstallOrder(plugins, dependencies)
+
+		require.NoError(t, err)
+		assert.Equal(t, []Plugin{Must(New("c:1.0")), Must(New("a:1.0"))}, got)
+	})
+	t.Run("circular dependencies", func(t *testing.T) {
+		plugins := []Plugin{Must(New("a:1.0")), Must(New("b:1.0")), Must(New("c:1.0"))}
+		dependencies := Dependencies{
+			"a": {"b"},
+			"b": {"a"},
+		}
+
+		_, err := InstallOrder(plugins, dependencies)
+
+		assert.EqualError(t, err, "plugins a, b have circular dependencies")
+	})
+	t.Run("base plugins", func(t *testing.T) {
+		got, err := InstallOrder(Batch(BasePlugins(), []Plugin{Must(New("credentials:2.6.1"))}), BasePluginDependencies())
+
+		require.NoError(t, err)
+		var names []string
+		for _, plugin := range got {
+			names = append(names, plugin.Name)
+		}
+		assert.Equal(t, []string{"workflow-job", "workflow-aggregator", "job-dsl", "configuration-as-code", "credentials",
+			"kubernetes", "git", "kubernetes-credentials-provider"}, names)
+	})
+}
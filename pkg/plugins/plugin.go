@@ -3,6 +3,7 @@ package plugins
 import (
 	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -10,16 +11,23 @@ import (
 
 // Plugin represents jenkins plugin.
 type Plugin struct {
-	Name                     string `json:"name"`
-	Version                  string `json:"version"`
-	DownloadURL              string `json:"downloadURL"`
-	rootPluginNameAndVersion string
+	Name        string `json:"name"`
+	Version     string `json:"version"`
+	DownloadURL string `json:"downloadURL"`
 }
 
 func (p Plugin) String() string {
 	return fmt.Sprintf("%s:%s", p.Name, p.Version)
 }
 
+// InstallString returns the plugin as it's passed to the install script, "name:version" or "name:version:downloadURL"
+func (p Plugin) InstallString() string {
+	if len(p.DownloadURL) > 0 {
+		return fmt.Sprintf("%s:%s:%s", p.Name, p.Version, p.DownloadURL)
+	}
+	return p.String()
+}
+
 var (
 	// NamePattern is the plugin name regex pattern
 	NamePattern = regexp.MustCompile(`^[0-9a-zA-Z\-_]+$`)
@@ -85,46 +93,57 @@ func Must(plugin *Plugin, err error) Plugin {
 	return *plugin
 }
 
-// VerifyDependencies checks if all plugins have compatible versions.
+// VerifyDependencies checks if all plugins have compatible versions, it returns messages describing conflicts of the Graph.
 func VerifyDependencies(values ...map[Plugin][]Plugin) []string {
-	var messages []string
-	// key - plugin name, value array of versions
-	allPlugins := make(map[string][]Plugin)
-
+	graph := NewGraph()
 	for _, value := range values {
-		for rootPlugin, plugins := range value {
-			allPlugins[rootPlugin.Name] = append(allPlugins[rootPlugin.Name], Plugin{
-				Name:                     rootPlugin.Name,
-				Version:                  rootPlugin.Version,
-				rootPluginNameAndVersion: rootPlugin.String()})
-			for _, plugin := range plugins {
-				allPlugins[plugin.Name] = append(allPlugins[plugin.Name], Plugin{
-					Name:                     plugin.Name,
-					Version:                  plugin.Version,
-					rootPluginNameAndVersion: rootPlugin.String()})
-			}
-		}
+		graph.Add(value)
 	}
 
-	for pluginName, versions := range allPlugins {
-		if len(versions) == 1 {
-			continue
+	var messages []string
+	for _, conflict := range graph.Conflicts() {
+		messages = append(messages, conflict.Messages()...)
+	}
+	return messages
+}
+
+// IsRequired tells if the plugin with the name is one of the required plugins
+func IsRequired(name string, requiredPlugins []Plugin) bool {
+	for _, plugin := range requiredPlugins {
+		if plugin.Name == name {
+			return true
 		}
+	}
+	return false
+}
 
-		for _, firstVersion := range versions {
-			for _, secondVersion := range versions {
-				if firstVersion.Version != secondVersion.Version {
-					messages = append(messages, fmt.Sprintf("Plugin '%s' requires version '%s' but plugin '%s' requires '%s' for plugin '%s'",
-						firstVersion.rootPluginNameAndVersion,
-						firstVersion.Version,
-						secondVersion.rootPluginNameAndVersion,
-						secondVersion.Version,
-						pluginName,
-					))
-				}
+// CompareVersions compares plugin versions part by part, numeric parts are compared as numbers.
+// It returns -1 if a is lower than b, 1 if a is greater than b and 0 if they are equal.
+func CompareVersions(a, b string) int {
+	split := func(version string) []string {
+		return strings.FieldsFunc(version, func(r rune) bool { return r == '.' || r == '-' })
+	}
+	aParts, bParts := split(a), split(b)
+
+	for i := 0; i < len(aParts) && i < len(bParts); i++ {
+		aNumber, aErr := strconv.Atoi(aParts[i])
+		bNumber, bErr := strconv.Atoi(bParts[i])
+		switch {
+		case aErr == nil && bErr == nil && aNumber != bNumber:
+			if aNumber < bNumber {
+				return -1
 			}
+			return 1
+		case (aErr != nil || bErr != nil) && aParts[i] != bParts[i]:
+			return strings.Compare(aParts[i], bParts[i])
 		}
 	}
 
-	return messages
+	switch {
+	case len(aParts) < len(bParts):
+		return -1
+	case len(aParts) > len(bParts):
+		return 1
+	}
+	return 0
 }
@@ -183,3 +183,19 @@ func TestVerifyDependencies(t *testing.T) {
 		assert.Nil(t, got)
 	})
 }
+
+func TestCompareVersions(t *testing.T) {
+	assert.Equal(t, 0, CompareVersions("1.2.3", "1.2.3"))
+	assert.Equal(t, -1, CompareVersions("1.2.3", "1.10.0"))
+	assert.Equal(t, 1, CompareVersions("2.0", "1.99.9"))
+	assert.Equal(t, 1, CompareVersions("1.2.1", "1.2"))
+	assert.Equal(t, -1, CompareVersions("1.509.v0b_0d13b_8d17e", "1.510.vb_5f4e3c2a_1b3"))
+}
+
+func TestIsRequired(t *testing.T) {
+	requiredPlugins := []Plugin{{Name: "git", Version: "4.10.2"}, {Name: "role-strategy", Version: "3.2.0"}}
+
+	assert.True(t, IsRequired("role-strategy", requiredPlugins))
+	assert.False(t, IsRequired("kubernetes", requiredPlugins))
+	assert.False(t, IsRequired("git", nil))
+}
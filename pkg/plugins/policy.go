@@ -0,0 +1,80 @@
+package plugins
+
+import (
+	"fmt"
+	"path"
+)
+
+// Policy restricts plugins which can be installed, plugin names are matched by glob patterns.
+type Policy struct {
+	// Allow contains patterns of plugins which can be installed, all plugins are allowed when it's empty
+	Allow []string
+	// Deny contains patterns of banned plugins, it takes precedence over Allow
+	Deny []string
+	// MinimumVersions contains the lowest versions of plugins which can be installed
+	MinimumVersions []MinimumVersion
+}
+
+// MinimumVersion is the lowest version of plugins matching the name pattern.
+type MinimumVersion struct {
+	Name    string
+	Version string
+}
+
+// Validate checks if the policy has valid patterns and versions.
+func (p Policy) Validate() []string {
+	var messages []string
+	patterns := append(append([]string{}, p.Allow...), p.Deny...)
+	for _, minimumVersion := range p.MinimumVersions {
+		patterns = append(patterns, minimumVersion.Name)
+		if !VersionPattern.MatchString(minimumVersion.Version) {
+			messages = append(messages, fmt.Sprintf("Plugin policy minimum version '%s' of '%s' is invalid", minimumVersion.Version, minimumVersion.Name))
+		}
+	}
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil || len(pattern) == 0 {
+			messages = append(messages, fmt.Sprintf("Plugin policy pattern '%s' is invalid", pattern))
+		}
+	}
+	return messages
+}
+
+// Verify returns messages about plugins which violate the policy, plugins required by operator are always allowed
+// by the allow list but they can be still denied.
+func (p Policy) Verify(plugins []Plugin) []string {
+	var messages []string
+	for _, plugin := range plugins {
+		if pattern, ok := matchPattern(plugin.Name, p.Deny); ok {
+			messages = append(messages, fmt.Sprintf("Plugin '%s' is denied by plugin policy pattern '%s'", plugin, pattern))
+			continue
+		}
+		if _, ok := matchPattern(plugin.Name, p.Allow); len(p.Allow) > 0 && !ok && !isBasePlugin(plugin.Name) {
+			messages = append(messages, fmt.Sprintf("Plugin '%s' isn't allowed by plugin policy", plugin))
+			continue
+		}
+		for _, minimumVersion := range p.MinimumVersions {
+			if matched, _ := path.Match(minimumVersion.Name, plugin.Name); matched && CompareVersions(plugin.Version, minimumVersion.Version) < 0 {
+				messages = append(messages, fmt.Sprintf("Plugin '%s' is older than version '%s' required by plugin policy", plugin, minimumVersion.Version))
+			}
+		}
+	}
+	return messages
+}
+
+func matchPattern(name string, patterns []string) (string, bool) {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, name); matched {
+			return pattern, true
+		}
+	}
+	return "", false
+}
+
+func isBasePlugin(name string) bool {
+	for _, plugin := range BasePlugins() {
+		if plugin.Name == name {
+			return true
+		}
+	}
+	return false
+}
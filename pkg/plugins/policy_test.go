@@ -0,0 +1,51 @@
+package plugins
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolicy_Validate(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		policy := Policy{Allow: []string{"*"}, Deny: []string{"blueocean-*"}, MinimumVersions: []MinimumVersion{{Name: "git", Version: "4.8.3"}}}
+
+		assert.Empty(t, policy.Validate())
+	})
+	t.Run("invalid", func(t *testing.T) {
+		policy := Policy{Allow: []string{""}, Deny: []string{"[a-"}, MinimumVersions: []MinimumVersion{{Name: "git", Version: "4.8 3"}}}
+
+		assert.Equal(t, []string{
+			"Plugin policy minimum version '4.8 3' of 'git' is invalid",
+			"Plugin policy pattern '' is invalid",
+			"Plugin policy pattern '[a-' is invalid",
+		}, policy.Validate())
+	})
+}
+
+func TestPolicy_Verify(t *testing.T) {
+	t.Run("empty policy allows all plugins", func(t *testing.T) {
+		assert.Empty(t, Policy{}.Verify([]Plugin{Must(New("git:4.10.0")), Must(New("blueocean:1.24.8"))}))
+	})
+	t.Run("deny takes precedence over allow", func(t *testing.T) {
+		policy := Policy{Allow: []string{"*"}, Deny: []string{"blueocean*"}}
+
+		got := policy.Verify([]Plugin{Must(New("git:4.10.0")), Must(New("blueocean-pipeline-editor:1.24.8"))})
+
+		assert.Equal(t, []string{"Plugin 'blueocean-pipeline-editor:1.24.8' is denied by plugin policy pattern 'blueocean*'"}, got)
+	})
+	t.Run("plugins required by operator are allowed", func(t *testing.T) {
+		policy := Policy{Allow: []string{"git"}}
+
+		got := policy.Verify(append(append([]Plugin{}, BasePlugins()...), Must(New("ansicolor:1.0.0"))))
+
+		assert.Equal(t, []string{"Plugin 'ansicolor:1.0.0' isn't allowed by plugin policy"}, got)
+	})
+	t.Run("minimum versions", func(t *testing.T) {
+		policy := Policy{MinimumVersions: []MinimumVersion{{Name: "workflow-*", Version: "2.40"}}}
+
+		got := policy.Verify([]Plugin{Must(New("workflow-job:2.42")), Must(New("workflow-api:2.9")), Must(New("git:1.0"))})
+
+		assert.Equal(t, []string{"Plugin 'workflow-api:2.9' is older than version '2.40' required by plugin policy"}, got)
+	})
+}
@@ -0,0 +1,132 @@
+package plugins
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha512"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	signingKeyBits    = 2048
+	signatureKey      = "signature"
+	certificatePEM    = "CERTIFICATE"
+	rsaPrivateKeyPEM  = "RSA PRIVATE KEY"
+	serialNumberLimit = 128
+)
+
+// NewSigningCertificate generates RSA key and self-signed certificate used to sign update center JSON,
+// both are PEM encoded.
+func NewSigningCertificate(commonName string, validity time.Duration) (keyPEM, certPEM []byte, err error) {
+	key, err := rsa.GenerateKey(rand.Reader, signingKeyBits)
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), serialNumberLimit))
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:          serialNumber,
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(validity),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	certificate, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+
+	keyPEM = pem.EncodeToMemory(&pem.Block{Type: rsaPrivateKeyPEM, Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	certPEM = pem.EncodeToMemory(&pem.Block{Type: certificatePEM, Bytes: certificate})
+	return keyPEM, certPEM, nil
+}
+
+// SignUpdateCenter returns the update center JSON with the signature verified by Jenkins, the certificate
+// has to be trusted by Jenkins, e.g. placed in update-center-rootCAs directory of Jenkins home.
+func SignUpdateCenter(document map[string]interface{}, keyPEM, certPEM []byte) ([]byte, error) {
+	key, certificate, err := parseSigningCertificate(keyPEM, certPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	unsigned := make(map[string]interface{}, len(document))
+	for name, value := range document {
+		if name != signatureKey {
+			unsigned[name] = value
+		}
+	}
+	canonical, err := canonicalJSON(unsigned)
+	if err != nil {
+		return nil, err
+	}
+
+	digest := sha1.Sum(canonical)
+	digest512 := sha512.Sum512(canonical)
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA1, digest[:])
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	signature512, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA512, digest512[:])
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	unsigned[signatureKey] = map[string]interface{}{
+		"certificates":         []string{base64.StdEncoding.EncodeToString(certificate.Raw)},
+		"correct_digest":       base64.StdEncoding.EncodeToString(digest[:]),
+		"correct_digest512":    hex.EncodeToString(digest512[:]),
+		"correct_signature":    base64.StdEncoding.EncodeToString(signature),
+		"correct_signature512": hex.EncodeToString(signature512),
+	}
+	return canonicalJSON(unsigned)
+}
+
+func parseSigningCertificate(keyPEM, certPEM []byte) (*rsa.PrivateKey, *x509.Certificate, error) {
+	keyBlock, _ := pem.Decode(keyPEM)
+	if keyBlock == nil || keyBlock.Type != rsaPrivateKeyPEM {
+		return nil, nil, errors.New("signing key isn't PEM encoded RSA private key")
+	}
+	key, err := x509.ParsePKCS1PrivateKey(keyBlock.Bytes)
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+
+	certBlock, _ := pem.Decode(certPEM)
+	if certBlock == nil || certBlock.Type != certificatePEM {
+		return nil, nil, errors.New("signing certificate isn't PEM encoded certificate")
+	}
+	certificate, err := x509.ParseCertificate(certBlock.Bytes)
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+	return key, certificate, nil
+}
+
+// canonicalJSON writes the JSON without whitespaces and with sorted keys, the form digested by Jenkins
+func canonicalJSON(value interface{}) ([]byte, error) {
+	buffer := &bytes.Buffer{}
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return bytes.TrimSuffix(buffer.Bytes(), []byte("\n")), nil
+}
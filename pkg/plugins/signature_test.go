@@ -0,0 +1,48 @@
+package plugins
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignUpdateCenter(t *testing.T) {
+	keyPEM, certPEM, err := NewSigningCertificate("test", time.Hour)
+	require.NoError(t, err)
+	document := map[string]interface{}{
+		"id":        "default",
+		"plugins":   map[string]interface{}{"git": map[string]interface{}{"name": "git", "version": "4.10.0", "url": "https://example.com/git.hpi?a=1&b=2"}},
+		"signature": map[string]interface{}{"correct_digest": "upstream"},
+	}
+
+	signed, err := SignUpdateCenter(document, keyPEM, certPEM)
+
+	require.NoError(t, err)
+	assert.Contains(t, string(signed), `"url":"https://example.com/git.hpi?a=1&b=2"`)
+	updateCenter := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(signed, &updateCenter))
+	signature := updateCenter["signature"].(map[string]interface{})
+	delete(updateCenter, "signature")
+	canonical, err := canonicalJSON(updateCenter)
+	require.NoError(t, err)
+	digest := sha512.Sum512(canonical)
+	assert.Equal(t, hex.EncodeToString(digest[:]), signature["correct_digest512"])
+
+	rawCertificate, err := base64.StdEncoding.DecodeString(signature["certificates"].([]interface{})[0].(string))
+	require.NoError(t, err)
+	certificate, err := x509.ParseCertificate(rawCertificate)
+	require.NoError(t, err)
+	signature512, err := hex.DecodeString(signature["correct_signature512"].(string))
+	require.NoError(t, err)
+	assert.NoError(t, rsa.VerifyPKCS1v15(certificate.PublicKey.(*rsa.PublicKey), crypto.SHA512, digest[:], signature512))
+	assert.NoError(t, certificate.CheckSignatureFrom(certificate))
+}
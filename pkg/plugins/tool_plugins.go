@@ -0,0 +1,13 @@
+package plugins
+
+const (
+	adoptOpenJDKPlugin = "adoptopenjdk:1.4"
+	nodeJSPlugin       = "nodejs:1.5.1"
+)
+
+var (
+	// AdoptOpenJDKPlugin installs JDK tools from AdoptOpenJDK releases, it's required by spec.tools.jdk installations
+	AdoptOpenJDKPlugin = Must(New(adoptOpenJDKPlugin))
+	// NodeJSPlugin provides NodeJS tools, it's required by spec.tools.nodejs installations
+	NodeJSPlugin = Must(New(nodeJSPlugin))
+)
@@ -0,0 +1,175 @@
+package plugins
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/tracing"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	updateCenterTimeout = 1 * time.Minute
+	pluginWarningType   = "plugin"
+	jsonpPrefix         = "updateCenter.post("
+)
+
+// UpdateCenter represents the update center JSON with the latest plugin versions and security warnings.
+type UpdateCenter struct {
+	Plugins  map[string]UpdateCenterPlugin `json:"plugins"`
+	Warnings []UpdateCenterWarning         `json:"warnings"`
+}
+
+// UpdateCenterPlugin represents the latest version of the plugin in the update center.
+type UpdateCenterPlugin struct {
+	Name         string `json:"name"`
+	Version      string `json:"version"`
+	RequiredCore string `json:"requiredCore"`
+	// Dependencies are the plugins required by the latest version
+	Dependencies []UpdateCenterDependency `json:"dependencies,omitempty"`
+}
+
+// UpdateCenterDependency represents the plugin required by the latest version of another plugin.
+type UpdateCenterDependency struct {
+	Name     string `json:"name"`
+	Version  string `json:"version"`
+	Optional bool   `json:"optional"`
+}
+
+// UpdateCenterWarning represents the security warning published in the update center.
+type UpdateCenterWarning struct {
+	ID       string                       `json:"id"`
+	Type     string                       `json:"type"`
+	Name     string                       `json:"name"`
+	Message  string                       `json:"message"`
+	URL      string                       `json:"url"`
+	Versions []UpdateCenterWarningVersion `json:"versions"`
+}
+
+// UpdateCenterWarningVersion represents versions affected by the security warning.
+type UpdateCenterWarningVersion struct {
+	LastVersion string `json:"lastVersion"`
+	Pattern     string `json:"pattern"`
+}
+
+// FetchUpdateCenter downloads the update center JSON from the url.
+func FetchUpdateCenter(ctx context.Context, url string) (*UpdateCenter, error) {
+	updateCenter := &UpdateCenter{}
+	if err := fetchUpdateCenter(ctx, url, updateCenter); err != nil {
+		return nil, err
+	}
+	return updateCenter, nil
+}
+
+// FetchUpdateCenterDocument downloads the update center JSON from the url with all its fields,
+// numbers are kept as json.Number so the document can be written back without changes.
+func FetchUpdateCenterDocument(ctx context.Context, url string) (map[string]interface{}, error) {
+	document := map[string]interface{}{}
+	if err := fetchUpdateCenter(ctx, url, &document); err != nil {
+		return nil, err
+	}
+	return document, nil
+}
+
+// ParseUpdateCenter decodes the update center JSON cached in a file, the JSONP form of update-center.json wrapped in
+// updateCenter.post(...) is accepted as well.
+func ParseUpdateCenter(data []byte) (*UpdateCenter, error) {
+	document := strings.TrimSpace(string(data))
+	if strings.HasPrefix(document, jsonpPrefix) {
+		document = strings.TrimSuffix(strings.TrimSuffix(strings.TrimPrefix(document, jsonpPrefix), ";"), ")")
+	}
+	updateCenter := &UpdateCenter{}
+	if err := json.Unmarshal([]byte(document), updateCenter); err != nil {
+		return nil, errors.Wrap(err, "failed to decode update center")
+	}
+	return updateCenter, nil
+}
+
+func fetchUpdateCenter(ctx context.Context, url string, value interface{}) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	client := http.Client{Timeout: updateCenterTimeout, Transport: tracing.NewTransport(ctx, nil)}
+	response, err := client.Do(request)
+	if err != nil {
+		return errors.Wrapf(err, "failed to download update center '%s'", url)
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	if response.StatusCode != http.StatusOK {
+		return errors.Errorf("failed to download update center '%s', status code '%d'", url, response.StatusCode)
+	}
+
+	decoder := json.NewDecoder(response.Body)
+	decoder.UseNumber()
+	if err := decoder.Decode(value); err != nil {
+		return errors.Wrapf(err, "failed to decode update center '%s'", url)
+	}
+	return nil
+}
+
+// IsAffectedBySecurityWarning returns true if the plugin version is affected by any security warning.
+func (u *UpdateCenter) IsAffectedBySecurityWarning(name, version string) bool {
+	for _, warning := range u.Warnings {
+		if warning.Type != pluginWarningType || warning.Name != name {
+			continue
+		}
+		for _, affected := range warning.Versions {
+			pattern, err := regexp.Compile(fmt.Sprintf("^(?:%s)$", affected.Pattern))
+			if err != nil {
+				continue
+			}
+			if pattern.MatchString(version) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Verify returns the messages about the plugins which don't exist in the update center or whose versions are newer than
+// the latest ones, and about the plugins declared in older versions than required by the latest versions of other plugins.
+// The missing dependencies aren't reported, they're installed with the plugins.
+// The plugins with the custom download URL aren't verified.
+func (u *UpdateCenter) Verify(plugins []Plugin) []string {
+	var messages []string
+	versions := map[string]string{}
+	for _, plugin := range plugins {
+		versions[plugin.Name] = plugin.Version
+	}
+	for _, plugin := range plugins {
+		if len(plugin.DownloadURL) > 0 {
+			continue
+		}
+		latest, found := u.Plugins[plugin.Name]
+		if !found {
+			messages = append(messages, fmt.Sprintf("Plugin '%s' doesn't exist in the update center", plugin.Name))
+			continue
+		}
+		if CompareVersions(plugin.Version, latest.Version) > 0 {
+			messages = append(messages, fmt.Sprintf("Plugin '%s' version '%s' is newer than the latest version '%s' in the update center", plugin.Name, plugin.Version, latest.Version))
+			continue
+		}
+		// the dependencies of older versions aren't published in the update center
+		if plugin.Version != latest.Version {
+			continue
+		}
+		for _, dependency := range latest.Dependencies {
+			if dependency.Optional {
+				continue
+			}
+			if version, found := versions[dependency.Name]; found && CompareVersions(version, dependency.Version) < 0 {
+				messages = append(messages, fmt.Sprintf("Plugin '%s' requires plugin '%s' in version '%s' or newer, '%s' is installed", plugin, dependency.Name, dependency.Version, version))
+			}
+		}
+	}
+	return messages
+}
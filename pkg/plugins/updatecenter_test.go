@@ -0,0 +1,142 @@
+package plugins
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const updateCenterJSON = `{
+  "plugins": {
+    "git": {"name": "git", "version": "4.10.2", "requiredCore": "2.263.1"}
+  },
+  "warnings": [
+    {
+      "id": "SECURITY-2478",
+      "type": "plugin",
+      "name": "git",
+      "message": "Missing permission check",
+      "url": "https://jenkins.io/security/advisory/2021-10-06/",
+      "versions": [{"lastVersion": "4.10.0", "pattern": "4[.]([0-9]|10[.]0)(|[.-].*)"}]
+    },
+    {
+      "id": "SECURITY-1",
+      "type": "core",
+      "name": "core",
+      "versions": [{"lastVersion": "2.300", "pattern": ".*"}]
+    }
+  ]
+}`
+
+func TestFetchUpdateCenter(t *testing.T) {
+	t.Run("happy", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(updateCenterJSON))
+		}))
+		defer server.Close()
+
+		updateCenter, err := FetchUpdateCenter(context.TODO(), server.URL)
+
+		require.NoError(t, err)
+		assert.Equal(t, UpdateCenterPlugin{Name: "git", Version: "4.10.2", RequiredCore: "2.263.1"}, updateCenter.Plugins["git"])
+		assert.Len(t, updateCenter.Warnings, 2)
+	})
+	t.Run("not found", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		_, err := FetchUpdateCenter(context.TODO(), server.URL)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestUpdateCenter_IsAffectedBySecurityWarning(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(updateCenterJSON))
+	}))
+	defer server.Close()
+	updateCenter, err := FetchUpdateCenter(context.TODO(), server.URL)
+	require.NoError(t, err)
+
+	assert.True(t, updateCenter.IsAffectedBySecurityWarning("git", "4.10.0"))
+	assert.True(t, updateCenter.IsAffectedBySecurityWarning("git", "4.9.1"))
+	assert.False(t, updateCenter.IsAffectedBySecurityWarning("git", "4.10.2"))
+	assert.False(t, updateCenter.IsAffectedBySecurityWarning("git", "4.100"))
+	assert.False(t, updateCenter.IsAffectedBySecurityWarning("core", "2.200"))
+}
+
+func TestParseUpdateCenter(t *testing.T) {
+	t.Run("JSON", func(t *testing.T) {
+		updateCenter, err := ParseUpdateCenter([]byte(updateCenterJSON))
+
+		require.NoError(t, err)
+		assert.Equal(t, "4.10.2", updateCenter.Plugins["git"].Version)
+	})
+	t.Run("JSONP", func(t *testing.T) {
+		updateCenter, err := ParseUpdateCenter([]byte("updateCenter.post(\n" + updateCenterJSON + "\n);\n"))
+
+		require.NoError(t, err)
+		assert.Equal(t, "4.10.2", updateCenter.Plugins["git"].Version)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, err := ParseUpdateCenter([]byte("<html></html>"))
+
+		assert.Error(t, err)
+	})
+}
+
+func TestUpdateCenter_Verify(t *testing.T) {
+	updateCenter := &UpdateCenter{Plugins: map[string]UpdateCenterPlugin{
+		"git": {Name: "git", Version: "4.10.2", Dependencies: []UpdateCenterDependency{
+			{Name: "git-client", Version: "3.10.0"},
+			{Name: "scm-api", Version: "2.6.5"},
+			{Name: "credentials-binding", Version: "1.27", Optional: true},
+		}},
+		"git-client": {Name: "git-client", Version: "3.10.1"},
+		"scm-api":    {Name: "scm-api", Version: "2.6.5"},
+	}}
+
+	t.Run("valid", func(t *testing.T) {
+		messages := updateCenter.Verify([]Plugin{
+			{Name: "git", Version: "4.10.2"},
+			{Name: "git-client", Version: "3.10.0"},
+			{Name: "scm-api", Version: "2.6.5"},
+		})
+
+		assert.Empty(t, messages)
+	})
+	t.Run("unknown plugin and newer version", func(t *testing.T) {
+		messages := updateCenter.Verify([]Plugin{
+			{Name: "unknown", Version: "1.0"},
+			{Name: "scm-api", Version: "2.7.0"},
+			{Name: "custom", Version: "1.0", DownloadURL: "https://example.com/custom.hpi"},
+		})
+
+		assert.Equal(t, []string{
+			"Plugin 'unknown' doesn't exist in the update center",
+			"Plugin 'scm-api' version '2.7.0' is newer than the latest version '2.6.5' in the update center",
+		}, messages)
+	})
+	t.Run("older dependencies", func(t *testing.T) {
+		messages := updateCenter.Verify([]Plugin{
+			{Name: "git", Version: "4.10.2"},
+			{Name: "git-client", Version: "3.9.0"},
+		})
+
+		assert.Equal(t, []string{
+			"Plugin 'git:4.10.2' requires plugin 'git-client' in version '3.10.0' or newer, '3.9.0' is installed",
+		}, messages)
+	})
+	t.Run("older version isn't verified against dependencies of the latest", func(t *testing.T) {
+		messages := updateCenter.Verify([]Plugin{{Name: "git", Version: "4.9.0"}})
+
+		assert.Empty(t, messages)
+	})
+}
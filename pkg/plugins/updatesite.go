@@ -0,0 +1,69 @@
+package plugins
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultUpdateSiteName is the name of the update center plugins are downloaded from by default
+	DefaultUpdateSiteName = "default"
+	// ExperimentalUpdateSiteName is the name of the experimental update center
+	ExperimentalUpdateSiteName = "experimental"
+
+	latestVersion = "latest"
+)
+
+// UpdateSite is the update center plugins are downloaded from.
+type UpdateSite struct {
+	Name        string
+	URL         string
+	DownloadURL string
+}
+
+// DefaultUpdateSites returns the default and the experimental update centers of Jenkins project
+func DefaultUpdateSites() []UpdateSite {
+	return []UpdateSite{
+		{Name: DefaultUpdateSiteName, URL: "https://updates.jenkins.io", DownloadURL: "https://updates.jenkins.io/download"},
+		{Name: ExperimentalUpdateSiteName, URL: "https://updates.jenkins.io/experimental", DownloadURL: "https://updates.jenkins.io/download"},
+	}
+}
+
+// PluginDownloadURL returns the URL of the plugin version in the update site, the latest version is resolved
+// by the update site
+func (s UpdateSite) PluginDownloadURL(name, version string) string {
+	if len(version) == 0 || version == latestVersion {
+		return fmt.Sprintf("%s/latest/%s.hpi", strings.TrimSuffix(s.URL, "/"), name)
+	}
+	downloadURL := s.DownloadURL
+	if len(downloadURL) == 0 {
+		downloadURL = strings.TrimSuffix(s.URL, "/") + "/download"
+	}
+	return fmt.Sprintf("%s/plugins/%s/%s/%s.hpi", strings.TrimSuffix(downloadURL, "/"), name, version, name)
+}
+
+// ResolveDownloadURLs sets the download URL of plugins installed from update sites other than the default one,
+// sitesByPlugin maps plugin names to update site names. The plugins with download URL set and the plugins
+// of the default update site are kept, the install script downloads them from the update center of Jenkins image.
+func ResolveDownloadURLs(installPlugins []Plugin, sites []UpdateSite, sitesByPlugin map[string]string) ([]Plugin, error) {
+	sitesByName := map[string]UpdateSite{}
+	for _, site := range append(DefaultUpdateSites(), sites...) {
+		sitesByName[site.Name] = site
+	}
+
+	var resolved []Plugin
+	for _, plugin := range installPlugins {
+		siteName := sitesByPlugin[plugin.Name]
+		if len(plugin.DownloadURL) == 0 && len(siteName) > 0 && siteName != DefaultUpdateSiteName {
+			site, found := sitesByName[siteName]
+			if !found {
+				return nil, errors.Errorf("update site '%s' of plugin '%s' not found", siteName, plugin.Name)
+			}
+			plugin.DownloadURL = site.PluginDownloadURL(plugin.Name, plugin.Version)
+		}
+		resolved = append(resolved, plugin)
+	}
+	return resolved, nil
+}
@@ -0,0 +1,52 @@
+package plugins
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateSite_PluginDownloadURL(t *testing.T) {
+	site := UpdateSite{Name: "internal", URL: "https://updates.example.com/"}
+
+	assert.Equal(t, "https://updates.example.com/latest/git.hpi", site.PluginDownloadURL("git", "latest"))
+	assert.Equal(t, "https://updates.example.com/download/plugins/git/4.8/git.hpi", site.PluginDownloadURL("git", "4.8"))
+
+	site.DownloadURL = "https://mirror.example.com/jenkins"
+	assert.Equal(t, "https://mirror.example.com/jenkins/plugins/git/4.8/git.hpi", site.PluginDownloadURL("git", "4.8"))
+}
+
+func TestResolveDownloadURLs(t *testing.T) {
+	installPlugins := []Plugin{
+		{Name: "git", Version: "4.8"},
+		{Name: "docker", Version: "1.2"},
+		{Name: "kubernetes", Version: "latest"},
+		{Name: "custom", Version: "1.0", DownloadURL: "https://example.com/custom.hpi"},
+	}
+	sites := []UpdateSite{{Name: "internal", URL: "https://updates.example.com"}}
+
+	t.Run("mixed update sites", func(t *testing.T) {
+		resolved, err := ResolveDownloadURLs(installPlugins, sites, map[string]string{
+			"docker":     "internal",
+			"kubernetes": ExperimentalUpdateSiteName,
+			"git":        DefaultUpdateSiteName,
+			"custom":     "internal",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []Plugin{
+			{Name: "git", Version: "4.8"},
+			{Name: "docker", Version: "1.2", DownloadURL: "https://updates.example.com/download/plugins/docker/1.2/docker.hpi"},
+			{Name: "kubernetes", Version: "latest", DownloadURL: "https://updates.jenkins.io/experimental/latest/kubernetes.hpi"},
+			{Name: "custom", Version: "1.0", DownloadURL: "https://example.com/custom.hpi"},
+		}, resolved)
+		assert.Equal(t, "docker:1.2:https://updates.example.com/download/plugins/docker/1.2/docker.hpi", resolved[1].InstallString())
+		assert.Equal(t, "git:4.8", resolved[0].InstallString())
+	})
+	t.Run("unknown update site", func(t *testing.T) {
+		_, err := ResolveDownloadURLs(installPlugins, sites, map[string]string{"docker": "missing"})
+
+		assert.EqualError(t, err, "update site 'missing' of plugin 'docker' not found")
+	})
+}
@@ -0,0 +1,85 @@
+package probes
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/client-go/discovery"
+)
+
+const (
+	// WebhookCertificateFileName is the name of the webhook server certificate in the certificate directory
+	WebhookCertificateFileName = "tls.crt"
+	// DefaultCacheSyncTimeout is the time the readiness check waits for informer caches
+	DefaultCacheSyncTimeout = 5 * time.Second
+)
+
+// APIServerChecker returns the check which fails when the operator can't get the Kubernetes API server version.
+func APIServerChecker(client discovery.ServerVersionInterface) func(*http.Request) error {
+	return func(*http.Request) error {
+		if _, err := client.ServerVersion(); err != nil {
+			return errors.Wrap(err, "Kubernetes API server is unreachable")
+		}
+		return nil
+	}
+}
+
+// CacheSyncer waits for informer caches, it's implemented by the manager cache.
+type CacheSyncer interface {
+	WaitForCacheSync(ctx context.Context) bool
+}
+
+// CacheSyncChecker returns the check which fails when informer caches haven't been synced within the timeout.
+func CacheSyncChecker(informers CacheSyncer, timeout time.Duration) func(*http.Request) error {
+	return func(req *http.Request) error {
+		ctx, cancel := context.WithTimeout(req.Context(), timeout)
+		defer cancel()
+		if !informers.WaitForCacheSync(ctx) {
+			return errors.New("informer caches aren't synced")
+		}
+		return nil
+	}
+}
+
+// WebhookCertificateChecker returns the check which fails when the webhook server certificate
+// in the directory can't be read, isn't valid yet or has expired. The empty directory is the default one of the webhook server.
+func WebhookCertificateChecker(certDir string) func(*http.Request) error {
+	if len(certDir) == 0 {
+		certDir = filepath.Join(os.TempDir(), "k8s-webhook-server", "serving-certs")
+	}
+	return func(*http.Request) error {
+		_, err := WebhookCertificateExpiration(certDir, time.Now())
+		return err
+	}
+}
+
+// WebhookCertificateExpiration returns the expiration time of the webhook server certificate valid at the time.
+func WebhookCertificateExpiration(certDir string, now time.Time) (time.Time, error) {
+	path := filepath.Join(certDir, WebhookCertificateFileName)
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, "webhook certificate can't be read")
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return time.Time{}, errors.Errorf("webhook certificate '%s' isn't PEM encoded", path)
+	}
+	certificate, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return time.Time{}, errors.Wrapf(err, "webhook certificate '%s' can't be parsed", path)
+	}
+	if now.Before(certificate.NotBefore) {
+		return certificate.NotAfter, errors.Errorf("webhook certificate '%s' isn't valid before %s", path, certificate.NotBefore.Format(time.RFC3339))
+	}
+	if now.After(certificate.NotAfter) {
+		return certificate.NotAfter, errors.Errorf("webhook certificate '%s' expired at %s", path, certificate.NotAfter.Format(time.RFC3339))
+	}
+	return certificate.NotAfter, nil
+}
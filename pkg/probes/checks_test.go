@@ -0,0 +1,136 @@
+package probes
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/version"
+)
+
+type fakeServerVersion struct {
+	err error
+}
+
+func (f fakeServerVersion) ServerVersion() (*version.Info, error) {
+	return &version.Info{}, f.err
+}
+
+type fakeInformers struct {
+	synced bool
+}
+
+func (f fakeInformers) WaitForCacheSync(context.Context) bool {
+	return f.synced
+}
+
+func writeCertificate(t *testing.T, dir string, notBefore, notAfter time.Time) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "webhook"},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+	}
+	certificate, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	data := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate})
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, WebhookCertificateFileName), data, 0600))
+}
+
+func TestAPIServerChecker(t *testing.T) {
+	t.Run("reachable", func(t *testing.T) {
+		assert.NoError(t, APIServerChecker(fakeServerVersion{})(nil))
+	})
+	t.Run("unreachable", func(t *testing.T) {
+		err := APIServerChecker(fakeServerVersion{err: errors.New("connection refused")})(nil)
+
+		require.Error(t, err)
+		assert.Equal(t, "Kubernetes API server is unreachable: connection refused", err.Error())
+	})
+}
+
+func TestCacheSyncChecker(t *testing.T) {
+	t.Run("synced", func(t *testing.T) {
+		assert.NoError(t, CacheSyncChecker(fakeInformers{synced: true}, time.Second)(httptest.NewRequest(http.MethodGet, "/readyz", nil)))
+	})
+	t.Run("not synced", func(t *testing.T) {
+		assert.EqualError(t, CacheSyncChecker(fakeInformers{}, time.Second)(httptest.NewRequest(http.MethodGet, "/readyz", nil)), "informer caches aren't synced")
+	})
+}
+
+func TestWebhookCertificateExpiration(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+
+	t.Run("valid", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "webhook")
+		require.NoError(t, err)
+		defer func() { _ = os.RemoveAll(dir) }()
+		writeCertificate(t, dir, now.Add(-time.Hour), now.Add(time.Hour))
+
+		expiration, err := WebhookCertificateExpiration(dir, now)
+
+		require.NoError(t, err)
+		assert.True(t, expiration.Equal(now.Add(time.Hour)))
+		assert.NoError(t, WebhookCertificateChecker(dir)(nil))
+	})
+	t.Run("expired", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "webhook")
+		require.NoError(t, err)
+		defer func() { _ = os.RemoveAll(dir) }()
+		writeCertificate(t, dir, now.Add(-2*time.Hour), now.Add(-time.Hour))
+
+		_, err = WebhookCertificateExpiration(dir, now)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "expired at")
+	})
+	t.Run("not valid yet", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "webhook")
+		require.NoError(t, err)
+		defer func() { _ = os.RemoveAll(dir) }()
+		writeCertificate(t, dir, now.Add(time.Hour), now.Add(2*time.Hour))
+
+		_, err = WebhookCertificateExpiration(dir, now)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "isn't valid before")
+	})
+	t.Run("missing", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "webhook")
+		require.NoError(t, err)
+		defer func() { _ = os.RemoveAll(dir) }()
+
+		_, err = WebhookCertificateExpiration(dir, now)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "webhook certificate can't be read")
+	})
+	t.Run("not PEM encoded", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "webhook")
+		require.NoError(t, err)
+		defer func() { _ = os.RemoveAll(dir) }()
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, WebhookCertificateFileName), []byte("certificate"), 0600))
+
+		_, err = WebhookCertificateExpiration(dir, now)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "isn't PEM encoded")
+	})
+}
@@ -0,0 +1,154 @@
+package probes
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultHungReconcileTimeout is the time after which the running reconcile loop is considered hung
+const DefaultHungReconcileTimeout = 15 * time.Minute
+
+// ControllerStatus describes reconcile loops of the controller.
+type ControllerStatus struct {
+	Name              string     `json:"name"`
+	Reconciles        uint64     `json:"reconciles"`
+	Errors            uint64     `json:"errors"`
+	Running           int        `json:"running"`
+	LastReconcileTime *time.Time `json:"lastReconcileTime,omitempty"`
+	LastError         string     `json:"lastError,omitempty"`
+	LastErrorTime     *time.Time `json:"lastErrorTime,omitempty"`
+	// OldestRunningSince is the start time of the longest running reconcile loop
+	OldestRunningSince *time.Time `json:"oldestRunningSince,omitempty"`
+}
+
+type controller struct {
+	status  ControllerStatus
+	running map[uint64]time.Time
+}
+
+// Controllers tracks reconcile loops of controllers, the controller is live when none of its reconcile loops is hung.
+type Controllers struct {
+	mutex       sync.Mutex
+	controllers map[string]*controller
+	sequence    uint64
+	now         func() time.Time
+	// longRunning - operation name, value number of the operations in progress
+	longRunning map[string]int
+}
+
+// Reconciles tracks reconcile loops of the operator controllers
+var Reconciles = NewControllers()
+
+// NewControllers creates the tracker of controller reconcile loops.
+func NewControllers() *Controllers {
+	return &Controllers{controllers: map[string]*controller{}, now: time.Now, longRunning: map[string]int{}}
+}
+
+// Track starts tracking the reconcile loop of the controller, the returned function has to be called
+// with the reconcile error when the loop ends.
+func (c *Controllers) Track(name string) func(err error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	tracked := c.get(name)
+	c.sequence++
+	id := c.sequence
+	tracked.running[id] = c.now()
+	return func(err error) {
+		c.mutex.Lock()
+		defer c.mutex.Unlock()
+		now := c.now()
+		delete(tracked.running, id)
+		tracked.status.Reconciles++
+		tracked.status.LastReconcileTime = &now
+		if err != nil {
+			tracked.status.Errors++
+			tracked.status.LastError = err.Error()
+			tracked.status.LastErrorTime = &now
+		}
+	}
+}
+
+// LongRunning marks the start of the operation which is expected to run longer than the hung reconcile timeout,
+// for example the backup or the restore. Reconcile loops aren't reported as hung until the returned function is called.
+func (c *Controllers) LongRunning(operation string) func() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.longRunning[operation]++
+	return func() {
+		c.mutex.Lock()
+		defer c.mutex.Unlock()
+		c.longRunning[operation]--
+		if c.longRunning[operation] <= 0 {
+			delete(c.longRunning, operation)
+		}
+	}
+}
+
+func (c *Controllers) hasLongRunning() bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return len(c.longRunning) > 0
+}
+
+// Register adds the controller without reconcile loops, so it is listed before its first reconcile.
+func (c *Controllers) Register(name string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.get(name)
+}
+
+func (c *Controllers) get(name string) *controller {
+	tracked, ok := c.controllers[name]
+	if !ok {
+		tracked = &controller{status: ControllerStatus{Name: name}, running: map[uint64]time.Time{}}
+		c.controllers[name] = tracked
+	}
+	return tracked
+}
+
+// Status returns the status of all controllers sorted by name.
+func (c *Controllers) Status() []ControllerStatus {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	statuses := make([]ControllerStatus, 0, len(c.controllers))
+	for _, tracked := range c.controllers {
+		status := tracked.status
+		status.Running = len(tracked.running)
+		for _, start := range tracked.running {
+			if status.OldestRunningSince == nil || start.Before(*status.OldestRunningSince) {
+				oldest := start
+				status.OldestRunningSince = &oldest
+			}
+		}
+		statuses = append(statuses, status)
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Name < statuses[j].Name })
+	return statuses
+}
+
+// LivenessChecker returns the check which fails when any reconcile loop runs longer than the timeout,
+// the check passes while a long running operation is in progress.
+func (c *Controllers) LivenessChecker(timeout time.Duration) func(*http.Request) error {
+	return func(*http.Request) error {
+		if c.hasLongRunning() {
+			return nil
+		}
+		now := c.now()
+		var hung []string
+		for _, status := range c.Status() {
+			if status.OldestRunningSince != nil && now.Sub(*status.OldestRunningSince) > timeout {
+				hung = append(hung, fmt.Sprintf("%s (running since %s)", status.Name, status.OldestRunningSince.Format(time.RFC3339)))
+			}
+		}
+		if len(hung) > 0 {
+			return errors.Errorf("reconcile loops of controllers %s are hung", strings.Join(hung, ", "))
+		}
+		return nil
+	}
+}
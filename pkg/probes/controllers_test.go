@@ -0,0 +1,89 @@
+package probes
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestControllers(t *testing.T) {
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	newControllers := func(now *time.Time) *Controllers {
+		controllers := NewControllers()
+		controllers.now = func() time.Time { return *now }
+		return controllers
+	}
+
+	t.Run("registered controller is live", func(t *testing.T) {
+		now := start
+		controllers := newControllers(&now)
+		controllers.Register("jenkins")
+
+		assert.NoError(t, controllers.LivenessChecker(time.Minute)(nil))
+		assert.Equal(t, []ControllerStatus{{Name: "jenkins"}}, controllers.Status())
+	})
+	t.Run("finished reconcile loops are counted", func(t *testing.T) {
+		now := start
+		controllers := newControllers(&now)
+
+		controllers.Track("jenkins")(nil)
+		now = start.Add(time.Second)
+		controllers.Track("jenkins")(errors.New("failed"))
+
+		statuses := controllers.Status()
+		require.Len(t, statuses, 1)
+		assert.Equal(t, uint64(2), statuses[0].Reconciles)
+		assert.Equal(t, uint64(1), statuses[0].Errors)
+		assert.Equal(t, 0, statuses[0].Running)
+		assert.Equal(t, "failed", statuses[0].LastError)
+		assert.Equal(t, now, *statuses[0].LastReconcileTime)
+		assert.Nil(t, statuses[0].OldestRunningSince)
+	})
+	t.Run("running reconcile loop within timeout", func(t *testing.T) {
+		now := start
+		controllers := newControllers(&now)
+		controllers.Track("jenkins")
+		now = start.Add(time.Minute)
+
+		assert.NoError(t, controllers.LivenessChecker(time.Minute)(nil))
+		statuses := controllers.Status()
+		require.Len(t, statuses, 1)
+		assert.Equal(t, 1, statuses[0].Running)
+		assert.Equal(t, start, *statuses[0].OldestRunningSince)
+	})
+	t.Run("hung reconcile loop", func(t *testing.T) {
+		now := start
+		controllers := newControllers(&now)
+		controllers.Track("jenkins")
+		now = start.Add(30 * time.Second)
+		controllers.Track("jenkins-disk-usage")(nil)
+		controllers.Track("jenkins")
+		now = start.Add(2 * time.Minute)
+
+		err := controllers.LivenessChecker(time.Minute)(nil)
+
+		require.Error(t, err)
+		assert.Equal(t, "reconcile loops of controllers jenkins (running since 2021-01-01T00:00:00Z) are hung", err.Error())
+		statuses := controllers.Status()
+		require.Len(t, statuses, 2)
+		assert.Equal(t, "jenkins", statuses[0].Name)
+		assert.Equal(t, 2, statuses[0].Running)
+		assert.Equal(t, "jenkins-disk-usage", statuses[1].Name)
+	})
+	t.Run("hung reconcile loop during long running operation", func(t *testing.T) {
+		now := start
+		controllers := newControllers(&now)
+		controllers.Track("jenkins")
+		done := controllers.LongRunning("backup")
+		now = start.Add(2 * time.Minute)
+
+		assert.NoError(t, controllers.LivenessChecker(time.Minute)(nil))
+
+		done()
+
+		assert.Error(t, controllers.LivenessChecker(time.Minute)(nil))
+	})
+}
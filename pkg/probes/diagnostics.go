@@ -0,0 +1,98 @@
+package probes
+
+import (
+	"encoding/json"
+	"net/http"
+	r "runtime"
+	"sort"
+	"time"
+
+	"github.com/jenkinsci/kubernetes-operator/version"
+)
+
+// DiagnosticsPath is the path of the diagnostics dump served by the operator metrics endpoint
+const DiagnosticsPath = "/debug/diagnostics"
+
+// CheckResult is the result of the health check.
+type CheckResult struct {
+	Name  string `json:"name"`
+	Ready bool   `json:"ready"`
+	Error string `json:"error,omitempty"`
+}
+
+// Report is the diagnostics dump of the operator.
+type Report struct {
+	Version     string             `json:"version"`
+	GitCommit   string             `json:"gitCommit"`
+	GoVersion   string             `json:"goVersion"`
+	Platform    string             `json:"platform"`
+	StartTime   time.Time          `json:"startTime"`
+	Goroutines  int                `json:"goroutines"`
+	Settings    map[string]string  `json:"settings,omitempty"`
+	Checks      []CheckResult      `json:"checks"`
+	Controllers []ControllerStatus `json:"controllers"`
+}
+
+// Diagnostics gathers results of health checks and status of controllers for support.
+type Diagnostics struct {
+	// Checks are the health checks by name
+	Checks map[string]func(*http.Request) error
+	// Controllers tracks reconcile loops of controllers
+	Controllers *Controllers
+	// Settings are the operator settings, e.g. command line flags, they mustn't contain secrets
+	Settings  map[string]string
+	startTime time.Time
+}
+
+// NewDiagnostics creates the diagnostics of the operator started now.
+func NewDiagnostics(controllers *Controllers, settings map[string]string) *Diagnostics {
+	return &Diagnostics{
+		Checks:      map[string]func(*http.Request) error{},
+		Controllers: controllers,
+		Settings:    settings,
+		startTime:   time.Now(),
+	}
+}
+
+// Add adds the health check to the diagnostics and returns it, so it can be registered in the manager too.
+func (d *Diagnostics) Add(name string, check func(*http.Request) error) func(*http.Request) error {
+	d.Checks[name] = check
+	return check
+}
+
+// Report runs all health checks and returns the diagnostics dump.
+func (d *Diagnostics) Report(req *http.Request) Report {
+	report := Report{
+		Version:     version.Version,
+		GitCommit:   version.GitCommit,
+		GoVersion:   r.Version(),
+		Platform:    r.GOOS + "/" + r.GOARCH,
+		StartTime:   d.startTime,
+		Goroutines:  r.NumGoroutine(),
+		Settings:    d.Settings,
+		Checks:      []CheckResult{},
+		Controllers: d.Controllers.Status(),
+	}
+	names := make([]string, 0, len(d.Checks))
+	for name := range d.Checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		result := CheckResult{Name: name, Ready: true}
+		if err := d.Checks[name](req); err != nil {
+			result.Ready = false
+			result.Error = err.Error()
+		}
+		report.Checks = append(report.Checks, result)
+	}
+	return report
+}
+
+// ServeHTTP writes the diagnostics dump as JSON.
+func (d *Diagnostics) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	_ = encoder.Encode(d.Report(req))
+}
@@ -0,0 +1,37 @@
+package probes
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiagnostics(t *testing.T) {
+	controllers := NewControllers()
+	controllers.Register("jenkins")
+	diagnostics := NewDiagnostics(controllers, map[string]string{"watchNamespace": "default"})
+	check := diagnostics.Add("ping", func(*http.Request) error { return nil })
+	diagnostics.Add("api-server", func(*http.Request) error { return errors.New("connection refused") })
+
+	recorder := httptest.NewRecorder()
+	diagnostics.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, DiagnosticsPath, nil))
+
+	assert.NoError(t, check(nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+	report := Report{}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &report))
+	assert.Equal(t, []CheckResult{
+		{Name: "api-server", Ready: false, Error: "connection refused"},
+		{Name: "ping", Ready: true},
+	}, report.Checks)
+	assert.Equal(t, []ControllerStatus{{Name: "jenkins"}}, report.Controllers)
+	assert.Equal(t, map[string]string{"watchNamespace": "default"}, report.Settings)
+	assert.NotEmpty(t, report.GoVersion)
+	assert.NotZero(t, report.Goroutines)
+}
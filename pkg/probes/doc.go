@@ -0,0 +1,2 @@
+// Package probes contains liveness and readiness checks of the operator and the diagnostics dump for support
+package probes
@@ -0,0 +1,142 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/constants"
+	"github.com/jenkinsci/kubernetes-operator/version"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// TracerName is the name of the tracer used by the operator
+	TracerName = "github.com/jenkinsci/kubernetes-operator"
+
+	// JenkinsNameKey is the span attribute with name of Jenkins CR
+	JenkinsNameKey = attribute.Key("jenkins.name")
+	// JenkinsNamespaceKey is the span attribute with namespace of Jenkins CR
+	JenkinsNamespaceKey = attribute.Key("jenkins.namespace")
+)
+
+// Options defines how the spans are exported.
+type Options struct {
+	// Endpoint is the address of OTLP gRPC collector, tracing is disabled if empty
+	Endpoint string
+	// Insecure disables TLS of the connection to the collector
+	Insecure bool
+	// SampleRatio is the ratio of sampled traces, between 0 and 1
+	SampleRatio float64
+}
+
+// Validate validates tracing options.
+func (o Options) Validate() error {
+	if o.SampleRatio < 0 || o.SampleRatio > 1 {
+		return errors.Errorf("tracing sample ratio must be between 0 and 1, got '%v'", o.SampleRatio)
+	}
+	return nil
+}
+
+// Setup configures global tracer provider which exports spans to the OTLP collector.
+// The returned function flushes and stops the exporter, it has to be called before the operator exits.
+func Setup(ctx context.Context, options Options) (func(context.Context) error, error) {
+	if len(options.Endpoint) == 0 {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporterOptions := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(options.Endpoint)}
+	if options.Insecure {
+		exporterOptions = append(exporterOptions, otlptracegrpc.WithInsecure())
+	}
+	exporter, err := otlptracegrpc.New(ctx, exporterOptions...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create OTLP trace exporter")
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(options.SampleRatio))),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL,
+			semconv.ServiceNameKey.String(constants.OperatorName),
+			semconv.ServiceVersionKey.String(version.Version),
+		)),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	return provider.Shutdown, nil
+}
+
+// Start creates a span and a context containing the span, nil context is treated as empty context.
+func Start(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return otel.Tracer(TracerName).Start(ctx, name, trace.WithAttributes(attributes...))
+}
+
+// End records the error in the span if it's not nil and ends the span.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// JenkinsAttributes returns span attributes identifying Jenkins CR.
+func JenkinsAttributes(namespace, name string) []attribute.KeyValue {
+	return []attribute.KeyValue{JenkinsNamespaceKey.String(namespace), JenkinsNameKey.String(name)}
+}
+
+type transport struct {
+	ctx context.Context
+	rt  http.RoundTripper
+}
+
+// NewTransport returns HTTP transport which creates span for every request.
+// Spans are children of the span in the request context or of the span in the ctx if the request context has no span.
+func NewTransport(ctx context.Context, rt http.RoundTripper) http.RoundTripper {
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	return &transport{ctx: ctx, rt: rt}
+}
+
+func (t *transport) RoundTrip(r *http.Request) (*http.Response, error) {
+	ctx := r.Context()
+	if !trace.SpanContextFromContext(ctx).IsValid() && t.ctx != nil {
+		ctx = trace.ContextWithSpan(ctx, trace.SpanFromContext(t.ctx))
+	}
+
+	ctx, span := otel.Tracer(TracerName).Start(ctx, fmt.Sprintf("HTTP %s", r.Method),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.HTTPClientAttributesFromHTTPRequest(r)...),
+	)
+	defer span.End()
+
+	r = r.WithContext(ctx)
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(r.Header))
+
+	response, err := t.rt.RoundTrip(r)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return response, err
+	}
+
+	span.SetAttributes(semconv.HTTPAttributesFromHTTPStatusCode(response.StatusCode)...)
+	span.SetStatus(semconv.SpanStatusFromHTTPStatusCode(response.StatusCode))
+	return response, nil
+}
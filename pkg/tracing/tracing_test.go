@@ -0,0 +1,83 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func setupSpanRecorder() *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	return recorder
+}
+
+func TestEnd(t *testing.T) {
+	recorder := setupSpanRecorder()
+
+	_, span := Start(context.TODO(), "Reconcile", JenkinsAttributes("default", "jenkins")...)
+	End(span, errors.New("reconcile failed"))
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "Reconcile", spans[0].Name())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, "reconcile failed", spans[0].Status().Description)
+	assert.Equal(t, JenkinsAttributes("default", "jenkins"), spans[0].Attributes())
+}
+
+func TestTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NotEmpty(t, r.Header.Get("traceparent"))
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	t.Run("parent from client context", func(t *testing.T) {
+		recorder := setupSpanRecorder()
+		ctx, parent := Start(context.Background(), "Reconcile")
+		client := &http.Client{Transport: NewTransport(ctx, nil)}
+
+		response, err := client.Get(server.URL + "/api/json")
+		require.NoError(t, err)
+		_ = response.Body.Close()
+		parent.End()
+
+		spans := recorder.Ended()
+		require.Len(t, spans, 2)
+		assert.Equal(t, "HTTP GET", spans[0].Name())
+		assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+		assert.Equal(t, codes.Unset, spans[0].Status().Code)
+	})
+	t.Run("error status code", func(t *testing.T) {
+		recorder := setupSpanRecorder()
+		client := &http.Client{Transport: NewTransport(context.Background(), nil)}
+
+		response, err := client.Get(server.URL + "/missing")
+		require.NoError(t, err)
+		_ = response.Body.Close()
+
+		spans := recorder.Ended()
+		require.Len(t, spans, 1)
+		assert.False(t, spans[0].Parent().IsValid())
+		assert.Equal(t, codes.Error, spans[0].Status().Code)
+	})
+}
+
+func TestOptions_Validate(t *testing.T) {
+	assert.NoError(t, Options{SampleRatio: 0.5}.Validate())
+	assert.Error(t, Options{SampleRatio: 1.5}.Validate())
+}
@@ -0,0 +1,3 @@
+// Package validation validates Jenkins CRs from manifests offline, without the API server and Jenkins, so CI pipelines
+// can gate changes of Jenkins CRs
+package validation
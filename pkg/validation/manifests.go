@@ -0,0 +1,114 @@
+package validation
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/jenkinsci/kubernetes-operator/pkg/plugins"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ReadObjects decodes the objects from YAML or JSON manifests, documents can be separated by --- and lists of objects
+// are flattened. The objects of kinds unknown to the scheme are skipped, the objects without namespace are moved to
+// the namespace. stringData of Secrets is merged into data as the API server does.
+func ReadObjects(reader io.Reader, scheme *runtime.Scheme, namespace string) ([]client.Object, error) {
+	decoder := yaml.NewYAMLOrJSONDecoder(reader, 4096)
+	deserializer := serializer.NewCodecFactory(scheme).UniversalDeserializer()
+
+	var objects []client.Object
+	for {
+		raw := runtime.RawExtension{}
+		if err := decoder.Decode(&raw); err == io.EOF {
+			return objects, nil
+		} else if err != nil {
+			return nil, errors.Wrap(err, "failed to decode manifests")
+		}
+		raw.Raw = bytes.TrimSpace(raw.Raw)
+		if len(raw.Raw) == 0 || bytes.Equal(raw.Raw, []byte("null")) {
+			continue
+		}
+		decoded, err := decodeObjects(deserializer, raw.Raw)
+		if err != nil {
+			return nil, err
+		}
+		for _, object := range decoded {
+			if len(object.GetNamespace()) == 0 {
+				object.SetNamespace(namespace)
+			}
+			if secret, ok := object.(*corev1.Secret); ok {
+				mergeStringData(secret)
+			}
+			objects = append(objects, object)
+		}
+	}
+}
+
+// LoadUpdateCenter reads the update center JSON cached in the file or downloads it if the source is http or https URL
+func LoadUpdateCenter(ctx context.Context, source string) (*plugins.UpdateCenter, error) {
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		return plugins.FetchUpdateCenter(ctx, source)
+	}
+	data, err := ioutil.ReadFile(source)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return plugins.ParseUpdateCenter(data)
+}
+
+func decodeObjects(deserializer runtime.Decoder, data []byte) ([]client.Object, error) {
+	decoded, _, err := deserializer.Decode(data, nil, nil)
+	if runtime.IsNotRegisteredError(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "failed to decode object")
+	}
+
+	if meta.IsListType(decoded) {
+		items, err := meta.ExtractList(decoded)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		var objects []client.Object
+		for _, item := range items {
+			var itemObjects []client.Object
+			if unknown, ok := item.(*runtime.Unknown); ok {
+				itemObjects, err = decodeObjects(deserializer, unknown.Raw)
+				if err != nil {
+					return nil, err
+				}
+			} else if object, ok := item.(client.Object); ok {
+				itemObjects = []client.Object{object}
+			}
+			objects = append(objects, itemObjects...)
+		}
+		return objects, nil
+	}
+
+	if object, ok := decoded.(client.Object); ok {
+		return []client.Object{object}, nil
+	}
+	return nil, nil
+}
+
+func mergeStringData(secret *corev1.Secret) {
+	if len(secret.StringData) == 0 {
+		return
+	}
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	for key, value := range secret.StringData {
+		secret.Data[key] = []byte(value)
+	}
+	secret.StringData = nil
+}
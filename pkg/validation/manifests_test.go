@@ -0,0 +1,75 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+)
+
+func newScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(v1alpha2.AddToScheme(scheme))
+	return scheme
+}
+
+func TestReadObjects(t *testing.T) {
+	t.Run("documents and lists", func(t *testing.T) {
+		manifests := `
+apiVersion: jenkins.io/v1alpha2
+kind: Jenkins
+metadata:
+  name: jenkins
+---
+---
+apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: casc
+    namespace: ci
+  data:
+    jenkins.yaml: "jenkins: {}"
+- apiVersion: v1
+  kind: Secret
+  metadata:
+    name: casc-secret
+  stringData:
+    TOKEN: token
+  data:
+    USER: dXNlcg==
+---
+apiVersion: example.com/v1
+kind: Unknown
+metadata:
+  name: skipped
+`
+
+		objects, err := ReadObjects(strings.NewReader(manifests), newScheme(), "default")
+
+		require.NoError(t, err)
+		require.Len(t, objects, 3)
+		assert.IsType(t, &v1alpha2.Jenkins{}, objects[0])
+		assert.Equal(t, "default", objects[0].GetNamespace())
+		assert.Equal(t, "ci", objects[1].GetNamespace())
+		secret, ok := objects[2].(*corev1.Secret)
+		require.True(t, ok)
+		assert.Equal(t, map[string][]byte{"TOKEN": []byte("token"), "USER": []byte("user")}, secret.Data)
+		assert.Empty(t, secret.StringData)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, err := ReadObjects(strings.NewReader("kind: [Jenkins"), newScheme(), "default")
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,43 @@
+package validation
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// OutputJSON writes the report as JSON document
+	OutputJSON = "json"
+	// OutputText writes the report as lines readable by humans
+	OutputText = "text"
+)
+
+// WriteReport writes the report in the output format, json or text
+func WriteReport(writer io.Writer, report Report, output string) error {
+	switch output {
+	case OutputJSON:
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", "  ")
+		return errors.WithStack(encoder.Encode(report))
+	case OutputText:
+		for _, result := range report.Results {
+			status := "valid"
+			if !result.Valid {
+				status = "invalid"
+			}
+			if _, err := fmt.Fprintf(writer, "Jenkins %s/%s: %s\n", result.Namespace, result.Name, status); err != nil {
+				return errors.WithStack(err)
+			}
+			for _, finding := range result.Findings {
+				if _, err := fmt.Fprintf(writer, "  %s [%s] %s\n", finding.Severity, finding.Check, finding.Message); err != nil {
+					return errors.WithStack(err)
+				}
+			}
+		}
+		return nil
+	}
+	return errors.Errorf("unknown output format '%s', must be one of json or text", output)
+}
@@ -0,0 +1,359 @@
+package validation
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/base/resources"
+	"github.com/jenkinsci/kubernetes-operator/pkg/configuration/user/casc"
+	"github.com/jenkinsci/kubernetes-operator/pkg/plugins"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Check is the kind of validation which has reported the finding
+type Check string
+
+const (
+	// CheckSpec is the validation of base and user configuration done by the reconcile loop
+	CheckSpec Check = "spec"
+	// CheckPlugins is the verification of plugins against the update center
+	CheckPlugins Check = "plugins"
+	// CheckConfigurationAsCode is the validation of Configuration as Code files
+	CheckConfigurationAsCode Check = "casc"
+	// CheckReferences is the validation of Secrets and ConfigMaps referenced by environment variables and variables
+	// of Configuration as Code files
+	CheckReferences Check = "references"
+)
+
+// Severity tells if the finding makes Jenkins CR invalid
+type Severity string
+
+const (
+	// SeverityError makes Jenkins CR invalid
+	SeverityError Severity = "error"
+	// SeverityWarning is reported only, Jenkins CR stays valid
+	SeverityWarning Severity = "warning"
+)
+
+// Finding is the problem found in Jenkins CR
+type Finding struct {
+	Check    Check    `json:"check"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+}
+
+// Result is the result of the validation of single Jenkins CR
+type Result struct {
+	Namespace string    `json:"namespace"`
+	Name      string    `json:"name"`
+	Valid     bool      `json:"valid"`
+	Findings  []Finding `json:"findings,omitempty"`
+}
+
+// Report is the result of the validation of all Jenkins CRs, it's valid if all Jenkins CRs are valid
+type Report struct {
+	Valid   bool     `json:"valid"`
+	Results []Result `json:"results"`
+}
+
+// SpecValidator validates Jenkins CR the same way as the reconcile loop does, Jenkins templates and defaults are
+// applied to the CR before
+type SpecValidator func(ctx context.Context, jenkins *v1alpha2.Jenkins) (baseMessages, userMessages []string, err error)
+
+// Validator validates Jenkins CRs served by the client, usually the fake client with the objects read from manifests
+type Validator struct {
+	client       client.Client
+	validateSpec SpecValidator
+	updateCenter *plugins.UpdateCenter
+}
+
+// New creates Validator, plugins aren't verified if the update center is nil
+func New(k8sClient client.Client, validateSpec SpecValidator, updateCenter *plugins.UpdateCenter) *Validator {
+	return &Validator{
+		client:       k8sClient,
+		validateSpec: validateSpec,
+		updateCenter: updateCenter,
+	}
+}
+
+// Validate validates all Jenkins CRs, the results are sorted by namespace and name
+func (v *Validator) Validate(ctx context.Context) (Report, error) {
+	jenkinses := &v1alpha2.JenkinsList{}
+	if err := v.client.List(ctx, jenkinses); err != nil {
+		return Report{}, errors.WithStack(err)
+	}
+	sort.Slice(jenkinses.Items, func(i, j int) bool {
+		a, b := jenkinses.Items[i], jenkinses.Items[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+
+	report := Report{Valid: true, Results: []Result{}}
+	for i := range jenkinses.Items {
+		result, err := v.validateJenkins(ctx, &jenkinses.Items[i])
+		if err != nil {
+			return Report{}, errors.Wrapf(err, "failed to validate Jenkins CR '%s/%s'", jenkinses.Items[i].Namespace, jenkinses.Items[i].Name)
+		}
+		report.Valid = report.Valid && result.Valid
+		report.Results = append(report.Results, result)
+	}
+	return report, nil
+}
+
+func (v *Validator) validateJenkins(ctx context.Context, jenkins *v1alpha2.Jenkins) (Result, error) {
+	result := Result{Namespace: jenkins.Namespace, Name: jenkins.Name}
+	add := func(check Check, severity Severity, messages ...string) {
+		for _, message := range messages {
+			result.Findings = append(result.Findings, Finding{Check: check, Severity: severity, Message: message})
+		}
+	}
+
+	baseMessages, userMessages, err := v.validateSpec(ctx, jenkins)
+	if err != nil {
+		return Result{}, err
+	}
+	add(CheckSpec, SeverityError, baseMessages...)
+	add(CheckSpec, SeverityError, userMessages...)
+
+	if v.updateCenter != nil {
+		verified := v.pluginsFromUpdateCenter(jenkins)
+		add(CheckPlugins, SeverityError, v.updateCenter.Verify(verified)...)
+		for _, plugin := range verified {
+			if v.updateCenter.IsAffectedBySecurityWarning(plugin.Name, plugin.Version) {
+				add(CheckPlugins, SeverityWarning, fmt.Sprintf("Plugin '%s' is affected by the security warnings of the update center", plugin))
+			}
+		}
+	}
+
+	variables, messages, err := v.validateConfigurationAsCode(ctx, jenkins)
+	if err != nil {
+		return Result{}, err
+	}
+	add(CheckConfigurationAsCode, SeverityError, messages...)
+
+	warnings, err := v.validateVariables(ctx, jenkins, variables)
+	if err != nil {
+		return Result{}, err
+	}
+	add(CheckReferences, SeverityWarning, warnings...)
+
+	messages, err = v.validateEnvReferences(ctx, jenkins)
+	if err != nil {
+		return Result{}, err
+	}
+	add(CheckReferences, SeverityError, messages...)
+
+	result.Valid = true
+	for _, finding := range result.Findings {
+		if finding.Severity == SeverityError {
+			result.Valid = false
+		}
+	}
+	return result, nil
+}
+
+// pluginsFromUpdateCenter returns the plugins downloaded from the default update center
+func (v *Validator) pluginsFromUpdateCenter(jenkins *v1alpha2.Jenkins) []plugins.Plugin {
+	var verified []plugins.Plugin
+	for _, requiredPlugins := range [][]v1alpha2.Plugin{jenkins.Spec.Master.BasePlugins, jenkins.Spec.Master.Plugins} {
+		for _, plugin := range requiredPlugins {
+			if len(plugin.UpdateSite) > 0 {
+				continue
+			}
+			verified = append(verified, plugins.Plugin{Name: plugin.Name, Version: plugin.Version, DownloadURL: plugin.DownloadURL})
+		}
+	}
+	return verified
+}
+
+// validateConfigurationAsCode validates Configuration as Code files and returns the variables used by them, missing
+// ConfigMaps are reported by the validation of spec
+func (v *Validator) validateConfigurationAsCode(ctx context.Context, jenkins *v1alpha2.Jenkins) ([]string, []string, error) {
+	var variables, messages []string
+	for _, configMapRef := range jenkins.Spec.ConfigurationAsCode.Configurations {
+		if len(configMapRef.Name) == 0 {
+			continue
+		}
+		configMap := &corev1.ConfigMap{}
+		err := v.client.Get(ctx, types.NamespacedName{Namespace: jenkins.Namespace, Name: configMapRef.Name}, configMap)
+		if apierrors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, nil, errors.WithStack(err)
+		}
+		messages = append(messages, casc.Validate(configMap.Name, configMap.Data)...)
+		variables = append(variables, casc.Variables(configMap.Data)...)
+	}
+	return variables, messages, nil
+}
+
+// validateVariables returns warnings about variables of Configuration as Code files which aren't keys of
+// spec.configurationAsCode.secret or environment variables of Jenkins container, they may still be set by the image
+func (v *Validator) validateVariables(ctx context.Context, jenkins *v1alpha2.Jenkins, variables []string) ([]string, error) {
+	if len(variables) == 0 {
+		return nil, nil
+	}
+
+	defined := map[string]bool{}
+	secretName := jenkins.Spec.ConfigurationAsCode.Secret.Name
+	if len(secretName) > 0 {
+		secret := &corev1.Secret{}
+		err := v.client.Get(ctx, types.NamespacedName{Namespace: jenkins.Namespace, Name: secretName}, secret)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return nil, errors.WithStack(err)
+		}
+		for key := range secret.Data {
+			defined[key] = true
+		}
+	}
+	if len(jenkins.Spec.Master.Containers) == 0 {
+		return nil, nil
+	}
+	container := jenkins.Spec.Master.Containers[0]
+	for _, env := range append(resources.GetJenkinsMasterContainerBaseEnvs(jenkins), container.Env...) {
+		defined[env.Name] = true
+	}
+	for _, envFrom := range container.EnvFrom {
+		keys, err := v.envFromKeys(ctx, jenkins.Namespace, envFrom)
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range keys {
+			defined[envFrom.Prefix+key] = true
+		}
+	}
+
+	var messages []string
+	seen := map[string]bool{}
+	for _, variable := range variables {
+		if defined[variable] || seen[variable] {
+			continue
+		}
+		seen[variable] = true
+		if len(secretName) > 0 {
+			messages = append(messages, fmt.Sprintf("Variable '${%s}' used in spec.configurationAsCode.configurations isn't a key of Secret '%s' configured in spec.configurationAsCode.secret.name or environment variable of Jenkins container", variable, secretName))
+		} else {
+			messages = append(messages, fmt.Sprintf("Variable '${%s}' used in spec.configurationAsCode.configurations isn't environment variable of Jenkins container and spec.configurationAsCode.secret.name isn't set", variable))
+		}
+	}
+	return messages, nil
+}
+
+// validateEnvReferences returns messages about Secrets, ConfigMaps and their keys referenced by environment variables
+// of containers which don't exist, the optional ones are skipped
+func (v *Validator) validateEnvReferences(ctx context.Context, jenkins *v1alpha2.Jenkins) ([]string, error) {
+	var messages []string
+	for _, container := range jenkins.Spec.Master.Containers {
+		for _, env := range container.Env {
+			if env.ValueFrom == nil {
+				continue
+			}
+			var message string
+			var err error
+			field := fmt.Sprintf("spec.master.containers[%s].env[%s]", container.Name, env.Name)
+			if selector := env.ValueFrom.SecretKeyRef; selector != nil && !isOptional(selector.Optional) {
+				message, err = v.validateKeyReference(ctx, &corev1.Secret{}, jenkins.Namespace, selector.Name, selector.Key, field)
+			} else if selector := env.ValueFrom.ConfigMapKeyRef; selector != nil && !isOptional(selector.Optional) {
+				message, err = v.validateKeyReference(ctx, &corev1.ConfigMap{}, jenkins.Namespace, selector.Name, selector.Key, field)
+			}
+			if err != nil {
+				return nil, err
+			}
+			if len(message) > 0 {
+				messages = append(messages, message)
+			}
+		}
+		for index, envFrom := range container.EnvFrom {
+			var message string
+			var err error
+			field := fmt.Sprintf("spec.master.containers[%s].envFrom[%d]", container.Name, index)
+			if ref := envFrom.SecretRef; ref != nil && !isOptional(ref.Optional) {
+				message, err = v.validateKeyReference(ctx, &corev1.Secret{}, jenkins.Namespace, ref.Name, "", field)
+			} else if ref := envFrom.ConfigMapRef; ref != nil && !isOptional(ref.Optional) {
+				message, err = v.validateKeyReference(ctx, &corev1.ConfigMap{}, jenkins.Namespace, ref.Name, "", field)
+			}
+			if err != nil {
+				return nil, err
+			}
+			if len(message) > 0 {
+				messages = append(messages, message)
+			}
+		}
+	}
+	return messages, nil
+}
+
+// validateKeyReference returns the message if the Secret or ConfigMap doesn't exist or doesn't have the key, the key
+// isn't checked if it's empty
+func (v *Validator) validateKeyReference(ctx context.Context, object client.Object, namespace, name, key, field string) (string, error) {
+	kind := "Secret"
+	if _, ok := object.(*corev1.ConfigMap); ok {
+		kind = "ConfigMap"
+	}
+	err := v.client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, object)
+	if apierrors.IsNotFound(err) {
+		return fmt.Sprintf("%s '%s' referenced by %s not found", kind, name, field), nil
+	} else if err != nil {
+		return "", errors.WithStack(err)
+	}
+	if len(key) == 0 {
+		return "", nil
+	}
+	for _, existing := range objectKeys(object) {
+		if existing == key {
+			return "", nil
+		}
+	}
+	return fmt.Sprintf("%s '%s' referenced by %s doesn't have key '%s'", kind, name, field, key), nil
+}
+
+func (v *Validator) envFromKeys(ctx context.Context, namespace string, envFrom corev1.EnvFromSource) ([]string, error) {
+	var object client.Object
+	var name string
+	switch {
+	case envFrom.SecretRef != nil:
+		object, name = &corev1.Secret{}, envFrom.SecretRef.Name
+	case envFrom.ConfigMapRef != nil:
+		object, name = &corev1.ConfigMap{}, envFrom.ConfigMapRef.Name
+	default:
+		return nil, nil
+	}
+	err := v.client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, object)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return objectKeys(object), nil
+}
+
+func objectKeys(object client.Object) []string {
+	var keys []string
+	switch typed := object.(type) {
+	case *corev1.Secret:
+		for key := range typed.Data {
+			keys = append(keys, key)
+		}
+	case *corev1.ConfigMap:
+		for key := range typed.Data {
+			keys = append(keys, key)
+		}
+		for key := range typed.BinaryData {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+func isOptional(optional *bool) bool {
+	return optional != nil && *optional
+}
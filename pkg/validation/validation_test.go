@@ -0,0 +1,132 @@
+package validation
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/jenkinsci/kubernetes-operator/api/v1alpha2"
+	"github.com/jenkinsci/kubernetes-operator/pkg/plugins"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestValidator_Validate(t *testing.T) {
+	optional := true
+	jenkins := &v1alpha2.Jenkins{
+		ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: "default"},
+		Spec: v1alpha2.JenkinsSpec{
+			Master: v1alpha2.JenkinsMaster{
+				Containers: []v1alpha2.Container{{
+					Name: "jenkins-master",
+					Env: []corev1.EnvVar{
+						{Name: "URL", Value: "https://jenkins.example.com"},
+						{Name: "TOKEN", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "tokens"}, Key: "github"}}},
+						{Name: "MISSING", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "tokens"}, Key: "gitlab"}}},
+						{Name: "OPTIONAL", ValueFrom: &corev1.EnvVarSource{ConfigMapKeyRef: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "missing"}, Key: "key", Optional: &optional}}},
+					},
+					EnvFrom: []corev1.EnvFromSource{{ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "settings"}}, Prefix: "SETTINGS_"}},
+				}},
+				BasePlugins: []v1alpha2.Plugin{{Name: "configuration-as-code", Version: "1.55"}},
+				Plugins: []v1alpha2.Plugin{
+					{Name: "git", Version: "4.10.0"},
+					{Name: "custom", Version: "1.0", UpdateSite: "internal"},
+				},
+			},
+			ConfigurationAsCode: v1alpha2.ConfigurationAsCode{Customization: v1alpha2.Customization{
+				Secret:         v1alpha2.SecretRef{Name: "casc-secret"},
+				Configurations: []v1alpha2.ConfigMapRef{{Name: "casc"}, {Name: "missing"}},
+			}},
+		},
+	}
+	objects := []client.Object{
+		jenkins,
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "tokens", Namespace: "default"}, Data: map[string][]byte{"github": []byte("token")}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "casc-secret", Namespace: "default"}, Data: map[string][]byte{"ADMIN_PASSWORD": []byte("password")}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "settings", Namespace: "default"}, Data: map[string]string{"THEME": "dark"}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "casc", Namespace: "default"}, Data: map[string]string{
+			"1-jenkins.yaml": "jenkins:\n  systemMessage: ${URL} ${SETTINGS_THEME} ${ADMIN_PASSWORD} ${UNDEFINED}\n",
+			"2-broken.yaml":  "jenkins: [",
+		}},
+	}
+	k8sClient := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(objects...).Build()
+	updateCenter := &plugins.UpdateCenter{
+		Plugins: map[string]plugins.UpdateCenterPlugin{
+			"configuration-as-code": {Name: "configuration-as-code", Version: "1.55"},
+			"git":                   {Name: "git", Version: "4.10.2"},
+		},
+		Warnings: []plugins.UpdateCenterWarning{{Type: "plugin", Name: "git", Versions: []plugins.UpdateCenterWarningVersion{{Pattern: "4[.]10[.]0"}}}},
+	}
+	validateSpec := func(_ context.Context, jenkins *v1alpha2.Jenkins) ([]string, []string, error) {
+		return []string{"base message"}, []string{"user message"}, nil
+	}
+
+	report, err := New(k8sClient, validateSpec, updateCenter).Validate(context.TODO())
+
+	require.NoError(t, err)
+	assert.False(t, report.Valid)
+	require.Len(t, report.Results, 1)
+	result := report.Results[0]
+	assert.Equal(t, "default", result.Namespace)
+	assert.Equal(t, "jenkins", result.Name)
+	assert.False(t, result.Valid)
+	require.Len(t, result.Findings, 6)
+	assert.Equal(t, Finding{Check: CheckSpec, Severity: SeverityError, Message: "base message"}, result.Findings[0])
+	assert.Equal(t, Finding{Check: CheckSpec, Severity: SeverityError, Message: "user message"}, result.Findings[1])
+	assert.Equal(t, Finding{Check: CheckPlugins, Severity: SeverityWarning, Message: "Plugin 'git:4.10.0' is affected by the security warnings of the update center"}, result.Findings[2])
+	assert.Equal(t, CheckConfigurationAsCode, result.Findings[3].Check)
+	assert.Contains(t, result.Findings[3].Message, "ConfigMap 'casc' file '2-broken.yaml' isn't valid YAML")
+	assert.Equal(t, Finding{Check: CheckReferences, Severity: SeverityWarning,
+		Message: "Variable '${UNDEFINED}' used in spec.configurationAsCode.configurations isn't a key of Secret 'casc-secret' configured in spec.configurationAsCode.secret.name or environment variable of Jenkins container"}, result.Findings[4])
+	assert.Equal(t, Finding{Check: CheckReferences, Severity: SeverityError,
+		Message: "Secret 'tokens' referenced by spec.master.containers[jenkins-master].env[MISSING] doesn't have key 'gitlab'"}, result.Findings[5])
+}
+
+func TestValidator_Validate_Valid(t *testing.T) {
+	objects := []client.Object{
+		&v1alpha2.Jenkins{ObjectMeta: metav1.ObjectMeta{Name: "b", Namespace: "ci"}},
+		&v1alpha2.Jenkins{ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "ci"}},
+	}
+	k8sClient := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(objects...).Build()
+	validateSpec := func(_ context.Context, jenkins *v1alpha2.Jenkins) ([]string, []string, error) {
+		return nil, nil, nil
+	}
+
+	report, err := New(k8sClient, validateSpec, nil).Validate(context.TODO())
+
+	require.NoError(t, err)
+	assert.Equal(t, Report{Valid: true, Results: []Result{
+		{Namespace: "ci", Name: "a", Valid: true},
+		{Namespace: "ci", Name: "b", Valid: true},
+	}}, report)
+}
+
+func TestWriteReport(t *testing.T) {
+	report := Report{Results: []Result{{Namespace: "ci", Name: "jenkins", Findings: []Finding{
+		{Check: CheckSpec, Severity: SeverityError, Message: "invalid"},
+	}}}}
+
+	t.Run("json", func(t *testing.T) {
+		buffer := &bytes.Buffer{}
+
+		require.NoError(t, WriteReport(buffer, report, OutputJSON))
+
+		assert.JSONEq(t, `{"valid": false, "results": [{"namespace": "ci", "name": "jenkins", "valid": false,
+			"findings": [{"check": "spec", "severity": "error", "message": "invalid"}]}]}`, buffer.String())
+	})
+	t.Run("text", func(t *testing.T) {
+		buffer := &bytes.Buffer{}
+
+		require.NoError(t, WriteReport(buffer, report, OutputText))
+
+		assert.Equal(t, "Jenkins ci/jenkins: invalid\n  error [spec] invalid\n", buffer.String())
+	})
+	t.Run("unknown output", func(t *testing.T) {
+		assert.Error(t, WriteReport(&bytes.Buffer{}, report, "yaml"))
+	})
+}
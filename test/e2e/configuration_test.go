@@ -179,7 +179,7 @@ func verifyPlugins(jenkinsClient jenkinsclient.Jenkins, jenkins *v1alpha2.Jenkin
 	installedPlugins, err := jenkinsClient.GetPlugins(1)
 	Expect(err).NotTo(HaveOccurred())
 
-	for _, basePlugin := range plugins.BasePlugins() {
+	for _, basePlugin := range plugins.DefaultBasePluginManifests().ForImage(jenkins.Spec.Master.Containers[0].Image).Plugins {
 		if found, ok := isPluginValid(installedPlugins, basePlugin); !ok {
 			Fail(fmt.Sprintf("Invalid plugin '%s', actual '%+v'", basePlugin, found))
 		}
@@ -71,7 +71,7 @@ var _ = BeforeSuite(func(done Done) {
 	Expect(err).NotTo(HaveOccurred())
 
 	// setup events
-	events, err := event.New(Cfg, constants.OperatorName)
+	events, err := event.New(Cfg, scheme.Scheme, constants.OperatorName)
 	Expect(err).NotTo(HaveOccurred())
 	notificationEvents := make(chan e.Event)
 	go notifications.Listen(notificationEvents, events, K8sClient)
@@ -89,6 +89,7 @@ var _ = BeforeSuite(func(done Done) {
 		ClientSet:                    *clientSet,
 		Config:                       *Cfg,
 		NotificationEvents:           &notificationEvents,
+		Events:                       events,
 		KubernetesClusterDomain:      "cluster.local",
 	}).SetupWithManager(k8sManager)
 	Expect(err).NotTo(HaveOccurred())